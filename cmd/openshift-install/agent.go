@@ -1,12 +1,15 @@
 package main
 
 import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/cmd/openshift-install/agent"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/agent/image"
+	"github.com/openshift/installer/pkg/asset/agent/interactive"
 	"github.com/openshift/installer/pkg/asset/agent/manifests"
 	"github.com/openshift/installer/pkg/asset/agent/mirror"
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
@@ -69,13 +72,16 @@ var (
 		},
 	}
 
-	//nolint:varcheck,deadcode
 	agentPXEFilesTarget = target{
 		name: "Agent PXE Files",
 		command: &cobra.Command{
 			Use:   "pxe-files",
 			Short: "Generates PXE bootable image files containing all the information needed to deploy a cluster",
-			Args:  cobra.ExactArgs(0),
+			Long: `Generates the kernel, initrd and rootfs extracted from the agent ISO, with the
+ignition of the ISO appended to the initrd, and an iPXE script booting them, in
+the pxe directory. The files are served from the bootArtifactsBaseURL of the
+agent config, or from the same location as the iPXE script if it is not set.`,
+			Args: cobra.ExactArgs(0),
 		},
 		assets: []asset.WritableAsset{
 			&image.AgentPXEFiles{},
@@ -84,7 +90,11 @@ var (
 		},
 	}
 
-	agentTargets = []target{agentConfigTarget, agentManifestsTarget, agentImageTarget}
+	agentImageOpts struct {
+		interactive bool
+	}
+
+	agentTargets = []target{agentConfigTarget, agentManifestsTarget, agentImageTarget, agentPXEFilesTarget}
 )
 
 func newAgentCreateCmd() *cobra.Command {
@@ -103,5 +113,21 @@ func newAgentCreateCmd() *cobra.Command {
 		cmd.AddCommand(t.command)
 	}
 
+	addParallelismFlag(cmd)
+
+	imageRun := agentImageTarget.command.Run
+	agentImageTarget.command.Run = func(cmd *cobra.Command, args []string) {
+		if agentImageOpts.interactive {
+			if rootOpts.nonInteractive {
+				logrus.Fatal("--interactive cannot be used with --non-interactive")
+			}
+			if err := interactive.Survey(rootOpts.dir); err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to survey the cluster configuration"))
+			}
+		}
+		imageRun(cmd, args)
+	}
+	agentImageTarget.command.Flags().BoolVar(&agentImageOpts.interactive, "interactive", false, "prompt for the install and agent configuration which is missing from the asset directory")
+
 	return cmd
 }
@@ -46,8 +46,12 @@ func handleBootstrapError(cluster *agentpkg.Cluster, err error) {
 	logrus.Exit(exitCodeBootstrapFailed)
 }
 
+// rebootlessProgressUsage describes the --rebootless-progress flag.
+const rebootlessProgressUsage = "report the progress of each host (discovered, validated, installing, rebooted, joined), following the hosts through the cluster nodes once the rendezvous host rebooted; use --progress-format=json for one event per host change"
+
 func newWaitForBootstrapCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var rebootlessProgress bool
+	cmd := &cobra.Command{
 		Use:   "bootstrap-complete",
 		Short: "Wait until the cluster bootstrap is complete",
 		Args:  cobra.ExactArgs(0),
@@ -63,16 +67,22 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			if err != nil {
 				logrus.Exit(exitCodeBootstrapFailed)
 			}
+			if rebootlessProgress {
+				cluster.EnableHostProgress()
+			}
 
 			if err := agentpkg.WaitForBootstrapComplete(cluster); err != nil {
 				handleBootstrapError(cluster, err)
 			}
 		},
 	}
+	cmd.Flags().BoolVar(&rebootlessProgress, "rebootless-progress", false, rebootlessProgressUsage)
+	return cmd
 }
 
 func newWaitForInstallCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var rebootlessProgress bool
+	cmd := &cobra.Command{
 		Use:   "install-complete",
 		Short: "Wait until the cluster installation is complete",
 		Args:  cobra.ExactArgs(0),
@@ -88,6 +98,9 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			if err != nil {
 				logrus.Exit(exitCodeBootstrapFailed)
 			}
+			if rebootlessProgress {
+				cluster.EnableHostProgress()
+			}
 
 			if err := agentpkg.WaitForBootstrapComplete(cluster); err != nil {
 				handleBootstrapError(cluster, err)
@@ -107,4 +120,6 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			cluster.PrintInstallationComplete()
 		},
 	}
+	cmd.Flags().BoolVar(&rebootlessProgress, "rebootless-progress", false, rebootlessProgressUsage)
+	return cmd
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -8,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/gather/service"
+	"github.com/openshift/installer/pkg/gather/signature"
 )
 
 var (
@@ -22,21 +24,33 @@ func newAnalyzeCmd() *cobra.Command {
 		Short: "Analyze debugging data for a given installation failure",
 		Long: `Analyze debugging data for a given installation failure.
 
-This command helps users to analyze the reasons for an installation that failed while bootstrapping.`,
+This command helps users to analyze the reasons for an installation that failed.
+The bootstrap gather bundle, if any, and the .openshift_install.log of the assets
+directory are searched for the messages of known causes of failures, such as
+denied cloud permissions, exhausted quotas, certificate errors, image pull
+failures and DNS failures, and the most likely root cause is reported with the
+steps to fix it.`,
 		Args: cobra.ExactArgs(0),
 		Run: func(_ *cobra.Command, _ []string) {
 			gatherBundle := analyzeOpts.gatherBundle
 			if gatherBundle == "" {
 				var err error
 				gatherBundle, err = getGatherBundleFromAssetsDirectory()
-				if err != nil {
+				if errors.Is(err, errNoGatherBundle) {
+					logrus.Info("No bootstrap gather bundle found in the assets directory, analyzing the install log only")
+				} else if err != nil {
 					logrus.Fatal(err)
 				}
 			}
-			if !filepath.IsAbs(gatherBundle) {
-				gatherBundle = filepath.Join(rootOpts.dir, gatherBundle)
+			if gatherBundle != "" {
+				if !filepath.IsAbs(gatherBundle) {
+					gatherBundle = filepath.Join(rootOpts.dir, gatherBundle)
+				}
+				if err := service.AnalyzeGatherBundle(gatherBundle); err != nil {
+					logrus.Fatal(err)
+				}
 			}
-			if err := service.AnalyzeGatherBundle(gatherBundle); err != nil {
+			if err := logRootCause(gatherBundle); err != nil {
 				logrus.Fatal(err)
 			}
 		},
@@ -45,6 +59,9 @@ This command helps users to analyze the reasons for an installation that failed
 	return cmd
 }
 
+// errNoGatherBundle is returned when the assets directory has no gather bundle.
+var errNoGatherBundle = errors.New("no bootstrap gather bundles found in assets directory")
+
 func getGatherBundleFromAssetsDirectory() (string, error) {
 	matches, err := filepath.Glob(filepath.Join(rootOpts.dir, "log-bundle-*.tar.gz"))
 	if err != nil {
@@ -52,10 +69,41 @@ func getGatherBundleFromAssetsDirectory() (string, error) {
 	}
 	switch len(matches) {
 	case 0:
-		return "", errors.New("no bootstrap gather bundles found in assets directory")
+		return "", errNoGatherBundle
 	case 1:
 		return matches[0], nil
 	default:
 		return "", errors.New("multiple bootstrap gather bundles found in assets directory; select specific gather bundle by using the --file flag")
 	}
 }
+
+// logRootCause logs the most likely root cause of the failed install found in
+// the gather bundle, if any, and in the install log, and how to fix it.
+func logRootCause(gatherBundle string) error {
+	matcher := signature.NewMatcher(signature.Signatures)
+	if gatherBundle != "" {
+		if err := matcher.ScanGatherBundle(gatherBundle); err != nil {
+			return err
+		}
+	}
+	if err := matcher.ScanFile(filepath.Join(rootOpts.dir, ".openshift_install.log")); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not analyze the install log")
+	}
+
+	matches := matcher.Matches()
+	if len(matches) == 0 {
+		logrus.Info("No known cause of installation failures was found in the logs")
+		return nil
+	}
+	cause := matches[0]
+	logrus.Errorf("Most likely root cause: %s", cause.Signature.Cause)
+	logrus.Infof("Found in %d log lines, e.g.:", cause.Count)
+	for _, evidence := range cause.Evidence {
+		logrus.Infof("  %s", evidence)
+	}
+	logrus.Infof("Remediation: %s", cause.Signature.Remediation)
+	for _, other := range matches[1:] {
+		logrus.Warnf("Other possible cause, found in %d log lines: %s", other.Count, other.Signature.Cause)
+	}
+	return nil
+}
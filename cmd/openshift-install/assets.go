@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/encryption"
+)
+
+func newAssetsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assets",
+		Short: "Inspect the assets in the asset directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newAssetsRollbackCmd())
+	cmd.AddCommand(newAssetsDecryptCmd())
+	return cmd
+}
+
+func newAssetsRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback",
+		Short: "Restores the asset directory to its state before the last create command",
+		Long: `Restores the asset directory to its state before the last create command which
+wrote it: the files it generated are removed, and the files it replaced or
+consumed, such as the install-config.yaml, are restored along with the state
+file. Only the last create command can be rolled back, once.
+
+A create cluster command which provisioned the cluster cannot be rolled back,
+and the cluster metadata and the Terraform state are never rolled back, so
+that the cluster can still be destroyed. The resources of a cluster are not
+destroyed.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := asset.Rollback(rootOpts.dir); err != nil {
+				return err
+			}
+			logrus.Infof("Restored the asset directory %s", rootOpts.dir)
+			return nil
+		},
+	}
+}
+
+func newAssetsDecryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt FILE",
+		Short: "Prints the plain content of a file of the asset directory",
+		Long: `Prints the plain content of a file of the asset directory, decrypting it with
+the passphrase of the asset directory if it is encrypted, e.g. the kubeconfig
+and the kubeadmin password of the auth directory. FILE is relative to the
+asset directory.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := encryption.ReadFile(filepath.Join(rootOpts.dir, args[0]))
+			if err != nil {
+				return err
+			}
+			_, err = os.Stdout.Write(data)
+			return err
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	"github.com/openshift/installer/pkg/gather/ssh"
+)
+
+var sshBastionOpts struct {
+	bastion string
+}
+
+// addSSHBastionFlag adds the --ssh-bastion flag to the command and its
+// subcommands.
+func addSSHBastionFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&sshBastionOpts.bastion, "ssh-bastion", "", "user@host, with an optional port, of an SSH bastion to tunnel the connections to the cluster through, e.g. when the cluster is published internally; the host key of the bastion must be in ~/.ssh/known_hosts")
+}
+
+// sshBastion returns the bastion of --ssh-bastion, or nil without it.
+func sshBastion() (*ssh.Bastion, error) {
+	if sshBastionOpts.bastion == "" {
+		return nil, nil
+	}
+	return ssh.ParseBastion(sshBastionOpts.bastion)
+}
+
+// loadKubeconfig loads the admin kubeconfig of the directory. With
+// --ssh-bastion, the connections of the clients to the Kubernetes API are
+// tunneled through the bastion, logged into with the SSH keys of the user's
+// environment, until the returned function closes the tunnel.
+func loadKubeconfig(directory string) (*rest.Config, func(), error) {
+	config, err := kubeconfig.RESTConfigFromFile(filepath.Join(directory, "auth", "kubeconfig"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "loading kubeconfig")
+	}
+
+	bastion, err := sshBastion()
+	if err != nil {
+		return nil, nil, err
+	}
+	if bastion == nil {
+		return config, func() {}, nil
+	}
+	logrus.Infof("Connecting to the Kubernetes API through the SSH bastion %s", bastion)
+	tunnel := ssh.NewTunnel(bastion, nil)
+	config.Dial = tunnel.DialContext
+	return config, func() {
+		if err := tunnel.Close(); err != nil {
+			logrus.Debugf("Failed to close the connection to the SSH bastion: %v", err)
+		}
+	}, nil
+}
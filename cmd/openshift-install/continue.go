@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+)
+
+func newContinueCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "continue",
+		Short: "Continue the creation of a cluster paused before the bootstrap",
+		Long: `Continue the creation of a cluster paused before the bootstrap.
+
+When create cluster is run with --pause-before-bootstrap, it stops once the
+infrastructure is provisioned, before the bootstrap resources are created, and
+writes a summary of the created resources to cluster-pause-summary.json in the
+assets directory. Once the resources are reviewed, e.g. in a change-management
+window, this command creates the bootstrap resources and waits for the
+installation to complete, as create cluster does. Running create cluster again
+with --resume and without --pause-before-bootstrap also continues the creation.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			summaryPath := filepath.Join(rootOpts.dir, cluster.PauseSummaryFileName)
+			if _, err := os.Stat(summaryPath); err != nil {
+				if os.IsNotExist(err) {
+					logrus.Fatalf("No creation of a cluster is paused in %s: %s does not exist", rootOpts.dir, summaryPath)
+				}
+				logrus.Fatal(errors.Wrap(err, "failed to read the pause summary"))
+			}
+
+			cluster.PauseBeforeBootstrap = false
+			cluster.ResumeProvisioning = true
+			clusterTarget.command.Run(cmd, args)
+			if err := os.Remove(summaryPath); err != nil {
+				logrus.Warnf("Failed to remove the pause summary: %v", err)
+			}
+			clusterTarget.command.PostRun(cmd, args)
+		},
+	}
+}
+
+// exitPaused exits after create cluster paused before the bootstrap, with
+// the guidance to continue the creation.
+func exitPaused(directory string, err *cluster.PausedError) {
+	logrus.Info(err)
+	if err.Summary != nil && len(err.Summary.Resources) > 0 {
+		logrus.Infof("Created %s resources, recorded in the cluster metadata", err.Summary.ResourceCounts())
+	}
+	logrus.Infof("The created resources are summarized in %s", filepath.Join(directory, cluster.PauseSummaryFileName))
+	logrus.Infof("Run 'openshift-install continue --dir %s' to create the bootstrap resources and complete the installation", directory)
+	logrus.Infof("Run 'openshift-install destroy cluster --dir %s' to delete the resources instead", directory)
+	logrus.Exit(0)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/costestimate"
+	_ "github.com/openshift/installer/pkg/costestimate/aws"
+	_ "github.com/openshift/installer/pkg/costestimate/powervs"
+)
+
+// logCostEstimate logs the estimated monthly cost of the cluster of the
+// install config in the directory. The estimate is informational, so its
+// failures are only logged as warnings.
+func logCostEstimate(directory string) {
+	cleanup := setupFileHook(directory)
+	defer cleanup()
+
+	if err := costEstimate(directory); err != nil {
+		logrus.Warnf("Skipping the cost estimate: %v", err)
+	}
+}
+
+func costEstimate(directory string) error {
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	config, err := assetStore.Load(&installconfig.InstallConfig{})
+	if err != nil {
+		return errors.Wrap(err, "failed to load the install config")
+	}
+	if config == nil {
+		return errors.New("no install config found")
+	}
+
+	estimate, err := costestimate.New(context.TODO(), config.(*installconfig.InstallConfig).Config)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := estimate.Print(buf); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		logrus.Info(scanner.Text())
+	}
+	return nil
+}
@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,7 +23,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/clientcmd"
 	clientwatch "k8s.io/client-go/tools/watch"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -29,12 +31,18 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/encryption"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	assetkubeconfig "github.com/openshift/installer/pkg/asset/kubeconfig"
 	"github.com/openshift/installer/pkg/asset/logging"
+	"github.com/openshift/installer/pkg/asset/manifests"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
 	"github.com/openshift/installer/pkg/gather/service"
+	"github.com/openshift/installer/pkg/logformat"
+	"github.com/openshift/installer/pkg/metrics/instrumentation"
+	"github.com/openshift/installer/pkg/metrics/progress"
 	timer "github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
@@ -52,8 +60,14 @@ const (
 	exitCodeInfrastructureFailed
 	exitCodeBootstrapFailed
 	exitCodeInstallFailed
+	exitCodePartialDestroy
+	exitCodeInterrupted
 )
 
+// clusterVersionPercentRegexp matches the completion in the Progressing
+// message of the ClusterVersion, e.g. "(66% complete)".
+var clusterVersionPercentRegexp = regexp.MustCompile(`\((\d+)% complete\)`)
+
 // each target is a variable to preserve the order when creating subcommands and still
 // allow other functions to directly access each target individually.
 var (
@@ -108,20 +122,29 @@ var (
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
 			PostRun: func(_ *cobra.Command, _ []string) {
-				ctx := context.Background()
+				if createClusterOpts.dryRun {
+					return
+				}
+
+				ctx := interruptCtx
 
 				cleanup := setupFileHook(rootOpts.dir)
 				defer cleanup()
 
 				// FIXME: pulling the kubeconfig and metadata out of the root
 				// directory is a bit cludgy when we already have them in memory.
-				config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+				config, err := assetkubeconfig.RESTConfigFromFile(filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
 				if err != nil {
 					logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 				}
 
 				timer.StartTimer("Bootstrap Complete")
+				progress.Start("Bootstrap Complete")
 				if err := waitForBootstrapComplete(ctx, config); err != nil {
+					progress.Fail("Bootstrap Complete", err.Unwrap())
+					if cluster.Interrupted() {
+						exitInterrupted(rootOpts.dir, true, fmt.Sprintf("Run 'openshift-install wait-for bootstrap-complete --dir %s' and then 'openshift-install destroy bootstrap --dir %s' to resume the installation", rootOpts.dir, rootOpts.dir))
+					}
 					bundlePath, gatherErr := runGatherBootstrapCmd(rootOpts.dir)
 					if gatherErr != nil {
 						logrus.Error("Attempted to gather debug logs after installation failure: ", gatherErr)
@@ -137,25 +160,36 @@ var (
 						}
 						logrus.Infof("Bootstrap gather logs captured here %q", bundlePath)
 					}
+					logrus.Info("Use the following command to find the most likely root cause in the logs")
+					logrus.Infof("openshift-install analyze --dir %s", rootOpts.dir)
 					logrus.Exit(exitCodeBootstrapFailed)
 				}
 				timer.StopTimer("Bootstrap Complete")
+				progress.Complete("Bootstrap Complete")
 				timer.StartTimer("Bootstrap Destroy")
+				progress.Start("Bootstrap Destroy")
 
 				if oi, ok := os.LookupEnv("OPENSHIFT_INSTALL_PRESERVE_BOOTSTRAP"); ok && oi != "" {
 					logrus.Warn("OPENSHIFT_INSTALL_PRESERVE_BOOTSTRAP is set, not destroying bootstrap resources. " +
 						"Warning: this should only be used for debugging purposes, and poses a risk to cluster stability.")
 				} else {
 					logrus.Info("Destroying the bootstrap resources...")
-					err = destroybootstrap.Destroy(rootOpts.dir)
+					err = destroybootstrap.Destroy(rootOpts.dir, destroybootstrap.Options{})
 					if err != nil {
+						progress.Fail("Bootstrap Destroy", err)
 						logrus.Fatal(err)
 					}
 				}
 				timer.StopTimer("Bootstrap Destroy")
+				progress.Complete("Bootstrap Destroy")
 
+				progress.Start("Install Complete")
 				err = waitForInstallComplete(ctx, config, rootOpts.dir)
 				if err != nil {
+					progress.Fail("Install Complete", err)
+					if cluster.Interrupted() {
+						exitInterrupted(rootOpts.dir, true, fmt.Sprintf("Run 'openshift-install wait-for install-complete --dir %s' to resume waiting for the installation", rootOpts.dir))
+					}
 					if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 						logrus.Error("Attempted to gather ClusterOperator status after installation failure: ", err2)
 					}
@@ -163,6 +197,7 @@ var (
 					logrus.Error(err)
 					logrus.Exit(exitCodeInstallFailed)
 				}
+				progress.Complete("Install Complete")
 				timer.StopTimer(timer.TotalTimeElapsed)
 				timer.LogSummary()
 			},
@@ -170,6 +205,21 @@ var (
 		assets: targetassets.Cluster,
 	}
 
+	createClusterOpts struct {
+		dryRun        bool
+		skipPreflight bool
+		statusAddress string
+		onInterrupt   string
+	}
+
+	createInstallConfigOpts struct {
+		costEstimate bool
+	}
+
+	// assetParallelism is the number of independent assets generated
+	// concurrently by the create commands.
+	assetParallelism int
+
 	targets = []target{installConfigTarget, manifestsTarget, ignitionConfigsTarget, clusterTarget, singleNodeIgnitionConfigTarget}
 )
 
@@ -237,9 +287,65 @@ func newCreateCmd() *cobra.Command {
 		cmd.AddCommand(t.command)
 	}
 
+	installConfigRun := installConfigTarget.command.Run
+	installConfigTarget.command.Run = func(cmd *cobra.Command, args []string) {
+		installConfigRun(cmd, args)
+		if createInstallConfigOpts.costEstimate {
+			logCostEstimate(rootOpts.dir)
+		}
+	}
+	installConfigTarget.command.Flags().BoolVar(&createInstallConfigOpts.costEstimate, "cost-estimate", false, "print the estimated monthly cost of the cluster, from the list prices of its machines, storage and load balancers (AWS and PowerVS only)")
+
+	clusterRun := clusterTarget.command.Run
+	clusterTarget.command.Run = func(cmd *cobra.Command, args []string) {
+		if createClusterOpts.skipPreflight {
+			logrus.Warn("Skipping the preflight checks of the platform")
+		}
+		if !createClusterOpts.dryRun {
+			if err := validateOnInterrupt(createClusterOpts.onInterrupt); err != nil {
+				logrus.Fatal(err)
+			}
+			if createClusterOpts.statusAddress != "" {
+				serveStatus(createClusterOpts.statusAddress)
+			}
+			trapInterrupts()
+			clusterRun(cmd, args)
+			return
+		}
+		runTargetCmd(targetassets.ClusterPlan...)(cmd, args)
+		logrus.Infof("The plan of the cluster resources was written to %q; no resources were created", filepath.Join(rootOpts.dir, cluster.PlanFileName))
+	}
+	clusterTarget.command.Flags().BoolVar(&createClusterOpts.dryRun, "dry-run", false, "write a plan of the infrastructure resources to create, without creating them (AWS only)")
+	clusterTarget.command.Flags().StringVar(&createClusterOpts.statusAddress, "status-address", "", "serve the current stage, completed assets, cluster operator progress and recent errors as JSON on http://<address>/status while the cluster is created, e.g. 127.0.0.1:8090 (loopback addresses only)")
+	clusterTarget.command.Flags().StringVar(&createClusterOpts.onInterrupt, "on-interrupt", onInterruptPrompt, "what to do with the resources created so far when the creation is interrupted by SIGINT or SIGTERM: prompt, destroy or keep them for a resumed attempt (prompt keeps them when the standard input is not a terminal)")
+	clusterTarget.command.Flags().BoolVar(&cluster.PauseBeforeBootstrap, "pause-before-bootstrap", false, "stop once the infrastructure is provisioned, before the bootstrap resources are created, and write a summary of the created resources; run 'openshift-install continue' to start the bootstrap")
+	clusterTarget.command.Flags().BoolVar(&cluster.ResumeProvisioning, "resume", false, "resume the creation of the infrastructure from the state left by an interrupted or paused run for the same cluster, instead of failing on it")
+	clusterTarget.command.Flags().BoolVar(&createClusterOpts.skipPreflight, "skip-preflight", false, "skip the platform permissions, provisioning, quota and FIPS checks, which are otherwise enforced (see the preflight command)")
+	clusterTarget.command.Flags().IntVar(&cluster.CapacityRetries.Retries, "capacity-retries", cluster.CapacityRetries.Retries, "number of times a stage is retried when an instance cannot be created for lack of capacity in its zone (AWS and PowerVS only)")
+	clusterTarget.command.Flags().DurationVar(&cluster.CapacityRetries.Backoff, "capacity-retry-backoff", cluster.CapacityRetries.Backoff, "delay before the first retry of a stage failing for lack of capacity, doubled for every following retry")
+	clusterTarget.command.Flags().BoolVar(&cluster.CapacityRetries.ZoneFallback, "capacity-zone-fallback", false, "move the control plane instances of a zone lacking capacity to the other zones of the control plane before retrying, regenerating their Machine and ControlPlaneMachineSet manifests (AWS only)")
+
+	addParallelismFlag(cmd)
+	cmd.PersistentFlags().StringVar(&manifests.ExtraManifestsDir, "extra-manifests-dir", "", "directory of day-0 manifests to validate and add to the manifests; the files of its root and openshift subdirectory are added to openshift/ and the files of its manifests subdirectory to manifests/ (overrides extraManifestsDir of the install config)")
+	cmd.PersistentFlags().BoolVar(&installconfig.SkipCloudValidation, "skip-cloud-validation", false, "log the failures of the validations which connect to the cloud APIs (e.g. capacity, DNS and quota) as warnings, for hosts which cannot reach the cloud APIs; schema validation failures are still errors")
+	// The classes must be attached with "=", as the flag has a value when it
+	// is given alone.
+	strictClasses := make([]string, 0, len(installconfig.WarningClasses))
+	for _, class := range installconfig.WarningClasses {
+		strictClasses = append(strictClasses, string(class))
+	}
+	cmd.PersistentFlags().StringSliceVar(&installconfig.StrictValidation, "strict-validation", nil, fmt.Sprintf("fail the validation of the install config on the classes of warnings passed as --strict-validation=<class>[,<class>...] (%s), or on all of them when no class is given (see also the %s annotation)", strings.Join(strictClasses, ", "), installconfig.StrictValidationAnnotation))
+	cmd.PersistentFlags().Lookup("strict-validation").NoOptDefVal = "all"
+
 	return cmd
 }
 
+// addParallelismFlag adds the flag of the number of assets generated
+// concurrently to the commands creating the targets.
+func addParallelismFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVar(&assetParallelism, "parallelism", 1, "number of independent assets generated concurrently")
+}
+
 func asFileWriter(a asset.WritableAsset) asset.FileWriter {
 	switch v := a.(type) {
 	case asset.FileWriter:
@@ -249,46 +355,126 @@ func asFileWriter(a asset.WritableAsset) asset.FileWriter {
 	}
 }
 
-func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
-	runner := func(directory string) error {
-		assetStore, err := assetstore.NewStore(directory)
-		if err != nil {
-			return errors.Wrap(err, "failed to create asset store")
+// fetchTargets fetches the targets with a store of the directory, and writes
+// them to the directory once all of them were generated.
+func fetchTargets(directory string, storeOpts []assetstore.Option, targets []asset.WritableAsset) error {
+	// The files of the targets, the state file and the purges of the
+	// consumed assets are staged, and only change the asset directory
+	// once all of the targets were generated.
+	tx, err := asset.BeginTransaction(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin writing the asset directory")
+	}
+	abort := func() {
+		if err := tx.Abort(); err != nil {
+			logrus.Warnf("Failed to remove the staged assets: %v", err)
 		}
+	}
 
-		for _, a := range targets {
-			err := assetStore.Fetch(a, targets...)
-			if err != nil {
-				err = errors.Wrapf(err, "failed to fetch %s", a.Name())
-			}
+	assetStore, err := assetstore.NewStore(directory, append(storeOpts, assetstore.WithTransaction(tx))...)
+	if err != nil {
+		abort()
+		return errors.Wrap(err, "failed to create asset store")
+	}
 
-			err2 := asFileWriter(a).PersistToFile(directory)
-			if err2 != nil {
-				err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
-				if err != nil {
-					logrus.Error(err2)
-					return err
+	// The install config of the state file is not loaded through its
+	// Load method, which sets the platform of the structured logs. It is
+	// read without the store, which would validate it before the fetch.
+	if config, err := loadUnvalidatedInstallConfig(directory); err == nil && config != nil {
+		logformat.SetPlatform(config.Config.Platform.Name())
+	}
+
+	for _, a := range targets {
+		progress.Start(a.Name())
+		err := assetStore.Fetch(a, targets...)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to fetch %s", a.Name())
+			var interruptedErr *cluster.InterruptedError
+			var pausedErr *cluster.PausedError
+			var provisioningErr *cluster.ProvisioningError
+			if errors.As(err, &interruptedErr) || errors.As(err, &pausedErr) || errors.As(err, &provisioningErr) {
+				// The assets generated before the interruption, the
+				// pause or the failure of the infrastructure, such as
+				// the cluster ID, are kept so that a resumed attempt
+				// creates the same cluster.
+				if err2 := tx.Commit(); err2 != nil {
+					logrus.Error(errors.Wrap(err2, "failed to write the assets to disk"))
 				}
-				return err2
+			} else {
+				abort()
 			}
-
-			if err != nil {
-				return err
+			// The files of the failed asset are still written, such as
+			// the metadata of a cluster which failed to be created, so
+			// that it can be destroyed.
+			if err2 := asFileWriter(a).PersistToFile(directory); err2 != nil {
+				logrus.Error(errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name()))
 			}
+			progress.Fail(a.Name(), err)
+			return err
 		}
-		return nil
+
+		if err := tx.Persist(asFileWriter(a)); err != nil {
+			err = errors.Wrapf(err, "failed to write asset (%s) to disk", a.Name())
+			abort()
+			progress.Fail(a.Name(), err)
+			return err
+		}
+		progress.Complete(a.Name())
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to write the assets to disk, run 'openshift-install assets rollback' to restore the asset directory")
+	}
+	return nil
+}
+
+func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
+	runner := func(directory string) error {
+		storeOpts := []assetstore.Option{assetstore.WithParallelism(assetParallelism)}
+		// The assets generated while the cloud validation is skipped are not
+		// cached, so that a later run with the validation does not reuse them.
+		// The annotation is part of the install config, so it is already
+		// covered by the cache keys.
+		if rootOpts.noCache || installconfig.SkipCloudValidation {
+			storeOpts = append(storeOpts, assetstore.DisableCache())
+		}
+		if createClusterOpts.skipPreflight {
+			storeOpts = append(storeOpts, assetstore.WithSkippedAssets(cluster.PreflightChecks()...))
+		}
+		return fetchTargets(directory, storeOpts, targets)
 	}
 
 	return func(cmd *cobra.Command, args []string) {
+		if assetParallelism < 1 {
+			logrus.Fatalf("invalid parallelism %d, must be at least 1", assetParallelism)
+		}
 		timer.StartTimer(timer.TotalTimeElapsed)
 
 		cleanup := setupFileHook(rootOpts.dir)
 		defer cleanup()
 
 		cluster.InstallDir = rootOpts.dir
+		if installconfig.SkipCloudValidation {
+			logrus.Warn("Skipping the cloud validation: failures of the capacity, DNS, permissions and quota checks are logged as warnings and the installation may fail later")
+		}
 
 		err := runner(rootOpts.dir)
+		if err2 := instrumentation.Write(rootOpts.dir); err2 != nil {
+			logrus.Debugf("Failed to write the metrics of the run: %v", err2)
+		}
 		if err != nil {
+			var interruptedErr *cluster.InterruptedError
+			if errors.As(err, &interruptedErr) {
+				logrus.Warn(err)
+				exitInterrupted(rootOpts.dir, interruptedErr.Created, fmt.Sprintf("Run 'openshift-install create cluster --resume --dir %s' to resume the creation of the cluster", rootOpts.dir))
+			}
+			var pausedErr *cluster.PausedError
+			if errors.As(err, &pausedErr) {
+				exitPaused(rootOpts.dir, pausedErr)
+			}
+			var provisioningErr *cluster.ProvisioningError
+			if errors.As(err, &provisioningErr) {
+				logrus.Infof("Run 'openshift-install create cluster --resume --dir %s' to resume the creation of the cluster once the failure is fixed, or 'openshift-install destroy cluster --dir %s' to delete the resources created so far", rootOpts.dir, rootOpts.dir)
+			}
 			if strings.Contains(err.Error(), asset.InstallConfigError) {
 				logrus.Error(err)
 				logrus.Exit(exitCodeInstallConfigError)
@@ -300,7 +486,7 @@ func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args
 			logrus.Fatal(err)
 		}
 		switch cmd.Name() {
-		case "cluster", "image", "pxe-files":
+		case "cluster", "continue", "image", "pxe-files":
 		default:
 			logrus.Infof(logging.LogCreatedFiles(cmd.Name(), rootOpts.dir, targets))
 		}
@@ -323,7 +509,7 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 
 	routerCrtBytes := []byte(caConfigMap.Data["ca-bundle.crt"])
 	kubeconfig := filepath.Join(directory, "auth", "kubeconfig")
-	kconfig, err := clientcmd.LoadFromFile(kubeconfig)
+	kconfig, err := assetkubeconfig.LoadFile(kubeconfig)
 	if err != nil {
 		return errors.Wrap(err, "loading kubeconfig")
 	}
@@ -348,7 +534,7 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 		newCA := append(routerCrtBytes, clusterCABytes...)
 		c.CertificateAuthorityData = newCA
 	}
-	if err := clientcmd.WriteToFile(*kconfig, kubeconfig); err != nil {
+	if err := assetkubeconfig.WriteFile(kconfig, kubeconfig); err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
 	}
 	return nil
@@ -377,6 +563,7 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config) *cluster
 	silenceRemaining := logDownsample
 	previousErrorSuffix := ""
 	timer.StartTimer("API")
+	progress.Start("API")
 
 	if assetStore, err := assetstore.NewStore(rootOpts.dir); err == nil {
 		checkIfAgentCommand(assetStore)
@@ -388,6 +575,7 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config) *cluster
 		if err == nil {
 			logrus.Infof("API %s up", version)
 			timer.StopTimer("API")
+			progress.Complete("API")
 			cancel()
 		} else {
 			lastErr = err
@@ -497,6 +685,7 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 
 	failing := configv1.ClusterStatusConditionType("Failing")
 	timer.StartTimer("Cluster Operators")
+	progress.Start("Cluster Operators")
 	var lastError string
 	_, err = clientwatch.UntilWithSync(
 		clusterVersionContext,
@@ -515,12 +704,17 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 					cov1helpers.IsStatusConditionFalse(cv.Status.Conditions, failing) &&
 					cov1helpers.IsStatusConditionFalse(cv.Status.Conditions, configv1.OperatorProgressing) {
 					timer.StopTimer("Cluster Operators")
+					progress.Complete("Cluster Operators")
 					return true, nil
 				}
 				if cov1helpers.IsStatusConditionTrue(cv.Status.Conditions, failing) {
 					lastError = cov1helpers.FindStatusCondition(cv.Status.Conditions, failing).Message
 				} else if cov1helpers.IsStatusConditionTrue(cv.Status.Conditions, configv1.OperatorProgressing) {
 					lastError = cov1helpers.FindStatusCondition(cv.Status.Conditions, configv1.OperatorProgressing).Message
+					if match := clusterVersionPercentRegexp.FindStringSubmatch(lastError); match != nil {
+						percent, _ := strconv.Atoi(match[1])
+						progress.Progress("Cluster Operators", percent, lastError)
+					}
 				}
 				logrus.Debugf("Still waiting for the cluster to initialize: %s", lastError)
 				return false, nil
@@ -567,6 +761,7 @@ func getConsole(ctx context.Context, config *rest.Config) (string, error) {
 	logDownsample := 15
 	silenceRemaining := logDownsample
 	timer.StartTimer("Console")
+	progress.Start("Console")
 	wait.Until(func() {
 		route, err := rc.RouteV1().Routes(consoleNamespace).Get(ctx, consoleRouteName, metav1.GetOptions{})
 		if err == nil {
@@ -599,6 +794,7 @@ func getConsole(ctx context.Context, config *rest.Config) (string, error) {
 		return url, errors.New("could not get openshift-console URL")
 	}
 	timer.StopTimer("Console")
+	progress.Complete("Console")
 	return url, nil
 }
 
@@ -610,12 +806,16 @@ func logComplete(directory, consoleURL string) error {
 	}
 	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
 	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
-	pw, err := os.ReadFile(pwFile)
+	pw, err := encryption.ReadFile(pwFile)
 	if err != nil {
 		return err
 	}
 	logrus.Info("Install complete!")
-	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
+	if encryption.Enabled() {
+		logrus.Infof("The kubeconfig and the kubeadmin password are encrypted, run 'openshift-install assets decrypt --dir %s auth/kubeconfig > kubeconfig' to get the kubeconfig for 'oc'", directory)
+	} else {
+		logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
+	}
 	if consoleURL != "" {
 		logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
 		logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
@@ -632,6 +832,10 @@ func waitForInstallComplete(ctx context.Context, config *rest.Config, directory
 		return err
 	}
 
+	if err := runExternalPostInstall(ctx, directory); err != nil {
+		return err
+	}
+
 	consoleURL, err := getConsole(ctx, config)
 	if err != nil {
 		logrus.Warnf("Cluster does not have a console available: %v", err)
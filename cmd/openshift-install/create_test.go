@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+)
+
+// The store creates the parents by type, so the behavior of the test cluster
+// is kept in package variables.
+var (
+	testClusterErr error
+	testClusterIDs []string
+)
+
+// testClusterID is generated with a new random ID, as the cluster ID is.
+type testClusterID struct {
+	ID string `json:"id"`
+}
+
+func (a *testClusterID) Name() string { return "Test Cluster ID" }
+
+func (a *testClusterID) Dependencies() []asset.Asset { return nil }
+
+func (a *testClusterID) Generate(asset.Parents) error {
+	a.ID = fmt.Sprintf("test-%08x", rand.Uint32()) //nolint:gosec // not a secret
+	return nil
+}
+
+func (a *testClusterID) Files() []*asset.File {
+	return []*asset.File{{Filename: "test-cluster-id", Data: []byte(a.ID)}}
+}
+
+func (a *testClusterID) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+// testCluster records the ID of each attempt to create it, and fails with
+// testClusterErr.
+type testCluster struct{}
+
+func (a *testCluster) Name() string { return "Test Cluster" }
+
+func (a *testCluster) Dependencies() []asset.Asset { return []asset.Asset{&testClusterID{}} }
+
+func (a *testCluster) Generate(parents asset.Parents) error {
+	id := &testClusterID{}
+	parents.Get(id)
+	testClusterIDs = append(testClusterIDs, id.ID)
+	return testClusterErr
+}
+
+func (a *testCluster) Files() []*asset.File { return nil }
+
+func (a *testCluster) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func TestFetchTargetsAfterFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		sameID   bool
+		expected string
+	}{
+		{
+			name:     "provisioning failure",
+			err:      &cluster.ProvisioningError{Err: errors.New("failed to create the bootstrap instance")},
+			sameID:   true,
+			expected: `failed to fetch Test Cluster: failed to generate asset "Test Cluster": failed to create the bootstrap instance`,
+		},
+		{
+			name:     "failure before provisioning",
+			err:      errors.New("invalid install config"),
+			expected: `failed to fetch Test Cluster: failed to generate asset "Test Cluster": invalid install config`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			testClusterIDs = nil
+			targets := func() []asset.WritableAsset {
+				return []asset.WritableAsset{&testClusterID{}, &testCluster{}}
+			}
+
+			testClusterErr = tc.err
+			assert.EqualError(t, fetchTargets(dir, nil, targets()), tc.expected)
+
+			// The resumed attempt creates the cluster of the failed one only
+			// when the failure was in the creation of the infrastructure.
+			testClusterErr = nil
+			assert.NoError(t, fetchTargets(dir, nil, targets()))
+			if assert.Len(t, testClusterIDs, 2) {
+				assert.Equal(t, tc.sameID, testClusterIDs[0] == testClusterIDs[1], "cluster IDs %v", testClusterIDs)
+			}
+		})
+	}
+}
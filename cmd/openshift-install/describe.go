@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	describeFormatText = "text"
+	describeFormatJSON = "json"
+)
+
+var (
+	describeClusterOpts struct {
+		format string
+		kinds  []string
+	}
+)
+
+func newDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Describe an OpenShift cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newDescribeClusterCmd())
+	return cmd
+}
+
+func newDescribeClusterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Prints the inventory of the infrastructure resources of the cluster",
+		Long: `Prints the inventory of the infrastructure resources created by the installer for
+the cluster, as recorded in the metadata.json file of the asset directory.
+
+The inventory is updated when the bootstrap resources are destroyed. Clusters
+created by older installers have no inventory.`,
+		Args: cobra.ExactArgs(0),
+		RunE: runDescribeClusterCmd,
+	}
+	cmd.Flags().StringVar(&describeClusterOpts.format, "format", describeFormatText, "output format (e.g. \"text | json\")")
+	cmd.Flags().StringSliceVar(&describeClusterOpts.kinds, "kind", nil, "kinds of the resources to print (e.g. \"Instance,Network,LoadBalancer,DNSRecord,Other\"), all of them if empty")
+	return cmd
+}
+
+func runDescribeClusterCmd(cmd *cobra.Command, args []string) error {
+	var write func(io.Writer, *types.ClusterMetadata) error
+	switch describeClusterOpts.format {
+	case describeFormatText:
+		write = writeClusterText
+	case describeFormatJSON:
+		write = writeClusterJSON
+	default:
+		return errors.Errorf("unsupported format %q, must be one of %q or %q", describeClusterOpts.format, describeFormatText, describeFormatJSON)
+	}
+
+	metadata, err := cluster.LoadMetadata(rootOpts.dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the cluster metadata")
+	}
+	metadata.Resources, err = resourcesOfKinds(metadata.Resources, describeClusterOpts.kinds)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, metadata)
+}
+
+// resourcesOfKinds returns the resources of the kinds, sorted by kind, stage
+// and type, or all the resources if there are no kinds.
+func resourcesOfKinds(resources []types.ClusterResource, kinds []string) ([]types.ClusterResource, error) {
+	known := map[types.ClusterResourceKind]bool{
+		types.ClusterResourceInstance:     true,
+		types.ClusterResourceNetwork:      true,
+		types.ClusterResourceLoadBalancer: true,
+		types.ClusterResourceDNSRecord:    true,
+		types.ClusterResourceOther:        true,
+	}
+	wanted := map[types.ClusterResourceKind]bool{}
+	for _, kind := range kinds {
+		if !known[types.ClusterResourceKind(kind)] {
+			return nil, errors.Errorf("unknown resource kind %q", kind)
+		}
+		wanted[types.ClusterResourceKind(kind)] = true
+	}
+
+	selected := make([]types.ClusterResource, 0, len(resources))
+	for _, resource := range resources {
+		if len(wanted) == 0 || wanted[resource.Kind] {
+			selected = append(selected, resource)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		ri, rj := selected[i], selected[j]
+		if ri.Kind != rj.Kind {
+			return ri.Kind < rj.Kind
+		}
+		if ri.Stage != rj.Stage {
+			return ri.Stage < rj.Stage
+		}
+		return ri.Type < rj.Type
+	})
+	return selected, nil
+}
+
+func writeClusterText(out io.Writer, metadata *types.ClusterMetadata) error {
+	fmt.Fprintf(out, "Cluster name:      %s\n", metadata.ClusterName)
+	fmt.Fprintf(out, "Cluster ID:        %s\n", metadata.ClusterID)
+	fmt.Fprintf(out, "Infrastructure ID: %s\n", metadata.InfraID)
+	fmt.Fprintf(out, "Platform:          %s\n", metadata.Platform())
+
+	if len(metadata.Resources) == 0 {
+		fmt.Fprintln(out, "\nNo resources are recorded in the cluster metadata.")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nResources (%d):\n", len(metadata.Resources))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tSTAGE\tTYPE\tNAME\tID")
+	for _, resource := range metadata.Resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", resource.Kind, resource.Stage, resource.Type, resource.Name, resource.ID)
+	}
+	return w.Flush()
+}
+
+func writeClusterJSON(out io.Writer, metadata *types.ClusterMetadata) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(metadata)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -11,7 +14,10 @@ import (
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/destroy"
 	"github.com/openshift/installer/pkg/destroy/bootstrap"
+	powervsdestroy "github.com/openshift/installer/pkg/destroy/powervs"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	quotaasset "github.com/openshift/installer/pkg/destroy/quota"
+	"github.com/openshift/installer/pkg/metrics/progress"
 	"github.com/openshift/installer/pkg/metrics/timer"
 
 	_ "github.com/openshift/installer/pkg/destroy/alibabacloud"
@@ -24,10 +30,14 @@ import (
 	_ "github.com/openshift/installer/pkg/destroy/nutanix"
 	_ "github.com/openshift/installer/pkg/destroy/openstack"
 	_ "github.com/openshift/installer/pkg/destroy/ovirt"
-	_ "github.com/openshift/installer/pkg/destroy/powervs"
 	_ "github.com/openshift/installer/pkg/destroy/vsphere"
 )
 
+const (
+	destroyClusterStage   = "Destroy Cluster"
+	destroyBootstrapStage = "Destroy Bootstrap"
+)
+
 func newDestroyCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "destroy",
@@ -42,8 +52,23 @@ func newDestroyCmd() *cobra.Command {
 	return cmd
 }
 
+var (
+	destroyClusterOpts struct {
+		parallelism int
+		dryRun      bool
+		timeout     time.Duration
+		infraID     string
+		platform    string
+		region      string
+	}
+
+	destroyBootstrapOpts struct {
+		force bool
+	}
+)
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
 		Args:  cobra.ExactArgs(0),
@@ -51,25 +76,107 @@ func newDestroyClusterCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
+			powervsdestroy.SetParallelism(destroyClusterOpts.parallelism)
+
+			if err := discoverCluster(rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+
+			if destroyClusterOpts.dryRun {
+				if err := runListResourcesCmd(rootOpts.dir); err != nil {
+					logrus.Fatal(err)
+				}
+				return
+			}
+
 			err := runDestroyCmd(rootOpts.dir, os.Getenv("OPENSHIFT_INSTALL_REPORT_QUOTA_FOOTPRINT") == "true")
+			if errors.Is(err, destroy.ErrTimedOut) {
+				logrus.Error(err)
+				logrus.Error("Run destroy cluster again to delete the remaining resources")
+				logrus.Exit(exitCodePartialDestroy)
+			}
 			if err != nil {
 				logrus.Fatal(err)
 			}
 			logrus.Infof("Uninstallation complete!")
 		},
 	}
+	cmd.Flags().IntVar(&destroyClusterOpts.parallelism, "parallelism", powervsdestroy.DefaultParallelism, "number of resources of a type to delete concurrently (PowerVS only)")
+	cmd.Flags().BoolVar(&destroyClusterOpts.dryRun, "dry-run", false, "print the resources which would be destroyed as JSON without deleting them")
+	cmd.Flags().DurationVar(&destroyClusterOpts.timeout, "timeout", 0, "stop the destroy after this duration, recording the deleted resources so that the next destroy resumes, and exit with a distinct code (0 for no timeout; IBM Cloud and PowerVS only)")
+	cmd.Flags().StringVar(&destroyClusterOpts.infraID, "infra-id", "", "destroy the cluster with this infra ID without its metadata.json, discovering its resources by their tags (requires --platform and --region)")
+	cmd.Flags().StringVar(&destroyClusterOpts.platform, "platform", "", "the platform of the cluster destroyed with --infra-id (aws, azure, gcp, ibmcloud or powervs)")
+	cmd.Flags().StringVar(&destroyClusterOpts.region, "region", "", "the region of the cluster destroyed with --infra-id")
+	return cmd
+}
+
+// discoverCluster writes the metadata of the cluster of --infra-id, discovered
+// from its cloud resources, to the directory, for destroying a cluster whose
+// metadata.json is lost. It does nothing without --infra-id.
+func discoverCluster(directory string) error {
+	if destroyClusterOpts.infraID == "" {
+		if destroyClusterOpts.platform != "" || destroyClusterOpts.region != "" {
+			return errors.New("--platform and --region are only used with --infra-id")
+		}
+		return nil
+	}
+	if destroyClusterOpts.platform == "" || destroyClusterOpts.region == "" {
+		return errors.New("--infra-id requires --platform and --region")
+	}
+	return destroy.Discover(logrus.StandardLogger(), directory, destroyClusterOpts.platform, destroyClusterOpts.infraID, destroyClusterOpts.region)
+}
+
+// runListResourcesCmd prints the resources of the cluster which destroying it
+// would delete as JSON, leaving the cluster and the asset directory untouched.
+func runListResourcesCmd(directory string) error {
+	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
+	if err != nil {
+		return errors.Wrap(err, "Failed while preparing to list cluster resources")
+	}
+	count, err := writeResources(os.Stdout, destroyer)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Found %d resources which would be destroyed", count)
+	return nil
+}
+
+// writeResources writes the resources which the destroyer would delete as
+// JSON, and returns their number.
+func writeResources(w io.Writer, destroyer providers.Destroyer) (int, error) {
+	lister, ok := destroyer.(providers.ResourceLister)
+	if !ok {
+		return 0, errors.New("listing the resources of the cluster is not supported on this platform")
+	}
+	resources, err := lister.ListResources()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to list cluster resources")
+	}
+
+	data, err := json.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal cluster resources")
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return 0, errors.Wrap(err, "failed to write cluster resources")
+	}
+	return len(resources), nil
 }
 
 func runDestroyCmd(directory string, reportQuota bool) error {
 	timer.StartTimer(timer.TotalTimeElapsed)
+	progress.Start(destroyClusterStage)
 	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
 	if err != nil {
+		progress.Fail(destroyClusterStage, err)
 		return errors.Wrap(err, "Failed while preparing to destroy cluster")
 	}
-	quota, err := destroyer.Run()
+	quota, err := destroy.Run(destroyer, directory, destroyClusterOpts.timeout)
 	if err != nil {
+		progress.Fail(destroyClusterStage, err)
 		return errors.Wrap(err, "Failed to destroy cluster")
 	}
+	progress.Complete(destroyClusterStage)
 
 	if reportQuota {
 		if err := quotaasset.WriteQuota(directory, quota); err != nil {
@@ -115,7 +222,7 @@ func runDestroyCmd(directory string, reportQuota bool) error {
 }
 
 func newDestroyBootstrapCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "bootstrap",
 		Short: "Destroy the bootstrap resources",
 		Args:  cobra.ExactArgs(0),
@@ -124,12 +231,17 @@ func newDestroyBootstrapCmd() *cobra.Command {
 			defer cleanup()
 
 			timer.StartTimer(timer.TotalTimeElapsed)
-			err := bootstrap.Destroy(rootOpts.dir)
+			progress.Start(destroyBootstrapStage)
+			err := bootstrap.Destroy(rootOpts.dir, bootstrap.Options{Force: destroyBootstrapOpts.force})
 			if err != nil {
+				progress.Fail(destroyBootstrapStage, err)
 				logrus.Fatal(err)
 			}
+			progress.Complete(destroyBootstrapStage)
 			timer.StopTimer(timer.TotalTimeElapsed)
 			timer.LogSummary()
 		},
 	}
+	cmd.Flags().BoolVar(&destroyBootstrapOpts.force, "force", false, "skip the stages whose Terraform state is missing and carry on when a stage fails, to recover from a partially completed destroy")
+	return cmd
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// fakeDestroyer is a destroyer which cannot list its resources.
+type fakeDestroyer struct{}
+
+func (d *fakeDestroyer) Run() (*types.ClusterQuota, error) {
+	return nil, nil
+}
+
+// fakeLister is a destroyer which lists its resources.
+type fakeLister struct {
+	fakeDestroyer
+	resources []providers.Resource
+	err       error
+}
+
+func (l *fakeLister) ListResources() ([]providers.Resource, error) {
+	return l.resources, l.err
+}
+
+func TestWriteResources(t *testing.T) {
+	cases := []struct {
+		name          string
+		destroyer     providers.Destroyer
+		expected      string
+		expectedCount int
+		errorMsg      string
+	}{
+		{
+			name: "resources",
+			destroyer: &fakeLister{resources: []providers.Resource{
+				{Type: "ec2:instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123", Name: "i-0123"},
+				{Type: "s3", ID: "arn:aws:s3:::ostest-x7k2p-bootstrap"},
+			}},
+			expected: `[
+  {
+    "type": "ec2:instance",
+    "id": "arn:aws:ec2:us-east-1:123456789012:instance/i-0123",
+    "name": "i-0123"
+  },
+  {
+    "type": "s3",
+    "id": "arn:aws:s3:::ostest-x7k2p-bootstrap"
+  }
+]
+`,
+			expectedCount: 2,
+		},
+		{
+			name:      "no resources",
+			destroyer: &fakeLister{resources: []providers.Resource{}},
+			expected:  "[]\n",
+		},
+		{
+			name:      "listing failed",
+			destroyer: &fakeLister{err: errors.New("access denied")},
+			errorMsg:  "Failed to list cluster resources: access denied",
+		},
+		{
+			name:      "listing not supported",
+			destroyer: &fakeDestroyer{},
+			errorMsg:  "listing the resources of the cluster is not supported on this platform",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			count, err := writeResources(&buf, tc.destroyer)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
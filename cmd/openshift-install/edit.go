@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/manifests"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+const (
+	installConfigFileName = "install-config.yaml"
+
+	// editErrorsHeader starts the comments listing the errors of the edited
+	// install config when the editor is reopened.
+	editErrorsHeader = "# The edited install config is invalid. Fix the errors below, or revert the changes to cancel the edit."
+	// editErrorsFooter ends the comments listing the errors.
+	editErrorsFooter = "# (end of errors)"
+)
+
+func newEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the assets in the asset directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newEditInstallConfigCmd())
+	return cmd
+}
+
+func newEditInstallConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install-config",
+		Short: "Edits the install config and validates it again",
+		Long: `Opens the install-config.yaml of the asset directory, or the install config
+recorded in the state file when the file was already consumed, in the editor set
+by the EDITOR environment variable (vi if unset).
+
+When the file is saved, the install config is validated again, including the
+validations which connect to the platform, and the editor is reopened with the
+errors until the install config is valid or the changes are reverted.
+
+Once the manifests are generated, the cluster name, base domain, platform,
+publishing strategy, FIPS mode and networks can no longer be changed. The assets
+which the next create command regenerates from the edited install config are
+listed.`,
+		Args: cobra.ExactArgs(0),
+		RunE: runEditInstallConfigCmd,
+	}
+}
+
+func runEditInstallConfigCmd(cmd *cobra.Command, args []string) error {
+	path := filepath.Join(rootOpts.dir, installConfigFileName)
+
+	generated := &installconfig.InstallConfig{}
+	inState, err := assetstore.LoadFromState(rootOpts.dir, generated)
+	if err != nil {
+		return err
+	}
+	original, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if !inState || generated.File == nil {
+			return errors.Errorf("no install config found in %q, create one with the create install-config command", rootOpts.dir)
+		}
+		original = generated.File.Data
+	} else if err != nil {
+		return err
+	}
+
+	manifestsGenerated, err := assetstore.LoadFromState(rootOpts.dir, &manifests.Manifests{})
+	if err != nil {
+		return err
+	}
+
+	edited, err := editInstallConfig(original, func(data []byte) error {
+		updated := &installconfig.InstallConfig{}
+		if _, err := updated.Load(&editedFileFetcher{file: &asset.File{Filename: installConfigFileName, Data: data}}); err != nil {
+			return err
+		}
+		if manifestsGenerated && inState {
+			return installconfig.ValidateImmutableFields(generated.Config, updated.Config).ToAggregate()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if edited == nil {
+		logrus.Info("Edit cancelled, no changes made")
+		return nil
+	}
+
+	if err := os.WriteFile(path, edited, 0o640); err != nil {
+		return err
+	}
+	logrus.Infof("Saved the install config to %q", path)
+	return logRegeneratedAssets()
+}
+
+// editInstallConfig opens the install config in the editor until the edited
+// install config passes the validation, and returns it, or nil if it was
+// left unchanged.
+func editInstallConfig(original []byte, validate func([]byte) error) ([]byte, error) {
+	f, err := os.CreateTemp("", "install-config-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	content := original
+	for {
+		if err := os.WriteFile(f.Name(), content, 0o600); err != nil {
+			return nil, err
+		}
+		if err := runEditor(f.Name()); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(f.Name())
+		if err != nil {
+			return nil, err
+		}
+		data = stripEditErrors(data)
+		if bytes.Equal(data, original) {
+			return nil, nil
+		}
+
+		err = validate(data)
+		if err == nil {
+			return data, nil
+		}
+		logrus.Errorf("The edited install config is invalid: %v", err)
+		content = append(editErrors(err), data...)
+	}
+}
+
+// runEditor opens the file in the editor set by the EDITOR environment
+// variable.
+func runEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	c := exec.Command(editor[0], append(editor[1:], path)...) //nolint:gosec // the editor is chosen by the user
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return errors.Wrapf(err, "failed to run the editor %q", strings.Join(editor, " "))
+	}
+	return nil
+}
+
+// editErrors returns the comments listing the errors of the edited install
+// config.
+func editErrors(err error) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, editErrorsHeader)
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(&b, "#   %s\n", line)
+	}
+	fmt.Fprintln(&b, editErrorsFooter)
+	return b.Bytes()
+}
+
+// stripEditErrors removes the comments listing the errors from the edited
+// install config.
+func stripEditErrors(data []byte) []byte {
+	if !bytes.HasPrefix(data, []byte(editErrorsHeader+"\n")) {
+		return data
+	}
+	if i := bytes.Index(data, []byte(editErrorsFooter+"\n")); i >= 0 {
+		return data[i+len(editErrorsFooter)+1:]
+	}
+	return data
+}
+
+// logRegeneratedAssets logs the assets which the next create command
+// regenerates because the install config in the asset directory differs from
+// the generated one.
+func logRegeneratedAssets() error {
+	assets, err := assetsOfTargets(nil)
+	if err != nil {
+		return err
+	}
+	statuses, err := assetstore.Inspect(rootOpts.dir, assets...)
+	if err != nil {
+		return errors.Wrap(err, "failed to inspect the assets")
+	}
+
+	var regenerated, discarded []string
+	for _, s := range statuses {
+		if !s.ParentsDirty {
+			continue
+		}
+		regenerated = append(regenerated, s.Name)
+		if s.OnDisk {
+			discarded = append(discarded, s.Name)
+		}
+	}
+	if len(regenerated) == 0 {
+		return nil
+	}
+	logrus.Infof("The next create command regenerates these assets from the edited install config: %s", strings.Join(regenerated, ", "))
+	if len(discarded) > 0 {
+		logrus.Warnf("The copies of these assets in %q will be discarded: %s", rootOpts.dir, strings.Join(discarded, ", "))
+	}
+	return nil
+}
+
+// editedFileFetcher fetches the edited install config.
+type editedFileFetcher struct {
+	file *asset.File
+}
+
+// FetchByName returns the edited file if it has the name.
+func (f *editedFileFetcher) FetchByName(name string) (*asset.File, error) {
+	if name != f.file.Filename {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return f.file, nil
+}
+
+// FetchByPattern returns no files.
+func (f *editedFileFetcher) FetchByPattern(pattern string) ([]*asset.File, error) {
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/encryption"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icexternal "github.com/openshift/installer/pkg/asset/installconfig/external"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+// runExternalPostInstall runs the post-install checks of the plugin of the
+// provider of the external platform, if the cluster of the directory has one.
+func runExternalPostInstall(ctx context.Context, directory string) error {
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	config, err := assetStore.Load(&installconfig.InstallConfig{})
+	if err != nil {
+		return errors.Wrap(err, "failed to load the install config")
+	}
+	if config == nil {
+		return nil
+	}
+	ic := config.(*installconfig.InstallConfig).Config
+	if ic.Platform.External == nil {
+		return nil
+	}
+
+	plugin, err := icexternal.Find(ctx, ic.Platform.External.PlatformName)
+	if err != nil || plugin == nil {
+		return err
+	}
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		return err
+	}
+	kubeconfig, cleanup, err := plainFile(filepath.Join(absDir, "auth", "kubeconfig"))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if err := plugin.PostInstall(ctx, ic, kubeconfig); err != nil {
+		return errors.Wrap(err, "the post-install checks of the external platform plugin failed")
+	}
+	return nil
+}
+
+// plainFile returns the path of the file of the asset directory, or the path
+// of a temporary plain copy of it when it is encrypted, for the external tools
+// which read it. The returned function removes the copy.
+func plainFile(path string) (string, func(), error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if !encryption.IsEncrypted(data) {
+		return path, func() {}, nil
+	}
+	plain, err := encryption.Decrypt(data)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	f, err := os.CreateTemp("", filepath.Base(path))
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(plain); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
@@ -30,6 +30,7 @@ import (
 	_ "github.com/openshift/installer/pkg/gather/aws"
 	_ "github.com/openshift/installer/pkg/gather/azure"
 	_ "github.com/openshift/installer/pkg/gather/gcp"
+	_ "github.com/openshift/installer/pkg/gather/powervs"
 )
 
 func newGatherCmd() *cobra.Command {
@@ -46,11 +47,13 @@ to debug the installation failures`,
 		},
 	}
 	cmd.AddCommand(newGatherBootstrapCmd())
+	addSSHBastionFlag(cmd)
 	return cmd
 }
 
 var gatherBootstrapOpts struct {
 	bootstrap    string
+	jumpHost     string
 	masters      []string
 	sshKeys      []string
 	skipAnalysis bool
@@ -79,6 +82,7 @@ func newGatherBootstrapCmd() *cobra.Command {
 		},
 	}
 	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "Hostname or IP of the bootstrap host")
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.jumpHost, "jump-host", "", "Hostname or IP, with an optional port, of an SSH host to proxy the connections to the bootstrap host through")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.masters, "master", []string{}, "Hostnames or IPs of all control plane hosts")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "key", []string{}, "Path to SSH private keys that should be used for authentication. If no key was provided, SSH private keys from user's environment will be used")
 	cmd.PersistentFlags().BoolVar(&gatherBootstrapOpts.skipAnalysis, "skipAnalysis", false, "Skip analysis of the gathered data")
@@ -162,7 +166,11 @@ func gatherBootstrap(bootstrap string, port int, masters []string, directory str
 	}
 
 	logrus.Info("Pulling debug logs from the bootstrap machine")
-	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	bastion, err := gatherBastion()
+	if err != nil {
+		return "", err
+	}
+	client, err := ssh.NewBastionClient(bastion, "core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
 	if err != nil {
 		if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ETIMEDOUT) {
 			return "", errors.Wrap(err, "failed to connect to the bootstrap machine")
@@ -194,6 +202,34 @@ func gatherBootstrap(bootstrap string, port int, masters []string, directory str
 	return logBundlePath, nil
 }
 
+// gatherBastion returns the host to tunnel the connections to the bootstrap
+// host through: the bastion of --ssh-bastion, or the jump host logged into as
+// the core user, or nil to connect directly.
+func gatherBastion() (*ssh.Bastion, error) {
+	bastion, err := sshBastion()
+	if err != nil {
+		return nil, err
+	}
+	if gatherBootstrapOpts.jumpHost == "" {
+		return bastion, nil
+	}
+	if bastion != nil {
+		return nil, errors.New("--jump-host and --ssh-bastion cannot be used together")
+	}
+	return &ssh.Bastion{User: "core", Address: jumpHostAddress(gatherBootstrapOpts.jumpHost)}, nil
+}
+
+// jumpHostAddress returns the address of the jump host, defaulting to the SSH port.
+func jumpHostAddress(jumpHost string) string {
+	if jumpHost == "" {
+		return ""
+	}
+	if _, _, err := net.SplitHostPort(jumpHost); err == nil {
+		return jumpHost
+	}
+	return net.JoinHostPort(jumpHost, "22")
+}
+
 func logClusterOperatorConditions(ctx context.Context, config *rest.Config) error {
 	client, err := configclient.NewForConfig(config)
 	if err != nil {
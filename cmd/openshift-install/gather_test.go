@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJumpHostAddress(t *testing.T) {
+	cases := []struct {
+		jumpHost string
+		expected string
+	}{
+		{jumpHost: "", expected: ""},
+		{jumpHost: "jump.example.com", expected: "jump.example.com:22"},
+		{jumpHost: "jump.example.com:2222", expected: "jump.example.com:2222"},
+		{jumpHost: "10.0.0.5", expected: "10.0.0.5:22"},
+		{jumpHost: "fd00::5", expected: "[fd00::5]:22"},
+		{jumpHost: "[fd00::5]:2222", expected: "[fd00::5]:2222"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.jumpHost, func(t *testing.T) {
+			assert.Equal(t, tc.expected, jumpHostAddress(tc.jumpHost))
+		})
+	}
+}
@@ -8,27 +8,51 @@ import (
 	"regexp"
 
 	"github.com/awalterschulze/gographviz"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
 )
 
 var (
 	graphOpts struct {
 		outputFile string
+		status     bool
 	}
 )
 
+// assetStatusColors are the fill colors of the nodes of the assets by state.
+var assetStatusColors = map[string]string{
+	"dirty":         "orange",
+	"regenerate":    "yellow",
+	"not generated": "lightgrey",
+	"up to date":    "palegreen",
+}
+
 func newGraphCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "graph",
 		Short: "Outputs the internal dependency graph for installer",
-		Long:  "",
-		Args:  cobra.ExactArgs(0),
-		RunE:  runGraphCmd,
+		Long: `Outputs the internal dependency graph for installer.
+
+With --status, each asset shows its status in the asset directory:
+
+  up to date     the asset is in the state file and is reused as it is
+  dirty          the asset was edited in the asset directory and is used instead
+                 of the generated one, so that the assets depending on it are regenerated
+  regenerate     the asset is regenerated because a dependency is dirty, discarding
+                 any copy of it in the asset directory
+  not generated  the asset was never generated
+
+None of the assets is generated, and the asset directory is not modified.`,
+		Args: cobra.ExactArgs(0),
+		RunE: runGraphCmd,
 	}
 	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	cmd.Flags().BoolVar(&graphOpts.status, "status", false, "show the status of each asset in the asset directory")
+	cmd.AddCommand(newGraphImagesCmd())
 	return cmd
 }
 
@@ -50,6 +74,12 @@ func runGraphCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if graphOpts.status {
+		if err := addAssetStatuses(g); err != nil {
+			return err
+		}
+	}
+
 	g.AddAttr("G", "rankdir", "LR")
 	r := regexp.MustCompile(`[. ]`)
 	for _, node := range g.Nodes.Nodes {
@@ -78,6 +108,52 @@ func runGraphCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// addAssetStatuses labels and colors the nodes of the assets with their status
+// in the asset directory.
+func addAssetStatuses(g *gographviz.Graph) error {
+	var assets []asset.Asset
+	for _, t := range targets {
+		for _, a := range t.assets {
+			assets = append(assets, a)
+		}
+	}
+	statuses, err := assetstore.Inspect(rootOpts.dir, assets...)
+	if err != nil {
+		return errors.Wrap(err, "failed to inspect the assets")
+	}
+	for _, s := range statuses {
+		g.AddNode("G", fmt.Sprintf("%q", s.Type), map[string]string{
+			string(gographviz.Label):     fmt.Sprintf("%q", fmt.Sprintf("%s\n%s", s.Type, assetStatusSummary(s))),
+			string(gographviz.Style):     "filled",
+			string(gographviz.FillColor): assetStatusColors[assetState(s)],
+		})
+	}
+	return nil
+}
+
+// assetState returns the state of the asset: dirty, regenerate, not
+// generated or up to date.
+func assetState(status assetstore.AssetStatus) string {
+	switch {
+	case status.Dirty:
+		return "dirty"
+	case status.ParentsDirty:
+		return "regenerate"
+	case status.Regenerate:
+		return "not generated"
+	default:
+		return "up to date"
+	}
+}
+
+// assetStatusSummary returns a short description of the status of the asset.
+func assetStatusSummary(status assetstore.AssetStatus) string {
+	if status.OnDisk {
+		return assetState(status) + ", on disk"
+	}
+	return assetState(status)
+}
+
 func addEdge(g *gographviz.Graph, parent string, asset asset.Asset) {
 	name := fmt.Sprintf("%q", reflect.TypeOf(asset).Elem())
 
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	graphImagesFormatText = "text"
+	graphImagesFormatJSON = "json"
+)
+
+var (
+	graphImagesOpts struct {
+		format  string
+		timeout time.Duration
+	}
+)
+
+// graphImage is an image pulled by the installation, and its mirrors.
+type graphImage struct {
+	Name     string   `json:"name,omitempty"`
+	PullSpec string   `json:"pullSpec"`
+	Mirrors  []string `json:"mirrors,omitempty"`
+}
+
+// graphImagesReport lists the release payload and the images it references.
+type graphImagesReport struct {
+	Release graphImage   `json:"release"`
+	Version string       `json:"version,omitempty"`
+	Images  []graphImage `json:"images"`
+}
+
+func newGraphImagesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "Outputs the container images pulled by the installation",
+		Long: `Resolves the release payload, and outputs the images which the bootstrap host and
+the cluster pull, by digest.
+
+The payload is the one of the installer, unless it is overridden by the
+OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE environment variable. The pull secret,
+the architecture of the control plane and the mirrors of the images are read
+from the install config of the asset directory, so that the mirror registry
+of a disconnected installation can be checked before creating the cluster.
+The payload is fetched from the first of its mirrors which serves it, or else
+from its registry, trusting the additional trust bundle of the install config.`,
+		Args: cobra.ExactArgs(0),
+		RunE: runGraphImagesCmd,
+	}
+	cmd.Flags().StringVar(&graphImagesOpts.format, "format", graphImagesFormatText, "output format (e.g. \"text | json\")")
+	cmd.Flags().DurationVar(&graphImagesOpts.timeout, "timeout", 5*time.Minute, "timeout of the download of the release payload")
+	return cmd
+}
+
+func runGraphImagesCmd(cmd *cobra.Command, args []string) error {
+	var write func(io.Writer, *graphImagesReport) error
+	switch graphImagesOpts.format {
+	case graphImagesFormatText:
+		write = writeGraphImagesText
+	case graphImagesFormatJSON:
+		write = writeGraphImagesJSON
+	default:
+		return errors.Errorf("unsupported format %q, must be one of %q or %q", graphImagesOpts.format, graphImagesFormatText, graphImagesFormatJSON)
+	}
+
+	releaseImage := &releaseimage.Image{}
+	if err := releaseImage.Generate(asset.Parents{}); err != nil {
+		return err
+	}
+
+	pullSecret, trustBundle, architecture := "", "", runtime.GOARCH
+	var sources []types.ImageContentSource
+	config, err := loadUnvalidatedInstallConfig(rootOpts.dir)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		pullSecret, trustBundle = config.Config.PullSecret, config.Config.AdditionalTrustBundle
+		if config.Config.ControlPlane != nil && config.Config.ControlPlane.Architecture != "" {
+			architecture = string(config.Config.ControlPlane.Architecture)
+		}
+		sources = append(append(sources, config.Config.ImageContentSources...), config.MirrorSources...)
+	} else {
+		logrus.Warnf("No install config found in %s, the release payload is fetched without a pull secret", rootOpts.dir)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), graphImagesOpts.timeout)
+	defer cancel()
+	logrus.Infof("Fetching the images of release payload %s for architecture %s...", releaseImage.PullSpec, architecture)
+	payload, err := releaseimage.PayloadImages(ctx, releaseImage.PullSpec, pullSecret, architecture, sources, trustBundle)
+	if err != nil {
+		return err
+	}
+
+	report := &graphImagesReport{
+		Release: graphImage{PullSpec: payload.PullSpec, Mirrors: releaseimage.MirrorPullSpecs(payload.PullSpec, sources)},
+		Version: payload.Version,
+		Images:  make([]graphImage, 0, len(payload.Images)),
+	}
+	for _, image := range payload.Images {
+		report.Images = append(report.Images, graphImage{
+			Name:     image.Name,
+			PullSpec: image.PullSpec,
+			Mirrors:  releaseimage.MirrorPullSpecs(image.PullSpec, sources),
+		})
+	}
+
+	out := os.Stdout
+	if graphOpts.outputFile != "" {
+		f, err := os.Create(graphOpts.outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return write(out, report)
+}
+
+func writeGraphImagesText(out io.Writer, report *graphImagesReport) error {
+	fmt.Fprintf(out, "Release payload: %s\n", report.Release.PullSpec)
+	if report.Version != "" {
+		fmt.Fprintf(out, "Version:         %s\n", report.Version)
+	}
+	for _, mirror := range report.Release.Mirrors {
+		fmt.Fprintf(out, "Mirror:          %s\n", mirror)
+	}
+
+	fmt.Fprintf(out, "\nImages (%d):\n", len(report.Images))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tIMAGE\tMIRRORS")
+	for _, image := range report.Images {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", image.Name, image.PullSpec, strings.Join(image.Mirrors, ","))
+	}
+	return w.Flush()
+}
+
+func writeGraphImagesJSON(out io.Writer, report *graphImagesReport) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
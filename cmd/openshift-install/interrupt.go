@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	terminal "golang.org/x/term"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+)
+
+const (
+	// onInterruptPrompt asks whether to destroy the resources of an
+	// interrupted cluster, when the standard input is a terminal.
+	onInterruptPrompt = "prompt"
+	// onInterruptDestroy destroys the resources of an interrupted cluster.
+	onInterruptDestroy = "destroy"
+	// onInterruptKeep leaves the resources of an interrupted cluster for a
+	// resumed attempt.
+	onInterruptKeep = "keep"
+)
+
+var (
+	// interruptCtx is canceled when create cluster is interrupted, so that
+	// the waits for the cluster stop.
+	interruptCtx = context.Background()
+
+	// stopTrappingInterrupts stops trapping the signals, if they are
+	// trapped.
+	stopTrappingInterrupts = func() {}
+)
+
+// trapInterrupts traps SIGINT and SIGTERM during create cluster. The first
+// signal stops the creation at the next safe point, so that the resources
+// created so far are recorded in the cluster metadata; a second one exits
+// immediately.
+func trapInterrupts() {
+	ctx, cancel := context.WithCancel(context.Background())
+	interruptCtx = ctx
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			logrus.Warnf("Received %s, stopping once the current step completes. Interrupt again to exit immediately, which may leave resources unrecorded.", sig)
+			cluster.Interrupt()
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			logrus.Error("Exiting immediately, the resources being created may not be recorded in the cluster metadata")
+			logrus.Exit(exitCodeInterrupted)
+		case <-done:
+		}
+	}()
+
+	stopped := false
+	stopTrappingInterrupts = func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
+
+// validateOnInterrupt checks the value of the --on-interrupt flag.
+func validateOnInterrupt(value string) error {
+	switch value {
+	case onInterruptPrompt, onInterruptDestroy, onInterruptKeep:
+		return nil
+	default:
+		return errors.Errorf("invalid --on-interrupt %q, must be one of %s, %s or %s", value, onInterruptPrompt, onInterruptDestroy, onInterruptKeep)
+	}
+}
+
+// exitInterrupted exits after an interrupted create cluster. The resources
+// created so far are destroyed, or left for a resumed attempt with resumeHint
+// as the guidance, as the --on-interrupt flag or the user chose.
+func exitInterrupted(directory string, created bool, resumeHint string) {
+	if !created {
+		logrus.Info("No resource of the cluster was created")
+		logrus.Exit(exitCodeInterrupted)
+	}
+
+	destroyNow := createClusterOpts.onInterrupt == onInterruptDestroy
+	if createClusterOpts.onInterrupt == onInterruptPrompt && terminal.IsTerminal(int(os.Stdin.Fd())) {
+		err := survey.AskOne(&survey.Confirm{
+			Message: "Destroy the resources created so far",
+			Help:    "The resources are recorded in the metadata.json file of the asset directory. Keep them to resume the creation of the cluster later.",
+			Default: false,
+		}, &destroyNow)
+		if err != nil {
+			logrus.Warnf("Failed to ask whether to destroy the resources: %v", err)
+		}
+	}
+
+	if destroyNow {
+		// The destroy resumes from the metadata when it is interrupted,
+		// so the signals are not trapped anymore.
+		stopTrappingInterrupts()
+		logrus.Info("Destroying the resources created so far...")
+		if err := runDestroyCmd(directory, false); err != nil {
+			logrus.Error(err)
+			logrus.Errorf("Run 'openshift-install destroy cluster --dir %s' to delete the remaining resources", directory)
+			logrus.Exit(exitCodeInterrupted)
+		}
+		logrus.Info("The resources created so far were destroyed")
+		logrus.Exit(exitCodeInterrupted)
+	}
+
+	logrus.Info("The resources created so far were kept, and are recorded in the cluster metadata")
+	logrus.Info(resumeHint)
+	logrus.Infof("Run 'openshift-install destroy cluster --dir %s' to delete them", directory)
+	logrus.Exit(exitCodeInterrupted)
+}
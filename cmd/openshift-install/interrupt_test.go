@@ -0,0 +1,52 @@
+package main
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+)
+
+func TestValidateOnInterrupt(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "prompt"},
+		{value: "destroy"},
+		{value: "keep"},
+		{value: "", expected: `invalid --on-interrupt "", must be one of prompt, destroy or keep`},
+		{value: "abort", expected: `invalid --on-interrupt "abort", must be one of prompt, destroy or keep`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			err := validateOnInterrupt(tc.value)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTrapInterrupts(t *testing.T) {
+	trapInterrupts()
+	defer stopTrappingInterrupts()
+
+	assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+	select {
+	case <-interruptCtx.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("the interrupt was not trapped")
+	}
+	assert.True(t, cluster.Interrupted())
+
+	// Stopping twice, as exitInterrupted and the end of the command do, is
+	// fine.
+	stopTrappingInterrupts()
+	stopTrappingInterrupts()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/logformat"
 	"github.com/openshift/installer/pkg/version"
 )
 
@@ -87,12 +88,18 @@ func setupFileHook(baseDir string) func() {
 	for k, v := range logrus.StandardLogger().Hooks {
 		originalHooks[k] = v
 	}
-	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, &logrus.TextFormatter{
-		DisableColors:          true,
-		DisableTimestamp:       false,
-		FullTimestamp:          true,
-		DisableLevelTruncation: false,
-	}))
+	var formatter logrus.Formatter = &logformat.TextFormatter{
+		TextFormatter: logrus.TextFormatter{
+			DisableColors:          true,
+			DisableTimestamp:       false,
+			FullTimestamp:          true,
+			DisableLevelTruncation: false,
+		},
+	}
+	if rootOpts.logFormat == logformat.FormatJSON {
+		formatter = &logformat.JSONFormatter{}
+	}
+	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, formatter))
 
 	versionString, err := version.String()
 	if err != nil {
@@ -12,12 +12,21 @@ import (
 	terminal "golang.org/x/term"
 	"k8s.io/klog"
 	klogv2 "k8s.io/klog/v2"
+
+	"github.com/openshift/installer/pkg/asset/prompt"
+	"github.com/openshift/installer/pkg/logformat"
+	"github.com/openshift/installer/pkg/metrics/progress"
 )
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir            string
+		logFormat      string
+		logLevel       string
+		nonInteractive bool
+		noCache        bool
+		progressFormat string
+		progressOutput string
 	}
 )
 
@@ -49,11 +58,16 @@ func installerMain() {
 		newAnalyzeCmd(),
 		newVersionCmd(),
 		newGraphCmd(),
+		newAssetsCmd(),
+		newDescribeCmd(),
+		newEditCmd(),
 		newCoreOSCmd(),
 		newCompletionCmd(),
 		newMigrateCmd(),
 		newExplainCmd(),
 		newAgentCmd(),
+		newPreflightCmd(),
+		newContinueCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
 	}
@@ -74,6 +88,11 @@ func newRootCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.logFormat, "log-format", logformat.FormatText, "log format (e.g. \"text | json\"); json writes one structured log entry per line")
+	cmd.PersistentFlags().BoolVar(&rootOpts.nonInteractive, "non-interactive", false, "fail instead of prompting for missing input")
+	cmd.PersistentFlags().BoolVar(&rootOpts.noCache, "no-cache", false, "generate all assets again instead of reusing cached ones whose inputs are unchanged")
+	cmd.PersistentFlags().StringVar(&rootOpts.progressFormat, "progress-format", progress.FormatText, "progress reporting format (e.g. \"text | json\"); json writes one event per line to stdout or --progress-output")
+	cmd.PersistentFlags().StringVar(&rootOpts.progressOutput, "progress-output", "", "file or named pipe to write the json progress events to instead of stdout")
 	return cmd
 }
 
@@ -86,19 +105,48 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 		level = logrus.InfoLevel
 	}
 
-	logrus.AddHook(newFileHookWithNewlineTruncate(os.Stderr, level, &logrus.TextFormatter{
-		// Setting ForceColors is necessary because logrus.TextFormatter determines
-		// whether or not to enable colors by looking at the output of the logger.
-		// In this case, the output is io.Discard, which is not a terminal.
-		// Overriding it here allows the same check to be done, but against the
-		// hook's output instead of the logger's output.
-		ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
-		DisableTimestamp:       true,
-		DisableLevelTruncation: true,
-		DisableQuote:           true,
-	}))
+	switch rootOpts.logFormat {
+	case logformat.FormatJSON:
+		logrus.AddHook(newFileHook(os.Stderr, level, &logformat.JSONFormatter{}))
+	default:
+		logrus.AddHook(newFileHookWithNewlineTruncate(os.Stderr, level, &logformat.TextFormatter{
+			TextFormatter: logrus.TextFormatter{
+				// Setting ForceColors is necessary because logrus.TextFormatter determines
+				// whether or not to enable colors by looking at the output of the logger.
+				// In this case, the output is io.Discard, which is not a terminal.
+				// Overriding it here allows the same check to be done, but against the
+				// hook's output instead of the logger's output.
+				ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
+				DisableTimestamp:       true,
+				DisableLevelTruncation: true,
+				DisableQuote:           true,
+			},
+		}))
+		if rootOpts.logFormat != logformat.FormatText {
+			logrus.Fatalf("invalid log-format %q", rootOpts.logFormat)
+		}
+	}
 
 	if err != nil {
 		logrus.Fatal(errors.Wrap(err, "invalid log-level"))
 	}
+
+	switch rootOpts.progressFormat {
+	case progress.FormatText:
+	case progress.FormatJSON:
+		out := io.Writer(os.Stdout)
+		if rootOpts.progressOutput != "" {
+			// Opening a named pipe blocks until the reader opens it.
+			f, err := os.OpenFile(rootOpts.progressOutput, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to open the progress output"))
+			}
+			out = f
+		}
+		progress.SetOutput(out)
+	default:
+		logrus.Fatalf("invalid progress-format %q", rootOpts.progressFormat)
+	}
+
+	prompt.SetNonInteractive(rootOpts.nonInteractive)
 }
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+)
+
+const (
+	operatorsStableOutputText = "text"
+	operatorsStableOutputJSON = "json"
+
+	// operatorsStablePollInterval is the interval between two checks of the
+	// ClusterOperators.
+	operatorsStablePollInterval = 10 * time.Second
+)
+
+// operatorStatus is the status of a ClusterOperator reported by
+// wait-for operators-stable.
+type operatorStatus struct {
+	Name        string `json:"name"`
+	Available   bool   `json:"available"`
+	Progressing bool   `json:"progressing"`
+	Degraded    bool   `json:"degraded"`
+	Message     string `json:"message,omitempty"`
+}
+
+// stable returns true if the operator is available, neither progressing nor
+// degraded.
+func (s operatorStatus) stable() bool {
+	return s.Available && !s.Progressing && !s.Degraded
+}
+
+// operatorsStableReport is the result of wait-for operators-stable.
+type operatorsStableReport struct {
+	Stable bool `json:"stable"`
+	// StableSince is when all of the operators last became stable.
+	StableSince *metav1.Time     `json:"stableSince,omitempty"`
+	Operators   []operatorStatus `json:"operators"`
+}
+
+// getOperatorStatuses returns the statuses of the operators, sorted by name.
+func getOperatorStatuses(operators []configv1.ClusterOperator) []operatorStatus {
+	statuses := make([]operatorStatus, 0, len(operators))
+	for _, operator := range operators {
+		conditions := operator.Status.Conditions
+		status := operatorStatus{
+			Name:        operator.Name,
+			Available:   cov1helpers.IsStatusConditionTrue(conditions, configv1.OperatorAvailable),
+			Progressing: !cov1helpers.IsStatusConditionFalse(conditions, configv1.OperatorProgressing),
+			Degraded:    !cov1helpers.IsStatusConditionFalse(conditions, configv1.OperatorDegraded),
+		}
+		// Report the message of the condition which makes the operator unstable.
+		for _, conditionType := range []configv1.ClusterStatusConditionType{configv1.OperatorDegraded, configv1.OperatorProgressing, configv1.OperatorAvailable} {
+			condition := cov1helpers.FindStatusCondition(conditions, conditionType)
+			if condition == nil {
+				continue
+			}
+			unstable := condition.Status != configv1.ConditionFalse
+			if conditionType == configv1.OperatorAvailable {
+				unstable = condition.Status != configv1.ConditionTrue
+			}
+			if unstable {
+				status.Message = fmt.Sprintf("%s=%s: %s", condition.Type, condition.Status, condition.Message)
+				break
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// waitForStableOperators waits until all of the ClusterOperators have been
+// stable for the settle period, and returns the last observed statuses.
+func waitForStableOperators(ctx context.Context, config *rest.Config, settlePeriod, timeout time.Duration) (*operatorsStableReport, error) {
+	client, err := configclient.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a config client")
+	}
+
+	untilTime := time.Now().Add(timeout)
+	logrus.Infof("Waiting up to %v (until %v) for the cluster operators to be stable for %v...",
+		timeout, untilTime.Format(time.Kitchen), settlePeriod)
+
+	report := &operatorsStableReport{}
+	err = wait.PollImmediateWithContext(ctx, operatorsStablePollInterval, timeout, func(ctx context.Context) (bool, error) {
+		operators, err := client.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logrus.Debugf("Failed to list ClusterOperators: %v", err)
+			return false, nil
+		}
+		report.Operators = getOperatorStatuses(operators.Items)
+
+		report.Stable = len(report.Operators) > 0
+		for _, status := range report.Operators {
+			if !status.stable() {
+				logrus.Debugf("Cluster operator %s is not stable: %s", status.Name, status.Message)
+				report.Stable = false
+			}
+		}
+		if !report.Stable {
+			report.StableSince = nil
+			return false, nil
+		}
+
+		if report.StableSince == nil {
+			now := metav1.Now()
+			report.StableSince = &now
+			logrus.Infof("All cluster operators are stable, waiting %v for them to settle", settlePeriod)
+		}
+		return time.Since(report.StableSince.Time) >= settlePeriod, nil
+	})
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			err = errors.Errorf("cluster operators did not settle within %v", timeout)
+		}
+		return report, err
+	}
+	return report, nil
+}
+
+// writeOperatorsStableReport writes the report in the output format.
+func writeOperatorsStableReport(w io.Writer, report *operatorsStableReport, output string) error {
+	switch output {
+	case operatorsStableOutputJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the operator statuses")
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case operatorsStableOutputText:
+		for _, status := range report.Operators {
+			if status.stable() {
+				logrus.Infof("Cluster operator %s is stable", status.Name)
+			} else {
+				logrus.Errorf("Cluster operator %s is not stable: %s", status.Name, status.Message)
+			}
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported output format %q", output)
+	}
+}
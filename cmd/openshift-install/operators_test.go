@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func clusterOperator(name string, conditions ...configv1.ClusterOperatorStatusCondition) configv1.ClusterOperator {
+	return configv1.ClusterOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     configv1.ClusterOperatorStatus{Conditions: conditions},
+	}
+}
+
+func condition(conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus, message string) configv1.ClusterOperatorStatusCondition {
+	return configv1.ClusterOperatorStatusCondition{Type: conditionType, Status: status, Message: message}
+}
+
+func TestGetOperatorStatuses(t *testing.T) {
+	cases := []struct {
+		name     string
+		operator configv1.ClusterOperator
+		expected operatorStatus
+	}{
+		{
+			name: "stable",
+			operator: clusterOperator("dns",
+				condition(configv1.OperatorAvailable, configv1.ConditionTrue, "DNS is available"),
+				condition(configv1.OperatorProgressing, configv1.ConditionFalse, ""),
+				condition(configv1.OperatorDegraded, configv1.ConditionFalse, ""),
+			),
+			expected: operatorStatus{Name: "dns", Available: true},
+		},
+		{
+			name: "not available",
+			operator: clusterOperator("dns",
+				condition(configv1.OperatorAvailable, configv1.ConditionFalse, "no DNS pods"),
+				condition(configv1.OperatorProgressing, configv1.ConditionFalse, ""),
+				condition(configv1.OperatorDegraded, configv1.ConditionFalse, ""),
+			),
+			expected: operatorStatus{Name: "dns", Message: "Available=False: no DNS pods"},
+		},
+		{
+			name: "progressing",
+			operator: clusterOperator("dns",
+				condition(configv1.OperatorAvailable, configv1.ConditionTrue, ""),
+				condition(configv1.OperatorProgressing, configv1.ConditionTrue, "rolling out"),
+				condition(configv1.OperatorDegraded, configv1.ConditionFalse, ""),
+			),
+			expected: operatorStatus{Name: "dns", Available: true, Progressing: true, Message: "Progressing=True: rolling out"},
+		},
+		{
+			name: "degraded reported before progressing",
+			operator: clusterOperator("dns",
+				condition(configv1.OperatorAvailable, configv1.ConditionFalse, "no DNS pods"),
+				condition(configv1.OperatorProgressing, configv1.ConditionTrue, "rolling out"),
+				condition(configv1.OperatorDegraded, configv1.ConditionTrue, "pods crash looping"),
+			),
+			expected: operatorStatus{Name: "dns", Progressing: true, Degraded: true, Message: "Degraded=True: pods crash looping"},
+		},
+		{
+			name: "unknown conditions",
+			operator: clusterOperator("dns",
+				condition(configv1.OperatorAvailable, configv1.ConditionTrue, ""),
+				condition(configv1.OperatorProgressing, configv1.ConditionUnknown, "no status yet"),
+			),
+			expected: operatorStatus{Name: "dns", Available: true, Progressing: true, Degraded: true, Message: "Progressing=Unknown: no status yet"},
+		},
+		{
+			name:     "no conditions",
+			operator: clusterOperator("dns"),
+			expected: operatorStatus{Name: "dns", Progressing: true, Degraded: true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			statuses := getOperatorStatuses([]configv1.ClusterOperator{tc.operator})
+			assert.Equal(t, []operatorStatus{tc.expected}, statuses)
+		})
+	}
+}
+
+func TestGetOperatorStatusesSorted(t *testing.T) {
+	stable := []configv1.ClusterOperatorStatusCondition{
+		condition(configv1.OperatorAvailable, configv1.ConditionTrue, ""),
+		condition(configv1.OperatorProgressing, configv1.ConditionFalse, ""),
+		condition(configv1.OperatorDegraded, configv1.ConditionFalse, ""),
+	}
+	operators := []configv1.ClusterOperator{
+		clusterOperator("network", stable...),
+		clusterOperator("authentication", stable...),
+		clusterOperator("dns", stable...),
+	}
+	var names []string
+	for _, status := range getOperatorStatuses(operators) {
+		assert.True(t, status.stable(), status.Name)
+		names = append(names, status.Name)
+	}
+	assert.Equal(t, []string{"authentication", "dns", "network"}, names)
+	assert.Empty(t, getOperatorStatuses(nil))
+}
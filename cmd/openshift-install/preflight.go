@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+const (
+	preflightPass = "PASS"
+	preflightWarn = "WARN"
+	preflightFail = "FAIL"
+)
+
+// preflightResult is the outcome of a preflight check.
+type preflightResult struct {
+	Check   string
+	Result  string
+	Details string
+}
+
+// warningCollector is a logrus hook which collects the warnings logged while
+// a preflight check runs.
+type warningCollector struct {
+	messages []string
+}
+
+func (w *warningCollector) Levels() []logrus.Level {
+	return []logrus.Level{logrus.WarnLevel}
+}
+
+func (w *warningCollector) Fire(entry *logrus.Entry) error {
+	w.messages = append(w.messages, entry.Message)
+	return nil
+}
+
+var preflightOpts struct {
+	printPolicy bool
+}
+
+func newPreflightCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Run the platform checks of the install config",
+		Long: `Run the platform checks of the install config.
+
+This command checks the credentials, permissions, provisioning requirements
+(e.g. DNS) and quota of the platform for the install config in the assets
+directory, and reports the result of every check. The assets generated for
+the checks are discarded, the assets directory is left as it is. When FIPS is enabled, it also checks that the installer runs with a
+FIPS-capable crypto backend on a host in FIPS mode, that the release payload
+is signed and that the architectures of the machines support FIPS. The same
+checks are run by create cluster, unless --skip-preflight is set.
+
+With --print-policy, the command instead prints the least-privilege IAM
+policy for the install config, so that the credentials can be provisioned
+ahead of the install. Only AWS is supported.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if preflightOpts.printPolicy {
+				if err := printPermissionsPolicy(os.Stdout, rootOpts.dir); err != nil {
+					logrus.Fatal(err)
+				}
+				return
+			}
+
+			results, err := runPreflightChecks(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			if err := writePreflightResults(os.Stdout, results); err != nil {
+				logrus.Fatal(err)
+			}
+
+			failed := 0
+			for _, result := range results {
+				if result.Result == preflightFail {
+					failed++
+				}
+			}
+			if failed > 0 {
+				logrus.Fatalf("%d of %d preflight checks failed", failed, len(results))
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&preflightOpts.printPolicy, "print-policy", false, "print the least-privilege IAM policy for the install config instead of running the checks (AWS only)")
+	return cmd
+}
+
+// runPreflightChecks runs every preflight check, rather than stopping at the
+// first failure.
+func runPreflightChecks(directory string) ([]preflightResult, error) {
+	assetStore, err := assetstore.NewStore(directory, assetstore.Ephemeral())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create asset store")
+	}
+
+	return runChecks(assetStore, append([]asset.Asset{&installconfig.PlatformCredsCheck{}}, cluster.PreflightChecks()...)), nil
+}
+
+// runChecks runs every check with the store, rather than stopping at the
+// first failure.
+func runChecks(assetStore asset.Store, checks []asset.Asset) []preflightResult {
+	results := make([]preflightResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, runPreflightCheck(assetStore, check))
+	}
+	return results
+}
+
+// runPreflightCheck runs the check and reports the warnings it logged.
+func runPreflightCheck(assetStore asset.Store, check asset.Asset) preflightResult {
+	originalHooks := logrus.LevelHooks{}
+	for k, v := range logrus.StandardLogger().Hooks {
+		originalHooks[k] = v
+	}
+	warnings := &warningCollector{}
+	logrus.AddHook(warnings)
+	defer logrus.StandardLogger().ReplaceHooks(originalHooks)
+
+	result := preflightResult{Check: check.Name(), Result: preflightPass}
+	if err := assetStore.Fetch(check); err != nil {
+		result.Result = preflightFail
+		result.Details = err.Error()
+	} else if len(warnings.messages) > 0 {
+		result.Result = preflightWarn
+		result.Details = strings.Join(warnings.messages, "; ")
+	}
+	return result
+}
+
+// writePreflightResults writes the results as a table.
+func writePreflightResults(w io.Writer, results []preflightResult) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRESULT\tDETAILS")
+	for _, result := range results {
+		details := strings.ReplaceAll(result.Details, "\n", "; ")
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Check, result.Result, details)
+	}
+	return tw.Flush()
+}
+
+// printPermissionsPolicy writes the least-privilege IAM policy for the install
+// config in the directory as JSON.
+func printPermissionsPolicy(w io.Writer, directory string) error {
+	config, err := loadUnvalidatedInstallConfig(directory)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return errors.New("no install config found")
+	}
+	ic := config.Config
+	if platform := ic.Platform.Name(); platform != aws.Name {
+		return errors.Errorf("least-privilege policies are not supported for platform %q", platform)
+	}
+
+	policy, err := awsconfig.LeastPrivilegePolicy(ic)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(policy)
+}
+
+// loadUnvalidatedInstallConfig returns the install config of the directory,
+// from its file or else from the state file, or nil if there is none. Unlike
+// the asset store, it does not validate the install config, which needs the
+// credentials of the platform, so it suits the commands which only read it.
+func loadUnvalidatedInstallConfig(directory string) (*installconfig.InstallConfig, error) {
+	config := &installconfig.InstallConfig{}
+	found, err := config.LoadFromFile(assetstore.NewFileFetcher(directory))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the install config")
+	}
+	if found {
+		// The mirrors of the state file were loaded with its install config.
+		if results := config.Config.ImageMirrorResults; results != nil {
+			if config.MirrorSources, err = installconfig.LoadImageMirrorResults(results.Path); err != nil {
+				return nil, errors.Wrapf(err, "invalid imageMirrorResults %q", results.Path)
+			}
+		}
+		return config, nil
+	}
+	found, err = assetstore.LoadFromState(directory, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the install config")
+	}
+	if !found {
+		return nil, nil
+	}
+	return config, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// fakeCheck is a check which logs its warnings and fails with its error.
+type fakeCheck struct {
+	name     string
+	warnings []string
+	err      error
+}
+
+func (c *fakeCheck) Name() string {
+	return c.name
+}
+
+func (c *fakeCheck) Dependencies() []asset.Asset {
+	return nil
+}
+
+func (c *fakeCheck) Generate(asset.Parents) error {
+	for _, warning := range c.warnings {
+		logrus.Warn(warning)
+	}
+	return c.err
+}
+
+// fakeStore generates the assets it fetches, without their dependencies.
+type fakeStore struct {
+	asset.Store
+}
+
+func (s *fakeStore) Fetch(a asset.Asset, _ ...asset.WritableAsset) error {
+	return a.Generate(asset.Parents{})
+}
+
+func TestRunChecks(t *testing.T) {
+	checks := []asset.Asset{
+		&fakeCheck{name: "Passing Check"},
+		&fakeCheck{name: "Warning Check", warnings: []string{"first warning", "second warning"}},
+		&fakeCheck{name: "Failing Check", warnings: []string{"ignored warning"}, err: errors.New("check failed")},
+		&fakeCheck{name: "Check After Failure"},
+	}
+	expected := []preflightResult{
+		{Check: "Passing Check", Result: preflightPass},
+		{Check: "Warning Check", Result: preflightWarn, Details: "first warning; second warning"},
+		{Check: "Failing Check", Result: preflightFail, Details: "check failed"},
+		{Check: "Check After Failure", Result: preflightPass},
+	}
+	assert.Equal(t, expected, runChecks(&fakeStore{}, checks))
+}
+
+func TestWritePreflightResults(t *testing.T) {
+	results := []preflightResult{
+		{Check: "Platform Credentials Check", Result: preflightPass},
+		{Check: "Platform Quota Check", Result: preflightFail, Details: "not enough quota:\nvCPUs"},
+	}
+	expected := "CHECK                       RESULT  DETAILS\n" +
+		"Platform Credentials Check  PASS    \n" +
+		"Platform Quota Check        FAIL    not enough quota:; vCPUs\n"
+	var buf bytes.Buffer
+	assert.NoError(t, writePreflightResults(&buf, results))
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/metrics/progress"
+	"github.com/openshift/installer/pkg/metrics/status"
+)
+
+// serveStatus serves the status of the command on the address until the
+// command exits.
+func serveStatus(address string) {
+	tracker := status.NewTracker()
+	progress.AddListener(tracker.Observe)
+	logrus.AddHook(tracker)
+
+	addr, err := status.Serve(context.Background(), address, tracker)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Infof("Serving the install status on http://%s%s", addr, status.Path)
+}
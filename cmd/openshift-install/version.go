@@ -1,26 +1,68 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/coreos/stream-metadata-go/stream"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/terraform/providers"
 	"github.com/openshift/installer/pkg/version"
 )
 
+const (
+	versionOutputText = "text"
+	versionOutputJSON = "json"
+)
+
+var versionOpts struct {
+	output string
+}
+
+// versionReport is the version information printed by version --output=json.
+type versionReport struct {
+	Version             string `json:"version"`
+	Commit              string `json:"commit,omitempty"`
+	ReleaseImage        string `json:"releaseImage,omitempty"`
+	ReleaseImageDigest  string `json:"releaseImageDigest,omitempty"`
+	ReleaseArchitecture string `json:"releaseArchitecture"`
+	// RHCOS is the release of the boot images of the embedded CoreOS
+	// stream, by architecture and platform.
+	RHCOS map[string]map[string]string `json:"rhcos,omitempty"`
+	// Terraform is the upstream version of the embedded terraform binary
+	// and providers, by name.
+	Terraform map[string]string `json:"terraform,omitempty"`
+}
+
 func newVersionCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
 		Long:  "",
 		Args:  cobra.ExactArgs(0),
 		RunE:  runVersionCmd,
 	}
+	cmd.Flags().StringVar(&versionOpts.output, "output", versionOutputText, "format of the version information, text or json")
+	return cmd
 }
 
 func runVersionCmd(cmd *cobra.Command, args []string) error {
+	switch versionOpts.output {
+	case versionOutputText:
+	case versionOutputJSON:
+		return printVersionReport()
+	default:
+		return errors.Errorf("invalid --output %q, must be one of %q or %q", versionOpts.output, versionOutputText, versionOutputJSON)
+	}
+
 	versionString, err := version.Version()
 	if err != nil {
 		return err
@@ -36,3 +78,57 @@ func runVersionCmd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("release architecture %s\n", version.DefaultArch())
 	return nil
 }
+
+// printVersionReport prints the version information as JSON, with the
+// versions of the embedded boot images and terraform providers.
+func printVersionReport() error {
+	versionString, err := version.Version()
+	if err != nil {
+		return err
+	}
+	report := versionReport{
+		Version:             versionString,
+		Commit:              version.Commit,
+		ReleaseArchitecture: string(version.DefaultArch()),
+	}
+	if image, err := releaseimage.Default(); err == nil {
+		report.ReleaseImage = image
+		if _, digest, ok := strings.Cut(image, "@"); ok {
+			report.ReleaseImageDigest = digest
+		}
+	}
+
+	st, err := rhcos.FetchCoreOSBuild(context.TODO())
+	if err != nil {
+		return err
+	}
+	report.RHCOS = bootImageReleases(st)
+
+	report.Terraform, err = providers.Versions()
+	if err != nil {
+		return err
+	}
+	if len(report.Terraform) == 0 {
+		logrus.Debug("The versions of the embedded terraform binary and providers were not recorded at build time")
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the version information")
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// bootImageReleases returns the release of the boot images of the stream, by
+// architecture and platform.
+func bootImageReleases(st *stream.Stream) map[string]map[string]string {
+	releases := map[string]map[string]string{}
+	for arch, a := range st.Architectures {
+		releases[arch] = map[string]string{}
+		for platform, artifacts := range a.Artifacts {
+			releases[arch][platform] = artifacts.Release
+		}
+	}
+	return releases
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/coreos/stream-metadata-go/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootImageReleases(t *testing.T) {
+	st := &stream.Stream{
+		Architectures: map[string]stream.Arch{
+			"x86_64": {
+				Artifacts: map[string]stream.PlatformArtifacts{
+					"aws":       {Release: "413.92.202305021736-0"},
+					"openstack": {Release: "413.92.202305021736-0"},
+				},
+			},
+			"aarch64": {
+				Artifacts: map[string]stream.PlatformArtifacts{
+					"aws": {Release: "413.92.202305021736-1"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, map[string]map[string]string{
+		"x86_64": {
+			"aws":       "413.92.202305021736-0",
+			"openstack": "413.92.202305021736-0",
+		},
+		"aarch64": {
+			"aws": "413.92.202305021736-1",
+		},
+	}, bootImageReleases(st))
+}
+
+func TestVersionReportJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		report   versionReport
+		expected string
+	}{
+		{
+			name: "unreleased build",
+			report: versionReport{
+				Version:             "unreleased-master-1234",
+				ReleaseArchitecture: "amd64",
+			},
+			expected: `{
+				"version": "unreleased-master-1234",
+				"releaseArchitecture": "amd64"
+			}`,
+		},
+		{
+			name: "release build",
+			report: versionReport{
+				Version:             "4.13.0",
+				Commit:              "a1b2c3d",
+				ReleaseImage:        "quay.io/openshift-release-dev/ocp-release@sha256:0123",
+				ReleaseImageDigest:  "sha256:0123",
+				ReleaseArchitecture: "amd64",
+				RHCOS: map[string]map[string]string{
+					"x86_64": {"aws": "413.92.202305021736-0"},
+				},
+				Terraform: map[string]string{
+					"terraform": "v1.3.7",
+					"aws":       "v4.34.0",
+				},
+			},
+			expected: `{
+				"version": "4.13.0",
+				"commit": "a1b2c3d",
+				"releaseImage": "quay.io/openshift-release-dev/ocp-release@sha256:0123",
+				"releaseImageDigest": "sha256:0123",
+				"releaseArchitecture": "amd64",
+				"rhcos": {"x86_64": {"aws": "413.92.202305021736-0"}},
+				"terraform": {"terraform": "v1.3.7", "aws": "v4.34.0"}
+			}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.report)
+			if assert.NoError(t, err) {
+				assert.JSONEq(t, tc.expected, string(data))
+			}
+		})
+	}
+}
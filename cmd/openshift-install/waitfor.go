@@ -2,12 +2,11 @@ package main
 
 import (
 	"context"
-	"path/filepath"
+	"os"
+	"time"
 
-	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"k8s.io/client-go/tools/clientcmd"
 
 	timer "github.com/openshift/installer/pkg/metrics/timer"
 )
@@ -27,6 +26,8 @@ them directly.`,
 	}
 	cmd.AddCommand(newWaitForBootstrapCompleteCmd())
 	cmd.AddCommand(newWaitForInstallCompleteCmd())
+	cmd.AddCommand(newWaitForOperatorsStableCmd())
+	addSSHBastionFlag(cmd)
 	return cmd
 }
 
@@ -42,10 +43,11 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			config, closeTunnel, err := loadKubeconfig(rootOpts.dir)
 			if err != nil {
-				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+				logrus.Fatal(err)
 			}
+			defer closeTunnel()
 			timer.StartTimer("Bootstrap Complete")
 			if err := waitForBootstrapComplete(ctx, config); err != nil {
 				if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
@@ -79,10 +81,11 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			config, closeTunnel, err := loadKubeconfig(rootOpts.dir)
 			if err != nil {
-				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+				logrus.Fatal(err)
 			}
+			defer closeTunnel()
 
 			err = waitForInstallComplete(ctx, config, rootOpts.dir)
 			if err != nil {
@@ -98,3 +101,61 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 		},
 	}
 }
+
+var waitForOperatorsStableOpts struct {
+	settlePeriod time.Duration
+	timeout      time.Duration
+	output       string
+}
+
+func newWaitForOperatorsStableCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operators-stable",
+		Short: "Wait until all cluster operators are stable",
+		Long: `Wait until all cluster operators are stable.
+
+An operator is stable when it is Available, not Progressing and not
+Degraded. The command succeeds once all of the operators have remained
+stable for the settle period.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			timer.StartTimer(timer.TotalTimeElapsed)
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			opts := waitForOperatorsStableOpts
+			if opts.output != operatorsStableOutputText && opts.output != operatorsStableOutputJSON {
+				logrus.Fatalf("invalid --output %q, must be one of %q or %q", opts.output, operatorsStableOutputText, operatorsStableOutputJSON)
+			}
+
+			config, closeTunnel, err := loadKubeconfig(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			defer closeTunnel()
+
+			timer.StartTimer("Operators Stable")
+			report, err := waitForStableOperators(ctx, config, opts.settlePeriod, opts.timeout)
+			if report != nil {
+				if err2 := writeOperatorsStableReport(os.Stdout, report, opts.output); err2 != nil {
+					logrus.Error("Failed to report the cluster operator statuses: ", err2)
+				}
+			}
+			if err != nil {
+				logrus.Error(err)
+				logrus.Exit(exitCodeInstallFailed)
+			}
+
+			logrus.Info("All cluster operators are stable")
+			timer.StopTimer("Operators Stable")
+			timer.StopTimer(timer.TotalTimeElapsed)
+			timer.LogSummary()
+		},
+	}
+	cmd.Flags().DurationVar(&waitForOperatorsStableOpts.settlePeriod, "settle-period", 5*time.Minute, "how long all of the operators must remain stable")
+	cmd.Flags().DurationVar(&waitForOperatorsStableOpts.timeout, "timeout", 60*time.Minute, "how long to wait for the operators to settle")
+	cmd.Flags().StringVar(&waitForOperatorsStableOpts.output, "output", operatorsStableOutputText, "format of the operator statuses, text or json")
+	return cmd
+}
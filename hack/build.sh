@@ -24,6 +24,29 @@ copy_terraform_to_mirror() {
 
   mkdir -p "${PWD}/pkg/terraform/providers/mirror/terraform/"
   cp "${PWD}/terraform/bin/${TARGET_OS_ARCH}/terraform" "${PWD}/pkg/terraform/providers/mirror/terraform/"
+
+  # Record the upstream versions, as the providers are mirrored as 1.0.0.
+  # The required version is a pseudo-version when the upstream tag cannot be
+  # required directly, so prefer the version the module is replaced with, then
+  # the version noted in the comment of the require directive.
+  {
+    printf '{'
+    for dir in "${PWD}"/terraform/terraform "${PWD}"/terraform/providers/*; do
+      name="$(basename "$dir")"
+      ver="$(awk '
+        /^require [^(]/ && !module { module = $2; version = $3; if ($4 == "//") { version = $5 } }
+        module && $1 == "replace" && $2 == module && $3 == "=>" && NF == 5 { version = $5 }
+        module && $1 == module && $2 == "=>" && NF == 4 { version = $4 }
+        END { print version }
+      ' "$dir/go.mod")"
+      if [ -z "$ver" ]; then
+        continue
+      fi
+      printf '%s"%s":"%s"' "${sep:-}" "$name" "$ver"
+      sep=','
+    done
+    printf '}\n'
+  } > "${PWD}/pkg/terraform/providers/mirror/versions.json"
 }
 
 minimum_go_version=1.18
@@ -75,5 +98,17 @@ then
 	export CGO_ENABLED=1
 fi
 
+# A Go toolchain with a FIPS crypto experiment builds a FIPS-capable binary.
+if (go env GOEXPERIMENT | grep -Eq 'boringcrypto|opensslcrypto|strictfipsruntime|systemcrypto')
+then
+	TAGS="${TAGS} fipscapable"
+fi
+
+# The FIPS-capable crypto backend of the Go toolchain calls OpenSSL through cgo.
+if (echo "${TAGS}" | grep -q 'fipscapable')
+then
+	export CGO_ENABLED=1
+fi
+
 # shellcheck disable=SC2086
 go build ${GOFLAGS} -gcflags "${GCFLAGS}" -ldflags "${LDFLAGS}" -tags "${TAGS}" -o "${OUTPUT}" ./cmd/openshift-install
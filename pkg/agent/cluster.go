@@ -3,7 +3,6 @@ package agent
 import (
 	"context"
 	"net"
-	"os"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/openshift/assisted-service/client/installer"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/installer/pkg/asset/encryption"
 	"github.com/openshift/installer/pkg/gather/ssh"
 )
 
@@ -27,6 +27,7 @@ type Cluster struct {
 	clusterID              *strfmt.UUID
 	clusterInfraEnvID      *strfmt.UUID
 	installHistory         *clusterInstallStatusHistory
+	hostProgress           *hostProgressTracker
 }
 
 type clientSet struct {
@@ -117,6 +118,27 @@ func NewCluster(ctx context.Context, assetDir string) (*Cluster, error) {
 	return czero, nil
 }
 
+// EnableHostProgress Report the progress of each host (discovered, validated,
+// installing, rebooted, joined) while waiting, following the hosts through the
+// cluster nodes once the rendezvous host rebooted.
+func (czero *Cluster) EnableHostProgress() {
+	czero.hostProgress = newHostProgressTracker()
+}
+
+// updateHostProgressFromNodes Report the hosts which joined the cluster as
+// ready nodes, if the progress of the hosts is reported.
+func (czero *Cluster) updateHostProgressFromNodes() {
+	if czero.hostProgress == nil {
+		return
+	}
+	nodes, err := czero.API.Kube.ListNodes()
+	if err != nil {
+		logrus.Debug(err)
+		return
+	}
+	reportHostProgress(czero.hostProgress.updateFromNodes(nodes.Items))
+}
+
 // IsBootstrapComplete (is-bootstrap-complete, exit-on-error, returned-error)
 // IsBootstrapComplete Determine if the cluster has completed the bootstrap process.
 func (czero *Cluster) IsBootstrapComplete() (bool, bool, error) {
@@ -169,6 +191,8 @@ func (czero *Cluster) IsBootstrapComplete() (bool, bool, error) {
 			czero.installHistory.ClusterKubeAPISeen = true
 		}
 
+		czero.updateHostProgressFromNodes()
+
 		configmap, err := czero.API.Kube.IsBootstrapConfigMapComplete()
 		if configmap {
 			logrus.Info("Bootstrap configMap status is complete")
@@ -218,6 +242,9 @@ func (czero *Cluster) IsBootstrapComplete() (bool, bool, error) {
 		}
 
 		czero.PrintInstallStatus(clusterMetadata)
+		if czero.hostProgress != nil {
+			reportHostProgress(czero.hostProgress.updateFromHosts(clusterMetadata.Hosts))
+		}
 
 		if *clusterMetadata.Status == models.ClusterStatusReady {
 			stuck, err := czero.IsClusterStuckInReady()
@@ -281,6 +308,8 @@ func (czero *Cluster) IsInstallComplete() (bool, error) {
 		return true, nil
 	}
 
+	czero.updateHostProgressFromNodes()
+
 	if !czero.installHistory.ClusterOperatorsInitialized {
 		initialized, err := czero.API.OpenShift.AreClusterOperatorsInitialized()
 		if initialized && err == nil {
@@ -412,12 +441,16 @@ func (czero *Cluster) PrintInstallationComplete() error {
 	}
 	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
 	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
-	pw, err := os.ReadFile(pwFile)
+	pw, err := encryption.ReadFile(pwFile)
 	if err != nil {
 		return err
 	}
 	logrus.Info("Install complete!")
-	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run\n    export KUBECONFIG=%s", kubeconfig)
+	if encryption.Enabled() {
+		logrus.Infof("The kubeconfig and the kubeadmin password are encrypted, run\n    openshift-install assets decrypt --dir %s auth/kubeconfig > kubeconfig\nto get the kubeconfig for 'oc'", czero.assetDir)
+	} else {
+		logrus.Infof("To access the cluster as the system:admin user when using 'oc', run\n    export KUBECONFIG=%s", kubeconfig)
+	}
 	logrus.Infof("Access the OpenShift web-console here: %s", czero.clusterConsoleRouteURL)
 	logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
 	return nil
@@ -0,0 +1,200 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/installer/pkg/metrics/progress"
+)
+
+// HostStage is the stage of the installation a host has reached.
+type HostStage string
+
+const (
+	// HostStageDiscovered is reached when the host registered with the
+	// assisted-service on the rendezvous host.
+	HostStageDiscovered HostStage = "discovered"
+	// HostStageValidated is reached when the host passed its validations.
+	HostStageValidated HostStage = "validated"
+	// HostStageInstalling is reached when the host writes the image to its
+	// disk.
+	HostStageInstalling HostStage = "installing"
+	// HostStageRebooted is reached when the host rebooted from its disk.
+	HostStageRebooted HostStage = "rebooted"
+	// HostStageJoined is reached when the host joined the cluster as a ready
+	// node.
+	HostStageJoined HostStage = "joined"
+	// HostStageFailed is reached when the installation of the host failed.
+	HostStageFailed HostStage = "failed"
+
+	// hostProgressStage is the progress event stage of the host events.
+	hostProgressStage = "Hosts"
+)
+
+// hostProgress is the installation progress of a host.
+type hostProgress struct {
+	Name              string
+	Role              string
+	Stage             HostStage
+	InstallationStage string
+	Percentage        int64
+}
+
+// hostProgressTracker follows the hosts through the installation. The hosts
+// are followed through the assisted-service API while it is served by the
+// rendezvous host, and through the nodes of the cluster once the rendezvous
+// host rebooted, so the progress of the hosts is not lost across the reboot.
+type hostProgressTracker struct {
+	hosts map[string]*hostProgress
+}
+
+func newHostProgressTracker() *hostProgressTracker {
+	return &hostProgressTracker{hosts: map[string]*hostProgress{}}
+}
+
+// hostStage returns the stage the host reported by the assisted-service has
+// reached.
+func hostStage(host *models.Host) HostStage {
+	status := ""
+	if host.Status != nil {
+		status = *host.Status
+	}
+	var installationStage models.HostStage
+	if host.Progress != nil {
+		installationStage = host.Progress.CurrentStage
+	}
+
+	switch status {
+	case models.HostStatusError, models.HostStatusCancelled, models.HostStatusPreparingFailed:
+		return HostStageFailed
+	case models.HostStatusInstalled, models.HostStatusAddedToExistingCluster:
+		return HostStageJoined
+	case models.HostStatusKnown, models.HostStatusPreparingForInstallation, models.HostStatusPreparingSuccessful:
+		return HostStageValidated
+	case models.HostStatusInstalling, models.HostStatusInstallingInProgress, models.HostStatusInstallingPendingUserAction:
+		switch installationStage {
+		case models.HostStageJoined, models.HostStageDone:
+			return HostStageJoined
+		case models.HostStageWaitingForIgnition, models.HostStageConfiguring:
+			return HostStageRebooted
+		case models.HostStageFailed:
+			return HostStageFailed
+		default:
+			return HostStageInstalling
+		}
+	default:
+		return HostStageDiscovered
+	}
+}
+
+// updateFromHosts records the progress of the hosts reported by the
+// assisted-service, and returns the hosts whose progress changed.
+func (t *hostProgressTracker) updateFromHosts(hosts []*models.Host) []hostProgress {
+	changed := []hostProgress{}
+	for _, host := range hosts {
+		name := host.RequestedHostname
+		if name == "" && host.ID != nil {
+			name = host.ID.String()
+		}
+		if name == "" {
+			continue
+		}
+		current := hostProgress{
+			Name:  name,
+			Role:  string(host.Role),
+			Stage: hostStage(host),
+		}
+		if host.Progress != nil {
+			current.InstallationStage = string(host.Progress.CurrentStage)
+			current.Percentage = host.Progress.InstallationPercentage
+		}
+		if current.Stage == HostStageJoined {
+			current.Percentage = 100
+		}
+		if t.update(current) {
+			changed = append(changed, current)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+	return changed
+}
+
+// updateFromNodes records the hosts which joined the cluster as ready nodes,
+// and returns the hosts whose progress changed.
+func (t *hostProgressTracker) updateFromNodes(nodes []corev1.Node) []hostProgress {
+	changed := []hostProgress{}
+	for _, node := range nodes {
+		if !isNodeReady(node) {
+			continue
+		}
+		current := hostProgress{
+			Name:       node.Name,
+			Stage:      HostStageJoined,
+			Percentage: 100,
+		}
+		if previous, ok := t.hosts[node.Name]; ok {
+			current.Role = previous.Role
+			current.InstallationStage = previous.InstallationStage
+		} else if _, ok := node.Labels["node-role.kubernetes.io/master"]; ok {
+			current.Role = string(models.HostRoleMaster)
+		} else {
+			current.Role = string(models.HostRoleWorker)
+		}
+		if t.update(current) {
+			changed = append(changed, current)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+	return changed
+}
+
+// update records the progress of the host, and returns whether it changed.
+// A host which joined the cluster stays joined, as the assisted-service may
+// still report an earlier stage of the host until it notices the node.
+func (t *hostProgressTracker) update(current hostProgress) bool {
+	previous, ok := t.hosts[current.Name]
+	if ok {
+		if *previous == current {
+			return false
+		}
+		if previous.Stage == HostStageJoined {
+			return false
+		}
+	}
+	t.hosts[current.Name] = &current
+	return true
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// reportHostProgress logs the progress of the hosts, and emits it as progress
+// events.
+func reportHostProgress(hosts []hostProgress) {
+	for _, host := range hosts {
+		message := ""
+		if host.InstallationStage != "" && host.Stage != HostStageJoined {
+			message = fmt.Sprintf("%s (%d%%)", host.InstallationStage, host.Percentage)
+		}
+		if message == "" {
+			logrus.Infof("Host %s (%s): %s", host.Name, host.Role, host.Stage)
+		} else {
+			logrus.Infof("Host %s (%s): %s, %s", host.Name, host.Role, host.Stage, message)
+		}
+		progress.HostProgress(hostProgressStage, progress.Host{
+			Name:  host.Name,
+			Role:  host.Role,
+			Stage: string(host.Stage),
+		}, int(host.Percentage), message)
+	}
+}
@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/assisted-service/models"
+)
+
+func testHost(name string, status string, stage models.HostStage) *models.Host {
+	return &models.Host{
+		RequestedHostname: name,
+		Role:              models.HostRoleMaster,
+		Status:            &status,
+		Progress:          &models.HostProgressInfo{CurrentStage: stage, InstallationPercentage: 42},
+	}
+}
+
+func TestHostStage(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     *models.Host
+		expected HostStage
+	}{
+		{
+			name:     "discovering",
+			host:     testHost("master-0", models.HostStatusDiscovering, ""),
+			expected: HostStageDiscovered,
+		},
+		{
+			name:     "insufficient",
+			host:     testHost("master-0", models.HostStatusInsufficient, ""),
+			expected: HostStageDiscovered,
+		},
+		{
+			name:     "known",
+			host:     testHost("master-0", models.HostStatusKnown, ""),
+			expected: HostStageValidated,
+		},
+		{
+			name:     "writing image to disk",
+			host:     testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageWritingImageToDisk),
+			expected: HostStageInstalling,
+		},
+		{
+			name:     "rebooting",
+			host:     testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageRebooting),
+			expected: HostStageInstalling,
+		},
+		{
+			name:     "configuring",
+			host:     testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageConfiguring),
+			expected: HostStageRebooted,
+		},
+		{
+			name:     "joined",
+			host:     testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageJoined),
+			expected: HostStageJoined,
+		},
+		{
+			name:     "installed",
+			host:     testHost("master-0", models.HostStatusInstalled, models.HostStageDone),
+			expected: HostStageJoined,
+		},
+		{
+			name:     "error",
+			host:     testHost("master-0", models.HostStatusError, models.HostStageWritingImageToDisk),
+			expected: HostStageFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hostStage(tc.host))
+		})
+	}
+}
+
+func TestHostProgressTracker(t *testing.T) {
+	tracker := newHostProgressTracker()
+
+	changed := tracker.updateFromHosts([]*models.Host{
+		testHost("master-1", models.HostStatusKnown, ""),
+		testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageWritingImageToDisk),
+	})
+	assert.Equal(t, []hostProgress{
+		{Name: "master-0", Role: "master", Stage: HostStageInstalling, InstallationStage: "Writing image to disk", Percentage: 42},
+		{Name: "master-1", Role: "master", Stage: HostStageValidated, Percentage: 42},
+	}, changed)
+
+	// Unchanged hosts are not reported again.
+	changed = tracker.updateFromHosts([]*models.Host{
+		testHost("master-1", models.HostStatusKnown, ""),
+		testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageConfiguring),
+	})
+	assert.Equal(t, []hostProgress{
+		{Name: "master-0", Role: "master", Stage: HostStageRebooted, InstallationStage: "Configuring", Percentage: 42},
+	}, changed)
+
+	ready := corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}}
+	notReady := corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}}
+	changed = tracker.updateFromNodes([]corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}, Status: ready},
+		{ObjectMeta: metav1.ObjectMeta{Name: "master-1"}, Status: notReady},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}, Status: ready},
+	})
+	assert.Equal(t, []hostProgress{
+		{Name: "master-0", Role: "master", Stage: HostStageJoined, InstallationStage: "Configuring", Percentage: 100},
+		{Name: "worker-0", Role: "worker", Stage: HostStageJoined, Percentage: 100},
+	}, changed)
+
+	// Joined hosts stay joined.
+	changed = tracker.updateFromHosts([]*models.Host{
+		testHost("master-0", models.HostStatusInstallingInProgress, models.HostStageConfiguring),
+	})
+	assert.Empty(t, changed)
+}
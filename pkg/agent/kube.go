@@ -6,10 +6,12 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+
+	assetkubeconfig "github.com/openshift/installer/pkg/asset/kubeconfig"
 )
 
 // ClusterKubeAPIClient is a kube client to interact with the cluster that agent installer is installing.
@@ -26,7 +28,7 @@ func NewClusterKubeAPIClient(ctx context.Context, assetDir string) (*ClusterKube
 	kubeClient := &ClusterKubeAPIClient{}
 
 	kubeconfigpath := filepath.Join(assetDir, "auth", "kubeconfig")
-	kubeconfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigpath)
+	kubeconfig, err := assetkubeconfig.RESTConfigFromFile(kubeconfigpath)
 	if err != nil {
 		return nil, errors.Wrap(err, "error loading kubeconfig from assets")
 	}
@@ -59,7 +61,7 @@ func (kube *ClusterKubeAPIClient) IsKubeAPILive() (bool, error) {
 // DoesKubeConfigExist Determine if the kubeconfig for the cluster can be used without errors.
 func (kube *ClusterKubeAPIClient) DoesKubeConfigExist() (bool, error) {
 
-	_, err := clientcmd.LoadFromFile(kube.configPath)
+	_, err := assetkubeconfig.LoadFile(kube.configPath)
 	if err != nil {
 		return false, errors.Wrap(err, "error loading kubeconfig from file")
 	}
@@ -89,3 +91,12 @@ func (kube *ClusterKubeAPIClient) IsBootstrapConfigMapComplete() (bool, error) {
 	}
 	return false, nil
 }
+
+// ListNodes Returns the nodes which have joined the cluster under install.
+func (kube *ClusterKubeAPIClient) ListNodes() (*corev1.NodeList, error) {
+	nodes, err := kube.Client.CoreV1().Nodes().List(kube.ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list the cluster nodes")
+	}
+	return nodes, nil
+}
@@ -8,13 +8,14 @@ import (
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 
 	configv1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	"github.com/openshift/library-go/pkg/route/routeapihelpers"
+
+	assetkubeconfig "github.com/openshift/installer/pkg/asset/kubeconfig"
 )
 
 // ClusterOpenShiftAPIClient Kube client using the OpenShift clientset instead of the Kubernetes clientset
@@ -39,7 +40,7 @@ func NewClusterOpenShiftAPIClient(ctx context.Context, assetDir string) (*Cluste
 	ocpClient := &ClusterOpenShiftAPIClient{}
 
 	kubeconfigpath := filepath.Join(assetDir, "auth", "kubeconfig")
-	kubeconfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigpath)
+	kubeconfig, err := assetkubeconfig.RESTConfigFromFile(kubeconfigpath)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating kubeconfig for ocp config client")
 	}
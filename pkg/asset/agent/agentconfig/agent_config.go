@@ -172,6 +172,26 @@ func (a *AgentConfig) validateAgent() field.ErrorList {
 		allErrs = append(allErrs, err...)
 	}
 
+	if err := a.validateBootArtifactsBaseURL(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	return allErrs
+}
+
+func (a *AgentConfig) validateBootArtifactsBaseURL() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if a.Config.BootArtifactsBaseURL == "" {
+		return nil
+	}
+
+	httpErr := validate.URIWithProtocol(a.Config.BootArtifactsBaseURL, "http")
+	httpsErr := validate.URIWithProtocol(a.Config.BootArtifactsBaseURL, "https")
+	if httpErr != nil && httpsErr != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("bootArtifactsBaseURL"), a.Config.BootArtifactsBaseURL, "must be an http or https URL"))
+	}
+
 	return allErrs
 }
 
@@ -332,6 +332,17 @@ rendezvousIP: 192.168.111.80`,
 			expectedFound: false,
 			expectedError: "invalid Agent Config configuration: AdditionalNTPSources[4]: Invalid value: \"invalid_pool.ntp.org\": NTP source is not a valid domain name nor a valid IP",
 		},
+		{
+			name: "invalid-bootArtifactsBaseURL",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+bootArtifactsBaseURL: ftp://example.org/pxe`,
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: bootArtifactsBaseURL: Invalid value: \"ftp://example.org/pxe\": must be an http or https URL",
+		},
 		{
 			name: "valid-rendezvousIPAssignedToMaster",
 			data: `
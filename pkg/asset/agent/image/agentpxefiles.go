@@ -7,11 +7,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/assisted-image-service/pkg/isoeditor"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 )
 
 const (
@@ -20,13 +22,19 @@ const (
 	vmlinuz   = "vmlinuz"
 	// pxeAssetsPath is the path where pxe files are created.
 	pxeAssetsPath = "pxe"
+	// pxeKernelArgs are the kernel arguments of the live system booted by PXE.
+	pxeKernelArgs = "rw ignition.firstboot ignition.platform.id=metal"
 )
 
-// AgentPXEFiles is an asset that generates the bootable image used to install clusters.
+// AgentPXEFiles is an asset that generates the kernel, initrd, rootfs and
+// iPXE script used to install clusters by PXE boot, from the same assets as
+// the agent ISO. The ignition of the ISO, with the configuration of every
+// host, is appended to the initrd.
 type AgentPXEFiles struct {
 	imageReader isoeditor.ImageReader
 	cpuArch     string
 	isoPath     string
+	baseURL     string
 }
 
 var _ asset.WritableAsset = (*AgentPXEFiles)(nil)
@@ -36,6 +44,7 @@ func (a *AgentPXEFiles) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&Ignition{},
 		&BaseIso{},
+		&agentconfig.AgentConfig{},
 	}
 }
 
@@ -45,7 +54,8 @@ func (a *AgentPXEFiles) Generate(dependencies asset.Parents) error {
 	dependencies.Get(ignition)
 
 	baseImage := &BaseIso{}
-	dependencies.Get(baseImage)
+	agentConfig := &agentconfig.AgentConfig{}
+	dependencies.Get(baseImage, agentConfig)
 
 	a.isoPath = baseImage.File.Filename
 
@@ -76,6 +86,9 @@ func (a *AgentPXEFiles) Generate(dependencies asset.Parents) error {
 
 	a.imageReader = custom
 	a.cpuArch = ignition.CPUArch
+	if agentConfig.Config != nil {
+		a.baseURL = agentConfig.Config.BootArtifactsBaseURL
+	}
 
 	return nil
 }
@@ -118,6 +131,13 @@ func (a *AgentPXEFiles) PersistToFile(directory string) error {
 		return err
 	}
 
+	ipxeFile := filepath.Join(pxeAssetsFullPath, fmt.Sprintf("agent.%s.ipxe", a.cpuArch))
+	script := ipxeScript(a.baseURL, filepath.Base(agentVmlinuzFile), filepath.Base(agentInitrdFile), filepath.Base(agentRootfsimgFile))
+	err = os.WriteFile(ipxeFile, []byte(script), 0644) //nolint:gosec // no sensitive info
+	if err != nil {
+		return err
+	}
+
 	logrus.Infof("PXE-files created in: %s", pxeAssetsFullPath)
 
 	return nil
@@ -141,6 +161,28 @@ func (a *AgentPXEFiles) Files() []*asset.File {
 	return []*asset.File{}
 }
 
+// ipxeScript returns the iPXE script booting the kernel, initrd and rootfs
+// files served from the base URL. When the base URL is empty, the files are
+// loaded relative to the URL of the script, and the rootfs is loaded as a
+// second initrd since the live system cannot fetch it from a relative URL.
+func ipxeScript(baseURL, kernel, initrd, rootfs string) string {
+	if baseURL == "" {
+		return fmt.Sprintf(`#!ipxe
+initrd --name initrd %s
+initrd --name rootfs %s
+kernel %s initrd=initrd initrd=rootfs %s
+boot
+`, initrd, rootfs, kernel, pxeKernelArgs)
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf(`#!ipxe
+initrd --name initrd %[1]s/%[2]s
+kernel %[1]s/%[3]s initrd=initrd coreos.live.rootfs_url=%[1]s/%[4]s %[5]s
+boot
+`, baseURL, initrd, kernel, rootfs, pxeKernelArgs)
+}
+
 func (a *AgentPXEFiles) extractPXEFileFromISO(isoPath string, srcfilename string, dstfilename string) error {
 	fileReader, err := isoeditor.GetFileFromISO(isoPath, srcfilename)
 	if err != nil {
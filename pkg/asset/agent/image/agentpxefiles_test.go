@@ -0,0 +1,39 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPXEScript(t *testing.T) {
+	cases := []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{
+			name: "relative to the script",
+			expected: `#!ipxe
+initrd --name initrd agent-initrd.x86_64.img
+initrd --name rootfs agent-rootfs.x86_64.img
+kernel agent-vmlinuz.x86_64 initrd=initrd initrd=rootfs rw ignition.firstboot ignition.platform.id=metal
+boot
+`,
+		},
+		{
+			name:    "base URL",
+			baseURL: "http://pxe.example.org/agent/",
+			expected: `#!ipxe
+initrd --name initrd http://pxe.example.org/agent/agent-initrd.x86_64.img
+kernel http://pxe.example.org/agent/agent-vmlinuz.x86_64 initrd=initrd coreos.live.rootfs_url=http://pxe.example.org/agent/agent-rootfs.x86_64.img rw ignition.firstboot ignition.platform.id=metal
+boot
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ipxeScript(tc.baseURL, "agent-vmlinuz.x86_64", "agent-initrd.x86_64.img", "agent-rootfs.x86_64.img"))
+		})
+	}
+}
@@ -38,7 +38,7 @@ func (i *BaseIso) Name() string {
 }
 
 // getIsoFile is a pluggable function that gets the base ISO file
-type getIsoFile func(archName string) (string, error)
+type getIsoFile func(archName string, coreOSStream *types.CoreOSStream) (string, error)
 
 type getIso struct {
 	getter getIsoFile
@@ -52,12 +52,12 @@ func newGetIso(getter getIsoFile) *getIso {
 var GetIsoPluggable = downloadIso
 
 // Download the ISO using the URL in rhcos.json
-func downloadIso(archName string) (string, error) {
+func downloadIso(archName string, coreOSStream *types.CoreOSStream) (string, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
 	// Get the ISO to use from rhcos.json
-	st, err := rhcos.FetchCoreOSBuild(ctx)
+	st, err := rhcos.FetchCoreOSStream(ctx, coreOSStream)
 	if err != nil {
 		return "", err
 	}
@@ -139,9 +139,16 @@ func (i *BaseIso) Generate(dependencies asset.Parents) error {
 		}
 	}
 
+	installConfig := &agent.OptionalInstallConfig{}
+	dependencies.Get(installConfig)
+	var coreOSStream *types.CoreOSStream
+	if installConfig.Supplied {
+		coreOSStream = installConfig.Config.CoreOSStream
+	}
+
 	logrus.Info("Downloading base ISO")
 	isoGetter := newGetIso(GetIsoPluggable)
-	baseIsoFileName, err2 := isoGetter.getter(archName)
+	baseIsoFileName, err2 := isoGetter.getter(archName, coreOSStream)
 	if err2 == nil {
 		logrus.Debugf("Using base ISO image %s", baseIsoFileName)
 		i.File = &asset.File{Filename: baseIsoFileName}
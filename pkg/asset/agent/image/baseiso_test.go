@@ -8,11 +8,12 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/agent"
 	"github.com/openshift/installer/pkg/asset/agent/manifests"
+	"github.com/openshift/installer/pkg/types"
 )
 
 func TestInfraBaseIso_Generate(t *testing.T) {
 
-	GetIsoPluggable = func(archName string) (string, error) {
+	GetIsoPluggable = func(archName string, coreOSStream *types.CoreOSStream) (string, error) {
 		return "some-openshift-release.iso", nil
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/openshift/assisted-service/api/v1beta1"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/installer/pkg/asset"
+	agentasset "github.com/openshift/installer/pkg/asset/agent"
 	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/agent/manifests"
 	"github.com/openshift/installer/pkg/asset/agent/mirror"
@@ -86,6 +87,7 @@ func (a *Ignition) Dependencies() []asset.Asset {
 		&agentconfig.AgentConfig{},
 		&mirror.RegistriesConf{},
 		&mirror.CaBundle{},
+		&agentasset.OptionalInstallConfig{},
 	}
 }
 
@@ -149,7 +151,14 @@ func (a *Ignition) Generate(dependencies asset.Parents) error {
 	infraEnvID := uuid.New().String()
 	logrus.Debug("Generated random infra-env id ", infraEnvID)
 
-	osImage, err := getOSImagesInfo(archName)
+	installConfig := &agentasset.OptionalInstallConfig{}
+	dependencies.Get(installConfig)
+	var coreOSStream *types.CoreOSStream
+	if installConfig.Supplied {
+		coreOSStream = installConfig.Config.CoreOSStream
+	}
+
+	osImage, err := getOSImagesInfo(archName, coreOSStream)
 	if err != nil {
 		return err
 	}
@@ -437,8 +446,8 @@ func addExtraManifests(config *igntypes.Config, extraManifests *manifests.ExtraM
 	return nil
 }
 
-func getOSImagesInfo(cpuArch string) (*models.OsImage, error) {
-	st, err := rhcos.FetchCoreOSBuild(context.Background())
+func getOSImagesInfo(cpuArch string, coreOSStream *types.CoreOSStream) (*models.OsImage, error) {
+	st, err := rhcos.FetchCoreOSStream(context.Background(), coreOSStream)
 	if err != nil {
 		return nil, err
 	}
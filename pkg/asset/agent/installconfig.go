@@ -77,6 +77,10 @@ func (a *OptionalInstallConfig) validateInstallConfig(installConfig *types.Insta
 
 	warnUnusedConfig(installConfig)
 
+	for _, warning := range validation.NetworkingWarnings(installConfig.Networking) {
+		logrus.Warnf("%s: %s", warning.Field, warning.Detail)
+	}
+
 	if err := a.validateSNOConfiguration(installConfig); err != nil {
 		allErrs = append(allErrs, err...)
 	}
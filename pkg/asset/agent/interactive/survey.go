@@ -0,0 +1,423 @@
+// Package interactive prompts the user for the configuration of an agent
+// based installation and writes it to the asset directory.
+package interactive
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	terminal "golang.org/x/term"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/agent"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/none"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+const (
+	installConfigFilename = "install-config.yaml"
+	agentConfigFilename   = "agent-config.yaml"
+
+	defaultMachineNetwork = "10.0.0.0/16"
+)
+
+// clusterConfig holds the answers of the survey.
+type clusterConfig struct {
+	ClusterName    string
+	BaseDomain     string
+	PullSecret     string
+	SSHKey         string
+	MachineNetwork string
+	ControlPlanes  int64
+	Workers        int64
+	APIVIP         string
+	IngressVIP     string
+	RendezvousIP   string
+	Hosts          []agent.Host
+}
+
+// hostAnswers holds the answers of the survey for a single host.
+type hostAnswers struct {
+	Hostname   string
+	Interface  string
+	MACAddress string
+}
+
+// Survey asks the user for the cluster configuration and the host inventory
+// and writes install-config.yaml and agent-config.yaml to the directory.
+// Configuration files which already exist in the directory are kept, and the
+// survey is skipped when both of them exist.
+func Survey(directory string) error {
+	installConfigPath := filepath.Join(directory, installConfigFilename)
+	agentConfigPath := filepath.Join(directory, agentConfigFilename)
+	installConfigExists, err := fileExists(installConfigPath)
+	if err != nil {
+		return err
+	}
+	agentConfigExists, err := fileExists(agentConfigPath)
+	if err != nil {
+		return err
+	}
+	if installConfigExists && agentConfigExists {
+		logrus.Infof("Using the existing %s and %s", installConfigFilename, agentConfigFilename)
+		return nil
+	}
+
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("the interactive survey requires a terminal")
+	}
+
+	config := &clusterConfig{}
+	if !installConfigExists {
+		if err := askCluster(config); err != nil {
+			return err
+		}
+	} else {
+		logrus.Infof("Using the existing %s", installConfigFilename)
+		if err := config.loadTopology(installConfigPath); err != nil {
+			return err
+		}
+	}
+	if !agentConfigExists {
+		if err := askHosts(config); err != nil {
+			return err
+		}
+	} else {
+		logrus.Infof("Using the existing %s", agentConfigFilename)
+	}
+
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", directory)
+	}
+	if !installConfigExists {
+		if err := writeConfig(installConfigPath, config.installConfig()); err != nil {
+			return err
+		}
+	}
+	if !agentConfigExists {
+		if err := writeConfig(agentConfigPath, config.agentConfig()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeConfig writes the configuration as YAML to the path.
+func writeConfig(path string, config interface{}) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", filepath.Base(path))
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+	logrus.Infof("Wrote %s", path)
+	return nil
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "failed to stat %s", path)
+}
+
+// askCluster asks for the settings of the install config.
+func askCluster(config *clusterConfig) error {
+	if err := survey.Ask([]*survey.Question{
+		{
+			Name: "BaseDomain",
+			Prompt: &survey.Input{
+				Message: "Base Domain",
+				Help:    "The base domain of the cluster. All DNS records will be sub-domains of this base and will also include the cluster name.",
+			},
+			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+				return validate.DomainName(ans.(string), true)
+			}),
+		},
+		{
+			Name: "ClusterName",
+			Prompt: &survey.Input{
+				Message: "Cluster Name",
+				Help:    "The name of the cluster. This will be used when generating sub-domains.",
+			},
+			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+				return validate.OnPremClusterName(ans.(string))
+			}),
+		},
+		{
+			Name: "PullSecret",
+			Prompt: &survey.Password{
+				Message: "Pull Secret",
+				Help:    "The container registry pull secret for this cluster, as a single line of JSON (e.g. {\"auths\": {...}}).\n\nYou can get this secret from https://console.redhat.com/openshift/install/pull-secret",
+			},
+			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+				return validate.ImagePullSecret(ans.(string))
+			}),
+		},
+		{
+			Name: "SSHKey",
+			Prompt: &survey.Input{
+				Message: "SSH Public Key",
+				Help:    "The SSH public key used to access the hosts. Leave empty to not configure one.",
+			},
+			Validate: func(ans interface{}) error {
+				if ans.(string) == "" {
+					return nil
+				}
+				return validate.SSHPublicKey(ans.(string))
+			},
+		},
+		{
+			Name: "MachineNetwork",
+			Prompt: &survey.Input{
+				Message: "Machine Network",
+				Help:    "The CIDR of the network the hosts are connected to.",
+				Default: defaultMachineNetwork,
+			},
+			Validate: func(ans interface{}) error {
+				_, err := ipnet.ParseCIDR(ans.(string))
+				return err
+			},
+		},
+	}, config); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+
+	if err := askTopology(config); err != nil {
+		return err
+	}
+	if config.ControlPlanes == 1 && config.Workers == 0 {
+		return nil
+	}
+
+	machineNetwork := ipnet.MustParseCIDR(config.MachineNetwork)
+	vipValidator := survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+		if err := validate.IP(ans.(string)); err != nil {
+			return err
+		}
+		if !machineNetwork.Contains(net.ParseIP(ans.(string))) {
+			return errors.Errorf("%s is not in the machine network %s", ans, config.MachineNetwork)
+		}
+		return nil
+	})
+	if err := survey.AskOne(&survey.Input{
+		Message: "API VIP",
+		Help:    "The virtual IP address of the Kubernetes API, in the machine network.",
+	}, &config.APIVIP, survey.WithValidator(vipValidator)); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	if err := survey.AskOne(&survey.Input{
+		Message: "Ingress VIP",
+		Help:    "The virtual IP address of the ingress, in the machine network.",
+	}, &config.IngressVIP, survey.WithValidator(survey.ComposeValidators(vipValidator, func(ans interface{}) error {
+		if ans.(string) == config.APIVIP {
+			return errors.New("the ingress VIP must be different from the API VIP")
+		}
+		return nil
+	}))); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	return nil
+}
+
+// askTopology asks for the number of control plane and compute hosts.
+func askTopology(config *clusterConfig) error {
+	var controlPlanes string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Control Plane Hosts",
+		Help:    "The number of control plane hosts. A single control plane host without compute hosts installs a single node cluster.",
+		Options: []string{"1", "3"},
+		Default: "3",
+	}, &controlPlanes); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	config.ControlPlanes, _ = strconv.ParseInt(controlPlanes, 10, 64)
+
+	var workers string
+	if err := survey.AskOne(&survey.Input{
+		Message: "Compute Hosts",
+		Help:    "The number of compute hosts.",
+		Default: "0",
+	}, &workers, survey.WithValidator(func(ans interface{}) error {
+		count, err := strconv.ParseInt(ans.(string), 10, 64)
+		if err != nil || count < 0 {
+			return errors.Errorf("%q is not a valid number of hosts", ans)
+		}
+		return nil
+	})); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	config.Workers, _ = strconv.ParseInt(workers, 10, 64)
+	return nil
+}
+
+// loadTopology reads the cluster name and the number of control plane and
+// compute hosts from an existing install config.
+func (c *clusterConfig) loadTopology(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+	installConfig := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, installConfig); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+
+	c.ClusterName = installConfig.ObjectMeta.Name
+	c.ControlPlanes = 3
+	if installConfig.ControlPlane != nil && installConfig.ControlPlane.Replicas != nil {
+		c.ControlPlanes = *installConfig.ControlPlane.Replicas
+	}
+	for _, pool := range installConfig.Compute {
+		if pool.Replicas != nil {
+			c.Workers += *pool.Replicas
+		}
+	}
+	return nil
+}
+
+// askHosts asks for the inventory of the hosts and the rendezvous IP.
+func askHosts(config *clusterConfig) error {
+	macs := map[string]bool{}
+	total := config.ControlPlanes + config.Workers
+	for i := int64(0); i < total; i++ {
+		role := "master"
+		if i >= config.ControlPlanes {
+			role = "worker"
+		}
+		answers := hostAnswers{}
+		if err := survey.Ask([]*survey.Question{
+			{
+				Name: "Hostname",
+				Prompt: &survey.Input{
+					Message: fmt.Sprintf("Host %d of %d (%s): Hostname", i+1, total, role),
+					Help:    "The hostname of the host. Leave empty to use the hostname provided by DHCP.",
+				},
+				Validate: func(ans interface{}) error {
+					if ans.(string) == "" {
+						return nil
+					}
+					return validate.DomainName(ans.(string), false)
+				},
+			},
+			{
+				Name: "Interface",
+				Prompt: &survey.Input{
+					Message: fmt.Sprintf("Host %d of %d (%s): Interface", i+1, total, role),
+					Help:    "The name of the network interface connected to the machine network.",
+					Default: "eth0",
+				},
+				Validate: survey.Required,
+			},
+			{
+				Name: "MACAddress",
+				Prompt: &survey.Input{
+					Message: fmt.Sprintf("Host %d of %d (%s): MAC Address", i+1, total, role),
+					Help:    "The MAC address of the interface, used to identify the host when it boots the image.",
+				},
+				Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+					if err := validate.MAC(ans.(string)); err != nil {
+						return err
+					}
+					if macs[ans.(string)] {
+						return errors.Errorf("MAC address %s is already used by another host", ans)
+					}
+					return nil
+				}),
+			},
+		}, &answers); err != nil {
+			return errors.Wrap(err, "failed UserInput")
+		}
+		macs[answers.MACAddress] = true
+		config.Hosts = append(config.Hosts, agent.Host{
+			Hostname: answers.Hostname,
+			Role:     role,
+			Interfaces: []*aiv1beta1.Interface{
+				{Name: answers.Interface, MacAddress: answers.MACAddress},
+			},
+		})
+	}
+
+	if err := survey.AskOne(&survey.Input{
+		Message: "Rendezvous IP",
+		Help:    "The IP address of the first control plane host. The host with this address runs the installation service which the other hosts register with.",
+	}, &config.RendezvousIP, survey.WithValidator(survey.ComposeValidators(survey.Required, func(ans interface{}) error {
+		return validate.IP(ans.(string))
+	}))); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	return nil
+}
+
+// installConfig returns the install config built from the answers.
+func (c *clusterConfig) installConfig() *types.InstallConfig {
+	installConfig := &types.InstallConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: types.InstallConfigVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: c.ClusterName,
+		},
+		BaseDomain: c.BaseDomain,
+		PullSecret: c.PullSecret,
+		SSHKey:     c.SSHKey,
+		Networking: &types.Networking{
+			NetworkType: "OVNKubernetes",
+			MachineNetwork: []types.MachineNetworkEntry{
+				{CIDR: *ipnet.MustParseCIDR(c.MachineNetwork)},
+			},
+		},
+		ControlPlane: &types.MachinePool{
+			Name:     "master",
+			Replicas: pointer.Int64Ptr(c.ControlPlanes),
+		},
+		Compute: []types.MachinePool{
+			{
+				Name:     "worker",
+				Replicas: pointer.Int64Ptr(c.Workers),
+			},
+		},
+	}
+	if c.ControlPlanes == 1 && c.Workers == 0 {
+		installConfig.Platform.None = &none.Platform{}
+	} else {
+		installConfig.Platform.BareMetal = &baremetal.Platform{
+			APIVIPs:     []string{c.APIVIP},
+			IngressVIPs: []string{c.IngressVIP},
+		}
+	}
+	return installConfig
+}
+
+// agentConfig returns the agent config built from the answers. The hosts get
+// their addresses from DHCP.
+func (c *clusterConfig) agentConfig() *agent.Config {
+	return &agent.Config{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: agent.AgentConfigVersion,
+			Kind:       "AgentConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: c.ClusterName,
+		},
+		RendezvousIP: c.RendezvousIP,
+		Hosts:        c.Hosts,
+	}
+}
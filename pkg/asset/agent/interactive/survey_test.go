@@ -0,0 +1,153 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/agent"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestInstallConfig(t *testing.T) {
+	cases := []struct {
+		name             string
+		controlPlanes    int64
+		workers          int64
+		expectedPlatform types.Platform
+	}{
+		{
+			name:             "single node",
+			controlPlanes:    1,
+			expectedPlatform: types.Platform{None: &none.Platform{}},
+		},
+		{
+			name:          "compact",
+			controlPlanes: 3,
+			expectedPlatform: types.Platform{BareMetal: &baremetal.Platform{
+				APIVIPs:     []string{"10.0.0.5"},
+				IngressVIPs: []string{"10.0.0.6"},
+			}},
+		},
+		{
+			name:          "single control plane with compute hosts",
+			controlPlanes: 1,
+			workers:       2,
+			expectedPlatform: types.Platform{BareMetal: &baremetal.Platform{
+				APIVIPs:     []string{"10.0.0.5"},
+				IngressVIPs: []string{"10.0.0.6"},
+			}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &clusterConfig{
+				ClusterName:    "ostest",
+				BaseDomain:     "example.com",
+				PullSecret:     `{"auths":{"example.com":{"auth":"c3VwZXItc2VjcmV0Cg=="}}}`,
+				SSHKey:         "ssh-ed25519 AAAA",
+				MachineNetwork: "10.0.0.0/16",
+				ControlPlanes:  tc.controlPlanes,
+				Workers:        tc.workers,
+				APIVIP:         "10.0.0.5",
+				IngressVIP:     "10.0.0.6",
+			}
+			expected := &types.InstallConfig{
+				TypeMeta:   metav1.TypeMeta{APIVersion: types.InstallConfigVersion},
+				ObjectMeta: metav1.ObjectMeta{Name: "ostest"},
+				BaseDomain: "example.com",
+				PullSecret: `{"auths":{"example.com":{"auth":"c3VwZXItc2VjcmV0Cg=="}}}`,
+				SSHKey:     "ssh-ed25519 AAAA",
+				Networking: &types.Networking{
+					NetworkType:    "OVNKubernetes",
+					MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+				},
+				ControlPlane: &types.MachinePool{Name: "master", Replicas: pointer.Int64Ptr(tc.controlPlanes)},
+				Compute:      []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(tc.workers)}},
+				Platform:     tc.expectedPlatform,
+			}
+			assert.Equal(t, expected, config.installConfig())
+		})
+	}
+}
+
+func TestAgentConfig(t *testing.T) {
+	hosts := []agent.Host{
+		{
+			Hostname:   "master-0",
+			Role:       "master",
+			Interfaces: []*aiv1beta1.Interface{{Name: "eth0", MacAddress: "52:54:00:00:00:01"}},
+		},
+		{
+			Role:       "worker",
+			Interfaces: []*aiv1beta1.Interface{{Name: "eth0", MacAddress: "52:54:00:00:00:02"}},
+		},
+	}
+	config := &clusterConfig{
+		ClusterName:  "ostest",
+		RendezvousIP: "10.0.0.10",
+		Hosts:        hosts,
+	}
+	expected := &agent.Config{
+		TypeMeta:     metav1.TypeMeta{APIVersion: agent.AgentConfigVersion, Kind: "AgentConfig"},
+		ObjectMeta:   metav1.ObjectMeta{Name: "ostest"},
+		RendezvousIP: "10.0.0.10",
+		Hosts:        hosts,
+	}
+	assert.Equal(t, expected, config.agentConfig())
+}
+
+func TestLoadTopology(t *testing.T) {
+	cases := []struct {
+		name                  string
+		installConfig         string
+		expectedControlPlanes int64
+		expectedWorkers       int64
+	}{
+		{
+			name: "replicas",
+			installConfig: `metadata:
+  name: ostest
+controlPlane:
+  name: master
+  replicas: 1
+compute:
+- name: worker
+  replicas: 2
+`,
+			expectedControlPlanes: 1,
+			expectedWorkers:       2,
+		},
+		{
+			name: "default control plane replicas",
+			installConfig: `metadata:
+  name: ostest
+compute:
+- name: worker
+  replicas: 0
+`,
+			expectedControlPlanes: 3,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), installConfigFilename)
+			if err := os.WriteFile(path, []byte(tc.installConfig), 0600); err != nil {
+				t.Fatal(err)
+			}
+			config := &clusterConfig{}
+			assert.NoError(t, config.loadTopology(path))
+			assert.Equal(t, "ostest", config.ClusterName)
+			assert.Equal(t, tc.expectedControlPlanes, config.ControlPlanes)
+			assert.Equal(t, tc.expectedWorkers, config.Workers)
+		})
+	}
+}
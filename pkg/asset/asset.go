@@ -8,6 +8,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/encryption"
 )
 
 const (
@@ -42,6 +44,39 @@ type WritableAsset interface {
 	Load(FileFetcher) (found bool, err error)
 }
 
+// CacheableAsset is an Asset whose generation is expensive, e.g. because it
+// queries the cloud, and which only depends on the contents of its parents.
+// The store reuses an earlier generated asset when the parents are unchanged.
+// Assets which hold secrets, or which validate the environment and must run
+// again on every install, must not be cacheable.
+type CacheableAsset interface {
+	Asset
+
+	// Cacheable marks the asset as cacheable.
+	Cacheable()
+}
+
+// RevalidatedAsset is an Asset whose validation depends on the options of the
+// run, e.g. the command-line flags. The store validates it again when it is
+// restored from the state file, as it is then neither loaded nor generated.
+type RevalidatedAsset interface {
+	Asset
+
+	// Revalidate validates the asset restored from the state file.
+	Revalidate() error
+}
+
+// InteractiveAsset is an Asset whose generation may prompt the user, or
+// mutates state shared with other assets, e.g. package variables. The store
+// never generates it concurrently with other assets, so that the prompts are
+// not interleaved and the other assets do not race with it.
+type InteractiveAsset interface {
+	Asset
+
+	// Interactive marks the asset as interactive.
+	Interactive()
+}
+
 // File is a file for an Asset.
 type File struct {
 	// Filename is the name of the file.
@@ -58,7 +93,11 @@ func PersistToFile(asset WritableAsset, directory string) error {
 		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
 			return errors.Wrap(err, "failed to create dir")
 		}
-		if err := os.WriteFile(path, f.Data, 0o640); err != nil { //nolint:gosec // no sensitive info
+		write := os.WriteFile
+		if encryption.Sensitive(f.Filename) {
+			write = encryption.WriteFile
+		}
+		if err := write(path, f.Data, 0o640); err != nil { //nolint:gosec // secrets are encrypted when a passphrase is set
 			return errors.Wrap(err, "failed to write file")
 		}
 	}
@@ -0,0 +1,298 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// PlanActionCreate marks a resource which the installer creates.
+	PlanActionCreate = "create"
+	// PlanActionUseExisting marks a pre-existing resource which the installer
+	// uses, and might tag, but does not create.
+	PlanActionUseExisting = "use-existing"
+	// PlanActionCreateByCluster marks a resource which is not created by the
+	// installer, but by the cluster once it is running.
+	PlanActionCreateByCluster = "create-by-cluster"
+)
+
+// PlanResource is an AWS resource of an installation plan. Types use the
+// names of the CloudFormation resource types.
+type PlanResource struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	Action     string                 `json:"action"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Plan returns the AWS resources which installing the cluster creates or
+// uses, without creating anything.
+func Plan(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig, masters []machinev1beta1.Machine, workers []machinev1beta1.MachineSet) ([]PlanResource, error) {
+	platform := installConfig.Config.Platform.AWS
+	external := installConfig.Config.Publish == types.ExternalPublishingStrategy
+	resources := []PlanResource{}
+
+	if len(platform.Subnets) == 0 {
+		zones, err := machineZones(masters, workers)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, vpcPlan(infraID, installConfig.Config, zones)...)
+	} else {
+		vpc, err := installConfig.AWS.VPC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, PlanResource{Type: "AWS::EC2::VPC", Name: vpc, Action: PlanActionUseExisting})
+		for _, id := range platform.Subnets {
+			resources = append(resources, PlanResource{Type: "AWS::EC2::Subnet", Name: id, Action: PlanActionUseExisting})
+		}
+	}
+
+	for _, role := range []string{"bootstrap", "master", "worker"} {
+		resources = append(resources, PlanResource{
+			Type:   "AWS::EC2::SecurityGroup",
+			Name:   fmt.Sprintf("%s-%s-sg", infraID, role),
+			Action: PlanActionCreate,
+		})
+	}
+
+	resources = append(resources, loadBalancerPlan(infraID, external)...)
+	resources = append(resources, dnsPlan(installConfig.Config, external)...)
+
+	resources = append(resources, PlanResource{
+		Type:   "AWS::S3::Bucket",
+		Name:   fmt.Sprintf("%s-bootstrap", infraID),
+		Action: PlanActionCreate,
+	})
+
+	instances, err := instancePlan(infraID, masters, workers, external)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, instances...)
+
+	return resources, nil
+}
+
+// vpcPlan returns the network resources of a VPC created by the installer.
+func vpcPlan(infraID string, ic *types.InstallConfig, zones []string) []PlanResource {
+	vpc := PlanResource{
+		Type:   "AWS::EC2::VPC",
+		Name:   fmt.Sprintf("%s-vpc", infraID),
+		Action: PlanActionCreate,
+	}
+	if len(ic.MachineNetwork) > 0 {
+		vpc.Properties = map[string]interface{}{"cidrBlock": ic.MachineNetwork[0].CIDR.String()}
+	}
+	resources := []PlanResource{
+		vpc,
+		{Type: "AWS::EC2::InternetGateway", Name: fmt.Sprintf("%s-igw", infraID), Action: PlanActionCreate},
+		{Type: "AWS::EC2::VPCEndpoint", Name: fmt.Sprintf("%s-vpce-s3", infraID), Action: PlanActionCreate},
+	}
+
+	for _, zone := range zones {
+		resources = append(resources,
+			PlanResource{
+				Type:       "AWS::EC2::Subnet",
+				Name:       fmt.Sprintf("%s-private-%s", infraID, zone),
+				Action:     PlanActionCreate,
+				Properties: map[string]interface{}{"availabilityZone": zone},
+			},
+			PlanResource{
+				Type:       "AWS::EC2::Subnet",
+				Name:       fmt.Sprintf("%s-public-%s", infraID, zone),
+				Action:     PlanActionCreate,
+				Properties: map[string]interface{}{"availabilityZone": zone},
+			},
+			PlanResource{
+				Type:   "AWS::EC2::EIP",
+				Name:   fmt.Sprintf("%s-eip-%s", infraID, zone),
+				Action: PlanActionCreate,
+			},
+			PlanResource{
+				Type:       "AWS::EC2::NatGateway",
+				Name:       fmt.Sprintf("%s-nat-%s", infraID, zone),
+				Action:     PlanActionCreate,
+				Properties: map[string]interface{}{"availabilityZone": zone},
+			},
+		)
+	}
+	return resources
+}
+
+// loadBalancerPlan returns the load balancers of the Kubernetes API.
+func loadBalancerPlan(infraID string, external bool) []PlanResource {
+	schemes := map[string]string{"int": "internal"}
+	if external {
+		schemes["ext"] = "internet-facing"
+	}
+
+	resources := []PlanResource{}
+	for _, suffix := range []string{"int", "ext"} {
+		scheme, ok := schemes[suffix]
+		if !ok {
+			continue
+		}
+		resources = append(resources, PlanResource{
+			Type:   "AWS::ElasticLoadBalancingV2::LoadBalancer",
+			Name:   fmt.Sprintf("%s-%s", infraID, suffix),
+			Action: PlanActionCreate,
+			Properties: map[string]interface{}{
+				"type":   "network",
+				"scheme": scheme,
+			},
+		})
+	}
+	return resources
+}
+
+// dnsPlan returns the Route 53 hosted zones and records of the cluster.
+func dnsPlan(ic *types.InstallConfig, external bool) []PlanResource {
+	clusterDomain := ic.ClusterDomain()
+	privateZone := PlanResource{
+		Type:   "AWS::Route53::HostedZone",
+		Name:   clusterDomain,
+		Action: PlanActionCreate,
+	}
+	if ic.AWS.HostedZone != "" {
+		privateZone.Name = ic.AWS.HostedZone
+		privateZone.Action = PlanActionUseExisting
+	}
+
+	resources := []PlanResource{
+		privateZone,
+		{Type: "AWS::Route53::RecordSet", Name: fmt.Sprintf("api.%s", clusterDomain), Action: PlanActionCreate, Properties: map[string]interface{}{"zone": privateZone.Name}},
+		{Type: "AWS::Route53::RecordSet", Name: fmt.Sprintf("api-int.%s", clusterDomain), Action: PlanActionCreate, Properties: map[string]interface{}{"zone": privateZone.Name}},
+		{Type: "AWS::Route53::RecordSet", Name: fmt.Sprintf("*.apps.%s", clusterDomain), Action: PlanActionCreateByCluster, Properties: map[string]interface{}{"zone": privateZone.Name}},
+	}
+	if external {
+		resources = append(resources,
+			PlanResource{Type: "AWS::Route53::HostedZone", Name: ic.BaseDomain, Action: PlanActionUseExisting},
+			PlanResource{Type: "AWS::Route53::RecordSet", Name: fmt.Sprintf("api.%s", clusterDomain), Action: PlanActionCreate, Properties: map[string]interface{}{"zone": ic.BaseDomain}},
+			PlanResource{Type: "AWS::Route53::RecordSet", Name: fmt.Sprintf("*.apps.%s", clusterDomain), Action: PlanActionCreateByCluster, Properties: map[string]interface{}{"zone": ic.BaseDomain}},
+		)
+	}
+	return resources
+}
+
+// instancePlan returns the bootstrap, control plane and compute instances and
+// their instance profiles.
+func instancePlan(infraID string, masters []machinev1beta1.Machine, workers []machinev1beta1.MachineSet, external bool) ([]PlanResource, error) {
+	resources := []PlanResource{}
+	profiles := map[string]bool{}
+	addProfile := func(config *machinev1beta1.AWSMachineProviderConfig, role string) {
+		if config.IAMInstanceProfile == nil || config.IAMInstanceProfile.ID == nil {
+			return
+		}
+		name := *config.IAMInstanceProfile.ID
+		if profiles[name] {
+			return
+		}
+		profiles[name] = true
+		action := PlanActionCreate
+		if name != fmt.Sprintf("%s-%s-profile", infraID, role) {
+			action = PlanActionUseExisting
+		}
+		resources = append(resources, PlanResource{Type: "AWS::IAM::InstanceProfile", Name: name, Action: action})
+	}
+
+	for i, master := range masters {
+		config, ok := master.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AWSMachineProviderConfig)
+		if !ok {
+			return nil, errors.Errorf("master %d does not have an AWS provider config", i)
+		}
+		if i == 0 {
+			bootstrap := instanceResource(fmt.Sprintf("%s-bootstrap", infraID), config, PlanActionCreate)
+			bootstrap.Properties["publicIp"] = external
+			resources = append(resources,
+				PlanResource{Type: "AWS::IAM::InstanceProfile", Name: fmt.Sprintf("%s-bootstrap-profile", infraID), Action: PlanActionCreate},
+				bootstrap,
+			)
+		}
+		addProfile(config, "master")
+		resources = append(resources, instanceResource(master.Name, config, PlanActionCreate))
+	}
+
+	for i, worker := range workers {
+		config, ok := worker.Spec.Template.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AWSMachineProviderConfig)
+		if !ok {
+			return nil, errors.Errorf("compute machine set %d does not have an AWS provider config", i)
+		}
+		addProfile(config, "worker")
+		resource := instanceResource(worker.Name, config, PlanActionCreateByCluster)
+		replicas := int32(0)
+		if worker.Spec.Replicas != nil {
+			replicas = *worker.Spec.Replicas
+		}
+		resource.Properties["replicas"] = replicas
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+func instanceResource(name string, config *machinev1beta1.AWSMachineProviderConfig, action string) PlanResource {
+	return PlanResource{
+		Type:   "AWS::EC2::Instance",
+		Name:   name,
+		Action: action,
+		Properties: map[string]interface{}{
+			"instanceType":     config.InstanceType,
+			"imageId":          aws.StringValue(config.AMI.ID),
+			"availabilityZone": config.Placement.AvailabilityZone,
+			"subnet":           resourceReference(config.Subnet),
+		},
+	}
+}
+
+// resourceReference returns the ID of a referenced resource or, for resources
+// which do not exist yet, the values of its filter.
+func resourceReference(ref machinev1beta1.AWSResourceReference) string {
+	if ref.ID != nil {
+		return *ref.ID
+	}
+	if ref.ARN != nil {
+		return *ref.ARN
+	}
+	for _, filter := range ref.Filters {
+		if len(filter.Values) > 0 {
+			return filter.Values[0]
+		}
+	}
+	return ""
+}
+
+// machineZones returns the sorted availability zones of the machines.
+func machineZones(masters []machinev1beta1.Machine, workers []machinev1beta1.MachineSet) ([]string, error) {
+	zones := map[string]bool{}
+	for i, master := range masters {
+		config, ok := master.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AWSMachineProviderConfig)
+		if !ok {
+			return nil, errors.Errorf("master %d does not have an AWS provider config", i)
+		}
+		zones[config.Placement.AvailabilityZone] = true
+	}
+	for i, worker := range workers {
+		config, ok := worker.Spec.Template.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AWSMachineProviderConfig)
+		if !ok {
+			return nil, errors.Errorf("compute machine set %d does not have an AWS provider config", i)
+		}
+		zones[config.Placement.AvailabilityZone] = true
+	}
+
+	sorted := make([]string, 0, len(zones))
+	for zone := range zones {
+		sorted = append(sorted, zone)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
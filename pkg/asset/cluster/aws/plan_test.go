@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"testing"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestVPCPlan(t *testing.T) {
+	ic := &types.InstallConfig{
+		Networking: &types.Networking{
+			MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+		},
+	}
+	expected := []PlanResource{
+		{Type: "AWS::EC2::VPC", Name: "ostest-x7k2p-vpc", Action: PlanActionCreate, Properties: map[string]interface{}{"cidrBlock": "10.0.0.0/16"}},
+		{Type: "AWS::EC2::InternetGateway", Name: "ostest-x7k2p-igw", Action: PlanActionCreate},
+		{Type: "AWS::EC2::VPCEndpoint", Name: "ostest-x7k2p-vpce-s3", Action: PlanActionCreate},
+		{Type: "AWS::EC2::Subnet", Name: "ostest-x7k2p-private-us-east-1a", Action: PlanActionCreate, Properties: map[string]interface{}{"availabilityZone": "us-east-1a"}},
+		{Type: "AWS::EC2::Subnet", Name: "ostest-x7k2p-public-us-east-1a", Action: PlanActionCreate, Properties: map[string]interface{}{"availabilityZone": "us-east-1a"}},
+		{Type: "AWS::EC2::EIP", Name: "ostest-x7k2p-eip-us-east-1a", Action: PlanActionCreate},
+		{Type: "AWS::EC2::NatGateway", Name: "ostest-x7k2p-nat-us-east-1a", Action: PlanActionCreate, Properties: map[string]interface{}{"availabilityZone": "us-east-1a"}},
+	}
+	assert.Equal(t, expected, vpcPlan("ostest-x7k2p", ic, []string{"us-east-1a"}))
+}
+
+func TestLoadBalancerPlan(t *testing.T) {
+	internal := PlanResource{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", Name: "ostest-x7k2p-int", Action: PlanActionCreate, Properties: map[string]interface{}{"type": "network", "scheme": "internal"}}
+	external := PlanResource{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", Name: "ostest-x7k2p-ext", Action: PlanActionCreate, Properties: map[string]interface{}{"type": "network", "scheme": "internet-facing"}}
+	cases := []struct {
+		name     string
+		external bool
+		expected []PlanResource
+	}{
+		{
+			name:     "external",
+			external: true,
+			expected: []PlanResource{internal, external},
+		},
+		{
+			name:     "internal",
+			expected: []PlanResource{internal},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, loadBalancerPlan("ostest-x7k2p", tc.external))
+		})
+	}
+}
+
+func TestDNSPlan(t *testing.T) {
+	record := func(name, zone, action string) PlanResource {
+		return PlanResource{Type: "AWS::Route53::RecordSet", Name: name, Action: action, Properties: map[string]interface{}{"zone": zone}}
+	}
+	cases := []struct {
+		name       string
+		hostedZone string
+		external   bool
+		expected   []PlanResource
+	}{
+		{
+			name:     "external",
+			external: true,
+			expected: []PlanResource{
+				{Type: "AWS::Route53::HostedZone", Name: "ostest.example.com", Action: PlanActionCreate},
+				record("api.ostest.example.com", "ostest.example.com", PlanActionCreate),
+				record("api-int.ostest.example.com", "ostest.example.com", PlanActionCreate),
+				record("*.apps.ostest.example.com", "ostest.example.com", PlanActionCreateByCluster),
+				{Type: "AWS::Route53::HostedZone", Name: "example.com", Action: PlanActionUseExisting},
+				record("api.ostest.example.com", "example.com", PlanActionCreate),
+				record("*.apps.ostest.example.com", "example.com", PlanActionCreateByCluster),
+			},
+		},
+		{
+			name:       "internal with an existing private hosted zone",
+			hostedZone: "Z3URY6TWQ91KVV",
+			expected: []PlanResource{
+				{Type: "AWS::Route53::HostedZone", Name: "Z3URY6TWQ91KVV", Action: PlanActionUseExisting},
+				record("api.ostest.example.com", "Z3URY6TWQ91KVV", PlanActionCreate),
+				record("api-int.ostest.example.com", "Z3URY6TWQ91KVV", PlanActionCreate),
+				record("*.apps.ostest.example.com", "Z3URY6TWQ91KVV", PlanActionCreateByCluster),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "ostest"},
+				BaseDomain: "example.com",
+				Platform:   types.Platform{AWS: &awstypes.Platform{HostedZone: tc.hostedZone}},
+			}
+			assert.Equal(t, tc.expected, dnsPlan(ic, tc.external))
+		})
+	}
+}
+
+func awsProviderConfig(zone string, profile string) *machinev1beta1.AWSMachineProviderConfig {
+	return &machinev1beta1.AWSMachineProviderConfig{
+		InstanceType:       "m6i.xlarge",
+		AMI:                machinev1beta1.AWSResourceReference{ID: pointer.String("ami-0123")},
+		Placement:          machinev1beta1.Placement{AvailabilityZone: zone},
+		Subnet:             machinev1beta1.AWSResourceReference{Filters: []machinev1beta1.Filter{{Name: "tag:Name", Values: []string{"ostest-x7k2p-private-" + zone}}}},
+		IAMInstanceProfile: &machinev1beta1.AWSResourceReference{ID: pointer.String(profile)},
+	}
+}
+
+func TestInstancePlan(t *testing.T) {
+	masters := []machinev1beta1.Machine{{
+		ObjectMeta: metav1.ObjectMeta{Name: "ostest-x7k2p-master-0"},
+		Spec: machinev1beta1.MachineSpec{ProviderSpec: machinev1beta1.ProviderSpec{
+			Value: &runtime.RawExtension{Object: awsProviderConfig("us-east-1a", "ostest-x7k2p-master-profile")},
+		}},
+	}}
+	workers := []machinev1beta1.MachineSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "ostest-x7k2p-worker-us-east-1b"},
+		Spec: machinev1beta1.MachineSetSpec{
+			Replicas: pointer.Int32(2),
+			Template: machinev1beta1.MachineTemplateSpec{Spec: machinev1beta1.MachineSpec{ProviderSpec: machinev1beta1.ProviderSpec{
+				Value: &runtime.RawExtension{Object: awsProviderConfig("us-east-1b", "custom-profile")},
+			}}},
+		},
+	}}
+	instance := func(name, zone, action string) PlanResource {
+		return PlanResource{Type: "AWS::EC2::Instance", Name: name, Action: action, Properties: map[string]interface{}{
+			"instanceType":     "m6i.xlarge",
+			"imageId":          "ami-0123",
+			"availabilityZone": zone,
+			"subnet":           "ostest-x7k2p-private-" + zone,
+		}}
+	}
+	bootstrap := instance("ostest-x7k2p-bootstrap", "us-east-1a", PlanActionCreate)
+	bootstrap.Properties["publicIp"] = true
+	worker := instance("ostest-x7k2p-worker-us-east-1b", "us-east-1b", PlanActionCreateByCluster)
+	worker.Properties["replicas"] = int32(2)
+	expected := []PlanResource{
+		{Type: "AWS::IAM::InstanceProfile", Name: "ostest-x7k2p-bootstrap-profile", Action: PlanActionCreate},
+		bootstrap,
+		{Type: "AWS::IAM::InstanceProfile", Name: "ostest-x7k2p-master-profile", Action: PlanActionCreate},
+		instance("ostest-x7k2p-master-0", "us-east-1a", PlanActionCreate),
+		{Type: "AWS::IAM::InstanceProfile", Name: "custom-profile", Action: PlanActionUseExisting},
+		worker,
+	}
+
+	resources, err := instancePlan("ostest-x7k2p", masters, workers, true)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resources)
+
+	zones, err := machineZones(masters, workers)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-east-1a", "us-east-1b"}, zones)
+}
+
+func TestResourceReference(t *testing.T) {
+	cases := []struct {
+		name      string
+		reference machinev1beta1.AWSResourceReference
+		expected  string
+	}{
+		{
+			name:      "id",
+			reference: machinev1beta1.AWSResourceReference{ID: pointer.String("subnet-0123")},
+			expected:  "subnet-0123",
+		},
+		{
+			name:      "arn",
+			reference: machinev1beta1.AWSResourceReference{ARN: pointer.String("arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123")},
+			expected:  "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123",
+		},
+		{
+			name:      "filter",
+			reference: machinev1beta1.AWSResourceReference{Filters: []machinev1beta1.Filter{{Name: "tag:Name", Values: []string{"ostest-x7k2p-private-us-east-1a"}}}},
+			expected:  "ostest-x7k2p-private-us-east-1a",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resourceReference(tc.reference))
+		})
+	}
+}
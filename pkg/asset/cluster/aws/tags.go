@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/manifests"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/aws/validation"
+)
+
+// ResourceTags returns the user tags of the resources created by the
+// installer, when the user tags are propagated, read from the Infrastructure
+// config of the manifests. The manifests may have been edited after they were
+// created, so that the tags of the resources follow those of the cluster. It
+// returns nil when the user tags of the install config apply.
+func ResourceTags(platform *awstypes.Platform, files []*asset.File) (map[string]string, error) {
+	if !platform.PropagateUserTag {
+		return nil, nil
+	}
+
+	var file *asset.File
+	for _, f := range files {
+		if f.Filename == manifests.InfraCfgFilename {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return nil, nil
+	}
+	infra := &configv1.Infrastructure{}
+	if err := yaml.Unmarshal(file.Data, infra); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", manifests.InfraCfgFilename)
+	}
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.AWS == nil {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(infra.Status.PlatformStatus.AWS.ResourceTags))
+	for _, tag := range infra.Status.PlatformStatus.AWS.ResourceTags {
+		tags[tag.Key] = tag.Value
+	}
+	if err := validation.ValidateResourceTags(tags, field.NewPath("status", "platformStatus", "aws", "resourceTags")).ToAggregate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid resource tags in %s", manifests.InfraCfgFilename)
+	}
+
+	if len(tags) == 0 && len(platform.UserTags) == 0 {
+		return tags, nil
+	}
+	if !reflect.DeepEqual(tags, platform.UserTags) {
+		logrus.Warnf("The resource tags of %s differ from the user tags of the install config, the resources created by the installer are tagged with the former. The Machine and MachineSet manifests keep the tags they were created with unless they are edited too.", manifests.InfraCfgFilename)
+	}
+	return tags, nil
+}
@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/manifests"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestResourceTags(t *testing.T) {
+	infrastructure := func(tags string) []*asset.File {
+		return []*asset.File{{
+			Filename: manifests.InfraCfgFilename,
+			Data: []byte(`apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  name: cluster
+status:
+  platformStatus:
+    type: AWS
+    aws:
+      region: us-east-1
+      resourceTags:` + tags),
+		}}
+	}
+
+	cases := []struct {
+		name     string
+		platform *awstypes.Platform
+		files    []*asset.File
+		expected map[string]string
+		errorMsg string
+	}{{
+		name:     "user tags not propagated",
+		platform: &awstypes.Platform{UserTags: map[string]string{"app": "production"}},
+		files:    infrastructure("\n      - key: app\n        value: staging\n"),
+	}, {
+		name:     "no infrastructure config",
+		platform: &awstypes.Platform{PropagateUserTag: true, UserTags: map[string]string{"app": "production"}},
+	}, {
+		name:     "tags of the install config",
+		platform: &awstypes.Platform{PropagateUserTag: true, UserTags: map[string]string{"app": "production"}},
+		files:    infrastructure("\n      - key: app\n        value: production\n"),
+		expected: map[string]string{"app": "production"},
+	}, {
+		name:     "edited tags",
+		platform: &awstypes.Platform{PropagateUserTag: true, UserTags: map[string]string{"app": "production"}},
+		files:    infrastructure("\n      - key: app\n        value: staging\n      - key: team\n        value: infra\n"),
+		expected: map[string]string{"app": "staging", "team": "infra"},
+	}, {
+		name:     "removed tags",
+		platform: &awstypes.Platform{PropagateUserTag: true, UserTags: map[string]string{"app": "production"}},
+		files:    infrastructure(" []\n"),
+		expected: map[string]string{},
+	}, {
+		name:     "invalid tags",
+		platform: &awstypes.Platform{PropagateUserTag: true},
+		files:    infrastructure("\n      - key: openshift.io/app\n        value: production\n"),
+		errorMsg: `^invalid resource tags in manifests/cluster-infrastructure-02-config\.yml: status\.platformStatus\.aws\.resourceTags\[openshift\.io/app\]: Invalid value: "production": key is in the openshift\.io namespace$`,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := ResourceTags(tc.platform, tc.files)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tags)
+		})
+	}
+}
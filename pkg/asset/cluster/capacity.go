@@ -0,0 +1,108 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// CapacityRetryPolicy is how the creation of the infrastructure recovers when
+// an instance cannot be created because its zone lacks capacity.
+type CapacityRetryPolicy struct {
+	// Retries is the number of times a stage failing for lack of capacity
+	// is retried.
+	Retries int
+	// Backoff is the delay before the first retry. It is doubled before
+	// every following retry.
+	Backoff time.Duration
+	// ZoneFallback moves the control plane instances of a zone lacking
+	// capacity to the other zones of the control plane before retrying.
+	ZoneFallback bool
+}
+
+// CapacityRetries is the policy applied to the stages of the cluster.
+var CapacityRetries = CapacityRetryPolicy{
+	Retries: 3,
+	Backoff: time.Minute,
+}
+
+// applyStageWithRetries applies the stage, retrying it with backoff while it
+// fails for lack of capacity. A retry resumes from the state of the failed
+// attempt, so that only the missing resources are created. When the zone
+// fallback is enabled, the instances of the zones lacking capacity are moved
+// to the other zones of the control plane, in the variables of tfvarsFiles,
+// for the retries and the following stages, and in the files of the cluster,
+// for a resumed attempt.
+func (c *Cluster) applyStageWithRetries(platform string, stage terraform.Stage, terraformDir string, tfvarsFiles []*asset.File, state *asset.File) (*asset.File, error) {
+	backoff := CapacityRetries.Backoff
+	exhausted := map[string]bool{}
+	for attempt := 1; ; attempt++ {
+		outputs, err := c.applyStage(platform, stage, terraformDir, tfvarsFiles, state)
+		var capacityErr *terraform.CapacityError
+		if err == nil || !errors.As(err, &capacityErr) || attempt > CapacityRetries.Retries {
+			return outputs, err
+		}
+
+		if CapacityRetries.ZoneFallback && capacityErr.Zone != "" {
+			exhausted[capacityErr.Zone] = true
+			files, err := fallBackFromZones(platform, stage.Name(), tfvarsFiles, exhausted)
+			if err != nil {
+				logrus.Warnf("Failed to move the control plane instances out of zone %s: %v", capacityErr.Zone, err)
+			}
+			for _, file := range files {
+				c.replaceFile(file)
+			}
+		}
+
+		logrus.Warnf("Stage %q failed for lack of capacity, retrying in %s (retry %d of %d): %v", stage.Name(), backoff, attempt, CapacityRetries.Retries, err)
+		if !sleepUnlessInterrupted(backoff) {
+			return nil, &InterruptedError{Created: true}
+		}
+		backoff *= 2
+
+		// The failed attempt added its state to the files of the cluster,
+		// the retry adds its own instead.
+		state = c.removeStateFile(stage)
+	}
+}
+
+// removeStateFile removes the state of the stage from the files of the
+// cluster and returns it, or nil if there is none.
+func (c *Cluster) removeStateFile(stage terraform.Stage) *asset.File {
+	for i := len(c.FileList) - 1; i >= 0; i-- {
+		if file := c.FileList[i]; file.Filename == stage.StateFilename() {
+			c.FileList = append(c.FileList[:i], c.FileList[i+1:]...)
+			return file
+		}
+	}
+	return nil
+}
+
+// replaceFile replaces the file of the same name in the files of the cluster,
+// or adds it.
+func (c *Cluster) replaceFile(file *asset.File) {
+	for i, f := range c.FileList {
+		if f.Filename == file.Filename {
+			c.FileList[i] = file
+			return
+		}
+	}
+	c.FileList = append(c.FileList, file)
+}
+
+// sleepUnlessInterrupted waits for the duration and returns true, or returns
+// false as soon as the creation of the cluster is interrupted.
+func sleepUnlessInterrupted(d time.Duration) bool {
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		if Interrupted() {
+			return false
+		}
+		time.Sleep(time.Second)
+	}
+	return !Interrupted()
+}
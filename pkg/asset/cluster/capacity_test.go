@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestRemoveStateFile(t *testing.T) {
+	network := &asset.File{Filename: "terraform.network.tfstate"}
+	bootstrap := &asset.File{Filename: "terraform.bootstrap.tfstate"}
+	outputs := &asset.File{Filename: "network.tfvars.json"}
+	c := &Cluster{FileList: []*asset.File{network, outputs, bootstrap}}
+
+	assert.Equal(t, bootstrap, c.removeStateFile(&fakeStage{name: "bootstrap"}))
+	assert.Equal(t, []*asset.File{network, outputs}, c.FileList)
+	assert.Nil(t, c.removeStateFile(&fakeStage{name: "bootstrap"}))
+	assert.Equal(t, []*asset.File{network, outputs}, c.FileList)
+}
+
+func TestReplaceFile(t *testing.T) {
+	outputs := &asset.File{Filename: "cluster.tfvars.json"}
+	c := &Cluster{FileList: []*asset.File{{Filename: TfVarsFileName}, outputs}}
+	vars := &asset.File{Filename: TfVarsFileName, Data: []byte(`{}`)}
+	platformVars := &asset.File{Filename: TfPlatformVarsFileName, Data: []byte(`{}`)}
+
+	c.replaceFile(vars)
+	c.replaceFile(platformVars)
+	assert.Equal(t, []*asset.File{vars, outputs, platformVars}, c.FileList)
+}
+
+func TestSleepUnlessInterrupted(t *testing.T) {
+	assert.True(t, sleepUnlessInterrupted(0))
+
+	Interrupt()
+	defer atomic.StoreInt32(&interrupted, 0)
+	assert.False(t, sleepUnlessInterrupted(time.Hour))
+}
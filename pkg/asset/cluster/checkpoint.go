@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ResumeProvisioning resumes the creation of the infrastructure from the
+// stage checkpoints left by a previous run of create cluster, which are
+// otherwise an error.
+var ResumeProvisioning bool
+
+// ProvisioningError is returned by the generation of the cluster when the
+// creation of the infrastructure failed after it started. Resources may have
+// been created, and the stage checkpoints are kept, so the assets the cluster
+// was generated from, such as the cluster ID, must be kept as well for
+// create cluster --resume to resume the same cluster.
+type ProvisioningError struct {
+	Err error
+}
+
+func (e *ProvisioningError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error which made the creation fail.
+func (e *ProvisioningError) Unwrap() error {
+	return e.Err
+}
+
+// provisioningError returns err as a ProvisioningError, unless it is nil or
+// already an interruption or a pause, which keep the assets on their own.
+func provisioningError(err error) error {
+	var interrupted *InterruptedError
+	var paused *PausedError
+	if err == nil || errors.As(err, &interrupted) || errors.As(err, &paused) {
+		return err
+	}
+	return &ProvisioningError{Err: err}
+}
+
+// stageCheckpoint is what a previous run of create cluster left in the install
+// directory for a stage: its state file, if the stage was started, and its
+// outputs file, if the stage was completed. A rerun skips the completed stages
+// and resumes a started one from its state, rather than starting over.
+type stageCheckpoint struct {
+	state   *asset.File
+	outputs *asset.File
+}
+
+// completed returns true if the stage was completed by the previous run.
+func (c *stageCheckpoint) completed() bool {
+	return c.outputs != nil
+}
+
+// loadCheckpoints returns the checkpoints of the stages in the directory, and
+// whether any stage was started by a previous run. The checkpoints are only
+// used when resuming, and when the cluster metadata of the directory is of
+// the cluster with the infra ID, so that the stages of another cluster are
+// never resumed.
+func loadCheckpoints(directory string, infraID string, resume bool, stages []terraform.Stage) ([]stageCheckpoint, bool, error) {
+	checkpoints := make([]stageCheckpoint, len(stages))
+	started := false
+	for i, stage := range stages {
+		state, err := readCheckpointFile(directory, stage.StateFilename())
+		if err != nil {
+			return nil, false, err
+		}
+		outputs, err := readCheckpointFile(directory, stage.OutputsFilename())
+		if err != nil {
+			return nil, false, err
+		}
+		checkpoints[i] = stageCheckpoint{state: state, outputs: outputs}
+		started = started || state != nil || outputs != nil
+	}
+	if !started {
+		return checkpoints, false, nil
+	}
+
+	if !resume {
+		return nil, false, errors.Errorf("a previous run left the state of the infrastructure in %s: rerun with --resume to resume the creation of the cluster, or destroy the cluster first", directory)
+	}
+	metadataFile, err := readCheckpointFile(directory, metadataFileName)
+	if err != nil {
+		return nil, false, err
+	}
+	if metadataFile == nil {
+		return nil, false, errors.Errorf("cannot resume the creation of the cluster: %s has no %s to check that the state of the infrastructure is of cluster %s", directory, metadataFileName, infraID)
+	}
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(metadataFile.Data, metadata); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to unmarshal %s", metadataFileName)
+	}
+	if metadata.InfraID != infraID {
+		return nil, false, errors.Errorf("cannot resume the creation of the cluster: the state of the infrastructure in %s is of cluster %s, not %s; destroy that cluster first", directory, metadata.InfraID, infraID)
+	}
+	return checkpoints, true, nil
+}
+
+// readCheckpointFile returns the file of the directory, or nil if it does not
+// exist.
+func readCheckpointFile(directory, filename string) (*asset.File, error) {
+	data, err := os.ReadFile(filepath.Join(directory, filename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read the checkpoint %q", filename)
+	}
+	return &asset.File{Filename: filename, Data: data}, nil
+}
@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+type fakeStage struct {
+	terraform.Stage
+	name string
+}
+
+func (s *fakeStage) Name() string {
+	return s.name
+}
+
+func (s *fakeStage) StateFilename() string {
+	return "terraform." + s.name + ".tfstate"
+}
+
+func (s *fakeStage) OutputsFilename() string {
+	return s.name + ".tfvars.json"
+}
+
+func TestLoadCheckpoints(t *testing.T) {
+	stages := []terraform.Stage{&fakeStage{name: "network"}, &fakeStage{name: "bootstrap"}, &fakeStage{name: "cluster"}}
+
+	dir := t.TempDir()
+	checkpoints, started, err := loadCheckpoints(dir, "test-a1b2c", false, stages)
+	assert.NoError(t, err)
+	assert.False(t, started)
+	assert.Len(t, checkpoints, 3)
+
+	for filename, data := range map[string]string{
+		"terraform.network.tfstate":   "network state",
+		"network.tfvars.json":         "network outputs",
+		"terraform.bootstrap.tfstate": "bootstrap state",
+		"metadata.json":               `{"infraID":"test-a1b2c"}`,
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, filename), []byte(data), 0o600))
+	}
+	checkpoints, started, err = loadCheckpoints(dir, "test-a1b2c", true, stages)
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.True(t, checkpoints[0].completed())
+	assert.Equal(t, "network outputs", string(checkpoints[0].outputs.Data))
+	assert.False(t, checkpoints[1].completed())
+	assert.Equal(t, "bootstrap state", string(checkpoints[1].state.Data))
+	assert.False(t, checkpoints[2].completed())
+	assert.True(t, checkpoints[2].state == nil)
+}
+
+func TestLoadCheckpointsErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		resume   bool
+		metadata string
+		expected string
+	}{
+		{
+			name:     "not resuming",
+			metadata: `{"infraID":"test-a1b2c"}`,
+			expected: `^a previous run left the state of the infrastructure in .*: rerun with --resume to resume the creation of the cluster, or destroy the cluster first$`,
+		},
+		{
+			name:     "no metadata",
+			resume:   true,
+			expected: `^cannot resume the creation of the cluster: .* has no metadata\.json to check that the state of the infrastructure is of cluster test-a1b2c$`,
+		},
+		{
+			name:     "other cluster",
+			resume:   true,
+			metadata: `{"infraID":"test-x9y8z"}`,
+			expected: `^cannot resume the creation of the cluster: the state of the infrastructure in .* is of cluster test-x9y8z, not test-a1b2c; destroy that cluster first$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "terraform.network.tfstate"), []byte("network state"), 0o600))
+			if tc.metadata != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(tc.metadata), 0o600))
+			}
+			_, _, err := loadCheckpoints(dir, "test-a1b2c", tc.resume, []terraform.Stage{&fakeStage{name: "network"}})
+			assert.Regexp(t, tc.expected, err)
+		})
+	}
+}
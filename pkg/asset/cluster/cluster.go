@@ -2,10 +2,12 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/pkg/errors"
@@ -15,15 +17,21 @@ import (
 	"github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/cluster/azure"
 	"github.com/openshift/installer/pkg/asset/cluster/openstack"
+	"github.com/openshift/installer/pkg/asset/cluster/powervs"
+	"github.com/openshift/installer/pkg/asset/cluster/vsphere"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/asset/quota"
+	"github.com/openshift/installer/pkg/metrics/instrumentation"
+	"github.com/openshift/installer/pkg/metrics/progress"
 	"github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/openshift/installer/pkg/terraform"
 	platformstages "github.com/openshift/installer/pkg/terraform/stages/platform"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	typesazure "github.com/openshift/installer/pkg/types/azure"
 	typesopenstack "github.com/openshift/installer/pkg/types/openstack"
+	typespowervs "github.com/openshift/installer/pkg/types/powervs"
+	typesvsphere "github.com/openshift/installer/pkg/types/vsphere"
 )
 
 var (
@@ -31,6 +39,17 @@ var (
 	InstallDir string
 )
 
+// PreflightChecks returns the platform checks which are run before the
+// infrastructure of the cluster is provisioned.
+func PreflightChecks() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.PlatformPermsCheck{},
+		&installconfig.PlatformProvisionCheck{},
+		&quota.PlatformQuotaCheck{},
+		&installconfig.FIPSCheck{},
+	}
+}
+
 // Cluster uses the terraform executable to launch a cluster
 // with the given terraform tfvar and generated templates.
 type Cluster struct {
@@ -47,20 +66,21 @@ func (c *Cluster) Name() string {
 // Dependencies returns the direct dependency for launching
 // the cluster.
 func (c *Cluster) Dependencies() []asset.Asset {
-	return []asset.Asset{
+	dependencies := []asset.Asset{
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
-		// PlatformCredsCheck, PlatformPermsCheck and PlatformProvisionCheck
-		// perform validations & check perms required to provision infrastructure.
+		// PlatformCredsCheck and the preflight checks perform validations
+		// & check perms required to provision infrastructure.
 		// We do not actually use them in this asset directly, hence
 		// they are put in the dependencies but not fetched in Generate.
 		&installconfig.PlatformCredsCheck{},
-		&installconfig.PlatformPermsCheck{},
-		&installconfig.PlatformProvisionCheck{},
-		&quota.PlatformQuotaCheck{},
+	}
+	dependencies = append(dependencies, PreflightChecks()...)
+	return append(dependencies,
 		&TerraformVariables{},
 		&password.KubeadminPassword{},
-	}
+		&Metadata{},
+	)
 }
 
 // Generate launches the cluster and generates the terraform state file on disk.
@@ -72,7 +92,8 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	terraformVariables := &TerraformVariables{}
-	parents.Get(clusterID, installConfig, terraformVariables)
+	metadata := &Metadata{}
+	parents.Get(clusterID, installConfig, terraformVariables, metadata)
 
 	if fs := installConfig.Config.FeatureSet; strings.HasSuffix(string(fs), "NoUpgrade") {
 		logrus.Warnf("FeatureSet %q is enabled. This FeatureSet does not allow upgrades and may affect the supportability of the cluster.", fs)
@@ -82,8 +103,12 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		return errors.New("cluster cannot be created with platform set to 'none'")
 	}
 
-	if installConfig.Config.BootstrapInPlace != nil {
-		return errors.New("cluster cannot be created with bootstrapInPlace set")
+	if installConfig.Config.Platform.External != nil {
+		return errors.New("cluster cannot be created with platform set to 'external'")
+	}
+
+	if installConfig.Config.BootstrapInPlace != nil && !installConfig.Config.IsBootstrapInPlaceIPI() {
+		return errors.Errorf("cluster cannot be created with bootstrapInPlace set on platform %q", installConfig.Config.Platform.Name())
 	}
 
 	platform := installConfig.Config.Platform.Name()
@@ -94,6 +119,10 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 
 	stages := platformstages.StagesForPlatform(platform)
 
+	if Interrupted() {
+		return &InterruptedError{}
+	}
+
 	terraformDir := filepath.Join(InstallDir, "terraform")
 	if err := os.Mkdir(terraformDir, 0777); err != nil {
 		return errors.Wrap(err, "could not create the terraform directory")
@@ -107,19 +136,55 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	defer os.RemoveAll(terraformDir)
 	terraform.UnpackTerraform(terraformDirPath, stages)
 
-	logrus.Infof("Creating infrastructure resources...")
-	switch platform {
-	case typesaws.Name:
-		if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
-			return err
+	// Record the resources in the cluster metadata even if a stage failed, so
+	// that they can be audited and destroyed.
+	clusterMetadata := metadata.File
+	defer func() {
+		metadataFile, recordErr := c.recordResources(clusterMetadata, stages)
+		if recordErr != nil {
+			logrus.Warnf("Failed to record the resources of the cluster in the metadata: %v", recordErr)
+			return
 		}
-	case typesazure.Name, typesazure.StackTerraformName:
-		if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
-			return err
+		c.FileList = append(c.FileList, metadataFile)
+
+		var paused *PausedError
+		if errors.As(err, &paused) {
+			summaryFile, summaryErr := pauseSummaryFile(metadataFile, paused)
+			if summaryErr != nil {
+				logrus.Warnf("Failed to summarize the resources created before the pause: %v", summaryErr)
+				return
+			}
+			c.FileList = append(c.FileList, summaryFile)
 		}
-	case typesopenstack.Name:
-		if err := openstack.PreTerraform(); err != nil {
-			return err
+	}()
+
+	checkpoints, resuming, err := loadCheckpoints(InstallDir, clusterID.InfraID, ResumeProvisioning, stages)
+	if err != nil {
+		return err
+	}
+	// From now on, resources may be created, so a failure keeps the assets
+	// the cluster is generated from for a resumed attempt.
+	defer func() { err = provisioningError(err) }()
+
+	if resuming {
+		// The resources created before the stages, e.g. by the PreTerraform
+		// steps, already exist, so only the stages are resumed.
+		logrus.Infof("Resuming the creation of infrastructure resources from a previous run...")
+	} else {
+		logrus.Infof("Creating infrastructure resources...")
+		switch platform {
+		case typesaws.Name:
+			if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+				return err
+			}
+		case typesazure.Name, typesazure.StackTerraformName:
+			if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+				return err
+			}
+		case typesopenstack.Name:
+			if err := openstack.PreTerraform(); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -128,13 +193,64 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		tfvarsFiles = append(tfvarsFiles, file)
 	}
 
-	for _, stage := range stages {
-		outputs, err := c.applyStage(platform, stage, terraformDirPath, tfvarsFiles)
+	// The workspace created by the installer is found again by its name when
+	// resuming, so this runs on every run.
+	if platform == typespowervs.Name {
+		workspaceVars, workspaceMetadata, err := powervs.EnsureWorkspace(context.TODO(), clusterID.InfraID, installConfig, metadata.File)
 		if err != nil {
-			return errors.Wrapf(err, "failure applying terraform for %q stage", stage.Name())
+			return err
+		}
+		if workspaceVars != nil {
+			tfvarsFiles = append(tfvarsFiles, workspaceVars)
+			clusterMetadata = workspaceMetadata
+		}
+	}
+
+	for i, stage := range stages {
+		if Interrupted() {
+			logrus.Warnf("Stopping before stage %q", stage.Name())
+			return &InterruptedError{Created: true}
+		}
+		if PauseBeforeBootstrap && stage.DestroyWithBootstrap() && !checkpoints[i].completed() {
+			logrus.Infof("Pausing before stage %q, which creates the bootstrap resources", stage.Name())
+			return &PausedError{Stage: stage.Name()}
+		}
+		if checkpoint := checkpoints[i]; checkpoint.completed() {
+			logrus.Infof("Skipping stage %q, which was completed by a previous run", stage.Name())
+			if checkpoint.state != nil {
+				c.FileList = append(c.FileList, checkpoint.state)
+			}
+			tfvarsFiles = append(tfvarsFiles, checkpoint.outputs)
+			c.FileList = append(c.FileList, checkpoint.outputs)
+			progress.Progress(c.Name(), (i+1)*100/len(stages), fmt.Sprintf("skipped completed stage %q", stage.Name()))
+		} else {
+			outputs, err := c.applyStageWithRetries(platform, stage, terraformDirPath, tfvarsFiles, checkpoints[i].state)
+			if err != nil {
+				return stageError(stage.Name(), err)
+			}
+			tfvarsFiles = append(tfvarsFiles, outputs)
+			c.FileList = append(c.FileList, outputs)
+			progress.Progress(c.Name(), (i+1)*100/len(stages), fmt.Sprintf("applied stage %q", stage.Name()))
+		}
+
+		if platform == typespowervs.Name && stage.Name() == powervs.DNSRecordsStage {
+			vpcRegion, err := powervsVPCRegion(terraformVariables)
+			if err != nil {
+				return err
+			}
+			if err := powervs.CreatePrivateDNSRecords(context.TODO(), clusterID.InfraID, installConfig, vpcRegion); err != nil {
+				return errors.Wrap(err, "failed to create the DNS records of the cluster")
+			}
+		}
+		if platform == typesvsphere.Name && stage.Name() == "master" && installConfig.Config.VSphere.ControlPlaneAntiAffinity != "" {
+			moids, err := vsphereControlPlaneMoids(tfvarsFiles[len(tfvarsFiles)-1])
+			if err != nil {
+				return err
+			}
+			if err := vsphere.CreateControlPlaneAntiAffinity(context.TODO(), clusterID.InfraID, installConfig.Config, moids); err != nil {
+				return errors.Wrap(err, "failed to create the anti-affinity rules of the control plane")
+			}
 		}
-		tfvarsFiles = append(tfvarsFiles, outputs)
-		c.FileList = append(c.FileList, outputs)
 	}
 
 	return nil
@@ -159,7 +275,9 @@ func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 	return false, nil
 }
 
-func (c *Cluster) applyStage(platform string, stage terraform.Stage, terraformDir string, tfvarsFiles []*asset.File) (*asset.File, error) {
+// applyStage applies the stage. If a previous run left the state of the stage,
+// the stage resumes from it, creating only the missing resources.
+func (c *Cluster) applyStage(platform string, stage terraform.Stage, terraformDir string, tfvarsFiles []*asset.File, state *asset.File) (*asset.File, error) {
 	// Copy the terraform.tfvars to a temp directory which will contain the terraform plan.
 	tmpDir, err := os.MkdirTemp("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
 	if err != nil {
@@ -167,6 +285,13 @@ func (c *Cluster) applyStage(platform string, stage terraform.Stage, terraformDi
 	}
 	defer os.RemoveAll(tmpDir)
 
+	if state != nil {
+		logrus.Infof("Resuming stage %q from the state of a previous run", stage.Name())
+		if err := os.WriteFile(filepath.Join(tmpDir, terraform.StateFilename), state.Data, 0o600); err != nil {
+			return nil, errors.Wrap(err, "failed to write the state of the previous run")
+		}
+	}
+
 	var extraOpts []tfexec.ApplyOption
 	for _, file := range tfvarsFiles {
 		if err := os.WriteFile(filepath.Join(tmpDir, file.Filename), file.Data, 0o600); err != nil {
@@ -181,23 +306,35 @@ func (c *Cluster) applyStage(platform string, stage terraform.Stage, terraformDi
 func (c *Cluster) applyTerraform(tmpDir string, platform string, stage terraform.Stage, terraformDir string, opts ...tfexec.ApplyOption) (*asset.File, error) {
 	timer.StartTimer(stage.Name())
 	defer timer.StopTimer(stage.Name())
+	progress.Start(stage.Name())
 
+	start := time.Now()
 	applyErr := terraform.Apply(tmpDir, platform, stage, terraformDir, opts...)
+	if platform == typespowervs.Name {
+		instrumentation.Observe(instrumentation.KindProvisioning, fmt.Sprintf("%s stage", stage.Name()), start, applyErr, nil)
+	}
 
 	// Write the state file to the install directory even if the apply failed.
+	var resources []string
 	if data, err := os.ReadFile(filepath.Join(tmpDir, terraform.StateFilename)); err == nil {
 		c.FileList = append(c.FileList, &asset.File{
 			Filename: stage.StateFilename(),
 			Data:     data,
 		})
+		if resources, err = terraform.ResourceIDs(data); err != nil {
+			logrus.Debugf("Failed to list the resources of stage %q: %v", stage.Name(), err)
+		}
 	} else if !os.IsNotExist(err) {
 		logrus.Errorf("Failed to read tfstate: %v", err)
+		progress.Fail(stage.Name(), err)
 		return nil, errors.Wrap(err, "failed to read tfstate")
 	}
 
 	if applyErr != nil {
+		progress.Fail(stage.Name(), applyErr)
 		return nil, errors.Wrap(applyErr, asset.ClusterCreationError)
 	}
+	progress.Complete(stage.Name(), resources...)
 
 	outputs, err := terraform.Outputs(tmpDir, terraformDir)
 	if err != nil {
@@ -210,3 +347,33 @@ func (c *Cluster) applyTerraform(tmpDir string, platform string, stage terraform
 	}
 	return outputsFile, nil
 }
+
+// vsphereControlPlaneMoids returns the managed object IDs of the control
+// plane virtual machines from the outputs of the master stage.
+func vsphereControlPlaneMoids(outputs *asset.File) ([]string, error) {
+	var values struct {
+		ControlPlaneMoids []string `json:"control_plane_moids"`
+	}
+	if err := json.Unmarshal(outputs.Data, &values); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", outputs.Filename)
+	}
+	return values.ControlPlaneMoids, nil
+}
+
+// powervsVPCRegion returns the VPC region of the cluster, from its platform
+// terraform variables.
+func powervsVPCRegion(terraformVariables *TerraformVariables) (string, error) {
+	for _, file := range terraformVariables.Files() {
+		if file.Filename != TfPlatformVarsFileName {
+			continue
+		}
+		var vars struct {
+			VPCRegion string `json:"powervs_vpc_region"`
+		}
+		if err := json.Unmarshal(file.Data, &vars); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal %s", TfPlatformVarsFileName)
+		}
+		return vars.VPCRegion, nil
+	}
+	return "", errors.Errorf("no %s file", TfPlatformVarsFileName)
+}
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// interrupted is set once the creation of the cluster is interrupted.
+var interrupted int32
+
+// Interrupt stops the creation of the infrastructure at the next safe point:
+// before the first resource is created, or between two stages, once the
+// resources of the running stage are created and recorded.
+func Interrupt() {
+	atomic.StoreInt32(&interrupted, 1)
+}
+
+// Interrupted returns true if the creation of the cluster was interrupted.
+func Interrupted() bool {
+	return atomic.LoadInt32(&interrupted) == 1
+}
+
+// InterruptedError is returned by the generation of the cluster when it
+// stopped at a safe point after an interruption.
+type InterruptedError struct {
+	// Created is true when resources of the cluster were created before the
+	// interruption. They are recorded in the cluster metadata.
+	Created bool
+}
+
+func (e *InterruptedError) Error() string {
+	if e.Created {
+		return "the creation of the infrastructure was interrupted"
+	}
+	return "the creation of the infrastructure was interrupted before any resource was created"
+}
+
+// stageError returns the error of a stage which failed to be applied. When
+// the creation was interrupted, the failure is the interruption: terraform
+// runs in its own process group on Linux, but elsewhere it shares the one of
+// the installer and also gets the SIGINT of a Ctrl-C in the terminal. The
+// state of the stage is then kept for a resumed attempt, as for any other
+// interruption.
+func stageError(stage string, err error) error {
+	if Interrupted() {
+		logrus.Warnf("Stage %q was interrupted: %v", stage, err)
+		return &InterruptedError{Created: true}
+	}
+	return errors.Wrapf(err, "failure applying terraform for %q stage", stage)
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageError(t *testing.T) {
+	cases := []struct {
+		name        string
+		interrupted bool
+		expected    string
+	}{
+		{
+			name:     "failed",
+			expected: `failure applying terraform for "bootstrap" stage: exit status 1`,
+		},
+		{
+			name:        "interrupted",
+			interrupted: true,
+			expected:    "the creation of the infrastructure was interrupted",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.interrupted {
+				Interrupt()
+				defer atomic.StoreInt32(&interrupted, 0)
+			}
+			err := stageError("bootstrap", errors.New("exit status 1"))
+			assert.EqualError(t, err, tc.expected)
+			var interruptedErr *InterruptedError
+			assert.Equal(t, tc.interrupted, errors.As(err, &interruptedErr))
+		})
+	}
+}
+
+func TestInterruptedError(t *testing.T) {
+	assert.EqualError(t, &InterruptedError{}, "the creation of the infrastructure was interrupted before any resource was created")
+	assert.EqualError(t, &InterruptedError{Created: true}, "the creation of the infrastructure was interrupted")
+}
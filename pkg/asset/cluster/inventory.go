@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// otherResourceWords are the words in the types of the terraform resources
+// which are not infrastructure of the kinds of the inventory, even if their
+// types also contain words of those kinds, e.g. aws_iam_instance_profile.
+var otherResourceWords = []string{"iam", "profile", "role", "policy", "image", "bucket", "object", "key", "secret", "template"}
+
+// resourceKindWords are the words in the types of the terraform resources
+// which identify the kinds of the resources, checked in order.
+var resourceKindWords = []struct {
+	kind  types.ClusterResourceKind
+	words []string
+}{
+	{
+		kind:  types.ClusterResourceDNSRecord,
+		words: []string{"dns", "cis", "route53", "record", "zone", "pvtz"},
+	},
+	{
+		kind:  types.ClusterResourceLoadBalancer,
+		words: []string{"lb", "elb", "slb", "load_balancer", "loadbalancer", "target_group", "forwarding_rule", "backend_service", "health_check", "listener", "pool"},
+	},
+	{
+		kind:  types.ClusterResourceInstance,
+		words: []string{"instance", "virtual_machine", "vm", "server", "domain"},
+	},
+	{
+		kind: types.ClusterResourceNetwork,
+		words: []string{"network", "subnet", "subnetwork", "vpc", "vnet", "vswitch", "security_group", "secgroup", "firewall",
+			"router", "route", "route_table", "port", "floatingip", "eip", "address", "public_ip", "gateway", "dhcp", "ccon", "connection"},
+	},
+}
+
+// resourceKind returns the kind of the terraform resource type.
+func resourceKind(resourceType string) types.ClusterResourceKind {
+	padded := "_" + resourceType + "_"
+	contains := func(words []string) bool {
+		for _, word := range words {
+			if strings.Contains(padded, "_"+word+"_") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if contains(otherResourceWords) {
+		return types.ClusterResourceOther
+	}
+	for _, k := range resourceKindWords {
+		if contains(k.words) {
+			return k.kind
+		}
+	}
+	return types.ClusterResourceOther
+}
+
+// RecordResources records the managed resources in the terraform state of the
+// stage in the inventory of the cluster metadata, replacing the resources
+// previously recorded for the stage.
+func RecordResources(metadata *types.ClusterMetadata, stage string, state []byte) error {
+	resources, err := terraform.Resources(state)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the resources of stage %q", stage)
+	}
+
+	inventory := make([]types.ClusterResource, 0, len(metadata.Resources)+len(resources))
+	for _, resource := range metadata.Resources {
+		if resource.Stage != stage {
+			inventory = append(inventory, resource)
+		}
+	}
+	for _, resource := range resources {
+		inventory = append(inventory, types.ClusterResource{
+			Kind:  resourceKind(resource.Type),
+			Type:  resource.Type,
+			Name:  resource.Name,
+			ID:    resource.ID,
+			Stage: stage,
+		})
+	}
+	metadata.Resources = inventory
+	return nil
+}
+
+// recordResources returns the metadata file with the inventory of the
+// resources in the terraform states of the stages which were applied.
+func (c *Cluster) recordResources(metadataFile *asset.File, stages []terraform.Stage) (*asset.File, error) {
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(metadataFile.Data, metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the cluster metadata")
+	}
+
+	for _, stage := range stages {
+		for _, file := range c.FileList {
+			if file.Filename != stage.StateFilename() {
+				continue
+			}
+			if err := RecordResources(metadata, stage.Name(), file.Data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the cluster metadata")
+	}
+	return &asset.File{Filename: metadataFileName, Data: data}, nil
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestResourceKind(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		expected     types.ClusterResourceKind
+	}{
+		{resourceType: "aws_instance", expected: types.ClusterResourceInstance},
+		{resourceType: "ibm_pi_instance", expected: types.ClusterResourceInstance},
+		{resourceType: "vsphere_virtual_machine", expected: types.ClusterResourceInstance},
+		{resourceType: "libvirt_domain", expected: types.ClusterResourceInstance},
+		{resourceType: "aws_iam_instance_profile", expected: types.ClusterResourceOther},
+		{resourceType: "aws_lb_target_group_attachment", expected: types.ClusterResourceLoadBalancer},
+		{resourceType: "ibm_is_lb_pool_member", expected: types.ClusterResourceLoadBalancer},
+		{resourceType: "openstack_lb_loadbalancer_v2", expected: types.ClusterResourceLoadBalancer},
+		{resourceType: "aws_route53_record", expected: types.ClusterResourceDNSRecord},
+		{resourceType: "ibm_dns_resource_record", expected: types.ClusterResourceDNSRecord},
+		{resourceType: "aws_security_group_rule", expected: types.ClusterResourceNetwork},
+		{resourceType: "ibm_pi_dhcp", expected: types.ClusterResourceNetwork},
+		{resourceType: "openstack_networking_floatingip_v2", expected: types.ClusterResourceNetwork},
+		{resourceType: "aws_s3_bucket", expected: types.ClusterResourceOther},
+		{resourceType: "ignition_config", expected: types.ClusterResourceOther},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, resourceKind(tc.resourceType), tc.resourceType)
+	}
+}
+
+func TestRecordResources(t *testing.T) {
+	metadata := &types.ClusterMetadata{
+		Resources: []types.ClusterResource{
+			{Kind: types.ClusterResourceNetwork, Type: "aws_vpc", Name: "new_vpc", ID: "vpc-1", Stage: "cluster"},
+			{Kind: types.ClusterResourceInstance, Type: "aws_instance", Name: "bootstrap", ID: "i-1", Stage: "bootstrap"},
+		},
+	}
+	state := []byte(`{
+  "resources": [
+    {"mode": "data", "type": "aws_ami", "name": "rhcos", "instances": [{"attributes": {"id": "ami-1"}}]},
+    {"mode": "managed", "type": "aws_lb_target_group_attachment", "name": "bootstrap", "instances": [{"attributes": {"id": "tg-1"}}, {"attributes": {"id": "tg-2"}}]}
+  ]
+}`)
+
+	assert.NoError(t, RecordResources(metadata, "bootstrap", state))
+	assert.Equal(t, []types.ClusterResource{
+		{Kind: types.ClusterResourceNetwork, Type: "aws_vpc", Name: "new_vpc", ID: "vpc-1", Stage: "cluster"},
+		{Kind: types.ClusterResourceLoadBalancer, Type: "aws_lb_target_group_attachment", Name: "bootstrap", ID: "tg-1", Stage: "bootstrap"},
+		{Kind: types.ClusterResourceLoadBalancer, Type: "aws_lb_target_group_attachment", Name: "bootstrap", ID: "tg-2", Stage: "bootstrap"},
+	}, metadata.Resources)
+
+	assert.Error(t, RecordResources(metadata, "bootstrap", []byte("not a state")))
+}
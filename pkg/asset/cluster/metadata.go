@@ -22,11 +22,13 @@ import (
 	"github.com/openshift/installer/pkg/asset/cluster/vsphere"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/logformat"
 	"github.com/openshift/installer/pkg/types"
 	alibabacloudtypes "github.com/openshift/installer/pkg/types/alibabacloud"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
 	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
+	externaltypes "github.com/openshift/installer/pkg/types/external"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 	libvirttypes "github.com/openshift/installer/pkg/types/libvirt"
@@ -99,7 +101,7 @@ func (m *Metadata) Generate(parents asset.Parents) (err error) {
 		metadata.ClusterPlatformMetadata.AlibabaCloud = alibabacloud.Metadata(installConfig.Config)
 	case powervstypes.Name:
 		metadata.ClusterPlatformMetadata.PowerVS = powervs.Metadata(installConfig.Config, installConfig.PowerVS)
-	case nonetypes.Name:
+	case externaltypes.Name, nonetypes.Name:
 	case nutanixtypes.Name:
 		metadata.ClusterPlatformMetadata.Nutanix = nutanix.Metadata(installConfig.Config)
 	default:
@@ -145,6 +147,18 @@ func LoadMetadata(dir string) (*types.ClusterMetadata, error) {
 	if err = json.Unmarshal(raw, &metadata); err != nil {
 		return nil, errors.Wrapf(err, "failed to Unmarshal data from %q to types.ClusterMetadata", path)
 	}
+	logformat.SetPlatform(metadata.Platform())
 
 	return metadata, err
 }
+
+// WriteMetadata writes the cluster metadata to an asset directory.
+func WriteMetadata(dir string, metadata *types.ClusterMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal ClusterMetadata")
+	}
+
+	path := filepath.Join(dir, metadataFileName)
+	return errors.Wrapf(os.WriteFile(path, data, 0o640), "failed to write %q", path) //nolint:gosec // no sensitive info
+}
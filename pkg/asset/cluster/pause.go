@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// PauseSummaryFileName is the name of the file summarizing the resources
+	// created before the creation of the cluster paused.
+	PauseSummaryFileName = "cluster-pause-summary.json"
+)
+
+// PauseBeforeBootstrap stops the creation of the cluster once the
+// infrastructure is provisioned, before the stage creating the bootstrap
+// resources, so that the bootstrap only starts once it is approved.
+var PauseBeforeBootstrap bool
+
+// PauseSummary is the summary of the infrastructure resources created before
+// the creation of the cluster paused.
+type PauseSummary struct {
+	ClusterName string `json:"clusterName"`
+	InfraID     string `json:"infraID"`
+	Platform    string `json:"platform"`
+	// NextStage is the stage which is applied once the creation continues.
+	NextStage string                  `json:"nextStage"`
+	Resources []types.ClusterResource `json:"resources"`
+}
+
+// PausedError is returned by the generation of the cluster when it paused
+// before the bootstrap.
+type PausedError struct {
+	// Stage is the stage which is applied once the creation continues.
+	Stage string
+	// Summary is the summary of the resources created before the pause,
+	// which is also written to PauseSummaryFileName.
+	Summary *PauseSummary
+}
+
+func (e *PausedError) Error() string {
+	return fmt.Sprintf("the creation of the cluster is paused before the %q stage", e.Stage)
+}
+
+// pauseSummaryFile returns the summary file of the resources in the inventory
+// of the cluster metadata, and sets the summary of the error.
+func pauseSummaryFile(metadataFile *asset.File, paused *PausedError) (*asset.File, error) {
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(metadataFile.Data, metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the cluster metadata")
+	}
+
+	summary := &PauseSummary{
+		ClusterName: metadata.ClusterName,
+		InfraID:     metadata.InfraID,
+		Platform:    metadata.Platform(),
+		NextStage:   paused.Stage,
+		Resources:   metadata.Resources,
+	}
+	if summary.Resources == nil {
+		summary.Resources = []types.ClusterResource{}
+	}
+	paused.Summary = summary
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the pause summary")
+	}
+	return &asset.File{Filename: PauseSummaryFileName, Data: data}, nil
+}
+
+// ResourceCounts returns the numbers of resources of each kind, e.g.
+// "3 Instance, 5 Network", sorted by kind.
+func (s *PauseSummary) ResourceCounts() string {
+	counts := map[types.ClusterResourceKind]int{}
+	for _, resource := range s.Resources {
+		counts[resource.Kind]++
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[types.ClusterResourceKind(kind)], kind))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestPauseSummaryFile(t *testing.T) {
+	resources := []types.ClusterResource{
+		{Kind: types.ClusterResourceNetwork, Type: "aws_vpc", Name: "cluster_vpc", ID: "vpc-1", Stage: "cluster"},
+		{Kind: types.ClusterResourceInstance, Type: "aws_instance", Name: "master", ID: "i-1", Stage: "cluster"},
+		{Kind: types.ClusterResourceNetwork, Type: "aws_subnet", Name: "private", ID: "subnet-1", Stage: "cluster"},
+	}
+
+	cases := []struct {
+		name      string
+		resources []types.ClusterResource
+		counts    string
+	}{
+		{
+			name:      "created resources",
+			resources: resources,
+			counts:    "1 Instance, 2 Network",
+		},
+		{
+			name:      "no resources",
+			resources: nil,
+			counts:    "",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(&types.ClusterMetadata{
+				ClusterName:             "test-cluster",
+				InfraID:                 "test-cluster-abcde",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+				Resources:               tc.resources,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			paused := &PausedError{Stage: "bootstrap"}
+			file, err := pauseSummaryFile(&asset.File{Filename: metadataFileName, Data: data}, paused)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, PauseSummaryFileName, file.Filename)
+
+			summary := &PauseSummary{}
+			if !assert.NoError(t, json.Unmarshal(file.Data, summary)) {
+				return
+			}
+			expected := &PauseSummary{
+				ClusterName: "test-cluster",
+				InfraID:     "test-cluster-abcde",
+				Platform:    aws.Name,
+				NextStage:   "bootstrap",
+				Resources:   tc.resources,
+			}
+			if expected.Resources == nil {
+				expected.Resources = []types.ClusterResource{}
+			}
+			assert.Equal(t, expected, summary)
+			assert.Equal(t, expected, paused.Summary)
+			assert.Equal(t, tc.counts, paused.Summary.ResourceCounts())
+			assert.Equal(t, `the creation of the cluster is paused before the "bootstrap" stage`, paused.Error())
+		})
+	}
+}
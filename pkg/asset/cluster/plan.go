@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster/aws"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+const (
+	// PlanFileName is the name of the file containing the plan of a dry run.
+	PlanFileName = "cluster-plan.json"
+)
+
+// ResourcePlan is the plan of the infrastructure resources which creating the
+// cluster would create or use.
+type ResourcePlan struct {
+	ClusterName string             `json:"clusterName"`
+	InfraID     string             `json:"infraID"`
+	Platform    string             `json:"platform"`
+	Region      string             `json:"region"`
+	Resources   []aws.PlanResource `json:"resources"`
+}
+
+// Plan renders the infrastructure resources of the cluster without creating
+// any of them.
+type Plan struct {
+	File *asset.File
+}
+
+var _ asset.WritableAsset = (*Plan)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (p *Plan) Name() string {
+	return "Cluster Plan"
+}
+
+// Dependencies returns the direct dependencies for the plan asset.
+func (p *Plan) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.ClusterID{},
+		&installconfig.InstallConfig{},
+		// PlatformCredsCheck just checks the creds (and asks, if needed)
+		// We do not actually use it in this asset directly, hence
+		// it is put in the dependencies but not fetched in Generate
+		&installconfig.PlatformCredsCheck{},
+		&machines.Master{},
+		&machines.Worker{},
+	}
+}
+
+// Generate generates the plan asset.
+func (p *Plan) Generate(parents asset.Parents) error {
+	clusterID := &installconfig.ClusterID{}
+	installConfig := &installconfig.InstallConfig{}
+	mastersAsset := &machines.Master{}
+	workersAsset := &machines.Worker{}
+	parents.Get(clusterID, installConfig, mastersAsset, workersAsset)
+
+	platform := installConfig.Config.Platform.Name()
+	if platform != awstypes.Name {
+		return errors.Errorf("a dry run is not supported on platform %q", platform)
+	}
+
+	masters, err := mastersAsset.Machines()
+	if err != nil {
+		return err
+	}
+	workers, err := workersAsset.MachineSets()
+	if err != nil {
+		return err
+	}
+
+	resources, err := aws.Plan(context.TODO(), clusterID.InfraID, installConfig, masters, workers)
+	if err != nil {
+		return errors.Wrap(err, "failed to plan AWS resources")
+	}
+
+	data, err := json.MarshalIndent(&ResourcePlan{
+		ClusterName: installConfig.Config.ObjectMeta.Name,
+		InfraID:     clusterID.InfraID,
+		Platform:    platform,
+		Region:      installConfig.Config.Platform.AWS.Region,
+		Resources:   resources,
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the cluster plan")
+	}
+
+	p.File = &asset.File{
+		Filename: PlanFileName,
+		Data:     data,
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (p *Plan) Files() []*asset.File {
+	if p.File != nil {
+		return []*asset.File{p.File}
+	}
+	return []*asset.File{}
+}
+
+// Load is a no-op, because the plan must reflect the current assets.
+func (p *Plan) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
@@ -0,0 +1,68 @@
+package powervs
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// DNSRecordsStage is the name of the stage which creates the private load
+// balancer of the cluster, after which the records of the Kubernetes API are
+// created in the private DNS zone.
+const DNSRecordsStage = "cluster"
+
+// CreatePrivateDNSRecords creates the records of the Kubernetes API of the
+// cluster in the private DNS zone of the base domain, pointing to the private
+// load balancer of the cluster in the VPC region, so that they resolve before
+// the bootstrap and control plane machines boot. It does nothing unless the
+// cluster is published internally, or when the load balancer is managed by
+// the user, whose records already point to it. It runs again on a resumed
+// install, which keeps the records pointing to the load balancer.
+func CreatePrivateDNSRecords(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig, vpcRegion string) error {
+	if installConfig.Config.Publish != types.InternalPublishingStrategy {
+		return nil
+	}
+	// There is no private load balancer of the cluster to point to.
+	if installConfig.Config.UserManagedCloudLoadBalancer() {
+		return nil
+	}
+
+	client, err := icpowervs.NewClient(installConfig.Config.PowerVS.ServiceEndpoints)
+	if err != nil {
+		return err
+	}
+
+	dnsCRN, err := installConfig.PowerVS.DNSInstanceCRN(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the DNS Services instance of the base domain")
+	}
+	dnsClient, err := client.NewDNSServicesClient(dnsCRN)
+	if err != nil {
+		return err
+	}
+	zone, err := dnsClient.GetZoneByName(ctx, installConfig.Config.BaseDomain)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return errors.Errorf("DNS zone %s not found in DNS Services instance %s", installConfig.Config.BaseDomain, dnsCRN)
+	}
+
+	hostname, err := client.GetPrivateLoadBalancerHostname(ctx, vpcRegion, infraID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range icpowervs.ClusterAPIRecordNames(installConfig.Config.ClusterDomain()) {
+		logrus.Debugf("Creating DNS record %s pointing to %s", name, hostname)
+		if err := dnsClient.EnsureCNAMERecord(ctx, zone.ID, name, hostname); err != nil {
+			return err
+		}
+	}
+	return nil
+}
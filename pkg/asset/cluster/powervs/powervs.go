@@ -23,5 +23,7 @@ func Metadata(config *types.InstallConfig, meta *icpowervs.Metadata) *powervs.Me
 		VPCRegion:            config.Platform.PowerVS.VPCRegion,
 		Zone:                 config.Platform.PowerVS.Zone,
 		ServiceInstanceGUID:  config.Platform.PowerVS.ServiceInstanceID,
+		ServiceEndpoints:     config.Platform.PowerVS.ServiceEndpoints,
+		UserTags:             config.Platform.PowerVS.UserTags,
 	}
 }
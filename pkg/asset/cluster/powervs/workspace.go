@@ -0,0 +1,56 @@
+package powervs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// workspaceVarsFileName is the name of the Terraform variables file with the
+// ID of the workspace created by the installer.
+const workspaceVarsFileName = "terraform.powervs.workspace.auto.tfvars.json"
+
+// EnsureWorkspace creates the Power VS workspace of the cluster when the
+// install config has none, unless a previous run created it, before the
+// Terraform stages create their resources in it. It returns the Terraform
+// variables file with the ID of the workspace, and the cluster metadata
+// recording it so that the workspace can be destroyed. It returns nil files
+// when the install config has a workspace.
+func EnsureWorkspace(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig, metadataFile *asset.File) (*asset.File, *asset.File, error) {
+	platform := installConfig.Config.Platform.PowerVS
+	if platform.ServiceInstanceID != "" {
+		return nil, nil, nil
+	}
+
+	client, err := icpowervs.NewClient(platform.ServiceEndpoints)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := icpowervs.EnsureWorkspace(ctx, client, platform, infraID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create the Power VS workspace")
+	}
+
+	vars, err := json.Marshal(map[string]string{"powervs_cloud_instance_id": id})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal the Terraform variables of the Power VS workspace")
+	}
+
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(metadataFile.Data, metadata); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal the cluster metadata")
+	}
+	metadata.PowerVS.ServiceInstanceGUID = id
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal the cluster metadata")
+	}
+
+	return &asset.File{Filename: workspaceVarsFileName, Data: vars}, &asset.File{Filename: metadataFile.Filename, Data: data}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	libvirtprovider "github.com/openshift/cluster-api-provider-libvirt/pkg/apis/libvirtproviderconfig/v1beta1"
 	ovirtprovider "github.com/openshift/cluster-api-provider-ovirt/pkg/apis/ovirtprovider/v1beta1"
 	"github.com/openshift/installer/pkg/asset"
+	awscluster "github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
 	baremetalbootstrap "github.com/openshift/installer/pkg/asset/ignition/bootstrap/baremetal"
@@ -57,6 +59,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -135,7 +138,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 
 	platform := installConfig.Config.Platform.Name()
 	switch platform {
-	case none.Name:
+	case external.Name, none.Name:
 		return errors.Errorf("cannot create the cluster because %q is a UPI platform", platform)
 	}
 
@@ -245,6 +248,10 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		if err != nil {
 			return err
 		}
+		userTags, err := awscluster.ResourceTags(installConfig.Config.AWS, manifestsAsset.Files())
+		if err != nil {
+			return err
+		}
 		masters, err := mastersAsset.Machines()
 		if err != nil {
 			return err
@@ -269,19 +276,32 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		}
 
 		workerIAMRoleName := ""
+		workerIAMInstanceProfileName := ""
 		if mp := installConfig.Config.WorkerMachinePool(); mp != nil {
 			awsMP := &aws.MachinePool{}
 			awsMP.Set(installConfig.Config.AWS.DefaultMachinePlatform)
 			awsMP.Set(mp.Platform.AWS)
 			workerIAMRoleName = awsMP.IAMRole
+			workerIAMInstanceProfileName = awsMP.IAMInstanceProfile
+		}
+
+		var edgeLocalZones []string
+		if len(installConfig.Config.AWS.Subnets) == 0 {
+			for _, mp := range installConfig.Config.Compute {
+				if mp.Name == types.MachinePoolEdgeRoleName && mp.Platform.AWS != nil {
+					edgeLocalZones = mp.Platform.AWS.Zones
+				}
+			}
 		}
 
 		masterIAMRoleName := ""
+		masterIAMInstanceProfileName := ""
 		if mp := installConfig.Config.ControlPlane; mp != nil {
 			awsMP := &aws.MachinePool{}
 			awsMP.Set(installConfig.Config.AWS.DefaultMachinePlatform)
 			awsMP.Set(mp.Platform.AWS)
 			masterIAMRoleName = awsMP.IAMRole
+			masterIAMInstanceProfileName = awsMP.IAMInstanceProfile
 		}
 
 		data, err := awstfvars.TFVars(awstfvars.TFVarsSources{
@@ -290,6 +310,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			PublicSubnets:         publicSubnets,
 			InternalZone:          installConfig.Config.AWS.HostedZone,
 			Services:              installConfig.Config.AWS.ServiceEndpoints,
+			EdgeLocalZones:        edgeLocalZones,
 			Publish:               installConfig.Config.Publish,
 			MasterConfigs:         masterConfigs,
 			WorkerConfigs:         workerConfigs,
@@ -302,6 +323,11 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			WorkerIAMRoleName:     workerIAMRoleName,
 			Architecture:          installConfig.Config.ControlPlane.Architecture,
 			Proxy:                 installConfig.Config.Proxy,
+
+			MasterIAMInstanceProfileName: masterIAMInstanceProfileName,
+			WorkerIAMInstanceProfileName: workerIAMInstanceProfileName,
+			UserManagedLoadBalancer:      installConfig.Config.AWS.UserManagedLoadBalancer(),
+			UserTags:                     userTags,
 		})
 		if err != nil {
 			return errors.Wrapf(err, "failed to get %s Terraform variables", platform)
@@ -347,6 +373,22 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 
 		preexistingnetwork := installConfig.Config.Azure.VirtualNetwork != ""
 
+		imageURL := string(*rhcosImage)
+		if upload := installConfig.Config.Azure.ClusterOSImageUpload; upload != nil {
+			parsedURL, err := url.Parse(imageURL)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse the RHCOS image URL")
+			}
+			cachedImage, err := azuretfvars.CachedImage(imageURL)
+			if err != nil {
+				return errors.Wrap(err, "failed to use cached azure stack image")
+			}
+			imageURL, err = client.UploadImage(ctx, upload, cachedImage, parsedURL.Query().Get("sha256"))
+			if err != nil {
+				return err
+			}
+		}
+
 		var bootstrapIgnStub, bootstrapIgnURLPlaceholder string
 		if installConfig.Azure.CloudName == azure.StackCloud {
 			// Due to the SAS created in Terraform to limit access to bootstrap ignition, we cannot know the URL in advance.
@@ -368,7 +410,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				BaseDomainResourceGroupName:     installConfig.Config.Azure.BaseDomainResourceGroupName,
 				MasterConfigs:                   masterConfigs,
 				WorkerConfigs:                   workerConfigs,
-				ImageURL:                        string(*rhcosImage),
+				ImageURL:                        imageURL,
 				ImageRelease:                    rhcosRelease.GetAzureReleaseVersion(),
 				PreexistingNetwork:              preexistingnetwork,
 				Publish:                         installConfig.Config.Publish,
@@ -378,6 +420,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				HyperVGeneration:                hyperVGeneration,
 				VMArchitecture:                  installConfig.Config.ControlPlane.Architecture,
 				InfrastructureName:              clusterID.InfraID,
+				UserManagedLoadBalancer:         installConfig.Config.Azure.UserManagedLoadBalancer(),
 			},
 		)
 		if err != nil {
@@ -440,7 +483,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		}
 
 		archName := coreosarch.RpmArch(string(installConfig.Config.ControlPlane.Architecture))
-		st, err := rhcospkg.FetchCoreOSBuild(ctx)
+		st, err := rhcospkg.FetchCoreOSStream(ctx, installConfig.Config.CoreOSStream)
 		if err != nil {
 			return err
 		}
@@ -459,15 +502,16 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		imageURL := fmt.Sprintf("https://storage.googleapis.com/rhcos/rhcos/%s.tar.gz", img.Name)
 		data, err := gcptfvars.TFVars(
 			gcptfvars.TFVarsSources{
-				Auth:                auth,
-				MasterConfigs:       masterConfigs,
-				WorkerConfigs:       workerConfigs,
-				CreateFirewallRules: createFirewallRules,
-				ImageURI:            imageURL,
-				ImageLicenses:       installConfig.Config.GCP.Licenses,
-				PreexistingNetwork:  preexistingnetwork,
-				PublicZoneName:      publicZone.Name,
-				PublishStrategy:     installConfig.Config.Publish,
+				Auth:                    auth,
+				MasterConfigs:           masterConfigs,
+				WorkerConfigs:           workerConfigs,
+				CreateFirewallRules:     createFirewallRules,
+				ImageURI:                imageURL,
+				ImageLicenses:           installConfig.Config.GCP.Licenses,
+				PreexistingNetwork:      preexistingnetwork,
+				PublicZoneName:          publicZone.Name,
+				PublishStrategy:         installConfig.Config.Publish,
+				UserManagedLoadBalancer: installConfig.Config.GCP.UserManagedLoadBalancer(),
 			},
 		)
 		if err != nil {
@@ -557,6 +601,24 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			}
 		}
 
+		// Get master placement group info
+		var masterPlacementGroup *ibmcloudtfvars.PlacementGroup
+		if pg := masterMachinePool.PlacementGroup; pg != nil {
+			if pg.Name != "" {
+				group, err := client.GetPlacementGroupByName(ctx, pg.Name, installConfig.Config.Platform.IBMCloud.Region)
+				if err != nil {
+					return err
+				}
+				masterPlacementGroup = &ibmcloudtfvars.PlacementGroup{
+					ID: *group.ID,
+				}
+			} else {
+				masterPlacementGroup = &ibmcloudtfvars.PlacementGroup{
+					Strategy: string(pg.Strategy),
+				}
+			}
+		}
+
 		var cisCRN, dnsID string
 		vpcPermitted := false
 
@@ -587,11 +649,13 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		data, err = ibmcloudtfvars.TFVars(
 			ibmcloudtfvars.TFVarsSources{
 				Auth:                     auth,
+				BootstrapInPlace:         installConfig.Config.IsBootstrapInPlaceIPI(),
 				CISInstanceCRN:           cisCRN,
 				DNSInstanceID:            dnsID,
 				ImageURL:                 string(*rhcosImage),
 				MasterConfigs:            masterConfigs,
 				MasterDedicatedHosts:     masterDedicatedHosts,
+				MasterPlacementGroup:     masterPlacementGroup,
 				NetworkResourceGroupName: installConfig.Config.Platform.IBMCloud.NetworkResourceGroupName,
 				PreexistingVPC:           preexistingVPC,
 				PublishStrategy:          installConfig.Config.Publish,
@@ -797,7 +861,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			masterConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*machinev1.PowerVSMachineProviderConfig)
 		}
 
-		client, err := powervsconfig.NewClient()
+		client, err := powervsconfig.NewClient(installConfig.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -837,30 +901,48 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			vpcZone = fmt.Sprintf("%s-%d", vpcRegion, rand.Intn(2)+1) //nolint:gosec // we don't need a crypto secure number
 		}
 
+		// The provider configs of the masters have no fields for the placement
+		// of the instances, which is read from the control plane machine pool.
+		masterPool := powervs.MachinePool{}
+		masterPool.Set(installConfig.Config.PowerVS.DefaultMachinePlatform)
+		masterPool.Set(installConfig.Config.ControlPlane.Platform.PowerVS)
+		var placementGroup string
+		if masterPool.ServerPlacementGroup != nil {
+			placementGroup = masterPool.ServerPlacementGroup.Name
+		}
+
 		osImage := strings.SplitN(string(*rhcosImage), "/", 2)
 		data, err = powervstfvars.TFVars(
 			powervstfvars.TFVarsSources{
-				MasterConfigs:        masterConfigs,
-				Region:               installConfig.Config.Platform.PowerVS.Region,
-				Zone:                 installConfig.Config.Platform.PowerVS.Zone,
-				APIKey:               APIKey,
-				SSHKey:               installConfig.Config.SSHKey,
-				PowerVSResourceGroup: installConfig.Config.PowerVS.PowerVSResourceGroup,
-				ImageBucketName:      osImage[0],
-				ImageBucketFileName:  osImage[1],
-				NetworkName:          installConfig.Config.PowerVS.PVSNetworkName,
-				VPCRegion:            vpcRegion,
-				VPCZone:              vpcZone,
-				VPCName:              vpcName,
-				VPCSubnetName:        vpcSubnet,
-				VPCPermitted:         vpcPermitted,
-				VPCGatewayName:       vpcGatewayName,
-				VPCGatewayAttached:   vpcGatewayAttached,
-				CloudConnectionName:  installConfig.Config.PowerVS.CloudConnectionName,
-				CISInstanceCRN:       cisCRN,
-				DNSInstanceCRN:       dnsCRN,
-				PublishStrategy:      installConfig.Config.Publish,
-				EnableSNAT:           len(installConfig.Config.ImageContentSources) == 0,
+				MasterConfigs:           masterConfigs,
+				Region:                  installConfig.Config.Platform.PowerVS.Region,
+				Zone:                    installConfig.Config.Platform.PowerVS.Zone,
+				APIKey:                  APIKey,
+				SSHKey:                  installConfig.Config.SSHKey,
+				PowerVSResourceGroup:    installConfig.Config.PowerVS.PowerVSResourceGroup,
+				ImageBucketName:         osImage[0],
+				ImageBucketFileName:     osImage[1],
+				NetworkName:             installConfig.Config.PowerVS.PVSNetworkName,
+				VPCRegion:               vpcRegion,
+				VPCZone:                 vpcZone,
+				VPCName:                 vpcName,
+				VPCSubnetName:           vpcSubnet,
+				VPCSecurityGroups:       installConfig.Config.PowerVS.VPCSecurityGroups,
+				VPCPermitted:            vpcPermitted,
+				VPCGatewayName:          vpcGatewayName,
+				VPCGatewayAttached:      vpcGatewayAttached,
+				CloudConnectionName:     installConfig.Config.PowerVS.CloudConnectionName,
+				TransitGatewayID:        installConfig.Config.PowerVS.TransitGatewayID,
+				CISInstanceCRN:          cisCRN,
+				DNSInstanceCRN:          dnsCRN,
+				PublishStrategy:         installConfig.Config.Publish,
+				EnableSNAT:              len(installConfig.Config.ImageContentSources) == 0,
+				UserManagedLoadBalancer: installConfig.Config.PowerVS.UserManagedLoadBalancer(),
+				BootstrapInPlace:        installConfig.Config.IsBootstrapInPlaceIPI(),
+				Tags:                    installConfig.Config.PowerVS.ResourceTags(clusterID.InfraID),
+				SharedProcessorPool:     masterPool.SharedProcessorPool,
+				PlacementGroup:          placementGroup,
+				ServiceEndpoints:        installConfig.Config.PowerVS.ServiceEndpoints,
 			},
 		)
 		if err != nil {
@@ -872,7 +954,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		})
 
 	case vsphere.Name:
-		vim25Client, _, cleanup, err := vsphereconfig.CreateVSphereClients(context.TODO(),
+		vim25Client, restClient, cleanup, err := vsphereconfig.CreateVSphereClients(context.TODO(),
 			installConfig.Config.VSphere.VCenters[0].Server,
 			installConfig.Config.VSphere.VCenters[0].Username,
 			installConfig.Config.VSphere.VCenters[0].Password)
@@ -910,10 +992,41 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			}
 		}
 
+		var libraryID, libraryTemplate, libraryTemplateDesc string
+		var libraryTemplateExists bool
+		if library := installConfig.Config.VSphere.ContentLibrary; library != "" {
+			checksum, err := vsphereconfig.OVAChecksum(string(*rhcosImage))
+			if err != nil {
+				return err
+			}
+			libraryID, err = vsphereconfig.GetContentLibraryID(context.TODO(), restClient, library)
+			if err != nil {
+				return err
+			}
+			template, err := vsphereconfig.FindLibraryTemplate(context.TODO(), restClient, libraryID, checksum)
+			if err != nil {
+				return err
+			}
+			if template != nil {
+				logrus.Infof("Reusing the template %s of content library %s", template.Name, library)
+				libraryTemplate = template.Name
+				libraryTemplateExists = true
+			} else {
+				libraryTemplate = vsphereconfig.LibraryTemplateName(checksum)
+				libraryTemplateDesc = vsphereconfig.TemplateDescription(checksum)
+			}
+		}
+
 		data, err = vspheretfvars.TFVars(
 			vspheretfvars.TFVarsSources{
 				ControlPlaneConfigs:     controlPlaneConfigs,
 				ImageURL:                string(*rhcosImage),
+				RestClient:              restClient,
+				ContentLibrary:          installConfig.Config.VSphere.ContentLibrary,
+				LibraryID:               libraryID,
+				LibraryTemplate:         libraryTemplate,
+				LibraryTemplateExists:   libraryTemplateExists,
+				LibraryTemplateDesc:     libraryTemplateDesc,
 				DiskType:                installConfig.Config.Platform.VSphere.DiskType,
 				NetworksInFailureDomain: networkFailureDomainMap,
 				InfraID:                 clusterID.InfraID,
@@ -0,0 +1,172 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+
+	icvsphere "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
+	"github.com/openshift/installer/pkg/types"
+	typesvsphere "github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// CreateControlPlaneAntiAffinity keeps the control plane virtual machines, with
+// the managed object IDs, on separate hosts with the anti-affinity policy of
+// the install config: a DRS rule in each compute cluster holding several of
+// them, or a compute policy applied to them through a tag. The rules which
+// already exist are kept, so that the creation can be resumed.
+func CreateControlPlaneAntiAffinity(ctx context.Context, infraID string, installConfig *types.InstallConfig, controlPlaneMoids []string) error {
+	policy := installConfig.VSphere.ControlPlaneAntiAffinity
+	if policy == "" || len(controlPlaneMoids) < 2 {
+		return nil
+	}
+
+	vcenter := installConfig.VSphere.VCenters[0]
+	client, restClient, cleanup, err := icvsphere.CreateVSphereClients(ctx, vcenter.Server, vcenter.Username, vcenter.Password)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	vms := make([]vim25types.ManagedObjectReference, 0, len(controlPlaneMoids))
+	for _, moid := range controlPlaneMoids {
+		vms = append(vms, vim25types.ManagedObjectReference{Type: "VirtualMachine", Value: moid})
+	}
+
+	name := icvsphere.ControlPlaneAntiAffinityName(infraID)
+	switch policy {
+	case typesvsphere.AntiAffinityPolicyDRSRule:
+		return createDRSRules(ctx, client, name, vms)
+	case typesvsphere.AntiAffinityPolicyComputePolicy:
+		return createComputePolicy(ctx, restClient, infraID, name, vms)
+	default:
+		return errors.Errorf("unsupported control plane anti-affinity policy %q", policy)
+	}
+}
+
+// createDRSRules creates the anti-affinity rule of the virtual machines in
+// each compute cluster holding several of them.
+func createDRSRules(ctx context.Context, client *vim25.Client, name string, vms []vim25types.ManagedObjectReference) error {
+	collector := property.DefaultCollector(client)
+	var vmMos []mo.VirtualMachine
+	if err := collector.Retrieve(ctx, vms, []string{"name", "resourcePool"}, &vmMos); err != nil {
+		return errors.Wrap(err, "failed to get the control plane virtual machines")
+	}
+
+	clusters := map[vim25types.ManagedObjectReference][]vim25types.ManagedObjectReference{}
+	for _, vm := range vmMos {
+		if vm.ResourcePool == nil {
+			return errors.Errorf("control plane virtual machine %s has no resource pool", vm.Name)
+		}
+		var pool mo.ResourcePool
+		if err := collector.RetrieveOne(ctx, *vm.ResourcePool, []string{"owner"}, &pool); err != nil {
+			return errors.Wrapf(err, "failed to get the resource pool of control plane virtual machine %s", vm.Name)
+		}
+		clusters[pool.Owner] = append(clusters[pool.Owner], vm.Reference())
+	}
+
+	for clusterRef, clusterVMs := range clusters {
+		if len(clusterVMs) < 2 {
+			continue
+		}
+		if clusterRef.Type != "ClusterComputeResource" {
+			logrus.Warnf("Control plane virtual machines are on standalone host %s, no anti-affinity rule is created", clusterRef.Value)
+			continue
+		}
+
+		var clusterMo mo.ClusterComputeResource
+		if err := collector.RetrieveOne(ctx, clusterRef, []string{"name", "configurationEx"}, &clusterMo); err != nil {
+			return errors.Wrapf(err, "failed to get compute cluster %s", clusterRef.Value)
+		}
+		if config, ok := clusterMo.ConfigurationEx.(*vim25types.ClusterConfigInfoEx); ok {
+			exists := false
+			for _, rule := range config.Rule {
+				if rule.GetClusterRuleInfo().Name == name {
+					exists = true
+					break
+				}
+			}
+			if exists {
+				logrus.Debugf("Anti-affinity rule %s already exists in compute cluster %s", name, clusterMo.Name)
+				continue
+			}
+		}
+
+		spec := &vim25types.ClusterConfigSpecEx{
+			RulesSpec: []vim25types.ClusterRuleSpec{{
+				ArrayUpdateSpec: vim25types.ArrayUpdateSpec{Operation: vim25types.ArrayUpdateOperationAdd},
+				Info: &vim25types.ClusterAntiAffinityRuleSpec{
+					ClusterRuleInfo: vim25types.ClusterRuleInfo{
+						Name:    name,
+						Enabled: vim25types.NewBool(true),
+					},
+					Vm: clusterVMs,
+				},
+			}},
+		}
+		task, err := object.NewClusterComputeResource(client, clusterRef).Reconfigure(ctx, spec, true)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create anti-affinity rule %s in compute cluster %s", name, clusterMo.Name)
+		}
+		if err := task.Wait(ctx); err != nil {
+			return errors.Wrapf(err, "failed to create anti-affinity rule %s in compute cluster %s", name, clusterMo.Name)
+		}
+		logrus.Infof("Created anti-affinity rule %s of %d control plane machines in compute cluster %s", name, len(clusterVMs), clusterMo.Name)
+	}
+	return nil
+}
+
+// createComputePolicy tags the virtual machines, in the tag category of the
+// cluster, and creates the anti-affinity compute policy of the tag.
+func createComputePolicy(ctx context.Context, restClient *rest.Client, infraID, name string, vms []vim25types.ManagedObjectReference) error {
+	tagManager := tags.NewManager(restClient)
+	categoryID := fmt.Sprintf("openshift-%s", infraID)
+	category, err := tagManager.GetCategory(ctx, categoryID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get tag category %s", categoryID)
+	}
+
+	existing, err := tagManager.GetTagsForCategory(ctx, category.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the tags of category %s", categoryID)
+	}
+	tagID := ""
+	for _, tag := range existing {
+		if tag.Name == name {
+			tagID = tag.ID
+			break
+		}
+	}
+	if tagID == "" {
+		tagID, err = tagManager.CreateTag(ctx, &tags.Tag{
+			Name:       name,
+			CategoryID: category.ID,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create tag %s", name)
+		}
+	}
+
+	refs := make([]mo.Reference, 0, len(vms))
+	for _, vm := range vms {
+		refs = append(refs, vm)
+	}
+	if err := tagManager.AttachTagToMultipleObjects(ctx, tagID, refs); err != nil {
+		return errors.Wrapf(err, "failed to attach tag %s to the control plane virtual machines", name)
+	}
+
+	if err := icvsphere.CreateAntiAffinityComputePolicy(ctx, restClient, name, tagID); err != nil {
+		return err
+	}
+	logrus.Infof("Created anti-affinity compute policy %s of %d control plane machines", name, len(vms))
+	return nil
+}
@@ -17,5 +17,7 @@ func Metadata(config *types.InstallConfig) *typesvsphere.Metadata {
 		Username:          config.VSphere.VCenters[0].Username,
 		Password:          config.VSphere.VCenters[0].Password,
 		TerraformPlatform: terraformPlatform,
+
+		ControlPlaneAntiAffinity: config.VSphere.ControlPlaneAntiAffinity,
 	}
 }
@@ -0,0 +1,320 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vincent-petithory/dataurl"
+	"sigs.k8s.io/yaml"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/asset"
+	typesaws "github.com/openshift/installer/pkg/types/aws"
+)
+
+// zoneFallback is how the control plane instances of a platform are moved
+// out of the zones lacking capacity.
+type zoneFallback struct {
+	// stage is the stage creating the control plane instances. The
+	// instances are only moved while it is applied, as the bootstrap
+	// Ignition config, which holds their manifests, is used by a later
+	// stage.
+	stage string
+	// variable is the platform terraform variable listing the zone of
+	// every control plane instance, in the order of their Machines.
+	variable string
+}
+
+// zoneFallbacks are the zone fallbacks by platform.
+var zoneFallbacks = map[string]zoneFallback{
+	typesaws.Name: {stage: "cluster", variable: "aws_master_availability_zones"},
+}
+
+const (
+	// bootstrapMachinePrefix is the path prefix, in the bootstrap Ignition
+	// config, of the master Machine manifests, which are followed by the
+	// index of the Machine.
+	bootstrapMachinePrefix = "/opt/openshift/openshift/99_openshift-cluster-api_master-machines-"
+
+	// bootstrapControlPlaneMachineSetPath is the path, in the bootstrap
+	// Ignition config, of the ControlPlaneMachineSet manifest.
+	bootstrapControlPlaneMachineSetPath = "/opt/openshift/openshift/99_openshift-machine-api_master-control-plane-machine-set.yaml"
+)
+
+// fallBackFromZones moves the control plane instances of the exhausted zones
+// to the other zones of the control plane. The zones are replaced in the
+// platform variables of tfvarsFiles, and the Machine and
+// ControlPlaneMachineSet manifests of the bootstrap Ignition config are
+// regenerated for them, so that the cluster manages the instances in their
+// new zones. The replaced files are returned, tfvarsFiles is only changed
+// when all of them were replaced.
+func fallBackFromZones(platform string, stage string, tfvarsFiles []*asset.File, exhausted map[string]bool) ([]*asset.File, error) {
+	fallback, ok := zoneFallbacks[platform]
+	if !ok {
+		return nil, errors.Errorf("zone fallback is not supported on platform %q", platform)
+	}
+	if stage != fallback.stage {
+		return nil, errors.Errorf("the control plane instances are only moved while stage %q is applied", fallback.stage)
+	}
+
+	varsIndex, platformVarsIndex := -1, -1
+	for i, file := range tfvarsFiles {
+		switch file.Filename {
+		case TfVarsFileName:
+			varsIndex = i
+		case TfPlatformVarsFileName:
+			platformVarsIndex = i
+		}
+	}
+	if varsIndex < 0 || platformVarsIndex < 0 {
+		return nil, errors.Errorf("no %s or %s file", TfVarsFileName, TfPlatformVarsFileName)
+	}
+
+	platformVars, zones, err := moveZones(tfvarsFiles[platformVarsIndex].Data, fallback.variable, exhausted)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := moveBootstrapMachines(tfvarsFiles[varsIndex].Data, zones, exhausted)
+	if err != nil {
+		return nil, err
+	}
+
+	tfvarsFiles[varsIndex] = &asset.File{Filename: TfVarsFileName, Data: vars}
+	tfvarsFiles[platformVarsIndex] = &asset.File{Filename: TfPlatformVarsFileName, Data: platformVars}
+	return []*asset.File{tfvarsFiles[varsIndex], tfvarsFiles[platformVarsIndex]}, nil
+}
+
+// moveZones returns the variables with the exhausted zones of the zone list
+// variable replaced by the other zones of the list, in turn, and the new zone
+// of every instance.
+func moveZones(data []byte, variable string, exhausted map[string]bool) ([]byte, []string, error) {
+	vars, err := unmarshalVars(data, TfPlatformVarsFileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, ok := vars[variable].([]interface{})
+	if !ok {
+		return nil, nil, errors.Errorf("%s is not a list of zones", variable)
+	}
+
+	zones := make([]string, len(list))
+	var candidates []string
+	seen := map[string]bool{}
+	for i, v := range list {
+		zones[i] = fmt.Sprint(v)
+		if !exhausted[zones[i]] && !seen[zones[i]] {
+			candidates = append(candidates, zones[i])
+		}
+		seen[zones[i]] = true
+	}
+	if len(candidates) == 0 {
+		return nil, nil, errors.New("the control plane has no other zone")
+	}
+
+	moved := 0
+	for i, zone := range zones {
+		if !exhausted[zone] {
+			continue
+		}
+		zones[i] = candidates[moved%len(candidates)]
+		logrus.Warnf("Moving control plane instance %d from zone %s to zone %s", i, zone, zones[i])
+		moved++
+	}
+	vars[variable] = zones
+	data, err = json.MarshalIndent(vars, "", "  ")
+	return data, zones, err
+}
+
+// moveBootstrapMachines returns the variables with the Machine manifests of
+// the bootstrap Ignition config placed in the zones, in the order of their
+// indexes, and with the exhausted zones removed from the failure domains of
+// the ControlPlaneMachineSet manifest. The subnet of a moved Machine is the
+// one of the Machines already in its new zone.
+func moveBootstrapMachines(data []byte, zones []string, exhausted map[string]bool) ([]byte, error) {
+	vars, err := unmarshalVars(data, TfVarsFileName)
+	if err != nil {
+		return nil, err
+	}
+	ignition, ok := vars["ignition_bootstrap"].(string)
+	if !ok {
+		return nil, errors.New("no bootstrap Ignition config")
+	}
+	config := &igntypes.Config{}
+	if err := json.Unmarshal([]byte(ignition), config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the bootstrap Ignition config")
+	}
+
+	var machines []bootstrapMachine
+	subnets := map[string]machinev1beta1.AWSResourceReference{}
+	for i, file := range config.Storage.Files {
+		if !strings.HasPrefix(file.Path, bootstrapMachinePrefix) {
+			continue
+		}
+		machine, err := loadBootstrapMachine(i, file)
+		if err != nil {
+			return nil, err
+		}
+		machines = append(machines, machine)
+		subnets[machine.provider.Placement.AvailabilityZone] = machine.provider.Subnet
+	}
+	if len(machines) != len(zones) {
+		return nil, errors.Errorf("the bootstrap Ignition config has %d control plane Machines for %d zones", len(machines), len(zones))
+	}
+
+	for _, m := range machines {
+		if m.index >= len(zones) {
+			return nil, errors.Errorf("no zone for control plane Machine %d", m.index)
+		}
+		zone := zones[m.index]
+		if m.provider.Placement.AvailabilityZone == zone {
+			continue
+		}
+		subnet, ok := subnets[zone]
+		if !ok {
+			return nil, errors.Errorf("no control plane Machine in zone %s to take the subnet from", zone)
+		}
+		m.provider.Placement.AvailabilityZone = zone
+		m.provider.Subnet = subnet
+		raw, err := json.Marshal(m.provider)
+		if err != nil {
+			return nil, err
+		}
+		m.machine.Spec.ProviderSpec.Value.Raw = raw
+		contents, err := yaml.Marshal(m.machine)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal control plane Machine %d", m.index)
+		}
+		config.Storage.Files[m.file].Contents.Source = dataURL(contents)
+	}
+
+	for i, file := range config.Storage.Files {
+		if file.Path != bootstrapControlPlaneMachineSetPath {
+			continue
+		}
+		contents, err := ignitionFileContents(file)
+		if err != nil {
+			return nil, err
+		}
+		if contents, err = removeFailureDomains(contents, exhausted); err != nil {
+			return nil, err
+		}
+		config.Storage.Files[i].Contents.Source = dataURL(contents)
+	}
+
+	ignitionData, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the bootstrap Ignition config")
+	}
+	vars["ignition_bootstrap"] = string(ignitionData)
+
+	// The stages upload the bootstrap Ignition config from its file.
+	f, err := os.CreateTemp("", "openshift-install-bootstrap-*.ign")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tmp file for bootstrap ignition")
+	}
+	defer f.Close()
+	if _, err := f.Write(ignitionData); err != nil {
+		return nil, errors.Wrap(err, "failed to write bootstrap ignition")
+	}
+	vars["ignition_bootstrap_file"] = f.Name()
+
+	return json.MarshalIndent(vars, "", "  ")
+}
+
+// bootstrapMachine is a master Machine manifest of the bootstrap Ignition
+// config.
+type bootstrapMachine struct {
+	// file is the index of the manifest in the files of the config.
+	file int
+	// index is the index of the Machine in the control plane.
+	index    int
+	machine  *machinev1beta1.Machine
+	provider *machinev1beta1.AWSMachineProviderConfig
+}
+
+// loadBootstrapMachine returns the master Machine of the file at index i of
+// the bootstrap Ignition config.
+func loadBootstrapMachine(i int, file igntypes.File) (bootstrapMachine, error) {
+	name := path.Base(file.Path)
+	index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(file.Path, bootstrapMachinePrefix), ".yaml"))
+	if err != nil {
+		return bootstrapMachine{}, errors.Wrapf(err, "failed to parse the index of %s", name)
+	}
+	contents, err := ignitionFileContents(file)
+	if err != nil {
+		return bootstrapMachine{}, err
+	}
+	machine := &machinev1beta1.Machine{}
+	if err := yaml.Unmarshal(contents, machine); err != nil {
+		return bootstrapMachine{}, errors.Wrapf(err, "failed to unmarshal %s", name)
+	}
+	if machine.Spec.ProviderSpec.Value == nil {
+		return bootstrapMachine{}, errors.Errorf("%s has no providerSpec", name)
+	}
+	provider := &machinev1beta1.AWSMachineProviderConfig{}
+	if err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, provider); err != nil {
+		return bootstrapMachine{}, errors.Wrapf(err, "failed to unmarshal the providerSpec of %s", name)
+	}
+	return bootstrapMachine{file: i, index: index, machine: machine, provider: provider}, nil
+}
+
+// removeFailureDomains returns the ControlPlaneMachineSet manifest without the
+// AWS failure domains of the exhausted zones, so that the control plane
+// machine set does not move the instances back to them.
+func removeFailureDomains(data []byte, exhausted map[string]bool) ([]byte, error) {
+	cpms := &machinev1.ControlPlaneMachineSet{}
+	if err := yaml.Unmarshal(data, cpms); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the control plane machine set")
+	}
+	template := cpms.Spec.Template.OpenShiftMachineV1Beta1Machine
+	if template == nil || template.FailureDomains.AWS == nil {
+		return data, nil
+	}
+	var domains []machinev1.AWSFailureDomain
+	for _, domain := range *template.FailureDomains.AWS {
+		if !exhausted[domain.Placement.AvailabilityZone] {
+			domains = append(domains, domain)
+		}
+	}
+	template.FailureDomains.AWS = &domains
+	return yaml.Marshal(cpms)
+}
+
+// ignitionFileContents returns the contents of the file of an Ignition config
+// generated by the installer, whose contents are uncompressed data URLs.
+func ignitionFileContents(file igntypes.File) ([]byte, error) {
+	if file.Contents.Source == nil || (file.Contents.Compression != nil && *file.Contents.Compression != "") {
+		return nil, errors.Errorf("%s is not an uncompressed embedded file", file.Path)
+	}
+	contents, err := dataurl.DecodeString(*file.Contents.Source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", file.Path)
+	}
+	return contents.Data, nil
+}
+
+func dataURL(data []byte) *string {
+	source := dataurl.EncodeBytes(data)
+	return &source
+}
+
+// unmarshalVars returns the terraform variables of the file, keeping the
+// numbers as they are.
+func unmarshalVars(data []byte, filename string) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&vars); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", filename)
+	}
+	return vars, nil
+}
@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestMoveZones(t *testing.T) {
+	cases := []struct {
+		name      string
+		zones     string
+		exhausted []string
+		expected  string
+		err       string
+	}{{
+		name:      "one zone exhausted",
+		zones:     `["us-east-1a", "us-east-1b", "us-east-1c"]`,
+		exhausted: []string{"us-east-1b"},
+		expected:  `["us-east-1a", "us-east-1a", "us-east-1c"]`,
+	}, {
+		name:      "moved instances are spread",
+		zones:     `["us-east-1a", "us-east-1a", "us-east-1b"]`,
+		exhausted: []string{"us-east-1a"},
+		expected:  `["us-east-1b", "us-east-1b", "us-east-1b"]`,
+	}, {
+		name:      "spread over the remaining zones",
+		zones:     `["us-east-1a", "us-east-1a", "us-east-1b", "us-east-1c"]`,
+		exhausted: []string{"us-east-1a"},
+		expected:  `["us-east-1b", "us-east-1c", "us-east-1b", "us-east-1c"]`,
+	}, {
+		name:      "every zone exhausted",
+		zones:     `["us-east-1a", "us-east-1b"]`,
+		exhausted: []string{"us-east-1a", "us-east-1b"},
+		err:       `^the control plane has no other zone$`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exhausted := map[string]bool{}
+			for _, zone := range tc.exhausted {
+				exhausted[zone] = true
+			}
+			data, zones, err := moveZones([]byte(`{"aws_master_availability_zones": `+tc.zones+`, "aws_master_root_volume_size": 1200000}`), "aws_master_availability_zones", exhausted)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"aws_master_availability_zones": `+tc.expected+`, "aws_master_root_volume_size": 1200000}`, string(data))
+			expected := []string{}
+			assert.NoError(t, json.Unmarshal([]byte(tc.expected), &expected))
+			assert.Equal(t, expected, zones)
+		})
+	}
+}
+
+// zoneFallbackSubnet is the subnet reference of the control plane Machines in
+// the zone.
+func zoneFallbackSubnet(zone string) machinev1beta1.AWSResourceReference {
+	return machinev1beta1.AWSResourceReference{ID: pointer.String("subnet-" + zone)}
+}
+
+func zoneFallbackMachine(t *testing.T, index int, zone string) igntypes.File {
+	provider := &machinev1beta1.AWSMachineProviderConfig{
+		InstanceType: "m6i.xlarge",
+		Placement:    machinev1beta1.Placement{Region: "us-east-1", AvailabilityZone: zone},
+		Subnet:       zoneFallbackSubnet(zone),
+	}
+	machine := &machinev1beta1.Machine{
+		Spec: machinev1beta1.MachineSpec{
+			ProviderSpec: machinev1beta1.ProviderSpec{Value: &runtime.RawExtension{Object: provider}},
+		},
+	}
+	machine.Name = fmt.Sprintf("test-master-%d", index)
+	data, err := yaml.Marshal(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zoneFallbackIgnitionFile(fmt.Sprintf("%s%d.yaml", bootstrapMachinePrefix, index), data)
+}
+
+func zoneFallbackIgnitionFile(path string, data []byte) igntypes.File {
+	return igntypes.File{
+		Node:          igntypes.Node{Path: path},
+		FileEmbedded1: igntypes.FileEmbedded1{Contents: igntypes.Resource{Source: dataURL(data)}},
+	}
+}
+
+func zoneFallbackFile(t *testing.T, config *igntypes.Config, path string) []byte {
+	for _, file := range config.Storage.Files {
+		if file.Path == path {
+			contents, err := ignitionFileContents(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return contents
+		}
+	}
+	t.Fatalf("no %s in the bootstrap Ignition config", path)
+	return nil
+}
+
+func TestFallBackFromZones(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+	domains := []machinev1.AWSFailureDomain{}
+	for _, zone := range zones {
+		domains = append(domains, machinev1.AWSFailureDomain{
+			Placement: machinev1.AWSFailureDomainPlacement{AvailabilityZone: zone},
+			Subnet:    &machinev1.AWSResourceReference{Type: machinev1.AWSIDReferenceType, ID: pointer.String("subnet-" + zone)},
+		})
+	}
+	cpms := &machinev1.ControlPlaneMachineSet{
+		Spec: machinev1.ControlPlaneMachineSetSpec{
+			Template: machinev1.ControlPlaneMachineSetTemplate{
+				OpenShiftMachineV1Beta1Machine: &machinev1.OpenShiftMachineV1Beta1MachineTemplate{
+					FailureDomains: machinev1.FailureDomains{Platform: "AWS", AWS: &domains},
+				},
+			},
+		},
+	}
+	cpmsData, err := yaml.Marshal(cpms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &igntypes.Config{}
+	for i, zone := range zones {
+		config.Storage.Files = append(config.Storage.Files, zoneFallbackMachine(t, i, zone))
+	}
+	config.Storage.Files = append(config.Storage.Files, zoneFallbackIgnitionFile(bootstrapControlPlaneMachineSetPath, cpmsData))
+	ignition, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars, err := json.Marshal(map[string]interface{}{"cluster_id": "test", "ignition_bootstrap": string(ignition)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := &asset.File{Filename: TfPlatformVarsFileName, Data: []byte(`{"aws_master_availability_zones": ["us-east-1a", "us-east-1b", "us-east-1c"]}`)}
+	tfvarsFiles := []*asset.File{{Filename: TfVarsFileName, Data: vars}, original}
+
+	assert.EqualError(t, func() error {
+		_, err := fallBackFromZones("aws", "bootstrap", tfvarsFiles, map[string]bool{"us-east-1b": true})
+		return err
+	}(), `the control plane instances are only moved while stage "cluster" is applied`)
+	assert.EqualError(t, func() error {
+		_, err := fallBackFromZones("powervs", "cluster", tfvarsFiles, map[string]bool{"us-east-1b": true})
+		return err
+	}(), `zone fallback is not supported on platform "powervs"`)
+
+	files, err := fallBackFromZones("aws", "cluster", tfvarsFiles, map[string]bool{"us-east-1b": true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, tfvarsFiles, files)
+	assert.JSONEq(t, `{"aws_master_availability_zones": ["us-east-1a", "us-east-1a", "us-east-1c"]}`, string(tfvarsFiles[1].Data))
+	assert.JSONEq(t, `{"aws_master_availability_zones": ["us-east-1a", "us-east-1b", "us-east-1c"]}`, string(original.Data), "the variables of the asset are not changed")
+
+	newVars := map[string]interface{}{}
+	if err := json.Unmarshal(tfvarsFiles[0].Data, &newVars); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test", newVars["cluster_id"])
+	bootstrapFile, _ := newVars["ignition_bootstrap_file"].(string)
+	defer os.Remove(bootstrapFile)
+	fileData, err := os.ReadFile(bootstrapFile)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newVars["ignition_bootstrap"], string(fileData))
+	}
+	newConfig := &igntypes.Config{}
+	if err := json.Unmarshal([]byte(newVars["ignition_bootstrap"].(string)), newConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, zone := range []string{"us-east-1a", "us-east-1a", "us-east-1c"} {
+		machine := &machinev1beta1.Machine{}
+		if err := yaml.Unmarshal(zoneFallbackFile(t, newConfig, fmt.Sprintf("%s%d.yaml", bootstrapMachinePrefix, i)), machine); err != nil {
+			t.Fatal(err)
+		}
+		provider := &machinev1beta1.AWSMachineProviderConfig{}
+		if err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, provider); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fmt.Sprintf("test-master-%d", i), machine.Name)
+		assert.Equal(t, "m6i.xlarge", provider.InstanceType)
+		assert.Equal(t, machinev1beta1.Placement{Region: "us-east-1", AvailabilityZone: zone}, provider.Placement, "machine %d", i)
+		assert.Equal(t, zoneFallbackSubnet(zone), provider.Subnet, "machine %d", i)
+	}
+
+	newCPMS := &machinev1.ControlPlaneMachineSet{}
+	if err := yaml.Unmarshal(zoneFallbackFile(t, newConfig, bootstrapControlPlaneMachineSetPath), newCPMS); err != nil {
+		t.Fatal(err)
+	}
+	var newZones []string
+	for _, domain := range *newCPMS.Spec.Template.OpenShiftMachineV1Beta1Machine.FailureDomains.AWS {
+		newZones = append(newZones, domain.Placement.AvailabilityZone)
+	}
+	assert.Equal(t, []string{"us-east-1a", "us-east-1c"}, newZones)
+}
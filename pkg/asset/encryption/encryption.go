@@ -0,0 +1,241 @@
+// Package encryption encrypts the state of the asset directory at rest. The
+// state file and the asset cache, which hold every secret of the cluster, and
+// the generated files of the auth directory, i.e. the kubeadmin password and
+// the kubeconfigs with their client keys, are encrypted with a key derived
+// from a passphrase, and decrypted transparently when they are read back. The
+// other files generated for the user, e.g. the ignition configs and
+// metadata.json, are outputs read by other tools and are left in plain text.
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/credentials"
+)
+
+const (
+	// PassphraseEnv is the environment variable with the passphrase of the
+	// asset directory.
+	PassphraseEnv = "OPENSHIFT_INSTALL_STATE_PASSPHRASE"
+
+	// KeyCommandEnv is the environment variable which names a command which
+	// prints the passphrase of the asset directory, e.g. a script which
+	// decrypts it with a KMS key. It is used when PassphraseEnv is unset.
+	KeyCommandEnv = "OPENSHIFT_INSTALL_STATE_KEY_COMMAND"
+
+	version    = 1
+	kdf        = "pbkdf2-sha256"
+	iterations = 600000
+	saltSize   = 16
+	keySize    = 32
+)
+
+// header starts every encrypted file, so that encrypted files are told apart
+// from the plain files of earlier installs.
+var header = []byte(`{"openshiftInstallEncryption":`)
+
+// envelope is the content of an encrypted file.
+type envelope struct {
+	Encryption encryptionParameters `json:"openshiftInstallEncryption"`
+	Data       []byte               `json:"data"`
+}
+
+type encryptionParameters struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+}
+
+var (
+	passphraseOnce sync.Once
+	passphrase     []byte
+	passphraseErr  error
+
+	// keys caches the keys derived from the passphrase by salt, as the key
+	// derivation is slow on purpose.
+	keysMu sync.Mutex
+	keys   = map[string][]byte{}
+	// writeSalt is the salt of the files encrypted by this process.
+	writeSalt []byte
+)
+
+// loadPassphrase returns the passphrase of the asset directory, or nil if
+// none is configured.
+func loadPassphrase() ([]byte, error) {
+	passphraseOnce.Do(func() {
+		chain := credentials.Chain{
+			&credentials.EnvSource{Name: PassphraseEnv},
+			&credentials.CommandSource{Path: os.Getenv(KeyCommandEnv)},
+		}
+		value, source, err := chain.Load(context.TODO())
+		switch {
+		case errors.Is(err, credentials.ErrNotFound):
+		case err != nil:
+			passphraseErr = errors.Wrap(err, "failed to load the passphrase of the asset directory")
+		default:
+			passphrase = bytes.TrimRight(value, "\r\n")
+			if len(passphrase) == 0 {
+				passphraseErr = errors.Errorf("the passphrase from %s is empty", source)
+			}
+		}
+	})
+	return passphrase, passphraseErr
+}
+
+// Enabled returns true if a passphrase is configured, in which case the state
+// of the asset directory is encrypted when it is written.
+func Enabled() bool {
+	p, err := loadPassphrase()
+	return err != nil || len(p) > 0
+}
+
+// Sensitive returns true if the generated file holds secrets and is encrypted
+// on disk when encryption is enabled: the files of the auth directory, i.e.
+// the kubeadmin password and the kubeconfigs, which embed the client keys of
+// the cluster admin.
+func Sensitive(filename string) bool {
+	return filepath.Dir(filepath.Clean(filename)) == "auth"
+}
+
+// IsEncrypted returns true if the data is encrypted.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, header)
+}
+
+// key returns the key derived from the passphrase and the salt.
+func key(salt []byte) ([]byte, error) {
+	p, err := loadPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		return nil, errors.Errorf("no passphrase: set %s or %s", PassphraseEnv, KeyCommandEnv)
+	}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	if k, ok := keys[string(salt)]; ok {
+		return k, nil
+	}
+	k := pbkdf2.Key(p, salt, iterations, keySize, sha256.New)
+	keys[string(salt)] = k
+	return k, nil
+}
+
+// Encrypt encrypts the data with the passphrase of the asset directory.
+func Encrypt(data []byte) ([]byte, error) {
+	keysMu.Lock()
+	if writeSalt == nil {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			keysMu.Unlock()
+			return nil, err
+		}
+		writeSalt = salt
+	}
+	salt := writeSalt
+	keysMu.Unlock()
+
+	k, err := key(salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(k)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	params := encryptionParameters{
+		Version:    version,
+		KDF:        kdf,
+		Iterations: iterations,
+		Salt:       salt,
+		Nonce:      nonce,
+	}
+	return json.Marshal(envelope{
+		Encryption: params,
+		Data:       gcm.Seal(nil, nonce, data, nil),
+	})
+}
+
+// Decrypt returns the plain data of encrypted data, and returns other data
+// as it is.
+func Decrypt(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	env := envelope{}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the encrypted data")
+	}
+	params := env.Encryption
+	if params.Version != version || params.KDF != kdf || params.Iterations != iterations {
+		return nil, errors.Errorf("unsupported encryption version %d with %s and %d iterations", params.Version, params.KDF, params.Iterations)
+	}
+	k, err := key(params.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "the data is encrypted")
+	}
+	gcm, err := newGCM(k)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	plain, err := gcm.Open(nil, params.Nonce, env.Data, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt the data: wrong passphrase or corrupted data")
+	}
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ReadFile reads the file, decrypting it if it is encrypted.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := Decrypt(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return plain, nil
+}
+
+// WriteFile writes the file, encrypting it if encryption is enabled.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if Enabled() {
+		encrypted, err := Encrypt(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encrypt %s", path)
+		}
+		data = encrypted
+	}
+	return os.WriteFile(path, data, perm)
+}
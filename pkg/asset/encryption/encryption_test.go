@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// setPassphrase resets the passphrase, keys and salt of the package to the
+// ones of the environment.
+func setPassphrase(t *testing.T, value string) {
+	t.Setenv(PassphraseEnv, value)
+	t.Setenv(KeyCommandEnv, "")
+	passphraseOnce = sync.Once{}
+	passphrase, passphraseErr = nil, nil
+	keys = map[string][]byte{}
+	writeSalt = nil
+}
+
+func TestPBKDF2(t *testing.T) {
+	// Test vector of RFC 7914, section 11, for the pbkdf2-sha256 key
+	// derivation of the encrypted files.
+	expected := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+	assert.Equal(t, expected, hex.EncodeToString(pbkdf2.Key([]byte("passwd"), []byte("salt"), 1, 64, sha256.New)))
+}
+
+func TestReadWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	content := []byte(`{"secret": "value"}`)
+
+	setPassphrase(t, "")
+	assert.False(t, Enabled())
+	if err := WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, content, data, "files are written in plain text without a passphrase")
+
+	setPassphrase(t, "correct horse battery staple")
+	assert.True(t, Enabled())
+	data, err = ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content, data, "plain files are read as they are")
+	}
+	if err := WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, IsEncrypted(data))
+	assert.NotContains(t, string(data), "secret")
+	data, err = ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content, data)
+	}
+
+	setPassphrase(t, "wrong")
+	_, err = ReadFile(path)
+	assert.Regexp(t, "wrong passphrase or corrupted data$", err)
+
+	setPassphrase(t, "")
+	_, err = ReadFile(path)
+	assert.Regexp(t, "the data is encrypted: no passphrase: set OPENSHIFT_INSTALL_STATE_PASSPHRASE or OPENSHIFT_INSTALL_STATE_KEY_COMMAND$", err)
+}
+
+func TestKeyCommand(t *testing.T) {
+	setPassphrase(t, "")
+	t.Setenv(KeyCommandEnv, "/bin/echo")
+	p, err := loadPassphrase()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", string(p))
+	}
+
+	setPassphrase(t, "")
+	script := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho from-kms\n"), 0o700); err != nil { //nolint:gosec // test script
+		t.Fatal(err)
+	}
+	t.Setenv(KeyCommandEnv, script)
+	p, err = loadPassphrase()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "from-kms", string(p))
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	assert.True(t, Sensitive("auth/kubeadmin-password"))
+	assert.True(t, Sensitive("auth/kubeconfig"))
+	assert.True(t, Sensitive(filepath.Join("auth", "kubeconfig")))
+	assert.False(t, Sensitive("bootstrap.ign"))
+	assert.False(t, Sensitive("metadata.json"))
+	assert.False(t, Sensitive("manifests/cluster-config.yaml"))
+	assert.False(t, Sensitive("tls/auth/kubeconfig"))
+}
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 const (
@@ -15,12 +16,28 @@ type Bootstrap struct {
 
 var _ asset.WritableAsset = (*Bootstrap)(nil)
 
-// Generate generates the ignition config for the Bootstrap asset.
+// Generate generates the ignition config for the Bootstrap asset. When the
+// installer provisions a single node with bootstrap in place, it is the
+// ignition config of that node, which bootstraps itself.
 func (a *Bootstrap) Generate(dependencies asset.Parents) error {
-	templateData := a.getTemplateData(dependencies, false)
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+	bootstrapInPlace := installConfig.Config.IsBootstrapInPlaceIPI()
+	if bootstrapInPlace {
+		if err := verifyBootstrapInPlace(installConfig.Config); err != nil {
+			return err
+		}
+	}
+
+	templateData := a.getTemplateData(dependencies, bootstrapInPlace)
 	if err := a.generateConfig(dependencies, templateData); err != nil {
 		return err
 	}
+	if bootstrapInPlace {
+		if err := a.addBootstrapInPlaceFiles(templateData); err != nil {
+			return err
+		}
+	}
 
 	if err := a.generateFile(bootstrapIgnFilename); err != nil {
 		return err
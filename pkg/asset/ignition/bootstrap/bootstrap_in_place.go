@@ -41,10 +41,7 @@ func (a *SingleNodeBootstrapInPlace) Generate(dependencies asset.Parents) error
 	if err := a.generateConfig(dependencies, templateData); err != nil {
 		return err
 	}
-	if err := AddStorageFiles(a.Config, "/", "bootstrap/bootstrap-in-place/files", templateData); err != nil {
-		return err
-	}
-	if err := AddSystemdUnits(a.Config, "bootstrap/bootstrap-in-place/systemd/units", templateData, bootstrapInPlaceEnabledServices); err != nil {
+	if err := a.addBootstrapInPlaceFiles(templateData); err != nil {
 		return err
 	}
 	if err := a.Common.generateFile(singleNodeBootstrapInPlaceIgnFilename); err != nil {
@@ -58,6 +55,15 @@ func (a *SingleNodeBootstrapInPlace) Load(f asset.FileFetcher) (found bool, err
 	return a.load(f, singleNodeBootstrapInPlaceIgnFilename)
 }
 
+// addBootstrapInPlaceFiles adds the files and units which install the node
+// to disk once it has bootstrapped itself.
+func (a *Common) addBootstrapInPlaceFiles(templateData *bootstrapTemplateData) error {
+	if err := AddStorageFiles(a.Config, "/", "bootstrap/bootstrap-in-place/files", templateData); err != nil {
+		return err
+	}
+	return AddSystemdUnits(a.Config, "bootstrap/bootstrap-in-place/systemd/units", templateData, bootstrapInPlaceEnabledServices)
+}
+
 // verifyBootstrapInPlace validate the number of control plane replica is one and that installation disk is set
 func verifyBootstrapInPlace(installConfig *types.InstallConfig) error {
 	errorList := field.ErrorList{}
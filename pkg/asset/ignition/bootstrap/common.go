@@ -200,6 +200,10 @@ func (a *Common) generateConfig(dependencies asset.Parents, templateData *bootst
 
 	a.addParentFiles(dependencies)
 
+	// The node customization of the install config comes last, so that it
+	// replaces the files and units of the installer.
+	ignition.ApplyNodeCustomization(a.Config, installConfig.Config.NodeCustomization, types.NodeRoleBootstrap)
+
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
 		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{
@@ -247,8 +251,10 @@ func (a *Common) getTemplateData(dependencies asset.Parents, bootstrapInPlace bo
 		etcdEndpoints[i] = fmt.Sprintf("https://etcd-%d.%s:2379", i, installConfig.Config.ClusterDomain())
 	}
 
+	mirrorSources := append([]types.ImageContentSource{}, installConfig.Config.ImageContentSources...)
+	mirrorSources = append(mirrorSources, installConfig.MirrorSources...)
 	registries := []sysregistriesv2.Registry{}
-	for _, group := range MergedMirrorSets(installConfig.Config.ImageContentSources) {
+	for _, group := range MergedMirrorSets(mirrorSources) {
 		if len(group.Mirrors) == 0 {
 			continue
 		}
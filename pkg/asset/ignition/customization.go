@@ -0,0 +1,57 @@
+package ignition
+
+import (
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// defaultNodeFileMode is the mode of the files of the node customization
+// which do not set one.
+const defaultNodeFileMode = 0644
+
+// ApplyNodeCustomization merges the files and systemd units of the node
+// customization for the role into the Ignition config, replacing the files
+// and units of the config with the same path or name.
+func ApplyNodeCustomization(config *igntypes.Config, customization *types.NodeCustomization, role types.NodeRole) {
+	for _, f := range customization.FilesFor(role) {
+		mode := defaultNodeFileMode
+		if f.Mode != nil {
+			mode = *f.Mode
+		}
+		file := FileFromString(f.Path, "root", mode, f.Contents)
+		replaced := false
+		for i := range config.Storage.Files {
+			if config.Storage.Files[i].Path == file.Path {
+				config.Storage.Files[i] = file
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			config.Storage.Files = append(config.Storage.Files, file)
+		}
+	}
+
+	for _, u := range customization.UnitsFor(role) {
+		unit := igntypes.Unit{
+			Name:     u.Name,
+			Contents: ignutil.StrToPtr(u.Contents),
+		}
+		if u.Enabled {
+			unit.Enabled = ignutil.BoolToPtr(true)
+		}
+		replaced := false
+		for i := range config.Systemd.Units {
+			if config.Systemd.Units[i].Name == unit.Name {
+				config.Systemd.Units[i] = unit
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			config.Systemd.Units = append(config.Systemd.Units, unit)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
 )
 
 const (
@@ -40,6 +41,9 @@ func (a *Master) Generate(dependencies asset.Parents) error {
 	dependencies.Get(installConfig, rootCA)
 
 	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "master")
+	// The node customization makes the pointer config differ from the default
+	// one, so that MasterIgnitionCustomizations also saves it to a machine config.
+	ignition.ApplyNodeCustomization(a.Config, installConfig.Config.NodeCustomization, types.NodeRoleMaster)
 
 	data, err := ignition.Marshal(a.Config)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
@@ -57,3 +58,87 @@ func TestMasterGenerate(t *testing.T) {
 	}
 	assert.Equal(t, expectedIgnitionConfigNames, actualIgnitionConfigNames, "unexpected names for master ignition configs")
 }
+
+// TestMasterGenerateNodeCustomization tests that the master asset merges in
+// the node customization of the control plane.
+func TestMasterGenerateNodeCustomization(t *testing.T) {
+	installConfig := installconfig.MakeAsset(
+		&types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cluster",
+			},
+			BaseDomain: "test-domain",
+			Platform: types.Platform{
+				AWS: &aws.Platform{
+					Region: "us-east",
+				},
+			},
+			NodeCustomization: &types.NodeCustomization{
+				Files: []types.NodeFile{
+					{Path: "/etc/motd", Contents: "hello"},
+					{Path: "/etc/worker", Roles: []types.NodeRole{types.NodeRoleWorker}, Contents: "worker"},
+				},
+				Units: []types.NodeUnit{
+					{Name: "hello.service", Enabled: true, Contents: "[Unit]"},
+				},
+			},
+		})
+
+	rootCA := &tls.RootCA{}
+	err := rootCA.Generate(nil)
+	assert.NoError(t, err, "unexpected error generating root CA")
+
+	parents := asset.Parents{}
+	parents.Add(installConfig, rootCA)
+
+	master := &Master{}
+	err = master.Generate(parents)
+	assert.NoError(t, err, "unexpected error generating master asset")
+	if assert.Len(t, master.Config.Storage.Files, 1) {
+		assert.Equal(t, "/etc/motd", master.Config.Storage.Files[0].Path)
+		assert.Equal(t, 0644, *master.Config.Storage.Files[0].Mode)
+	}
+	if assert.Len(t, master.Config.Systemd.Units, 1) {
+		assert.Equal(t, "hello.service", master.Config.Systemd.Units[0].Name)
+		assert.Equal(t, true, *master.Config.Systemd.Units[0].Enabled)
+	}
+}
+
+// TestMasterGeneratePointerIgnition tests that the master asset points at the
+// machine config server URL of the install config, and trusts its CA bundle.
+func TestMasterGeneratePointerIgnition(t *testing.T) {
+	installConfig := installconfig.MakeAsset(
+		&types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cluster",
+			},
+			BaseDomain: "test-domain",
+			Platform: types.Platform{
+				AWS: &aws.Platform{
+					Region: "us-east",
+				},
+			},
+			PointerIgnition: &types.PointerIgnition{
+				URL:      "https://mcs.example.com:8443/test-cluster/",
+				CABundle: "-----BEGIN CERTIFICATE-----\nproxy\n-----END CERTIFICATE-----\n",
+			},
+		})
+
+	rootCA := &tls.RootCA{}
+	err := rootCA.Generate(nil)
+	assert.NoError(t, err, "unexpected error generating root CA")
+
+	parents := asset.Parents{}
+	parents.Add(installConfig, rootCA)
+
+	master := &Master{}
+	err = master.Generate(parents)
+	assert.NoError(t, err, "unexpected error generating master asset")
+	if assert.Len(t, master.Config.Ignition.Config.Merge, 1) {
+		assert.Equal(t, "https://mcs.example.com:8443/test-cluster/config/master", *master.Config.Ignition.Config.Merge[0].Source)
+	}
+	if assert.Len(t, master.Config.Ignition.Security.TLS.CertificateAuthorities, 2) {
+		assert.Equal(t, dataurl.EncodeBytes(rootCA.Cert()), *master.Config.Ignition.Security.TLS.CertificateAuthorities[0].Source)
+		assert.Equal(t, dataurl.EncodeBytes([]byte(installConfig.Config.PointerIgnition.CABundle)), *master.Config.Ignition.Security.TLS.CertificateAuthorities[1].Source)
+	}
+}
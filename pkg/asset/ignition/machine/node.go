@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"path"
 
 	ignutil "github.com/coreos/ignition/v2/config/util"
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
@@ -47,25 +48,39 @@ func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, ro
 			ignitionHost = net.JoinHostPort(installConfig.VSphere.APIVIPs[0], "22623")
 		}
 	}
+	source := &url.URL{
+		Scheme: "https",
+		Host:   ignitionHost,
+		Path:   fmt.Sprintf("/config/%s", role),
+	}
+	certificateAuthorities := []igntypes.Resource{{
+		Source: ignutil.StrToPtr(dataurl.EncodeBytes(rootCA)),
+	}}
+	if pointer := installConfig.PointerIgnition; pointer != nil {
+		// The URL is validated with the install config.
+		if pointer.URL != "" {
+			if base, err := url.Parse(pointer.URL); err == nil {
+				base.Path = path.Join("/", base.Path, "config", role)
+				source = base
+			}
+		}
+		if pointer.CABundle != "" {
+			certificateAuthorities = append(certificateAuthorities, igntypes.Resource{
+				Source: ignutil.StrToPtr(dataurl.EncodeBytes([]byte(pointer.CABundle))),
+			})
+		}
+	}
 	return &igntypes.Config{
 		Ignition: igntypes.Ignition{
 			Version: igntypes.MaxVersion.String(),
 			Config: igntypes.IgnitionConfig{
 				Merge: []igntypes.Resource{{
-					Source: ignutil.StrToPtr(func() *url.URL {
-						return &url.URL{
-							Scheme: "https",
-							Host:   ignitionHost,
-							Path:   fmt.Sprintf("/config/%s", role),
-						}
-					}().String()),
+					Source: ignutil.StrToPtr(source.String()),
 				}},
 			},
 			Security: igntypes.Security{
 				TLS: igntypes.TLS{
-					CertificateAuthorities: []igntypes.Resource{{
-						Source: ignutil.StrToPtr(dataurl.EncodeBytes(rootCA)),
-					}},
+					CertificateAuthorities: certificateAuthorities,
 				},
 			},
 		},
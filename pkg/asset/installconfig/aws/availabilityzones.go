@@ -11,6 +11,19 @@ import (
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 )
 
+// Zone holds metadata for a zone of the region.
+type Zone struct {
+	// Name is the name of the zone.
+	Name string
+
+	// Type is the type of the zone, e.g. availability-zone or local-zone.
+	Type string
+
+	// GroupName is the zone group name. For Local Zones, the name of the
+	// associated group, for example us-west-2-lax-1.
+	GroupName string
+}
+
 // describeAvailabilityZones retrieves a list of all zones for the given region.
 func describeAvailabilityZones(ctx context.Context, session *session.Session, region string) ([]*ec2.AvailabilityZone, error) {
 	client := ec2.New(session, aws.NewConfig().WithRegion(region))
@@ -52,3 +65,24 @@ func availabilityZones(ctx context.Context, session *session.Session, region str
 
 	return zones, nil
 }
+
+// localZones retrieves the Local Zones of the region which the account opted
+// in, indexed by name.
+func localZones(ctx context.Context, session *session.Session, region string) (map[string]Zone, error) {
+	azs, err := describeAvailabilityZones(ctx, session, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching local zones")
+	}
+	zones := map[string]Zone{}
+	for _, zone := range azs {
+		if aws.StringValue(zone.ZoneType) != typesaws.LocalZoneType || aws.StringValue(zone.OptInStatus) == ec2.AvailabilityZoneOptInStatusNotOptedIn {
+			continue
+		}
+		zones[*zone.ZoneName] = Zone{
+			Name:      *zone.ZoneName,
+			Type:      *zone.ZoneType,
+			GroupName: aws.StringValue(zone.GroupName),
+		}
+	}
+	return zones, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // InstanceType holds metadata for an instance type.
@@ -36,3 +37,35 @@ func instanceTypes(ctx context.Context, session *session.Session, region string)
 
 	return types, nil
 }
+
+// instanceTypeZones retrieves the zones, among the given ones, where the
+// instance type is offered.
+func instanceTypeZones(ctx context.Context, session *session.Session, region string, instanceType string, zones []string) (sets.String, error) {
+	offered := sets.NewString()
+
+	client := ec2.New(session, aws.NewConfig().WithRegion(region))
+	if err := client.DescribeInstanceTypeOfferingsPagesWithContext(ctx,
+		&ec2.DescribeInstanceTypeOfferingsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("location"),
+					Values: aws.StringSlice(zones),
+				},
+				{
+					Name:   aws.String("instance-type"),
+					Values: []*string{aws.String(instanceType)},
+				},
+			},
+			LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		},
+		func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+			for _, offering := range page.InstanceTypeOfferings {
+				offered.Insert(aws.StringValue(offering.Location))
+			}
+			return !lastPage
+		}); err != nil {
+		return nil, errors.Wrap(err, "fetching instance type offerings")
+	}
+
+	return offered, nil
+}
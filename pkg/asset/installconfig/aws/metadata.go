@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 )
@@ -16,6 +17,7 @@ import (
 type Metadata struct {
 	session           *session.Session
 	availabilityZones []string
+	localZones        map[string]Zone
 	privateSubnets    map[string]Subnet
 	publicSubnets     map[string]Subnet
 	edgeSubnets       map[string]Subnet
@@ -76,9 +78,30 @@ func (m *Metadata) AvailabilityZones(ctx context.Context) ([]string, error) {
 	return m.availabilityZones, nil
 }
 
+// LocalZones retrieves the Local Zones of the configured region which the
+// account opted in, indexed by name.
+func (m *Metadata) LocalZones(ctx context.Context) (map[string]Zone, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.localZones == nil {
+		session, err := m.unlockedSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		m.localZones, err = localZones(ctx, session, m.Region)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m.localZones, nil
+}
+
 // EdgeSubnets retrieves subnet metadata indexed by subnet ID, for
 // subnets that the cloud-provider logic considers to be edge
-// (i.e. Local Zone).
+// (i.e. Local Zone or Outpost).
 func (m *Metadata) EdgeSubnets(ctx context.Context) (map[string]Subnet, error) {
 	err := m.populateSubnets(ctx)
 	if err != nil {
@@ -175,3 +198,18 @@ func (m *Metadata) InstanceTypes(ctx context.Context) (map[string]InstanceType,
 
 	return m.instanceTypes, nil
 }
+
+// InstanceTypeZones retrieves the zones, among the given ones, where the
+// instance type is offered.
+func (m *Metadata) InstanceTypeZones(ctx context.Context, instanceType string, zones []string) (sets.String, error) {
+	session, err := m.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := instanceTypeZones(ctx, session, m.Region, instanceType, zones)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the offerings of instance type %s", instanceType)
+	}
+	return offered, nil
+}
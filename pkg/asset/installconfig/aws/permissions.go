@@ -8,6 +8,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	ccaws "github.com/openshift/cloud-credential-operator/pkg/aws"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 // PermissionGroup is the group of permissions needed by cluster creation, operation, or teardown.
@@ -32,6 +34,22 @@ const (
 	// PermissionDeleteSharedInstanceRole is a set of permissions required when the installer destroys resources from a
 	// cluster with user-supplied IAM roles for instances.
 	PermissionDeleteSharedInstanceRole PermissionGroup = "delete-shared-instance-role"
+
+	// PermissionCreateHostedZone is an additional set of permissions required when the installer creates the private
+	// hosted zone of the cluster.
+	PermissionCreateHostedZone PermissionGroup = "create-hosted-zone"
+
+	// PermissionDeleteHostedZone is a set of permissions required when the installer destroys the private hosted zone
+	// of the cluster.
+	PermissionDeleteHostedZone PermissionGroup = "delete-hosted-zone"
+
+	// PermissionKMSEncryptionKeys is an additional set of permissions required when the root volumes of the machines
+	// are encrypted with a customer managed KMS key.
+	PermissionKMSEncryptionKeys PermissionGroup = "kms-encryption-keys"
+
+	// PermissionMintCreds is the set of permissions required by the cloud-credential-operator to mint the credentials
+	// of the cluster components. The simulator checks it separately, so it is only used to build policies.
+	PermissionMintCreds PermissionGroup = "mint-creds"
 )
 
 var permissions = map[PermissionGroup][]string{
@@ -109,6 +127,8 @@ var permissions = map[PermissionGroup][]string{
 		"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
 
 		// IAM related perms
+		// The role and the instance profile of the bootstrap instance are
+		// created even when the machine pools use pre-existing ones.
 		"iam:AddRoleToInstanceProfile",
 		"iam:CreateInstanceProfile",
 		"iam:CreateRole",
@@ -131,8 +151,6 @@ var permissions = map[PermissionGroup][]string{
 		// Route53 related perms
 		"route53:ChangeResourceRecordSets",
 		"route53:ChangeTagsForResource",
-		"route53:CreateHostedZone",
-		"route53:DeleteHostedZone",
 		"route53:GetChange",
 		"route53:GetHostedZone",
 		"route53:ListHostedZones",
@@ -210,6 +228,39 @@ var permissions = map[PermissionGroup][]string{
 		"ec2:ModifySubnetAttribute",
 		"ec2:ModifyVpcAttribute",
 	},
+	// Permissions required for creating the private hosted zone
+	PermissionCreateHostedZone: {
+		"route53:CreateHostedZone",
+	},
+	// Permissions required for deleting the private hosted zone
+	PermissionDeleteHostedZone: {
+		"route53:DeleteHostedZone",
+	},
+	// Permissions required for using a customer managed KMS key for the root volumes
+	PermissionKMSEncryptionKeys: {
+		"kms:CreateGrant",
+		"kms:Decrypt",
+		"kms:DescribeKey",
+		"kms:Encrypt",
+		"kms:GenerateDataKey",
+		"kms:GenerateDataKeyWithoutPlainText",
+		"kms:ListGrants",
+		"kms:RevokeGrant",
+	},
+	// Permissions required for minting the credentials of the cluster components
+	PermissionMintCreds: {
+		"iam:CreateAccessKey",
+		"iam:CreateUser",
+		"iam:DeleteAccessKey",
+		"iam:DeleteUser",
+		"iam:DeleteUserPolicy",
+		"iam:GetUser",
+		"iam:GetUserPolicy",
+		"iam:ListAccessKeys",
+		"iam:PutUserPolicy",
+		"iam:TagUser",
+		"iam:SimulatePrincipalPolicy",
+	},
 	// Permissions required for deleting network resources
 	PermissionDeleteNetworking: {
 		"ec2:DeleteDhcpOptions",
@@ -290,3 +341,52 @@ func ValidateCreds(ssn *session.Session, groups []PermissionGroup, region string
 
 	return errors.New("AWS credentials cannot be used to either create new creds or use as-is")
 }
+
+// RequiredPermissionGroups returns the permission groups needed to install
+// and destroy the cluster of the install config.
+func RequiredPermissionGroups(ic *types.InstallConfig) []PermissionGroup {
+	groups := []PermissionGroup{PermissionCreateBase}
+	usingExistingVPC := len(ic.AWS.Subnets) != 0
+	usingExistingHostedZone := ic.AWS.HostedZone != ""
+
+	if !usingExistingVPC {
+		groups = append(groups, PermissionCreateNetworking)
+	}
+	if !usingExistingHostedZone {
+		groups = append(groups, PermissionCreateHostedZone)
+	}
+	if usesKMSKey(ic) {
+		groups = append(groups, PermissionKMSEncryptionKeys)
+	}
+
+	// Add delete permissions for non-C2S installs.
+	if !awstypes.IsSecretRegion(ic.AWS.Region) {
+		groups = append(groups, PermissionDeleteBase)
+		if usingExistingVPC {
+			groups = append(groups, PermissionDeleteSharedNetworking)
+		} else {
+			groups = append(groups, PermissionDeleteNetworking)
+		}
+		if !usingExistingHostedZone {
+			groups = append(groups, PermissionDeleteHostedZone)
+		}
+	}
+	return groups
+}
+
+// usesKMSKey returns true if the root volumes of any machine pool are
+// encrypted with a customer managed KMS key.
+func usesKMSKey(ic *types.InstallConfig) bool {
+	for _, mp := range []*types.MachinePool{ic.ControlPlane, ic.WorkerMachinePool()} {
+		if mp == nil {
+			continue
+		}
+		awsMP := &awstypes.MachinePool{}
+		awsMP.Set(ic.AWS.DefaultMachinePlatform)
+		awsMP.Set(mp.Platform.AWS)
+		if awsMP.EC2RootVolume.KMSKeyARN != "" {
+			return true
+		}
+	}
+	return false
+}
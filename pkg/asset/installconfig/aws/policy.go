@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// policyVersion is the version of the IAM policy language.
+const policyVersion = "2012-10-17"
+
+// PolicyDocument is an IAM policy document.
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of an IAM policy document.
+type PolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// NewPolicyDocument returns a policy document which allows the permissions of
+// the groups, sorted and without duplicates.
+func NewPolicyDocument(groups []PermissionGroup) (*PolicyDocument, error) {
+	seen := map[string]bool{}
+	actions := []string{}
+	for _, group := range groups {
+		groupPerms, ok := permissions[group]
+		if !ok {
+			return nil, errors.Errorf("unable to access permissions group %s", group)
+		}
+		for _, action := range groupPerms {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+	sort.Strings(actions)
+
+	return &PolicyDocument{
+		Version: policyVersion,
+		Statement: []PolicyStatement{{
+			Effect:   "Allow",
+			Action:   actions,
+			Resource: "*",
+		}},
+	}, nil
+}
+
+// LeastPrivilegePolicy returns the policy document with the permissions
+// needed by the installer to install and destroy the cluster of the install
+// config, so that the credentials can be provisioned ahead of the install.
+// Unless the credentials mode is manual or passthrough, the policy includes
+// the permissions the cloud-credential-operator needs to mint the credentials
+// of the cluster components; in passthrough mode those components use the
+// credentials as they are, and need the permissions of their
+// CredentialsRequests too, which are not included.
+func LeastPrivilegePolicy(ic *types.InstallConfig) (*PolicyDocument, error) {
+	groups := RequiredPermissionGroups(ic)
+	switch ic.CredentialsMode {
+	case "", types.MintCredentialsMode:
+		groups = append(groups, PermissionMintCreds)
+	}
+	return NewPolicyDocument(groups)
+}
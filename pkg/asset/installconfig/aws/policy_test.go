@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestRequiredPermissionGroups(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform awstypes.Platform
+		edit     func(ic *types.InstallConfig)
+		expected []PermissionGroup
+	}{
+		{
+			name:     "full IPI",
+			platform: awstypes.Platform{Region: "us-east-1"},
+			expected: []PermissionGroup{
+				PermissionCreateBase,
+				PermissionCreateNetworking,
+				PermissionCreateHostedZone,
+				PermissionDeleteBase,
+				PermissionDeleteNetworking,
+				PermissionDeleteHostedZone,
+			},
+		},
+		{
+			name: "existing VPC and hosted zone",
+			platform: awstypes.Platform{
+				Region:     "us-east-1",
+				Subnets:    []string{"subnet-1"},
+				HostedZone: "Z1",
+			},
+			expected: []PermissionGroup{
+				PermissionCreateBase,
+				PermissionDeleteBase,
+				PermissionDeleteSharedNetworking,
+			},
+		},
+		{
+			name: "custom KMS key in a secret region",
+			platform: awstypes.Platform{
+				Region: "us-iso-east-1",
+				DefaultMachinePlatform: &awstypes.MachinePool{
+					EC2RootVolume: awstypes.EC2RootVolume{KMSKeyARN: "arn:aws:kms:us-iso-east-1:123456789012:key/abcd"},
+				},
+			},
+			expected: []PermissionGroup{
+				PermissionCreateBase,
+				PermissionCreateNetworking,
+				PermissionCreateHostedZone,
+				PermissionKMSEncryptionKeys,
+			},
+		},
+		{
+			name:     "pre-existing instance profiles",
+			platform: awstypes.Platform{Region: "us-east-1"},
+			edit: func(ic *types.InstallConfig) {
+				ic.ControlPlane.Platform.AWS = &awstypes.MachinePool{IAMInstanceProfile: "master-profile"}
+				ic.Compute[0].Platform.AWS = &awstypes.MachinePool{IAMInstanceProfile: "worker-profile"}
+			},
+			expected: []PermissionGroup{
+				PermissionCreateBase,
+				PermissionCreateNetworking,
+				PermissionCreateHostedZone,
+				PermissionDeleteBase,
+				PermissionDeleteNetworking,
+				PermissionDeleteHostedZone,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				ControlPlane: &types.MachinePool{Name: "master"},
+				Compute:      []types.MachinePool{{Name: "worker"}},
+				Platform:     types.Platform{AWS: &tc.platform},
+			}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+			assert.Equal(t, tc.expected, RequiredPermissionGroups(ic))
+		})
+	}
+}
+
+func TestLeastPrivilegePolicy(t *testing.T) {
+	ic := &types.InstallConfig{
+		CredentialsMode: types.PassthroughCredentialsMode,
+		ControlPlane:    &types.MachinePool{Name: "master", Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{IAMRole: "role"}}},
+		Compute:         []types.MachinePool{{Name: "worker", Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{IAMRole: "role"}}}},
+		Platform: types.Platform{AWS: &awstypes.Platform{
+			Region:     "us-iso-east-1",
+			Subnets:    []string{"subnet-1"},
+			HostedZone: "Z1",
+		}},
+	}
+
+	policy, err := LeastPrivilegePolicy(ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2012-10-17", policy.Version)
+	if !assert.Len(t, policy.Statement, 1) {
+		return
+	}
+	statement := policy.Statement[0]
+	assert.Equal(t, "Allow", statement.Effect)
+	assert.Equal(t, "*", statement.Resource)
+	assert.IsIncreasing(t, statement.Action)
+	// The role of the bootstrap instance is created even with the roles of
+	// the machine pools provided.
+	assert.Contains(t, statement.Action, "iam:CreateRole")
+	assert.NotContains(t, statement.Action, "iam:CreateUser")
+	assert.NotContains(t, statement.Action, "route53:CreateHostedZone")
+	assert.NotContains(t, statement.Action, "ec2:CreateVpc")
+
+	ic.CredentialsMode = ""
+	policy, err = LeastPrivilegePolicy(ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, policy.Statement[0].Action, "iam:CreateUser")
+}
+
+func TestBootstrapPermissions(t *testing.T) {
+	// The bootstrap instance always gets a role and an instance profile of
+	// its own, whatever the machine pools use.
+	cases := []struct {
+		name   string
+		action string
+	}{
+		{name: "create role", action: "iam:CreateRole"},
+		{name: "put role policy", action: "iam:PutRolePolicy"},
+		{name: "create instance profile", action: "iam:CreateInstanceProfile"},
+		{name: "add role to instance profile", action: "iam:AddRoleToInstanceProfile"},
+		{name: "pass role", action: "iam:PassRole"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Contains(t, permissions[PermissionCreateBase], tc.action)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,14 +20,20 @@ import (
 	"github.com/sirupsen/logrus"
 	ini "gopkg.in/ini.v1"
 
+	installcredentials "github.com/openshift/installer/pkg/asset/installconfig/credentials"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/version"
 )
 
+// credentialHelperProviderName is the name of the provider of the
+// credentials printed by the credential helper.
+const credentialHelperProviderName = "CredentialHelperProvider"
+
 var (
 	onceLoggers = map[string]*sync.Once{
 		credentials.SharedCredsProviderName: new(sync.Once),
 		credentials.EnvProviderName:         new(sync.Once),
+		credentialHelperProviderName:        new(sync.Once),
 		"credentialsFromSession":            new(sync.Once),
 	}
 )
@@ -87,13 +94,18 @@ func GetSessionWithOptions(optFuncs ...SessionOptions) (*session.Session, error)
 
 func getCredentials(options session.Options) (*credentials.Credentials, error) {
 	sharedCredentialsProvider := &credentials.SharedCredentialsProvider{}
+	helperProvider := &credentialHelperProvider{source: installcredentials.HelperSource("aws")}
 	providers := []credentials.Provider{
 		&credentials.EnvProvider{},
+		helperProvider,
 		sharedCredentialsProvider,
 	}
 
 	creds := credentials.NewChainCredentials(providers)
 	credsValue, err := creds.Get()
+	if err != nil && helperProvider.err != nil {
+		return nil, helperProvider.err
+	}
 	if err != nil && errCodeEquals(err, "NoCredentialProviders") {
 		// getCredentialsFromSession returns credentials derived from a session. A
 		// session uses the AWS SDK Go chain of providers so may use a provider (e.g.,
@@ -114,10 +126,85 @@ func getCredentials(options session.Options) (*credentials.Credentials, error) {
 		onceLoggers[credentials.EnvProviderName].Do(func() {
 			logrus.Info("Credentials loaded from default AWS environment variables")
 		})
+	case credentialHelperProviderName:
+		onceLoggers[credentialHelperProviderName].Do(func() {
+			logrus.Infof("Credentials loaded from the %q profile printed by %s", credentialsProfile(), helperProvider.source)
+		})
 	}
 	return creds, nil
 }
 
+// credentialHelperProvider retrieves the credentials of the profile from the
+// credential helper, which prints them in the format of the shared
+// credentials file.
+type credentialHelperProvider struct {
+	source installcredentials.Source
+	// err is the failure of the credential helper, which the chain of
+	// providers would otherwise hide by moving on to the next provider.
+	err       error
+	retrieved bool
+}
+
+// Retrieve returns the credentials printed by the credential helper.
+func (p *credentialHelperProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved = false
+	content, err := p.source.Load(context.TODO())
+	if errors.Is(err, installcredentials.ErrNotFound) {
+		return credentials.Value{ProviderName: credentialHelperProviderName}, awserr.New("CredentialHelperNotFound", "the credential helper has no credentials", nil)
+	}
+	if err == nil {
+		var value credentials.Value
+		value, err = parseSharedCredentials(content, credentialsProfile())
+		if err == nil {
+			p.retrieved = true
+			return value, nil
+		}
+	}
+	p.err = errors.Wrapf(err, "failed to load credentials from %s", p.source)
+	return credentials.Value{ProviderName: credentialHelperProviderName}, p.err
+}
+
+// IsExpired returns true if the credentials were not retrieved. The
+// credential helper is run again when the credentials are retrieved again.
+func (p *credentialHelperProvider) IsExpired() bool {
+	return !p.retrieved
+}
+
+// parseSharedCredentials returns the credentials of the profile in the
+// content of a shared credentials file.
+func parseSharedCredentials(content []byte, profile string) (credentials.Value, error) {
+	file, err := ini.Load(content)
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to parse the credentials")
+	}
+	section, err := file.GetSection(profile)
+	if err != nil {
+		return credentials.Value{}, errors.Errorf("the credentials have no %q profile", profile)
+	}
+	value := credentials.Value{
+		AccessKeyID:     section.Key("aws_access_key_id").String(),
+		SecretAccessKey: section.Key("aws_secret_access_key").String(),
+		SessionToken:    section.Key("aws_session_token").String(),
+		ProviderName:    credentialHelperProviderName,
+	}
+	if value.AccessKeyID == "" {
+		return credentials.Value{}, errors.Errorf("the %q profile of the credentials has no aws_access_key_id", profile)
+	}
+	if value.SecretAccessKey == "" {
+		return credentials.Value{}, errors.Errorf("the %q profile of the credentials has no aws_secret_access_key", profile)
+	}
+	return value, nil
+}
+
+// credentialsProfile returns the profile of the credentials, AWS_PROFILE or
+// default.
+func credentialsProfile() string {
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	return "default"
+}
+
 func getCredentialsFromSession(options session.Options) (*credentials.Credentials, error) {
 	sess, err := session.NewSessionWithOptions(options)
 	if err != nil {
@@ -143,7 +230,7 @@ func getCredentialsFromSession(options session.Options) (*credentials.Credential
 // static credentials safe for installer to transfer to cluster for use as-is.
 func IsStaticCredentials(credsValue credentials.Value) bool {
 	switch credsValue.ProviderName {
-	case credentials.EnvProviderName, credentials.StaticProviderName, credentials.SharedCredsProviderName, session.EnvProviderName:
+	case credentials.EnvProviderName, credentials.StaticProviderName, credentials.SharedCredsProviderName, credentialHelperProviderName, session.EnvProviderName:
 		return credsValue.SessionToken == ""
 	}
 	if strings.HasPrefix(credsValue.ProviderName, "SharedConfigCredentials") {
@@ -209,10 +296,7 @@ func getUserCredentials() error {
 		creds.Section("").Comment = "https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-files.html"
 	}
 
-	profile := os.Getenv("AWS_PROFILE")
-	if profile == "" {
-		profile = "default"
-	}
+	profile := credentialsProfile()
 
 	creds.Section(profile).Key("aws_access_key_id").SetValue(keyID)
 	creds.Section(profile).Key("aws_secret_access_key").SetValue(secretKey)
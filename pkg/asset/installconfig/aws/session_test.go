@@ -1,11 +1,16 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	installcredentials "github.com/openshift/installer/pkg/asset/installconfig/credentials"
+
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 )
 
@@ -51,3 +56,113 @@ func TestAWSResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSharedCredentials(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		profile  string
+		expected credentials.Value
+		errorMsg string
+	}{
+		{
+			name:     "static credentials",
+			content:  "[default]\naws_access_key_id = AKID\naws_secret_access_key = SECRET\n",
+			profile:  "default",
+			expected: credentials.Value{AccessKeyID: "AKID", SecretAccessKey: "SECRET", ProviderName: credentialHelperProviderName},
+		},
+		{
+			name:     "temporary credentials of a profile",
+			content:  "[default]\naws_access_key_id = AKID\naws_secret_access_key = SECRET\n[ci]\naws_access_key_id = CIKID\naws_secret_access_key = CISECRET\naws_session_token = TOKEN\n",
+			profile:  "ci",
+			expected: credentials.Value{AccessKeyID: "CIKID", SecretAccessKey: "CISECRET", SessionToken: "TOKEN", ProviderName: credentialHelperProviderName},
+		},
+		{
+			name:     "missing profile",
+			content:  "[default]\naws_access_key_id = AKID\naws_secret_access_key = SECRET\n",
+			profile:  "ci",
+			errorMsg: `the credentials have no "ci" profile`,
+		},
+		{
+			name:     "missing secret",
+			content:  "[default]\naws_access_key_id = AKID\n",
+			profile:  "default",
+			errorMsg: `the "default" profile of the credentials has no aws_secret_access_key`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parseSharedCredentials([]byte(tc.content), tc.profile)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+type fakeCredentialsSource struct {
+	content string
+	err     error
+}
+
+func (s *fakeCredentialsSource) Load(ctx context.Context) ([]byte, error) {
+	return []byte(s.content), s.err
+}
+
+func (s *fakeCredentialsSource) String() string {
+	return "fake helper"
+}
+
+func TestCredentialHelperProvider(t *testing.T) {
+	cases := []struct {
+		name        string
+		source      *fakeCredentialsSource
+		expected    credentials.Value
+		errorMsg    string
+		helperError bool
+	}{
+		{
+			name:     "credentials",
+			source:   &fakeCredentialsSource{content: "[default]\naws_access_key_id = AKID\naws_secret_access_key = SECRET\n"},
+			expected: credentials.Value{AccessKeyID: "AKID", SecretAccessKey: "SECRET", ProviderName: credentialHelperProviderName},
+		},
+		{
+			name:     "no credentials",
+			source:   &fakeCredentialsSource{err: installcredentials.ErrNotFound},
+			expected: credentials.Value{ProviderName: credentialHelperProviderName},
+			errorMsg: "CredentialHelperNotFound: the credential helper has no credentials",
+		},
+		{
+			name:        "helper failure",
+			source:      &fakeCredentialsSource{err: errors.New("exit status 1")},
+			expected:    credentials.Value{ProviderName: credentialHelperProviderName},
+			errorMsg:    "failed to load credentials from fake helper: exit status 1",
+			helperError: true,
+		},
+		{
+			name:        "invalid credentials",
+			source:      &fakeCredentialsSource{content: "[default]\naws_secret_access_key = SECRET\n"},
+			expected:    credentials.Value{ProviderName: credentialHelperProviderName},
+			errorMsg:    `failed to load credentials from fake helper: the "default" profile of the credentials has no aws_access_key_id`,
+			helperError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("AWS_PROFILE", "")
+			provider := &credentialHelperProvider{source: tc.source}
+			value, err := provider.Retrieve()
+			assert.Equal(t, tc.expected, value)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.errorMsg != "", provider.IsExpired())
+			assert.Equal(t, tc.helperError, provider.err != nil)
+		})
+	}
+}
@@ -42,6 +42,9 @@ type Subnet struct {
 	// Public is the flag to define the subnet public.
 	Public bool
 
+	// OutpostARN is the ARN of the AWS Outpost of the subnet, if any.
+	OutpostARN string
+
 	// PreferredEdgeInstanceType is the preferred instance type on the subnet's zone.
 	// It's used for the edge pools which does not offer the same type across zone groups.
 	PreferredEdgeInstanceType string
@@ -108,11 +111,12 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 				}
 
 				metas[*subnet.SubnetId] = Subnet{
-					ID:     *subnet.SubnetId,
-					ARN:    *subnet.SubnetArn,
-					Zone:   *subnet.AvailabilityZone,
-					CIDR:   *subnet.CidrBlock,
-					Public: false,
+					ID:         *subnet.SubnetId,
+					ARN:        *subnet.SubnetArn,
+					Zone:       *subnet.AvailabilityZone,
+					CIDR:       *subnet.CidrBlock,
+					Public:     false,
+					OutpostARN: aws.StringValue(subnet.OutpostArn),
 				}
 				zoneNames = append(zoneNames, subnet.AvailabilityZone)
 			}
@@ -168,6 +172,13 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 		meta.ZoneType = *availabilityZones[meta.Zone].ZoneType
 		meta.ZoneGroupName = *availabilityZones[meta.Zone].GroupName
 
+		// AWS Outposts subnets are grouped as Edge subnets, whatever their routes.
+		if meta.OutpostARN != "" {
+			meta.ZoneType = typesaws.OutpostZoneType
+			subnetGroups.Edge[id] = meta
+			continue
+		}
+
 		// AWS Local Zones are grouped as Edge subnets
 		if meta.ZoneType == typesaws.LocalZoneType {
 			// Local Zones is supported only in Public subnets
@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/pkg/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -21,6 +24,7 @@ import (
 	"github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/validation"
 )
 
 type resourceRequirements struct {
@@ -38,6 +42,36 @@ var computeReq = resourceRequirements{
 	minimumMemory: 8192,
 }
 
+// controlPlaneInstanceActions are the actions which the IAM role of a
+// pre-existing control plane instance profile must allow.
+var controlPlaneInstanceActions = []string{
+	"ec2:AttachVolume",
+	"ec2:AuthorizeSecurityGroupIngress",
+	"ec2:CreateSecurityGroup",
+	"ec2:CreateTags",
+	"ec2:CreateVolume",
+	"ec2:DeleteSecurityGroup",
+	"ec2:DeleteVolume",
+	"ec2:DescribeInstances",
+	"ec2:DescribeRegions",
+	"ec2:DetachVolume",
+	"ec2:ModifyInstanceAttribute",
+	"ec2:ModifyVolume",
+	"ec2:RevokeSecurityGroupIngress",
+	"elasticloadbalancing:CreateLoadBalancer",
+	"elasticloadbalancing:DeleteLoadBalancer",
+	"elasticloadbalancing:DescribeLoadBalancers",
+	"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+	"kms:DescribeKey",
+}
+
+// computeInstanceActions are the actions which the IAM role of a pre-existing
+// compute instance profile must allow.
+var computeInstanceActions = []string{
+	"ec2:DescribeInstances",
+	"ec2:DescribeRegions",
+}
+
 // Validate executes platform-specific validation.
 func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
@@ -56,18 +90,26 @@ func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig)
 		fldPath := field.NewPath("compute").Index(idx)
 
 		// Pool's specific validation.
-		// Edge Compute Pool: AWS Local Zones is valid only when installing in existing VPC.
+		// Edge Compute Pool: when installing in a new VPC, the installer creates
+		// the subnets of the Local Zones of the pool. AWS Outposts is valid only
+		// when installing in existing VPC.
 		if compute.Name == types.MachinePoolEdgeRoleName {
 			if len(config.Platform.AWS.Subnets) == 0 {
-				return errors.New(field.Required(fldPath, "invalid install config. edge machine pool is valid when installing in existing VPC").Error())
-			}
-			edgeSubnets, err := meta.EdgeSubnets(ctx)
-			if err != nil {
-				errMsg := fmt.Sprintf("%s pool. %v", compute.Name, err.Error())
-				return errors.New(field.Invalid(field.NewPath("platform", "aws", "subnets"), config.Platform.AWS.Subnets, errMsg).Error())
-			}
-			if len(edgeSubnets) == 0 {
-				return errors.New(field.Required(fldPath, "invalid install config. There is no valid subnets for edge machine pool").Error())
+				if compute.Platform.AWS == nil || len(compute.Platform.AWS.Zones) == 0 {
+					return errors.New(field.Required(fldPath.Child("platform", "aws", "zones"), "invalid install config. edge machine pool requires Local Zones when installing in a new VPC").Error())
+				}
+				if compute.Platform.AWS.OutpostARN != "" {
+					return errors.New(field.Forbidden(fldPath.Child("platform", "aws", "outpostARN"), "invalid install config. edge machine pool on an Outpost is valid when installing in existing VPC").Error())
+				}
+			} else {
+				edgeSubnets, err := meta.EdgeSubnets(ctx)
+				if err != nil {
+					errMsg := fmt.Sprintf("%s pool. %v", compute.Name, err.Error())
+					return errors.New(field.Invalid(field.NewPath("platform", "aws", "subnets"), config.Platform.AWS.Subnets, errMsg).Error())
+				}
+				if len(edgeSubnets) == 0 {
+					return errors.New(field.Required(fldPath, "invalid install config. There is no valid subnets for edge machine pool").Error())
+				}
 			}
 		}
 
@@ -99,7 +141,11 @@ func validatePlatform(ctx context.Context, meta *Metadata, fldPath *field.Path,
 
 func validateAMI(ctx context.Context, config *types.InstallConfig) field.ErrorList {
 	// accept AMI from the rhcos stream metadata
-	if rhcos.AMIRegions(config.ControlPlane.Architecture).Has(config.Platform.AWS.Region) {
+	st, err := rhcos.FetchCoreOSStream(ctx, config.CoreOSStream)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("platform", "aws", "region"), err)}
+	}
+	if rhcos.StreamAMIRegions(st, config.ControlPlane.Architecture).Has(config.Platform.AWS.Region) {
 		return nil
 	}
 
@@ -184,6 +230,10 @@ func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, s
 
 	allErrs = append(allErrs, validateSubnetCIDR(fldPath, privateSubnets, privateSubnetsIdx, networking.MachineNetwork)...)
 	allErrs = append(allErrs, validateSubnetCIDR(fldPath, publicSubnets, publicSubnetsIdx, networking.MachineNetwork)...)
+	cloudNetworks := subnetCloudNetworks(fldPath, privateSubnets, privateSubnetsIdx)
+	cloudNetworks = append(cloudNetworks, subnetCloudNetworks(fldPath, publicSubnets, publicSubnetsIdx)...)
+	cloudNetworks = append(cloudNetworks, subnetCloudNetworks(fldPath, edgeSubnets, edgeSubnetsIdx)...)
+	allErrs = append(allErrs, validation.ValidateCloudNetworks(networking, cloudNetworks)...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, privateSubnets, privateSubnetsIdx, "private")...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, publicSubnets, publicSubnetsIdx, "public")...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, edgeSubnets, edgeSubnetsIdx, "edge")...)
@@ -223,6 +273,14 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 			for _, subnet := range subnets {
 				availableZones.Insert(subnet.Zone)
 			}
+		} else if poolName == types.MachinePoolEdgeRoleName {
+			localZones, err := meta.LocalZones(ctx)
+			if err != nil {
+				return append(allErrs, field.InternalError(fldPath, err))
+			}
+			for zone := range localZones {
+				availableZones.Insert(zone)
+			}
 		} else {
 			allzones, err := meta.AvailabilityZones(ctx)
 			if err != nil {
@@ -233,9 +291,15 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 
 		if diff := sets.NewString(pool.Zones...).Difference(availableZones); diff.Len() > 0 {
 			errMsg := fmt.Sprintf("No subnets provided for zones %s", diff.List())
+			if len(platform.Subnets) == 0 && poolName == types.MachinePoolEdgeRoleName {
+				errMsg = fmt.Sprintf("No Local Zones opted in for zones %s", diff.List())
+			}
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones"), pool.Zones, errMsg))
 		}
 	}
+	if poolName == types.MachinePoolEdgeRoleName {
+		allErrs = append(allErrs, validateEdgeMachinePool(ctx, meta, fldPath, platform, pool)...)
+	}
 	if pool.InstanceType != "" {
 		instanceTypes, err := meta.InstanceTypes(ctx)
 		if err != nil {
@@ -255,6 +319,112 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, errMsg))
 		}
 	}
+	if pool.IAMInstanceProfile != "" {
+		actions := controlPlaneInstanceActions
+		if poolName != "" {
+			actions = computeInstanceActions
+		}
+		allErrs = append(allErrs, validateInstanceProfile(ctx, meta, fldPath.Child("iamInstanceProfile"), pool.IAMInstanceProfile, actions)...)
+	}
+	return allErrs
+}
+
+// validateEdgeMachinePool ensures that the Outpost of the edge machine pool
+// has subnets, and that the instance type of the pool is offered in its Local
+// Zones.
+func validateEdgeMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform, pool *awstypes.MachinePool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	zones := pool.Zones
+	if len(platform.Subnets) > 0 {
+		subnets, err := meta.EdgeSubnets(ctx)
+		if err != nil {
+			return append(allErrs, field.InternalError(fldPath, err))
+		}
+		placementZones := sets.NewString()
+		for _, subnet := range subnets {
+			if subnet.OutpostARN == pool.OutpostARN {
+				placementZones.Insert(subnet.Zone)
+			}
+		}
+		if pool.OutpostARN != "" && placementZones.Len() == 0 {
+			return append(allErrs, field.Invalid(fldPath.Child("outpostARN"), pool.OutpostARN, "No subnets provided for the Outpost"))
+		}
+		if len(zones) == 0 {
+			zones = placementZones.List()
+		}
+	}
+
+	// The capacity of an Outpost is not part of the instance type offerings of
+	// the region, it is checked by EC2 when the machines are created.
+	if pool.OutpostARN != "" {
+		if pool.InstanceType == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("type"), "instance type must be provided for an edge machine pool on an Outpost"))
+		}
+		return allErrs
+	}
+	if pool.InstanceType == "" || len(zones) == 0 {
+		return allErrs
+	}
+
+	offered, err := meta.InstanceTypeZones(ctx, pool.InstanceType, zones)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath.Child("type"), err))
+	}
+	if diff := sets.NewString(zones...).Difference(offered); diff.Len() > 0 {
+		errMsg := fmt.Sprintf("instance type is not offered in zones %s", diff.List())
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, errMsg))
+	}
+	return allErrs
+}
+
+// validateInstanceProfile ensures that the instance profile exists and that
+// the policies of its role allow the actions.
+func validateInstanceProfile(ctx context.Context, meta *Metadata, fldPath *field.Path, name string, actions []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	sess, err := meta.Session(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	client := iam.New(sess)
+
+	output, err := client.GetInstanceProfileWithContext(ctx, &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == iam.ErrCodeNoSuchEntityException {
+			return append(allErrs, field.NotFound(fldPath, name))
+		}
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if len(output.InstanceProfile.Roles) == 0 {
+		return append(allErrs, field.Invalid(fldPath, name, "instance profile has no IAM role"))
+	}
+	role := output.InstanceProfile.Roles[0]
+
+	var denied []string
+	if err := client.SimulatePrincipalPolicyPagesWithContext(ctx,
+		&iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: role.Arn,
+			ActionNames:     aws.StringSlice(actions),
+		},
+		func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+			for _, result := range page.EvaluationResults {
+				if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+					denied = append(denied, aws.StringValue(result.EvalActionName))
+				}
+			}
+			return !lastPage
+		}); err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		errMsg := fmt.Sprintf("the policies of IAM role %s do not allow %s", aws.StringValue(role.RoleName), strings.Join(denied, ", "))
+		allErrs = append(allErrs, field.Invalid(fldPath, name, errMsg))
+	}
 	return allErrs
 }
 
@@ -272,6 +442,26 @@ func validateSubnetCIDR(fldPath *field.Path, subnets map[string]Subnet, idxMap m
 	return allErrs
 }
 
+// subnetCloudNetworks returns the CIDR blocks of the subnets provided in the
+// install config, as cloud networks sorted by their index. Unparsable blocks
+// are reported by validateSubnetCIDR.
+func subnetCloudNetworks(fldPath *field.Path, subnets map[string]Subnet, idxMap map[string]int) []validation.CloudNetwork {
+	var cloudNetworks []validation.CloudNetwork
+	ids := make([]string, 0, len(idxMap))
+	for id := range idxMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return idxMap[ids[i]] < idxMap[ids[j]] })
+	for _, id := range ids {
+		_, cidr, err := net.ParseCIDR(subnets[id].CIDR)
+		if err != nil {
+			continue
+		}
+		cloudNetworks = append(cloudNetworks, validation.CloudNetwork{Name: fmt.Sprintf("subnet %s", id), Path: fldPath.Index(idxMap[id]), CIDR: cidr})
+	}
+	return cloudNetworks
+}
+
 func validateMachineNetworksContainIP(fldPath *field.Path, networks []types.MachineNetworkEntry, subnetName string, ip net.IP) field.ErrorList {
 	for _, network := range networks {
 		if network.CIDR.Contains(ip) {
@@ -416,8 +606,12 @@ func ValidateForProvisioning(client API, ic *types.InstallConfig, metadata *Meta
 		zone = baseDomainOutput
 	}
 
-	if errors = client.ValidateZoneRecords(zone, zoneName, zonePath, ic); len(errors) > 0 {
-		allErrs = append(allErrs, errors...)
+	// The records of a user-managed load balancer are created by the user
+	// before the install, so they are expected to exist in the zone.
+	if !ic.UserManagedCloudLoadBalancer() {
+		if errors = client.ValidateZoneRecords(zone, zoneName, zonePath, ic); len(errors) > 0 {
+			allErrs = append(allErrs, errors...)
+		}
 	}
 
 	return allErrs.ToAggregate()
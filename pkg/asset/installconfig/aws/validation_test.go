@@ -169,6 +169,30 @@ func validEdgeSubnets() map[string]Subnet {
 	}
 }
 
+const validOutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+
+func validOutpostSubnets() map[string]Subnet {
+	subnets := validEdgeSubnets()
+	for id, subnet := range subnets {
+		subnet.ZoneType = aws.OutpostZoneType
+		subnet.OutpostARN = validOutpostARN
+		subnets[id] = subnet
+	}
+	return subnets
+}
+
+func validLocalZones() map[string]Zone {
+	zones := map[string]Zone{}
+	for _, name := range validAvailZonesOnlyEdge() {
+		zones[name] = Zone{
+			Name:      name,
+			Type:      aws.LocalZoneType,
+			GroupName: "us-east-1-edge",
+		}
+	}
+	return zones
+}
+
 func validServiceEndpoints() []aws.ServiceEndpoint {
 	return []aws.ServiceEndpoint{{
 		Name: "ec2",
@@ -254,6 +278,7 @@ func TestValidate(t *testing.T) {
 		privateSubnets map[string]Subnet
 		publicSubnets  map[string]Subnet
 		edgeSubnets    map[string]Subnet
+		localZones     map[string]Zone
 		instanceTypes  map[string]InstanceType
 		proxy          string
 		expectErr      string
@@ -512,7 +537,79 @@ func TestValidate(t *testing.T) {
 		privateSubnets: validPrivateSubnets(),
 		publicSubnets:  validPublicSubnets(),
 		edgeSubnets:    validEdgeSubnets(),
-		expectErr:      `^compute\[1\]: Required value: invalid install config\. edge machine pool is valid when installing in existing VPC$`,
+		expectErr:      `^compute\[1\]\.platform\.aws\.zones: Required value: invalid install config\. edge machine pool requires Local Zones when installing in a new VPC$`,
+	}, {
+		name: "valid edge pool local zones in new VPC",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Platform.AWS.Subnets = []string{}
+			c.Compute[1].Platform.AWS.Zones = []string{"edge-a", "edge-b"}
+			return c
+		}(),
+		availZones: validAvailZones(),
+		localZones: validLocalZones(),
+	}, {
+		name: "invalid edge pool local zones not opted in",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Platform.AWS.Subnets = []string{}
+			c.Compute[1].Platform.AWS.Zones = []string{"edge-a", "edge-d"}
+			return c
+		}(),
+		availZones: validAvailZones(),
+		localZones: validLocalZones(),
+		expectErr:  `^compute\[1\]\.platform\.aws\.zones: Invalid value: \[\]string{\"edge-a\", \"edge-d\"}: No Local Zones opted in for zones \[edge-d\]$`,
+	}, {
+		name: "invalid edge pool outpost in new VPC",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Platform.AWS.Subnets = []string{}
+			c.Compute[1].Platform.AWS.Zones = []string{"edge-a"}
+			c.Compute[1].Platform.AWS.OutpostARN = validOutpostARN
+			return c
+		}(),
+		availZones: validAvailZones(),
+		localZones: validLocalZones(),
+		expectErr:  `^compute\[1\]\.platform\.aws\.outpostARN: Forbidden: invalid install config\. edge machine pool on an Outpost is valid when installing in existing VPC$`,
+	}, {
+		name: "valid edge pool outpost",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Compute[1].Platform.AWS.OutpostARN = validOutpostARN
+			c.Compute[1].Platform.AWS.InstanceType = "m5.xlarge"
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		edgeSubnets:    validOutpostSubnets(),
+		instanceTypes:  validInstanceTypes(),
+	}, {
+		name: "invalid edge pool outpost without instance type",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Compute[1].Platform.AWS.OutpostARN = validOutpostARN
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		edgeSubnets:    validOutpostSubnets(),
+		expectErr:      `^compute\[1\]\.platform\.aws\.type: Required value: instance type must be provided for an edge machine pool on an Outpost$`,
+	}, {
+		name: "invalid edge pool outpost without subnets",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfigEdge()
+			c.Compute[1].Platform.AWS.OutpostARN = validOutpostARN
+			c.Compute[1].Platform.AWS.InstanceType = "m5.xlarge"
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		edgeSubnets:    validEdgeSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		expectErr:      `^compute\[1\]\.platform\.aws\.outpostARN: Invalid value: \"arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0\": No subnets provided for the Outpost$`,
 	}, {
 		name: "invalid edge pool missing edge subnets",
 		installConfig: func() *types.InstallConfig {
@@ -748,6 +845,7 @@ func TestValidate(t *testing.T) {
 				privateSubnets:    test.privateSubnets,
 				publicSubnets:     test.publicSubnets,
 				edgeSubnets:       test.edgeSubnets,
+				localZones:        test.localZones,
 				instanceTypes:     test.instanceTypes,
 			}
 			if test.proxy != "" {
@@ -23,6 +23,7 @@ type API interface {
 	GetVirtualNetwork(ctx context.Context, resourceGroupName, virtualNetwork string) (*aznetwork.VirtualNetwork, error)
 	GetComputeSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
 	GetControlPlaneSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
+	GetNatGateway(ctx context.Context, natGatewayID string) (*azres.GenericResource, error)
 	ListLocations(ctx context.Context) (*[]azsubs.Location, error)
 	GetResourcesProvider(ctx context.Context, resourceProviderNamespace string) (*azres.Provider, error)
 	GetVirtualMachineSku(ctx context.Context, name, region string) (*azsku.ResourceSku, error)
@@ -35,6 +36,7 @@ type API interface {
 	GetHyperVGenerationVersion(ctx context.Context, instanceType string, region string, imageHyperVGen string) (string, error)
 	GetMarketplaceImage(ctx context.Context, region, publisher, offer, sku, version string) (azenc.VirtualMachineImage, error)
 	AreMarketplaceImageTermsAccepted(ctx context.Context, publisher, offer, sku string) (bool, error)
+	AcceptMarketplaceImageTerms(ctx context.Context, publisher, offer, sku string) error
 	GetVMCapabilities(ctx context.Context, instanceType, region string) (map[string]string, error)
 	GetAvailabilityZones(ctx context.Context, region string, instanceType string) ([]string, error)
 	GetLocationInfo(ctx context.Context, region string, instanceType string) (*azenc.ResourceSkuLocationInfo, error)
@@ -99,6 +101,25 @@ func (c *Client) GetControlPlaneSubnet(ctx context.Context, resourceGroupName, v
 	return c.getSubnet(ctx, resourceGroupName, virtualNetwork, subNetwork)
 }
 
+// natGatewayAPIVersion is the version of the network API used to get the NAT
+// gateways, which are not part of the network API of the profile.
+const natGatewayAPIVersion = "2020-11-01"
+
+// GetNatGateway gets the Azure NAT gateway with the resource ID, as a generic
+// resource whose properties are those of the NAT gateway.
+func (c *Client) GetNatGateway(ctx context.Context, natGatewayID string) (*azres.GenericResource, error) {
+	client := azres.NewClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	client.Authorizer = c.ssn.Authorizer
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := client.GetByID(ctx, natGatewayID, natGatewayAPIVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get NAT gateway %s", natGatewayID)
+	}
+	return &res, nil
+}
+
 // getVnetsClient sets up a new client to retrieve vnets
 func (c *Client) getVirtualNetworksClient(ctx context.Context) (*aznetwork.VirtualNetworksClient, error) {
 	vnetsClient := aznetwork.NewVirtualNetworksClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
@@ -352,6 +373,29 @@ func (c *Client) AreMarketplaceImageTermsAccepted(ctx context.Context, publisher
 	return terms.AgreementProperties.Accepted != nil && *terms.AgreementProperties.Accepted, nil
 }
 
+// AcceptMarketplaceImageTerms accepts the terms of the specified marketplace VM image for the subscription.
+func (c *Client) AcceptMarketplaceImageTerms(ctx context.Context, publisher, offer, sku string) error {
+	client := azmarketplace.NewMarketplaceAgreementsClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	client.Authorizer = c.ssn.Authorizer
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	terms, err := client.Get(ctx, publisher, offer, sku)
+	if err != nil {
+		return err
+	}
+
+	if terms.AgreementProperties == nil {
+		return errors.New("no agreement properties for image")
+	}
+
+	terms.AgreementProperties.Accepted = to.BoolPtr(true)
+	if _, err := client.Create(ctx, publisher, offer, sku, terms); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetAvailabilityZones retrieves a list of availability zones for the given region, and instance type.
 func (c *Client) GetAvailabilityZones(ctx context.Context, region string, instanceType string) ([]string, error) {
 	locationInfo, err := c.GetLocationInfo(ctx, region, instanceType)
@@ -0,0 +1,247 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/storage/mgmt/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	aztypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+const (
+	// uploadPageSize is the size of the pages of a page blob, to which the
+	// size of the VHD and of the uploaded ranges are aligned.
+	uploadPageSize = 512
+
+	// uploadChunkSize is the size of the ranges uploaded in parallel, the
+	// largest range accepted by a single Put Page request.
+	uploadChunkSize = 4 << 20
+
+	// uploadWorkers is the number of ranges uploaded at the same time.
+	uploadWorkers = 8
+
+	// uploadChecksumKey is the metadata key of the blob holding the sha256
+	// checksum of the VHD.
+	uploadChecksumKey = "sha256"
+
+	// uploadCompleteKey is the metadata key set on the blob once every page
+	// of the VHD is uploaded.
+	uploadCompleteKey = "uploadcomplete"
+)
+
+// uploadRange is a byte range of the VHD.
+type uploadRange struct {
+	start int64
+	end   int64 // inclusive
+}
+
+// UploadImage uploads the VHD at the path to the blob container of the upload
+// and returns the URL of the blob. Azure Stack creates images from page blobs
+// only, so the VHD is uploaded as a page blob, in ranges written in parallel.
+// The sha256 checksum of the VHD is recorded in the metadata of the blob: a
+// blob with the same checksum is reused when its upload completed, and resumed
+// from the pages it already holds otherwise.
+func (c *Client) UploadImage(ctx context.Context, upload *aztypes.ImageUpload, path string, sha256 string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+	if size%uploadPageSize != 0 {
+		return "", errors.Errorf("the size of %s is not a multiple of %d bytes, it is not a fixed VHD", path, uploadPageSize)
+	}
+
+	containerClient, err := c.getContainerClient(ctx, upload)
+	if err != nil {
+		return "", err
+	}
+	if _, err := containerClient.Create(ctx, nil); err != nil {
+		var storageErr *azblob.StorageError
+		if !errors.As(err, &storageErr) || storageErr.ErrorCode != azblob.StorageErrorCodeContainerAlreadyExists {
+			return "", errors.Wrapf(err, "failed to create the container %s", upload.ContainerName())
+		}
+	}
+	blobClient, err := containerClient.NewPageBlobClient(filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+
+	resume := false
+	props, err := blobClient.GetProperties(ctx, nil)
+	if err == nil && to.Int64(props.ContentLength) == size && props.Metadata[uploadChecksumKey] == sha256 {
+		if props.Metadata[uploadCompleteKey] == "true" {
+			logrus.Infof("The RHCOS VHD was found in %s. Reusing...", blobClient.URL())
+			return blobClient.URL(), nil
+		}
+		resume = true
+	}
+
+	var uploaded []uploadRange
+	if resume {
+		uploaded, err = listPageRanges(ctx, blobClient)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		_, err = blobClient.Create(ctx, size, &azblob.PageBlobCreateOptions{
+			Metadata: map[string]string{uploadChecksumKey: sha256},
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create the blob %s", blobClient.URL())
+		}
+	}
+
+	pending := pendingUploadRanges(size, uploaded)
+	if resume {
+		logrus.Infof("Resuming the upload of the RHCOS VHD to %s, %d of %d ranges left", blobClient.URL(), len(pending), (size+uploadChunkSize-1)/uploadChunkSize)
+	} else {
+		logrus.Infof("Uploading the RHCOS VHD to %s", blobClient.URL())
+	}
+	if err := uploadRanges(ctx, blobClient, file, pending); err != nil {
+		return "", errors.Wrapf(err, "failed to upload the RHCOS VHD to %s, the upload will resume on the next attempt", blobClient.URL())
+	}
+
+	_, err = blobClient.SetMetadata(ctx, map[string]string{uploadChecksumKey: sha256, uploadCompleteKey: "true"}, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to mark the upload to %s as complete", blobClient.URL())
+	}
+	return blobClient.URL(), nil
+}
+
+// getContainerClient returns a client of the blob container of the upload,
+// authenticated with a key of its storage account.
+func (c *Client) getContainerClient(ctx context.Context, upload *aztypes.ImageUpload) (*azblob.ContainerClient, error) {
+	accountsClient := storage.NewAccountsClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	accountsClient.Authorizer = c.ssn.Authorizer
+
+	keys, err := accountsClient.ListKeys(ctx, upload.ResourceGroup, upload.StorageAccount)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the keys of the storage account %s", upload.StorageAccount)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.Errorf("the storage account %s has no key", upload.StorageAccount)
+	}
+	credential, err := azblob.NewSharedKeyCredential(upload.StorageAccount, to.String((*keys.Keys)[0].Value))
+	if err != nil {
+		return nil, err
+	}
+
+	containerURL := fmt.Sprintf("https://%s.blob.%s/%s", upload.StorageAccount, c.ssn.Environment.StorageEndpointSuffix, upload.ContainerName())
+	return azblob.NewContainerClientWithSharedKey(containerURL, credential, nil)
+}
+
+// listPageRanges returns the ranges of the pages written to the blob.
+func listPageRanges(ctx context.Context, blobClient *azblob.PageBlobClient) ([]uploadRange, error) {
+	var ranges []uploadRange
+	pager := blobClient.GetPageRanges(nil)
+	for pager.NextPage(ctx) {
+		for _, r := range pager.PageResponse().PageRange {
+			ranges = append(ranges, uploadRange{start: to.Int64(r.Start), end: to.Int64(r.End)})
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the pages of %s", blobClient.URL())
+	}
+	return ranges, nil
+}
+
+// pendingUploadRanges splits a VHD of the size into the ranges to upload,
+// leaving out the ranges entirely covered by the uploaded pages.
+func pendingUploadRanges(size int64, uploaded []uploadRange) []uploadRange {
+	var pending []uploadRange
+	for start := int64(0); start < size; start += uploadChunkSize {
+		r := uploadRange{start: start, end: start + uploadChunkSize - 1}
+		if r.end >= size {
+			r.end = size - 1
+		}
+		covered := false
+		for _, u := range uploaded {
+			if u.start <= r.start && r.end <= u.end {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			pending = append(pending, r)
+		}
+	}
+	return pending
+}
+
+// uploadRanges writes the ranges of the file to the blob in parallel. The
+// ranges holding only zeroes are skipped, as the pages of a new page blob are
+// zeroed, which leaves the blob sparse.
+func uploadRanges(ctx context.Context, blobClient *azblob.PageBlobClient, file io.ReaderAt, pending []uploadRange) error {
+	var done int64
+	ranges := make(chan uploadRange)
+	errs := make(chan error, len(pending))
+	var wg sync.WaitGroup
+	for w := 0; w < uploadWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, uploadChunkSize)
+			for r := range ranges {
+				data := buf[:r.end-r.start+1]
+				if _, err := file.ReadAt(data, r.start); err != nil {
+					errs <- err
+					continue
+				}
+				if !isZero(data) {
+					_, err := blobClient.UploadPages(ctx, nopCloser{bytes.NewReader(data)}, &azblob.PageBlobUploadPagesOptions{
+						PageRange: azblob.NewHttpRange(r.start, int64(len(data))),
+					})
+					if err != nil {
+						errs <- err
+						continue
+					}
+				}
+				if n := atomic.AddInt64(&done, 1); n%100 == 0 {
+					logrus.Debugf("Uploaded %d of %d ranges of the RHCOS VHD", n, len(pending))
+				}
+			}
+		}()
+	}
+	for _, r := range pending {
+		ranges <- r
+	}
+	close(ranges)
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// nopCloser adds a Close method which does nothing to a reader, as the
+// ranges are read from a buffer reused by the next range.
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
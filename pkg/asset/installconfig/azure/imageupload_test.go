@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingUploadRanges(t *testing.T) {
+	cases := []struct {
+		name     string
+		size     int64
+		uploaded []uploadRange
+		expected []uploadRange
+	}{
+		{
+			name: "new upload",
+			size: 2*uploadChunkSize + 512,
+			expected: []uploadRange{
+				{start: 0, end: uploadChunkSize - 1},
+				{start: uploadChunkSize, end: 2*uploadChunkSize - 1},
+				{start: 2 * uploadChunkSize, end: 2*uploadChunkSize + 511},
+			},
+		},
+		{
+			name:     "resumed upload",
+			size:     3 * uploadChunkSize,
+			uploaded: []uploadRange{{start: 0, end: 2*uploadChunkSize - 1}},
+			expected: []uploadRange{
+				{start: 2 * uploadChunkSize, end: 3*uploadChunkSize - 1},
+			},
+		},
+		{
+			name:     "partially uploaded range",
+			size:     2 * uploadChunkSize,
+			uploaded: []uploadRange{{start: 0, end: uploadChunkSize - 1}, {start: uploadChunkSize, end: uploadChunkSize + 511}},
+			expected: []uploadRange{
+				{start: uploadChunkSize, end: 2*uploadChunkSize - 1},
+			},
+		},
+		{
+			name:     "complete upload",
+			size:     uploadChunkSize,
+			uploaded: []uploadRange{{start: 0, end: uploadChunkSize - 1}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, pendingUploadRanges(tc.size, tc.uploaded))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package azure
+
+import (
+	"fmt"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/prompt"
+	aztypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+// promptAcceptImageTerms asks the user whether to accept the license terms of
+// the marketplace image. It returns false without asking if the user cannot be
+// prompted for input.
+var promptAcceptImageTerms = func(osImage aztypes.OSImage) (bool, error) {
+	if !prompt.Interactive() {
+		return false, nil
+	}
+
+	var accept bool
+	if err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Accept the license terms of marketplace image %s:%s:%s", osImage.Publisher, osImage.Offer, osImage.SKU),
+		Help:    "The license terms of a marketplace image must be accepted for the subscription before virtual machines can be created from it.",
+		Default: false,
+	}, &accept); err != nil {
+		return false, errors.Wrap(err, "failed UserInput")
+	}
+	return accept, nil
+}
@@ -39,6 +39,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// AcceptMarketplaceImageTerms mocks base method.
+func (m *MockAPI) AcceptMarketplaceImageTerms(ctx context.Context, publisher, offer, sku string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptMarketplaceImageTerms", ctx, publisher, offer, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptMarketplaceImageTerms indicates an expected call of AcceptMarketplaceImageTerms.
+func (mr *MockAPIMockRecorder) AcceptMarketplaceImageTerms(ctx, publisher, offer, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptMarketplaceImageTerms", reflect.TypeOf((*MockAPI)(nil).AcceptMarketplaceImageTerms), ctx, publisher, offer, sku)
+}
+
 // AreMarketplaceImageTermsAccepted mocks base method.
 func (m *MockAPI) AreMarketplaceImageTermsAccepted(ctx context.Context, publisher, offer, sku string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -189,6 +203,21 @@ func (mr *MockAPIMockRecorder) GetMarketplaceImage(ctx, region, publisher, offer
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketplaceImage", reflect.TypeOf((*MockAPI)(nil).GetMarketplaceImage), ctx, region, publisher, offer, sku, version)
 }
 
+// GetNatGateway mocks base method.
+func (m *MockAPI) GetNatGateway(ctx context.Context, natGatewayID string) (*resources.GenericResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNatGateway", ctx, natGatewayID)
+	ret0, _ := ret[0].(*resources.GenericResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNatGateway indicates an expected call of GetNatGateway.
+func (mr *MockAPIMockRecorder) GetNatGateway(ctx, natGatewayID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNatGateway", reflect.TypeOf((*MockAPI)(nil).GetNatGateway), ctx, natGatewayID)
+}
+
 // GetResourcesProvider mocks base method.
 func (m *MockAPI) GetResourcesProvider(ctx context.Context, resourceProviderNamespace string) (*resources.Provider, error) {
 	m.ctrl.T.Helper()
@@ -1,8 +1,8 @@
 package azure
 
 import (
+	"context"
 	"encoding/json"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/credentials"
 	"github.com/openshift/installer/pkg/types/azure"
 )
 
@@ -27,6 +28,10 @@ const azureAuthEnv = "AZURE_AUTH_LOCATION"
 var (
 	defaultAuthFilePath = filepath.Join(os.Getenv("HOME"), ".azure", "osServicePrincipal.json")
 	onceLoggers         = map[string]*sync.Once{}
+
+	// credentialsMutex serializes the loading of the credentials by the
+	// assets generated concurrently, as it may prompt the user.
+	credentialsMutex sync.Mutex
 )
 
 // Session is an object representing session for subscription
@@ -108,51 +113,58 @@ func GetSessionWithCredentials(cloudName azure.CloudEnvironment, armEndpoint str
 	return newSessionFromCredentials(cloudEnv, credentials, cred)
 }
 
-// credentialsFromFileOrUser returns credentials found
-// in ~/.azure/osServicePrincipal.json and, if no creds are found,
-// asks for them and stores them on disk in a config file
+// credentialsFromFileOrUser returns the credentials printed by the
+// credential helper, if any, or found in ~/.azure/osServicePrincipal.json
+// and, if no creds are found, asks for them and stores them on disk in a
+// config file
 func credentialsFromFileOrUser() (*Credentials, error) {
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+
 	authFilePath := defaultAuthFilePath
 	if f := os.Getenv(azureAuthEnv); len(f) > 0 {
 		authFilePath = f
 	}
 
-	var authFile Credentials
-
-	contents, err := os.ReadFile(authFilePath)
-	if err != nil {
-		// If the file with creds was not found, ask user for auth info
-		if errors.Is(err, fs.ErrNotExist) {
+	prompt := &credentials.PromptSource{
+		Prompt: func(context.Context) ([]byte, error) {
 			logrus.Infof("Asking user to provide authentication info")
-			credentials, cerr := askForCredentials()
-			if cerr != nil {
-				return nil, errors.Wrap(cerr, "failed to retrieve credentials from user")
+			creds, err := askForCredentials()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to retrieve credentials from user")
 			}
 			logrus.Infof("Saving user credentials to %q", authFilePath)
-			if cerr = saveCredentials(*credentials, authFilePath); cerr != nil {
-				return nil, errors.Wrap(cerr, "failed to save credentials")
+			if err = saveCredentials(*creds, authFilePath); err != nil {
+				return nil, errors.Wrap(err, "failed to save credentials")
 			}
-			authFile = *credentials
-		} else {
-			// File was found but we failed to read it, just error out and let the user handle it
-			return nil, err
-		}
-	} else {
-		err = json.Unmarshal(contents, &authFile)
-		if err != nil {
-			return nil, err
-		}
+			return json.Marshal(creds)
+		},
+	}
+	chain := credentials.Chain{
+		credentials.HelperSource("azure"),
+		&credentials.FileSource{Path: authFilePath},
+		prompt,
+	}
+
+	contents, source, err := chain.Load(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var authFile Credentials
+	if err := json.Unmarshal(contents, &authFile); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse credentials from %s", source)
 	}
 
 	if err := checkCredentials(authFile); err != nil {
 		return nil, err
 	}
 
-	if _, has := onceLoggers[authFilePath]; !has {
-		onceLoggers[authFilePath] = new(sync.Once)
+	if _, has := onceLoggers[source.String()]; !has {
+		onceLoggers[source.String()] = new(sync.Once)
 	}
-	onceLoggers[authFilePath].Do(func() {
-		logrus.Infof("Credentials loaded from file %q", authFilePath)
+	onceLoggers[source.String()].Do(func() {
+		logrus.Infof("Credentials loaded from %s", source)
 	})
 
 	return &authFile, nil
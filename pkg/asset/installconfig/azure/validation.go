@@ -2,6 +2,7 @@ package azure
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
@@ -20,6 +21,7 @@ import (
 	"github.com/openshift/installer/pkg/types"
 	aztypes "github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/azure/defaults"
+	"github.com/openshift/installer/pkg/types/validation"
 )
 
 type resourceRequirements struct {
@@ -41,7 +43,7 @@ var computeReq = resourceRequirements{
 func Validate(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateNetworks(client, ic.Azure, ic.Networking.MachineNetwork, field.NewPath("platform").Child("azure"))...)
+	allErrs = append(allErrs, validateNetworks(client, ic.Azure, ic.Networking, field.NewPath("platform").Child("azure"))...)
 	allErrs = append(allErrs, validateRegion(client, field.NewPath("platform").Child("azure").Child("region"), ic.Azure)...)
 	allErrs = append(allErrs, validateInstanceTypes(client, ic)...)
 	if ic.Azure.CloudName == aztypes.StackCloud && ic.Azure.ClusterOSImage != "" {
@@ -387,11 +389,12 @@ func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList
 }
 
 // validateNetworks checks that the user-provided VNet and subnets are valid.
-func validateNetworks(client API, p *aztypes.Platform, machineNetworks []types.MachineNetworkEntry, fieldPath *field.Path) field.ErrorList {
+func validateNetworks(client API, p *aztypes.Platform, networking *types.Networking, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if p.VirtualNetwork != "" {
-		_, err := client.GetVirtualNetwork(context.TODO(), p.NetworkResourceGroupName, p.VirtualNetwork)
+		machineNetworks := networking.MachineNetwork
+		virtualNetwork, err := client.GetVirtualNetwork(context.TODO(), p.NetworkResourceGroupName, p.VirtualNetwork)
 		if err != nil {
 			return append(allErrs, field.Invalid(fieldPath.Child("virtualNetwork"), p.VirtualNetwork, err.Error()))
 		}
@@ -409,11 +412,106 @@ func validateNetworks(client API, p *aztypes.Platform, machineNetworks []types.M
 		}
 
 		allErrs = append(allErrs, validateSubnet(client, fieldPath.Child("controlPlaneSubnet"), controlPlaneSubnet, p.ControlPlaneSubnet, machineNetworks)...)
+
+		cloudNetworks := virtualNetworkCloudNetworks(fieldPath.Child("virtualNetwork"), virtualNetwork)
+		cloudNetworks = append(cloudNetworks, subnetCloudNetworks(fieldPath.Child("computeSubnet"), computeSubnet)...)
+		cloudNetworks = append(cloudNetworks, subnetCloudNetworks(fieldPath.Child("controlPlaneSubnet"), controlPlaneSubnet)...)
+		allErrs = append(allErrs, validation.ValidateCloudNetworks(networking, cloudNetworks)...)
+
+		if p.OutboundType == aztypes.UserDefinedNATGatewayOutboundType {
+			subnetIDs := map[string]string{
+				p.ComputeSubnet:      to.String(computeSubnet.ID),
+				p.ControlPlaneSubnet: to.String(controlPlaneSubnet.ID),
+			}
+			for i, gateway := range p.NATGateways {
+				allErrs = append(allErrs, validateNATGateway(client, fieldPath.Child("natGateways").Index(i), gateway, subnetIDs[gateway.Subnet])...)
+			}
+		}
 	}
 
 	return allErrs
 }
 
+// natGatewayProperties are the properties of a NAT gateway checked by the
+// validation.
+type natGatewayProperties struct {
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	Subnets              []struct {
+		ID string `json:"id"`
+	} `json:"subnets,omitempty"`
+}
+
+// defaultNATGatewayIdleTimeout is the idle timeout, in minutes, of the NAT
+// gateways which do not set one.
+const defaultNATGatewayIdleTimeout = 4
+
+// validateNATGateway checks that the NAT gateway exists, is associated with
+// the subnet with the ID, and has the expected idle timeout.
+func validateNATGateway(client API, fieldPath *field.Path, gateway aztypes.NATGateway, subnetID string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	res, err := client.GetNatGateway(context.TODO(), gateway.ID)
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath.Child("id"), gateway.ID, err.Error()))
+	}
+	properties := natGatewayProperties{}
+	data, err := json.Marshal(res.Properties)
+	if err == nil {
+		err = json.Unmarshal(data, &properties)
+	}
+	if err != nil {
+		return append(allErrs, field.InternalError(fieldPath.Child("id"), errors.Wrapf(err, "failed to read the properties of NAT gateway %s", gateway.ID)))
+	}
+
+	associated := false
+	for _, subnet := range properties.Subnets {
+		if strings.EqualFold(subnet.ID, subnetID) {
+			associated = true
+			break
+		}
+	}
+	if !associated {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("id"), gateway.ID, fmt.Sprintf("NAT gateway is not associated with subnet %s", gateway.Subnet)))
+	}
+
+	idleTimeout := int32(defaultNATGatewayIdleTimeout)
+	if properties.IdleTimeoutInMinutes != nil {
+		idleTimeout = *properties.IdleTimeoutInMinutes
+	}
+	if gateway.IdleTimeoutInMinutes != nil && *gateway.IdleTimeoutInMinutes != idleTimeout {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("idleTimeoutInMinutes"), *gateway.IdleTimeoutInMinutes, fmt.Sprintf("the idle timeout of the NAT gateway is %d minutes", idleTimeout)))
+	}
+	return allErrs
+}
+
+// virtualNetworkCloudNetworks returns the address space of the virtual
+// network, as cloud networks.
+func virtualNetworkCloudNetworks(fieldPath *field.Path, virtualNetwork *aznetwork.VirtualNetwork) []validation.CloudNetwork {
+	var cloudNetworks []validation.CloudNetwork
+	if virtualNetwork.VirtualNetworkPropertiesFormat == nil || virtualNetwork.AddressSpace == nil || virtualNetwork.AddressSpace.AddressPrefixes == nil {
+		return cloudNetworks
+	}
+	for _, prefix := range *virtualNetwork.AddressSpace.AddressPrefixes {
+		if _, cidr, err := net.ParseCIDR(prefix); err == nil {
+			cloudNetworks = append(cloudNetworks, validation.CloudNetwork{Name: fmt.Sprintf("virtual network %s", to.String(virtualNetwork.Name)), Path: fieldPath, CIDR: cidr})
+		}
+	}
+	return cloudNetworks
+}
+
+// subnetCloudNetworks returns the address prefix of the subnet, as a cloud
+// network. Unparsable prefixes are reported by validateSubnet.
+func subnetCloudNetworks(fieldPath *field.Path, subnet *aznetwork.Subnet) []validation.CloudNetwork {
+	if subnet.SubnetPropertiesFormat == nil || subnet.AddressPrefix == nil {
+		return nil
+	}
+	_, cidr, err := net.ParseCIDR(*subnet.AddressPrefix)
+	if err != nil {
+		return nil
+	}
+	return []validation.CloudNetwork{{Name: fmt.Sprintf("subnet %s", to.String(subnet.Name)), Path: fieldPath, CIDR: cidr}}
+}
+
 // validateSubnet checks that the subnet is in the same network as the machine CIDR
 func validateSubnet(client API, fieldPath *field.Path, subnet *aznetwork.Subnet, subnetName string, networks []types.MachineNetworkEntry) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -487,6 +585,11 @@ func ValidatePublicDNS(ic *types.InstallConfig, azureDNS *DNSConfig) error {
 		return nil
 	}
 
+	// The user creates the api record of a user-managed load balancer
+	if ic.UserManagedCloudLoadBalancer() {
+		return nil
+	}
+
 	clusterName := ic.ObjectMeta.Name
 	record := fmt.Sprintf("api.%s", clusterName)
 	rgName := ic.Azure.BaseDomainResourceGroupName
@@ -519,7 +622,7 @@ func ValidateForProvisioning(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResourceGroup(client, field.NewPath("platform").Child("azure"), ic.Azure)...)
 	allErrs = append(allErrs, ValidateDiskEncryptionSet(client, ic)...)
-	if ic.Azure.CloudName == aztypes.StackCloud {
+	if ic.Azure.CloudName == aztypes.StackCloud && ic.Azure.ClusterOSImageUpload == nil {
 		allErrs = append(allErrs, checkAzureStackClusterOSImageSet(ic.Azure.ClusterOSImage, field.NewPath("platform").Child("azure"))...)
 	}
 	return allErrs.ToAggregate()
@@ -574,7 +677,7 @@ func validateResourceGroup(client API, fieldPath *field.Path, platform *aztypes.
 func checkAzureStackClusterOSImageSet(ClusterOSImage string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	if ClusterOSImage == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("clusterOSImage"), "clusterOSImage must be set when installing on Azure Stack"))
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterOSImage"), "clusterOSImage or clusterOSImageUpload must be set when installing on Azure Stack"))
 	}
 	return allErrs
 }
@@ -594,61 +697,112 @@ func validateAzureStackClusterOSImage(StorageEndpointSuffix string, ClusterOSIma
 
 func validateMarketplaceImage(client API, installConfig *types.InstallConfig) field.ErrorList {
 	var allErrs field.ErrorList
-	for i, compute := range installConfig.Compute {
-		platform := compute.Platform.Azure
-		if platform == nil {
-			continue
+
+	var defaultOSImage aztypes.OSImage
+	var defaultInstanceType string
+	defaultFieldPath := field.NewPath("platform", "azure", "defaultMachinePlatform")
+	if defaultPool := installConfig.Platform.Azure.DefaultMachinePlatform; defaultPool != nil {
+		defaultOSImage = defaultPool.OSImage
+		defaultInstanceType = defaultPool.InstanceType
+	}
+
+	// Images inherited from the default machine platform are checked once per
+	// instance type, and the terms of each image only once.
+	checkedImages := sets.NewString()
+	checkedTerms := sets.NewString()
+
+	validatePool := func(fldPath *field.Path, platform *aztypes.MachinePool, instanceType string) {
+		osImage := defaultOSImage
+		osImageFieldPath := defaultFieldPath.Child("osImage")
+		if platform != nil && platform.OSImage.Publisher != "" {
+			osImage = platform.OSImage
+			osImageFieldPath = fldPath.Child("platform", "azure", "osImage")
+		}
+		if osImage.Publisher == "" {
+			return
 		}
-		if platform.OSImage.Publisher == "" {
-			continue
+		if platform != nil && platform.InstanceType != "" {
+			instanceType = platform.InstanceType
+		} else if defaultInstanceType != "" {
+			instanceType = defaultInstanceType
+		}
+
+		imageKey := fmt.Sprintf("%s/%s", osImageFieldPath, instanceType)
+		if checkedImages.Has(imageKey) {
+			return
 		}
-		osImageFieldPath := field.NewPath("compute").Index(i).Child("platform", "azure", "osImage")
+		checkedImages.Insert(imageKey)
+
 		vmImage, err := client.GetMarketplaceImage(
 			context.Background(),
 			installConfig.Platform.Azure.Region,
-			platform.OSImage.Publisher,
-			platform.OSImage.Offer,
-			platform.OSImage.SKU,
-			platform.OSImage.Version,
+			osImage.Publisher,
+			osImage.Offer,
+			osImage.SKU,
+			osImage.Version,
 		)
 		if err != nil {
-			allErrs = append(allErrs, field.Invalid(osImageFieldPath, platform.OSImage, err.Error()))
-			continue
-		}
-		instanceType := platform.InstanceType
-		if instanceType == "" && installConfig.Platform.Azure.DefaultMachinePlatform != nil {
-			instanceType = installConfig.Platform.Azure.DefaultMachinePlatform.InstanceType
-		}
-		if instanceType == "" {
-			instanceType = defaults.ComputeInstanceType(installConfig.Azure.CloudName, installConfig.Azure.Region, compute.Architecture)
+			allErrs = append(allErrs, field.Invalid(osImageFieldPath, osImage, err.Error()))
+			return
 		}
 		capabilities, err := client.GetVMCapabilities(context.Background(), instanceType, installConfig.Azure.Region)
 		if err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("compute").Index(i).Child("platform", "azure", "type"), instanceType, err.Error()))
-			continue
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "azure", "type"), instanceType, err.Error()))
+			return
 		}
 
 		generations, err := GetHyperVGenerationVersions(capabilities)
 		if err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("compute").Index(i).Child("platform", "azure", "type"), instanceType, err.Error()))
-			continue
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "azure", "type"), instanceType, err.Error()))
+			return
 		}
 		imageHyperVGen := string(vmImage.HyperVGeneration)
 		if !generations.Has(imageHyperVGen) {
 			errMsg := fmt.Sprintf("instance type %s supports HyperVGenerations %v but the specified image is for HyperVGeneration %s; to correct this issue either specify a compatible instance type or change the HyperVGeneration for the image by using a different SKU", instanceType, generations.UnsortedList(), imageHyperVGen)
-			allErrs = append(allErrs, field.Invalid(osImageFieldPath, platform.OSImage.SKU, errMsg))
-			continue
+			allErrs = append(allErrs, field.Invalid(osImageFieldPath, osImage.SKU, errMsg))
+			return
 		}
 
-		termsAccepted, err := client.AreMarketplaceImageTermsAccepted(context.Background(), platform.OSImage.Publisher, platform.OSImage.Offer, platform.OSImage.SKU)
-		if err == nil {
-			if !termsAccepted {
-				allErrs = append(allErrs, field.Invalid(osImageFieldPath, platform.OSImage, "the license terms for the marketplace image have not been accepted"))
-			}
-		} else {
-			allErrs = append(allErrs, field.Invalid(osImageFieldPath, platform.OSImage,
-				fmt.Sprintf("could not determine if the license terms for the marketplace image have been accepted: %v", err)))
+		termsKey := fmt.Sprintf("%s/%s/%s", osImage.Publisher, osImage.Offer, osImage.SKU)
+		if checkedTerms.Has(termsKey) {
+			return
 		}
+		checkedTerms.Insert(termsKey)
+		allErrs = append(allErrs, validateMarketplaceImageTerms(client, osImageFieldPath, osImage)...)
+	}
+
+	if installConfig.ControlPlane != nil {
+		instanceType := defaults.ControlPlaneInstanceType(installConfig.Azure.CloudName, installConfig.Azure.Region, installConfig.ControlPlane.Architecture)
+		validatePool(field.NewPath("controlPlane"), installConfig.ControlPlane.Platform.Azure, instanceType)
+	}
+	for i, compute := range installConfig.Compute {
+		instanceType := defaults.ComputeInstanceType(installConfig.Azure.CloudName, installConfig.Azure.Region, compute.Architecture)
+		validatePool(field.NewPath("compute").Index(i), compute.Platform.Azure, instanceType)
 	}
 	return allErrs
 }
+
+// validateMarketplaceImageTerms ensures the license terms of the marketplace
+// image have been accepted, offering to accept them when running interactively.
+func validateMarketplaceImageTerms(client API, fldPath *field.Path, osImage aztypes.OSImage) field.ErrorList {
+	termsAccepted, err := client.AreMarketplaceImageTermsAccepted(context.Background(), osImage.Publisher, osImage.Offer, osImage.SKU)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, osImage,
+			fmt.Sprintf("could not determine if the license terms for the marketplace image have been accepted: %v", err))}
+	}
+	if termsAccepted {
+		return nil
+	}
+
+	accept, err := promptAcceptImageTerms(osImage)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if !accept {
+		return field.ErrorList{field.Invalid(fldPath, osImage, "the license terms for the marketplace image have not been accepted")}
+	}
+	if err := client.AcceptMarketplaceImageTerms(context.Background(), osImage.Publisher, osImage.Offer, osImage.SKU); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, osImage, fmt.Sprintf("could not accept the license terms for the marketplace image: %v", err))}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package azure
 import (
 	"fmt"
 	"net"
+	"strings"
 	"testing"
 
 	azsku "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
@@ -270,6 +271,13 @@ var (
 		validOSImageCompute(ic)
 		ic.Compute[0].Platform.Azure.OSImage.SKU = erroringOSImageSKU
 	}
+	validOSImageDefault = func(ic *types.InstallConfig) {
+		ic.Platform.Azure.DefaultMachinePlatform.OSImage = validOSImage
+	}
+	unacceptedLicenseTermsOSImageDefault = func(ic *types.InstallConfig) {
+		validOSImageDefault(ic)
+		ic.Platform.Azure.DefaultMachinePlatform.OSImage.SKU = unacceptedLicenseTermsOSImageSKU
+	}
 )
 
 func validInstallConfig() *types.InstallConfig {
@@ -444,6 +452,15 @@ func TestAzureInstallConfigValidation(t *testing.T) {
 			edits:    editFunctions{erroringGenerationOsImageCompute},
 			errorMsg: `compute\[0\].platform.azure.osImage: Invalid value: .* supports HyperVGenerations \[(V[12])\] but the specified image is for HyperVGeneration [^\\1].*`,
 		},
+		{
+			name:  "Valid default OS Image",
+			edits: editFunctions{validOSImageDefault},
+		},
+		{
+			name:     "Default OS Image with unaccepted license terms",
+			edits:    editFunctions{unacceptedLicenseTermsOSImageDefault},
+			errorMsg: `^platform.azure.defaultMachinePlatform.osImage: Invalid value: .*: the license terms for the marketplace image have not been accepted$`,
+		},
 	}
 
 	mockCtrl := gomock.NewController(t)
@@ -529,6 +546,46 @@ func TestAzureInstallConfigValidation(t *testing.T) {
 	}
 }
 
+func TestValidateMarketplaceImageTermsAcceptance(t *testing.T) {
+	cases := []struct {
+		name     string
+		accept   bool
+		errorMsg string
+	}{
+		{
+			name:   "Terms accepted when prompted",
+			accept: true,
+		},
+		{
+			name:     "Terms declined when prompted",
+			errorMsg: `the license terms for the marketplace image have not been accepted`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			defer func(prompt func(azure.OSImage) (bool, error)) { promptAcceptImageTerms = prompt }(promptAcceptImageTerms)
+			promptAcceptImageTerms = func(azure.OSImage) (bool, error) { return tc.accept, nil }
+
+			azureClient := mock.NewMockAPI(mockCtrl)
+			azureClient.EXPECT().AreMarketplaceImageTermsAccepted(gomock.Any(), validOSImagePublisher, validOSImageOffer, validOSImageSKU).Return(false, nil)
+			if tc.accept {
+				azureClient.EXPECT().AcceptMarketplaceImageTerms(gomock.Any(), validOSImagePublisher, validOSImageOffer, validOSImageSKU).Return(nil)
+			}
+
+			errs := validateMarketplaceImageTerms(azureClient, field.NewPath("osImage"), validOSImage)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, errs.ToAggregate())
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
 var validGroupResult = &azres.Group{
 	ID:       to.StringPtr("valid-resource-group"),
 	Location: to.StringPtr("centralus"),
@@ -609,6 +666,69 @@ func Test_validateResourceGroup(t *testing.T) {
 	}
 }
 
+func Test_validateNATGateway(t *testing.T) {
+	const (
+		subnetID       = "/subscriptions/sub/resourceGroups/valid-network-resource-group/providers/Microsoft.Network/virtualNetworks/valid-virtual-network/subnets/valid-compute-subnet"
+		natGatewayID   = "/subscriptions/sub/resourceGroups/valid-network-resource-group/providers/Microsoft.Network/natGateways/valid-nat-gateway"
+		missingGateway = "/subscriptions/sub/resourceGroups/valid-network-resource-group/providers/Microsoft.Network/natGateways/missing-nat-gateway"
+	)
+	cases := []struct {
+		name     string
+		gateway  azure.NATGateway
+		subnetID string
+		err      string
+	}{{
+		name:     "valid",
+		gateway:  azure.NATGateway{Subnet: validComputeSubnet, ID: natGatewayID, IdleTimeoutInMinutes: to.Int32Ptr(10)},
+		subnetID: subnetID,
+	}, {
+		name:     "valid without idle timeout",
+		gateway:  azure.NATGateway{Subnet: validComputeSubnet, ID: natGatewayID},
+		subnetID: strings.ToUpper(subnetID),
+	}, {
+		name:     "missing NAT gateway",
+		gateway:  azure.NATGateway{Subnet: validComputeSubnet, ID: missingGateway},
+		subnetID: subnetID,
+		err:      `^\Qplatform.azure.natGateways[0].id: Invalid value: "` + missingGateway + `": NAT gateway not found\E$`,
+	}, {
+		name:     "not associated",
+		gateway:  azure.NATGateway{Subnet: validComputeSubnet, ID: natGatewayID},
+		subnetID: subnetID + "-other",
+		err:      `^\Qplatform.azure.natGateways[0].id: Invalid value: "` + natGatewayID + `": NAT gateway is not associated with subnet valid-compute-subnet\E$`,
+	}, {
+		name:     "different idle timeout",
+		gateway:  azure.NATGateway{Subnet: validComputeSubnet, ID: natGatewayID, IdleTimeoutInMinutes: to.Int32Ptr(4)},
+		subnetID: subnetID,
+		err:      `^\Qplatform.azure.natGateways[0].idleTimeoutInMinutes: Invalid value: 4: the idle timeout of the NAT gateway is 10 minutes\E$`,
+	}}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	azureClient := mock.NewMockAPI(mockCtrl)
+	azureClient.EXPECT().GetNatGateway(gomock.Any(), natGatewayID).Return(&azres.GenericResource{
+		ID: to.StringPtr(natGatewayID),
+		Properties: map[string]interface{}{
+			"idleTimeoutInMinutes": 10,
+			"subnets": []interface{}{
+				map[string]interface{}{"id": subnetID},
+			},
+		},
+	}, nil).AnyTimes()
+	azureClient.EXPECT().GetNatGateway(gomock.Any(), missingGateway).Return(nil, fmt.Errorf("NAT gateway not found")).AnyTimes()
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateNATGateway(azureClient, field.NewPath("platform", "azure", "natGateways").Index(0), test.gateway, test.subnetID)
+			if test.err != "" {
+				assert.Regexp(t, test.err, err.ToAggregate())
+			} else {
+				assert.NoError(t, err.ToAggregate())
+			}
+		})
+	}
+}
+
 func TestCheckAzureStackClusterOSImageSet(t *testing.T) {
 	cases := []struct {
 		ClusterOSImage string
@@ -618,7 +738,7 @@ func TestCheckAzureStackClusterOSImageSet(t *testing.T) {
 		err:            "",
 	}, {
 		ClusterOSImage: "",
-		err:            "^platform.azure.clusterOSImage: Required value: clusterOSImage must be set when installing on Azure Stack$",
+		err:            "^platform.azure.clusterOSImage: Required value: clusterOSImage or clusterOSImageUpload must be set when installing on Azure Stack$",
 	}}
 	for _, test := range cases {
 		t.Run("", func(t *testing.T) {
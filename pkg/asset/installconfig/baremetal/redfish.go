@@ -0,0 +1,202 @@
+package baremetal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// redfishTimeout bounds every request to a BMC.
+const redfishTimeout = 30 * time.Second
+
+// odataID is a reference to another Redfish resource.
+type odataID struct {
+	ID string `json:"@odata.id"`
+}
+
+type redfishCollection struct {
+	Members []odataID `json:"Members"`
+}
+
+type redfishSystem struct {
+	Boot struct {
+		AllowableTargets []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+	} `json:"Boot"`
+	Links struct {
+		ManagedBy []odataID `json:"ManagedBy"`
+	} `json:"Links"`
+}
+
+type redfishManager struct {
+	VirtualMedia odataID `json:"VirtualMedia"`
+}
+
+type redfishVirtualMedia struct {
+	MediaTypes []string `json:"MediaTypes"`
+}
+
+// redfishClient is a minimal client of the Redfish API of a BMC.
+type redfishClient struct {
+	address  string
+	username string
+	password string
+	client   *http.Client
+}
+
+func newRedfishClient(address, username, password string, disableCertificateVerification bool) *redfishClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if disableCertificateVerification {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // requested by the user
+	}
+	return &redfishClient{
+		address:  strings.TrimSuffix(address, "/"),
+		username: username,
+		password: password,
+		client:   &http.Client{Transport: transport, Timeout: redfishTimeout},
+	}
+}
+
+// get reads the Redfish resource at the path into out.
+func (c *redfishClient) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.address+path, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to the BMC")
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.Errorf("the BMC rejected the credentials (%s)", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return errors.Errorf("failed to get %s from the BMC (%s)", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "failed to decode %s", path)
+	}
+	return nil
+}
+
+// validateRedfishBMC checks that the BMC is reachable with the credentials
+// and, for virtual media drivers, that the system can boot from a virtual CD.
+func validateRedfishBMC(ctx context.Context, c *redfishClient, systemID string, virtualMedia bool) error {
+	var root struct{}
+	if err := c.get(ctx, "/redfish/v1/", &root); err != nil {
+		return err
+	}
+
+	if systemID == "" {
+		systems := redfishCollection{}
+		if err := c.get(ctx, "/redfish/v1/Systems", &systems); err != nil {
+			return err
+		}
+		if len(systems.Members) != 1 {
+			return errors.Errorf("the BMC manages %d systems, the system must be part of the address", len(systems.Members))
+		}
+		systemID = systems.Members[0].ID
+	}
+
+	system := redfishSystem{}
+	if err := c.get(ctx, systemID, &system); err != nil {
+		return err
+	}
+	if !virtualMedia {
+		return nil
+	}
+
+	if targets := system.Boot.AllowableTargets; len(targets) > 0 && !contains(targets, "Cd") {
+		return errors.Errorf("the system cannot boot from a virtual CD, the allowed boot targets are %v", targets)
+	}
+
+	if len(system.Links.ManagedBy) == 0 {
+		return errors.New("the system has no manager providing virtual media")
+	}
+	manager := redfishManager{}
+	if err := c.get(ctx, system.Links.ManagedBy[0].ID, &manager); err != nil {
+		return err
+	}
+	if manager.VirtualMedia.ID == "" {
+		return errors.New("the manager of the system does not support virtual media")
+	}
+	devices := redfishCollection{}
+	if err := c.get(ctx, manager.VirtualMedia.ID, &devices); err != nil {
+		return err
+	}
+	for _, member := range devices.Members {
+		device := redfishVirtualMedia{}
+		if err := c.get(ctx, member.ID, &device); err != nil {
+			return err
+		}
+		if contains(device.MediaTypes, "CD") || contains(device.MediaTypes, "DVD") {
+			return nil
+		}
+	}
+	return errors.New("the manager of the system has no virtual CD or DVD device")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBMCs connects to the Redfish BMC of every host, verifying the
+// credentials and, for the virtual media drivers, the virtual media and boot
+// capabilities of the system. The BMCs of the other drivers are not checked.
+func ValidateBMCs(ctx context.Context, ic *types.InstallConfig) error {
+	fldPath := field.NewPath("platform", "baremetal", "hosts")
+	hosts := ic.Platform.BareMetal.Hosts
+
+	errs := make([]error, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		accessDetails, err := bmc.NewAccessDetails(host.BMC.Address, host.BMC.DisableCertificateVerification)
+		if err != nil {
+			// Invalid addresses are reported by the install config validation.
+			continue
+		}
+		driverInfo := accessDetails.DriverInfo(bmc.Credentials{Username: host.BMC.Username, Password: host.BMC.Password})
+		address, ok := driverInfo["redfish_address"].(string)
+		if !ok {
+			continue
+		}
+		systemID, _ := driverInfo["redfish_system_id"].(string)
+		virtualMedia := strings.Contains(accessDetails.BootInterface(), "virtual-media")
+		client := newRedfishClient(address, host.BMC.Username, host.BMC.Password, host.BMC.DisableCertificateVerification)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = validateRedfishBMC(ctx, client, systemID, virtualMedia)
+		}(i)
+	}
+	wg.Wait()
+
+	allErrs := field.ErrorList{}
+	for i, err := range errs {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("bmc", "address"), hosts[i].BMC.Address, fmt.Sprintf("host %s: %v", hosts[i].Name, err)))
+		}
+	}
+	return allErrs.ToAggregate()
+}
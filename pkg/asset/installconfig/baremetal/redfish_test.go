@@ -0,0 +1,102 @@
+package baremetal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBMC serves the resources in a map, requiring the admin credentials.
+func fakeBMC(resources map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resource))
+	}))
+}
+
+func TestValidateRedfishBMC(t *testing.T) {
+	resources := map[string]string{
+		"/redfish/v1/":                               `{}`,
+		"/redfish/v1/Systems":                        `{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`,
+		"/redfish/v1/Systems/1":                      `{"Boot": {"BootSourceOverrideTarget@Redfish.AllowableValues": ["Pxe", "Hdd", "Cd"]}, "Links": {"ManagedBy": [{"@odata.id": "/redfish/v1/Managers/1"}]}}`,
+		"/redfish/v1/Systems/2":                      `{"Boot": {"BootSourceOverrideTarget@Redfish.AllowableValues": ["Pxe", "Hdd"]}}`,
+		"/redfish/v1/Managers/1":                     `{"VirtualMedia": {"@odata.id": "/redfish/v1/Managers/1/VirtualMedia"}}`,
+		"/redfish/v1/Managers/1/VirtualMedia":        `{"Members": [{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/Floppy"}, {"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/Cd"}]}`,
+		"/redfish/v1/Managers/1/VirtualMedia/Floppy": `{"MediaTypes": ["Floppy"]}`,
+		"/redfish/v1/Managers/1/VirtualMedia/Cd":     `{"MediaTypes": ["CD", "DVD"]}`,
+	}
+	server := fakeBMC(resources)
+	defer server.Close()
+
+	cases := []struct {
+		name         string
+		password     string
+		systemID     string
+		virtualMedia bool
+		errorMsg     string
+	}{
+		{
+			name:         "virtual media",
+			systemID:     "/redfish/v1/Systems/1",
+			virtualMedia: true,
+		},
+		{
+			name:         "single system",
+			virtualMedia: true,
+		},
+		{
+			name:     "invalid credentials",
+			password: "wrong",
+			systemID: "/redfish/v1/Systems/1",
+			errorMsg: "the BMC rejected the credentials (401 Unauthorized)",
+		},
+		{
+			name:     "unknown system",
+			systemID: "/redfish/v1/Systems/3",
+			errorMsg: "failed to get /redfish/v1/Systems/3 from the BMC (404 Not Found)",
+		},
+		{
+			name:     "no virtual media required",
+			systemID: "/redfish/v1/Systems/2",
+		},
+		{
+			name:         "no virtual CD boot",
+			systemID:     "/redfish/v1/Systems/2",
+			virtualMedia: true,
+			errorMsg:     "the system cannot boot from a virtual CD, the allowed boot targets are [Pxe Hdd]",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			password := tc.password
+			if password == "" {
+				password = "password"
+			}
+			client := newRedfishClient(server.URL, "admin", password, false)
+			err := validateRedfishBMC(context.Background(), client, tc.systemID, tc.virtualMedia)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRedfishBMCUnreachable(t *testing.T) {
+	client := newRedfishClient("http://127.0.0.1:1", "admin", "password", false)
+	err := validateRedfishBMC(context.Background(), client, "", false)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to connect to the BMC"))
+}
@@ -1,8 +1,15 @@
 package baremetal
 
 import (
+	"context"
+	"os/exec"
+
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/staticnetworkconfig"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/baremetal/validation"
@@ -35,3 +42,29 @@ func ValidateStaticBootstrapNetworking(ic *types.InstallConfig) error {
 
 	return nil
 }
+
+// ValidateNetworkConfig validates the network configs of the hosts with
+// nmstatectl, as the agent installer does, when it is installed.
+func ValidateNetworkConfig(ctx context.Context, ic *types.InstallConfig) error {
+	staticNetworkConfig := []*models.HostStaticNetworkConfig{}
+	for _, host := range ic.Platform.BareMetal.Hosts {
+		if host.NetworkConfig == nil {
+			continue
+		}
+		networkYaml, err := yaml.JSONToYAML(host.NetworkConfig.Raw)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert the network config of host %s", host.Name)
+		}
+		staticNetworkConfig = append(staticNetworkConfig, &models.HostStaticNetworkConfig{NetworkYaml: string(networkYaml)})
+	}
+	if len(staticNetworkConfig) == 0 {
+		return nil
+	}
+	if _, err := exec.LookPath("nmstatectl"); err != nil {
+		logrus.Debug("nmstatectl is not installed, skipping the validation of the network configs of the hosts")
+		return nil
+	}
+
+	generator := staticnetworkconfig.New(logrus.WithField("pkg", "baremetal"), staticnetworkconfig.Config{MaxConcurrentGenerations: 2})
+	return errors.Wrap(generator.ValidateStaticConfigParams(ctx, staticNetworkConfig), "invalid network config")
+}
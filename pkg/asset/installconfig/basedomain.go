@@ -25,7 +25,7 @@ type baseDomain struct {
 	BaseDomain string
 }
 
-var _ asset.Asset = (*baseDomain)(nil)
+var _ asset.InteractiveAsset = (*baseDomain)(nil)
 
 // Dependencies returns no dependencies.
 func (a *baseDomain) Dependencies() []asset.Asset {
@@ -111,3 +111,6 @@ func (a *baseDomain) Generate(parents asset.Parents) error {
 func (a *baseDomain) Name() string {
 	return "Base Domain"
 }
+
+// Interactive returns that the asset prompts the user
+func (a *baseDomain) Interactive() {}
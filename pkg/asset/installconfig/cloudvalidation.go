@@ -0,0 +1,39 @@
+package installconfig
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// SkipCloudValidationAnnotation is the install-config annotation which, when
+// set to "true", has the same effect as the --skip-cloud-validation flag.
+const SkipCloudValidationAnnotation = "installer.openshift.io/skip-cloud-validation"
+
+// SkipCloudValidation downgrades the failures of the validations which
+// connect to the cloud APIs (e.g. capacity, DNS and quota) to warnings, for
+// installer hosts which cannot reach the cloud APIs. The validations of the
+// install-config schema still fail. The asset cache is not used while it is
+// set, so that the assets generated without the validations are not reused.
+var SkipCloudValidation bool
+
+// CloudValidationSkipped returns true if the failures of the validations which
+// connect to the cloud APIs are downgraded to warnings, either by the flag or
+// by the annotation of the install config.
+func CloudValidationSkipped(config *types.InstallConfig) bool {
+	if SkipCloudValidation {
+		return true
+	}
+	return config != nil && config.Annotations[SkipCloudValidationAnnotation] == "true"
+}
+
+// CloudValidationError returns the error of the named validation which
+// connects to the cloud APIs. When the cloud validation is skipped, the error
+// is logged as a warning and nil is returned instead.
+func CloudValidationError(config *types.InstallConfig, name string, err error) error {
+	if err == nil || !CloudValidationSkipped(config) {
+		return err
+	}
+	logrus.Warnf("Ignoring the failure of the %s because the cloud validation is skipped: %v", name, err)
+	return nil
+}
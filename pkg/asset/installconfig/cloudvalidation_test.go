@@ -0,0 +1,57 @@
+package installconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestCloudValidationError(t *testing.T) {
+	validationErr := errors.New("failed to reach the cloud API")
+	cases := []struct {
+		name        string
+		flag        bool
+		annotations map[string]string
+		err         error
+		expectedErr error
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:        "not skipped",
+			err:         validationErr,
+			expectedErr: validationErr,
+		},
+		{
+			name: "skipped by the flag",
+			flag: true,
+			err:  validationErr,
+		},
+		{
+			name:        "skipped by the annotation",
+			annotations: map[string]string{SkipCloudValidationAnnotation: "true"},
+			err:         validationErr,
+		},
+		{
+			name:        "annotation not true",
+			annotations: map[string]string{SkipCloudValidationAnnotation: "yes"},
+			err:         validationErr,
+			expectedErr: validationErr,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SkipCloudValidation = tc.flag
+			defer func() { SkipCloudValidation = false }()
+			config := &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+			}
+			assert.Equal(t, tc.expectedErr, CloudValidationError(config, "test check", tc.err))
+		})
+	}
+}
@@ -13,7 +13,7 @@ type clusterName struct {
 	ClusterName string
 }
 
-var _ asset.Asset = (*clusterName)(nil)
+var _ asset.InteractiveAsset = (*clusterName)(nil)
 
 // Dependencies returns no dependencies.
 func (a *clusterName) Dependencies() []asset.Asset {
@@ -76,3 +76,6 @@ func (a *clusterName) Generate(parents asset.Parents) error {
 func (a *clusterName) Name() string {
 	return "Cluster Name"
 }
+
+// Interactive returns that the asset prompts the user
+func (a *clusterName) Interactive() {}
@@ -0,0 +1,64 @@
+package installconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coreos/stream-metadata-go/stream"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// loadCoreOSStream checks the stream metadata file and the pinned build of
+// the boot images of the install config, if any. The assets which select the
+// boot images read the stream from the install config.
+func loadCoreOSStream(ic *types.InstallConfig) field.ErrorList {
+	if ic.CoreOSStream == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	st, err := rhcos.FetchCoreOSStream(ctx, ic.CoreOSStream)
+	if err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("coreOSStream", "path"), ic.CoreOSStream.Path, err.Error())}
+	}
+	return validateCoreOSStream(ic, st)
+}
+
+// validateCoreOSStream checks that the stream provides the boot images of the
+// platform for the architectures of the cluster, and that they are the pinned
+// build, if any.
+func validateCoreOSStream(ic *types.InstallConfig, st *stream.Stream) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("coreOSStream")
+
+	architectures := []types.Architecture{}
+	seen := map[types.Architecture]bool{}
+	pools := append([]types.MachinePool{}, ic.Compute...)
+	if ic.ControlPlane != nil {
+		pools = append([]types.MachinePool{*ic.ControlPlane}, pools...)
+	}
+	for _, pool := range pools {
+		if !seen[pool.Architecture] {
+			seen[pool.Architecture] = true
+			architectures = append(architectures, pool.Architecture)
+		}
+	}
+
+	for _, architecture := range architectures {
+		release, err := rhcos.BootImageRelease(st, architecture, &ic.Platform)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), ic.CoreOSStream.Path, err.Error()))
+			continue
+		}
+		if pinned := ic.CoreOSStream.Release; pinned != "" && release != pinned {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("release"), pinned,
+				fmt.Sprintf("the stream provides the %s build %s for %s %s", st.Stream, release, ic.Platform.Name(), architecture)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,72 @@
+package installconfig
+
+import (
+	"testing"
+
+	"github.com/coreos/stream-metadata-go/stream"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestValidateCoreOSStream(t *testing.T) {
+	st := &stream.Stream{
+		Stream: "rhcos-4.13",
+		Architectures: map[string]stream.Arch{
+			"x86_64": {
+				Images: stream.Images{
+					Aws: &stream.AwsImage{Regions: map[string]stream.AwsRegionImage{
+						"us-east-1": {Release: "413.92.202305021736-0", Image: "ami-0123"},
+					}},
+				},
+			},
+			"aarch64": {},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		computeArch types.Architecture
+		release     string
+		expected    string
+	}{
+		{
+			name: "boot image found",
+		},
+		{
+			name:    "pinned build found",
+			release: "413.92.202305021736-0",
+		},
+		{
+			name:     "pinned build not found",
+			release:  "413.92.202304131328-0",
+			expected: `^coreOSStream\.release: Invalid value: "413\.92\.202304131328-0": the stream provides the rhcos-4\.13 build 413\.92\.202305021736-0 for aws amd64$`,
+		},
+		{
+			name:        "no boot image for the compute architecture",
+			computeArch: types.ArchitectureARM64,
+			expected:    `^coreOSStream\.path: Invalid value: "rhcos\.json": rhcos-4\.13/aarch64: No AMI found in us-west-2$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			computeArch := tc.computeArch
+			if computeArch == "" {
+				computeArch = types.ArchitectureAMD64
+			}
+			ic := &types.InstallConfig{
+				CoreOSStream: &types.CoreOSStream{Path: "rhcos.json", Release: tc.release},
+				Platform:     types.Platform{AWS: &aws.Platform{Region: "us-west-2"}},
+				ControlPlane: &types.MachinePool{Architecture: types.ArchitectureAMD64},
+				Compute:      []types.MachinePool{{Name: "worker", Architecture: computeArch}},
+			}
+			err := validateCoreOSStream(ic, st).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
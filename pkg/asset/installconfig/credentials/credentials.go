@@ -0,0 +1,144 @@
+// Package credentials discovers the credentials of the platforms from a
+// chain of sources, so that every platform applies the same precedence.
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// HelperEnv is the environment variable which names an external credential
+// helper. The helper is run as "<helper> get <platform>" and prints the
+// credentials of the platform on stdout, in the format of the credentials
+// file of the platform. It prints nothing if it has no credentials.
+const HelperEnv = "OPENSHIFT_INSTALL_CREDENTIAL_HELPER"
+
+// ErrNotFound is returned by a Source which has no credentials.
+var ErrNotFound = errors.New("credentials not found")
+
+// Source is a source of the credentials of a platform.
+type Source interface {
+	// Load returns the credentials, or ErrNotFound if the source has none.
+	Load(ctx context.Context) ([]byte, error)
+	// String describes the source for the logs, without the credentials.
+	String() string
+}
+
+// Chain is a list of sources in order of precedence.
+type Chain []Source
+
+// Load returns the credentials of the first source which has any, along with
+// that source. Only ErrNotFound moves on to the next source, any other error
+// is returned.
+func (c Chain) Load(ctx context.Context) ([]byte, Source, error) {
+	for _, source := range c {
+		content, err := source.Load(ctx)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, source, errors.Wrapf(err, "failed to load credentials from %s", source)
+		}
+		return content, source, nil
+	}
+	return nil, nil, ErrNotFound
+}
+
+// FileSource loads the credentials from a file.
+type FileSource struct {
+	Path string
+}
+
+// Load returns the content of the file, or ErrNotFound if it does not exist.
+func (f *FileSource) Load(ctx context.Context) ([]byte, error) {
+	content, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return content, err
+}
+
+func (f *FileSource) String() string {
+	return fmt.Sprintf("file %q", f.Path)
+}
+
+// EnvSource loads the credentials from the value of an environment variable.
+type EnvSource struct {
+	Name string
+}
+
+// Load returns the value of the environment variable, or ErrNotFound if it is
+// unset or empty.
+func (e *EnvSource) Load(ctx context.Context) ([]byte, error) {
+	if value := os.Getenv(e.Name); value != "" {
+		return []byte(value), nil
+	}
+	return nil, ErrNotFound
+}
+
+func (e *EnvSource) String() string {
+	return fmt.Sprintf("environment variable %q", e.Name)
+}
+
+// PromptSource asks the user for the credentials.
+type PromptSource struct {
+	Prompt func(ctx context.Context) ([]byte, error)
+}
+
+// Load returns the credentials entered by the user.
+func (p *PromptSource) Load(ctx context.Context) ([]byte, error) {
+	return p.Prompt(ctx)
+}
+
+func (p *PromptSource) String() string {
+	return "user input"
+}
+
+// CommandSource loads the credentials from the output of a command.
+type CommandSource struct {
+	Path string
+	Args []string
+}
+
+// Load runs the command and returns its output, or ErrNotFound if the path is
+// empty or the command printed nothing.
+func (c *CommandSource) Load(ctx context.Context) ([]byte, error) {
+	if c.Path == "" {
+		return nil, ErrNotFound
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.Path, c.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrap(err, msg)
+		}
+		return nil, err
+	}
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, ErrNotFound
+	}
+	return stdout.Bytes(), nil
+}
+
+func (c *CommandSource) String() string {
+	return fmt.Sprintf("command %q", strings.Join(append([]string{c.Path}, c.Args...), " "))
+}
+
+// HelperSource returns the source of the credentials of the platform from the
+// credential helper named by HelperEnv, if any.
+func HelperSource(platform string) Source {
+	return &CommandSource{
+		Path: os.Getenv(HelperEnv),
+		Args: []string{"get", platform},
+	}
+}
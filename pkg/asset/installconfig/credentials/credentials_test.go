@@ -0,0 +1,141 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticSource struct {
+	content string
+	err     error
+}
+
+func (s *staticSource) Load(ctx context.Context) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []byte(s.content), nil
+}
+
+func (s *staticSource) String() string {
+	return "static"
+}
+
+func TestChainLoad(t *testing.T) {
+	notFound := &staticSource{err: ErrNotFound}
+	found := &staticSource{content: "first"}
+	cases := []struct {
+		name     string
+		chain    Chain
+		expected string
+		source   Source
+		errorMsg string
+	}{
+		{
+			name:     "empty",
+			errorMsg: "credentials not found",
+		},
+		{
+			name:     "skips sources without credentials",
+			chain:    Chain{notFound, found, &staticSource{content: "second"}},
+			expected: "first",
+			source:   found,
+		},
+		{
+			name:     "nothing found",
+			chain:    Chain{notFound, notFound},
+			errorMsg: "credentials not found",
+		},
+		{
+			name:     "stops at an error",
+			chain:    Chain{&staticSource{err: errors.New("broken")}, found},
+			errorMsg: "failed to load credentials from static: broken",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, source, err := tc.chain.Load(context.Background())
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(content))
+			assert.Equal(t, tc.source, source)
+		})
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.json")
+
+	_, err := (&FileSource{Path: path}).Load(context.Background())
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"key": "value"}`), 0o600))
+	content, err := (&FileSource{Path: path}).Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key": "value"}`, string(content))
+}
+
+func TestEnvSource(t *testing.T) {
+	t.Setenv("TEST_CREDENTIALS", "")
+	_, err := (&EnvSource{Name: "TEST_CREDENTIALS"}).Load(context.Background())
+	assert.Equal(t, ErrNotFound, err)
+
+	t.Setenv("TEST_CREDENTIALS", "secret")
+	content, err := (&EnvSource{Name: "TEST_CREDENTIALS"}).Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(content))
+}
+
+func TestCommandSource(t *testing.T) {
+	cases := []struct {
+		name     string
+		source   *CommandSource
+		expected string
+		errorMsg string
+		notFound bool
+	}{
+		{
+			name:     "no command",
+			source:   &CommandSource{},
+			notFound: true,
+		},
+		{
+			name:     "output",
+			source:   &CommandSource{Path: "sh", Args: []string{"-c", `echo '{"key": "value"}'`}},
+			expected: "{\"key\": \"value\"}\n",
+		},
+		{
+			name:     "no output",
+			source:   &CommandSource{Path: "sh", Args: []string{"-c", "true"}},
+			notFound: true,
+		},
+		{
+			name:     "failure",
+			source:   &CommandSource{Path: "sh", Args: []string{"-c", "echo denied >&2; exit 1"}},
+			errorMsg: "denied: exit status 1",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := tc.source.Load(context.Background())
+			switch {
+			case tc.notFound:
+				assert.Equal(t, ErrNotFound, err)
+			case tc.errorMsg != "":
+				assert.EqualError(t, err, tc.errorMsg)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, string(content))
+			}
+		})
+	}
+}
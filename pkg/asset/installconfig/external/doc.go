@@ -0,0 +1,26 @@
+// Package external runs the plugins of the providers of the external
+// platform.
+//
+// A plugin is an executable which the installer runs once for each hook with
+// the name of the hook as its only argument. The installer writes a JSON
+// Request to the standard input of the plugin and reads a JSON Response from
+// its standard output. The standard error of the plugin is logged at the debug
+// level. A plugin fails a hook by exiting with a non-zero status.
+//
+// The installer first runs the describe hook, to which the plugin responds
+// with the hooks it implements:
+//
+//	validate-install-config: validates the install config before the assets
+//	    are generated, and responds with the errors of its fields.
+//	manifests: responds with the files to add to the manifests of the
+//	    cluster, such as the deployments of the cloud controller manager.
+//	post-install: checks the cluster once it is initialized, with the path of
+//	    the admin kubeconfig in the request.
+//
+// The plugin is the executable named by the OPENSHIFT_INSTALL_EXTERNAL_PLUGIN
+// environment variable if it is set, or else the executable named after the
+// platform name of the install config in the plugin directory. The plugin
+// directory is named by the OPENSHIFT_INSTALL_PLUGIN_DIR environment variable
+// and defaults to openshift-install/plugins in the user configuration
+// directory, e.g. ~/.config/openshift-install/plugins.
+package external
@@ -0,0 +1,295 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/external/defaults"
+)
+
+const (
+	// APIVersion is the version of the requests and of the responses of the
+	// plugins.
+	APIVersion = "v1"
+
+	// PluginEnvVar is the environment variable which names the executable of
+	// the plugin.
+	PluginEnvVar = "OPENSHIFT_INSTALL_EXTERNAL_PLUGIN"
+	// PluginDirEnvVar is the environment variable which names the directory
+	// of the plugins.
+	PluginDirEnvVar = "OPENSHIFT_INSTALL_PLUGIN_DIR"
+)
+
+// Hook is a step of the install which a plugin can implement.
+type Hook string
+
+const (
+	// DescribeHook lists the hooks the plugin implements. All the plugins
+	// implement it.
+	DescribeHook Hook = "describe"
+	// ValidateInstallConfigHook validates the install config.
+	ValidateInstallConfigHook Hook = "validate-install-config"
+	// ManifestsHook returns the manifests of the provider.
+	ManifestsHook Hook = "manifests"
+	// PostInstallHook checks the initialized cluster.
+	PostInstallHook Hook = "post-install"
+)
+
+// Request is the message the installer writes to the standard input of the
+// plugin.
+type Request struct {
+	APIVersion string `json:"apiVersion"`
+	Hook       Hook   `json:"hook"`
+	// InstallConfig is the install config of the cluster, without its pull
+	// secret.
+	InstallConfig *types.InstallConfig `json:"installConfig,omitempty"`
+	// Kubeconfig is the path of the admin kubeconfig of the cluster, which is
+	// only set for the post-install hook.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// Response is the message the plugin writes to its standard output.
+type Response struct {
+	APIVersion string `json:"apiVersion"`
+	// Hooks are the hooks the plugin implements, in the response to the
+	// describe hook.
+	Hooks []Hook `json:"hooks,omitempty"`
+	// Errors fail the validation of the install config or the post-install
+	// checks.
+	Errors []Error `json:"errors,omitempty"`
+	// Warnings are logged without failing the hook.
+	Warnings []string `json:"warnings,omitempty"`
+	// Files are the manifests of the provider.
+	Files []File `json:"files,omitempty"`
+}
+
+// Error is an error reported by a plugin.
+type Error struct {
+	// Field is the path of the invalid field of the install config, e.g.
+	// platform.external.platformName. It is empty for the errors which are not
+	// about a field.
+	Field string `json:"field,omitempty"`
+	// Value is the invalid value of the field.
+	Value   interface{} `json:"value,omitempty"`
+	Message string      `json:"message"`
+}
+
+// File is a manifest returned by a plugin.
+type File struct {
+	// Filename is the name of the file in the manifests directory.
+	Filename string `json:"filename"`
+	Contents string `json:"contents"`
+}
+
+// Plugin is the plugin of a provider of the external platform.
+type Plugin struct {
+	// Path is the path of the executable of the plugin.
+	Path  string
+	hooks map[Hook]bool
+}
+
+// Find returns the plugin of the provider of the platform, or nil if there is
+// none. The plugin is run once to learn the hooks it implements.
+func Find(ctx context.Context, platformName string) (*Plugin, error) {
+	path, err := findPath(platformName)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	p := &Plugin{Path: path}
+	resp, err := p.run(ctx, &Request{Hook: DescribeHook})
+	if err != nil {
+		return nil, err
+	}
+	p.hooks = make(map[Hook]bool, len(resp.Hooks))
+	for _, hook := range resp.Hooks {
+		p.hooks[hook] = true
+	}
+	logrus.Debugf("Using external platform plugin %s with hooks %v", path, resp.Hooks)
+	return p, nil
+}
+
+// findPath returns the path of the executable of the plugin of the platform,
+// or an empty path if there is none.
+func findPath(platformName string) (string, error) {
+	if path := os.Getenv(PluginEnvVar); path != "" {
+		if err := checkExecutable(path); err != nil {
+			return "", errors.Wrapf(err, "invalid plugin %s set by %s", path, PluginEnvVar)
+		}
+		return path, nil
+	}
+
+	if platformName == "" || platformName == defaults.UnknownPlatformName {
+		return "", nil
+	}
+
+	dir := os.Getenv(PluginDirEnvVar)
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			logrus.Debugf("No external platform plugin directory: %v", err)
+			return "", nil
+		}
+		dir = filepath.Join(configDir, "openshift-install", "plugins")
+	}
+
+	path := filepath.Join(dir, platformName)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		logrus.Debugf("No plugin for the external platform %q in %s", platformName, dir)
+		return "", nil
+	}
+	if err := checkExecutable(path); err != nil {
+		return "", errors.Wrapf(err, "invalid plugin %s", path)
+	}
+	return path, nil
+}
+
+func checkExecutable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+		return errors.New("not an executable file")
+	}
+	return nil
+}
+
+// Implements returns whether the plugin implements the hook.
+func (p *Plugin) Implements(hook Hook) bool {
+	return p.hooks[hook]
+}
+
+// ValidateInstallConfig returns the errors of the install config reported by
+// the plugin. The warnings of the plugin are logged.
+func (p *Plugin) ValidateInstallConfig(ctx context.Context, ic *types.InstallConfig) (field.ErrorList, error) {
+	if !p.Implements(ValidateInstallConfigHook) {
+		return nil, nil
+	}
+	resp, err := p.run(ctx, &Request{Hook: ValidateInstallConfigHook, InstallConfig: withoutPullSecret(ic)})
+	if err != nil {
+		return nil, err
+	}
+	logWarnings(resp.Warnings)
+
+	allErrs := field.ErrorList{}
+	for _, e := range resp.Errors {
+		fldPath := field.NewPath("platform", "external")
+		if e.Field != "" {
+			fldPath = field.NewPath(e.Field)
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath, e.Value, e.Message))
+	}
+	return allErrs, nil
+}
+
+// Manifests returns the manifests of the provider.
+func (p *Plugin) Manifests(ctx context.Context, ic *types.InstallConfig) ([]File, error) {
+	if !p.Implements(ManifestsHook) {
+		return nil, nil
+	}
+	resp, err := p.run(ctx, &Request{Hook: ManifestsHook, InstallConfig: withoutPullSecret(ic)})
+	if err != nil {
+		return nil, err
+	}
+	logWarnings(resp.Warnings)
+
+	for _, file := range resp.Files {
+		if file.Filename == "" || file.Filename != filepath.Base(file.Filename) || strings.HasPrefix(file.Filename, ".") {
+			return nil, errors.Errorf("plugin %s returned the invalid manifest file name %q", p.Path, file.Filename)
+		}
+		switch filepath.Ext(file.Filename) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil, errors.Errorf("plugin %s returned the manifest %q, which is not a YAML or JSON file", p.Path, file.Filename)
+		}
+	}
+	return resp.Files, nil
+}
+
+// PostInstall runs the checks of the plugin on the initialized cluster.
+func (p *Plugin) PostInstall(ctx context.Context, ic *types.InstallConfig, kubeconfig string) error {
+	if !p.Implements(PostInstallHook) {
+		return nil
+	}
+	resp, err := p.run(ctx, &Request{Hook: PostInstallHook, InstallConfig: withoutPullSecret(ic), Kubeconfig: kubeconfig})
+	if err != nil {
+		return err
+	}
+	logWarnings(resp.Warnings)
+
+	var errs []error
+	for _, e := range resp.Errors {
+		if e.Field != "" {
+			errs = append(errs, errors.Errorf("%s: %s", e.Field, e.Message))
+			continue
+		}
+		errs = append(errs, errors.New(e.Message))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// run runs the hook of the request and returns the response of the plugin.
+func (p *Plugin) run(ctx context.Context, req *Request) (*Response, error) {
+	req.APIVersion = APIVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the %s request", req.Hook)
+	}
+
+	// #nosec G204 -- the plugin is chosen by the user who runs the installer.
+	cmd := exec.CommandContext(ctx, p.Path, string(req.Hook))
+	cmd.Stdin = bytes.NewReader(input)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	scanner := bufio.NewScanner(bytes.NewReader(stderr.Bytes()))
+	for scanner.Scan() {
+		logrus.Debugf("%s %s: %s", filepath.Base(p.Path), req.Hook, scanner.Text())
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrapf(err, "plugin %s failed the %s hook: %s", p.Path, req.Hook, msg)
+		}
+		return nil, errors.Wrapf(err, "plugin %s failed the %s hook", p.Path, req.Hook)
+	}
+
+	resp := &Response{}
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, errors.Wrapf(err, "plugin %s returned an invalid %s response", p.Path, req.Hook)
+	}
+	if resp.APIVersion != APIVersion {
+		return nil, errors.Errorf("plugin %s returned the %s response with apiVersion %q, expected %q", p.Path, req.Hook, resp.APIVersion, APIVersion)
+	}
+	return resp, nil
+}
+
+func withoutPullSecret(ic *types.InstallConfig) *types.InstallConfig {
+	if ic == nil {
+		return nil
+	}
+	c := *ic
+	c.PullSecret = ""
+	return &c
+}
+
+func logWarnings(warnings []string) {
+	for _, warning := range warnings {
+		logrus.Warn(warning)
+	}
+}
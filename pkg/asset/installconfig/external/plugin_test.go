@@ -0,0 +1,193 @@
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// writePlugin writes a plugin which responds to each hook with the response of
+// the hook, and which saves the requests it receives in the directory.
+func writePlugin(t *testing.T, dir, name string, responses map[Hook]string) string {
+	script := "#!/bin/sh\ncat > \"$(dirname \"$0\")/$1.request\"\ncase \"$1\" in\n"
+	for hook, response := range responses {
+		script += string(hook) + ")\n\tcat <<'EOF'\n" + response + "\nEOF\n\t;;\n"
+	}
+	script += "*)\n\techo \"unknown hook $1\" >&2\n\texit 1\n\t;;\nesac\n"
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	describe := map[Hook]string{
+		DescribeHook: `{"apiVersion": "v1", "hooks": ["validate-install-config"]}`,
+	}
+	cases := []struct {
+		name         string
+		platformName string
+		envPlugin    bool
+		expectedPath string
+		expectedErr  string
+	}{
+		{
+			name:         "plugin directory",
+			platformName: "oci",
+			expectedPath: "oci",
+		},
+		{
+			name:         "no plugin in plugin directory",
+			platformName: "other",
+		},
+		{
+			name:         "unknown platform name",
+			platformName: "Unknown",
+		},
+		{
+			name:         "environment variable",
+			platformName: "other",
+			envPlugin:    true,
+			expectedPath: "env-plugin",
+		},
+		{
+			name:         "not executable",
+			platformName: "data",
+			expectedErr:  `^invalid plugin .*/data: not an executable file$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePlugin(t, dir, "oci", describe)
+			envPlugin := writePlugin(t, t.TempDir(), "env-plugin", describe)
+			if err := os.WriteFile(filepath.Join(dir, "data"), []byte("{}"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv(PluginDirEnvVar, dir)
+			t.Setenv(PluginEnvVar, "")
+			if tc.envPlugin {
+				t.Setenv(PluginEnvVar, envPlugin)
+			}
+
+			plugin, err := Find(context.Background(), tc.platformName)
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.expectedPath == "" {
+				assert.Nil(t, plugin)
+				return
+			}
+			if assert.NotNil(t, plugin) {
+				assert.Equal(t, tc.expectedPath, filepath.Base(plugin.Path))
+				assert.True(t, plugin.Implements(ValidateInstallConfigHook))
+				assert.False(t, plugin.Implements(ManifestsHook))
+			}
+		})
+	}
+}
+
+func TestHooks(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(PluginDirEnvVar, dir)
+	t.Setenv(PluginEnvVar, "")
+	writePlugin(t, dir, "oci", map[Hook]string{
+		DescribeHook:              `{"apiVersion": "v1", "hooks": ["validate-install-config", "manifests", "post-install"]}`,
+		ValidateInstallConfigHook: `{"apiVersion": "v1", "warnings": ["no compartment set"], "errors": [{"field": "platform.external.platformName", "value": "oci", "message": "tenancy not found"}]}`,
+		ManifestsHook:             `{"apiVersion": "v1", "files": [{"filename": "oci-ccm.yaml", "contents": "kind: Deployment"}]}`,
+		PostInstallHook:           `{"apiVersion": "v1", "errors": [{"message": "load balancer not ready"}]}`,
+	})
+
+	plugin, err := Find(context.Background(), "oci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ic := &types.InstallConfig{PullSecret: `{"auths": {}}`}
+
+	allErrs, err := plugin.ValidateInstallConfig(context.Background(), ic)
+	assert.NoError(t, err)
+	assert.EqualError(t, allErrs.ToAggregate(), `platform.external.platformName: Invalid value: "oci": tenancy not found`)
+
+	request, err := os.ReadFile(filepath.Join(dir, string(ValidateInstallConfigHook)+".request"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(request), `"apiVersion":"v1","hook":"validate-install-config"`)
+	assert.NotContains(t, string(request), "auths")
+	assert.Equal(t, `{"auths": {}}`, ic.PullSecret, "the install config must not be modified")
+
+	files, err := plugin.Manifests(context.Background(), ic)
+	assert.NoError(t, err)
+	assert.Equal(t, []File{{Filename: "oci-ccm.yaml", Contents: "kind: Deployment"}}, files)
+
+	err = plugin.PostInstall(context.Background(), ic, "/tmp/auth/kubeconfig")
+	assert.EqualError(t, err, "load balancer not ready")
+	request, err = os.ReadFile(filepath.Join(dir, string(PostInstallHook)+".request"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(request), `"kubeconfig":"/tmp/auth/kubeconfig"`)
+}
+
+func TestInvalidResponses(t *testing.T) {
+	cases := []struct {
+		name        string
+		responses   map[Hook]string
+		expectedErr string
+	}{
+		{
+			name:        "hook fails",
+			responses:   map[Hook]string{},
+			expectedErr: `^plugin .*/oci failed the describe hook: unknown hook describe: exit status 1$`,
+		},
+		{
+			name:        "invalid JSON",
+			responses:   map[Hook]string{DescribeHook: `hooks: []`},
+			expectedErr: `^plugin .*/oci returned an invalid describe response: `,
+		},
+		{
+			name:        "wrong API version",
+			responses:   map[Hook]string{DescribeHook: `{"apiVersion": "v2"}`},
+			expectedErr: `^plugin .*/oci returned the describe response with apiVersion "v2", expected "v1"$`,
+		},
+		{
+			name: "manifest outside the manifests directory",
+			responses: map[Hook]string{
+				DescribeHook:  `{"apiVersion": "v1", "hooks": ["manifests"]}`,
+				ManifestsHook: `{"apiVersion": "v1", "files": [{"filename": "../auth/kubeconfig.yaml"}]}`,
+			},
+			expectedErr: `^plugin .*/oci returned the invalid manifest file name "\.\./auth/kubeconfig\.yaml"$`,
+		},
+		{
+			name: "manifest not YAML",
+			responses: map[Hook]string{
+				DescribeHook:  `{"apiVersion": "v1", "hooks": ["manifests"]}`,
+				ManifestsHook: `{"apiVersion": "v1", "files": [{"filename": "ccm.sh"}]}`,
+			},
+			expectedErr: `^plugin .*/oci returned the manifest "ccm\.sh", which is not a YAML or JSON file$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv(PluginDirEnvVar, dir)
+			t.Setenv(PluginEnvVar, "")
+			writePlugin(t, dir, "oci", tc.responses)
+
+			plugin, err := Find(context.Background(), "oci")
+			if err == nil {
+				_, err = plugin.Manifests(context.Background(), &types.InstallConfig{})
+			}
+			assert.Regexp(t, tc.expectedErr, err)
+		})
+	}
+}
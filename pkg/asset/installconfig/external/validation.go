@@ -0,0 +1,31 @@
+package external
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// Validate executes platform-specific validation, which the plugin of the
+// provider implements.
+func Validate(ic *types.InstallConfig) error {
+	if ic.Platform.External == nil {
+		return field.Required(field.NewPath("platform", "external"), "external validation requires an external platform configuration")
+	}
+
+	plugin, err := Find(context.TODO(), ic.Platform.External.PlatformName)
+	if err != nil {
+		return field.InternalError(field.NewPath("platform", "external", "platformName"), err)
+	}
+	if plugin == nil {
+		return nil
+	}
+
+	allErrs, err := plugin.ValidateInstallConfig(context.TODO(), ic)
+	if err != nil {
+		return field.InternalError(field.NewPath("platform", "external"), err)
+	}
+	return allErrs.ToAggregate()
+}
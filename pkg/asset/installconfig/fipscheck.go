@@ -0,0 +1,135 @@
+package installconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/hostcrypt"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// fipsArchitectures are the architectures for which RHCOS ships FIPS
+// validated crypto modules.
+var fipsArchitectures = sets.NewString(types.ArchitectureAMD64, types.ArchitecturePPC64LE, types.ArchitectureS390X)
+
+// allowUnsignedFIPSPayloadEnv allows a FIPS install of a release payload
+// which is not signed, e.g. a CI build, or whose signature cannot be looked
+// up, e.g. in disconnected installs.
+const allowUnsignedFIPSPayloadEnv = "OPENSHIFT_INSTALL_ALLOW_UNSIGNED_FIPS_PAYLOAD"
+
+// FIPSCheck is an asset that checks, when FIPS is enabled in the install
+// config, that the installer host, the release payload and the platform can
+// install a FIPS cluster, so that the install fails before provisioning the
+// infrastructure rather than when the nodes boot.
+type FIPSCheck struct {
+}
+
+var _ asset.Asset = (*FIPSCheck)(nil)
+
+// Dependencies returns the dependencies for FIPSCheck
+func (a *FIPSCheck) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&InstallConfig{},
+	}
+}
+
+// Generate checks the FIPS requirements of the install config.
+func (a *FIPSCheck) Generate(dependencies asset.Parents) error {
+	ic := &InstallConfig{}
+	dependencies.Get(ic)
+	if !ic.Config.FIPS {
+		return nil
+	}
+	allowUnsigned, _ := strconv.ParseBool(os.Getenv(allowUnsignedFIPSPayloadEnv))
+	return validateFIPS(ic.Config, hostcrypt.VerifyFIPS, findReleaseSignature, allowUnsigned)
+}
+
+// Name returns the human-friendly name of the asset.
+func (a *FIPSCheck) Name() string {
+	return "FIPS Check"
+}
+
+// signatureFinder returns the pull spec of the release payload, and its
+// signature in the signature store.
+type signatureFinder func(pullSecret string) (string, *releaseimage.PayloadSignature, error)
+
+// findReleaseSignature looks up the signature of the release payload in the
+// default signature store.
+func findReleaseSignature(pullSecret string) (string, *releaseimage.PayloadSignature, error) {
+	releaseImage := &releaseimage.Image{}
+	if err := releaseImage.Generate(asset.Parents{}); err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	signature, err := releaseimage.FindPayloadSignature(ctx, releaseImage.PullSpec, pullSecret, releaseimage.DefaultSignatureStore)
+	return releaseImage.PullSpec, signature, err
+}
+
+// validateFIPS checks that the crypto of the installer host is FIPS-capable,
+// that the platform supports the FIPS mode of RHCOS and that the release
+// payload is signed. An unsigned payload, or a payload whose signature cannot
+// be looked up, is only reported with a warning when allowUnsigned is set.
+func validateFIPS(ic *types.InstallConfig, verifyHost func() error, findSignature signatureFinder, allowUnsigned bool) error {
+	var errs []error
+	if err := verifyHost(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFIPSPlatform(ic).ToAggregate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	pullSpec, signature, err := findSignature(ic.PullSecret)
+	switch {
+	case err != nil:
+		err = errors.Wrapf(err, "unable to check that the release payload %s is signed", pullSpec)
+	case !signature.Signed:
+		err = errors.Errorf("the release payload %s (%s) is not signed: no signature found at %s", pullSpec, signature.Digest, signature.URL)
+	}
+	if err != nil {
+		if !allowUnsigned {
+			errs = append(errs, errors.Errorf("%v (set %s=true to install it anyway)", err, allowUnsignedFIPSPayloadEnv))
+		} else {
+			logrus.Warnf("Installing the payload anyway, as %s is set: %v", allowUnsignedFIPSPayloadEnv, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateFIPSPlatform checks that the machines of the cluster run an
+// operating system and architecture with FIPS validated crypto modules.
+func validateFIPSPlatform(ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ic.IsFCOS() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("fips"), ic.FIPS, "Fedora CoreOS does not support the FIPS mode"))
+	}
+
+	if ic.ControlPlane != nil {
+		allErrs = append(allErrs, validateFIPSArchitecture(ic.ControlPlane, field.NewPath("controlPlane"))...)
+	}
+	for i := range ic.Compute {
+		allErrs = append(allErrs, validateFIPSArchitecture(&ic.Compute[i], field.NewPath("compute").Index(i))...)
+	}
+	return allErrs
+}
+
+func validateFIPSArchitecture(pool *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if arch := string(pool.Architecture); arch != "" && !fipsArchitectures.Has(arch) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("architecture"), arch,
+			fmt.Sprintf("FIPS validated crypto modules are only available for the %s architectures", strings.Join(fipsArchitectures.List(), ", "))))
+	}
+	return allErrs
+}
@@ -0,0 +1,97 @@
+package installconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestValidateFIPS(t *testing.T) {
+	const pullSpec = "quay.io/openshift-release-dev/ocp-release:4.13.0-x86_64"
+
+	signed := &releaseimage.PayloadSignature{Digest: "sha256:1234", URL: "https://example.com/sha256=1234/signature-1", Signed: true}
+	unsigned := &releaseimage.PayloadSignature{Digest: "sha256:1234", URL: "https://example.com/sha256=1234/signature-1"}
+
+	cases := []struct {
+		name          string
+		hostErr       error
+		computeArch   types.Architecture
+		signature     *releaseimage.PayloadSignature
+		checkErr      error
+		allowUnsigned bool
+		expected      string
+	}{
+		{
+			name:        "FIPS install",
+			computeArch: types.ArchitectureAMD64,
+			signature:   signed,
+		},
+		{
+			name:        "host not in FIPS mode",
+			hostErr:     errors.New("the host is not in FIPS mode"),
+			computeArch: types.ArchitectureAMD64,
+			signature:   signed,
+			expected:    `^the host is not in FIPS mode$`,
+		},
+		{
+			name:        "unsupported architecture",
+			computeArch: types.ArchitectureARM64,
+			signature:   signed,
+			expected:    `^compute\[0\]\.architecture: Invalid value: "arm64": FIPS validated crypto modules are only available for the amd64, ppc64le, s390x architectures$`,
+		},
+		{
+			name:        "unsigned payload",
+			computeArch: types.ArchitectureAMD64,
+			signature:   unsigned,
+			expected:    `^the release payload quay\.io/openshift-release-dev/ocp-release:4\.13\.0-x86_64 \(sha256:1234\) is not signed: no signature found at https://example\.com/sha256=1234/signature-1 \(set OPENSHIFT_INSTALL_ALLOW_UNSIGNED_FIPS_PAYLOAD=true to install it anyway\)$`,
+		},
+		{
+			name:          "allowed unsigned payload",
+			computeArch:   types.ArchitectureAMD64,
+			signature:     unsigned,
+			allowUnsigned: true,
+		},
+		{
+			name:        "unreachable signature store",
+			computeArch: types.ArchitectureAMD64,
+			checkErr:    errors.New("connection refused"),
+			expected:    `^unable to check that the release payload quay\.io/openshift-release-dev/ocp-release:4\.13\.0-x86_64 is signed: connection refused \(set OPENSHIFT_INSTALL_ALLOW_UNSIGNED_FIPS_PAYLOAD=true to install it anyway\)$`,
+		},
+		{
+			name:          "allowed unreachable signature store",
+			computeArch:   types.ArchitectureAMD64,
+			checkErr:      errors.New("connection refused"),
+			allowUnsigned: true,
+		},
+		{
+			name:        "every failure",
+			hostErr:     errors.New("the host is not in FIPS mode"),
+			computeArch: types.ArchitectureARM64,
+			signature:   unsigned,
+			expected:    `^\[the host is not in FIPS mode, compute\[0\]\.architecture: Invalid value: "arm64": .*, the release payload .* is not signed: .*\]$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				FIPS:         true,
+				PullSecret:   `{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`,
+				ControlPlane: &types.MachinePool{Architecture: types.ArchitectureAMD64},
+				Compute:      []types.MachinePool{{Name: "worker", Architecture: tc.computeArch}},
+			}
+			err := validateFIPS(ic, func() error { return tc.hostErr }, func(secret string) (string, *releaseimage.PayloadSignature, error) {
+				assert.Equal(t, ic.PullSecret, secret)
+				return pullSpec, tc.signature, tc.checkErr
+			}, tc.allowUnsigned)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
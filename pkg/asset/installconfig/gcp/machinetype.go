@@ -0,0 +1,261 @@
+package gcp
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// customMachineTypeRx matches the custom machine types, e.g. custom-4-16384,
+// n2-custom-8-32768 or n2-custom-8-81920-ext.
+var customMachineTypeRx = regexp.MustCompile(`^(?:(n2|n2d|e2)-)?custom-(\d+)-(\d+)(-ext)?$`)
+
+// customMemoryGranularity is the granularity in MB of the memory of the custom
+// machine types.
+const customMemoryGranularity = 256
+
+// machineFamily holds the rules of the custom machine types of a machine
+// family, and its predefined machine types.
+type machineFamily struct {
+	name string
+
+	// validVCPUs returns true if the custom machine types may have the number of vCPUs.
+	validVCPUs func(vcpus int64) bool
+	// vcpusRule describes the valid numbers of vCPUs.
+	vcpusRule string
+	maxVCPUs  int64
+
+	// minMemoryPerVCPU and maxMemoryPerVCPU bound the memory in MB per vCPU,
+	// unless the machine type has extended memory.
+	minMemoryPerVCPU float64
+	maxMemoryPerVCPU float64
+	// maxMemory is the maximum memory in MB, including extended memory.
+	maxMemory      int64
+	extendedMemory bool
+
+	// predefinedVCPUs are the numbers of vCPUs of the predefined machine types.
+	predefinedVCPUs []int64
+	// predefinedClasses maps the classes of the predefined machine types to
+	// their memory in MB per vCPU.
+	predefinedClasses []predefinedClass
+}
+
+type predefinedClass struct {
+	name          string
+	memoryPerVCPU float64
+	// minVCPUs and maxVCPUs bound the vCPUs of the class, if set.
+	minVCPUs int64
+	maxVCPUs int64
+}
+
+var (
+	standardClasses = []predefinedClass{
+		{name: "highcpu", memoryPerVCPU: 1024, maxVCPUs: 96},
+		{name: "standard", memoryPerVCPU: 4096},
+		{name: "highmem", memoryPerVCPU: 8192, maxVCPUs: 96},
+	}
+
+	machineFamilies = map[string]machineFamily{
+		"n1": {
+			name:             "n1",
+			validVCPUs:       func(v int64) bool { return v == 1 || v%2 == 0 },
+			vcpusRule:        "1 or an even number of vCPUs",
+			maxVCPUs:         96,
+			minMemoryPerVCPU: 921.6,
+			maxMemoryPerVCPU: 6656,
+			maxMemory:        638976,
+			extendedMemory:   true,
+			predefinedVCPUs:  []int64{1, 2, 4, 8, 16, 32, 64, 96},
+			predefinedClasses: []predefinedClass{
+				{name: "highcpu", memoryPerVCPU: 921.6, minVCPUs: 2},
+				{name: "standard", memoryPerVCPU: 3840},
+				{name: "highmem", memoryPerVCPU: 6656, minVCPUs: 2},
+			},
+		},
+		"n2": {
+			name:              "n2",
+			validVCPUs:        func(v int64) bool { return (v <= 32 && v%2 == 0) || v%4 == 0 },
+			vcpusRule:         "a multiple of 2 vCPUs up to 32, and a multiple of 4 vCPUs above",
+			maxVCPUs:          128,
+			minMemoryPerVCPU:  512,
+			maxMemoryPerVCPU:  8192,
+			maxMemory:         884736,
+			extendedMemory:    true,
+			predefinedVCPUs:   []int64{2, 4, 8, 16, 32, 48, 64, 80, 96, 128},
+			predefinedClasses: standardClasses,
+		},
+		"n2d": {
+			name:              "n2d",
+			validVCPUs:        func(v int64) bool { return v == 2 || v == 4 || v == 8 || v%16 == 0 },
+			vcpusRule:         "2, 4, 8 or a multiple of 16 vCPUs",
+			maxVCPUs:          96,
+			minMemoryPerVCPU:  512,
+			maxMemoryPerVCPU:  8192,
+			maxMemory:         786432,
+			extendedMemory:    true,
+			predefinedVCPUs:   []int64{2, 4, 8, 16, 32, 48, 64, 80, 96, 128, 224},
+			predefinedClasses: standardClasses,
+		},
+		"e2": {
+			name:              "e2",
+			validVCPUs:        func(v int64) bool { return v%2 == 0 },
+			vcpusRule:         "an even number of vCPUs",
+			maxVCPUs:          32,
+			minMemoryPerVCPU:  512,
+			maxMemoryPerVCPU:  8192,
+			maxMemory:         131072,
+			predefinedVCPUs:   []int64{2, 4, 8, 16, 32},
+			predefinedClasses: standardClasses,
+		},
+	}
+)
+
+// customMachineType is a parsed custom machine type.
+type customMachineType struct {
+	family         machineFamily
+	vcpus          int64
+	memory         int64
+	extendedMemory bool
+}
+
+// parseCustomMachineType parses a custom machine type. It returns false if the
+// machine type is not a custom machine type.
+func parseCustomMachineType(machineType string) (customMachineType, bool) {
+	m := customMachineTypeRx.FindStringSubmatch(machineType)
+	if m == nil {
+		return customMachineType{}, false
+	}
+	family := m[1]
+	if family == "" {
+		family = "n1"
+	}
+	vcpus, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return customMachineType{}, false
+	}
+	memory, err := strconv.ParseInt(m[3], 10, 64)
+	if err != nil {
+		return customMachineType{}, false
+	}
+	return customMachineType{
+		family:         machineFamilies[family],
+		vcpus:          vcpus,
+		memory:         memory,
+		extendedMemory: m[4] != "",
+	}, true
+}
+
+// String returns the name of the custom machine type.
+func (t customMachineType) String() string {
+	name := fmt.Sprintf("custom-%d-%d", t.vcpus, t.memory)
+	if t.family.name != "n1" {
+		name = fmt.Sprintf("%s-%s", t.family.name, name)
+	}
+	if t.extendedMemory {
+		name += "-ext"
+	}
+	return name
+}
+
+// validate checks the custom machine type against the rules of its family.
+func (t customMachineType) validate() error {
+	f := t.family
+	switch {
+	case t.vcpus < 1 || t.vcpus > f.maxVCPUs:
+		return fmt.Errorf("%s custom machine types must have between 1 and %d vCPUs", f.name, f.maxVCPUs)
+	case !f.validVCPUs(t.vcpus):
+		return fmt.Errorf("%s custom machine types must have %s", f.name, f.vcpusRule)
+	case t.memory%customMemoryGranularity != 0:
+		return fmt.Errorf("the memory of custom machine types must be a multiple of %d MB", customMemoryGranularity)
+	case t.extendedMemory && !f.extendedMemory:
+		return fmt.Errorf("%s custom machine types do not support extended memory", f.name)
+	case float64(t.memory) < f.minMemoryPerVCPU*float64(t.vcpus):
+		return fmt.Errorf("%s custom machine types must have at least %g MB of memory per vCPU", f.name, f.minMemoryPerVCPU)
+	case !t.extendedMemory && float64(t.memory) > f.maxMemoryPerVCPU*float64(t.vcpus):
+		return fmt.Errorf("%s custom machine types must have at most %g MB of memory per vCPU without extended memory", f.name, f.maxMemoryPerVCPU)
+	case t.memory > f.maxMemory:
+		return fmt.Errorf("%s custom machine types must have at most %d MB of memory", f.name, f.maxMemory)
+	}
+	return nil
+}
+
+// nearestCustom returns the valid custom machine type of the family nearest to
+// the custom machine type: the fewest valid vCPUs and the least memory that
+// are at least those requested, within the limits of the family.
+func (t customMachineType) nearestCustom() customMachineType {
+	f := t.family
+	nearest := customMachineType{family: f, extendedMemory: t.extendedMemory && f.extendedMemory}
+
+	nearest.vcpus = f.maxVCPUs
+	for v := t.vcpus; v < f.maxVCPUs; v++ {
+		if v >= 1 && f.validVCPUs(v) {
+			nearest.vcpus = v
+			break
+		}
+	}
+
+	memory := roundUpMemory(float64(t.memory))
+	if memory > f.maxMemory {
+		memory = f.maxMemory
+	}
+	if !nearest.extendedMemory {
+		// Add vCPUs until the memory fits, or cap the memory.
+		for float64(memory) > f.maxMemoryPerVCPU*float64(nearest.vcpus) && nearest.vcpus < f.maxVCPUs {
+			nearest.vcpus++
+			for !f.validVCPUs(nearest.vcpus) {
+				nearest.vcpus++
+			}
+		}
+		if limit := int64(f.maxMemoryPerVCPU*float64(nearest.vcpus)) / customMemoryGranularity * customMemoryGranularity; memory > limit {
+			memory = limit
+		}
+	}
+	if limit := roundUpMemory(f.minMemoryPerVCPU * float64(nearest.vcpus)); memory < limit {
+		memory = limit
+	}
+	nearest.memory = memory
+	return nearest
+}
+
+// nearestPredefined returns the smallest predefined machine type of the family
+// with at least the vCPUs and memory of the custom machine type, or "" if
+// there is none.
+func (t customMachineType) nearestPredefined() string {
+	f := t.family
+	for _, vcpus := range f.predefinedVCPUs {
+		if vcpus < t.vcpus {
+			continue
+		}
+		for _, class := range f.predefinedClasses {
+			if vcpus < class.minVCPUs || (class.maxVCPUs != 0 && vcpus > class.maxVCPUs) {
+				continue
+			}
+			if class.memoryPerVCPU*float64(vcpus) >= float64(t.memory) {
+				return fmt.Sprintf("%s-%s-%d", f.name, class.name, vcpus)
+			}
+		}
+	}
+	return ""
+}
+
+// roundUpMemory rounds the memory in MB up to the granularity of the memory
+// of custom machine types.
+func roundUpMemory(memory float64) int64 {
+	return int64(math.Ceil(memory/customMemoryGranularity)) * customMemoryGranularity
+}
+
+// validateCustomMachineType checks that a custom machine type follows the
+// rules of its machine family. On failure, the error suggests the nearest
+// valid custom and predefined machine types.
+func validateCustomMachineType(t customMachineType) error {
+	err := t.validate()
+	if err == nil {
+		return nil
+	}
+	suggestion := fmt.Sprintf("the nearest valid custom machine type is %s", t.nearestCustom())
+	if predefined := t.nearestPredefined(); predefined != "" {
+		suggestion = fmt.Sprintf("%s, and the nearest predefined machine type is %s", suggestion, predefined)
+	}
+	return fmt.Errorf("%v; %s", err, suggestion)
+}
@@ -0,0 +1,74 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCustomMachineType(t *testing.T) {
+	cases := []struct {
+		machineType string
+		errorMsg    string
+	}{
+		{
+			machineType: "custom-4-16384",
+		},
+		{
+			machineType: "n2-custom-8-81920-ext",
+		},
+		{
+			machineType: "custom-3-12288",
+			errorMsg:    "n1 custom machine types must have 1 or an even number of vCPUs; the nearest valid custom machine type is custom-4-12288, and the nearest predefined machine type is n1-standard-4",
+		},
+		{
+			machineType: "n2-custom-34-65536",
+			errorMsg:    "n2 custom machine types must have a multiple of 2 vCPUs up to 32, and a multiple of 4 vCPUs above; the nearest valid custom machine type is n2-custom-36-65536, and the nearest predefined machine type is n2-standard-48",
+		},
+		{
+			machineType: "n2d-custom-12-49152",
+			errorMsg:    "n2d custom machine types must have 2, 4, 8 or a multiple of 16 vCPUs; the nearest valid custom machine type is n2d-custom-16-49152, and the nearest predefined machine type is n2d-standard-16",
+		},
+		{
+			machineType: "custom-4-16000",
+			errorMsg:    "the memory of custom machine types must be a multiple of 256 MB; the nearest valid custom machine type is custom-4-16128, and the nearest predefined machine type is n1-highmem-4",
+		},
+		{
+			machineType: "e2-custom-4-65536",
+			errorMsg:    "e2 custom machine types must have at most 8192 MB of memory per vCPU without extended memory; the nearest valid custom machine type is e2-custom-8-65536, and the nearest predefined machine type is e2-highmem-8",
+		},
+		{
+			machineType: "custom-8-4096",
+			errorMsg:    "n1 custom machine types must have at least 921.6 MB of memory per vCPU; the nearest valid custom machine type is custom-8-7424, and the nearest predefined machine type is n1-highcpu-8",
+		},
+		{
+			machineType: "e2-custom-4-16384-ext",
+			errorMsg:    "e2 custom machine types do not support extended memory; the nearest valid custom machine type is e2-custom-4-16384, and the nearest predefined machine type is e2-standard-4",
+		},
+		{
+			machineType: "e2-custom-64-262144",
+			errorMsg:    "e2 custom machine types must have between 1 and 32 vCPUs; the nearest valid custom machine type is e2-custom-32-131072",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.machineType, func(t *testing.T) {
+			custom, ok := parseCustomMachineType(tc.machineType)
+			assert.True(t, ok)
+			err := validateCustomMachineType(custom)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseCustomMachineType(t *testing.T) {
+	_, ok := parseCustomMachineType("n1-standard-4")
+	assert.False(t, ok)
+
+	custom, ok := parseCustomMachineType("n2-custom-8-81920-ext")
+	assert.True(t, ok)
+	assert.Equal(t, "n2-custom-8-81920-ext", custom.String())
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/sirupsen/logrus"
 	googleoauth "golang.org/x/oauth2/google"
 	compute "google.golang.org/api/compute/v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/credentials"
 )
 
 var (
@@ -20,6 +22,10 @@ var (
 	defaultAuthFilePath = filepath.Join(os.Getenv("HOME"), ".gcp", "osServiceAccount.json")
 	credLoaders         = []credLoader{}
 	onceLoggers         = map[credLoader]*sync.Once{}
+
+	// credLoadersMutex serializes the loading of the credentials by the
+	// assets generated concurrently, as it may prompt the user.
+	credLoadersMutex sync.Mutex
 )
 
 // Session is an object representing session for GCP API.
@@ -31,6 +37,7 @@ type Session struct {
 // env GOOGLE_CREDENTIALS,
 // env GOOGLE_CLOUD_KEYFILE_JSON,
 // env GCLOUD_KEYFILE_JSON,
+// the credential helper named by env OPENSHIFT_INSTALL_CREDENTIAL_HELPER,
 // file ~/.gcp/osServiceAccount.json, and
 // gcloud cli defaults
 // and, if no creds are found, asks for them and stores them on disk in a config file
@@ -46,10 +53,14 @@ func GetSession(ctx context.Context) (*Session, error) {
 }
 
 func loadCredentials(ctx context.Context) (*googleoauth.Credentials, error) {
+	credLoadersMutex.Lock()
+	defer credLoadersMutex.Unlock()
+
 	if len(credLoaders) == 0 {
 		for _, authEnv := range authEnvs {
 			credLoaders = append(credLoaders, &envLoader{env: authEnv})
 		}
+		credLoaders = append(credLoaders, &sourceLoader{source: credentials.HelperSource("gcp")})
 		credLoaders = append(credLoaders, &fileLoader{path: defaultAuthFilePath})
 		credLoaders = append(credLoaders, &cliLoader{})
 
@@ -166,6 +177,22 @@ func (f *contentLoader) String() string {
 	return "content <redacted>"
 }
 
+type sourceLoader struct {
+	source credentials.Source
+}
+
+func (s *sourceLoader) Load(ctx context.Context) (*googleoauth.Credentials, error) {
+	content, err := s.source.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (&contentLoader{content: string(content)}).Load(ctx)
+}
+
+func (s *sourceLoader) String() string {
+	return s.source.String()
+}
+
 type cliLoader struct{}
 
 func (c *cliLoader) Load(ctx context.Context) (*googleoauth.Credentials, error) {
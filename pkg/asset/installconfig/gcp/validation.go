@@ -33,6 +33,24 @@ var computeReq = resourceRequirements{
 	minimumMemory: 7680,
 }
 
+// networkProjectPermissions are the permissions required in the host project
+// of a shared VPC to attach the machines to its subnets and to bind the
+// private DNS zone of the cluster to its network.
+var networkProjectPermissions = []string{
+	"compute.networks.get",
+	"compute.subnetworks.get",
+	"compute.subnetworks.use",
+	"dns.networks.bindPrivateDNSZone",
+}
+
+// networkProjectFirewallPermissions are the permissions required in the host
+// project of a shared VPC to create the firewall rules of the cluster.
+var networkProjectFirewallPermissions = []string{
+	"compute.firewalls.create",
+	"compute.firewalls.delete",
+	"compute.networks.updatePolicy",
+}
+
 // Validate executes platform-specific validation.
 func Validate(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
@@ -55,6 +73,12 @@ func Validate(client API, ic *types.InstallConfig) error {
 func ValidateInstanceType(client API, fieldPath *field.Path, project, zone, instanceType string, req resourceRequirements) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if custom, ok := parseCustomMachineType(instanceType); ok {
+		if err := validateCustomMachineType(custom); err != nil {
+			return append(allErrs, field.Invalid(fieldPath.Child("type"), instanceType, err.Error()))
+		}
+	}
+
 	typeMeta, err := client.GetMachineType(context.TODO(), project, zone, instanceType)
 	if err != nil {
 		if _, ok := err.(*googleapi.Error); ok {
@@ -124,6 +148,11 @@ func ValidatePreExistingPublicDNS(client API, ic *types.InstallConfig) error {
 		return nil
 	}
 
+	// The records of a user-managed load balancer are expected to exist
+	if ic.UserManagedCloudLoadBalancer() {
+		return nil
+	}
+
 	record := fmt.Sprintf("api.%s.", strings.TrimSuffix(ic.ClusterDomain(), "."))
 
 	zone, err := client.GetPublicDNSZone(context.TODO(), ic.Platform.GCP.ProjectID, ic.BaseDomain)
@@ -178,6 +207,30 @@ func validateNetworkProject(client API, ic *types.InstallConfig, fieldPath *fiel
 		if _, found := projects[ic.GCP.NetworkProjectID]; !found {
 			return append(allErrs, field.Invalid(fieldPath.Child("networkProjectID"), ic.GCP.NetworkProjectID, "invalid project ID"))
 		}
+		allErrs = append(allErrs, validateNetworkProjectPermissions(client, ic, fieldPath.Child("networkProjectID"))...)
+	}
+
+	return allErrs
+}
+
+// validateNetworkProjectPermissions checks that the credentials have the
+// permissions in the host project which installing into a shared VPC requires.
+// Missing firewall permissions only produce a warning, because the firewall
+// rules can be created by the administrator of the host project instead.
+func validateNetworkProjectPermissions(client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	permissions, err := client.GetProjectPermissions(context.TODO(), ic.GCP.NetworkProjectID, append(networkProjectPermissions, networkProjectFirewallPermissions...))
+	if err != nil {
+		return append(allErrs, field.InternalError(fieldPath, err))
+	}
+
+	if missing := sets.List(sets.New[string](networkProjectPermissions...).Difference(permissions)); len(missing) > 0 {
+		errMsg := fmt.Sprintf("the credentials are missing the permissions %s in the network project", strings.Join(missing, ", "))
+		allErrs = append(allErrs, field.Forbidden(fieldPath, errMsg))
+	}
+	if missing := sets.List(sets.New[string](networkProjectFirewallPermissions...).Difference(permissions)); len(missing) > 0 {
+		logrus.Warnf("The credentials are missing the permissions %s in network project %s; the firewall rules of the cluster will not be created and must be created in the network project before installing", strings.Join(missing, ", "), ic.GCP.NetworkProjectID)
 	}
 
 	return allErrs
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/api/googleapi"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/asset/installconfig/gcp/mock"
 	"github.com/openshift/installer/pkg/ipnet"
@@ -75,6 +76,10 @@ var (
 		ic.Compute[0].Platform.GCP.InstanceType = "n1-standard-1"
 	}
 
+	invalidateCustomControlPlaneMachineTypes = func(ic *types.InstallConfig) {
+		ic.ControlPlane.Platform.GCP.InstanceType = "custom-5-20480"
+	}
+
 	undefinedDefaultMachineTypes = func(ic *types.InstallConfig) {
 		ic.Platform.GCP.DefaultMachinePlatform.InstanceType = "n1-dne-1"
 	}
@@ -214,6 +219,12 @@ func TestGCPInstallConfigValidation(t *testing.T) {
 			expectedError:  true,
 			expectedErrMsg: `[controlPlane.platform.gcp.type: Invalid value: "n1\-standard\-1": instance type does not meet minimum resource requirements of 4 vCPUs, controlPlane.platform.gcp.type: Invalid value: "n1\-standard\-1": instance type does not meet minimum resource requirements of 15361 MB Memory]`,
 		},
+		{
+			name:           "Invalid custom control plane machine types",
+			edits:          editFunctions{invalidateCustomControlPlaneMachineTypes},
+			expectedError:  true,
+			expectedErrMsg: `controlPlane.platform.gcp.type: Invalid value: "custom\-5\-20480": n1 custom machine types must have 1 or an even number of vCPUs; the nearest valid custom machine type is custom\-6\-20480, and the nearest predefined machine type is n1\-standard\-8`,
+		},
 		{
 			name:           "Invalid compute machine types",
 			edits:          editFunctions{invalidateComputeMachineTypes},
@@ -492,3 +503,42 @@ func TestValidateCredentialMode(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNetworkProjectPermissions(t *testing.T) {
+	cases := []struct {
+		name        string
+		permissions []string
+		err         string
+	}{{
+		name:        "all permissions",
+		permissions: append(networkProjectPermissions, networkProjectFirewallPermissions...),
+	}, {
+		name:        "missing firewall permissions",
+		permissions: networkProjectPermissions,
+	}, {
+		name:        "missing subnetwork permissions",
+		permissions: []string{"compute.networks.get", "compute.subnetworks.get", "dns.networks.bindPrivateDNSZone"},
+		err:         `^platform.gcp.networkProjectID: Forbidden: the credentials are missing the permissions compute.subnetworks.use in the network project$`,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			gcpClient := mock.NewMockAPI(mockCtrl)
+			gcpClient.EXPECT().GetProjectPermissions(gomock.Any(), "host-project", gomock.Any()).Return(sets.New[string](test.permissions...), nil)
+
+			ic := types.InstallConfig{
+				Platform: types.Platform{GCP: &gcp.Platform{ProjectID: "project-id", NetworkProjectID: "host-project"}},
+			}
+
+			err := validateNetworkProjectPermissions(gcpClient, &ic, field.NewPath("platform", "gcp", "networkProjectID")).ToAggregate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, test.err, err)
+			}
+		})
+	}
+}
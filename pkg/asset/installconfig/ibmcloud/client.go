@@ -38,6 +38,7 @@ type API interface {
 	GetDNSZoneIDByName(ctx context.Context, name string, publish types.PublishingStrategy) (string, error)
 	GetDNSZones(ctx context.Context, publish types.PublishingStrategy) ([]responses.DNSZoneResponse, error)
 	GetEncryptionKey(ctx context.Context, keyCRN string) (*responses.EncryptionKeyResponse, error)
+	GetPlacementGroupByName(ctx context.Context, name string, region string) (*vpcv1.PlacementGroup, error)
 	GetResourceGroups(ctx context.Context) ([]resourcemanagerv2.ResourceGroup, error)
 	GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error)
 	GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet, error)
@@ -223,6 +224,37 @@ func (c *Client) GetDedicatedHostProfiles(ctx context.Context, region string) ([
 	return profiles.Profiles, nil
 }
 
+// GetPlacementGroupByName gets placement group by name.
+func (c *Client) GetPlacementGroupByName(ctx context.Context, name string, region string) (*vpcv1.PlacementGroup, error) {
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	options := c.vpcAPI.NewListPlacementGroupsOptions()
+	for {
+		groups, _, err := c.vpcAPI.ListPlacementGroupsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list placement groups")
+		}
+
+		for i := range groups.PlacementGroups {
+			if *groups.PlacementGroups[i].Name == name {
+				return &groups.PlacementGroups[i], nil
+			}
+		}
+
+		start, err := groups.GetNextStart()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list placement groups")
+		}
+		if start == nil {
+			return nil, fmt.Errorf("placement group %q not found", name)
+		}
+		options.SetStart(*start)
+	}
+}
+
 // GetDNSRecordsByName gets DNS records in specific Cloud Internet Services instance
 // by its CRN, zone ID, and DNS record name.
 func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID string, recordName string) ([]dnsrecordsv1.DnsrecordDetails, error) {
@@ -205,6 +205,21 @@ func (mr *MockAPIMockRecorder) GetEncryptionKey(ctx, keyCRN interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncryptionKey", reflect.TypeOf((*MockAPI)(nil).GetEncryptionKey), ctx, keyCRN)
 }
 
+// GetPlacementGroupByName mocks base method.
+func (m *MockAPI) GetPlacementGroupByName(ctx context.Context, name, region string) (*vpcv1.PlacementGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlacementGroupByName", ctx, name, region)
+	ret0, _ := ret[0].(*vpcv1.PlacementGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlacementGroupByName indicates an expected call of GetPlacementGroupByName.
+func (mr *MockAPIMockRecorder) GetPlacementGroupByName(ctx, name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlacementGroupByName", reflect.TypeOf((*MockAPI)(nil).GetPlacementGroupByName), ctx, name, region)
+}
+
 // GetResourceGroup mocks base method.
 func (m *MockAPI) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
 	m.ctrl.T.Helper()
@@ -22,19 +22,44 @@ func Validate(client API, ic *types.InstallConfig) error {
 	if ic.ControlPlane != nil && ic.ControlPlane.Platform.IBMCloud != nil {
 		machinePool := ic.ControlPlane.Platform.IBMCloud
 		fldPath := field.NewPath("controlPlane").Child("platform").Child("ibmcloud")
-		allErrs = append(allErrs, validateMachinePool(client, ic.Platform.IBMCloud, machinePool, fldPath)...)
+		allErrs = append(allErrs, validateMachinePool(client, ic.Platform.IBMCloud, machinePool, poolReplicas(ic.ControlPlane), fldPath)...)
 	}
 	for idx, compute := range ic.Compute {
 		machinePool := compute.Platform.IBMCloud
 		fldPath := field.NewPath("compute").Index(idx).Child("platform").Child("ibmcloud")
 		if machinePool != nil {
-			allErrs = append(allErrs, validateMachinePool(client, ic.Platform.IBMCloud, machinePool, fldPath)...)
+			allErrs = append(allErrs, validateMachinePool(client, ic.Platform.IBMCloud, machinePool, poolReplicas(&ic.Compute[idx]), fldPath)...)
 		}
 	}
 
 	return allErrs.ToAggregate()
 }
 
+// poolReplicas returns the number of machines of the machine pool.
+func poolReplicas(pool *types.MachinePool) int64 {
+	if pool == nil || pool.Replicas == nil {
+		return 0
+	}
+	return *pool.Replicas
+}
+
+// defaultMachinePlatformReplicas returns the number of machines of the
+// machine pools whose dedicated hosts are those of the default machine
+// platform.
+func defaultMachinePlatformReplicas(ic *types.InstallConfig) int64 {
+	replicas := int64(0)
+	pools := append([]types.MachinePool{}, ic.Compute...)
+	if ic.ControlPlane != nil {
+		pools = append(pools, *ic.ControlPlane)
+	}
+	for i := range pools {
+		if pools[i].Platform.IBMCloud == nil || len(pools[i].Platform.IBMCloud.DedicatedHosts) == 0 {
+			replicas += poolReplicas(&pools[i])
+		}
+	}
+	return replicas
+}
+
 func validatePlatform(client API, ic *types.InstallConfig, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -47,12 +72,12 @@ func validatePlatform(client API, ic *types.InstallConfig, path *field.Path) fie
 	}
 
 	if ic.Platform.IBMCloud.DefaultMachinePlatform != nil {
-		allErrs = append(allErrs, validateMachinePool(client, ic.IBMCloud, ic.Platform.IBMCloud.DefaultMachinePlatform, path)...)
+		allErrs = append(allErrs, validateMachinePool(client, ic.IBMCloud, ic.Platform.IBMCloud.DefaultMachinePlatform, defaultMachinePlatformReplicas(ic), path)...)
 	}
 	return allErrs
 }
 
-func validateMachinePool(client API, platform *ibmcloud.Platform, machinePool *ibmcloud.MachinePool, path *field.Path) field.ErrorList {
+func validateMachinePool(client API, platform *ibmcloud.Platform, machinePool *ibmcloud.MachinePool, replicas int64, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if machinePool.InstanceType != "" {
@@ -68,13 +93,44 @@ func validateMachinePool(client API, platform *ibmcloud.Platform, machinePool *i
 	}
 
 	if len(machinePool.DedicatedHosts) > 0 {
-		allErrs = append(allErrs, validateMachinePoolDedicatedHosts(client, machinePool.DedicatedHosts, machinePool.InstanceType, machinePool.Zones, platform.Region, path.Child("dedicatedHosts"))...)
+		allErrs = append(allErrs, validateMachinePoolDedicatedHosts(client, machinePool.DedicatedHosts, machinePool.InstanceType, machinePool.Zones, replicas, platform.Region, path.Child("dedicatedHosts"))...)
+	}
+
+	if machinePool.PlacementGroup != nil && machinePool.PlacementGroup.Name != "" {
+		allErrs = append(allErrs, validateMachinePoolPlacementGroup(client, machinePool.PlacementGroup.Name, platform.Region, path.Child("placementGroup", "name"))...)
 	}
 
 	return allErrs
 }
 
-func validateMachinePoolDedicatedHosts(client API, dhosts []ibmcloud.DedicatedHost, machineType string, zones []string, region string, path *field.Path) field.ErrorList {
+func validateMachinePoolPlacementGroup(client API, name string, region string, path *field.Path) field.ErrorList {
+	pg, err := client.GetPlacementGroupByName(context.TODO(), name, region)
+	if err != nil {
+		return field.ErrorList{field.NotFound(path, name)}
+	}
+
+	// Instances can only be placed in a placement group which is ready
+	if pg.LifecycleState != nil && *pg.LifecycleState != vpcv1.PlacementGroupLifecycleStateStableConst {
+		return field.ErrorList{field.Invalid(path, name, fmt.Sprintf("placement group is %s, it must be %s", *pg.LifecycleState, vpcv1.PlacementGroupLifecycleStateStableConst))}
+	}
+	return nil
+}
+
+// instanceProfileCapacity returns the vCPU count and memory in GiB of an
+// instance profile with fixed values, and false otherwise.
+func instanceProfileCapacity(profile vpcv1.InstanceProfile) (int64, int64, bool) {
+	vcpu, ok := profile.VcpuCount.(*vpcv1.InstanceProfileVcpu)
+	if !ok || vcpu.Value == nil {
+		return 0, 0, false
+	}
+	memory, ok := profile.Memory.(*vpcv1.InstanceProfileMemory)
+	if !ok || memory.Value == nil {
+		return 0, 0, false
+	}
+	return *vcpu.Value, *memory.Value, true
+}
+
+func validateMachinePoolDedicatedHosts(client API, dhosts []ibmcloud.DedicatedHost, machineType string, zones []string, replicas int64, region string, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	// Get list of supported profiles in region
@@ -83,6 +139,18 @@ func validateMachinePoolDedicatedHosts(client API, dhosts []ibmcloud.DedicatedHo
 		allErrs = append(allErrs, field.InternalError(path, err))
 	}
 
+	// Get list of machine profiles, for the capacity of pre-existing hosts
+	var vsiProfiles []vpcv1.InstanceProfile
+	for _, dhost := range dhosts {
+		if dhost.Name != "" {
+			vsiProfiles, err = client.GetVSIProfiles(context.TODO())
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(path, err))
+			}
+			break
+		}
+	}
+
 	for i, dhost := range dhosts {
 		if dhost.Name != "" {
 			// Check if host with name exists
@@ -106,6 +174,11 @@ func validateMachinePoolDedicatedHosts(client API, dhosts []ibmcloud.DedicatedHo
 				if !isInstanceProfileInList(machineType, dh.SupportedInstanceProfiles) {
 					allErrs = append(allErrs, field.Invalid(path.Index(i).Child("name"), dhost.Name, fmt.Sprintf("dedicated host does not support machine type %s", machineType)))
 				}
+
+				// Check if host has capacity left for the machines of its zone
+				if err := checkDedicatedHostCapacity(dh, machineType, zoneReplicas(replicas, len(zones), i), vsiProfiles); err != nil {
+					allErrs = append(allErrs, field.Invalid(path.Index(i).Child("name"), dhost.Name, err.Error()))
+				}
 			}
 		} else {
 			// Check if host profile is supported in region
@@ -128,6 +201,43 @@ func validateMachinePoolDedicatedHosts(client API, dhosts []ibmcloud.DedicatedHo
 	return allErrs
 }
 
+// zoneReplicas returns the number of the machines of a machine pool which are
+// placed in the zone of the index, the machines being spread over the zones
+// in turn.
+func zoneReplicas(replicas int64, zones int, index int) int64 {
+	if zones == 0 {
+		return 0
+	}
+	machines := replicas / int64(zones)
+	if int64(index) < replicas%int64(zones) {
+		machines++
+	}
+	return machines
+}
+
+// checkDedicatedHostCapacity checks that the dedicated host has the vCPUs and
+// memory left to provision the machines of the machine type.
+func checkDedicatedHostCapacity(dh *vpcv1.DedicatedHost, machineType string, machines int64, vsiProfiles []vpcv1.InstanceProfile) error {
+	if machines == 0 || dh.AvailableVcpu == nil || dh.AvailableVcpu.Count == nil || dh.AvailableMemory == nil {
+		return nil
+	}
+	for _, profile := range vsiProfiles {
+		if profile.Name == nil || *profile.Name != machineType {
+			continue
+		}
+		vcpu, memory, ok := instanceProfileCapacity(profile)
+		if !ok {
+			return nil
+		}
+		vcpu, memory = vcpu*machines, memory*machines
+		if *dh.AvailableVcpu.Count < vcpu || *dh.AvailableMemory < memory {
+			return fmt.Errorf("dedicated host has %d vCPUs and %d GiB of memory available, its %d machines of type %s require %d vCPUs and %d GiB", *dh.AvailableVcpu.Count, *dh.AvailableMemory, machines, machineType, vcpu, memory)
+		}
+		return nil
+	}
+	return nil
+}
+
 func isInstanceProfileInList(profile string, list []vpcv1.InstanceProfileReference) bool {
 	for _, each := range list {
 		if *each.Name == profile {
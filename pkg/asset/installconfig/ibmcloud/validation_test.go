@@ -58,6 +58,9 @@ var (
 		validZoneUSSouth3: validSubnet3Name,
 	}
 
+	validPlacementGroupName   = "valid-placement-group"
+	pendingPlacementGroupName = "pending-placement-group"
+
 	wrongRG           = "wrong-resource-group"
 	wrongSubnetName   = "wrong-subnet"
 	wrongVPCID        = "wrong-id"
@@ -611,6 +614,40 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "control plane placement group valid",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.PlacementGroup = &ibmcloudtypes.PlacementGroup{Name: validPlacementGroupName}
+				},
+			},
+		},
+		{
+			name: "control plane placement group not found",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.PlacementGroup = &ibmcloudtypes.PlacementGroup{Name: "missing-placement-group"}
+				},
+			},
+			errorMsg: `controlPlane.platform.ibmcloud.placementGroup.name: Not found: "missing-placement-group"`,
+		},
+		{
+			name: "control plane placement group not stable",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.PlacementGroup = &ibmcloudtypes.PlacementGroup{Name: pendingPlacementGroupName}
+				},
+			},
+			errorMsg: `controlPlane.platform.ibmcloud.placementGroup.name: Invalid value: "pending-placement-group": placement group is pending, it must be stable`,
+		},
+		{
+			name: "control plane new placement group",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.PlacementGroup = &ibmcloudtypes.PlacementGroup{Strategy: ibmcloudtypes.PlacementGroupStrategyHostSpread}
+				},
+			},
+		},
 	}
 
 	mockCtrl := gomock.NewController(t)
@@ -777,6 +814,11 @@ func TestValidate(t *testing.T) {
 	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
 	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
 
+	// Mocks: control plane placement groups
+	ibmcloudClient.EXPECT().GetPlacementGroupByName(gomock.Any(), validPlacementGroupName, validRegion).Return(&vpcv1.PlacementGroup{Name: &validPlacementGroupName, LifecycleState: core.StringPtr(vpcv1.PlacementGroupLifecycleStateStableConst)}, nil)
+	ibmcloudClient.EXPECT().GetPlacementGroupByName(gomock.Any(), "missing-placement-group", validRegion).Return(nil, fmt.Errorf("placement group %q not found", "missing-placement-group"))
+	ibmcloudClient.EXPECT().GetPlacementGroupByName(gomock.Any(), pendingPlacementGroupName, validRegion).Return(&vpcv1.PlacementGroup{Name: &pendingPlacementGroupName, LifecycleState: core.StringPtr(vpcv1.PlacementGroupLifecycleStatePendingConst)}, nil)
+
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			editedInstallConfig := validInstallConfig()
@@ -868,3 +910,104 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDedicatedHostCapacity(t *testing.T) {
+	profiles := []vpcv1.InstanceProfile{{
+		Name:      core.StringPtr("bx2-4x16"),
+		VcpuCount: &vpcv1.InstanceProfileVcpu{Value: core.Int64Ptr(4)},
+		Memory:    &vpcv1.InstanceProfileMemory{Value: core.Int64Ptr(16)},
+	}}
+	host := func(vcpu, memory int64) *vpcv1.DedicatedHost {
+		return &vpcv1.DedicatedHost{
+			AvailableVcpu:   &vpcv1.Vcpu{Count: core.Int64Ptr(vcpu)},
+			AvailableMemory: core.Int64Ptr(memory),
+		}
+	}
+
+	cases := []struct {
+		name        string
+		host        *vpcv1.DedicatedHost
+		machineType string
+		machines    int64
+		errorMsg    string
+	}{
+		{
+			name:        "capacity for the machines",
+			host:        host(8, 32),
+			machineType: "bx2-4x16",
+			machines:    2,
+		},
+		{
+			name:        "unknown machine type",
+			host:        host(0, 0),
+			machineType: "unknown-type",
+			machines:    1,
+		},
+		{
+			name:        "no machines",
+			host:        host(0, 0),
+			machineType: "bx2-4x16",
+		},
+		{
+			name:        "not enough vCPUs",
+			host:        host(2, 32),
+			machineType: "bx2-4x16",
+			machines:    1,
+			errorMsg:    "dedicated host has 2 vCPUs and 32 GiB of memory available, its 1 machines of type bx2-4x16 require 4 vCPUs and 16 GiB",
+		},
+		{
+			name:        "not enough memory for the replicas",
+			host:        host(16, 32),
+			machineType: "bx2-4x16",
+			machines:    3,
+			errorMsg:    "dedicated host has 16 vCPUs and 32 GiB of memory available, its 3 machines of type bx2-4x16 require 12 vCPUs and 48 GiB",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDedicatedHostCapacity(tc.host, tc.machineType, tc.machines, profiles)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestZoneReplicas(t *testing.T) {
+	cases := []struct {
+		name     string
+		replicas int64
+		zones    int
+		expected []int64
+	}{
+		{
+			name:     "one per zone",
+			replicas: 3,
+			zones:    3,
+			expected: []int64{1, 1, 1},
+		},
+		{
+			name:     "uneven",
+			replicas: 5,
+			zones:    3,
+			expected: []int64{2, 2, 1},
+		},
+		{
+			name:     "fewer machines than zones",
+			replicas: 1,
+			zones:    2,
+			expected: []int64{1, 0},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []int64
+			for i := 0; i < tc.zones; i++ {
+				actual = append(actual, zoneReplicas(tc.replicas, tc.zones, i))
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package installconfig
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ValidateImmutableFields checks that the fields of the install config which
+// shape the identity, the platform and the networks of the cluster are not
+// changed from the old install config. These fields are baked into too many
+// of the manifests to be changed once the manifests are generated.
+func ValidateImmutableFields(old, updated *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	immutable := func(fldPath *field.Path, oldValue, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be changed once the manifests are generated"))
+		}
+	}
+
+	immutable(field.NewPath("metadata", "name"), old.ObjectMeta.Name, updated.ObjectMeta.Name)
+	immutable(field.NewPath("baseDomain"), old.BaseDomain, updated.BaseDomain)
+	immutable(field.NewPath("platform"), old.Platform.Name(), updated.Platform.Name())
+	immutable(field.NewPath("publish"), old.Publish, updated.Publish)
+	immutable(field.NewPath("fips"), old.FIPS, updated.FIPS)
+
+	oldNetworking, newNetworking := old.Networking, updated.Networking
+	if oldNetworking == nil {
+		oldNetworking = &types.Networking{}
+	}
+	if newNetworking == nil {
+		newNetworking = &types.Networking{}
+	}
+	fldPath := field.NewPath("networking")
+	immutable(fldPath.Child("networkType"), oldNetworking.NetworkType, newNetworking.NetworkType)
+	immutable(fldPath.Child("machineNetwork"), oldNetworking.MachineNetwork, newNetworking.MachineNetwork)
+	immutable(fldPath.Child("clusterNetwork"), oldNetworking.ClusterNetwork, newNetworking.ClusterNetwork)
+	immutable(fldPath.Child("serviceNetwork"), oldNetworking.ServiceNetwork, newNetworking.ServiceNetwork)
+	return allErrs
+}
@@ -0,0 +1,71 @@
+package installconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestValidateImmutableFields(t *testing.T) {
+	base := func() *types.InstallConfig {
+		return &types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			BaseDomain: "example.com",
+			Platform:   types.Platform{None: &none.Platform{}},
+			Networking: &types.Networking{
+				NetworkType:    "OVNKubernetes",
+				MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+			},
+			SSHKey: "ssh-ed25519 AAAA",
+		}
+	}
+
+	cases := []struct {
+		name     string
+		edit     func(ic *types.InstallConfig)
+		expected string
+	}{
+		{
+			name: "no change",
+			edit: func(ic *types.InstallConfig) {},
+		},
+		{
+			name: "mutable field changed",
+			edit: func(ic *types.InstallConfig) {
+				ic.SSHKey = "ssh-ed25519 BBBB"
+			},
+		},
+		{
+			name: "cluster name changed",
+			edit: func(ic *types.InstallConfig) {
+				ic.ObjectMeta.Name = "other-cluster"
+			},
+			expected: `^metadata\.name: Forbidden: cannot be changed once the manifests are generated$`,
+		},
+		{
+			name: "machine network and base domain changed",
+			edit: func(ic *types.InstallConfig) {
+				ic.BaseDomain = "example.org"
+				ic.Networking.MachineNetwork[0].CIDR = *ipnet.MustParseCIDR("10.1.0.0/16")
+			},
+			expected: `^\[baseDomain: Forbidden: cannot be changed once the manifests are generated, networking\.machineNetwork: Forbidden: cannot be changed once the manifests are generated\]$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			updated := base()
+			tc.edit(updated)
+			err := ValidateImmutableFields(base(), updated).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icexternal "github.com/openshift/installer/pkg/asset/installconfig/external"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
 	icnutanix "github.com/openshift/installer/pkg/asset/installconfig/nutanix"
@@ -19,6 +20,7 @@ import (
 	icovirt "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	icvsphere "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
+	"github.com/openshift/installer/pkg/logformat"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/defaults"
 	"github.com/openshift/installer/pkg/types/validation"
@@ -36,9 +38,16 @@ type InstallConfig struct {
 	IBMCloud     *icibmcloud.Metadata   `json:"ibmcloud,omitempty"`
 	AlibabaCloud *alibabacloud.Metadata `json:"alibabacloud,omitempty"`
 	PowerVS      *icpowervs.Metadata    `json:"powervs,omitempty"`
+
+	// MirrorSources are the mirrors of the image mirror results of the
+	// install config.
+	MirrorSources []types.ImageContentSource `json:"mirrorSources,omitempty"`
 }
 
-var _ asset.WritableAsset = (*InstallConfig)(nil)
+var (
+	_ asset.WritableAsset    = (*InstallConfig)(nil)
+	_ asset.InteractiveAsset = (*InstallConfig)(nil)
+)
 
 // MakeAsset returns an InstallConfig asset containing a given InstallConfig CR.
 func MakeAsset(config *types.InstallConfig) *InstallConfig {
@@ -62,6 +71,10 @@ func (a *InstallConfig) Dependencies() []asset.Asset {
 	}
 }
 
+// Interactive returns that the asset may prompt the user for the credentials
+// of the platform, and sets the proxy of the Power VS clients.
+func (a *InstallConfig) Interactive() {}
+
 // Generate generates the install-config.yaml file.
 func (a *InstallConfig) Generate(parents asset.Parents) error {
 	sshPublicKey := &sshPublicKey{}
@@ -125,6 +138,16 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 	return found, err
 }
 
+// Revalidate fails if the install config restored from the state file has
+// warnings of the classes made strict by this run, which were possibly not
+// strict when it was loaded.
+func (a *InstallConfig) Revalidate() error {
+	if err := validateWarnings(a.Config, false); err != nil {
+		return errors.Wrap(err, "invalid install config")
+	}
+	return nil
+}
+
 // finishAWS set defaults for AWS Platform before the config validation.
 func (a *InstallConfig) finishAWS() error {
 	// Set the Default Edge Compute pool when the subnets are defined.
@@ -146,6 +169,8 @@ func (a *InstallConfig) finishAWS() error {
 }
 
 func (a *InstallConfig) finish(filename string) error {
+	logformat.SetPlatform(a.Config.Platform.Name())
+
 	if a.Config.AWS != nil {
 		a.AWS = aws.NewMetadata(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets, a.Config.AWS.ServiceEndpoints)
 		if err := a.finishAWS(); err != nil {
@@ -162,7 +187,11 @@ func (a *InstallConfig) finish(filename string) error {
 		a.IBMCloud = icibmcloud.NewMetadata(a.Config.BaseDomain, a.Config.IBMCloud.Region, a.Config.IBMCloud.ControlPlaneSubnets, a.Config.IBMCloud.ComputeSubnets)
 	}
 	if a.Config.PowerVS != nil {
-		a.PowerVS = icpowervs.NewMetadata(a.Config.BaseDomain)
+		icpowervs.SetProxy(a.Config.Proxy)
+		a.PowerVS = icpowervs.NewMetadata(a.Config.BaseDomain, a.Config.PowerVS.ServiceEndpoints)
+		if crn := a.Config.PowerVS.DNSInstanceCRN; crn != "" {
+			a.PowerVS.SetDNSInstanceCRN(crn)
+		}
 	}
 
 	if err := validation.ValidateInstallConfig(a.Config, false).ToAggregate(); err != nil {
@@ -172,7 +201,29 @@ func (a *InstallConfig) finish(filename string) error {
 		return errors.Wrapf(err, "invalid %q file", filename)
 	}
 
-	if err := a.platformValidation(); err != nil {
+	if err := validateWarnings(a.Config, true); err != nil {
+		if filename == "" {
+			return errors.Wrap(err, "invalid install config")
+		}
+		return errors.Wrapf(err, "invalid %q file", filename)
+	}
+
+	if err := loadCoreOSStream(a.Config).ToAggregate(); err != nil {
+		if filename == "" {
+			return errors.Wrap(err, "invalid install config")
+		}
+		return errors.Wrapf(err, "invalid %q file", filename)
+	}
+
+	if results := a.Config.ImageMirrorResults; results != nil {
+		sources, err := LoadImageMirrorResults(results.Path)
+		if err != nil {
+			return errors.Wrapf(err, "invalid imageMirrorResults %q", results.Path)
+		}
+		a.MirrorSources = sources
+	}
+
+	if err := CloudValidationError(a.Config, "platform validation", a.platformValidation()); err != nil {
 		return err
 	}
 
@@ -224,10 +275,20 @@ func (a *InstallConfig) platformValidation() error {
 		return icopenstack.Validate(a.Config)
 	}
 	if a.Config.Platform.PowerVS != nil {
-		return icpowervs.Validate(a.Config)
+		if err := icpowervs.Validate(a.Config); err != nil {
+			return err
+		}
+		client, err := icpowervs.NewClient(a.Config.PowerVS.ServiceEndpoints)
+		if err != nil {
+			return err
+		}
+		return icpowervs.ValidateDNSZone(client, a.Config)
 	}
 	if a.Config.Platform.Nutanix != nil {
 		return icnutanix.Validate(a.Config)
 	}
+	if a.Config.Platform.External != nil {
+		return icexternal.Validate(a.Config)
+	}
 	return field.ErrorList{}.ToAggregate()
 }
@@ -65,14 +65,23 @@ func (a *AssetBase) LoadFromFile(f asset.FileFetcher) (found bool, err error) {
 		return false, errors.Wrap(errors.Wrap(err, "failed to upconvert install config"), asset.InstallConfigError)
 	}
 
+	if err := resolveSecretReferences(a.Config).ToAggregate(); err != nil {
+		return false, errors.Wrap(errors.Wrapf(err, "failed to resolve the secrets referenced by %s", installConfigFilename), asset.InstallConfigError)
+	}
+
 	defaults.SetInstallConfigDefaults(a.Config)
 
 	return true, nil
 }
 
-// RecordFile generates the asset manifest file from the config CR.
+// RecordFile generates the asset manifest file from the config CR. The
+// secrets referenced by file path or environment variable are left out.
 func (a *AssetBase) RecordFile() error {
-	data, err := yaml.Marshal(a.Config)
+	config, err := withoutReferencedSecrets(a.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to copy InstallConfig")
+	}
+	data, err := yaml.Marshal(config)
 	if err != nil {
 		return errors.Wrap(err, "failed to Marshal InstallConfig")
 	}
@@ -0,0 +1,34 @@
+package installconfig
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// lookupHost resolves a hostname, and is replaced by the tests.
+var lookupHost = net.LookupHost
+
+// validateUserManagedLoadBalancer validates that the API hostnames of a
+// cluster with a user-managed load balancer resolve, since the installer does
+// not create the load balancers nor their DNS records.
+func validateUserManagedLoadBalancer(ic *types.InstallConfig) error {
+	if !ic.UserManagedCloudLoadBalancer() {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("platform", ic.Platform.Name(), "loadBalancer", "type")
+	domain := strings.TrimSuffix(ic.ClusterDomain(), ".")
+	for _, name := range []string{"api", "api-int"} {
+		host := fmt.Sprintf("%s.%s", name, domain)
+		if _, err := lookupHost(host); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, "UserManaged", fmt.Sprintf("%s must resolve to the user-managed load balancer: %v", host, err)))
+		}
+	}
+	return allErrs.ToAggregate()
+}
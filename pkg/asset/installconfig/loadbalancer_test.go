@@ -0,0 +1,62 @@
+package installconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+func TestValidateUserManagedLoadBalancer(t *testing.T) {
+	cases := []struct {
+		name         string
+		loadBalancer *gcp.LoadBalancer
+		resolved     map[string]bool
+		expected     string
+	}{
+		{
+			name: "managed load balancer",
+		},
+		{
+			name:         "user-managed load balancer",
+			loadBalancer: &gcp.LoadBalancer{Type: configv1.LoadBalancerTypeUserManaged},
+			resolved:     map[string]bool{"api.test-cluster.example.com": true, "api-int.test-cluster.example.com": true},
+		},
+		{
+			name:         "unresolved internal API",
+			loadBalancer: &gcp.LoadBalancer{Type: configv1.LoadBalancerTypeUserManaged},
+			resolved:     map[string]bool{"api.test-cluster.example.com": true},
+			expected:     `^platform\.gcp\.loadBalancer\.type: Invalid value: "UserManaged": api-int\.test-cluster\.example\.com must resolve to the user-managed load balancer: no such host$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(string) ([]string, error)) { lookupHost = f }(lookupHost)
+			lookupHost = func(host string) ([]string, error) {
+				if tc.resolved[host] {
+					return []string{"192.0.2.1"}, nil
+				}
+				return nil, errors.New("no such host")
+			}
+
+			ic := &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				BaseDomain: "example.com",
+				Platform: types.Platform{
+					GCP: &gcp.Platform{LoadBalancer: tc.loadBalancer},
+				},
+			}
+			err := validateUserManagedLoadBalancer(ic)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package installconfig
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// mirrorResultsDocument holds the fields of the ImageContentSourcePolicy and
+// ImageDigestMirrorSet documents written by oc-mirror. Both list the mirrors
+// of a source with the same fields as an ImageContentSource.
+type mirrorResultsDocument struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		RepositoryDigestMirrors []types.ImageContentSource `json:"repositoryDigestMirrors"`
+		ImageDigestMirrors      []types.ImageContentSource `json:"imageDigestMirrors"`
+	} `json:"spec"`
+}
+
+// LoadImageMirrorResults returns the mirrors of the ImageContentSourcePolicy
+// and ImageDigestMirrorSet documents of an oc-mirror results file. The other
+// documents of the file, e.g. CatalogSources, are ignored.
+func LoadImageMirrorResults(path string) ([]types.ImageContentSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the image mirror results")
+	}
+	return parseImageMirrorResults(data)
+}
+
+func parseImageMirrorResults(data []byte) ([]types.ImageContentSource, error) {
+	var sources []types.ImageContentSource
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		doc := mirrorResultsDocument{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrap(err, "failed to parse the image mirror results")
+		}
+		switch doc.Kind {
+		case "ImageContentSourcePolicy":
+			sources = append(sources, doc.Spec.RepositoryDigestMirrors...)
+		case "ImageDigestMirrorSet":
+			sources = append(sources, doc.Spec.ImageDigestMirrors...)
+		case "":
+		default:
+			logrus.Debugf("Ignoring the %s of the image mirror results", doc.Kind)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("the image mirror results have no ImageContentSourcePolicy or ImageDigestMirrorSet mirrors")
+	}
+	return mergeMirrorSources(sources), nil
+}
+
+// mergeMirrorSources consolidates the sources so that each source appears
+// only once, as oc-mirror may list a source in several documents.
+func mergeMirrorSources(sources []types.ImageContentSource) []types.ImageContentSource {
+	merged := []types.ImageContentSource{}
+	index := map[string]int{}
+	mirrorSets := map[string]sets.String{}
+	for _, group := range sources {
+		i, ok := index[group.Source]
+		if !ok {
+			i = len(merged)
+			index[group.Source] = i
+			mirrorSets[group.Source] = sets.NewString()
+			merged = append(merged, types.ImageContentSource{Source: group.Source})
+		}
+		for _, mirror := range group.Mirrors {
+			if !mirrorSets[group.Source].Has(mirror) {
+				mirrorSets[group.Source].Insert(mirror)
+				merged[i].Mirrors = append(merged[i].Mirrors, mirror)
+			}
+		}
+	}
+	return merged
+}
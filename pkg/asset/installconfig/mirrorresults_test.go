@@ -0,0 +1,95 @@
+package installconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestParseImageMirrorResults(t *testing.T) {
+	cases := []struct {
+		name     string
+		results  string
+		expected []types.ImageContentSource
+		errorMsg string
+	}{
+		{
+			name: "image content source policies",
+			results: `---
+apiVersion: operator.openshift.io/v1alpha1
+kind: ImageContentSourcePolicy
+metadata:
+  name: release-0
+spec:
+  repositoryDigestMirrors:
+  - mirrors:
+    - mirror.example.com/openshift/release
+    source: quay.io/openshift-release-dev/ocp-v4.0-art-dev
+---
+apiVersion: operator.openshift.io/v1alpha1
+kind: ImageContentSourcePolicy
+metadata:
+  name: release-1
+spec:
+  repositoryDigestMirrors:
+  - mirrors:
+    - mirror.example.com/openshift/release-images
+    source: quay.io/openshift-release-dev/ocp-release
+  - mirrors:
+    - mirror.example.com/openshift/release
+    - mirror2.example.com/openshift/release
+    source: quay.io/openshift-release-dev/ocp-v4.0-art-dev
+`,
+			expected: []types.ImageContentSource{
+				{Source: "quay.io/openshift-release-dev/ocp-v4.0-art-dev", Mirrors: []string{"mirror.example.com/openshift/release", "mirror2.example.com/openshift/release"}},
+				{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/openshift/release-images"}},
+			},
+		},
+		{
+			name: "image digest mirror set",
+			results: `apiVersion: config.openshift.io/v1
+kind: ImageDigestMirrorSet
+metadata:
+  name: idms-release-0
+spec:
+  imageDigestMirrors:
+  - mirrors:
+    - mirror.example.com/openshift/release
+    source: quay.io/openshift-release-dev/ocp-v4.0-art-dev
+---
+apiVersion: operators.coreos.com/v1alpha1
+kind: CatalogSource
+metadata:
+  name: redhat-operator-index
+spec:
+  image: mirror.example.com/redhat/redhat-operator-index:v4.13
+  sourceType: grpc
+`,
+			expected: []types.ImageContentSource{
+				{Source: "quay.io/openshift-release-dev/ocp-v4.0-art-dev", Mirrors: []string{"mirror.example.com/openshift/release"}},
+			},
+		},
+		{
+			name: "no mirrors",
+			results: `apiVersion: operators.coreos.com/v1alpha1
+kind: CatalogSource
+metadata:
+  name: redhat-operator-index
+`,
+			errorMsg: "the image mirror results have no ImageContentSourcePolicy or ImageDigestMirrorSet mirrors",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sources, err := parseImageMirrorResults([]byte(tc.results))
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, sources)
+		})
+	}
+}
@@ -11,7 +11,7 @@ type networking struct {
 	machineNetwork []types.MachineNetworkEntry
 }
 
-var _ asset.Asset = (*networking)(nil)
+var _ asset.InteractiveAsset = (*networking)(nil)
 
 // Dependencies returns no dependencies.
 func (a *networking) Dependencies() []asset.Asset {
@@ -46,3 +46,6 @@ func selectMachineNetworkCIDR() (string, error) {
 func (a *networking) Name() string {
 	return "Networking"
 }
+
+// Interactive returns that the asset prompts the user
+func (a *networking) Interactive() {}
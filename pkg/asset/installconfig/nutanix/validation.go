@@ -2,8 +2,10 @@ package nutanix
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	nutanixclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -17,7 +19,54 @@ func Validate(ic *types.InstallConfig) error {
 		return field.Required(field.NewPath("platform", "nutanix"), "nutanix validation requires a nutanix platform configuration")
 	}
 
-	return nil
+	p := ic.Platform.Nutanix
+	if len(p.FailureDomains) == 0 {
+		return nil
+	}
+
+	nc, err := nutanixtypes.CreateNutanixClient(context.TODO(),
+		p.PrismCentral.Endpoint.Address,
+		strconv.Itoa(int(p.PrismCentral.Endpoint.Port)),
+		p.PrismCentral.Username,
+		p.PrismCentral.Password)
+	if err != nil {
+		return field.InternalError(field.NewPath("platform", "nutanix"), errors.Wrapf(err, "unable to connect to Prism Central %q", p.PrismCentral.Endpoint.Address))
+	}
+
+	return validateFailureDomains(nc.V3, p, field.NewPath("platform", "nutanix", "failureDomains")).ToAggregate()
+}
+
+// validateFailureDomains checks that the Prism Element and the subnets of each
+// failure domain exist, and that the subnets belong to the Prism Element.
+func validateFailureDomains(client nutanixclientv3.Service, p *nutanixtypes.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, fd := range p.FailureDomains {
+		fdPath := fldPath.Index(i)
+		if _, err := client.GetCluster(fd.PrismElement.UUID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("prismElement", "uuid"), fd.PrismElement.UUID,
+				fmt.Sprintf("does not correspond to a valid prism element in Prism: %v", err)))
+			continue
+		}
+
+		for _, subnetUUID := range fd.SubnetUUIDs {
+			subnet, err := client.GetSubnet(subnetUUID)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fdPath.Child("subnetUUIDs"), subnetUUID,
+					fmt.Sprintf("does not correspond to a valid subnet in Prism: %v", err)))
+				continue
+			}
+			if subnet.Spec == nil || subnet.Spec.ClusterReference == nil || subnet.Spec.ClusterReference.UUID == nil {
+				continue
+			}
+			if ref := *subnet.Spec.ClusterReference.UUID; ref != fd.PrismElement.UUID {
+				allErrs = append(allErrs, field.Invalid(fdPath.Child("subnetUUIDs"), subnetUUID,
+					fmt.Sprintf("the subnet belongs to prism element %s, not to the prism element of the failure domain", ref)))
+			}
+		}
+	}
+
+	return allErrs
 }
 
 // ValidateForProvisioning performs platform validation specifically for installer-
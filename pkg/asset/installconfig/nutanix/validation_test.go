@@ -0,0 +1,90 @@
+package nutanix
+
+import (
+	"fmt"
+	"testing"
+
+	nutanixclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nutanixtypes "github.com/openshift/installer/pkg/types/nutanix"
+)
+
+// fakeService serves the clusters and the subnets, keyed by their UUIDs. The
+// subnets map to the UUID of their cluster.
+type fakeService struct {
+	nutanixclientv3.Service
+	clusters map[string]bool
+	subnets  map[string]string
+}
+
+func (s *fakeService) GetCluster(uuid string) (*nutanixclientv3.ClusterIntentResponse, error) {
+	if !s.clusters[uuid] {
+		return nil, fmt.Errorf("ENTITY_NOT_FOUND")
+	}
+	return &nutanixclientv3.ClusterIntentResponse{}, nil
+}
+
+func (s *fakeService) GetSubnet(uuid string) (*nutanixclientv3.SubnetIntentResponse, error) {
+	cluster, ok := s.subnets[uuid]
+	if !ok {
+		return nil, fmt.Errorf("ENTITY_NOT_FOUND")
+	}
+	return &nutanixclientv3.SubnetIntentResponse{
+		Spec: &nutanixclientv3.Subnet{
+			ClusterReference: &nutanixclientv3.Reference{UUID: &cluster},
+		},
+	}, nil
+}
+
+func TestValidateFailureDomains(t *testing.T) {
+	client := &fakeService{
+		clusters: map[string]bool{"pe-1": true, "pe-2": true},
+		subnets:  map[string]string{"subnet-1": "pe-1", "subnet-2": "pe-2"},
+	}
+	failureDomain := func(pe, subnet string) nutanixtypes.FailureDomain {
+		return nutanixtypes.FailureDomain{
+			Name:         "fd",
+			PrismElement: nutanixtypes.PrismElement{UUID: pe},
+			SubnetUUIDs:  []string{subnet},
+		}
+	}
+
+	cases := []struct {
+		name           string
+		failureDomains []nutanixtypes.FailureDomain
+		expectedError  string
+	}{
+		{
+			name:           "valid",
+			failureDomains: []nutanixtypes.FailureDomain{failureDomain("pe-1", "subnet-1"), failureDomain("pe-2", "subnet-2")},
+		},
+		{
+			name:           "unknown prism element",
+			failureDomains: []nutanixtypes.FailureDomain{failureDomain("pe-3", "subnet-1")},
+			expectedError:  `platform.nutanix.failureDomains[0].prismElement.uuid: Invalid value: "pe-3": does not correspond to a valid prism element in Prism: ENTITY_NOT_FOUND`,
+		},
+		{
+			name:           "unknown subnet",
+			failureDomains: []nutanixtypes.FailureDomain{failureDomain("pe-1", "subnet-3")},
+			expectedError:  `platform.nutanix.failureDomains[0].subnetUUIDs: Invalid value: "subnet-3": does not correspond to a valid subnet in Prism: ENTITY_NOT_FOUND`,
+		},
+		{
+			name:           "subnet of another prism element",
+			failureDomains: []nutanixtypes.FailureDomain{failureDomain("pe-1", "subnet-1"), failureDomain("pe-1", "subnet-2")},
+			expectedError:  `platform.nutanix.failureDomains[1].subnetUUIDs: Invalid value: "subnet-2": the subnet belongs to prism element pe-2, not to the prism element of the failure domain`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &nutanixtypes.Platform{FailureDomains: tc.failureDomains}
+			err := validateFailureDomains(client, p, field.NewPath("platform", "nutanix", "failureDomains")).ToAggregate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/availabilityzones"
+	volumequotasets "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
 	"github.com/gophercloud/gophercloud/openstack/common/extensions"
 	computequotasets "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
@@ -14,6 +15,7 @@ import (
 	tokensv2 "github.com/gophercloud/gophercloud/openstack/identity/v2/tokens"
 	tokensv3 "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/external"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	networkquotasets "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
@@ -29,6 +31,7 @@ import (
 	"github.com/openshift/installer/pkg/types"
 	openstackdefaults "github.com/openshift/installer/pkg/types/openstack/defaults"
 	"github.com/openshift/installer/pkg/types/openstack/validation/networkextensions"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 // CloudInfo caches data fetched from the user's openstack cloud
@@ -39,6 +42,7 @@ type CloudInfo struct {
 	IngressFIP        *floatingips.FloatingIP
 	MachinesSubnet    *subnets.Subnet
 	OSImage           *images.Image
+	ProviderNetwork   *ProviderNetwork
 	ComputeZones      []string
 	VolumeZones       []string
 	VolumeTypes       []string
@@ -63,6 +67,21 @@ type Flavor struct {
 	Baremetal bool
 }
 
+// ProviderNetwork embeds information from the Gophercloud Network struct and
+// adds the provider and external-net attributes of the network.
+type ProviderNetwork struct {
+	networks.Network
+	external.NetworkExternalExt
+	NetworkProviderExt
+}
+
+// NetworkProviderExt represents the attributes of the "provider" extension of
+// a network. The attributes are only visible to administrators by default, so
+// NetworkType may be empty.
+type NetworkProviderExt struct {
+	NetworkType string `json:"provider:network_type"`
+}
+
 var ci *CloudInfo
 
 // GetCloudInfo fetches and caches metadata from openstack
@@ -122,6 +141,11 @@ func (ci *CloudInfo) collectInfo(ic *types.InstallConfig, opts *clientconfig.Cli
 		return fmt.Errorf("failed to fetch external network info: %w", err)
 	}
 
+	ci.ProviderNetwork, err = ci.getProviderNetwork(ic.OpenStack.ProviderNetwork)
+	if err != nil {
+		return fmt.Errorf("failed to fetch provider network info: %w", err)
+	}
+
 	// Fetch the image info if the user provided a Glance image name
 	imagePtr := ic.OpenStack.ClusterOSImage
 	if imagePtr != "" {
@@ -305,6 +329,38 @@ func (ci *CloudInfo) getNetwork(networkName string) (*networks.Network, error) {
 	return network, nil
 }
 
+// getProviderNetwork returns the network with the UUID or the name, or nil if
+// there is none.
+func (ci *CloudInfo) getProviderNetwork(nameOrID string) (*ProviderNetwork, error) {
+	if nameOrID == "" {
+		return nil, nil
+	}
+
+	var network ProviderNetwork
+	if validate.UUID(nameOrID) == nil {
+		err := networks.Get(ci.clients.networkClient, nameOrID).ExtractInto(&network)
+		if err == nil {
+			return &network, nil
+		}
+		if !isNotFoundError(err) {
+			return nil, err
+		}
+	}
+
+	networkID, err := networkutils.IDFromName(ci.clients.networkClient, nameOrID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := networks.Get(ci.clients.networkClient, networkID).ExtractInto(&network); err != nil {
+		return nil, err
+	}
+
+	return &network, nil
+}
+
 func (ci *CloudInfo) getFloatingIP(fip string) (*floatingips.FloatingIP, error) {
 	if fip != "" {
 		opts := floatingips.ListOpts{
@@ -428,6 +484,12 @@ func loadQuotas(ci *CloudInfo) ([]quota.Quota, error) {
 	}
 	quotas = append(quotas, networkRecords...)
 
+	volumeRecords, err := getVolumeLimits(ci, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume quota records: %w", err)
+	}
+	quotas = append(quotas, volumeRecords...)
+
 	return quotas, nil
 }
 
@@ -476,6 +538,29 @@ func getNetworkLimits(ci *CloudInfo, projectID string) ([]quota.Quota, error) {
 	addQuota("Network", qs.Network)
 	addQuota("SecurityGroup", qs.SecurityGroup)
 	addQuota("SecurityGroupRule", qs.SecurityGroupRule)
+	addQuota("FloatingIP", qs.FloatingIP)
+
+	return quotas, nil
+}
+
+func getVolumeLimits(ci *CloudInfo, projectID string) ([]quota.Quota, error) {
+	qs, err := volumequotasets.GetUsage(ci.clients.volumeClient, projectID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get QuotaSets from OpenStack Block Storage API: %w", err)
+	}
+
+	var quotas []quota.Quota
+	addQuota := func(name string, quotaUsage volumequotasets.QuotaUsage) {
+		quotas = append(quotas, quota.Quota{
+			Service:   "volume",
+			Name:      name,
+			InUse:     int64(quotaUsage.InUse),
+			Limit:     int64(quotaUsage.Limit - quotaUsage.Reserved),
+			Unlimited: quotaUsage.Limit < 0,
+		})
+	}
+	addQuota("Volumes", qs.Volumes)
+	addQuota("Gigabytes", qs.Gigabytes)
 
 	return quotas, nil
 }
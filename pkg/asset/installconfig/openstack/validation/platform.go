@@ -25,6 +25,9 @@ func ValidatePlatform(p *openstack.Platform, n *types.Networking, ci *CloudInfo)
 	// validate the externalNetwork
 	allErrs = append(allErrs, validateExternalNetwork(p, ci, fldPath)...)
 
+	// validate the providerNetwork
+	allErrs = append(allErrs, validateProviderNetwork(p, ci, fldPath)...)
+
 	// validate floating ips
 	allErrs = append(allErrs, validateFloatingIPs(p, ci, fldPath)...)
 
@@ -66,6 +69,37 @@ func validateExternalNetwork(p *openstack.Platform, ci *CloudInfo, fldPath *fiel
 	return allErrs
 }
 
+// validateProviderNetwork validates the provider network the nodes are attached to and returns a list of all validation errors
+func validateProviderNetwork(p *openstack.Platform, ci *CloudInfo, fldPath *field.Path) (allErrs field.ErrorList) {
+	if p.ProviderNetwork == "" {
+		return allErrs
+	}
+
+	network := ci.ProviderNetwork
+	if network == nil {
+		return append(allErrs, field.NotFound(fldPath.Child("providerNetwork"), p.ProviderNetwork))
+	}
+
+	// The network type is only visible to administrators by default
+	switch network.NetworkType {
+	case "", "flat", "vlan":
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("providerNetwork"), p.ProviderNetwork, fmt.Sprintf("provider network must be of type flat or vlan but it is of type %s", network.NetworkType)))
+	}
+
+	// Unless it is shared, only the administrators can attach ports to an
+	// external network
+	if network.External && !network.Shared {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("providerNetwork"), p.ProviderNetwork, "provider network is external but not shared, the nodes cannot be attached to it"))
+	}
+
+	if ci.MachinesSubnet != nil && ci.MachinesSubnet.NetworkID != network.ID {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("machinesSubnet"), p.MachinesSubnet, fmt.Sprintf("machinesSubnet must be a subnet of the provider network %s", network.ID)))
+	}
+
+	return allErrs
+}
+
 func validateFloatingIPs(p *openstack.Platform, ci *CloudInfo, fldPath *field.Path) (allErrs field.ErrorList) {
 	if p.APIFloatingIP != "" {
 		if ci.APIFIP == nil {
@@ -482,3 +482,130 @@ func TestMachineSubnet(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderNetwork(t *testing.T) {
+	const (
+		providerNetworkID = "c8a3f6d1-5e3b-4f0e-9d2a-7b6e1f4c2d90"
+		machinesSubnetID  = "031a5b9d-5a89-4465-8d54-3517ec2bad48"
+	)
+
+	providerNetworkPlatform := func() *openstack.Platform {
+		p := validPlatform()
+		p.ExternalNetwork = ""
+		p.APIFloatingIP = ""
+		p.IngressFloatingIP = ""
+		p.ProviderNetwork = "provider-vlan"
+		p.MachinesSubnet = machinesSubnetID
+		return p
+	}
+	providerNetworkCloudInfo := func() *CloudInfo {
+		ci := validPlatformCloudInfo()
+		ci.ExternalNetwork = nil
+		ci.ProviderNetwork = &ProviderNetwork{
+			Network: networks.Network{
+				ID:     providerNetworkID,
+				Name:   "provider-vlan",
+				Shared: true,
+			},
+			NetworkProviderExt: NetworkProviderExt{
+				NetworkType: "vlan",
+			},
+		}
+		ci.MachinesSubnet = &subnets.Subnet{
+			ID:        machinesSubnetID,
+			NetworkID: providerNetworkID,
+			CIDR:      "172.0.0.1/24",
+		}
+		return ci
+	}
+	providerNetworking := func() *types.Networking {
+		n := validNetworking()
+		n.MachineNetwork = []types.MachineNetworkEntry{{
+			CIDR: *ipnet.MustParseCIDR("172.0.0.1/24"),
+		}}
+		return n
+	}
+
+	cases := []struct {
+		name           string
+		platform       *openstack.Platform
+		cloudInfo      *CloudInfo
+		expectedErrMsg string // NOTE: this is a REGEXP
+	}{
+		{
+			name:      "valid provider network",
+			platform:  providerNetworkPlatform(),
+			cloudInfo: providerNetworkCloudInfo(),
+		},
+		{
+			name:     "valid provider network with hidden type",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.ProviderNetwork.NetworkType = ""
+				return ci
+			}(),
+		},
+		{
+			name:     "valid external provider network",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.ProviderNetwork.External = true
+				return ci
+			}(),
+		},
+		{
+			name:     "provider network not found",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.ProviderNetwork = nil
+				return ci
+			}(),
+			expectedErrMsg: `platform.openstack.providerNetwork: Not found: "provider-vlan"`,
+		},
+		{
+			name:     "tenant network",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.ProviderNetwork.NetworkType = "geneve"
+				return ci
+			}(),
+			expectedErrMsg: `platform.openstack.providerNetwork: Invalid value: "provider-vlan": provider network must be of type flat or vlan but it is of type geneve`,
+		},
+		{
+			name:     "external network not shared",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.ProviderNetwork.External = true
+				ci.ProviderNetwork.Shared = false
+				return ci
+			}(),
+			expectedErrMsg: `platform.openstack.providerNetwork: Invalid value: "provider-vlan": provider network is external but not shared, the nodes cannot be attached to it`,
+		},
+		{
+			name:     "machines subnet of another network",
+			platform: providerNetworkPlatform(),
+			cloudInfo: func() *CloudInfo {
+				ci := providerNetworkCloudInfo()
+				ci.MachinesSubnet.NetworkID = "9b0a2cb1-6f3e-4c52-8a27-d5e8f1a4b3c6"
+				return ci
+			}(),
+			expectedErrMsg: `platform.openstack.machinesSubnet: Invalid value: "031a5b9d-5a89-4465-8d54-3517ec2bad48": machinesSubnet must be a subnet of the provider network c8a3f6d1-5e3b-4f0e-9d2a-7b6e1f4c2d90`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregatedErrors := ValidatePlatform(tc.platform, providerNetworking(), tc.cloudInfo).ToAggregate()
+			if tc.expectedErrMsg != "" {
+				assert.Regexp(t, tc.expectedErrMsg, aggregatedErrors)
+			} else {
+				assert.NoError(t, aggregatedErrors)
+			}
+		})
+	}
+}
@@ -43,7 +43,7 @@ type platform struct {
 	types.Platform
 }
 
-var _ asset.Asset = (*platform)(nil)
+var _ asset.InteractiveAsset = (*platform)(nil)
 
 // Dependencies returns no dependencies.
 func (a *platform) Dependencies() []asset.Asset {
@@ -132,6 +132,9 @@ func (a *platform) Name() string {
 	return "Platform"
 }
 
+// Interactive returns that the asset prompts the user
+func (a *platform) Interactive() {}
+
 func (a *platform) queryUserForPlatform() (platform string, err error) {
 	err = survey.Ask([]*survey.Question{
 		{
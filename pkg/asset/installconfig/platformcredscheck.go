@@ -17,6 +17,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -33,7 +34,7 @@ import (
 type PlatformCredsCheck struct {
 }
 
-var _ asset.Asset = (*PlatformCredsCheck)(nil)
+var _ asset.InteractiveAsset = (*PlatformCredsCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformCredsCheck
 func (a *PlatformCredsCheck) Dependencies() []asset.Asset {
@@ -78,7 +79,7 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "creating IBM Cloud session")
 		}
 	case powervs.Name:
-		bxCli, err := powervsconfig.NewBxClient()
+		bxCli, err := powervsconfig.NewBxClient(ic.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -91,7 +92,7 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "creating OpenStack session")
 		}
-	case baremetal.Name, libvirt.Name, none.Name, vsphere.Name, nutanix.Name:
+	case baremetal.Name, external.Name, libvirt.Name, none.Name, vsphere.Name, nutanix.Name:
 		// no creds to check
 	case azure.Name:
 		azureSession, err := ic.Azure.Session()
@@ -121,3 +122,7 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 func (a *PlatformCredsCheck) Name() string {
 	return "Platform Credentials Check"
 }
+
+// Interactive returns that the asset may prompt the user for the credentials
+// of the platform.
+func (a *PlatformCredsCheck) Interactive() {}
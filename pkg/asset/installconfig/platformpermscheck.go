@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -30,7 +31,7 @@ import (
 type PlatformPermsCheck struct {
 }
 
-var _ asset.Asset = (*PlatformPermsCheck)(nil)
+var _ asset.InteractiveAsset = (*PlatformPermsCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformPermsCheck
 func (a *PlatformPermsCheck) Dependencies() []asset.Asset {
@@ -41,10 +42,15 @@ func (a *PlatformPermsCheck) Dependencies() []asset.Asset {
 
 // Generate queries for input from the user.
 func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
-	ctx := context.TODO()
 	ic := &InstallConfig{}
 	dependencies.Get(ic)
+	return CloudValidationError(ic.Config, "platform permissions check", validatePermissions(ic))
+}
 
+// validatePermissions validates that the credentials have the permissions
+// required to install the cluster.
+func validatePermissions(ic *InstallConfig) error {
+	ctx := context.TODO()
 	if ic.Config.CredentialsMode != "" {
 		return nil
 	}
@@ -53,29 +59,12 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 	platform := ic.Config.Platform.Name()
 	switch platform {
 	case aws.Name:
-		permissionGroups := []awsconfig.PermissionGroup{awsconfig.PermissionCreateBase}
-		usingExistingVPC := len(ic.Config.AWS.Subnets) != 0
-
-		if !usingExistingVPC {
-			permissionGroups = append(permissionGroups, awsconfig.PermissionCreateNetworking)
-		}
-
-		// Add delete permissions for non-C2S installs.
-		if !aws.IsSecretRegion(ic.Config.AWS.Region) {
-			permissionGroups = append(permissionGroups, awsconfig.PermissionDeleteBase)
-			if usingExistingVPC {
-				permissionGroups = append(permissionGroups, awsconfig.PermissionDeleteSharedNetworking)
-			} else {
-				permissionGroups = append(permissionGroups, awsconfig.PermissionDeleteNetworking)
-			}
-		}
-
 		ssn, err := ic.AWS.Session(ctx)
 		if err != nil {
 			return err
 		}
 
-		err = awsconfig.ValidateCreds(ssn, permissionGroups, ic.Config.Platform.AWS.Region)
+		err = awsconfig.ValidateCreds(ssn, awsconfig.RequiredPermissionGroups(ic.Config), ic.Config.Platform.AWS.Region)
 		if err != nil {
 			return errors.Wrap(err, "validate AWS credentials")
 		}
@@ -91,7 +80,7 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 	case ibmcloud.Name:
 		// TODO: IBM[#90]: platformpermscheck
 	case powervs.Name:
-		bxCli, err := powervsconfig.NewBxClient()
+		bxCli, err := powervsconfig.NewBxClient(ic.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -99,7 +88,11 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return err
 		}
-	case azure.Name, baremetal.Name, libvirt.Name, none.Name, openstack.Name, ovirt.Name, vsphere.Name, alibabacloud.Name, nutanix.Name:
+		err = bxCli.CheckRequiredPermissions(ctx, ic.Config)
+		if err != nil {
+			return err
+		}
+	case azure.Name, baremetal.Name, external.Name, libvirt.Name, none.Name, openstack.Name, ovirt.Name, vsphere.Name, alibabacloud.Name, nutanix.Name:
 		// no permissions to check
 	default:
 		err = fmt.Errorf("unknown platform type %q", platform)
@@ -111,3 +104,7 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 func (a *PlatformPermsCheck) Name() string {
 	return "Platform Permissions Check"
 }
+
+// Interactive returns that the asset may prompt the user for the credentials
+// of the platform.
+func (a *PlatformPermsCheck) Interactive() {}
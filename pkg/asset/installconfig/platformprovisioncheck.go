@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/openshift/installer/pkg/asset"
 	alibabacloudconfig "github.com/openshift/installer/pkg/asset/installconfig/alibabacloud"
 	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
@@ -16,10 +18,12 @@ import (
 	ovirtconfig "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	powervsconfig "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	vsconfig "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/types/alibabacloud"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -36,19 +40,37 @@ import (
 type PlatformProvisionCheck struct {
 }
 
-var _ asset.Asset = (*PlatformProvisionCheck)(nil)
+var _ asset.InteractiveAsset = (*PlatformProvisionCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformProvisionCheck
 func (a *PlatformProvisionCheck) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&InstallConfig{},
+		&releaseimage.Image{},
 	}
 }
 
 // Generate queries for input from the user.
 func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 	ic := &InstallConfig{}
-	dependencies.Get(ic)
+	releaseImage := &releaseimage.Image{}
+	dependencies.Get(ic, releaseImage)
+	if err := validateReleaseArchitecture(ic.Config, releaseImage.PullSpec, releaseimage.IsMultiArch).ToAggregate(); err != nil {
+		return errors.Wrap(err, "invalid release payload for the install config")
+	}
+	return CloudValidationError(ic.Config, "platform provisioning check", validateForProvisioning(ic))
+}
+
+// validateForProvisioning validates the requirements of the platform for
+// provisioning the infrastructure.
+func validateForProvisioning(ic *InstallConfig) error {
+	if err := validateUserManagedLoadBalancer(ic.Config); err != nil {
+		return err
+	}
+	if err := validatePointerIgnitionURL(ic.Config); err != nil {
+		return err
+	}
+
 	platform := ic.Config.Platform.Name()
 	switch platform {
 	case aws.Name:
@@ -85,6 +107,14 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return err
 		}
+		err = bmconfig.ValidateNetworkConfig(context.TODO(), ic.Config)
+		if err != nil {
+			return err
+		}
+		err = bmconfig.ValidateBMCs(context.TODO(), ic.Config)
+		if err != nil {
+			return err
+		}
 	case gcp.Name:
 		client, err := gcpconfig.NewClient(context.TODO())
 		if err != nil {
@@ -127,7 +157,7 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 	case powervs.Name:
-		client, err := powervsconfig.NewClient()
+		client, err := powervsconfig.NewClient(ic.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -139,7 +169,7 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return err
 		}
-	case libvirt.Name, none.Name:
+	case external.Name, libvirt.Name, none.Name:
 		// no special provisioning requirements to check
 	case nutanix.Name:
 		err := nutanixconfig.ValidateForProvisioning(ic.Config)
@@ -156,3 +186,7 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 func (a *PlatformProvisionCheck) Name() string {
 	return "Platform Provisioning Check"
 }
+
+// Interactive returns that the asset may prompt the user for the credentials
+// of the platform.
+func (a *PlatformProvisionCheck) Interactive() {}
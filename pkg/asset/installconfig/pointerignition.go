@@ -0,0 +1,75 @@
+package installconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// dialTLS opens a TLS connection to the address, and is replaced by the tests.
+var dialTLS = func(address string, config *tls.Config) error {
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", address, config)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// validatePointerIgnitionURL checks, where possible, that the machines can
+// fetch their config from the machine config server URL of the pointer
+// Ignition configs: its host must resolve and, when an endpoint already
+// answers on it, e.g. an L7 proxy, its certificate must be trusted for the
+// SNI hostname by the system CAs or the CA bundle, as by Ignition. The
+// machine config server itself only runs once the bootstrap machine is up,
+// so a connection failure is only reported as a warning.
+func validatePointerIgnitionURL(ic *types.InstallConfig) error {
+	if ic.PointerIgnition == nil || ic.PointerIgnition.URL == "" {
+		return nil
+	}
+	fldPath := field.NewPath("pointerIgnition", "url")
+	u, err := url.Parse(ic.PointerIgnition.URL)
+	if err != nil {
+		return field.Invalid(fldPath, ic.PointerIgnition.URL, err.Error())
+	}
+	if _, err := lookupHost(u.Hostname()); err != nil {
+		return field.Invalid(fldPath, ic.PointerIgnition.URL, fmt.Sprintf("%s must resolve to the machine config server: %v", u.Hostname(), err))
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if ic.PointerIgnition.CABundle != "" {
+		roots.AppendCertsFromPEM([]byte(ic.PointerIgnition.CABundle))
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	address := net.JoinHostPort(u.Hostname(), port)
+	err = dialTLS(address, &tls.Config{
+		ServerName: u.Hostname(),
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	})
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	switch {
+	case err == nil:
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostname), errors.As(err, &invalid):
+		return field.Invalid(fldPath, ic.PointerIgnition.URL, fmt.Sprintf("the certificate served by %s is not trusted by the machines: %v", address, err))
+	default:
+		logrus.Warnf("Unable to connect to the machine config server URL %s, which must be reachable from the machines: %v", ic.PointerIgnition.URL, err)
+	}
+	return nil
+}
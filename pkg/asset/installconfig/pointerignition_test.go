@@ -0,0 +1,102 @@
+package installconfig
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestValidatePointerIgnitionURL(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	cases := []struct {
+		name     string
+		url      string
+		caBundle string
+		resolved bool
+		listen   bool
+		expected string
+	}{
+		{
+			name: "default URL",
+		},
+		{
+			name:     "trusted proxy",
+			url:      "https://example.com:22623",
+			caBundle: serverCA,
+			resolved: true,
+			listen:   true,
+		},
+		{
+			name:     "unresolved host",
+			url:      "https://example.com:22623",
+			caBundle: serverCA,
+			expected: `^pointerIgnition\.url: Invalid value: "https://example\.com:22623": example\.com must resolve to the machine config server: no such host$`,
+		},
+		{
+			name:     "untrusted proxy",
+			url:      "https://example.com:22623",
+			resolved: true,
+			listen:   true,
+			expected: `^pointerIgnition\.url: Invalid value: "https://example\.com:22623": the certificate served by example\.com:22623 is not trusted by the machines: .*certificate signed by unknown authority`,
+		},
+		{
+			name:     "SNI hostname not in the certificate",
+			url:      "https://mcs.example.org:22623",
+			caBundle: serverCA,
+			resolved: true,
+			listen:   true,
+			expected: `^pointerIgnition\.url: Invalid value: "https://mcs\.example\.org:22623": the certificate served by mcs\.example\.org:22623 is not trusted by the machines: .*certificate is valid for .*, not mcs\.example\.org`,
+		},
+		{
+			name:     "machine config server not running yet",
+			url:      "https://example.com:22623",
+			caBundle: serverCA,
+			resolved: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(string) ([]string, error)) { lookupHost = f }(lookupHost)
+			lookupHost = func(host string) ([]string, error) {
+				if tc.resolved {
+					return []string{"127.0.0.1"}, nil
+				}
+				return nil, errors.New("no such host")
+			}
+			defer func(f func(string, *tls.Config) error) { dialTLS = f }(dialTLS)
+			dialTLS = func(address string, config *tls.Config) error {
+				if !tc.listen {
+					return errors.New("connection refused")
+				}
+				// Connect to the test server, with the SNI hostname of the URL.
+				conn, err := tls.Dial("tcp", strings.TrimPrefix(server.URL, "https://"), config)
+				if err != nil {
+					return err
+				}
+				return conn.Close()
+			}
+
+			ic := &types.InstallConfig{}
+			if tc.url != "" || tc.caBundle != "" {
+				ic.PointerIgnition = &types.PointerIgnition{URL: tc.url, CABundle: tc.caBundle}
+			}
+			err := validatePointerIgnitionURL(ic)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/platform-services-go-sdk/iampolicymanagementv1"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// ServiceAuthorization describes an IAM service-to-service authorization
+// which must exist between two IBM Cloud services for the install to succeed.
+type ServiceAuthorization struct {
+	// Source is the service name of the service which needs access.
+	Source string
+	// Target is the service name of the service being accessed.
+	Target string
+	// Role is the name of the role which must be granted, e.g. Reader.
+	Role string
+	// Reason explains why the installer needs the authorization.
+	Reason string
+}
+
+func (a ServiceAuthorization) String() string {
+	return fmt.Sprintf("%s -> %s (%s) needed to %s", a.Source, a.Target, a.Role, a.Reason)
+}
+
+// requiredServiceAuthorizations are the authorizations between the Power VS
+// workspace, the VPC, the Transit Gateway and COS which the cluster relies on.
+var requiredServiceAuthorizations = []ServiceAuthorization{
+	{
+		Source: "power-iaas",
+		Target: "cloud-object-storage",
+		Role:   "Reader",
+		Reason: "import the RHCOS boot image from Cloud Object Storage",
+	},
+	{
+		Source: "transit.gateway",
+		Target: "power-iaas",
+		Role:   "Operator",
+		Reason: "attach the Power VS workspace to the Transit Gateway",
+	},
+	{
+		Source: "transit.gateway",
+		Target: "is",
+		Role:   "Operator",
+		Reason: "attach the VPC to the Transit Gateway",
+	},
+}
+
+// ValidateServiceAuthorizations checks that the IAM service-to-service
+// authorizations needed by the cluster exist in the account, and reports all
+// of the missing ones.
+func (c *BxClient) ValidateServiceAuthorizations(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	policyService, err := iampolicymanagementv1.NewIamPolicyManagementV1(&iampolicymanagementv1.IamPolicyManagementV1Options{
+		Authenticator: c.Authenticator(),
+		URL:           c.ServiceURL(powervs.IAMServiceEndpointName, ""),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM policy management client")
+	}
+	configureService(policyService.Service)
+
+	policies, err := listPolicies(ctx, policyService.Service, map[string]string{"account_id": c.User.Account, "type": "authorization"})
+	if err != nil {
+		return errors.Wrap(err, "failed to list IAM authorizations")
+	}
+
+	missing := missingServiceAuthorizations(requiredServiceAuthorizations, policies)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(missing))
+	for _, authorization := range missing {
+		descriptions = append(descriptions, authorization.String())
+	}
+	return errors.Errorf("missing IAM service authorizations: %s (set %s=1 to install without them)", strings.Join(descriptions, "; "), skipServiceAuthorizationsEnvVar)
+}
+
+// missingServiceAuthorizations returns the required authorizations which are
+// not granted by any of the policies.
+func missingServiceAuthorizations(required []ServiceAuthorization, policies []iampolicymanagementv1.Policy) []ServiceAuthorization {
+	var missing []ServiceAuthorization
+	for _, authorization := range required {
+		found := false
+		for _, policy := range policies {
+			if policyGrants(policy, authorization) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, authorization)
+		}
+	}
+	return missing
+}
+
+// policyGrants returns true if the policy authorizes the source service to
+// access the target service with the required role.
+func policyGrants(policy iampolicymanagementv1.Policy, authorization ServiceAuthorization) bool {
+	if len(policy.Subjects) == 0 || len(policy.Resources) == 0 {
+		return false
+	}
+	if policyServiceName(policy.Subjects[0].Attributes) != authorization.Source {
+		return false
+	}
+	if policyServiceName(resourceAttributes(policy.Resources[0].Attributes)) != authorization.Target {
+		return false
+	}
+	for _, role := range policy.Roles {
+		if role.RoleID != nil && strings.HasSuffix(*role.RoleID, ":"+authorization.Role) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyServiceName(attributes []iampolicymanagementv1.SubjectAttribute) string {
+	for _, attribute := range attributes {
+		if attribute.Name != nil && *attribute.Name == "serviceName" && attribute.Value != nil {
+			return *attribute.Value
+		}
+	}
+	return ""
+}
+
+// resourceAttributes converts resource attributes so that they can be
+// inspected the same way as subject attributes.
+func resourceAttributes(attributes []iampolicymanagementv1.ResourceAttribute) []iampolicymanagementv1.SubjectAttribute {
+	converted := make([]iampolicymanagementv1.SubjectAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		converted = append(converted, iampolicymanagementv1.SubjectAttribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+		})
+	}
+	return converted
+}
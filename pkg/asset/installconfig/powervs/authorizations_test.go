@@ -0,0 +1,75 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/iampolicymanagementv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func authorizationPolicy(source string, target string, roleID string) iampolicymanagementv1.Policy {
+	return iampolicymanagementv1.Policy{
+		Subjects: []iampolicymanagementv1.PolicySubject{{
+			Attributes: []iampolicymanagementv1.SubjectAttribute{
+				{Name: core.StringPtr("accountId"), Value: core.StringPtr("account")},
+				{Name: core.StringPtr("serviceName"), Value: core.StringPtr(source)},
+			},
+		}},
+		Resources: []iampolicymanagementv1.PolicyResource{{
+			Attributes: []iampolicymanagementv1.ResourceAttribute{
+				{Name: core.StringPtr("accountId"), Value: core.StringPtr("account")},
+				{Name: core.StringPtr("serviceName"), Value: core.StringPtr(target)},
+			},
+		}},
+		Roles: []iampolicymanagementv1.PolicyRole{{
+			RoleID: core.StringPtr(roleID),
+		}},
+	}
+}
+
+func TestMissingServiceAuthorizations(t *testing.T) {
+	required := []ServiceAuthorization{
+		{Source: "power-iaas", Target: "cloud-object-storage", Role: "Reader"},
+		{Source: "transit.gateway", Target: "is", Role: "Operator"},
+	}
+
+	cases := []struct {
+		name     string
+		policies []iampolicymanagementv1.Policy
+		missing  []ServiceAuthorization
+	}{
+		{
+			name:    "no policies",
+			missing: required,
+		},
+		{
+			name: "all granted",
+			policies: []iampolicymanagementv1.Policy{
+				authorizationPolicy("power-iaas", "cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Reader"),
+				authorizationPolicy("transit.gateway", "is", "crn:v1:bluemix:public:iam::::role:Operator"),
+			},
+		},
+		{
+			name: "wrong role",
+			policies: []iampolicymanagementv1.Policy{
+				authorizationPolicy("power-iaas", "cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+				authorizationPolicy("transit.gateway", "is", "crn:v1:bluemix:public:iam::::role:Operator"),
+			},
+			missing: required[:1],
+		},
+		{
+			name: "reversed services",
+			policies: []iampolicymanagementv1.Policy{
+				authorizationPolicy("cloud-object-storage", "power-iaas", "crn:v1:bluemix:public:iam::::serviceRole:Reader"),
+				authorizationPolicy("transit.gateway", "is", "crn:v1:bluemix:public:iam::::role:Operator"),
+			},
+			missing: required[:1],
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.missing, missingServiceAuthorizations(required, tc.policies))
+		})
+	}
+}
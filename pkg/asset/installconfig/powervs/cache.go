@@ -0,0 +1,149 @@
+package powervs
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+)
+
+const (
+	// defaultCacheTTL is how long a memoized API response is considered valid.
+	defaultCacheTTL = 5 * time.Minute
+
+	// forceRefreshEnvVar, when set to a non-empty value, disables reuse of
+	// cached sessions and API responses.
+	forceRefreshEnvVar = "POWERVS_FORCE_REFRESH"
+)
+
+var (
+	// sessions holds the authenticated BxClients, keyed by API key and region,
+	// so that every asset which needs a session does not re-authenticate.
+	sessions = &sessionCache{clients: map[string]*BxClient{}}
+
+	// responses memoizes expensive lookups such as system pools and cloud
+	// connections for the lifetime of the process.
+	responses = newTTLCache(defaultCacheTTL)
+)
+
+// sessionCache is a process-wide store of authenticated BxClients.
+type sessionCache struct {
+	mutex   sync.Mutex
+	clients map[string]*BxClient
+}
+
+// get returns a copy of the cached client for the key, if any. A copy is
+// returned because callers mutate the client (e.g. NewPISession).
+func (s *sessionCache) get(key string) (*BxClient, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if forceRefresh() {
+		return nil, false
+	}
+	c, ok := s.clients[key]
+	if !ok {
+		return nil, false
+	}
+	clientCopy := *c
+	return &clientCopy, true
+}
+
+func (s *sessionCache) set(key string, c *BxClient) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	clientCopy := *c
+	s.clients[key] = &clientCopy
+}
+
+func (s *sessionCache) reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.clients = map[string]*BxClient{}
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// ttlCache is a simple thread-safe map whose entries expire after a fixed
+// duration.
+type ttlCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// get returns the value stored for the key if it has not expired.
+func (c *ttlCache) get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if forceRefresh() {
+		return nil, false
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache) set(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = cacheEntry{value: value, expires: c.now().Add(c.ttl)}
+}
+
+func (c *ttlCache) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = map[string]cacheEntry{}
+}
+
+// ResetCache drops every cached session and API response, and closes the
+// circuit breakers, so that the next call re-authenticates and re-fetches
+// from the PowerVS APIs.
+func ResetCache() {
+	sessions.reset()
+	responses.reset()
+	breakers.reset()
+}
+
+func forceRefresh() bool {
+	return os.Getenv(forceRefreshEnvVar) != ""
+}
+
+// copySystemPools returns a deep copy of the system pools, since
+// ValidateCapacityWithPools consumes the available capacity in place.
+func copySystemPools(pools models.SystemPools) (models.SystemPools, error) {
+	data, err := json.Marshal(pools)
+	if err != nil {
+		return nil, err
+	}
+	var poolsCopy models.SystemPools
+	if err := json.Unmarshal(data, &poolsCopy); err != nil {
+		return nil, err
+	}
+	return poolsCopy, nil
+}
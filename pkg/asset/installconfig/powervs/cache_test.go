@@ -0,0 +1,56 @@
+package powervs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Now()
+	cache := newTTLCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.get("key")
+	assert.False(t, ok)
+
+	cache.set("key", "value")
+	value, ok := cache.get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.get("key")
+	assert.False(t, ok)
+
+	cache.set("key", "value")
+	t.Setenv(forceRefreshEnvVar, "1")
+	_, ok = cache.get("key")
+	assert.False(t, ok)
+}
+
+func TestCopySystemPools(t *testing.T) {
+	cores := 10.0
+	memory := int64(100)
+	pools := models.SystemPools{
+		"s922": models.SystemPool{
+			Type: "s922",
+			MaxCoresAvailable: &models.System{
+				Cores:  &cores,
+				Memory: &memory,
+			},
+		},
+	}
+
+	poolsCopy, err := copySystemPools(pools)
+	assert.NoError(t, err)
+
+	*poolsCopy["s922"].MaxCoresAvailable.Cores -= 4
+	*poolsCopy["s922"].MaxCoresAvailable.Memory -= 40
+
+	assert.Equal(t, 10.0, *pools["s922"].MaxCoresAvailable.Cores)
+	assert.Equal(t, int64(100), *pools["s922"].MaxCoresAvailable.Memory)
+	assert.Equal(t, 6.0, *poolsCopy["s922"].MaxCoresAvailable.Cores)
+}
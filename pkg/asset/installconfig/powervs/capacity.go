@@ -0,0 +1,82 @@
+package powervs
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// CapacityModel converts the processors requested for a machine into the
+// physical cores it consumes from a system pool.
+type CapacityModel struct {
+	// SMTFactor is the number of hardware threads per physical core, which
+	// are the processors a shared processor machine requests.
+	SMTFactor float64
+	// Granularity is the increment in which shared processor entitlement is
+	// allocated.
+	Granularity float64
+}
+
+// DefaultCapacityModel is the capacity model of Power VS, where shared and
+// capped processors are entitled in quarter-core increments of SMT8 cores.
+var DefaultCapacityModel = CapacityModel{
+	SMTFactor:   8,
+	Granularity: 0.25,
+}
+
+// WithSMTLevel returns the capacity model of machines running with the SMT
+// level, which have fewer hardware threads per core than SMT8 with the
+// lower levels, and so consume more cores for the same processors.
+func (m CapacityModel) WithSMTLevel(level string) CapacityModel {
+	m.SMTFactor = float64(powervstypes.SMTThreads(level))
+	return m
+}
+
+// SMTLevels are the SMT levels of the control plane and compute machines,
+// which are set by a MachineConfig rather than by their provider configs.
+type SMTLevels struct {
+	ControlPlane string
+	Compute      string
+}
+
+// Cores returns the physical cores consumed by a machine with the given
+// processor type and processors.
+func (m CapacityModel) Cores(processorType string, processors intstr.IntOrString) (float64, error) {
+	requested, err := parseProcessors(processors)
+	if err != nil {
+		return 0, err
+	}
+
+	switch processorType {
+	case "Dedicated":
+		return requested, nil
+	case "Shared", "Capped":
+		// Capped processors are shared processors which cannot use the
+		// idle cycles of the pool beyond their entitlement.
+		return m.Entitlement(requested), nil
+	default:
+		return 0, errors.Errorf("unknown processor type (%v)", processorType)
+	}
+}
+
+// Entitlement returns the physical cores a shared processor machine is
+// entitled to, rounded up to the granularity.
+func (m CapacityModel) Entitlement(processors float64) float64 {
+	entitlement := math.Ceil(processors/m.SMTFactor/m.Granularity) * m.Granularity
+	return math.Max(entitlement, m.Granularity)
+}
+
+func parseProcessors(processors intstr.IntOrString) (float64, error) {
+	if processors.Type == intstr.Int {
+		return float64(processors.IntVal), nil
+	}
+	cores, err := strconv.ParseFloat(processors.StrVal, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to convert cores to a float")
+	}
+	return cores, nil
+}
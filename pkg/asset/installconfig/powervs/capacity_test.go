@@ -0,0 +1,104 @@
+package powervs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/powervs"
+)
+
+func TestCapacityModelCores(t *testing.T) {
+	cases := []struct {
+		name          string
+		processorType string
+		processors    intstr.IntOrString
+		expected      float64
+		errorMsg      string
+	}{
+		{
+			name:          "dedicated",
+			processorType: "Dedicated",
+			processors:    intstr.FromInt(2),
+			expected:      2,
+		},
+		{
+			name:          "shared, whole entitlement",
+			processorType: "Shared",
+			processors:    intstr.FromInt(16),
+			expected:      2,
+		},
+		{
+			name:          "shared, fractional entitlement",
+			processorType: "Shared",
+			processors:    intstr.FromInt(4),
+			expected:      0.5,
+		},
+		{
+			name:          "shared, rounded up to the granularity",
+			processorType: "Shared",
+			processors:    intstr.FromString("2.5"),
+			expected:      0.5,
+		},
+		{
+			name:          "shared, minimum entitlement",
+			processorType: "Shared",
+			processors:    intstr.FromString("0.5"),
+			expected:      0.25,
+		},
+		{
+			name:          "capped, as shared",
+			processorType: "Capped",
+			processors:    intstr.FromString("2.5"),
+			expected:      0.5,
+		},
+		{
+			name:          "invalid processors",
+			processorType: "Shared",
+			processors:    intstr.FromString("half"),
+			errorMsg:      `^failed to convert cores to a float`,
+		},
+		{
+			name:          "unknown processor type",
+			processorType: "Turbo",
+			processors:    intstr.FromInt(1),
+			errorMsg:      `^unknown processor type \(Turbo\)$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cores, err := powervs.DefaultCapacityModel.Cores(tc.processorType, tc.processors)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, cores)
+			}
+		})
+	}
+}
+
+func TestCapacityModelWithSMTLevel(t *testing.T) {
+	cases := []struct {
+		smtLevel      string
+		processorType string
+		expected      float64
+	}{
+		{smtLevel: "", processorType: "Shared", expected: 0.5},
+		{smtLevel: "8", processorType: "Shared", expected: 0.5},
+		{smtLevel: "4", processorType: "Shared", expected: 1},
+		{smtLevel: "2", processorType: "Shared", expected: 2},
+		{smtLevel: "off", processorType: "Shared", expected: 4},
+		{smtLevel: "off", processorType: "Dedicated", expected: 4},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.processorType+" SMT "+tc.smtLevel, func(t *testing.T) {
+			cores, err := powervs.DefaultCapacityModel.WithSMTLevel(tc.smtLevel).Cores(tc.processorType, intstr.FromInt(4))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, cores)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/IBM-Cloud/bluemix-go/crn"
@@ -18,8 +19,12 @@ import (
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/quota"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/powervs"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/powervsclient_generated.go -package=mock
@@ -29,6 +34,8 @@ type API interface {
 	GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID string, recordName string, publish types.PublishingStrategy) ([]DNSRecordResponse, error)
 	GetDNSZoneIDByName(ctx context.Context, name string, publish types.PublishingStrategy) (string, error)
 	GetDNSZones(ctx context.Context, publish types.PublishingStrategy) ([]DNSZoneResponse, error)
+	GetDNSZoneByName(ctx context.Context, name string, publish types.PublishingStrategy) (*DNSZoneResponse, error)
+	GetDNSServicesZoneByName(ctx context.Context, instanceCRN string, name string) (*DNSZoneResponse, error)
 	GetDNSInstancePermittedNetworks(ctx context.Context, dnsID string, dnsZone string) ([]string, error)
 	GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC, error)
 	GetPublicGatewayByVPC(ctx context.Context, vpcName string) (*vpcv1.PublicGateway, error)
@@ -37,21 +44,31 @@ type API interface {
 	GetAPIKey() string
 	SetVPCServiceURLForRegion(ctx context.Context, region string) error
 	GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error)
+	GetSecurityGroups(ctx context.Context, region string, vpcName string) ([]vpcv1.SecurityGroup, error)
+	GetVPCQuotas(ctx context.Context, region string, vpcName string) ([]quota.Quota, error)
+	GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error)
+	GetWorkspaces(ctx context.Context, zone string) ([]WorkspaceResponse, error)
+	CreateWorkspace(ctx context.Context, name string, zone string, resourceGroupID string, tags []string) (*WorkspaceResponse, error)
 }
 
 // Client makes calls to the PowerVS API.
 type Client struct {
-	APIKey         string
-	managementAPI  *resourcemanagerv2.ResourceManagerV2
-	controllerAPI  *resourcecontrollerv2.ResourceControllerV2
-	vpcAPI         *vpcv1.VpcV1
-	dnsServicesAPI *dnssvcsv1.DnsSvcsV1
+	APIKey           string
+	TrustedProfileID string
+	accountID        string
+	serviceEndpoints []configv1.PowerVSServiceEndpoint
+	managementAPI    *resourcemanagerv2.ResourceManagerV2
+	controllerAPI    *resourcecontrollerv2.ResourceControllerV2
+	vpcAPI           *vpcv1.VpcV1
+	dnsServicesAPI   *dnssvcsv1.DnsSvcsV1
 }
 
 // cisServiceID is the Cloud Internet Services' catalog service ID.
 const (
-	cisServiceID = "75874a60-cb12-11e7-948e-37ac098eb1b9"
-	dnsServiceID = "b4ed8a30-936f-11e9-b289-1d079699cbe5"
+	cisServiceID       = "75874a60-cb12-11e7-948e-37ac098eb1b9"
+	dnsServiceID       = "b4ed8a30-936f-11e9-b289-1d079699cbe5"
+	powerIAASServiceID = "abd259f0-9990-11e8-acc8-b9f54a8f1661"
+	powerIAASPlanID    = "f165dd34-3a40-423b-9d95-e90a23f724dd"
 )
 
 // DNSZoneResponse represents a DNS zone response.
@@ -72,6 +89,26 @@ type DNSZoneResponse struct {
 
 	// ResourceGroupID is the resource group ID of the CIS instance.
 	ResourceGroupID string
+
+	// Status is the state of the zone, e.g. active or pending.
+	Status string
+}
+
+// IsActive returns true if the zone is active.
+func (z DNSZoneResponse) IsActive() bool {
+	return strings.EqualFold(z.Status, "active")
+}
+
+// WorkspaceResponse represents a Power VS workspace (service instance).
+type WorkspaceResponse struct {
+	// Name is the display name of the workspace.
+	Name string
+
+	// ID is the GUID of the workspace.
+	ID string
+
+	// Zone is the Power VS zone of the workspace.
+	Zone string
 }
 
 // DNSRecordResponse represents a DNS record response.
@@ -80,15 +117,19 @@ type DNSRecordResponse struct {
 	Type string
 }
 
-// NewClient initializes a client with a session.
-func NewClient() (*Client, error) {
-	bxCli, err := NewBxClient()
+// NewClient initializes a client with a session, using the custom endpoints
+// of the services instead of the default ones.
+func NewClient(serviceEndpoints []configv1.PowerVSServiceEndpoint) (*Client, error) {
+	bxCli, err := NewBxClient(serviceEndpoints)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Client{
-		APIKey: bxCli.APIKey,
+		APIKey:           bxCli.APIKey,
+		TrustedProfileID: bxCli.TrustedProfileID,
+		accountID:        bxCli.User.Account,
+		serviceEndpoints: serviceEndpoints,
 	}
 
 	if err := client.loadSDKServices(); err != nil {
@@ -119,9 +160,7 @@ func (c *Client) loadSDKServices() error {
 // GetDNSRecordsByName gets DNS records in specific Cloud Internet Services instance
 // by its CRN, zone ID, and DNS record name.
 func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID string, recordName string, publish types.PublishingStrategy) ([]DNSRecordResponse, error) {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	dnsRecords := []DNSRecordResponse{}
 	switch publish {
 	case types.ExternalPublishingStrategy:
@@ -134,6 +173,7 @@ func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID
 		if err != nil {
 			return nil, err
 		}
+		configureService(dnsService.Service)
 
 		// Get CIS DNS records by name
 		records, _, err := dnsService.ListAllDnsRecordsWithContext(ctx, &dnsrecordsv1.ListAllDnsRecordsOptions{
@@ -149,10 +189,12 @@ func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID
 		// Set DNS record service
 		dnsService, err := resourcerecordsv1.NewResourceRecordsV1(&resourcerecordsv1.ResourceRecordsV1Options{
 			Authenticator: authenticator,
+			URL:           c.serviceURL(powervs.DNSServicesServiceEndpointName),
 		})
 		if err != nil {
 			return nil, err
 		}
+		configureService(dnsService.Service)
 
 		dnsCRN, err := crn.Parse(crnstr)
 		if err != nil {
@@ -213,6 +255,38 @@ func (c *Client) GetDNSZoneIDByName(ctx context.Context, name string, publish ty
 
 // GetDNSZones returns all of the active DNS zones managed by CIS.
 func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrategy) ([]DNSZoneResponse, error) {
+	zones, err := c.listDNSZones(ctx, publish)
+	if err != nil {
+		return nil, err
+	}
+
+	var activeZones []DNSZoneResponse
+	for _, zone := range zones {
+		if zone.IsActive() {
+			activeZones = append(activeZones, zone)
+		}
+	}
+	return activeZones, nil
+}
+
+// GetDNSZoneByName returns the DNS zone with the given domain name, whatever
+// its state, or nil if there is no such zone.
+func (c *Client) GetDNSZoneByName(ctx context.Context, name string, publish types.PublishingStrategy) (*DNSZoneResponse, error) {
+	zones, err := c.listDNSZones(ctx, publish)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range zones {
+		if zones[idx].Name == name {
+			return &zones[idx], nil
+		}
+	}
+	return nil, nil
+}
+
+// listDNSZones returns all of the DNS zones managed by CIS or IBM DNS Services.
+func (c *Client) listDNSZones(ctx context.Context, publish types.PublishingStrategy) ([]DNSZoneResponse, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
@@ -233,9 +307,7 @@ func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrate
 
 	var allZones []DNSZoneResponse
 	for _, instance := range listResourceInstancesResponse.Resources {
-		authenticator := &core.IamAuthenticator{
-			ApiKey: c.APIKey,
-		}
+		authenticator := c.authenticator()
 
 		switch publish {
 		case types.ExternalPublishingStrategy:
@@ -246,6 +318,7 @@ func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrate
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to list DNS zones")
 			}
+			configureService(zonesService.Service)
 
 			options := zonesService.NewListZonesOptions()
 			listZonesResponse, _, err := zonesService.ListZones(options)
@@ -255,24 +328,25 @@ func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrate
 			}
 
 			for _, zone := range listZonesResponse.Result {
-				if *zone.Status == "active" {
-					zoneStruct := DNSZoneResponse{
-						Name:            *zone.Name,
-						ID:              *zone.ID,
-						InstanceCRN:     *instance.CRN,
-						InstanceName:    *instance.Name,
-						ResourceGroupID: *instance.ResourceGroupID,
-					}
-					allZones = append(allZones, zoneStruct)
+				zoneStruct := DNSZoneResponse{
+					Name:            *zone.Name,
+					ID:              *zone.ID,
+					InstanceCRN:     *instance.CRN,
+					InstanceName:    *instance.Name,
+					ResourceGroupID: *instance.ResourceGroupID,
+					Status:          *zone.Status,
 				}
+				allZones = append(allZones, zoneStruct)
 			}
 		case types.InternalPublishingStrategy:
 			dnsZonesService, err := dnszonesv1.NewDnsZonesV1(&dnszonesv1.DnsZonesV1Options{
 				Authenticator: authenticator,
+				URL:           c.serviceURL(powervs.DNSServicesServiceEndpointName),
 			})
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to list DNS zones")
 			}
+			configureService(dnsZonesService.Service)
 
 			options := dnsZonesService.NewListDnszonesOptions(*instance.GUID)
 			listZonesResponse, _, err := dnsZonesService.ListDnszones(options)
@@ -282,16 +356,15 @@ func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrate
 			}
 
 			for _, zone := range listZonesResponse.Dnszones {
-				if *zone.State == "ACTIVE" {
-					zoneStruct := DNSZoneResponse{
-						Name:            *zone.Name,
-						ID:              *zone.ID,
-						InstanceCRN:     *instance.CRN,
-						InstanceName:    *instance.Name,
-						ResourceGroupID: *instance.ResourceGroupID,
-					}
-					allZones = append(allZones, zoneStruct)
+				zoneStruct := DNSZoneResponse{
+					Name:            *zone.Name,
+					ID:              *zone.ID,
+					InstanceCRN:     *instance.CRN,
+					InstanceName:    *instance.Name,
+					ResourceGroupID: *instance.ResourceGroupID,
+					Status:          *zone.State,
 				}
+				allZones = append(allZones, zoneStruct)
 			}
 		}
 	}
@@ -321,6 +394,18 @@ func (c *Client) GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC,
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
+	if c.serviceURL(powervs.VPCServiceEndpointName) != "" {
+		// The custom endpoint serves the VPCs of its region only.
+		vpc, _, err := c.findVPCByName(ctx, vpcName)
+		if err != nil {
+			return nil, err
+		}
+		if vpc == nil {
+			return nil, errors.New("failed to find VPC")
+		}
+		return vpc, nil
+	}
+
 	listRegionsOptions := c.vpcAPI.NewListRegionsOptions()
 	listRegionsResponse, _, err := c.vpcAPI.ListRegionsWithContext(ctx, listRegionsOptions)
 	if err != nil {
@@ -333,23 +418,45 @@ func (c *Client) GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC,
 			return nil, errors.Wrap(err, "failed to set vpc api service url")
 		}
 
-		vpcs, detailedResponse, err := c.vpcAPI.ListVpcsWithContext(ctx, c.vpcAPI.NewListVpcsOptions())
+		vpc, detailedResponse, err := c.findVPCByName(ctx, vpcName)
 		if err != nil {
 			if detailedResponse.GetStatusCode() != http.StatusNotFound {
 				return nil, err
 			}
-		} else {
-			for _, vpc := range vpcs.Vpcs {
-				if *vpc.Name == vpcName {
-					return &vpc, nil
-				}
-			}
+		} else if vpc != nil {
+			return vpc, nil
 		}
 	}
 
 	return nil, errors.New("failed to find VPC")
 }
 
+// findVPCByName pages through the VPCs of the region of the VPC service URL
+// and returns the VPC with the name, or nil if there is none.
+func (c *Client) findVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC, *core.DetailedResponse, error) {
+	options := c.vpcAPI.NewListVpcsOptions()
+	for {
+		vpcs, detailedResponse, err := c.vpcAPI.ListVpcsWithContext(ctx, options)
+		if err != nil {
+			return nil, detailedResponse, err
+		}
+		for i := range vpcs.Vpcs {
+			if *vpcs.Vpcs[i].Name == vpcName {
+				return &vpcs.Vpcs[i], detailedResponse, nil
+			}
+		}
+
+		start, err := vpcs.GetNextStart()
+		if err != nil {
+			return nil, detailedResponse, err
+		}
+		if start == nil {
+			return nil, detailedResponse, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
 // GetPublicGatewayByVPC gets all PublicGateways in a region
 func (c *Client) GetPublicGatewayByVPC(ctx context.Context, vpcName string) (*vpcv1.PublicGateway, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -412,10 +519,19 @@ func (c *Client) GetSubnetByName(ctx context.Context, subnetName string, region
 	return nil, errors.New("failed to find VPC Subnet")
 }
 
+// authenticator returns the authenticator used for the IBM Cloud services.
+func (c *Client) authenticator() core.Authenticator {
+	return NewAuthenticator(c.APIKey, c.TrustedProfileID, c.serviceURL(powervs.IAMServiceEndpointName))
+}
+
+// serviceURL returns the custom endpoint of the service with the name, or ""
+// when the clients use the default one.
+func (c *Client) serviceURL(name string) string {
+	return powervs.ServiceEndpointURL(c.serviceEndpoints, name)
+}
+
 func (c *Client) loadResourceManagementAPI() error {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	options := &resourcemanagerv2.ResourceManagerV2Options{
 		Authenticator: authenticator,
 	}
@@ -423,55 +539,60 @@ func (c *Client) loadResourceManagementAPI() error {
 	if err != nil {
 		return err
 	}
+	configureService(resourceManagerV2Service.Service)
 	c.managementAPI = resourceManagerV2Service
 	return nil
 }
 
 func (c *Client) loadResourceControllerAPI() error {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	options := &resourcecontrollerv2.ResourceControllerV2Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(powervs.ResourceControllerServiceEndpointName),
 	}
 	resourceControllerV2Service, err := resourcecontrollerv2.NewResourceControllerV2(options)
 	if err != nil {
 		return err
 	}
+	configureService(resourceControllerV2Service.Service)
 	c.controllerAPI = resourceControllerV2Service
 	return nil
 }
 
 func (c *Client) loadVPCV1API() error {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	vpcService, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(powervs.VPCServiceEndpointName),
 	})
 	if err != nil {
 		return err
 	}
+	configureService(vpcService.Service)
 	c.vpcAPI = vpcService
 	return nil
 }
 
 func (c *Client) loadDNSServicesAPI() error {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	dnsService, err := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(powervs.DNSServicesServiceEndpointName),
 	})
 	if err != nil {
 		return err
 	}
+	configureService(dnsService.Service)
 	c.dnsServicesAPI = dnsService
 	return nil
 }
 
-// SetVPCServiceURLForRegion will set the VPC Service URL to a specific IBM Cloud Region, in order to access Region scoped resources
+// SetVPCServiceURLForRegion will set the VPC Service URL to a specific IBM Cloud Region, in order to access Region scoped resources.
+// A custom VPC endpoint is kept as is.
 func (c *Client) SetVPCServiceURLForRegion(ctx context.Context, region string) error {
+	if c.serviceURL(powervs.VPCServiceEndpointName) != "" {
+		return nil
+	}
 	regionOptions := c.vpcAPI.NewGetRegionOptions(region)
 	vpcRegion, _, err := c.vpcAPI.GetRegionWithContext(ctx, regionOptions)
 	if err != nil {
@@ -487,15 +608,15 @@ func (c *Client) SetVPCServiceURLForRegion(ctx context.Context, region string) e
 // GetAuthenticatorAPIKeyDetails gets detailed information on the API key used
 // for authentication to the IBM Cloud APIs.
 func (c *Client) GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error) {
-	authenticator := &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
+	authenticator := c.authenticator()
 	iamIdentityService, err := iamidentityv1.NewIamIdentityV1(&iamidentityv1.IamIdentityV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(powervs.IAMServiceEndpointName),
 	})
 	if err != nil {
 		return nil, err
 	}
+	configureService(iamIdentityService.Service)
 
 	options := iamIdentityService.NewGetAPIKeysDetailsOptions()
 	options.SetIamAPIKey(c.APIKey)
@@ -535,3 +656,132 @@ func (c *Client) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error
 
 	return vpcs.Vpcs, nil
 }
+
+// GetSecurityGroups gets all security groups of a VPC in a region.
+func (c *Client) GetSecurityGroups(ctx context.Context, region string, vpcName string) ([]vpcv1.SecurityGroup, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	var securityGroups []vpcv1.SecurityGroup
+	options := c.vpcAPI.NewListSecurityGroupsOptions()
+	options.SetVPCName(vpcName)
+	for {
+		collection, _, err := c.vpcAPI.ListSecurityGroupsWithContext(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		securityGroups = append(securityGroups, collection.SecurityGroups...)
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			return securityGroups, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+// GetResourceGroup gets a resource group by its name or ID.
+func (c *Client) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	options := c.managementAPI.NewListResourceGroupsOptions()
+	options.SetAccountID(c.accountID)
+	listResourceGroupsResponse, _, err := c.managementAPI.ListResourceGroupsWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, rg := range listResourceGroupsResponse.Resources {
+		if *rg.ID == nameOrID || *rg.Name == nameOrID {
+			return &listResourceGroupsResponse.Resources[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("resource group %q not found", nameOrID)
+}
+
+// GetWorkspaces returns the active Power VS workspaces in a zone.
+func (c *Client) GetWorkspaces(ctx context.Context, zone string) ([]WorkspaceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	options := c.controllerAPI.NewListResourceInstancesOptions()
+	options.SetResourceID(powerIAASServiceID)
+	options.SetState("active")
+
+	var workspaces []WorkspaceResponse
+	for {
+		listResourceInstancesResponse, _, err := c.controllerAPI.ListResourceInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Power VS workspaces")
+		}
+
+		for _, instance := range listResourceInstancesResponse.Resources {
+			if instance.RegionID == nil || *instance.RegionID != zone {
+				continue
+			}
+			workspaces = append(workspaces, WorkspaceResponse{
+				Name: *instance.Name,
+				ID:   *instance.GUID,
+				Zone: *instance.RegionID,
+			})
+		}
+
+		start, err := core.GetQueryParam(listResourceInstancesResponse.NextURL, "start")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Power VS workspaces")
+		}
+		if start == nil {
+			return workspaces, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+// CreateWorkspace creates a Power VS workspace with the name and tags in the
+// zone and resource group, and waits until it is active.
+func (c *Client) CreateWorkspace(ctx context.Context, name string, zone string, resourceGroupID string, tags []string) (*WorkspaceResponse, error) {
+	createCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	options := c.controllerAPI.NewCreateResourceInstanceOptions(name, zone, resourceGroupID, powerIAASPlanID)
+	options.SetTags(tags)
+	instance, _, err := c.controllerAPI.CreateResourceInstanceWithContext(createCtx, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create Power VS workspace %s", name)
+	}
+
+	err = wait.PollImmediateWithContext(ctx, 15*time.Second, 15*time.Minute, func(ctx context.Context) (bool, error) {
+		getOptions := c.controllerAPI.NewGetResourceInstanceOptions(*instance.ID)
+		current, _, err := c.controllerAPI.GetResourceInstanceWithContext(ctx, getOptions)
+		if err != nil {
+			return false, err
+		}
+		instance = current
+		switch *instance.State {
+		case "active":
+			return true, nil
+		case "failed", "removed":
+			return false, errors.Errorf("workspace is %s", *instance.State)
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for Power VS workspace %s to be active", name)
+	}
+
+	return &WorkspaceResponse{
+		Name: *instance.Name,
+		ID:   *instance.GUID,
+		Zone: zone,
+	}, nil
+}
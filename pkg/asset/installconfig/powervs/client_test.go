@@ -0,0 +1,77 @@
+package powervs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkspaces(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses map[string]string
+		expected  []WorkspaceResponse
+		errorMsg  string
+	}{
+		{
+			name: "workspaces of the zone",
+			responses: map[string]string{
+				"/v2/resource_instances": `{"resources": [
+					{"guid": "workspace-1", "name": "workspace-1", "region_id": "dal10"},
+					{"guid": "workspace-2", "name": "workspace-2", "region_id": "dal12"}
+				], "next_url": "/v2/resource_instances?start=page-2"}`,
+				"/v2/resource_instances?start=page-2": `{"resources": [
+					{"guid": "workspace-3", "name": "workspace-3", "region_id": "dal10"},
+					{"guid": "workspace-4", "name": "workspace-4"}
+				]}`,
+			},
+			expected: []WorkspaceResponse{
+				{Name: "workspace-1", ID: "workspace-1", Zone: "dal10"},
+				{Name: "workspace-3", ID: "workspace-3", Zone: "dal10"},
+			},
+		},
+		{
+			name: "no workspaces",
+			responses: map[string]string{
+				"/v2/resource_instances": `{"resources": []}`,
+			},
+		},
+		{
+			name:     "list failing",
+			errorMsg: `^failed to list Power VS workspaces: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, tc.responses)
+			if err := client.loadResourceControllerAPI(); err != nil {
+				t.Fatal(err)
+			}
+			workspaces, err := client.GetWorkspaces(context.Background(), "dal10")
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, workspaces)
+		})
+	}
+}
+
+func TestWorkspaceOptions(t *testing.T) {
+	options, optionToIDMap := workspaceOptions([]WorkspaceResponse{
+		{Name: "workspace-b", ID: "id-b", Zone: "dal10"},
+		{Name: "workspace-a", ID: "id-a", Zone: "dal10"},
+	})
+	assert.Equal(t, []string{
+		"workspace-a (id-a)",
+		"workspace-b (id-b)",
+		newWorkspaceOption,
+	}, options)
+	assert.Equal(t, map[string]string{
+		"workspace-a (id-a)": "id-a",
+		"workspace-b (id-b)": "id-b",
+		newWorkspaceOption:   "",
+	}, optionToIDMap)
+}
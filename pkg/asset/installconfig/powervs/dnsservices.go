@@ -0,0 +1,289 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/crn"
+	"github.com/IBM/networking-go-sdk/dnszonesv1"
+	"github.com/IBM/networking-go-sdk/resourcerecordsv1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+const (
+	// dnsServicesServiceName is the service name in the CRNs of the IBM Cloud
+	// DNS Services instances.
+	dnsServicesServiceName = "dns-svcs"
+
+	// dnsServicesRecordTTL is the TTL, in seconds, of the records created in
+	// the private DNS zones.
+	dnsServicesRecordTTL = 60
+
+	// dnsServicesPageSize is the number of zones or records listed per call.
+	dnsServicesPageSize = 200
+)
+
+// DNSServicesClient makes calls to the API of an IBM Cloud DNS Services
+// instance, which manages the private DNS zones used when the cluster is
+// published internally.
+type DNSServicesClient struct {
+	instanceCRN string
+	instanceID  string
+	zonesAPI    *dnszonesv1.DnsZonesV1
+	recordsAPI  *resourcerecordsv1.ResourceRecordsV1
+}
+
+// ParseDNSServicesCRN returns the GUID of the DNS Services instance with the
+// CRN.
+func ParseDNSServicesCRN(instanceCRN string) (string, error) {
+	parsed, err := crn.Parse(instanceCRN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse DNS Services instance CRN %q", instanceCRN)
+	}
+	if parsed.ServiceName != dnsServicesServiceName {
+		return "", errors.Errorf("CRN %q is not the CRN of a DNS Services instance, its service is %q instead of %q", instanceCRN, parsed.ServiceName, dnsServicesServiceName)
+	}
+	if parsed.ServiceInstance == "" {
+		return "", errors.Errorf("CRN %q has no service instance", instanceCRN)
+	}
+	return parsed.ServiceInstance, nil
+}
+
+// NewDNSServicesClient returns a client for the DNS Services instance with the
+// CRN.
+func (c *Client) NewDNSServicesClient(instanceCRN string) (*DNSServicesClient, error) {
+	instanceID, err := ParseDNSServicesCRN(instanceCRN)
+	if err != nil {
+		return nil, err
+	}
+
+	zonesAPI, err := dnszonesv1.NewDnsZonesV1(&dnszonesv1.DnsZonesV1Options{
+		Authenticator: c.authenticator(),
+		URL:           c.serviceURL(powervs.DNSServicesServiceEndpointName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the DNS zones service")
+	}
+	configureService(zonesAPI.Service)
+
+	recordsAPI, err := resourcerecordsv1.NewResourceRecordsV1(&resourcerecordsv1.ResourceRecordsV1Options{
+		Authenticator: c.authenticator(),
+		URL:           c.serviceURL(powervs.DNSServicesServiceEndpointName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the DNS resource records service")
+	}
+	configureService(recordsAPI.Service)
+
+	return &DNSServicesClient{
+		instanceCRN: instanceCRN,
+		instanceID:  instanceID,
+		zonesAPI:    zonesAPI,
+		recordsAPI:  recordsAPI,
+	}, nil
+}
+
+// GetDNSServicesZoneByName returns the private DNS zone with the given domain
+// name in the DNS Services instance with the CRN, whatever its state, or nil
+// if the instance has no such zone.
+func (c *Client) GetDNSServicesZoneByName(ctx context.Context, instanceCRN string, name string) (*DNSZoneResponse, error) {
+	dnsClient, err := c.NewDNSServicesClient(instanceCRN)
+	if err != nil {
+		return nil, err
+	}
+	return dnsClient.GetZoneByName(ctx, name)
+}
+
+// GetZoneByName returns the zone with the given domain name, whatever its
+// state, or nil if there is no such zone.
+func (d *DNSServicesClient) GetZoneByName(ctx context.Context, name string) (*DNSZoneResponse, error) {
+	options := d.zonesAPI.NewListDnszonesOptions(d.instanceID)
+	options.SetLimit(dnsServicesPageSize)
+	for offset := int64(0); ; offset += dnsServicesPageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		options.SetOffset(offset)
+		zones, _, err := d.zonesAPI.ListDnszones(options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the DNS zones of instance %s", d.instanceID)
+		}
+		for _, zone := range zones.Dnszones {
+			if zone.Name != nil && *zone.Name == name {
+				return &DNSZoneResponse{
+					Name:        *zone.Name,
+					ID:          *zone.ID,
+					InstanceCRN: d.instanceCRN,
+					Status:      *zone.State,
+				}, nil
+			}
+		}
+		if int64(len(zones.Dnszones)) < dnsServicesPageSize {
+			return nil, nil
+		}
+	}
+}
+
+// listRecords returns the records of the zone with the given name.
+func (d *DNSServicesClient) listRecords(ctx context.Context, zoneID string, name string) ([]resourcerecordsv1.ResourceRecord, error) {
+	var records []resourcerecordsv1.ResourceRecord
+	options := d.recordsAPI.NewListResourceRecordsOptions(d.instanceID, zoneID)
+	options.SetLimit(dnsServicesPageSize)
+	for offset := int64(0); ; offset += dnsServicesPageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		options.SetOffset(offset)
+		page, _, err := d.recordsAPI.ListResourceRecords(options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the records of DNS zone %s", zoneID)
+		}
+		for _, record := range page.ResourceRecords {
+			if record.Name != nil && *record.Name == name {
+				records = append(records, record)
+			}
+		}
+		if int64(len(page.ResourceRecords)) < dnsServicesPageSize {
+			return records, nil
+		}
+	}
+}
+
+// EnsureCNAMERecord creates a CNAME record with the name in the zone, pointing
+// to the target. The DNS zone validation ensures there is no record of the
+// cluster before the install, so an existing CNAME record with the name was
+// created by a previous run of the install, and is updated when it points to
+// another target, e.g. a load balancer which was created again.
+func (d *DNSServicesClient) EnsureCNAMERecord(ctx context.Context, zoneID string, name string, target string) error {
+	records, err := d.listRecords(ctx, zoneID, name)
+	if err != nil {
+		return err
+	}
+	record, create, err := cnameRecordToEnsure(records, target)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create record %s in DNS zone %s", name, zoneID)
+	}
+	if record != nil {
+		logrus.Debugf("Updating DNS record %s to point to %s instead of %s", name, target, cnameOf(*record))
+		rdata, err := d.recordsAPI.NewResourceRecordUpdateInputRdataRdataCnameRecord(target)
+		if err != nil {
+			return err
+		}
+		options := d.recordsAPI.NewUpdateResourceRecordOptions(d.instanceID, zoneID, *record.ID)
+		options.SetRdata(rdata)
+		options.SetTTL(dnsServicesRecordTTL)
+		if _, _, err := d.recordsAPI.UpdateResourceRecord(options); err != nil {
+			return errors.Wrapf(err, "failed to update record %s in DNS zone %s", name, zoneID)
+		}
+		return nil
+	}
+	if !create {
+		return nil
+	}
+
+	rdata, err := d.recordsAPI.NewResourceRecordInputRdataRdataCnameRecord(target)
+	if err != nil {
+		return err
+	}
+	options := d.recordsAPI.NewCreateResourceRecordOptions(d.instanceID, zoneID)
+	options.SetName(name)
+	options.SetType(resourcerecordsv1.CreateResourceRecordOptions_Type_Cname)
+	options.SetRdata(rdata)
+	options.SetTTL(dnsServicesRecordTTL)
+	if _, _, err := d.recordsAPI.CreateResourceRecord(options); err != nil {
+		return errors.Wrapf(err, "failed to create record %s in DNS zone %s", name, zoneID)
+	}
+	return nil
+}
+
+// cnameRecordToEnsure returns the record, among the existing records with a
+// name, to update so that it points to the target, or whether a record is to
+// be created. Nothing is to be done when a record already points to the
+// target. Records of other types than CNAME are not replaced.
+func cnameRecordToEnsure(records []resourcerecordsv1.ResourceRecord, target string) (*resourcerecordsv1.ResourceRecord, bool, error) {
+	for _, record := range records {
+		if record.Type == nil || *record.Type != resourcerecordsv1.ResourceRecord_Type_Cname {
+			recordType := "untyped"
+			if record.Type != nil {
+				recordType = *record.Type
+			}
+			return nil, false, errors.Errorf("a record of type %s with the name already exists", recordType)
+		}
+	}
+	for _, record := range records {
+		if cnameOf(record) == target {
+			return nil, false, nil
+		}
+	}
+	if len(records) == 0 {
+		return nil, true, nil
+	}
+	return &records[0], false, nil
+}
+
+// cnameOf returns the canonical name of the CNAME record.
+func cnameOf(record resourcerecordsv1.ResourceRecord) string {
+	rdata, ok := record.Rdata.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	cname, _ := rdata["cname"].(string)
+	return strings.TrimSuffix(cname, ".")
+}
+
+// ClusterAPIRecordNames returns the names of the records of the Kubernetes API
+// of the cluster in its private DNS zone.
+func ClusterAPIRecordNames(clusterDomain string) []string {
+	return []string{
+		fmt.Sprintf("api.%s", clusterDomain),
+		fmt.Sprintf("api-int.%s", clusterDomain),
+	}
+}
+
+// PrivateLoadBalancerName returns the name of the private VPC load balancer
+// of the cluster with the infrastructure ID.
+func PrivateLoadBalancerName(infraID string) string {
+	return fmt.Sprintf("%s-loadbalancer-int", infraID)
+}
+
+// GetPrivateLoadBalancerHostname returns the hostname of the private VPC load
+// balancer of the cluster with the infrastructure ID, in the VPC region.
+func (c *Client) GetPrivateLoadBalancerHostname(ctx context.Context, region string, infraID string) (string, error) {
+	if err := c.SetVPCServiceURLForRegion(ctx, region); err != nil {
+		return "", errors.Wrapf(err, "failed to set the VPC region to %s", region)
+	}
+
+	name := PrivateLoadBalancerName(infraID)
+	options := c.vpcAPI.NewListLoadBalancersOptions()
+	for {
+		collection, _, err := c.vpcAPI.ListLoadBalancersWithContext(ctx, options)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list the load balancers")
+		}
+		for _, lb := range collection.LoadBalancers {
+			if lb.Name == nil || *lb.Name != name {
+				continue
+			}
+			if lb.IsPublic != nil && *lb.IsPublic {
+				return "", errors.Errorf("load balancer %s is public", name)
+			}
+			if lb.Hostname == nil || *lb.Hostname == "" {
+				return "", errors.Errorf("load balancer %s has no hostname yet", name)
+			}
+			return *lb.Hostname, nil
+		}
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return "", err
+		}
+		if start == nil {
+			return "", errors.Errorf("load balancer %s not found in %s", name, region)
+		}
+		options.SetStart(*start)
+	}
+}
@@ -0,0 +1,126 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/resourcerecordsv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDNSServicesCRN(t *testing.T) {
+	cases := []struct {
+		name       string
+		crn        string
+		instanceID string
+		errorMsg   string
+	}{
+		{
+			name:       "DNS Services instance",
+			crn:        "crn:v1:bluemix:public:dns-svcs:global:a/account:instance-id::",
+			instanceID: "instance-id",
+		},
+		{
+			name:     "CIS instance",
+			crn:      "crn:v1:bluemix:public:internet-svcs:global:a/account:instance-id::",
+			errorMsg: `CRN "crn:v1:bluemix:public:internet-svcs:global:a/account:instance-id::" is not the CRN of a DNS Services instance, its service is "internet-svcs" instead of "dns-svcs"`,
+		},
+		{
+			name:     "no service instance",
+			crn:      "crn:v1:bluemix:public:dns-svcs:global:a/account:::",
+			errorMsg: `CRN "crn:v1:bluemix:public:dns-svcs:global:a/account:::" has no service instance`,
+		},
+		{
+			name:     "invalid CRN",
+			crn:      "dns-svcs",
+			errorMsg: `^failed to parse DNS Services instance CRN "dns-svcs": `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instanceID, err := ParseDNSServicesCRN(tc.crn)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.instanceID, instanceID)
+			}
+		})
+	}
+}
+
+func cnameRecord(id string, cname string) resourcerecordsv1.ResourceRecord {
+	return resourcerecordsv1.ResourceRecord{
+		ID:    core.StringPtr(id),
+		Name:  core.StringPtr("api.cluster.example.com"),
+		Type:  core.StringPtr(resourcerecordsv1.ResourceRecord_Type_Cname),
+		Rdata: map[string]interface{}{"cname": cname},
+	}
+}
+
+func TestCNAMERecordToEnsure(t *testing.T) {
+	const target = "12345678-us-south.lb.appdomain.cloud"
+	cases := []struct {
+		name     string
+		records  []resourcerecordsv1.ResourceRecord
+		update   string
+		create   bool
+		errorMsg string
+	}{
+		{
+			name:   "no record",
+			create: true,
+		},
+		{
+			name:    "record pointing to the target",
+			records: []resourcerecordsv1.ResourceRecord{cnameRecord("record-1", target)},
+		},
+		{
+			name:    "fully qualified record pointing to the target",
+			records: []resourcerecordsv1.ResourceRecord{cnameRecord("record-1", target+".")},
+		},
+		{
+			name:    "record pointing to a former load balancer",
+			records: []resourcerecordsv1.ResourceRecord{cnameRecord("record-1", "87654321-us-south.lb.appdomain.cloud")},
+			update:  "record-1",
+		},
+		{
+			name: "one of the records pointing to the target",
+			records: []resourcerecordsv1.ResourceRecord{
+				cnameRecord("record-1", "87654321-us-south.lb.appdomain.cloud"),
+				cnameRecord("record-2", target),
+			},
+		},
+		{
+			name: "A record",
+			records: []resourcerecordsv1.ResourceRecord{{
+				ID:    core.StringPtr("record-1"),
+				Name:  core.StringPtr("api.cluster.example.com"),
+				Type:  core.StringPtr(resourcerecordsv1.ResourceRecord_Type_A),
+				Rdata: map[string]interface{}{"ip": "10.0.0.1"},
+			}},
+			errorMsg: "a record of type A with the name already exists",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			record, create, err := cnameRecordToEnsure(tc.records, target)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.create, create)
+			if tc.update == "" {
+				assert.Nil(t, record)
+			} else if assert.NotNil(t, record) {
+				assert.Equal(t, tc.update, *record.ID)
+			}
+		})
+	}
+}
+
+func TestClusterAPIRecordNames(t *testing.T) {
+	assert.Equal(t, []string{"api.cluster.example.com", "api-int.cluster.example.com"}, ClusterAPIRecordNames("cluster.example.com"))
+	assert.Equal(t, "cluster-a1b2c-loadbalancer-int", PrivateLoadBalancerName("cluster-a1b2c"))
+}
@@ -0,0 +1,168 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	gohttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/coreos/stream-metadata-go/arch"
+	"github.com/pkg/errors"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// cosObjectURL returns the URL of an object of a bucket on the public
+// endpoint of IBM Cloud Object Storage in the region.
+func cosObjectURL(region, bucket, object string) string {
+	return fmt.Sprintf("https://s3.%s.cloud-object-storage.appdomain.cloud/%s/%s", region, url.PathEscape(bucket), url.PathEscape(object))
+}
+
+// splitImageLocation splits the location of the RHCOS image, in the form
+// bucket/object, into its bucket and object.
+func splitImageLocation(location string) (string, string, error) {
+	bucket, object, ok := strings.Cut(location, "/")
+	if !ok || bucket == "" || object == "" {
+		return "", "", errors.Errorf("invalid RHCOS image location %q, it must be bucket/object", location)
+	}
+	return bucket, object, nil
+}
+
+// checkImageObject checks that the image object can be read anonymously, as
+// the import reads it from a public bucket without HMAC keys.
+func checkImageObject(ctx context.Context, client *gohttp.Client, region, bucket, object string) error {
+	req, err := gohttp.NewRequestWithContext(ctx, gohttp.MethodHead, cosObjectURL(region, bucket, object), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to reach the COS bucket %s in %s", bucket, region)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case gohttp.StatusOK:
+		return nil
+	case gohttp.StatusNotFound:
+		return errors.Errorf("the RHCOS image %s does not exist in the COS bucket %s in %s", object, bucket, region)
+	case gohttp.StatusUnauthorized, gohttp.StatusForbidden:
+		return errors.Errorf("the COS bucket %s in %s is not public, importing the RHCOS image from it would require HMAC keys", bucket, region)
+	default:
+		return errors.Errorf("failed to check the RHCOS image %s in the COS bucket %s in %s: %s", object, bucket, region, resp.Status)
+	}
+}
+
+// controlPlaneImageName returns the name of the image of the control plane
+// machines, or "" if there are none.
+func controlPlaneImageName(controlPlanes []machinev1beta1.Machine) string {
+	for _, m := range controlPlanes {
+		config, ok := m.Spec.ProviderSpec.Value.Object.(*machinev1.PowerVSMachineProviderConfig)
+		if ok && config.Image.Name != nil {
+			return *config.Image.Name
+		}
+	}
+	return ""
+}
+
+// getImages returns the images of the workspace by their names.
+func (c *BxClient) getImages(ctx context.Context, serviceInstanceID string) (map[string]*models.ImageReference, error) {
+	imageClient := instance.NewIBMPIImageClient(ctx, c.PISession, serviceInstanceID)
+	images, err := imageClient.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*models.ImageReference{}
+	for _, image := range images.Images {
+		if image.Name != nil {
+			byName[*image.Name] = image
+		}
+	}
+	return byName, nil
+}
+
+// rhcosImageChecksum returns the SHA-256 checksum of the Power VS RHCOS image
+// of the stream of the install config.
+func rhcosImageChecksum(ctx context.Context, ic *types.InstallConfig) (string, error) {
+	archName := arch.RpmArch(string(ic.ControlPlane.Architecture))
+	st, err := rhcos.FetchCoreOSStream(ctx, ic.CoreOSStream)
+	if err != nil {
+		return "", err
+	}
+	streamArch, err := st.GetArchitecture(archName)
+	if err != nil {
+		return "", err
+	}
+	artifacts, ok := streamArch.Artifacts["powervs"]
+	if !ok {
+		return "", errors.Errorf("%s: No Power VS build found", st.FormatPrefix(archName))
+	}
+	for _, format := range artifacts.Formats {
+		if format.Disk != nil && format.Disk.Sha256 != "" {
+			return format.Disk.Sha256, nil
+		}
+	}
+	return "", errors.Errorf("%s: No Power VS disk checksum found", st.FormatPrefix(archName))
+}
+
+// checkExistingImage checks that an image of the workspace with the name of
+// the RHCOS image to import is that RHCOS image, so that it can be used in
+// place of the import. Power VS keeps no checksum of the imported images, so
+// the checksum of the RHCOS image must be recorded in the description of the
+// image.
+func checkExistingImage(image *models.ImageReference, checksum string, serviceInstanceID string) error {
+	if image.Description != nil && checksum != "" && strings.Contains(*image.Description, checksum) {
+		return nil
+	}
+	return errors.Errorf("an image named %s already exists in workspace %s and is not the RHCOS image with checksum %s: delete it, or record the checksum in its description to use it", *image.Name, serviceInstanceID, checksum)
+}
+
+// ValidateImageImport checks that the RHCOS image at osImage, in the form
+// bucket/object, can be imported into the workspace as imageName: the object
+// exists in the public COS bucket of the region of the workspace. An image of
+// the workspace which already has the name is accepted in place of the import
+// if it is the RHCOS image, with its checksum. If the install config sets a
+// cluster OS image, nothing is imported and that image must exist instead.
+func (c *BxClient) ValidateImageImport(ctx context.Context, ic *types.InstallConfig, osImage string, imageName string) error {
+	platform := ic.Platform.PowerVS
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	images, err := c.getImages(ctx, platform.ServiceInstanceID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list the images of the workspace")
+	}
+
+	if platform.ClusterOSImage != "" {
+		if _, ok := images[platform.ClusterOSImage]; !ok {
+			return errors.Errorf("the cluster OS image %s does not exist in workspace %s", platform.ClusterOSImage, platform.ServiceInstanceID)
+		}
+		return nil
+	}
+	if image, ok := images[imageName]; ok {
+		checksum, err := rhcosImageChecksum(ctx, ic)
+		if err != nil {
+			return errors.Wrap(err, "failed to find the checksum of the RHCOS image")
+		}
+		return checkExistingImage(image, checksum, platform.ServiceInstanceID)
+	}
+
+	region, err := powervstypes.VPCRegionForPowerVSRegion(platform.Region)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the COS region of the RHCOS image")
+	}
+	bucket, object, err := splitImageLocation(osImage)
+	if err != nil {
+		return err
+	}
+
+	return checkImageObject(ctx, newHTTPClient(), region, bucket, object)
+}
@@ -0,0 +1,110 @@
+package powervs
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestSplitImageLocation(t *testing.T) {
+	bucket, object, err := splitImageLocation("rhcos-powervs-images-us-east/rhcos-413.ova.gz")
+	assert.NoError(t, err)
+	assert.Equal(t, "rhcos-powervs-images-us-east", bucket)
+	assert.Equal(t, "rhcos-413.ova.gz", object)
+
+	_, _, err = splitImageLocation("rhcos-413.ova.gz")
+	assert.EqualError(t, err, `invalid RHCOS image location "rhcos-413.ova.gz", it must be bucket/object`)
+}
+
+func TestCheckImageObject(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/public/rhcos.ova.gz":
+			w.WriteHeader(http.StatusOK)
+		case "/private/rhcos.ova.gz":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Send the requests for the COS endpoint to the test server.
+	client := server.Client()
+	transport := client.Transport.(*http.Transport)
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	transport.TLSClientConfig.ServerName = "example.com"
+
+	cases := []struct {
+		name     string
+		bucket   string
+		errorMsg string
+	}{
+		{
+			name:   "public bucket",
+			bucket: "public",
+		},
+		{
+			name:     "private bucket",
+			bucket:   "private",
+			errorMsg: "the COS bucket private in us-east is not public, importing the RHCOS image from it would require HMAC keys",
+		},
+		{
+			name:     "missing image",
+			bucket:   "other",
+			errorMsg: "the RHCOS image rhcos.ova.gz does not exist in the COS bucket other in us-east",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkImageObject(context.Background(), client, "us-east", tc.bucket, "rhcos.ova.gz")
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckExistingImage(t *testing.T) {
+	const checksum = "0123456789abcdef"
+	cases := []struct {
+		name        string
+		description *string
+		errorMsg    string
+	}{
+		{
+			name:        "matching checksum",
+			description: pointer.String("RHCOS 413.92 sha256:0123456789abcdef"),
+		},
+		{
+			name:        "other checksum",
+			description: pointer.String("sha256:fedcba9876543210"),
+			errorMsg:    "an image named rhcos-infra already exists in workspace workspace and is not the RHCOS image with checksum 0123456789abcdef: delete it, or record the checksum in its description to use it",
+		},
+		{
+			name:     "no description",
+			errorMsg: "an image named rhcos-infra already exists in workspace workspace and is not the RHCOS image with checksum 0123456789abcdef: delete it, or record the checksum in its description to use it",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			image := &models.ImageReference{Name: pointer.String("rhcos-infra"), Description: tc.description}
+			err := checkExistingImage(image, checksum, "workspace")
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package powervs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/pkg/errors"
+)
+
+// GetInstanceLeaseIPs returns the IP addresses which the DHCP servers of the
+// service instance leased to the PVM instances whose names start with
+// prefix, keyed by the name of the instance.
+func (c *BxClient) GetInstanceLeaseIPs(ctx context.Context, svcInsID string, prefix string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	instanceClient := instance.NewIBMPIInstanceClient(ctx, c.PISession, svcInsID)
+	instances, err := instanceClient.GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list PVM instances")
+	}
+
+	// The DHCP leases only record the MAC address of the instance.
+	names := map[string]string{}
+	for _, pvmInstance := range instances.PvmInstances {
+		if pvmInstance.ServerName == nil || !strings.HasPrefix(*pvmInstance.ServerName, prefix) {
+			continue
+		}
+		for _, network := range pvmInstance.Networks {
+			if network.MacAddress != "" {
+				names[strings.ToLower(network.MacAddress)] = *pvmInstance.ServerName
+			}
+		}
+	}
+
+	dhcpClient := instance.NewIBMPIDhcpClient(ctx, c.PISession, svcInsID)
+	dhcpServers, err := dhcpClient.GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list DHCP servers")
+	}
+
+	ips := map[string]string{}
+	for _, dhcpServer := range dhcpServers {
+		if dhcpServer.ID == nil {
+			continue
+		}
+		detail, err := dhcpClient.Get(*dhcpServer.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get DHCP server %s", *dhcpServer.ID)
+		}
+		for _, lease := range detail.Leases {
+			if lease.InstanceMacAddress == nil || lease.InstanceIP == nil {
+				continue
+			}
+			if name, ok := names[strings.ToLower(*lease.InstanceMacAddress)]; ok {
+				ips[name] = *lease.InstanceIP
+			}
+		}
+	}
+
+	return ips, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/IBM-Cloud/bluemix-go/crn"
 	"github.com/pkg/errors"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 )
 
@@ -24,7 +25,8 @@ type MetadataAPI interface {
 // do not need to be user-supplied (e.g. because it can be retrieved
 // from external APIs).
 type Metadata struct {
-	BaseDomain string
+	BaseDomain       string
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint
 
 	accountID      string
 	apiKey         string
@@ -35,9 +37,10 @@ type Metadata struct {
 	mutex sync.Mutex
 }
 
-// NewMetadata initializes a new Metadata object.
-func NewMetadata(baseDomain string) *Metadata {
-	return &Metadata{BaseDomain: baseDomain}
+// NewMetadata initializes a new Metadata object, whose client uses the custom
+// endpoints of the services.
+func NewMetadata(baseDomain string, serviceEndpoints []configv1.PowerVSServiceEndpoint) *Metadata {
+	return &Metadata{BaseDomain: baseDomain, ServiceEndpoints: serviceEndpoints}
 }
 
 // AccountID returns the IBM Cloud account ID associated with the authentication
@@ -47,7 +50,7 @@ func (m *Metadata) AccountID(ctx context.Context) (string, error) {
 	defer m.mutex.Unlock()
 
 	if m.client == nil {
-		client, err := NewClient()
+		client, err := NewClient(m.ServiceEndpoints)
 		if err != nil {
 			return "", err
 		}
@@ -55,6 +58,12 @@ func (m *Metadata) AccountID(ctx context.Context) (string, error) {
 		m.client = client
 	}
 
+	if m.accountID == "" && m.client.TrustedProfileID != "" {
+		// There are no API key details for a trusted profile, the account
+		// is taken from the IAM token instead.
+		m.accountID = m.client.accountID
+	}
+
 	if m.accountID == "" {
 		apiKeyDetails, err := m.client.GetAuthenticatorAPIKeyDetails(ctx)
 		if err != nil {
@@ -74,7 +83,7 @@ func (m *Metadata) APIKey(ctx context.Context) (string, error) {
 	defer m.mutex.Unlock()
 
 	if m.client == nil {
-		client, err := NewClient()
+		client, err := NewClient(m.ServiceEndpoints)
 		if err != nil {
 			return "", err
 		}
@@ -97,7 +106,7 @@ func (m *Metadata) CISInstanceCRN(ctx context.Context) (string, error) {
 
 	var err error
 	if m.client == nil {
-		client, err := NewClient()
+		client, err := NewClient(m.ServiceEndpoints)
 		if err != nil {
 			return "", err
 		}
@@ -127,7 +136,7 @@ func (m *Metadata) DNSInstanceCRN(ctx context.Context) (string, error) {
 
 	var err error
 	if m.client == nil {
-		client, err := NewClient()
+		client, err := NewClient(m.ServiceEndpoints)
 		if err != nil {
 			return "", err
 		}
@@ -203,7 +212,7 @@ func (m *Metadata) IsVPCPermittedNetwork(ctx context.Context, vpcName string, ba
 	}
 
 	if m.client == nil {
-		client, err := NewClient()
+		client, err := NewClient(m.ServiceEndpoints)
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,49 @@
+package powervs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataAccountID(t *testing.T) {
+	cases := []struct {
+		name             string
+		trustedProfileID string
+		responses        map[string]string
+		expected         string
+		expectedRequests int
+	}{
+		{
+			name:             "API key",
+			responses:        map[string]string{"/v1/apikeys/details": `{"id": "key-id", "account_id": "key-account"}`},
+			expected:         "key-account",
+			expectedRequests: 1,
+		},
+		{
+			name:             "trusted profile",
+			trustedProfileID: "profile-id",
+			expected:         "account",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api, endpoints := newTestAPI(t, tc.responses)
+			m := &Metadata{
+				client: &Client{
+					APIKey:           "api-key",
+					TrustedProfileID: tc.trustedProfileID,
+					accountID:        "account",
+					serviceEndpoints: endpoints,
+				},
+			}
+			for i := 0; i < 2; i++ {
+				accountID, err := m.AccountID(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, accountID)
+			}
+			assert.Equal(t, tc.expectedRequests, api.requests["/v1/apikeys/details"])
+		})
+	}
+}
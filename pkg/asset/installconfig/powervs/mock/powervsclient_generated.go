@@ -9,9 +9,11 @@ import (
 	reflect "reflect"
 
 	iamidentityv1 "github.com/IBM/platform-services-go-sdk/iamidentityv1"
+	resourcemanagerv2 "github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	vpcv1 "github.com/IBM/vpc-go-sdk/vpcv1"
 	gomock "github.com/golang/mock/gomock"
 	powervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	quota "github.com/openshift/installer/pkg/quota"
 	types "github.com/openshift/installer/pkg/types"
 )
 
@@ -38,6 +40,21 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// CreateWorkspace mocks base method.
+func (m *MockAPI) CreateWorkspace(ctx context.Context, name, zone, resourceGroupID string, tags []string) (*powervs.WorkspaceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, name, zone, resourceGroupID, tags)
+	ret0, _ := ret[0].(*powervs.WorkspaceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockAPIMockRecorder) CreateWorkspace(ctx, name, zone, resourceGroupID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockAPI)(nil).CreateWorkspace), ctx, name, zone, resourceGroupID, tags)
+}
+
 // GetAPIKey mocks base method.
 func (m *MockAPI) GetAPIKey() string {
 	m.ctrl.T.Helper()
@@ -97,6 +114,36 @@ func (mr *MockAPIMockRecorder) GetDNSRecordsByName(ctx, crnstr, zoneID, recordNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSRecordsByName", reflect.TypeOf((*MockAPI)(nil).GetDNSRecordsByName), ctx, crnstr, zoneID, recordName, publish)
 }
 
+// GetDNSServicesZoneByName mocks base method.
+func (m *MockAPI) GetDNSServicesZoneByName(ctx context.Context, instanceCRN, name string) (*powervs.DNSZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSServicesZoneByName", ctx, instanceCRN, name)
+	ret0, _ := ret[0].(*powervs.DNSZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSServicesZoneByName indicates an expected call of GetDNSServicesZoneByName.
+func (mr *MockAPIMockRecorder) GetDNSServicesZoneByName(ctx, instanceCRN, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSServicesZoneByName", reflect.TypeOf((*MockAPI)(nil).GetDNSServicesZoneByName), ctx, instanceCRN, name)
+}
+
+// GetDNSZoneByName mocks base method.
+func (m *MockAPI) GetDNSZoneByName(ctx context.Context, name string, publish types.PublishingStrategy) (*powervs.DNSZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSZoneByName", ctx, name, publish)
+	ret0, _ := ret[0].(*powervs.DNSZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSZoneByName indicates an expected call of GetDNSZoneByName.
+func (mr *MockAPIMockRecorder) GetDNSZoneByName(ctx, name, publish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSZoneByName", reflect.TypeOf((*MockAPI)(nil).GetDNSZoneByName), ctx, name, publish)
+}
+
 // GetDNSZoneIDByName mocks base method.
 func (m *MockAPI) GetDNSZoneIDByName(ctx context.Context, name string, publish types.PublishingStrategy) (string, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +189,36 @@ func (mr *MockAPIMockRecorder) GetPublicGatewayByVPC(ctx, vpcName interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicGatewayByVPC", reflect.TypeOf((*MockAPI)(nil).GetPublicGatewayByVPC), ctx, vpcName)
 }
 
+// GetResourceGroup mocks base method.
+func (m *MockAPI) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceGroup", ctx, nameOrID)
+	ret0, _ := ret[0].(*resourcemanagerv2.ResourceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceGroup indicates an expected call of GetResourceGroup.
+func (mr *MockAPIMockRecorder) GetResourceGroup(ctx, nameOrID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceGroup", reflect.TypeOf((*MockAPI)(nil).GetResourceGroup), ctx, nameOrID)
+}
+
+// GetSecurityGroups mocks base method.
+func (m *MockAPI) GetSecurityGroups(ctx context.Context, region, vpcName string) ([]vpcv1.SecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroups", ctx, region, vpcName)
+	ret0, _ := ret[0].([]vpcv1.SecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityGroups indicates an expected call of GetSecurityGroups.
+func (mr *MockAPIMockRecorder) GetSecurityGroups(ctx, region, vpcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroups", reflect.TypeOf((*MockAPI)(nil).GetSecurityGroups), ctx, region, vpcName)
+}
+
 // GetSubnetByName mocks base method.
 func (m *MockAPI) GetSubnetByName(ctx context.Context, subnetName, region string) (*vpcv1.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +249,21 @@ func (mr *MockAPIMockRecorder) GetVPCByName(ctx, vpcName interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCByName", reflect.TypeOf((*MockAPI)(nil).GetVPCByName), ctx, vpcName)
 }
 
+// GetVPCQuotas mocks base method.
+func (m *MockAPI) GetVPCQuotas(ctx context.Context, region, vpcName string) ([]quota.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPCQuotas", ctx, region, vpcName)
+	ret0, _ := ret[0].([]quota.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVPCQuotas indicates an expected call of GetVPCQuotas.
+func (mr *MockAPIMockRecorder) GetVPCQuotas(ctx, region, vpcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCQuotas", reflect.TypeOf((*MockAPI)(nil).GetVPCQuotas), ctx, region, vpcName)
+}
+
 // GetVPCs mocks base method.
 func (m *MockAPI) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error) {
 	m.ctrl.T.Helper()
@@ -187,6 +279,21 @@ func (mr *MockAPIMockRecorder) GetVPCs(ctx, region interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCs", reflect.TypeOf((*MockAPI)(nil).GetVPCs), ctx, region)
 }
 
+// GetWorkspaces mocks base method.
+func (m *MockAPI) GetWorkspaces(ctx context.Context, zone string) ([]powervs.WorkspaceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, zone)
+	ret0, _ := ret[0].([]powervs.WorkspaceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockAPIMockRecorder) GetWorkspaces(ctx, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockAPI)(nil).GetWorkspaces), ctx, zone)
+}
+
 // SetVPCServiceURLForRegion mocks base method.
 func (m *MockAPI) SetVPCServiceURLForRegion(ctx context.Context, region string) error {
 	m.ctrl.T.Helper()
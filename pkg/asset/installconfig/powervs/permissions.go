@@ -0,0 +1,326 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/iampolicymanagementv1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// ServiceRole describes an IAM access role which the API key must be granted
+// on an IBM Cloud service for the install to succeed.
+type ServiceRole struct {
+	// Service is the service name of the service, e.g. power-iaas.
+	Service string
+	// DisplayName is the name of the service shown in the IBM Cloud console.
+	DisplayName string
+	// Role is the name of the role which must be granted, e.g. Manager.
+	Role string
+	// Reason explains why the installer needs the role.
+	Reason string
+}
+
+func (r ServiceRole) String() string {
+	return fmt.Sprintf("%s on %s (%s) needed to %s", r.Role, r.DisplayName, r.Service, r.Reason)
+}
+
+// roleRanks orders the IAM service roles and platform roles, so that a
+// stronger role of the same kind satisfies a weaker one, e.g. Manager grants
+// everything Writer does.
+var roleRanks = map[string]int{
+	"serviceRole:Reader":  1,
+	"serviceRole:Writer":  2,
+	"serviceRole:Manager": 3,
+	"role:Viewer":         1,
+	"role:Operator":       2,
+	"role:Editor":         3,
+	"role:Administrator":  4,
+}
+
+// requiredServiceRoles returns the access roles which the installer needs to
+// create the resources of the cluster.
+func requiredServiceRoles(ic *types.InstallConfig) []ServiceRole {
+	dns := ServiceRole{
+		Service:     "internet-svcs",
+		DisplayName: "Internet Services",
+		Role:        "Manager",
+		Reason:      "create the DNS records of the cluster",
+	}
+	if ic.Publish == types.InternalPublishingStrategy {
+		dns.Service = "dns-svcs"
+		dns.DisplayName = "DNS Services"
+	}
+	return []ServiceRole{
+		{
+			Service:     "power-iaas",
+			DisplayName: "Power Virtual Server",
+			Role:        "Manager",
+			Reason:      "create the workspace, networks and instances",
+		},
+		{
+			Service:     "is",
+			DisplayName: "VPC Infrastructure Services",
+			Role:        "Editor",
+			Reason:      "create the VPC, subnets and load balancers",
+		},
+		dns,
+		{
+			Service:     "cloud-object-storage",
+			DisplayName: "Cloud Object Storage",
+			Role:        "Writer",
+			Reason:      "upload the RHCOS image and the bootstrap ignition",
+		},
+		{
+			Service:     "transit.gateway",
+			DisplayName: "Transit Gateway",
+			Role:        "Editor",
+			Reason:      "connect the workspace to the VPC",
+		},
+	}
+}
+
+// CheckRequiredPermissions checks that the access policies of the API key
+// grant the service roles needed by the install, including the policies of
+// the access groups of its identity, and reports all of the missing roles.
+func (c *BxClient) CheckRequiredPermissions(ctx context.Context, ic *types.InstallConfig) error {
+	if c.TrustedProfileID != "" {
+		logrus.Debugf("Skipping the IAM service role check for trusted profile %s", c.TrustedProfileID)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	policyService, err := iampolicymanagementv1.NewIamPolicyManagementV1(&iampolicymanagementv1.IamPolicyManagementV1Options{
+		Authenticator: c.Authenticator(),
+		URL:           c.ServiceURL(powervs.IAMServiceEndpointName, ""),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM policy management client")
+	}
+	configureService(policyService.Service)
+
+	policies, err := listPolicies(ctx, policyService.Service, map[string]string{"account_id": c.User.Account, "type": "access", "iam_id": c.User.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list IAM access policies")
+	}
+
+	groups, err := c.listAccessGroups(ctx, policyService.Service)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		groupPolicies, err := listPolicies(ctx, policyService.Service, map[string]string{"account_id": c.User.Account, "type": "access", "access_group_id": group})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list IAM access policies of access group %s", group)
+		}
+		policies = append(policies, groupPolicies...)
+	}
+
+	missing, scoped := missingServiceRoles(requiredServiceRoles(ic), policies)
+	for _, role := range scoped {
+		logrus.Warnf("The API key of %s is granted %s only on some of the resources of the service, the install fails if they do not include those of the cluster", c.User.ID, role)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(missing))
+	for _, role := range missing {
+		descriptions = append(descriptions, role.String())
+	}
+	return errors.Errorf("the API key of %s is missing IAM service access roles: %s", c.User.ID, strings.Join(descriptions, "; "))
+}
+
+// accessGroupsResponse is the response of the IAM access groups API. The
+// access groups SDK is not vendored, so the API is called with the base
+// service of the policy management client.
+type accessGroupsResponse struct {
+	TotalCount int64 `json:"total_count"`
+	Groups     []struct {
+		ID string `json:"id"`
+	} `json:"groups"`
+}
+
+// listAccessGroups returns the IDs of the access groups of the identity of the
+// API key.
+func (c *BxClient) listAccessGroups(ctx context.Context, service *core.BaseService) ([]string, error) {
+	const limit = 100
+	var groups []string
+	for offset := 0; ; offset += limit {
+		builder := core.NewRequestBuilder(core.GET).WithContext(ctx)
+		if _, err := builder.ResolveRequestURL(service.GetServiceURL(), "/v2/groups", nil); err != nil {
+			return nil, err
+		}
+		builder.AddHeader("Accept", "application/json")
+		builder.AddQuery("account_id", c.User.Account)
+		builder.AddQuery("iam_id", c.User.ID)
+		builder.AddQuery("limit", strconv.Itoa(limit))
+		builder.AddQuery("offset", strconv.Itoa(offset))
+		request, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		response := &accessGroupsResponse{}
+		if _, err := service.Request(request, response); err != nil {
+			return nil, errors.Wrap(err, "failed to list IAM access groups")
+		}
+		for _, group := range response.Groups {
+			groups = append(groups, group.ID)
+		}
+		if len(response.Groups) < limit || int64(offset+limit) >= response.TotalCount {
+			return groups, nil
+		}
+	}
+}
+
+// policiesResponse is a page of the response of the IAM policies API. The
+// vendored policy management SDK does not page the policies, so the API is
+// called with the base service of its client.
+type policiesResponse struct {
+	Policies []iampolicymanagementv1.Policy `json:"policies"`
+	Next     *struct {
+		Start string `json:"start"`
+	} `json:"next"`
+}
+
+// listPolicies returns all of the pages of the policies matching the query.
+func listPolicies(ctx context.Context, service *core.BaseService, query map[string]string) ([]iampolicymanagementv1.Policy, error) {
+	const limit = 100
+	var policies []iampolicymanagementv1.Policy
+	start := ""
+	for {
+		builder := core.NewRequestBuilder(core.GET).WithContext(ctx)
+		if _, err := builder.ResolveRequestURL(service.GetServiceURL(), "/v1/policies", nil); err != nil {
+			return nil, err
+		}
+		builder.AddHeader("Accept", "application/json")
+		for key, value := range query {
+			builder.AddQuery(key, value)
+		}
+		builder.AddQuery("limit", strconv.Itoa(limit))
+		if start != "" {
+			builder.AddQuery("start", start)
+		}
+		request, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		response := &policiesResponse{}
+		if _, err := service.Request(request, response); err != nil {
+			return nil, err
+		}
+		policies = append(policies, response.Policies...)
+		if response.Next == nil || response.Next.Start == "" {
+			return policies, nil
+		}
+		start = response.Next.Start
+	}
+}
+
+// policyScope is how much of a service an access policy applies to.
+type policyScope int
+
+const (
+	// scopeNone is the scope of a policy which does not apply to the
+	// service.
+	scopeNone policyScope = iota
+	// scopeResources is the scope of a policy which only applies to some
+	// resources of the service, e.g. those of a resource group or a region.
+	scopeResources
+	// scopeService is the scope of a policy which applies to all of the
+	// resources of the service.
+	scopeService
+)
+
+// missingServiceRoles returns the required roles which are not granted by any
+// of the policies, and those which are only granted on some resources of
+// their service.
+func missingServiceRoles(required []ServiceRole, policies []iampolicymanagementv1.Policy) ([]ServiceRole, []ServiceRole) {
+	var missing, scoped []ServiceRole
+	for _, role := range required {
+		scope := scopeNone
+		for _, policy := range policies {
+			if s := policyGrantsRole(policy, role); s > scope {
+				scope = s
+			}
+		}
+		switch scope {
+		case scopeNone:
+			missing = append(missing, role)
+		case scopeResources:
+			scoped = append(scoped, role)
+		}
+	}
+	return missing, scoped
+}
+
+// policyServiceScope returns the scope of the access policy on the service.
+// A policy without a service name applies to all of the services of the
+// account, or with the service type "service" to all of the IAM enabled
+// services, and any other resource attribute, e.g. resourceGroupId or
+// serviceInstance, restricts it to some resources.
+func policyServiceScope(policy iampolicymanagementv1.Policy, service string) policyScope {
+	if len(policy.Resources) == 0 {
+		return scopeNone
+	}
+	scope := scopeService
+	for _, attribute := range policy.Resources[0].Attributes {
+		if attribute.Name == nil || attribute.Value == nil {
+			continue
+		}
+		switch *attribute.Name {
+		case "accountId":
+		case "serviceName":
+			if *attribute.Value != service {
+				return scopeNone
+			}
+		case "serviceType":
+			if *attribute.Value != "service" {
+				return scopeNone
+			}
+		default:
+			scope = scopeResources
+		}
+	}
+	return scope
+}
+
+// policyGrantsRole returns the scope on which the access policy grants the
+// required role, or a stronger role of the same kind, on the service.
+func policyGrantsRole(policy iampolicymanagementv1.Policy, required ServiceRole) policyScope {
+	scope := policyServiceScope(policy, required.Service)
+	if scope == scopeNone {
+		return scopeNone
+	}
+	kind := "serviceRole:"
+	if _, ok := roleRanks["role:"+required.Role]; ok {
+		kind = "role:"
+	}
+	requiredRank := roleRanks[kind+required.Role]
+	for _, role := range policy.Roles {
+		if role.RoleID == nil {
+			continue
+		}
+		index := strings.LastIndex(*role.RoleID, kind)
+		if index < 0 {
+			continue
+		}
+		if rank, ok := roleRanks[(*role.RoleID)[index:]]; ok && rank >= requiredRank {
+			return scope
+		}
+	}
+	return scopeNone
+}
@@ -0,0 +1,192 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/iampolicymanagementv1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func accessPolicy(service string, roleIDs ...string) iampolicymanagementv1.Policy {
+	return scopedAccessPolicy(service, nil, roleIDs...)
+}
+
+// scopedAccessPolicy returns an access policy on the service restricted by the
+// resource attributes.
+func scopedAccessPolicy(service string, scope map[string]string, roleIDs ...string) iampolicymanagementv1.Policy {
+	attributes := []iampolicymanagementv1.ResourceAttribute{
+		{Name: core.StringPtr("accountId"), Value: core.StringPtr("account")},
+	}
+	if service != "" {
+		attributes = append(attributes, iampolicymanagementv1.ResourceAttribute{Name: core.StringPtr("serviceName"), Value: core.StringPtr(service)})
+	}
+	for name, value := range scope {
+		attributes = append(attributes, iampolicymanagementv1.ResourceAttribute{Name: core.StringPtr(name), Value: core.StringPtr(value)})
+	}
+	roles := make([]iampolicymanagementv1.PolicyRole, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		roles = append(roles, iampolicymanagementv1.PolicyRole{RoleID: core.StringPtr(roleID)})
+	}
+	return iampolicymanagementv1.Policy{
+		Resources: []iampolicymanagementv1.PolicyResource{{Attributes: attributes}},
+		Roles:     roles,
+	}
+}
+
+func TestMissingServiceRoles(t *testing.T) {
+	required := []ServiceRole{
+		{Service: "power-iaas", Role: "Manager"},
+		{Service: "is", Role: "Editor"},
+		{Service: "cloud-object-storage", Role: "Writer"},
+	}
+
+	cases := []struct {
+		name     string
+		policies []iampolicymanagementv1.Policy
+		missing  []ServiceRole
+		scoped   []ServiceRole
+	}{
+		{
+			name:    "no policies",
+			missing: required,
+		},
+		{
+			name: "all granted",
+			policies: []iampolicymanagementv1.Policy{
+				accessPolicy("power-iaas", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Editor"),
+				accessPolicy("cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+			},
+		},
+		{
+			name: "stronger roles",
+			policies: []iampolicymanagementv1.Policy{
+				accessPolicy("power-iaas", "crn:v1:bluemix:public:iam::::role:Viewer", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Administrator"),
+				accessPolicy("cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+			},
+		},
+		{
+			name: "weaker roles",
+			policies: []iampolicymanagementv1.Policy{
+				accessPolicy("power-iaas", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Operator"),
+				accessPolicy("cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+			},
+			missing: required[:2],
+		},
+		{
+			name: "platform role does not grant a service role",
+			policies: []iampolicymanagementv1.Policy{
+				accessPolicy("power-iaas", "crn:v1:bluemix:public:iam::::role:Administrator"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Editor"),
+				accessPolicy("cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+			},
+			missing: required[:1],
+		},
+		{
+			name: "all services of the account",
+			policies: []iampolicymanagementv1.Policy{
+				accessPolicy("", "crn:v1:bluemix:public:iam::::role:Editor", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+			},
+		},
+		{
+			name: "all IAM enabled services",
+			policies: []iampolicymanagementv1.Policy{
+				scopedAccessPolicy("", map[string]string{"serviceType": "service"}, "crn:v1:bluemix:public:iam::::role:Editor", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+			},
+		},
+		{
+			name: "account management services",
+			policies: []iampolicymanagementv1.Policy{
+				scopedAccessPolicy("", map[string]string{"serviceType": "platform_service"}, "crn:v1:bluemix:public:iam::::role:Administrator", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+			},
+			missing: required,
+		},
+		{
+			name: "resource group",
+			policies: []iampolicymanagementv1.Policy{
+				scopedAccessPolicy("", map[string]string{"resourceGroupId": "rg"}, "crn:v1:bluemix:public:iam::::role:Editor", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+			},
+			scoped: required,
+		},
+		{
+			name: "service instance",
+			policies: []iampolicymanagementv1.Policy{
+				scopedAccessPolicy("power-iaas", map[string]string{"serviceInstance": "workspace"}, "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Editor"),
+			},
+			missing: required[2:],
+			scoped:  required[:1],
+		},
+		{
+			name: "service and service instance",
+			policies: []iampolicymanagementv1.Policy{
+				scopedAccessPolicy("power-iaas", map[string]string{"serviceInstance": "workspace"}, "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+				accessPolicy("power-iaas", "crn:v1:bluemix:public:iam::::serviceRole:Manager"),
+				accessPolicy("is", "crn:v1:bluemix:public:iam::::role:Editor"),
+				accessPolicy("cloud-object-storage", "crn:v1:bluemix:public:iam::::serviceRole:Writer"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			missing, scoped := missingServiceRoles(required, tc.policies)
+			assert.Equal(t, tc.missing, missing)
+			assert.Equal(t, tc.scoped, scoped)
+		})
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"policies":[{"id":"policy-1"},{"id":"policy-2"}],"next":{"start":"page-2"}}`,
+		"page-2": `{"policies":[{"id":"policy-3"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/policies", r.URL.Path)
+		assert.Equal(t, "access", r.URL.Query().Get("type"))
+		page, ok := pages[r.URL.Query().Get("start")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	service, err := core.NewBaseService(&core.ServiceOptions{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policies, err := listPolicies(context.Background(), service, map[string]string{"account_id": "account", "type": "access"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		ids = append(ids, *policy.ID)
+	}
+	assert.Equal(t, []string{"policy-1", "policy-2", "policy-3"}, ids)
+}
+
+func TestRequiredServiceRolesDNS(t *testing.T) {
+	dnsService := func(publish types.PublishingStrategy) string {
+		for _, role := range requiredServiceRoles(&types.InstallConfig{Publish: publish}) {
+			if role.Reason == "create the DNS records of the cluster" {
+				return role.Service
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "internet-svcs", dnsService(types.ExternalPublishingStrategy))
+	assert.Equal(t, "dns-svcs", dnsService(types.InternalPublishingStrategy))
+}
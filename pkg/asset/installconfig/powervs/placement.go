@@ -0,0 +1,245 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/helpers"
+	"github.com/IBM-Cloud/power-go-client/power/client/p_cloud_shared_processor_pools"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/pkg/errors"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// GetSharedProcessorPools returns the shared processor pools of the workspace.
+func (c *BxClient) GetSharedProcessorPools(ctx context.Context, serviceInstanceID string) (*models.SharedProcessorPools, error) {
+	key := fmt.Sprintf("sharedprocessorpools/%s", serviceInstanceID)
+	if cached, ok := responses.get(key); ok {
+		return cached.(*models.SharedProcessorPools), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	params := p_cloud_shared_processor_pools.NewPcloudSharedprocessorpoolsGetallParams().
+		WithContext(ctx).WithTimeout(helpers.PIGetTimeOut).
+		WithCloudInstanceID(serviceInstanceID)
+	resp, err := c.PISession.Power.PCloudSharedProcessorPools.PcloudSharedprocessorpoolsGetall(params, c.PISession.AuthInfo(serviceInstanceID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get shared processor pools")
+	}
+	if resp == nil || resp.Payload == nil {
+		return nil, errors.New("failed to get shared processor pools")
+	}
+
+	responses.set(key, resp.Payload)
+	return resp.Payload, nil
+}
+
+// GetServerPlacementGroups returns the server placement groups of the
+// workspace.
+func (c *BxClient) GetServerPlacementGroups(ctx context.Context, serviceInstanceID string) (*models.PlacementGroups, error) {
+	key := fmt.Sprintf("placementgroups/%s", serviceInstanceID)
+	if cached, ok := responses.get(key); ok {
+		return cached.(*models.PlacementGroups), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	placementGroupClient := instance.NewIBMPIPlacementGroupClient(ctx, c.PISession, serviceInstanceID)
+	groups, err := placementGroupClient.GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get server placement groups")
+	}
+
+	responses.set(key, groups)
+	return groups, nil
+}
+
+// ValidatePlacement validates that the shared processor pools and the server
+// placement groups of the machine pools exist in the workspace, and that the
+// shared processor pools have enough cores available for their machines.
+func (c *BxClient) ValidatePlacement(ctx context.Context, ic *types.InstallConfig, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet) error {
+	svcInsID := ic.Platform.PowerVS.ServiceInstanceID
+	pools := placementPools(ic)
+
+	sharedProcessorPools := &models.SharedProcessorPools{}
+	if pools.ControlPlane.SharedProcessorPool != "" || pools.Compute.SharedProcessorPool != "" {
+		var err error
+		sharedProcessorPools, err = c.GetSharedProcessorPools(ctx, svcInsID)
+		if err != nil {
+			return err
+		}
+	}
+	placementGroups := &models.PlacementGroups{}
+	if pools.ControlPlane.ServerPlacementGroup != nil || pools.Compute.ServerPlacementGroup != nil {
+		var err error
+		placementGroups, err = c.GetServerPlacementGroups(ctx, svcInsID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Call another function which we can also test with mock
+	return ValidatePlacementWithPools(ic, controlPlanes, computes, sharedProcessorPools, placementGroups)
+}
+
+// ValidatePlacementWithPools validates that the shared processor pools and
+// the server placement groups of the machine pools are in the given lists,
+// and that the shared processor pools have enough cores available for the
+// machines they host.
+func ValidatePlacementWithPools(ic *types.InstallConfig, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet, sharedProcessorPools *models.SharedProcessorPools, placementGroups *models.PlacementGroups) error {
+	pools := placementPools(ic)
+
+	cores, err := sharedProcessorPoolCores(pools, controlPlanes, computes)
+	if err != nil {
+		return err
+	}
+	nameOrIDs := make([]string, 0, len(cores))
+	for nameOrID := range cores {
+		nameOrIDs = append(nameOrIDs, nameOrID)
+	}
+	sort.Strings(nameOrIDs)
+	for _, nameOrID := range nameOrIDs {
+		pool := findSharedProcessorPool(sharedProcessorPools, nameOrID)
+		if pool == nil {
+			return errors.Errorf("shared processor pool %q not found in the workspace", nameOrID)
+		}
+		if err := validateSharedProcessorPoolCapacity(pool, nameOrID, cores[nameOrID]); err != nil {
+			return err
+		}
+	}
+
+	for _, pool := range []powervstypes.MachinePool{pools.ControlPlane, pools.Compute} {
+		if pool.ServerPlacementGroup == nil {
+			continue
+		}
+		group := findServerPlacementGroup(placementGroups, pool.ServerPlacementGroup.Name)
+		if group == nil {
+			return errors.Errorf("server placement group %q not found in the workspace", pool.ServerPlacementGroup.Name)
+		}
+		if err := validateServerPlacementGroupPolicy(group, pool.ServerPlacementGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findSharedProcessorPool(pools *models.SharedProcessorPools, nameOrID string) *models.SharedProcessorPool {
+	for _, pool := range pools.SharedProcessorPools {
+		if pool != nil && ((pool.ID != nil && *pool.ID == nameOrID) || (pool.Name != nil && *pool.Name == nameOrID)) {
+			return pool
+		}
+	}
+	return nil
+}
+
+func findServerPlacementGroup(groups *models.PlacementGroups, nameOrID string) *models.PlacementGroup {
+	for _, group := range groups.PlacementGroups {
+		if group != nil && ((group.ID != nil && *group.ID == nameOrID) || (group.Name != nil && *group.Name == nameOrID)) {
+			return group
+		}
+	}
+	return nil
+}
+
+// validateSharedProcessorPoolCapacity validates that the shared processor
+// pool has the cores needed by the machines it hosts.
+func validateSharedProcessorPoolCapacity(pool *models.SharedProcessorPool, nameOrID string, cores float64) error {
+	if pool.AvailableCores == nil {
+		return errors.Errorf("the available cores of the shared processor pool %q are unknown", nameOrID)
+	}
+	if cores > *pool.AvailableCores {
+		return errors.Errorf("Not enough cores available (%v) in the shared processor pool %q for the machines (need %v)", *pool.AvailableCores, nameOrID, cores)
+	}
+	return nil
+}
+
+// validateServerPlacementGroupPolicy validates that the placement group has
+// the policy expected by the machine pool, if any.
+func validateServerPlacementGroupPolicy(group *models.PlacementGroup, expected *powervstypes.ServerPlacementGroup) error {
+	if expected.Policy == "" || group.Policy == nil {
+		return nil
+	}
+	if *group.Policy != string(expected.Policy) {
+		return errors.Errorf("the server placement group %q has the %s policy, not %s", expected.Name, *group.Policy, expected.Policy)
+	}
+	return nil
+}
+
+// poolPlatforms are the machine pools of the control plane and compute
+// machines, merged with the default machine platform.
+type poolPlatforms struct {
+	ControlPlane powervstypes.MachinePool
+	Compute      powervstypes.MachinePool
+}
+
+// placementPools returns the control plane and compute machine pools of the
+// install config, merged with the default machine platform.
+func placementPools(ic *types.InstallConfig) poolPlatforms {
+	pool := func(p *powervstypes.MachinePool) powervstypes.MachinePool {
+		mpool := powervstypes.MachinePool{}
+		mpool.Set(ic.Platform.PowerVS.DefaultMachinePlatform)
+		mpool.Set(p)
+		return mpool
+	}
+	pools := poolPlatforms{ControlPlane: pool(nil), Compute: pool(nil)}
+	if ic.ControlPlane != nil {
+		pools.ControlPlane = pool(ic.ControlPlane.Platform.PowerVS)
+	}
+	for _, compute := range ic.Compute {
+		if compute.Name == types.MachinePoolComputeRoleName {
+			pools.Compute = pool(compute.Platform.PowerVS)
+		}
+	}
+	return pools
+}
+
+// sharedProcessorPoolCores returns the cores consumed by the machines from
+// each shared processor pool, by the name or ID of the pool.
+func sharedProcessorPoolCores(pools poolPlatforms, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet) (map[string]float64, error) {
+	cores := map[string]float64{}
+	add := func(pool powervstypes.MachinePool, config *machinev1.PowerVSMachineProviderConfig, replicas int64) error {
+		if pool.SharedProcessorPool == "" {
+			return nil
+		}
+		machineCores, err := DefaultCapacityModel.WithSMTLevel(pool.SMTLevel).Cores(string(config.ProcessorType), config.Processors)
+		if err != nil {
+			return errors.Wrapf(err, "failed to calculate the cores of the machines in the shared processor pool %q", pool.SharedProcessorPool)
+		}
+		cores[pool.SharedProcessorPool] += float64(replicas) * machineCores
+		return nil
+	}
+
+	if len(controlPlanes) > 0 {
+		config, ok := controlPlanes[0].Spec.ProviderSpec.Value.Object.(*machinev1.PowerVSMachineProviderConfig)
+		if !ok {
+			return nil, errors.New("m.Spec.ProviderSpec.Value.Object failed")
+		}
+		if err := add(pools.ControlPlane, config, int64(len(controlPlanes))); err != nil {
+			return nil, err
+		}
+	}
+	for _, w := range computes {
+		config, ok := w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machinev1.PowerVSMachineProviderConfig)
+		if !ok {
+			return nil, errors.New("w.Spec.Template.Spec.ProviderSpec.Value.Object")
+		}
+		var replicas int64
+		if w.Spec.Replicas != nil {
+			replicas = int64(*w.Spec.Replicas)
+		}
+		if err := add(pools.Compute, config, replicas); err != nil {
+			return nil, err
+		}
+	}
+	return cores, nil
+}
@@ -9,7 +9,7 @@ import (
 // Platform collects powervs-specific configuration.
 func Platform() (*powervs.Platform, error) {
 
-	bxCli, err := NewBxClient()
+	bxCli, err := NewBxClient(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -32,5 +32,10 @@ func Platform() (*powervs.Platform, error) {
 	p.Zone = bxCli.PISession.Options.Zone
 	p.UserID = bxCli.PISession.Options.UserAccount
 
+	p.ServiceInstanceID, err = GetWorkspace(p.Zone)
+	if err != nil {
+		return nil, err
+	}
+
 	return &p, nil
 }
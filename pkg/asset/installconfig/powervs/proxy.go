@@ -0,0 +1,67 @@
+package powervs
+
+import (
+	gohttp "net/http"
+	"net/url"
+
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM/go-sdk-core/v5/core"
+	httptransport "github.com/go-openapi/runtime/client"
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// proxyConfig is the cluster-wide proxy from the install config, or nil when
+// the IBM Cloud APIs are reached directly.
+var proxyConfig *httpproxy.Config
+
+// SetProxy configures the IBM Cloud clients to connect through the proxy of the
+// install config. The process environment is not consulted, because the Go
+// standard library only reads it once.
+func SetProxy(proxy *types.Proxy) {
+	if proxy == nil || (proxy.HTTPProxy == "" && proxy.HTTPSProxy == "") {
+		proxyConfig = nil
+		return
+	}
+	proxyConfig = &httpproxy.Config{
+		HTTPProxy:  proxy.HTTPProxy,
+		HTTPSProxy: proxy.HTTPSProxy,
+		NoProxy:    proxy.NoProxy,
+	}
+}
+
+// newTransport returns the transport of the IBM Cloud clients, which connects
+// through the configured proxy, if any, and retries the requests which fail
+// transiently.
+func newTransport() gohttp.RoundTripper {
+	transport := gohttp.DefaultTransport.(*gohttp.Transport).Clone()
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	if proxyConfig != nil {
+		proxyFunc := proxyConfig.ProxyFunc()
+		transport.Proxy = func(req *gohttp.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	return newRetryTransport(transport)
+}
+
+// newHTTPClient returns the HTTP client of the IBM Cloud clients.
+func newHTTPClient() *gohttp.Client {
+	return &gohttp.Client{Transport: newTransport()}
+}
+
+// configureService makes an IBM Cloud SDK service use the HTTP client of the
+// IBM Cloud clients.
+func configureService(service *core.BaseService) {
+	service.SetHTTPClient(newHTTPClient())
+}
+
+// configurePISession makes a Power VS session use the transport of the IBM
+// Cloud clients. The Power VS client has no option for its HTTP client, so the
+// transport of its runtime is replaced instead.
+func configurePISession(session *ibmpisession.IBMPISession) {
+	if runtime, ok := session.Power.Transport.(*httptransport.Runtime); ok {
+		runtime.Transport = newTransport()
+	}
+}
@@ -0,0 +1,151 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// testProxy is a fake HTTP proxy, which answers the requests with the JSON
+// response instead of forwarding them, and records the URLs requested.
+type testProxy struct {
+	mutex    sync.Mutex
+	response string
+	urls     []string
+}
+
+func (p *testProxy) ServeHTTP(w gohttp.ResponseWriter, r *gohttp.Request) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.urls = append(p.urls, fmt.Sprintf("%s://%s%s", r.URL.Scheme, r.URL.Host, r.URL.Path))
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(p.response))
+}
+
+// newTestProxy starts a fake proxy answering with the response, and sets it
+// as the proxy of the IBM Cloud clients for the test.
+func newTestProxy(t *testing.T, response string) *testProxy {
+	proxy := &testProxy{response: response}
+	server := httptest.NewServer(proxy)
+	t.Cleanup(server.Close)
+	SetProxy(&types.Proxy{HTTPProxy: server.URL})
+	t.Cleanup(func() { SetProxy(nil) })
+	return proxy
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	cases := []struct {
+		name     string
+		proxy    *types.Proxy
+		expected map[string]string
+	}{
+		{
+			name: "no proxy",
+			expected: map[string]string{
+				"http://iam.cloud.ibm.com":  "",
+				"https://iam.cloud.ibm.com": "",
+			},
+		},
+		{
+			name:  "empty proxy",
+			proxy: &types.Proxy{NoProxy: ".cloud.ibm.com"},
+			expected: map[string]string{
+				"http://iam.cloud.ibm.com":  "",
+				"https://iam.cloud.ibm.com": "",
+			},
+		},
+		{
+			name:  "HTTP and HTTPS proxies",
+			proxy: &types.Proxy{HTTPProxy: "http://http-proxy:3128", HTTPSProxy: "http://https-proxy:3129"},
+			expected: map[string]string{
+				"http://iam.cloud.ibm.com":  "http://http-proxy:3128",
+				"https://iam.cloud.ibm.com": "http://https-proxy:3129",
+			},
+		},
+		{
+			name:  "no proxy for some domains",
+			proxy: &types.Proxy{HTTPSProxy: "http://https-proxy:3129", NoProxy: ".iaas.cloud.ibm.com,10.0.0.0/8"},
+			expected: map[string]string{
+				"https://iam.cloud.ibm.com":               "http://https-proxy:3129",
+				"https://us-south.iaas.cloud.ibm.com/v1":  "",
+				"https://10.1.2.3/v1":                     "",
+				"https://dal.power-iaas.cloud.ibm.com/v1": "http://https-proxy:3129",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetProxy(tc.proxy)
+			t.Cleanup(func() { SetProxy(nil) })
+
+			transport := newTransport().(*retryTransport).next.(*gohttp.Transport)
+			actual := map[string]string{}
+			for rawURL := range tc.expected {
+				req, err := gohttp.NewRequest(gohttp.MethodGet, rawURL, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				actual[rawURL] = ""
+				if transport.Proxy == nil {
+					continue
+				}
+				proxyURL, err := transport.Proxy(req)
+				assert.NoError(t, err)
+				if proxyURL != nil {
+					actual[rawURL] = proxyURL.String()
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestConfigureServiceProxy(t *testing.T) {
+	proxy := newTestProxy(t, `{"vpcs": [{"id": "vpc-1", "name": "vpc-1"}]}`)
+
+	vpcService, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: &core.NoAuthAuthenticator{},
+		URL:           "http://us-south.iaas.cloud.ibm.com/v1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureService(vpcService.Service)
+
+	vpcs, _, err := vpcService.ListVpcsWithContext(context.Background(), vpcService.NewListVpcsOptions())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, vpcs.Vpcs, 1)
+	assert.Equal(t, []string{"http://us-south.iaas.cloud.ibm.com/v1/vpcs"}, proxy.urls)
+}
+
+func TestConfigurePISessionProxy(t *testing.T) {
+	proxy := newTestProxy(t, `[]`)
+
+	session, err := ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+		Authenticator: &core.NoAuthAuthenticator{},
+		UserAccount:   "account",
+		Zone:          "dal10",
+		URL:           "http://dal.power-iaas.cloud.ibm.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configurePISession(session)
+
+	_, err = instance.NewIBMPIDhcpClient(context.Background(), session, "service-instance-id").GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://dal.power-iaas.cloud.ibm.com/pcloud/v1/cloud-instances/service-instance-id/services/dhcp"}, proxy.urls)
+}
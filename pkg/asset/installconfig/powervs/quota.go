@@ -0,0 +1,239 @@
+package powervs
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// IBM Cloud does not expose an API for the VPC quotas of an account, so the
+// documented default limits are used.
+// https://cloud.ibm.com/docs/vpc?topic=vpc-quotas
+var vpcDefaultLimits = map[string]int64{
+	"vpcs":            10,
+	"subnets":         100,
+	"security-groups": 100,
+	"load-balancers":  50,
+	"floating-ips":    40,
+}
+
+// GetVPCQuotas returns the usage and limit of the VPC resources created by
+// the installer. Subnets and security groups are limited per VPC, so they are
+// only counted when an existing VPC is used.
+func (c *Client) GetVPCQuotas(ctx context.Context, region string, vpcName string) ([]quota.Quota, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	usage := map[string]int64{}
+
+	if usage["vpcs"], err = c.countVPCs(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to count VPCs")
+	}
+	if usage["load-balancers"], err = c.countLoadBalancers(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to count load balancers")
+	}
+	if usage["floating-ips"], err = c.countFloatingIPs(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to count floating IPs")
+	}
+	if vpcName != "" {
+		if usage["subnets"], err = c.countSubnets(ctx, vpcName); err != nil {
+			return nil, errors.Wrap(err, "failed to count subnets")
+		}
+		if usage["security-groups"], err = c.countSecurityGroups(ctx, vpcName); err != nil {
+			return nil, errors.Wrap(err, "failed to count security groups")
+		}
+	}
+
+	quotas := make([]quota.Quota, 0, len(vpcDefaultLimits))
+	for name, limit := range vpcDefaultLimits {
+		quotas = append(quotas, quota.Quota{
+			Service: "is",
+			Name:    name,
+			Region:  region,
+			InUse:   usage[name],
+			Limit:   limit,
+		})
+	}
+	return quotas, nil
+}
+
+func (c *Client) countVPCs(ctx context.Context) (int64, error) {
+	var count int64
+	options := c.vpcAPI.NewListVpcsOptions()
+	for {
+		collection, _, err := c.vpcAPI.ListVpcsWithContext(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(collection.Vpcs))
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return 0, err
+		}
+		if start == nil {
+			return count, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+func (c *Client) countLoadBalancers(ctx context.Context) (int64, error) {
+	var count int64
+	options := c.vpcAPI.NewListLoadBalancersOptions()
+	for {
+		collection, _, err := c.vpcAPI.ListLoadBalancersWithContext(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(collection.LoadBalancers))
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return 0, err
+		}
+		if start == nil {
+			return count, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+func (c *Client) countFloatingIPs(ctx context.Context) (int64, error) {
+	var count int64
+	options := c.vpcAPI.NewListFloatingIpsOptions()
+	for {
+		collection, _, err := c.vpcAPI.ListFloatingIpsWithContext(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(collection.FloatingIps))
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return 0, err
+		}
+		if start == nil {
+			return count, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+func (c *Client) countSubnets(ctx context.Context, vpcName string) (int64, error) {
+	var count int64
+	options := c.vpcAPI.NewListSubnetsOptions()
+	for {
+		collection, _, err := c.vpcAPI.ListSubnetsWithContext(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		for _, subnet := range collection.Subnets {
+			if subnet.VPC != nil && subnet.VPC.Name != nil && *subnet.VPC.Name == vpcName {
+				count++
+			}
+		}
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return 0, err
+		}
+		if start == nil {
+			return count, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+func (c *Client) countSecurityGroups(ctx context.Context, vpcName string) (int64, error) {
+	var count int64
+	options := c.vpcAPI.NewListSecurityGroupsOptions()
+	options.SetVPCName(vpcName)
+	for {
+		collection, _, err := c.vpcAPI.ListSecurityGroupsWithContext(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(collection.SecurityGroups))
+
+		start, err := collection.GetNextStart()
+		if err != nil {
+			return 0, err
+		}
+		if start == nil {
+			return count, nil
+		}
+		options.SetStart(*start)
+	}
+}
+
+// VPCConstraints returns the VPC resources which the installer will create
+// for the install config.
+func VPCConstraints(ic *types.InstallConfig, region string) []quota.Constraint {
+	newVPC := ic.PowerVS.VPCName == ""
+
+	var constraints []quota.Constraint
+	add := func(name string, count int64) {
+		if count > 0 {
+			constraints = append(constraints, quota.Constraint{Name: name, Region: region, Count: count})
+		}
+	}
+
+	if newVPC {
+		add("vpcs", 1)
+		// The public gateway of the new VPC consumes a floating IP.
+		add("floating-ips", 1)
+	}
+	if len(ic.PowerVS.VPCSubnets) == 0 {
+		add("subnets", 1)
+	}
+	if ic.Publish == types.ExternalPublishingStrategy {
+		add("load-balancers", 2)
+	} else {
+		add("load-balancers", 1)
+	}
+	add("security-groups", 1)
+
+	return constraints
+}
+
+// ValidateVPCQuotas checks that the account has enough VPC quota left for the
+// resources the installer will create. The reports are returned even when the
+// check fails, so that they can be summarized.
+func ValidateVPCQuotas(ctx context.Context, client API, ic *types.InstallConfig) ([]quota.ConstraintReport, error) {
+	var reports []quota.ConstraintReport
+	err := timeValidation("VPC quotas", func() error {
+		var err error
+		reports, err = validateVPCQuotas(ctx, client, ic)
+		return err
+	})
+	return reports, err
+}
+
+func validateVPCQuotas(ctx context.Context, client API, ic *types.InstallConfig) ([]quota.ConstraintReport, error) {
+	region := ic.PowerVS.VPCRegion
+	if region == "" {
+		var err error
+		region, err = powervstypes.VPCRegionForPowerVSRegion(ic.PowerVS.Region)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quotas, err := client.GetVPCQuotas(ctx, region, ic.PowerVS.VPCName)
+	if err != nil {
+		return nil, err
+	}
+
+	return quota.Check(quotas, VPCConstraints(ic, region))
+}
@@ -0,0 +1,208 @@
+package powervs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// newTestClient returns a client whose IBM Cloud services are all served by
+// a fake of their APIs answering with the responses.
+func newTestClient(t *testing.T, responses map[string]string) *Client {
+	_, endpoints := newTestAPI(t, responses)
+	client := &Client{
+		APIKey:           "api-key",
+		accountID:        "account",
+		serviceEndpoints: endpoints,
+	}
+	if err := client.loadVPCV1API(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// vpcResponses are the VPC resources of the account, with the VPCs listed in
+// two pages.
+var vpcResponses = map[string]string{
+	"/vpcs":              `{"vpcs": [{"id": "vpc-1", "name": "vpc-1"}], "next": {"href": "https://us-south.iaas.cloud.ibm.com/v1/vpcs?start=page-2"}}`,
+	"/vpcs?start=page-2": `{"vpcs": [{"id": "vpc-2", "name": "vpc-2"}, {"id": "vpc-3", "name": "vpc-3"}]}`,
+	"/load_balancers":    `{"load_balancers": [{"id": "lb-1", "name": "lb-1"}]}`,
+	"/floating_ips":      `{"floating_ips": [{"id": "fip-1", "name": "fip-1"}, {"id": "fip-2", "name": "fip-2"}]}`,
+	"/subnets": `{"subnets": [
+		{"id": "subnet-1", "name": "subnet-1", "vpc": {"id": "vpc-1", "name": "vpc-1"}},
+		{"id": "subnet-2", "name": "subnet-2", "vpc": {"id": "vpc-1", "name": "vpc-1"}},
+		{"id": "subnet-3", "name": "subnet-3", "vpc": {"id": "vpc-2", "name": "vpc-2"}}
+	]}`,
+	"/security_groups": `{"security_groups": [{"id": "sg-1", "name": "sg-1"}]}`,
+}
+
+func TestGetVPCQuotas(t *testing.T) {
+	cases := []struct {
+		name     string
+		vpcName  string
+		expected map[string]int64
+	}{
+		{
+			name: "new VPC",
+			expected: map[string]int64{
+				"floating-ips":    2,
+				"load-balancers":  1,
+				"security-groups": 0,
+				"subnets":         0,
+				"vpcs":            3,
+			},
+		},
+		{
+			name:    "existing VPC",
+			vpcName: "vpc-1",
+			expected: map[string]int64{
+				"floating-ips":    2,
+				"load-balancers":  1,
+				"security-groups": 1,
+				"subnets":         2,
+				"vpcs":            3,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, vpcResponses)
+			quotas, err := client.GetVPCQuotas(context.Background(), "us-south", tc.vpcName)
+			if !assert.NoError(t, err) {
+				return
+			}
+			inUse := map[string]int64{}
+			for _, q := range quotas {
+				assert.Equal(t, "is", q.Service)
+				assert.Equal(t, "us-south", q.Region)
+				assert.Equal(t, vpcDefaultLimits[q.Name], q.Limit)
+				inUse[q.Name] = q.InUse
+			}
+			assert.Equal(t, tc.expected, inUse)
+		})
+	}
+
+	client := newTestClient(t, map[string]string{"/vpcs": `{"vpcs": []}`})
+	_, err := client.GetVPCQuotas(context.Background(), "us-south", "")
+	assert.Regexp(t, `^failed to count load balancers: `, err)
+}
+
+func TestVPCConstraints(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform powervstypes.Platform
+		publish  types.PublishingStrategy
+		expected []quota.Constraint
+	}{
+		{
+			name:    "new VPC",
+			publish: types.ExternalPublishingStrategy,
+			expected: []quota.Constraint{
+				{Name: "vpcs", Region: "us-south", Count: 1},
+				{Name: "floating-ips", Region: "us-south", Count: 1},
+				{Name: "subnets", Region: "us-south", Count: 1},
+				{Name: "load-balancers", Region: "us-south", Count: 2},
+				{Name: "security-groups", Region: "us-south", Count: 1},
+			},
+		},
+		{
+			name:     "existing VPC",
+			platform: powervstypes.Platform{VPCName: "vpc-1"},
+			publish:  types.ExternalPublishingStrategy,
+			expected: []quota.Constraint{
+				{Name: "subnets", Region: "us-south", Count: 1},
+				{Name: "load-balancers", Region: "us-south", Count: 2},
+				{Name: "security-groups", Region: "us-south", Count: 1},
+			},
+		},
+		{
+			name:     "existing VPC and subnets, internal",
+			platform: powervstypes.Platform{VPCName: "vpc-1", VPCSubnets: []string{"subnet-1"}},
+			publish:  types.InternalPublishingStrategy,
+			expected: []quota.Constraint{
+				{Name: "load-balancers", Region: "us-south", Count: 1},
+				{Name: "security-groups", Region: "us-south", Count: 1},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Platform: types.Platform{PowerVS: &tc.platform},
+				Publish:  tc.publish,
+			}
+			assert.Equal(t, tc.expected, VPCConstraints(ic, "us-south"))
+		})
+	}
+}
+
+func TestValidateVPCQuotas(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses map[string]string
+		expected  map[string]quota.ConstraintReportResult
+		errorMsg  string
+	}{
+		{
+			name:      "enough quota",
+			responses: vpcResponses,
+			expected: map[string]quota.ConstraintReportResult{
+				"floating-ips":    quota.Available,
+				"load-balancers":  quota.Available,
+				"security-groups": quota.Available,
+				"subnets":         quota.Available,
+				"vpcs":            quota.Available,
+			},
+		},
+		{
+			name: "no VPC left",
+			responses: map[string]string{
+				"/vpcs": `{"vpcs": [
+					{"id": "vpc-1"}, {"id": "vpc-2"}, {"id": "vpc-3"}, {"id": "vpc-4"}, {"id": "vpc-5"},
+					{"id": "vpc-6"}, {"id": "vpc-7"}, {"id": "vpc-8"}, {"id": "vpc-9"}, {"id": "vpc-10"}
+				]}`,
+				"/load_balancers": `{"load_balancers": []}`,
+				"/floating_ips":   `{"floating_ips": []}`,
+			},
+			expected: map[string]quota.ConstraintReportResult{
+				"floating-ips":    quota.Available,
+				"load-balancers":  quota.Available,
+				"security-groups": quota.Available,
+				"subnets":         quota.Available,
+				"vpcs":            quota.NotAvailable,
+			},
+			errorMsg: `checks failed`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Platform: types.Platform{PowerVS: &powervstypes.Platform{Region: "dal"}},
+				Publish:  types.ExternalPublishingStrategy,
+			}
+			reports, err := ValidateVPCQuotas(context.Background(), newTestClient(t, tc.responses), ic)
+			if tc.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.errorMsg, err)
+			}
+			results := map[string]quota.ConstraintReportResult{}
+			for _, report := range reports {
+				assert.Equal(t, "us-south", report.For.Region)
+				results[report.For.Name] = report.Result
+			}
+			assert.Equal(t, tc.expected, results)
+		})
+	}
+
+	// The quotas are not checked when the VPC region is unknown.
+	ic := &types.InstallConfig{Platform: types.Platform{PowerVS: &powervstypes.Platform{Region: "unknown"}}}
+	reports, err := ValidateVPCQuotas(context.Background(), newTestClient(t, nil), ic)
+	assert.Nil(t, reports)
+	assert.Error(t, err)
+}
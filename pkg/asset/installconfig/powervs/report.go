@@ -0,0 +1,211 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/metrics/instrumentation"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// Severity describes how a failed validation check affects the install.
+type Severity string
+
+const (
+	// SeverityError is a failed check which prevents the install.
+	SeverityError Severity = "Error"
+	// SeverityWarning is a failed check which is reported but does not prevent the install.
+	SeverityWarning Severity = "Warning"
+)
+
+// skipServiceAuthorizationsEnvVar, when set to a non-empty value, reports
+// the missing IAM service authorizations as a warning instead of failing the
+// install, e.g. when the account manages them out of band.
+const skipServiceAuthorizationsEnvVar = "OPENSHIFT_INSTALL_POWERVS_SKIP_SERVICE_AUTHORIZATIONS"
+
+// ValidationResult is the outcome of a single failed validation check.
+type ValidationResult struct {
+	// Check is the name of the validation check.
+	Check string
+	// Severity is the severity of the failure.
+	Severity Severity
+	// Err is the failure reported by the check.
+	Err error
+}
+
+func (r ValidationResult) Error() string {
+	return fmt.Sprintf("%s: %v", r.Check, r.Err)
+}
+
+// ValidationReport collects the results of all of the validation checks,
+// rather than stopping at the first failure.
+type ValidationReport struct {
+	Results []ValidationResult
+}
+
+// add records the result of a check, ignoring checks which passed.
+func (r *ValidationReport) add(check string, severity Severity, err error) {
+	if err == nil {
+		return
+	}
+	r.Results = append(r.Results, ValidationResult{Check: check, Severity: severity, Err: err})
+}
+
+// check runs a check, recording its duration, and adds its result.
+func (r *ValidationReport) check(check string, severity Severity, validate func() error) {
+	r.add(check, severity, timeValidation(check, validate))
+}
+
+// timeValidation runs a validation check and records its duration.
+func timeValidation(check string, validate func() error) error {
+	return instrumentation.Time(instrumentation.KindValidation, "powervs "+check, validate)
+}
+
+// Errors returns the results with error severity.
+func (r *ValidationReport) Errors() []ValidationResult {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the results with warning severity.
+func (r *ValidationReport) Warnings() []ValidationResult {
+	return r.filter(SeverityWarning)
+}
+
+func (r *ValidationReport) filter(severity Severity) []ValidationResult {
+	var results []ValidationResult
+	for _, result := range r.Results {
+		if result.Severity == severity {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// LogWarnings logs every result with warning severity.
+func (r *ValidationReport) LogWarnings() {
+	for _, warning := range r.Warnings() {
+		logrus.Warn(warning.Error())
+	}
+}
+
+// ToAggregate returns an aggregate of all the results with error severity, or
+// nil if there are none.
+func (r *ValidationReport) ToAggregate() error {
+	results := r.Errors()
+	errs := make([]error, 0, len(results))
+	for _, result := range results {
+		errs = append(errs, result)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateAll runs all of the Power VS pre-install checks for the install
+// config and reports every failure. osImage is the location of the RHCOS
+// image, which is imported into the workspace. The checks of the contents of
+// the workspace are skipped when the installer creates the workspace.
+func (c *BxClient) ValidateAll(ctx context.Context, ic *types.InstallConfig, osImage string, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet) *ValidationReport {
+	report := &ValidationReport{}
+	svcInsID := ic.Platform.PowerVS.ServiceInstanceID
+
+	report.check("account permissions", SeverityError, c.ValidateAccountPermissions)
+	report.check("service authorizations", serviceAuthorizationsSeverity(), func() error {
+		return c.ValidateServiceAuthorizations(ctx)
+	})
+
+	if svcInsID == "" {
+		// The workspace is created when the cluster is provisioned, so there
+		// is nothing in it to check yet.
+		logrus.Debugf("Skipping the checks of the Power VS workspace, which is created with the cluster")
+		return report
+	}
+
+	var per bool
+	err := timeValidation("zone capabilities", func() error {
+		var err error
+		per, err = c.IsPERWorkspace(ctx, svcInsID)
+		return err
+	})
+	if err != nil {
+		report.add("zone capabilities", SeverityError, err)
+	} else {
+		// Only check that there isn't an existing Cloud connection if we're not re-using one.
+		// Power Edge Router zones have no Cloud connections and use a Transit Gateway instead.
+		// An existing Transit Gateway may already be attached to the workspace.
+		if ic.Platform.PowerVS.TransitGatewayID != "" {
+			report.check("transit gateway", SeverityError, func() error {
+				return c.ValidateTransitGateway(ctx, svcInsID, ic.Platform.PowerVS.TransitGatewayID, vpcRegion(ic), ic.Platform.PowerVS.VPCName, ic.MachineNetwork)
+			})
+		} else if per || ic.Platform.PowerVS.CloudConnectionName == "" {
+			report.check("network connectivity", SeverityError, func() error {
+				return errors.Wrap(c.ValidateNetworkConnectivityInPowerVSRegion(ctx, svcInsID), "failed to meet the prerequisite for network connectivity")
+			})
+		}
+		if ic.Platform.PowerVS.PVSNetworkName == "" && !per {
+			report.check("DHCP service", SeverityError, func() error {
+				return errors.Wrap(c.ValidateDhcpService(ctx, svcInsID, ic.MachineNetwork), "failed to meet the prerequisite of one DHCP service per Power VS instance")
+			})
+		}
+	}
+
+	report.check("capacity", SeverityError, func() error {
+		return c.ValidateCapacity(ctx, controlPlanes, computes, smtLevels(ic), svcInsID)
+	})
+	report.check("placement", SeverityError, func() error {
+		return c.ValidatePlacement(ctx, ic, controlPlanes, computes)
+	})
+	report.check("image import", SeverityError, func() error {
+		return c.ValidateImageImport(ctx, ic, osImage, controlPlaneImageName(controlPlanes))
+	})
+
+	return report
+}
+
+// serviceAuthorizationsSeverity returns the severity of the missing IAM
+// service authorizations, which fail the install unless the user opts out.
+func serviceAuthorizationsSeverity() Severity {
+	if os.Getenv(skipServiceAuthorizationsEnvVar) != "" {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+// smtLevels returns the SMT levels of the control plane and compute machine
+// pools, or of the default machine platform.
+func smtLevels(ic *types.InstallConfig) SMTLevels {
+	level := func(pool *powervstypes.MachinePool) string {
+		if pool != nil && pool.SMTLevel != "" {
+			return pool.SMTLevel
+		}
+		if ic.Platform.PowerVS.DefaultMachinePlatform != nil {
+			return ic.Platform.PowerVS.DefaultMachinePlatform.SMTLevel
+		}
+		return ""
+	}
+	levels := SMTLevels{ControlPlane: level(nil), Compute: level(nil)}
+	if ic.ControlPlane != nil {
+		levels.ControlPlane = level(ic.ControlPlane.Platform.PowerVS)
+	}
+	for _, pool := range ic.Compute {
+		if pool.Name == types.MachinePoolComputeRoleName {
+			levels.Compute = level(pool.Platform.PowerVS)
+		}
+	}
+	return levels
+}
+
+// vpcRegion returns the region of the VPC of the cluster.
+func vpcRegion(ic *types.InstallConfig) string {
+	if ic.Platform.PowerVS.VPCRegion != "" {
+		return ic.Platform.PowerVS.VPCRegion
+	}
+	// An unknown region is reported by the install config validation.
+	region, _ := powervstypes.VPCRegionForPowerVSRegion(ic.Platform.PowerVS.Region)
+	return region
+}
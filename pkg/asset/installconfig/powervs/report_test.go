@@ -0,0 +1,31 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAuthorizationsSeverity(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      string
+		expected Severity
+	}{
+		{
+			name:     "default",
+			expected: SeverityError,
+		},
+		{
+			name:     "opted out",
+			env:      "1",
+			expected: SeverityWarning,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(skipServiceAuthorizationsEnvVar, tc.env)
+			assert.Equal(t, tc.expected, serviceAuthorizationsSeverity())
+		})
+	}
+}
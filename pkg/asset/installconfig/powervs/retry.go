@@ -0,0 +1,321 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	gohttp "net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/metrics/instrumentation"
+)
+
+const (
+	// retryAttempts is the number of attempts of a request which fails
+	// transiently.
+	retryAttempts = 5
+	// retryBaseDelay is the delay before the first retry. The delay doubles
+	// with each retry, up to retryMaxDelay.
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+	// retryMaxRetryAfter is the longest Retry-After which is waited for. The
+	// response is returned to the caller when the API asks to wait longer.
+	retryMaxRetryAfter = 2 * time.Minute
+
+	// breakerThreshold is the number of consecutive requests to an API which
+	// fail, after their retries, before the breaker opens. While the breaker is
+	// open, the requests to the API fail immediately.
+	breakerThreshold = 3
+	// breakerCooldown is how long the breaker stays open before one request
+	// is let through to probe the API.
+	breakerCooldown = 1 * time.Minute
+
+	// responseHeaderTimeout bounds the wait for the response of an attempt.
+	responseHeaderTimeout = 1 * time.Minute
+)
+
+// apiVersionRx matches the version segments of the API paths, e.g. v1 or v2beta1.
+var apiVersionRx = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+
+// breakers holds the circuit breakers of the IBM Cloud APIs, keyed by host,
+// for the lifetime of the process.
+var breakers = &circuitBreakers{breakers: map[string]*circuitBreaker{}}
+
+// BreakerOpenError is returned for the requests to an IBM Cloud API whose
+// circuit breaker is open after consecutive failures.
+type BreakerOpenError struct {
+	Host     string
+	Failures int
+	LastErr  string
+	Retry    time.Time
+}
+
+func (e *BreakerOpenError) Error() string {
+	return fmt.Sprintf("the IBM Cloud API at %s failed %d consecutive times (last error: %s) and is not called again until %s; check the network connection and proxy of the installer host, and the status of IBM Cloud at https://cloud.ibm.com/status",
+		e.Host, e.Failures, e.LastErr, e.Retry.Format(time.RFC3339))
+}
+
+// circuitBreaker fails the requests to an API fast after consecutive
+// failures, until the cooldown has passed.
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	failures  int
+	lastErr   string
+	openUntil time.Time
+	probing   bool
+}
+
+// allow returns an error if the breaker is open. Once the cooldown has
+// passed, a single request is allowed to probe the API.
+func (b *circuitBreaker) allow(host string, now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.failures < breakerThreshold {
+		return nil
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return &BreakerOpenError{Host: host, Failures: b.failures, LastErr: b.lastErr, Retry: b.openUntil}
+	}
+	b.probing = true
+	return nil
+}
+
+// record records the outcome of a request, opening the breaker when the
+// failures reach the threshold.
+func (b *circuitBreaker) record(failure string, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+	if failure == "" {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	b.lastErr = failure
+	if b.failures >= breakerThreshold {
+		b.openUntil = now.Add(breakerCooldown)
+	}
+}
+
+type circuitBreakers struct {
+	mutex    sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func (c *circuitBreakers) get(host string) *circuitBreaker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	b, ok := c.breakers[host]
+	if !ok {
+		b = &circuitBreaker{}
+		c.breakers[host] = b
+	}
+	return b
+}
+
+func (c *circuitBreakers) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.breakers = map[string]*circuitBreaker{}
+}
+
+// retryTransport retries the requests to the IBM Cloud APIs which fail
+// transiently, with jittered exponential backoff, and fails fast once the
+// circuit breaker of the API is open.
+type retryTransport struct {
+	next     gohttp.RoundTripper
+	breakers *circuitBreakers
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next gohttp.RoundTripper) *retryTransport {
+	return &retryTransport{
+		next:     next,
+		breakers: breakers,
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. The latency of the call, including
+// its retries, is recorded.
+func (t *retryTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	start := time.Now()
+	attempts := 0
+	resp, err := t.roundTrip(req, &attempts)
+
+	attributes := map[string]string{"attempts": strconv.Itoa(attempts)}
+	failure := err
+	if resp != nil {
+		attributes["status"] = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode >= gohttp.StatusBadRequest && failure == nil {
+			failure = errors.New(resp.Status)
+		}
+	}
+	instrumentation.Observe(instrumentation.KindAPICall, apiCallName(req), start, failure, attributes)
+	return resp, err
+}
+
+func (t *retryTransport) roundTrip(req *gohttp.Request, attempts *int) (*gohttp.Response, error) {
+	breaker := t.breakers.get(req.URL.Host)
+	if err := breaker.allow(req.URL.Host, t.now()); err != nil {
+		return nil, err
+	}
+
+	retryable := isRetryableRequest(req)
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		*attempts = attempt
+		resp, err := t.next.RoundTrip(attemptReq)
+		failure := transientFailure(resp, err)
+		if failure == "" || attempt == retryAttempts || !(retryable || isThrottled(resp)) {
+			breaker.record(failure, t.now())
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.now()); ok {
+				if retryAfter > retryMaxRetryAfter {
+					breaker.record(failure, t.now())
+					return resp, err
+				}
+				delay = retryAfter
+			}
+		}
+		nextReq, ok := rewindRequest(req)
+		if !ok {
+			breaker.record(failure, t.now())
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		logrus.Debugf("Retrying %s %s in %s after attempt %d failed: %s", req.Method, req.URL.Redacted(), delay.Round(time.Millisecond), attempt, failure)
+		if err := t.sleep(req.Context(), delay); err != nil {
+			breaker.record(failure, t.now())
+			return nil, err
+		}
+		attemptReq = nextReq
+	}
+}
+
+// apiCallName returns the name of the API call of the request, with the IDs
+// in its path replaced, so that the calls to the same API are summarized
+// together, e.g. "GET power-iaas.cloud.ibm.com/pcloud/v1/cloud-instances/{id}".
+func apiCallName(req *gohttp.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789") && !apiVersionRx.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return fmt.Sprintf("%s %s%s", req.Method, req.URL.Host, strings.Join(segments, "/"))
+}
+
+// isRetryableRequest returns true if the request can be sent again after a
+// failure: it is idempotent, or it requests an IAM token.
+func isRetryableRequest(req *gohttp.Request) bool {
+	switch req.Method {
+	case gohttp.MethodGet, gohttp.MethodHead, gohttp.MethodOptions, gohttp.MethodPut, gohttp.MethodDelete:
+		return true
+	case gohttp.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/identity/token")
+	}
+	return false
+}
+
+// isThrottled returns true if the API rejected the request because of its
+// rate limits. The request was not processed, so it can always be sent again.
+func isThrottled(resp *gohttp.Response) bool {
+	return resp != nil && resp.StatusCode == gohttp.StatusTooManyRequests
+}
+
+// transientFailure describes the failure of an attempt which may succeed if
+// it is retried, or returns "" if the attempt did not fail transiently.
+func transientFailure(resp *gohttp.Response, err error) string {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return ""
+		case errors.As(err, &netErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+			return err.Error()
+		}
+		return ""
+	}
+	switch resp.StatusCode {
+	case gohttp.StatusTooManyRequests, gohttp.StatusInternalServerError, gohttp.StatusBadGateway, gohttp.StatusServiceUnavailable, gohttp.StatusGatewayTimeout:
+		return resp.Status
+	}
+	return ""
+}
+
+// backoff returns the jittered exponential delay before the retry of the
+// attempt: a random delay between half and all of the exponential delay.
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << (attempt - 1)
+	if delay > retryMaxDelay || delay <= 0 {
+		delay = retryMaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)) //nolint:gosec // no security impact
+}
+
+// parseRetryAfter parses the Retry-After header, either in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := gohttp.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// rewindRequest returns a copy of the request to send it again, with a new
+// body, or false if the body cannot be read again.
+func rewindRequest(req *gohttp.Request) (*gohttp.Request, bool) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == gohttp.NoBody {
+		return next, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next.Body = body
+	return next, true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package powervs
+
+import (
+	"context"
+	"errors"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResponse struct {
+	status     int
+	retryAfter string
+}
+
+// fakeAPI serves the responses in order, repeating the last one, and counts
+// the requests.
+func fakeAPI(responses []fakeResponse, requests *int) *httptest.Server {
+	return httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		response := responses[len(responses)-1]
+		if *requests < len(responses) {
+			response = responses[*requests]
+		}
+		*requests++
+		if response.retryAfter != "" {
+			w.Header().Set("Retry-After", response.retryAfter)
+		}
+		w.WriteHeader(response.status)
+	}))
+}
+
+func newTestRetryTransport(now *time.Time, delays *[]time.Duration) *retryTransport {
+	return &retryTransport{
+		next:     gohttp.DefaultTransport,
+		breakers: &circuitBreakers{breakers: map[string]*circuitBreaker{}},
+		now:      func() time.Time { return *now },
+		sleep: func(ctx context.Context, d time.Duration) error {
+			*delays = append(*delays, d)
+			return nil
+		},
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name             string
+		method           string
+		path             string
+		responses        []fakeResponse
+		expectedStatus   int
+		expectedRequests int
+		expectedDelays   []time.Duration
+	}{
+		{
+			name:             "server errors are retried",
+			method:           gohttp.MethodGet,
+			responses:        []fakeResponse{{status: 503}, {status: 502}, {status: 200}},
+			expectedStatus:   200,
+			expectedRequests: 3,
+		},
+		{
+			name:             "retry after is respected",
+			method:           gohttp.MethodGet,
+			responses:        []fakeResponse{{status: 429, retryAfter: "7"}, {status: 200}},
+			expectedStatus:   200,
+			expectedRequests: 2,
+			expectedDelays:   []time.Duration{7 * time.Second},
+		},
+		{
+			name:             "retry after too long",
+			method:           gohttp.MethodGet,
+			responses:        []fakeResponse{{status: 429, retryAfter: "600"}, {status: 200}},
+			expectedStatus:   429,
+			expectedRequests: 1,
+		},
+		{
+			name:             "client errors are not retried",
+			method:           gohttp.MethodGet,
+			responses:        []fakeResponse{{status: 404}, {status: 200}},
+			expectedStatus:   404,
+			expectedRequests: 1,
+		},
+		{
+			name:             "non-idempotent requests are not retried",
+			method:           gohttp.MethodPost,
+			path:             "/pcloud/v1/cloud-instances",
+			responses:        []fakeResponse{{status: 503}, {status: 200}},
+			expectedStatus:   503,
+			expectedRequests: 1,
+		},
+		{
+			name:             "throttled non-idempotent requests are retried",
+			method:           gohttp.MethodPost,
+			path:             "/pcloud/v1/cloud-instances",
+			responses:        []fakeResponse{{status: 429, retryAfter: "1"}, {status: 200}},
+			expectedStatus:   200,
+			expectedRequests: 2,
+			expectedDelays:   []time.Duration{time.Second},
+		},
+		{
+			name:             "IAM token requests are retried",
+			method:           gohttp.MethodPost,
+			path:             "/identity/token",
+			responses:        []fakeResponse{{status: 502}, {status: 200}},
+			expectedStatus:   200,
+			expectedRequests: 2,
+		},
+		{
+			name:             "attempts are exhausted",
+			method:           gohttp.MethodGet,
+			responses:        []fakeResponse{{status: 500}},
+			expectedStatus:   500,
+			expectedRequests: retryAttempts,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := fakeAPI(tc.responses, &requests)
+			defer server.Close()
+
+			now := time.Now()
+			var delays []time.Duration
+			client := &gohttp.Client{Transport: newTestRetryTransport(&now, &delays)}
+			req, err := gohttp.NewRequest(tc.method, server.URL+tc.path, strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			resp.Body.Close()
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedRequests, requests)
+			if tc.expectedDelays != nil {
+				assert.Equal(t, tc.expectedDelays, delays)
+			}
+		})
+	}
+}
+
+func TestRetryTransportCircuitBreaker(t *testing.T) {
+	requests := 0
+	responses := []fakeResponse{{status: 500}}
+	server := fakeAPI(responses, &requests)
+	defer server.Close()
+
+	now := time.Now()
+	var delays []time.Duration
+	client := &gohttp.Client{Transport: newTestRetryTransport(&now, &delays)}
+
+	for i := 0; i < breakerThreshold; i++ {
+		resp, err := client.Get(server.URL)
+		if !assert.NoError(t, err) {
+			return
+		}
+		resp.Body.Close()
+		assert.Equal(t, 500, resp.StatusCode)
+	}
+	assert.Equal(t, breakerThreshold*retryAttempts, requests)
+
+	// The breaker is open, the API is not called.
+	_, err := client.Get(server.URL)
+	var breakerErr *BreakerOpenError
+	assert.True(t, errors.As(err, &breakerErr))
+	assert.Equal(t, breakerThreshold*retryAttempts, requests)
+
+	// After the cooldown, a probe closes the breaker once the API recovers.
+	now = now.Add(breakerCooldown)
+	responses[0].status = 200
+	resp, err := client.Get(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, breakerThreshold*retryAttempts+1, requests)
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := backoff(attempt)
+		exponential := retryBaseDelay << (attempt - 1)
+		if exponential > retryMaxDelay {
+			exponential = retryMaxDelay
+		}
+		assert.True(t, delay >= exponential/2 && delay <= exponential, "attempt %d: delay %s", attempt, delay)
+	}
+}
+
+func TestAPICallName(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://us-south.power-iaas.cloud.ibm.com/pcloud/v1/cloud-instances/2a8d4ac1-5c2f-4a43-9f7c-6b21e6f2a0b9/pvm-instances",
+			expected: "GET us-south.power-iaas.cloud.ibm.com/pcloud/v1/cloud-instances/{id}/pvm-instances",
+		},
+		{
+			url:      "https://us-south.iaas.cloud.ibm.com/v1/vpcs/r006-4b1c7f3e-0d5a-4c6b-a4a2-2f1e0c9d8b7a?version=2023-01-01",
+			expected: "GET us-south.iaas.cloud.ibm.com/v1/vpcs/{id}",
+		},
+		{
+			url:      "https://iam.cloud.ibm.com/identity/token",
+			expected: "GET iam.cloud.ibm.com/identity/token",
+		},
+	}
+	for _, tc := range cases {
+		req, err := gohttp.NewRequest(gohttp.MethodGet, tc.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tc.expected, apiCallName(req))
+	}
+}
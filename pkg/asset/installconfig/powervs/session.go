@@ -8,8 +8,8 @@ import (
 	gohttp "net/http"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	survey "github.com/AlecAivazis/survey/v2"
@@ -26,10 +26,13 @@ import (
 	"github.com/form3tech-oss/jwt-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/intstr"
+	"golang.org/x/sync/errgroup"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machinev1 "github.com/openshift/api/machine/v1"
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/asset/installconfig/credentials"
+	"github.com/openshift/installer/pkg/asset/prompt"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/powervs"
 )
@@ -38,15 +41,38 @@ var (
 	defSessionTimeout   time.Duration = 9000000000000000000.0
 	defRegion                         = "us_south"
 	defaultAuthFilePath               = filepath.Join(os.Getenv("HOME"), ".powervs", "config.json")
+
+	// lookupConcurrency bounds the number of concurrent Power VS API calls.
+	lookupConcurrency = 8
+
+	// idEnvVars is a list of environment variable names containing an IBM Cloud user ID.
+	idEnvVars = []string{"IBMID"}
+	// apiKeyEnvVars is a list of environment variable names containing an IBM Cloud API key.
+	apiKeyEnvVars = []string{"IC_API_KEY", "IBMCLOUD_API_KEY", "BM_API_KEY", "BLUEMIX_API_KEY"}
+	// regionEnvVars is a list of environment variable names containing a Power VS region.
+	regionEnvVars = []string{"IBMCLOUD_REGION", "IC_REGION"}
+	// zoneEnvVars is a list of environment variable names containing a Power VS zone.
+	zoneEnvVars = []string{"IBMCLOUD_ZONE"}
+	// trustedProfileEnvVars is a list of environment variable names containing an IAM trusted profile ID.
+	trustedProfileEnvVars = []string{"IBMCLOUD_TRUSTED_PROFILE"}
+
+	// sessionVarsMutex serializes the gathering of the session variables by
+	// the assets generated concurrently, as it may prompt the user and
+	// writes them to the AuthFile.
+	sessionVarsMutex sync.Mutex
 )
 
 // BxClient is struct which provides bluemix session details
 type BxClient struct {
 	*bxsession.Session
-	APIKey       string
-	PISession    *ibmpisession.IBMPISession
-	User         *User
-	AccountAPIV2 accountv2.Accounts
+	APIKey           string
+	TrustedProfileID string
+	PISession        *ibmpisession.IBMPISession
+	User             *User
+	AccountAPIV2     accountv2.Accounts
+	// ServiceEndpoints are the custom endpoints of the IBM Cloud services
+	// which the clients use instead of the default ones.
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint
 }
 
 // User is struct with user details
@@ -58,10 +84,61 @@ type User struct {
 
 // PISessionVars is an object that holds the variables required to create an ibmpisession object.
 type PISessionVars struct {
-	ID     string `json:"id,omitempty"`
-	APIKey string `json:"apikey,omitempty"`
-	Region string `json:"region,omitempty"`
-	Zone   string `json:"zone,omitempty"`
+	ID               string `json:"id,omitempty"`
+	APIKey           string `json:"apikey,omitempty"`
+	TrustedProfileID string `json:"trustedprofileid,omitempty"`
+	Region           string `json:"region,omitempty"`
+	Zone             string `json:"zone,omitempty"`
+}
+
+// authenticateTrustedProfile obtains an IAM access token for the trusted
+// profile of the compute resource the installer runs on.
+func authenticateTrustedProfile(sess *bxsession.Session, trustedProfileID string, iamURL string) error {
+	authenticator := &core.ContainerAuthenticator{
+		IAMProfileID: trustedProfileID,
+		URL:          iamURL,
+		Client:       newHTTPClient(),
+	}
+	tokenResponse, err := authenticator.RequestToken()
+	if err != nil {
+		return errors.Wrap(err, "failed to authenticate with the trusted profile")
+	}
+	sess.Config.IAMAccessToken = fmt.Sprintf("Bearer %s", tokenResponse.AccessToken)
+	sess.Config.IAMRefreshToken = tokenResponse.RefreshToken
+	return nil
+}
+
+// NewAuthenticator returns the authenticator for the IBM Cloud services. A
+// trusted profile is used when one is given, otherwise the API key. The
+// tokens are requested from iamURL, or from the default IAM endpoint when it
+// is empty.
+func NewAuthenticator(apiKey string, trustedProfileID string, iamURL string) core.Authenticator {
+	if trustedProfileID != "" {
+		return &core.ContainerAuthenticator{
+			IAMProfileID: trustedProfileID,
+			URL:          iamURL,
+			Client:       newHTTPClient(),
+		}
+	}
+	return &core.IamAuthenticator{
+		ApiKey: apiKey,
+		URL:    iamURL,
+		Client: newHTTPClient(),
+	}
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *BxClient) Authenticator() core.Authenticator {
+	return NewAuthenticator(c.APIKey, c.TrustedProfileID, c.ServiceURL(powervs.IAMServiceEndpointName, ""))
+}
+
+// ServiceURL returns the custom endpoint of the service with the name, or
+// defaultURL when the endpoint of the service is not overridden.
+func (c *BxClient) ServiceURL(name string, defaultURL string) string {
+	if u := powervs.ServiceEndpointURL(c.ServiceEndpoints, name); u != "" {
+		return u
+	}
+	return defaultURL
 }
 
 func authenticateAPIKey(sess *bxsession.Session) error {
@@ -70,6 +147,7 @@ func authenticateAPIKey(sess *bxsession.Session) error {
 		DefaultHeader: gohttp.Header{
 			"User-Agent": []string{http.UserAgent()},
 		},
+		HTTPClient: newHTTPClient(),
 	})
 	if err != nil {
 		return err
@@ -105,9 +183,11 @@ func fetchUserDetails(sess *bxsession.Session) (*User, error) {
 	return &user, nil
 }
 
-// NewBxClient func returns bluemix client
-func NewBxClient() (*BxClient, error) {
-	c := &BxClient{}
+// NewBxClient func returns bluemix client, whose clients use the custom
+// endpoints of the services instead of the default ones.
+func NewBxClient(serviceEndpoints []configv1.PowerVSServiceEndpoint) (*BxClient, error) {
+	sessionVarsMutex.Lock()
+	defer sessionVarsMutex.Unlock()
 
 	var pisv PISessionVars
 	// Grab variables from the installer written authFilePath
@@ -116,6 +196,14 @@ func NewBxClient() (*BxClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	saved := pisv
+
+	// Grab variables from the credential helper, which override the AuthFile
+	logrus.Debug("Gathering variables from the credential helper")
+	err = getPISessionVarsFromHelper(&pisv)
+	if err != nil {
+		return nil, err
+	}
 
 	// Grab variables from the users environment
 	logrus.Debug("Gathering variables from user environment")
@@ -125,29 +213,75 @@ func NewBxClient() (*BxClient, error) {
 	}
 
 	// Prompt the user for the remaining variables.
+	gathered := pisv
 	err = getPISessionVarsFromUser(&pisv)
 	if err != nil {
 		return nil, err
 	}
 
-	// Save variables to disk.
-	err = savePISessionVars(&pisv)
+	c, err := newBxClient(&pisv, serviceEndpoints)
 	if err != nil {
 		return nil, err
 	}
 
+	// The zone is prompted for once the client can look up the capacity of
+	// the zones. The zone is prompted for by the survey, before the machine
+	// pools are known, so the capacity is that of the default machine pools.
+	if len(pisv.Zone) == 0 {
+		pisv.Zone, err = c.GetZoneWithCapacity(pisv.Region, defaultInstallConfig())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Save the variables the user was prompted for to disk, with those of
+	// the AuthFile. The variables of the credential helper and of the
+	// environment, such as their API keys, are never written to the AuthFile.
+	if promptedPISessionVars(&saved, &gathered, &pisv) {
+		err = savePISessionVars(&saved)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// newBxClient returns an authenticated client for the session variables.
+// The clients are memoized by credentials, region and endpoints, see
+// ResetCache.
+func newBxClient(pisv *PISessionVars, serviceEndpoints []configv1.PowerVSServiceEndpoint) (*BxClient, error) {
+	cacheKey := fmt.Sprintf("%s/%s/%s/%v", pisv.APIKey, pisv.TrustedProfileID, pisv.Region, serviceEndpoints)
+	if cached, ok := sessions.get(cacheKey); ok {
+		logrus.Debug("Reusing cached IBM Cloud session")
+		return cached, nil
+	}
+
+	c := &BxClient{}
 	c.APIKey = pisv.APIKey
+	c.TrustedProfileID = pisv.TrustedProfileID
+	c.ServiceEndpoints = serviceEndpoints
 
-	bxSess, err := bxsession.New(&bluemix.Config{
+	config := &bluemix.Config{
 		BluemixAPIKey: pisv.APIKey,
-	})
+		HTTPClient:    newHTTPClient(),
+	}
+	iamURL := c.ServiceURL(powervs.IAMServiceEndpointName, "")
+	if iamURL != "" {
+		config.TokenProviderEndpoint = &iamURL
+	}
+	bxSess, err := bxsession.New(config)
 	if err != nil {
 		return nil, err
 	}
 
 	c.Session = bxSess
 
-	err = authenticateAPIKey(bxSess)
+	if c.TrustedProfileID != "" {
+		err = authenticateTrustedProfile(bxSess, c.TrustedProfileID, iamURL)
+	} else {
+		err = authenticateAPIKey(bxSess)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +298,8 @@ func NewBxClient() (*BxClient, error) {
 
 	c.AccountAPIV2 = accClient.Accounts()
 	c.Session.Config.Region = powervs.Regions[pisv.Region].VPCRegion
+
+	sessions.set(cacheKey, c)
 	return c, nil
 }
 
@@ -194,45 +330,76 @@ func (c *BxClient) ValidateDhcpService(ctx context.Context, svcInsID string, mac
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	// Create PowerVS network client
-	networkClient := instance.NewIBMPINetworkClient(ctx, c.PISession, svcInsID)
-
-	// Create PowerVS CloudConnection client
-	cloudConnectionClient := instance.NewIBMPICloudConnectionClient(ctx, c.PISession, svcInsID)
-
-	allCloudConnecitons, err := cloudConnectionClient.GetAll()
+	allCloudConnecitons, err := c.getCloudConnections(ctx, svcInsID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get all existing Cloud Connections")
 	}
 
+	var (
+		mutex      sync.Mutex
+		networkIDs []string
+		cidrs      []string
+	)
+
+	// Unfortunately, the Networks array is not filled in for a GetAll call :(
+	// so the details of every Cloud Connection are fetched.
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(lookupConcurrency)
 	for _, singleCloudConnection := range allCloudConnecitons.CloudConnections {
-		// Unfortunately, the Networks array is not filled in for a GetAll call :(
-		cloudConnection, err := cloudConnectionClient.Get(*singleCloudConnection.CloudConnectionID)
-		if err != nil {
-			return errors.Wrap(err, "failed to get existing Cloud Connection details")
-		}
-		for _, ccNetwork := range cloudConnection.Networks {
-			// The NetworkReference object does not provide subnet CIDRs.
-			// So you have to get the network object based on the ID to find the CIDR.
-			network, err := networkClient.Get(*ccNetwork.NetworkID)
+		cloudConnectionID := *singleCloudConnection.CloudConnectionID
+		g.Go(func() error {
+			cloudConnection, err := c.getCloudConnection(gctx, svcInsID, cloudConnectionID)
 			if err != nil {
-				return errors.Wrap(err, "failed to get CC's network")
+				return errors.Wrap(err, "failed to get existing Cloud Connection details")
 			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, ccNetwork := range cloudConnection.Networks {
+				networkIDs = append(networkIDs, *ccNetwork.NetworkID)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
 
-			_, n1, err := net.ParseCIDR(*network.Cidr)
+	// The NetworkReference object does not provide subnet CIDRs.
+	// So you have to get the network object based on the ID to find the CIDR.
+	g, gctx = errgroup.WithContext(ctx)
+	g.SetLimit(lookupConcurrency)
+	for _, networkID := range networkIDs {
+		networkID := networkID
+		g.Go(func() error {
+			networkClient := instance.NewIBMPINetworkClient(gctx, c.PISession, svcInsID)
+			network, err := networkClient.Get(networkID)
 			if err != nil {
-				return errors.Wrap(err, "failed to parse network.Cidr")
+				return errors.Wrap(err, "failed to get CC's network")
 			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			cidrs = append(cidrs, *network.Cidr)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
 
-			// Check each machineNetwork, typically one
-			for _, machineNetwork := range machineNetworks {
-				_, n2, err := net.ParseCIDR(machineNetwork.CIDR.String())
-				if err != nil {
-					return errors.Wrap(err, "failed to parse machineNetwork.CIDR")
-				}
-				if n2.Contains(n1.IP) || n1.Contains(n2.IP) {
-					return fmt.Errorf("cidr conflicts with existing network")
-				}
+	for _, cidr := range cidrs {
+		_, n1, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse network.Cidr")
+		}
+
+		// Check each machineNetwork, typically one
+		for _, machineNetwork := range machineNetworks {
+			_, n2, err := net.ParseCIDR(machineNetwork.CIDR.String())
+			if err != nil {
+				return errors.Wrap(err, "failed to parse machineNetwork.CIDR")
+			}
+			if n2.Contains(n1.IP) || n1.Contains(n2.IP) {
+				return fmt.Errorf("cidr conflicts with existing network")
 			}
 		}
 	}
@@ -245,10 +412,9 @@ func (c *BxClient) ValidateCloudConnectionInPowerVSRegion(ctx context.Context, s
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 	var cloudConnectionsIDs []string
-	cloudConnectionClient := instance.NewIBMPICloudConnectionClient(ctx, c.PISession, svcInsID)
 
 	//check number of cloudconnections
-	getAllResp, err := cloudConnectionClient.GetAll()
+	getAllResp, err := c.getCloudConnections(ctx, svcInsID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get existing Cloud connection details")
 	}
@@ -263,7 +429,7 @@ func (c *BxClient) ValidateCloudConnectionInPowerVSRegion(ctx context.Context, s
 
 	//check for Cloud connection attached to DHCP Service
 	for _, cc := range cloudConnectionsIDs {
-		cloudConn, err := cloudConnectionClient.Get(cc)
+		cloudConn, err := c.getCloudConnection(ctx, svcInsID, cc)
 		if err != nil {
 			return errors.Wrap(err, "failed to get Cloud connection details")
 		}
@@ -278,36 +444,79 @@ func (c *BxClient) ValidateCloudConnectionInPowerVSRegion(ctx context.Context, s
 	return nil
 }
 
-// GetSystemPools returns the system pools that are in the cloud.
+// getCloudConnections returns all of the cloud connections in the service instance.
+// The result is memoized, see ResetCache.
+func (c *BxClient) getCloudConnections(ctx context.Context, svcInsID string) (*models.CloudConnections, error) {
+	key := fmt.Sprintf("cloudconnections/%s", svcInsID)
+	if cached, ok := responses.get(key); ok {
+		return cached.(*models.CloudConnections), nil
+	}
+
+	cloudConnectionClient := instance.NewIBMPICloudConnectionClient(ctx, c.PISession, svcInsID)
+	cloudConnections, err := cloudConnectionClient.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses.set(key, cloudConnections)
+	return cloudConnections, nil
+}
+
+// getCloudConnection returns the details of a single cloud connection.
+// The result is memoized, see ResetCache.
+func (c *BxClient) getCloudConnection(ctx context.Context, svcInsID string, id string) (*models.CloudConnection, error) {
+	key := fmt.Sprintf("cloudconnection/%s/%s", svcInsID, id)
+	if cached, ok := responses.get(key); ok {
+		return cached.(*models.CloudConnection), nil
+	}
+
+	cloudConnectionClient := instance.NewIBMPICloudConnectionClient(ctx, c.PISession, svcInsID)
+	cloudConnection, err := cloudConnectionClient.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses.set(key, cloudConnection)
+	return cloudConnection, nil
+}
+
+// GetSystemPools returns the system pools that are in the cloud. The pools are
+// memoized, and every caller receives its own copy, see ResetCache.
 func (c *BxClient) GetSystemPools(ctx context.Context, serviceInstanceID string) (models.SystemPools, error) {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
-	systemPoolClient := instance.NewIBMPISystemPoolClient(ctx, c.PISession, serviceInstanceID)
+	key := fmt.Sprintf("systempools/%s", serviceInstanceID)
+	systemPools, ok := responses.get(key)
+	if !ok {
+		systemPoolClient := instance.NewIBMPISystemPoolClient(ctx, c.PISession, serviceInstanceID)
 
-	systemPools, err := systemPoolClient.GetSystemPools()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get system pools")
+		pools, err := systemPoolClient.GetSystemPools()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get system pools")
+		}
+
+		responses.set(key, pools)
+		systemPools = pools
 	}
 
-	return systemPools, nil
+	return copySystemPools(systemPools.(models.SystemPools))
 }
 
 // ValidateCapacityWithPools validates that the VMs created for both the controlPlanes and the
-// computes will fit inside the given systemPools.
-func ValidateCapacityWithPools(controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet, systemPools models.SystemPools) error {
+// computes will fit inside the given systemPools, which must offer their system types, with the
+// cores they consume at their SMT levels.
+func ValidateCapacityWithPools(controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet, systemPools models.SystemPools, smtLevels SMTLevels) error {
 	var (
-		numCompute           int
-		computeSystemType    string
-		computeProcessorType string
-		computeProcessors    float64
-		computeMemoryGiB     int64
-		numWorker            int64
-		workerSystemType     string
-		workerProcessorType  string
-		workerProcessors     float64
-		workerMemoryGiB      int64
-		ok                   bool
+		numCompute        int
+		computeSystemType string
+		computeProcessors float64
+		computeMemoryGiB  int64
+		numWorker         int64
+		workerSystemType  string
+		workerProcessors  float64
+		workerMemoryGiB   int64
+		ok                bool
 	)
 
 	// Find out the control plane master information
@@ -320,16 +529,11 @@ func ValidateCapacityWithPools(controlPlanes []machinev1beta1.Machine, computes
 		}
 	}
 	computeSystemType = ctrplConfigs[0].SystemType
-	computeProcessorType = string(ctrplConfigs[0].ProcessorType)
-	if ctrplConfigs[0].Processors.Type == intstr.Int {
-		computeProcessors = float64(numCompute) * float64(ctrplConfigs[0].Processors.IntVal)
-	} else {
-		cores, err := strconv.ParseFloat(ctrplConfigs[0].Processors.StrVal, 64)
-		if err != nil {
-			return errors.Wrap(err, "failed to convert compute cores to a float")
-		}
-		computeProcessors = float64(numCompute) * cores
+	cores, err := DefaultCapacityModel.WithSMTLevel(smtLevels.ControlPlane).Cores(string(ctrplConfigs[0].ProcessorType), ctrplConfigs[0].Processors)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate the cores of the compute nodes")
 	}
+	computeProcessors = float64(numCompute) * cores
 	computeMemoryGiB = int64(numCompute) * int64(ctrplConfigs[0].MemoryGiB)
 
 	// Find out the worker information
@@ -344,42 +548,28 @@ func ValidateCapacityWithPools(controlPlanes []machinev1beta1.Machine, computes
 		}
 
 		workerSystemType = computeConfigs[i].SystemType
-		workerProcessorType = string(computeConfigs[i].ProcessorType)
-		if computeConfigs[i].Processors.Type == intstr.Int {
-			workerProcessors = float64(computeReplicas[i]) * float64(computeConfigs[0].Processors.IntVal)
-		} else {
-			cores, err := strconv.ParseFloat(computeConfigs[0].Processors.StrVal, 64)
-			if err != nil {
-				return errors.Wrap(err, "failed to convert worker cores to a float")
-			}
-			workerProcessors = float64(computeReplicas[i]) * cores
+		cores, err := DefaultCapacityModel.WithSMTLevel(smtLevels.Compute).Cores(string(computeConfigs[i].ProcessorType), computeConfigs[i].Processors)
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate the cores of the worker nodes")
 		}
+		workerProcessors += float64(computeReplicas[i]) * cores
 		workerMemoryGiB += numWorker * int64(computeConfigs[i].MemoryGiB)
 	}
 
 	// Helpful debug statement to save typing
-	// fmt.Printf("ValidateCapacityWithPools: compute(%v) = {%v, %v, %v, %v}, worker(%v) = {%v, %v, %v, %v}\n", numCompute, computeSystemType, computeProcessorType, computeProcessors, computeMemoryGiB, numWorker, workerSystemType, workerProcessorType, workerProcessors, workerMemoryGiB)
-
-	switch computeProcessorType {
-	case "Dedicated":
-	case "Shared":
-		// @TODO I would think we should reduce the number of cores by some factor.
-		// However, I cannot currently find documentation which describes what
-		// PowerVS uses internally.
-		computeProcessors = 0
-	default:
-		return errors.Errorf("Unknown compute processor type (%v)", computeProcessorType)
-	}
-
-	switch workerProcessorType {
-	case "Dedicated":
-	case "Shared":
-		// @TODO I would think we should reduce the number of cores by some factor.
-		// However, I cannot currently find documentation which describes what
-		// PowerVS uses internally.
-		workerProcessors = 0
-	default:
-		return errors.Errorf("Unknown worker processor type (%v)", workerProcessorType)
+	// fmt.Printf("ValidateCapacityWithPools: compute(%v) = {%v, %v, %v}, worker(%v) = {%v, %v, %v}\n", numCompute, computeSystemType, computeProcessors, computeMemoryGiB, numWorker, workerSystemType, workerProcessors, workerMemoryGiB)
+
+	// The machines of a system type which the workspace does not offer
+	// cannot be created.
+	available := map[string]bool{}
+	for _, systemPool := range systemPools {
+		available[systemPool.Type] = true
+	}
+	if !available[computeSystemType] {
+		return errors.Errorf("The system type %s of the compute nodes is not available in the workspace", computeSystemType)
+	}
+	if len(computes) > 0 && !available[workerSystemType] {
+		return errors.Errorf("The system type %s of the worker nodes is not available in the workspace", workerSystemType)
 	}
 
 	for _, systemPool := range systemPools {
@@ -414,7 +604,7 @@ func ValidateCapacityWithPools(controlPlanes []machinev1beta1.Machine, computes
 }
 
 // ValidateCapacity validates space for processors and storage in the cloud.
-func (c *BxClient) ValidateCapacity(ctx context.Context, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet, serviceInstanceID string) error {
+func (c *BxClient) ValidateCapacity(ctx context.Context, controlPlanes []machinev1beta1.Machine, computes []machinev1beta1.MachineSet, smtLevels SMTLevels, serviceInstanceID string) error {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
@@ -424,7 +614,7 @@ func (c *BxClient) ValidateCapacity(ctx context.Context, controlPlanes []machine
 	}
 
 	// Call another function which we can also test with mock
-	return ValidateCapacityWithPools(controlPlanes, computes, systemPools)
+	return ValidateCapacityWithPools(controlPlanes, computes, systemPools, smtLevels)
 }
 
 // NewPISession updates pisession details, return error on fail
@@ -438,15 +628,12 @@ func (c *BxClient) NewPISession() error {
 		return err
 	}
 
-	var authenticator core.Authenticator = &core.IamAuthenticator{
-		ApiKey: c.APIKey,
-	}
-
 	// Create the session
 	options := &ibmpisession.IBMPIOptions{
-		Authenticator: authenticator,
+		Authenticator: c.Authenticator(),
 		UserAccount:   c.User.Account,
 		Zone:          pisv.Zone,
+		URL:           c.ServiceURL(powervs.PowerServiceEndpointName, ""),
 		Debug:         false,
 	}
 
@@ -454,6 +641,7 @@ func (c *BxClient) NewPISession() error {
 	if err != nil {
 		return err
 	}
+	configurePISession(c.PISession)
 	return nil
 }
 
@@ -490,6 +678,25 @@ func getPISessionVarsFromAuthFile(pisv *PISessionVars) error {
 	return nil
 }
 
+// getPISessionVarsFromHelper sets the variables printed, as the JSON of the
+// AuthFile, by the credential helper named by OPENSHIFT_INSTALL_CREDENTIAL_HELPER.
+func getPISessionVarsFromHelper(pisv *PISessionVars) error {
+
+	if pisv == nil {
+		return errors.New("nil var: PISessionVars")
+	}
+
+	content, err := credentials.HelperSource("powervs").Load(context.TODO())
+	if errors.Is(err, credentials.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get credentials from the credential helper")
+	}
+
+	return json.Unmarshal(content, pisv)
+}
+
 func getPISessionVarsFromEnv(pisv *PISessionVars) error {
 
 	if pisv == nil {
@@ -497,28 +704,74 @@ func getPISessionVarsFromEnv(pisv *PISessionVars) error {
 	}
 
 	if len(pisv.ID) == 0 {
-		pisv.ID = os.Getenv("IBMID")
+		pisv.ID = getEnv(idEnvVars)
 	}
 
 	if len(pisv.APIKey) == 0 {
-		// APIKeyEnvVars is a list of environment variable names containing an IBM Cloud API key.
-		var APIKeyEnvVars = []string{"IC_API_KEY", "IBMCLOUD_API_KEY", "BM_API_KEY", "BLUEMIX_API_KEY"}
-		pisv.APIKey = getEnv(APIKeyEnvVars)
+		pisv.APIKey = getEnv(apiKeyEnvVars)
+	}
+
+	if len(pisv.TrustedProfileID) == 0 {
+		pisv.TrustedProfileID = getEnv(trustedProfileEnvVars)
 	}
 
 	if len(pisv.Region) == 0 {
-		var regionEnvVars = []string{"IBMCLOUD_REGION", "IC_REGION"}
 		pisv.Region = getEnv(regionEnvVars)
 	}
 
 	if len(pisv.Zone) == 0 {
-		var zoneEnvVars = []string{"IBMCLOUD_ZONE"}
 		pisv.Zone = getEnv(zoneEnvVars)
 	}
 
 	return nil
 }
 
+// MissingSessionVar describes a session variable which could not be found.
+type MissingSessionVar struct {
+	// Name is the name of the PISessionVars field.
+	Name string
+	// EnvVars are the environment variables which would satisfy it.
+	EnvVars []string
+}
+
+// MissingSessionVarsError is returned when session variables are missing and
+// the installer is not allowed to prompt for them.
+type MissingSessionVarsError struct {
+	Missing []MissingSessionVar
+}
+
+func (e *MissingSessionVarsError) Error() string {
+	vars := make([]string, 0, len(e.Missing))
+	for _, m := range e.Missing {
+		vars = append(vars, fmt.Sprintf("%s (set one of %s)", m.Name, strings.Join(m.EnvVars, ", ")))
+	}
+	return fmt.Sprintf("missing Power VS session variables in non-interactive mode: %s", strings.Join(vars, "; "))
+}
+
+// missingPISessionVars returns an error listing the unset session variables,
+// or nil if all of them are set.
+func missingPISessionVars(pisv *PISessionVars) error {
+	var missing []MissingSessionVar
+
+	if len(pisv.ID) == 0 {
+		missing = append(missing, MissingSessionVar{Name: "ID", EnvVars: idEnvVars})
+	}
+	if len(pisv.APIKey) == 0 && len(pisv.TrustedProfileID) == 0 {
+		missing = append(missing, MissingSessionVar{Name: "APIKey", EnvVars: append(apiKeyEnvVars, trustedProfileEnvVars...)})
+	}
+	if len(pisv.Region) == 0 {
+		missing = append(missing, MissingSessionVar{Name: "Region", EnvVars: regionEnvVars})
+	}
+	if len(pisv.Zone) == 0 {
+		missing = append(missing, MissingSessionVar{Name: "Zone", EnvVars: zoneEnvVars})
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	return &MissingSessionVarsError{Missing: missing}
+}
+
 func getPISessionVarsFromUser(pisv *PISessionVars) error {
 	var err error
 
@@ -526,6 +779,10 @@ func getPISessionVarsFromUser(pisv *PISessionVars) error {
 		return errors.New("nil var: PiSessionVars")
 	}
 
+	if !prompt.Interactive() {
+		return missingPISessionVars(pisv)
+	}
+
 	if len(pisv.ID) == 0 {
 		err = survey.Ask([]*survey.Question{
 			{
@@ -541,7 +798,7 @@ func getPISessionVarsFromUser(pisv *PISessionVars) error {
 
 	}
 
-	if len(pisv.APIKey) == 0 {
+	if len(pisv.APIKey) == 0 && len(pisv.TrustedProfileID) == 0 {
 		err = survey.Ask([]*survey.Question{
 			{
 				Prompt: &survey.Password{
@@ -564,14 +821,30 @@ func getPISessionVarsFromUser(pisv *PISessionVars) error {
 
 	}
 
-	if len(pisv.Zone) == 0 {
-		pisv.Zone, err = GetZone(pisv.Region)
-		if err != nil {
-			return err
+	return nil
+}
+
+// promptedPISessionVars sets the variables of saved which the user was
+// prompted for, those unset in gathered and set in prompted, and returns
+// whether there were any.
+func promptedPISessionVars(saved, gathered, prompted *PISessionVars) bool {
+	changed := false
+	for _, v := range []struct {
+		saved              *string
+		gathered, prompted string
+	}{
+		{&saved.ID, gathered.ID, prompted.ID},
+		{&saved.APIKey, gathered.APIKey, prompted.APIKey},
+		{&saved.TrustedProfileID, gathered.TrustedProfileID, prompted.TrustedProfileID},
+		{&saved.Region, gathered.Region, prompted.Region},
+		{&saved.Zone, gathered.Zone, prompted.Zone},
+	} {
+		if len(v.gathered) == 0 && len(v.prompted) > 0 {
+			*v.saved = v.prompted
+			changed = true
 		}
 	}
-
-	return nil
+	return changed
 }
 
 func savePISessionVars(pisv *PISessionVars) error {
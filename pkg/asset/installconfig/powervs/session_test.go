@@ -0,0 +1,222 @@
+package powervs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthenticator(t *testing.T) {
+	cases := []struct {
+		name             string
+		apiKey           string
+		trustedProfileID string
+		iamURL           string
+		expected         core.Authenticator
+	}{
+		{
+			name:     "API key",
+			apiKey:   "api-key",
+			expected: &core.IamAuthenticator{ApiKey: "api-key"},
+		},
+		{
+			name:     "API key and IAM endpoint",
+			apiKey:   "api-key",
+			iamURL:   "https://iam.example.com",
+			expected: &core.IamAuthenticator{ApiKey: "api-key", URL: "https://iam.example.com"},
+		},
+		{
+			name:             "trusted profile",
+			trustedProfileID: "profile-id",
+			iamURL:           "https://iam.example.com",
+			expected:         &core.ContainerAuthenticator{IAMProfileID: "profile-id", URL: "https://iam.example.com"},
+		},
+		{
+			name:             "trusted profile over API key",
+			apiKey:           "api-key",
+			trustedProfileID: "profile-id",
+			expected:         &core.ContainerAuthenticator{IAMProfileID: "profile-id"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator := NewAuthenticator(tc.apiKey, tc.trustedProfileID, tc.iamURL)
+			switch a := authenticator.(type) {
+			case *core.IamAuthenticator:
+				assert.NotNil(t, a.Client)
+				a.Client = nil
+			case *core.ContainerAuthenticator:
+				assert.NotNil(t, a.Client)
+				a.Client = nil
+			}
+			assert.Equal(t, tc.expected, authenticator)
+		})
+	}
+}
+
+func TestGetPISessionVarsTrustedProfile(t *testing.T) {
+	cases := []struct {
+		name     string
+		authFile string
+		env      string
+		expected string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:     "from the environment",
+			env:      "env-profile",
+			expected: "env-profile",
+		},
+		{
+			name:     "from the auth file",
+			authFile: `{"id": "user", "trustedprofileid": "file-profile"}`,
+			expected: "file-profile",
+		},
+		{
+			name:     "auth file over the environment",
+			authFile: `{"id": "user", "trustedprofileid": "file-profile"}`,
+			env:      "env-profile",
+			expected: "file-profile",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			authFilePath := filepath.Join(t.TempDir(), "config.json")
+			t.Setenv("POWERVS_AUTH_FILEPATH", authFilePath)
+			t.Setenv("IBMCLOUD_TRUSTED_PROFILE", tc.env)
+			if tc.authFile != "" {
+				if err := os.WriteFile(authFilePath, []byte(tc.authFile), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var pisv PISessionVars
+			assert.NoError(t, getPISessionVarsFromAuthFile(&pisv))
+			assert.NoError(t, getPISessionVarsFromEnv(&pisv))
+			assert.Equal(t, tc.expected, pisv.TrustedProfileID)
+		})
+	}
+}
+
+func TestMissingPISessionVars(t *testing.T) {
+	cases := []struct {
+		name     string
+		pisv     PISessionVars
+		expected string
+	}{
+		{
+			name: "API key",
+			pisv: PISessionVars{ID: "user", APIKey: "api-key", Region: "dal", Zone: "dal10"},
+		},
+		{
+			name: "trusted profile",
+			pisv: PISessionVars{ID: "user", TrustedProfileID: "profile-id", Region: "dal", Zone: "dal10"},
+		},
+		{
+			name:     "no credentials",
+			pisv:     PISessionVars{ID: "user", Region: "dal", Zone: "dal10"},
+			expected: `^missing Power VS session variables in non-interactive mode: APIKey \(set one of IC_API_KEY, IBMCLOUD_API_KEY, BM_API_KEY, BLUEMIX_API_KEY, IBMCLOUD_TRUSTED_PROFILE\)$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := missingPISessionVars(&tc.pisv)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestPromptedPISessionVars(t *testing.T) {
+	cases := []struct {
+		name     string
+		authFile string
+		helper   string
+		env      map[string]string
+		prompted PISessionVars
+		expected string
+	}{
+		{
+			name:     "nothing prompted",
+			authFile: `{"id": "user", "apikey": "file-key", "region": "dal", "zone": "dal10"}`,
+			expected: `{"id": "user", "apikey": "file-key", "region": "dal", "zone": "dal10"}`,
+		},
+		{
+			name:     "all prompted",
+			prompted: PISessionVars{ID: "user", APIKey: "typed-key", Region: "dal", Zone: "dal10"},
+			expected: `{"id": "user", "apikey": "typed-key", "region": "dal", "zone": "dal10"}`,
+		},
+		{
+			name:     "API key from the environment",
+			env:      map[string]string{"IC_API_KEY": "env-key"},
+			prompted: PISessionVars{ID: "user", Region: "dal", Zone: "dal10"},
+			expected: `{"id": "user", "region": "dal", "zone": "dal10"}`,
+		},
+		{
+			name:     "API key from the credential helper",
+			authFile: `{"id": "user", "region": "dal"}`,
+			helper:   `{"apikey": "helper-key"}`,
+			prompted: PISessionVars{Zone: "dal10"},
+			expected: `{"id": "user", "region": "dal", "zone": "dal10"}`,
+		},
+		{
+			name:     "zone from the environment",
+			authFile: `{"id": "user", "apikey": "file-key"}`,
+			env:      map[string]string{"IBMCLOUD_REGION": "dal", "IBMCLOUD_ZONE": "dal10"},
+			expected: `{"id": "user", "apikey": "file-key"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			authFilePath := filepath.Join(t.TempDir(), "config.json")
+			t.Setenv("POWERVS_AUTH_FILEPATH", authFilePath)
+			for _, envs := range [][]string{idEnvVars, apiKeyEnvVars, trustedProfileEnvVars, regionEnvVars, zoneEnvVars} {
+				for _, k := range envs {
+					t.Setenv(k, tc.env[k])
+				}
+			}
+			if tc.authFile != "" {
+				if err := os.WriteFile(authFilePath, []byte(tc.authFile), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var pisv PISessionVars
+			assert.NoError(t, getPISessionVarsFromAuthFile(&pisv))
+			saved := pisv
+			if tc.helper != "" {
+				assert.NoError(t, json.Unmarshal([]byte(tc.helper), &pisv))
+			}
+			assert.NoError(t, getPISessionVarsFromEnv(&pisv))
+			gathered := pisv
+			prompt := func(field *string, value string) {
+				if len(*field) == 0 {
+					*field = value
+				}
+			}
+			prompt(&pisv.ID, tc.prompted.ID)
+			prompt(&pisv.APIKey, tc.prompted.APIKey)
+			prompt(&pisv.Region, tc.prompted.Region)
+			prompt(&pisv.Zone, tc.prompted.Zone)
+
+			if promptedPISessionVars(&saved, &gathered, &pisv) {
+				assert.NoError(t, savePISessionVars(&saved))
+			}
+			data, err := os.ReadFile(authFilePath)
+			if tc.expected == "" {
+				assert.True(t, os.IsNotExist(err), "auth file written")
+			} else if assert.NoError(t, err) {
+				assert.JSONEq(t, tc.expected, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package powervs
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// getSSHKeyNames returns the names of the SSH keys of the workspace.
+func (c *BxClient) getSSHKeyNames(ctx context.Context, serviceInstanceID string) (sets.String, error) {
+	keyClient := instance.NewIBMPIKeyClient(ctx, c.PISession, serviceInstanceID)
+	keys, err := keyClient.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	names := sets.NewString()
+	for _, key := range keys.SSHKeys {
+		if key.Name != nil {
+			names.Insert(*key.Name)
+		}
+	}
+	return names, nil
+}
+
+// ResolveSSHKeyName returns the name of the SSH key of the machines of a
+// pool naming the SSH key name: the key itself when it exists in the
+// workspace, and otherwise the key named after the infra ID, into which the
+// sshKey of the install config is uploaded when the cluster is provisioned.
+// A workspace created by the installer has no keys yet, so it is not looked
+// up.
+func ResolveSSHKeyName(ctx context.Context, ic *types.InstallConfig, infraID string, name string) (string, error) {
+	existing := sets.NewString()
+	if workspace := ic.Platform.PowerVS.ServiceInstanceID; workspace != "" && name != powervstypes.SSHKeyName(infraID) {
+		client, err := NewBxClient(ic.Platform.PowerVS.ServiceEndpoints)
+		if err != nil {
+			return "", err
+		}
+		if err := client.NewPISession(); err != nil {
+			return "", errors.Wrap(err, "failed to get PowerVS connection details")
+		}
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+		if existing, err = client.getSSHKeyNames(ctx, workspace); err != nil {
+			return "", errors.Wrap(err, "failed to list the SSH keys of the workspace")
+		}
+	}
+	return resolveSSHKeyName(ic, infraID, name, existing)
+}
+
+// resolveSSHKeyName returns the SSH key name when it is one of the existing
+// keys, and otherwise the key named after the infra ID.
+func resolveSSHKeyName(ic *types.InstallConfig, infraID string, name string, existing sets.String) (string, error) {
+	keyName := powervstypes.SSHKeyName(infraID)
+	if existing.Has(name) || name == keyName {
+		return name, nil
+	}
+	workspace := ic.Platform.PowerVS.ServiceInstanceID
+	if workspace == "" {
+		workspace = powervstypes.WorkspaceName(infraID)
+	}
+	if ic.SSHKey == "" {
+		return "", errors.Errorf("the SSH key %s does not exist in workspace %s, and the install config has no sshKey to upload instead", name, workspace)
+	}
+	logrus.Warnf("The SSH key %s does not exist in workspace %s, the sshKey of the install config is uploaded as %s instead", name, workspace, keyName)
+	return keyName, nil
+}
@@ -0,0 +1,76 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestResolveSSHKeyName(t *testing.T) {
+	cases := []struct {
+		name      string
+		sshKey    string
+		workspace string
+		keyName   string
+		expected  string
+		errorMsg  string
+	}{
+		{
+			name:      "existing key",
+			workspace: "workspace-id",
+			keyName:   "admin-key",
+			expected:  "admin-key",
+		},
+		{
+			name:      "installer key",
+			workspace: "workspace-id",
+			keyName:   "test-cluster-a1b2c-key",
+			expected:  "test-cluster-a1b2c-key",
+		},
+		{
+			name:      "missing key uploaded",
+			sshKey:    "ssh-ed25519 AAAA",
+			workspace: "workspace-id",
+			keyName:   "missing-key",
+			expected:  "test-cluster-a1b2c-key",
+		},
+		{
+			name:      "missing key without install config key",
+			workspace: "workspace-id",
+			keyName:   "missing-key",
+			errorMsg:  "the SSH key missing-key does not exist in workspace workspace-id, and the install config has no sshKey to upload instead",
+		},
+		{
+			name:     "workspace created by the installer",
+			keyName:  "admin-key",
+			errorMsg: "the SSH key admin-key does not exist in workspace test-cluster-a1b2c-power-iaas, and the install config has no sshKey to upload instead",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				SSHKey: tc.sshKey,
+				Platform: types.Platform{
+					PowerVS: &powervstypes.Platform{ServiceInstanceID: tc.workspace},
+				},
+			}
+			existing := sets.NewString()
+			if tc.workspace != "" {
+				existing.Insert("admin-key", "ops-key")
+			}
+
+			name, err := resolveSSHKeyName(ic, "test-cluster-a1b2c", tc.keyName, existing)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
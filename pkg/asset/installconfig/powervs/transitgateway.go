@@ -0,0 +1,304 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/bluemix-go/crn"
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+const (
+	// transitGatewayURL is the endpoint of the global Transit Gateway API.
+	transitGatewayURL = "https://transit.cloud.ibm.com/v1"
+	// transitGatewayAPIVersion is the API version date sent on every request.
+	transitGatewayAPIVersion = "2021-12-30"
+
+	// perCapability is the workspace capability advertised by zones which use
+	// Power Edge Router instead of Cloud Connections.
+	perCapability = "power-edge-router"
+
+	// transitGatewayConnectionLimit is the default maximum number of
+	// connections of a Transit Gateway.
+	transitGatewayConnectionLimit = 25
+	// transitGatewayClusterConnections is the number of connections the
+	// installer adds to a Transit Gateway: one for the workspace and one for
+	// the VPC.
+	transitGatewayClusterConnections = 2
+)
+
+// TransitGateway is the subset of a Transit Gateway used by the installer.
+type TransitGateway struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Status   string `json:"status"`
+	// Global is true if the gateway routes between regions.
+	Global bool `json:"global"`
+}
+
+// TransitGatewayConnection is the subset of a Transit Gateway connection used
+// by the installer.
+type TransitGatewayConnection struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	NetworkType string `json:"network_type"`
+	NetworkID   string `json:"network_id"`
+	Status      string `json:"status"`
+}
+
+// IsPERWorkspace returns true if the workspace is in a zone which uses Power
+// Edge Router, and therefore has no Cloud Connections.
+func (c *BxClient) IsPERWorkspace(ctx context.Context, svcInsID string) (bool, error) {
+	key := fmt.Sprintf("capabilities/%s", svcInsID)
+	if cached, ok := responses.get(key); ok {
+		return cached.(bool), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	cloudInstanceClient := instance.NewIBMPICloudInstanceClient(ctx, c.PISession, svcInsID)
+	cloudInstance, err := cloudInstanceClient.Get(svcInsID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get workspace capabilities")
+	}
+
+	per := false
+	for _, capability := range cloudInstance.Capabilities {
+		if capability == perCapability {
+			per = true
+			break
+		}
+	}
+
+	responses.set(key, per)
+	return per, nil
+}
+
+// ValidateNetworkConnectivityInPowerVSRegion validates the prerequisites for
+// connecting the workspace to the VPC. Workspaces in Power Edge Router zones are
+// attached through a Transit Gateway, all others through a Cloud Connection.
+func (c *BxClient) ValidateNetworkConnectivityInPowerVSRegion(ctx context.Context, svcInsID string) error {
+	per, err := c.IsPERWorkspace(ctx, svcInsID)
+	if err != nil {
+		return err
+	}
+	if per {
+		return c.ValidatePERInPowerVSRegion(ctx, svcInsID)
+	}
+	return c.ValidateCloudConnectionInPowerVSRegion(ctx, svcInsID)
+}
+
+// ValidatePERInPowerVSRegion checks that the Transit Gateway API is reachable
+// and that the workspace is not already attached to a Transit Gateway, since the
+// installer attaches it to the one it creates.
+func (c *BxClient) ValidatePERInPowerVSRegion(ctx context.Context, svcInsID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	gateways, err := c.ListTransitGateways(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list Transit Gateways")
+	}
+
+	for _, gateway := range gateways {
+		connections, err := c.ListTransitGatewayConnections(ctx, gateway.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list connections of Transit Gateway %s", gateway.Name)
+		}
+		for _, connection := range connections {
+			if connection.NetworkType == "power_virtual_server" && strings.Contains(connection.NetworkID, svcInsID) {
+				return fmt.Errorf("the Power VS workspace is already attached to Transit Gateway %s", gateway.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateTransitGateway checks that an existing Transit Gateway can connect
+// the workspace and the VPC of the cluster. The gateway must be available, be
+// in the VPC region unless it routes globally, have room for the connections
+// of the cluster and not be attached to another VPC whose address prefixes
+// overlap the machine networks.
+func (c *BxClient) ValidateTransitGateway(ctx context.Context, svcInsID string, gatewayID string, vpcRegion string, vpcName string, machineNetworks []types.MachineNetworkEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	gateway, err := c.GetTransitGateway(ctx, gatewayID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get Transit Gateway %s", gatewayID)
+	}
+	if gateway.Status != "available" {
+		return fmt.Errorf("the Transit Gateway %s is %s, not available", gateway.Name, gateway.Status)
+	}
+	if !gateway.Global && gateway.Location != vpcRegion {
+		return fmt.Errorf("the Transit Gateway %s is in %s, but the VPC region is %s and the gateway does not route globally", gateway.Name, gateway.Location, vpcRegion)
+	}
+
+	connections, err := c.ListTransitGatewayConnections(ctx, gateway.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list connections of Transit Gateway %s", gateway.Name)
+	}
+
+	needed := transitGatewayClusterConnections
+	for _, connection := range connections {
+		switch connection.NetworkType {
+		case "power_virtual_server":
+			if strings.Contains(connection.NetworkID, svcInsID) {
+				needed--
+			}
+		case "vpc":
+			name, prefixes, err := c.getVPCAddressPrefixes(ctx, connection.NetworkID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the VPC of connection %s of Transit Gateway %s", connection.Name, gateway.Name)
+			}
+			if vpcName != "" && name == vpcName {
+				needed--
+				continue
+			}
+			if err := checkPrefixOverlap(prefixes, machineNetworks); err != nil {
+				return errors.Wrapf(err, "the Transit Gateway %s is attached to conflicting VPC %s", gateway.Name, name)
+			}
+		}
+	}
+
+	if len(connections)+needed > transitGatewayConnectionLimit {
+		return fmt.Errorf("the Transit Gateway %s has %d connections, which leaves no room for the %d connections of the cluster", gateway.Name, len(connections), needed)
+	}
+	return nil
+}
+
+// checkPrefixOverlap returns an error if any of the address prefixes
+// overlaps any of the machine networks.
+func checkPrefixOverlap(prefixes []string, machineNetworks []types.MachineNetworkEntry) error {
+	for _, prefix := range prefixes {
+		_, prefixNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse address prefix %s", prefix)
+		}
+		for _, machineNetwork := range machineNetworks {
+			machineNet := machineNetwork.CIDR.IPNet
+			if prefixNet.Contains(machineNet.IP) || machineNet.Contains(prefixNet.IP) {
+				return fmt.Errorf("address prefix %s overlaps the machine network %s", prefix, machineNetwork.CIDR.String())
+			}
+		}
+	}
+	return nil
+}
+
+// getVPCAddressPrefixes returns the name and the address prefixes of the VPC
+// with the CRN.
+func (c *BxClient) getVPCAddressPrefixes(ctx context.Context, vpcCRN string) (string, []string, error) {
+	parsed, err := crn.Parse(vpcCRN)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to parse VPC CRN %s", vpcCRN)
+	}
+	vpcID := parsed.Resource
+
+	vpcService, err := c.vpcService(parsed.Region)
+	if err != nil {
+		return "", nil, err
+	}
+
+	vpc, _, err := vpcService.GetVPCWithContext(ctx, vpcService.NewGetVPCOptions(vpcID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var prefixes []string
+	options := vpcService.NewListVPCAddressPrefixesOptions(vpcID)
+	for {
+		collection, _, err := vpcService.ListVPCAddressPrefixesWithContext(ctx, options)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, prefix := range collection.AddressPrefixes {
+			if prefix.CIDR != nil {
+				prefixes = append(prefixes, *prefix.CIDR)
+			}
+		}
+		start, err := collection.GetNextStart()
+		if err != nil || start == nil {
+			break
+		}
+		options.SetStart(*start)
+	}
+
+	name := ""
+	if vpc.Name != nil {
+		name = *vpc.Name
+	}
+	return name, prefixes, nil
+}
+
+// GetTransitGateway returns the Transit Gateway with the ID.
+func (c *BxClient) GetTransitGateway(ctx context.Context, gatewayID string) (*TransitGateway, error) {
+	var result TransitGateway
+	pathParams := map[string]string{"id": gatewayID}
+	if err := c.transitGatewayGet(ctx, "/transit_gateways/{id}", pathParams, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTransitGateways returns all of the Transit Gateways in the account.
+func (c *BxClient) ListTransitGateways(ctx context.Context) ([]TransitGateway, error) {
+	var result struct {
+		TransitGateways []TransitGateway `json:"transit_gateways"`
+	}
+	if err := c.transitGatewayGet(ctx, "/transit_gateways", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.TransitGateways, nil
+}
+
+// ListTransitGatewayConnections returns the connections of a Transit Gateway.
+func (c *BxClient) ListTransitGatewayConnections(ctx context.Context, gatewayID string) ([]TransitGatewayConnection, error) {
+	var result struct {
+		Connections []TransitGatewayConnection `json:"connections"`
+	}
+	pathParams := map[string]string{"transit_gateway_id": gatewayID}
+	if err := c.transitGatewayGet(ctx, "/transit_gateways/{transit_gateway_id}/connections", pathParams, &result); err != nil {
+		return nil, err
+	}
+	return result.Connections, nil
+}
+
+// transitGatewayGet issues a GET request against the Transit Gateway API and
+// decodes the JSON response into result.
+func (c *BxClient) transitGatewayGet(ctx context.Context, path string, pathParams map[string]string, result interface{}) error {
+	service, err := core.NewBaseService(&core.ServiceOptions{
+		URL:           c.ServiceURL(powervs.TransitGatewayServiceEndpointName, transitGatewayURL),
+		Authenticator: c.Authenticator(),
+	})
+	if err != nil {
+		return err
+	}
+	configureService(service)
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	if _, err = builder.ResolveRequestURL(service.GetServiceURL(), path, pathParams); err != nil {
+		return err
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddQuery("version", transitGatewayAPIVersion)
+
+	request, err := builder.Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = service.Request(request, result)
+	return err
+}
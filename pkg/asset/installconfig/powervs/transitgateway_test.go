@@ -0,0 +1,227 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/stretchr/testify/assert"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// testAPI is a fake of the IBM Cloud APIs, which answers the requests for
+// the paths with their JSON responses, and the other requests with a 404
+// error. The pages after the first one of a collection are keyed by their
+// path and start, e.g. /vpcs?start=page-2. It also issues the IAM tokens.
+type testAPI struct {
+	mutex     sync.Mutex
+	responses map[string]string
+	// requests counts the requests for each path.
+	requests map[string]int
+}
+
+func (a *testAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	path := r.URL.Path
+	if start := r.URL.Query().Get("start"); start != "" {
+		path += "?start=" + start
+	}
+	a.requests[path]++
+
+	w.Header().Set("Content-Type", "application/json")
+	if path == "/identity/token" {
+		fmt.Fprintf(w, `{"access_token": "token", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600, "expiration": %d}`, time.Now().Add(time.Hour).Unix())
+		return
+	}
+	response, ok := a.responses[path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"code": "not_found", "message": "not found"}]}`))
+		return
+	}
+	w.Write([]byte(response))
+}
+
+// newTestAPI starts a fake of the IBM Cloud APIs answering with the
+// responses, and returns it with the endpoints of all the services at it.
+func newTestAPI(t *testing.T, responses map[string]string) (*testAPI, []configv1.PowerVSServiceEndpoint) {
+	api := &testAPI{responses: responses, requests: map[string]int{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	t.Cleanup(ResetCache)
+
+	endpoints := make([]configv1.PowerVSServiceEndpoint, 0, len(powervstypes.ServiceEndpointNames))
+	for _, name := range powervstypes.ServiceEndpointNames {
+		endpoints = append(endpoints, configv1.PowerVSServiceEndpoint{Name: name, URL: server.URL})
+	}
+	return api, endpoints
+}
+
+// newTestBxClient returns a client whose IBM Cloud services are all served
+// by a fake of their APIs answering with the responses.
+func newTestBxClient(t *testing.T, responses map[string]string) (*BxClient, *testAPI) {
+	api, endpoints := newTestAPI(t, responses)
+	client := &BxClient{
+		APIKey:           "api-key",
+		User:             &User{Account: "account"},
+		ServiceEndpoints: endpoints,
+	}
+	piSession, err := ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+		Authenticator: client.Authenticator(),
+		UserAccount:   client.User.Account,
+		Zone:          "dal10",
+		URL:           client.ServiceURL(powervstypes.PowerServiceEndpointName, ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.PISession = piSession
+	return client, api
+}
+
+func TestIsPERWorkspace(t *testing.T) {
+	cases := []struct {
+		name         string
+		capabilities string
+		expected     bool
+	}{
+		{
+			name:         "Power Edge Router zone",
+			capabilities: `["cloud-connections", "power-edge-router", "vpn-connections"]`,
+			expected:     true,
+		},
+		{
+			name:         "Cloud Connection zone",
+			capabilities: `["cloud-connections", "vpn-connections"]`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := "/pcloud/v1/cloud-instances/workspace-id"
+			client, api := newTestBxClient(t, map[string]string{
+				path: fmt.Sprintf(`{"capabilities": %s}`, tc.capabilities),
+			})
+			for i := 0; i < 2; i++ {
+				per, err := client.IsPERWorkspace(context.Background(), "workspace-id")
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, per)
+			}
+			assert.Equal(t, 1, api.requests[path], "the capabilities are cached")
+		})
+	}
+
+	client, _ := newTestBxClient(t, nil)
+	_, err := client.IsPERWorkspace(context.Background(), "missing-id")
+	assert.Regexp(t, `^failed to get workspace capabilities: `, err)
+}
+
+func TestValidatePERInPowerVSRegion(t *testing.T) {
+	const gateways = `{"transit_gateways": [{"id": "gateway-1", "name": "gateway-1"}, {"id": "gateway-2", "name": "gateway-2"}]}`
+	cases := []struct {
+		name      string
+		responses map[string]string
+		errorMsg  string
+	}{
+		{
+			name: "no Transit Gateways",
+			responses: map[string]string{
+				"/transit_gateways": `{"transit_gateways": []}`,
+			},
+		},
+		{
+			name: "workspace not attached",
+			responses: map[string]string{
+				"/transit_gateways":                       gateways,
+				"/transit_gateways/gateway-1/connections": `{"connections": [{"id": "connection-1", "name": "vpc", "network_type": "vpc", "network_id": "crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-id"}]}`,
+				"/transit_gateways/gateway-2/connections": `{"connections": [{"id": "connection-2", "name": "other", "network_type": "power_virtual_server", "network_id": "crn:v1:bluemix:public:power-iaas:dal10:a/account:other-id::"}]}`,
+			},
+		},
+		{
+			name: "workspace attached",
+			responses: map[string]string{
+				"/transit_gateways":                       gateways,
+				"/transit_gateways/gateway-1/connections": `{"connections": []}`,
+				"/transit_gateways/gateway-2/connections": `{"connections": [{"id": "connection-2", "name": "workspace", "network_type": "power_virtual_server", "network_id": "crn:v1:bluemix:public:power-iaas:dal10:a/account:workspace-id::"}]}`,
+			},
+			errorMsg: `^the Power VS workspace is already attached to Transit Gateway gateway-2$`,
+		},
+		{
+			name: "connections failing",
+			responses: map[string]string{
+				"/transit_gateways": gateways,
+			},
+			errorMsg: `^failed to list connections of Transit Gateway gateway-1: `,
+		},
+		{
+			name:     "Transit Gateways failing",
+			errorMsg: `^failed to list Transit Gateways: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newTestBxClient(t, tc.responses)
+			err := client.ValidatePERInPowerVSRegion(context.Background(), "workspace-id")
+			if tc.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestCheckPrefixOverlap(t *testing.T) {
+	machineNetworks := []types.MachineNetworkEntry{
+		{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")},
+	}
+
+	cases := []struct {
+		name     string
+		prefixes []string
+		errorMsg string
+	}{
+		{
+			name:     "no prefixes",
+			prefixes: nil,
+		},
+		{
+			name:     "disjoint prefixes",
+			prefixes: []string{"10.1.0.0/16", "192.168.0.0/24"},
+		},
+		{
+			name:     "prefix inside the machine network",
+			prefixes: []string{"10.1.0.0/16", "10.0.128.0/24"},
+			errorMsg: "address prefix 10.0.128.0/24 overlaps the machine network 10.0.0.0/16",
+		},
+		{
+			name:     "prefix containing the machine network",
+			prefixes: []string{"10.0.0.0/8"},
+			errorMsg: "address prefix 10.0.0.0/8 overlaps the machine network 10.0.0.0/16",
+		},
+		{
+			name:     "invalid prefix",
+			prefixes: []string{"10.0.0.0"},
+			errorMsg: "failed to parse address prefix 10.0.0.0: invalid CIDR address: 10.0.0.0",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPrefixOverlap(tc.prefixes, machineNetworks)
+			if tc.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
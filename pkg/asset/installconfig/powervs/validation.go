@@ -3,7 +3,9 @@ package powervs
 import (
 	"context"
 	"fmt"
+	"net"
 
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -40,8 +42,19 @@ func validateMachinePool(fldPath *field.Path, machinePool *types.MachinePool) fi
 // ValidatePreExistingDNS ensures no pre-existing DNS record exists in the CIS
 // DNS zone or IBM DNS zone for cluster's Kubernetes API.
 func ValidatePreExistingDNS(client API, ic *types.InstallConfig, metadata MetadataAPI) error {
+	return timeValidation("pre-existing DNS", func() error {
+		return validatePreExistingDNS(client, ic, metadata)
+	})
+}
+
+func validatePreExistingDNS(client API, ic *types.InstallConfig, metadata MetadataAPI) error {
 	allErrs := field.ErrorList{}
 
+	// The api records already point to a user-managed load balancer.
+	if ic.UserManagedCloudLoadBalancer() {
+		return nil
+	}
+
 	fldPath := field.NewPath("baseDomain")
 	if ic.Publish == types.ExternalPublishingStrategy {
 		allErrs = append(allErrs, validatePreExistingPublicDNS(fldPath, client, ic, metadata)...)
@@ -90,10 +103,22 @@ func validatePreExistingPrivateDNS(fldPath *field.Path, client API, ic *types.In
 		return append(allErrs, field.InternalError(fldPath, err))
 	}
 
-	// Get CIS zone ID by name
-	zoneID, err := client.GetDNSZoneIDByName(context.TODO(), ic.BaseDomain, types.InternalPublishingStrategy)
-	if err != nil {
-		return append(allErrs, field.InternalError(fldPath, err))
+	// Get DNS zone ID by name
+	var zoneID string
+	if ic.PowerVS.DNSInstanceCRN != "" {
+		zone, err := client.GetDNSServicesZoneByName(context.TODO(), ic.PowerVS.DNSInstanceCRN, ic.BaseDomain)
+		if err != nil {
+			return append(allErrs, field.InternalError(fldPath, err))
+		}
+		if zone == nil {
+			return append(allErrs, field.NotFound(fldPath, ic.BaseDomain))
+		}
+		zoneID = zone.ID
+	} else {
+		zoneID, err = client.GetDNSZoneIDByName(context.TODO(), ic.BaseDomain, types.InternalPublishingStrategy)
+		if err != nil {
+			return append(allErrs, field.InternalError(fldPath, err))
+		}
 	}
 
 	// Search for existing records
@@ -112,8 +137,85 @@ func validatePreExistingPrivateDNS(fldPath *field.Path, client API, ic *types.In
 	return allErrs
 }
 
+// ValidateDNSZone ensures the CIS DNS zone or IBM DNS zone of the base domain
+// exists, is active, is in the Power VS resource group and does not contain any
+// of the cluster's DNS records.
+func ValidateDNSZone(client API, ic *types.InstallConfig) error {
+	return timeValidation("DNS zone", func() error {
+		return validateDNSZone(client, ic)
+	})
+}
+
+func validateDNSZone(client API, ic *types.InstallConfig) error {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("baseDomain")
+
+	// A private zone is looked up in the DNS Services instance of the
+	// platform, if there is one, rather than in any instance of the account.
+	customDNSInstance := ic.Publish == types.InternalPublishingStrategy && ic.PowerVS.DNSInstanceCRN != ""
+
+	var zone *DNSZoneResponse
+	var err error
+	if customDNSInstance {
+		zone, err = client.GetDNSServicesZoneByName(context.TODO(), ic.PowerVS.DNSInstanceCRN, ic.BaseDomain)
+	} else {
+		zone, err = client.GetDNSZoneByName(context.TODO(), ic.BaseDomain, ic.Publish)
+	}
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err)).ToAggregate()
+	}
+	if zone == nil {
+		if customDNSInstance {
+			return append(allErrs, field.NotFound(fldPath, fmt.Sprintf("%s in DNS Services instance %s", ic.BaseDomain, ic.PowerVS.DNSInstanceCRN))).ToAggregate()
+		}
+		return append(allErrs, field.NotFound(fldPath, ic.BaseDomain)).ToAggregate()
+	}
+
+	if !zone.IsActive() {
+		allErrs = append(allErrs, field.Invalid(fldPath, ic.BaseDomain, fmt.Sprintf("DNS zone (%s) is %s, it must be active", zone.ID, zone.Status)))
+	}
+
+	// A DNS Services instance given in the platform may be shared by several
+	// resource groups.
+	if ic.PowerVS.PowerVSResourceGroup != "" && !customDNSInstance {
+		rgPath := field.NewPath("platform", "powervs", "powervsResourceGroup")
+		resourceGroup, err := client.GetResourceGroup(context.TODO(), ic.PowerVS.PowerVSResourceGroup)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(rgPath, err))
+		} else if *resourceGroup.ID != zone.ResourceGroupID {
+			allErrs = append(allErrs, field.Invalid(rgPath, ic.PowerVS.PowerVSResourceGroup, fmt.Sprintf("DNS zone (%s) is managed by instance %s in a different resource group", zone.ID, zone.InstanceName)))
+		}
+	}
+
+	recordNames := [...]string{
+		fmt.Sprintf("api.%s", ic.ClusterDomain()),
+		fmt.Sprintf("api-int.%s", ic.ClusterDomain()),
+		fmt.Sprintf("*.apps.%s", ic.ClusterDomain()),
+	}
+	for _, recordName := range recordNames {
+		records, err := client.GetDNSRecordsByName(context.TODO(), zone.InstanceCRN, zone.ID, recordName, ic.Publish)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+			continue
+		}
+
+		// DNS record exists
+		if len(records) != 0 {
+			allErrs = append(allErrs, field.Duplicate(fldPath, fmt.Sprintf("record %s already exists in DNS zone (%s) and might be in use by another cluster, please remove it to continue", recordName, zone.ID)))
+		}
+	}
+
+	return allErrs.ToAggregate()
+}
+
 // ValidateCustomVPCSetup ensures optional VPC settings, if specified, are all legit.
 func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
+	return timeValidation("custom VPC setup", func() error {
+		return validateCustomVPCSetup(client, ic)
+	})
+}
+
+func validateCustomVPCSetup(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 	var vpcRegion = ic.PowerVS.VPCRegion
 	var vpcName = ic.PowerVS.VPCName
@@ -123,6 +225,8 @@ func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
 	if vpcRegion != "" {
 		if !powervstypes.ValidateVPCRegion(vpcRegion) {
 			allErrs = append(allErrs, field.NotFound(fldPath.Child("vpcRegion"), vpcRegion))
+		} else if region, err := powervstypes.VPCRegionForPowerVSRegion(ic.PowerVS.Region); err == nil && region != vpcRegion {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcRegion"), vpcRegion, fmt.Sprintf("must be %s for Power VS region %s", region, ic.PowerVS.Region)))
 		}
 	} else {
 		vpcRegion, err = powervstypes.VPCRegionForPowerVSRegion(ic.PowerVS.Region)
@@ -133,7 +237,8 @@ func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
 
 	if vpcName != "" {
 		allErrs = append(allErrs, findVPCInRegion(client, vpcName, vpcRegion, fldPath)...)
-		allErrs = append(allErrs, findSubnetInVPC(client, ic.PowerVS.VPCSubnets, vpcRegion, vpcName, fldPath)...)
+		allErrs = append(allErrs, findSubnetInVPC(client, ic.PowerVS.VPCSubnets, vpcRegion, vpcName, ic.MachineNetwork, fldPath)...)
+		allErrs = append(allErrs, findSecurityGroupsInVPC(client, ic.PowerVS.VPCSecurityGroups, vpcRegion, vpcName, fldPath)...)
 	} else if len(ic.PowerVS.VPCSubnets) != 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcSubnets"), nil, "invalid without vpcName"))
 	}
@@ -167,19 +272,68 @@ func findVPCInRegion(client API, name string, region string, path *field.Path) f
 	return allErrs
 }
 
-func findSubnetInVPC(client API, subnets []string, region string, name string, path *field.Path) field.ErrorList {
+// findSubnetInVPC checks that the subnets are attached to the VPC, that no
+// two of them are in the same zone and that they cover the machine networks.
+func findSubnetInVPC(client API, subnets []string, region string, name string, machineNetworks []types.MachineNetworkEntry, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if len(subnets) == 0 {
 		return allErrs
 	}
 
-	subnet, err := client.GetSubnetByName(context.TODO(), subnets[0], region)
-	if err != nil {
-		allErrs = append(allErrs, field.InternalError(path.Child("vpcSubnets"), err))
-	} else if *subnet.VPC.Name != name {
-		allErrs = append(allErrs, field.Invalid(path.Child("vpcSubnets"), nil, "not attached to VPC"))
+	zones := map[string]string{}
+	var cidrs []*net.IPNet
+	for _, subnetName := range subnets {
+		subnet, err := client.GetSubnetByName(context.TODO(), subnetName, region)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(path.Child("vpcSubnets"), err))
+			continue
+		}
+		if *subnet.VPC.Name != name {
+			allErrs = append(allErrs, field.Invalid(path.Child("vpcSubnets"), nil, "not attached to VPC"))
+			continue
+		}
+		if subnet.Zone != nil && subnet.Zone.Name != nil {
+			zone := *subnet.Zone.Name
+			if other, ok := zones[zone]; ok {
+				allErrs = append(allErrs, field.Invalid(path.Child("vpcSubnets"), subnetName, fmt.Sprintf("subnet %s is also in zone %s, only one subnet per zone is allowed", other, zone)))
+				continue
+			}
+			zones[zone] = subnetName
+		}
+		if subnet.Ipv4CIDRBlock != nil {
+			_, cidr, err := net.ParseCIDR(*subnet.Ipv4CIDRBlock)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(path.Child("vpcSubnets"), errors.Wrapf(err, "failed to parse the CIDR of subnet %s", subnetName)))
+				continue
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	for _, machineNetwork := range machineNetworks {
+		if !coveredBy(&machineNetwork.CIDR.IPNet, cidrs) {
+			allErrs = append(allErrs, field.Invalid(path.Child("vpcSubnets"), subnets, fmt.Sprintf("the subnets do not cover the machine network %s", machineNetwork.CIDR.String())))
+		}
 	}
 
 	return allErrs
 }
+
+// findSecurityGroupsInVPC checks that the security groups exist in the VPC
+// and that together they allow the ports of the cluster inbound.
+func findSecurityGroupsInVPC(client API, securityGroups []string, region string, name string, path *field.Path) field.ErrorList {
+	if len(securityGroups) == 0 {
+		return nil
+	}
+
+	vpcSecurityGroups, err := client.GetSecurityGroups(context.TODO(), region, name)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path.Child("vpcSecurityGroups"), err)}
+	}
+
+	return validateVPCSecurityGroups(vpcSecurityGroups, securityGroups, path.Child("vpcSecurityGroups"))
+}
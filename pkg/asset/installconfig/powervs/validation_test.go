@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -91,6 +92,8 @@ var (
 	validVPCSubnet   = "valid-vpc-subnet"
 	invalidVPCSubnet = "invalid-vpc-subnet"
 	wrongVPCSubnet   = "wrong-vpc-subnet"
+	validVPCZone     = "eu-gb-1"
+	validSubnetCIDR  = "192.168.0.0/20"
 	validSubnet      = &vpcv1.Subnet{
 		Name: &validRG,
 		VPC: &vpcv1.VPCReference{
@@ -101,6 +104,50 @@ var (
 			Name: &validRG,
 			ID:   &validRG,
 		},
+		Zone:          &vpcv1.ZoneReference{Name: &validVPCZone},
+		Ipv4CIDRBlock: &validSubnetCIDR,
+	}
+	sameZoneVPCSubnet = "same-zone-vpc-subnet"
+	sameZoneSubnet    = &vpcv1.Subnet{
+		Name: &sameZoneVPCSubnet,
+		VPC: &vpcv1.VPCReference{
+			Name: &validVPC,
+			ID:   &validVPCID,
+		},
+		Zone:          &vpcv1.ZoneReference{Name: &validVPCZone},
+		Ipv4CIDRBlock: &otherSubnetCIDR,
+	}
+	otherVPCSubnet  = "other-vpc-subnet"
+	otherSubnetCIDR = "10.0.0.0/24"
+	otherSubnet     = &vpcv1.Subnet{
+		Name: &otherVPCSubnet,
+		VPC: &vpcv1.VPCReference{
+			Name: &validVPC,
+			ID:   &validVPCID,
+		},
+		Ipv4CIDRBlock: &otherSubnetCIDR,
+	}
+	validVPCSecurityGroup  = "valid-security-group"
+	validSecurityGroupRule = "inbound"
+	validSecurityGroups    = []vpcv1.SecurityGroup{
+		{
+			Name: &validVPCSecurityGroup,
+			Rules: []vpcv1.SecurityGroupRuleIntf{
+				&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll{Direction: &validSecurityGroupRule},
+			},
+		},
+	}
+	httpsSecurityGroup     = "https-security-group"
+	httpsSecurityGroupRule = &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{
+		Direction: &validSecurityGroupRule,
+		Protocol:  &tcpProtocol,
+		PortMin:   &httpsPort,
+		PortMax:   &httpsPort,
+	}
+	tcpProtocol           = "tcp"
+	httpsPort       int64 = 443
+	httpsOnlyGroups       = []vpcv1.SecurityGroup{
+		{Name: &httpsSecurityGroup, Rules: []vpcv1.SecurityGroupRuleIntf{httpsSecurityGroupRule}},
 	}
 	wrongSubnet = &vpcv1.Subnet{
 		Name: &validRG,
@@ -261,6 +308,143 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 	}
 }
 
+func TestValidateDNSZone(t *testing.T) {
+	cases := []struct {
+		name     string
+		errorMsg string
+	}{
+		{
+			name:     "valid DNS zone",
+			errorMsg: "",
+		},
+		{
+			name:     "DNS zone not found",
+			errorMsg: `^baseDomain: Not found: "valid\.base\.domain"$`,
+		},
+		{
+			name:     "DNS zone not active",
+			errorMsg: `^baseDomain: Invalid value: "valid\.base\.domain": DNS zone \(valid-zone-id\) is pending, it must be active$`,
+		},
+		{
+			name:     "DNS zone in another resource group",
+			errorMsg: `^platform\.powervs\.powervsResourceGroup: Invalid value: "valid-resource-group": DNS zone \(valid-zone-id\) is managed by instance valid-instance-name in a different resource group$`,
+		},
+		{
+			name:     "pre-existing DNS records",
+			errorMsg: `record \*\.apps\.valid-cluster-name\.valid\.base\.domain already exists in DNS zone \(valid-zone-id\)`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	powervsClient := mock.NewMockAPI(mockCtrl)
+
+	dnsRecordNames := [...]string{
+		fmt.Sprintf("api.%s.%s", validClusterName, validBaseDomain),
+		fmt.Sprintf("api-int.%s.%s", validClusterName, validBaseDomain),
+		fmt.Sprintf("*.apps.%s.%s", validClusterName, validBaseDomain),
+	}
+	zone := func(status string, resourceGroupID string) *powervs.DNSZoneResponse {
+		return &powervs.DNSZoneResponse{
+			Name:            validBaseDomain,
+			ID:              validDNSZoneID,
+			InstanceCRN:     validCISInstanceCRN,
+			InstanceName:    "valid-instance-name",
+			ResourceGroupID: resourceGroupID,
+			Status:          status,
+		}
+	}
+
+	// Mock common to all tests
+	powervsClient.EXPECT().GetResourceGroup(gomock.Any(), validPowerVSResourceGroup).Return(&resourcemanagerv2.ResourceGroup{ID: &validRG, Name: &validRG}, nil).AnyTimes()
+
+	// Mocks: valid DNS zone
+	powervsClient.EXPECT().GetDNSZoneByName(gomock.Any(), validBaseDomain, types.ExternalPublishingStrategy).Return(zone("active", validRG), nil)
+	for _, dnsRecordName := range dnsRecordNames {
+		powervsClient.EXPECT().GetDNSRecordsByName(gomock.Any(), validCISInstanceCRN, validDNSZoneID, dnsRecordName, types.ExternalPublishingStrategy).Return(noDNSRecordsResponse, nil)
+	}
+
+	// Mocks: DNS zone not found
+	powervsClient.EXPECT().GetDNSZoneByName(gomock.Any(), validBaseDomain, types.ExternalPublishingStrategy).Return(nil, nil)
+
+	// Mocks: DNS zone not active
+	powervsClient.EXPECT().GetDNSZoneByName(gomock.Any(), validBaseDomain, types.ExternalPublishingStrategy).Return(zone("pending", validRG), nil)
+	for _, dnsRecordName := range dnsRecordNames {
+		powervsClient.EXPECT().GetDNSRecordsByName(gomock.Any(), validCISInstanceCRN, validDNSZoneID, dnsRecordName, types.ExternalPublishingStrategy).Return(noDNSRecordsResponse, nil)
+	}
+
+	// Mocks: DNS zone in another resource group
+	powervsClient.EXPECT().GetDNSZoneByName(gomock.Any(), validBaseDomain, types.ExternalPublishingStrategy).Return(zone("active", anotherValidRG), nil)
+	for _, dnsRecordName := range dnsRecordNames {
+		powervsClient.EXPECT().GetDNSRecordsByName(gomock.Any(), validCISInstanceCRN, validDNSZoneID, dnsRecordName, types.ExternalPublishingStrategy).Return(noDNSRecordsResponse, nil)
+	}
+
+	// Mocks: pre-existing DNS records
+	powervsClient.EXPECT().GetDNSZoneByName(gomock.Any(), validBaseDomain, types.ExternalPublishingStrategy).Return(zone("active", validRG), nil)
+	for _, dnsRecordName := range dnsRecordNames {
+		powervsClient.EXPECT().GetDNSRecordsByName(gomock.Any(), validCISInstanceCRN, validDNSZoneID, dnsRecordName, types.ExternalPublishingStrategy).Return(existingDNSRecordsResponse, nil)
+	}
+
+	// Run tests
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregatedErrors := powervs.ValidateDNSZone(powervsClient, validInstallConfig())
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
+			} else {
+				assert.NoError(t, aggregatedErrors)
+			}
+		})
+	}
+}
+
+func TestValidateDNSZoneInDNSServicesInstance(t *testing.T) {
+	dnsInstanceCRN := "crn:v1:bluemix:public:dns-svcs:global:a/valid-account:valid-dns-instance::"
+	cases := []struct {
+		name     string
+		zone     *powervs.DNSZoneResponse
+		errorMsg string
+	}{
+		{
+			name: "DNS zone in another resource group",
+			zone: &powervs.DNSZoneResponse{
+				Name:        validBaseDomain,
+				ID:          validDNSZoneID,
+				InstanceCRN: dnsInstanceCRN,
+				Status:      "ACTIVE",
+			},
+		},
+		{
+			name:     "DNS zone not found",
+			errorMsg: `^baseDomain: Not found: "valid\.base\.domain in DNS Services instance crn:v1:bluemix:public:dns-svcs:global:a/valid-account:valid-dns-instance::"$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			powervsClient := mock.NewMockAPI(mockCtrl)
+			powervsClient.EXPECT().GetDNSServicesZoneByName(gomock.Any(), dnsInstanceCRN, validBaseDomain).Return(tc.zone, nil)
+			if tc.zone != nil {
+				powervsClient.EXPECT().GetDNSRecordsByName(gomock.Any(), dnsInstanceCRN, validDNSZoneID, gomock.Any(), types.InternalPublishingStrategy).Return(noDNSRecordsResponse, nil).Times(3)
+			}
+
+			ic := validInstallConfig()
+			ic.Publish = types.InternalPublishingStrategy
+			ic.PowerVS.DNSInstanceCRN = dnsInstanceCRN
+			aggregatedErrors := powervs.ValidateDNSZone(powervsClient, ic)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
+			} else {
+				assert.NoError(t, aggregatedErrors)
+			}
+		})
+	}
+}
+
 func TestValidateCustomVPCSettings(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -359,6 +543,66 @@ func TestValidateCustomVPCSettings(t *testing.T) {
 			},
 			errorMsg: "",
 		},
+		{
+			name: "VPC region not the one of the Power VS region",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCRegion = "us-south"
+				},
+			},
+			errorMsg: `VPC.vpcRegion: Invalid value: "us-south": must be eu-gb for Power VS region lon`,
+		},
+		{
+			name: "two subnets in the same zone",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCSubnets = []string{validVPCSubnet, sameZoneVPCSubnet}
+				},
+			},
+			errorMsg: `VPC.vpcSubnets: Invalid value: "same-zone-vpc-subnet": subnet valid-vpc-subnet is also in zone eu-gb-1, only one subnet per zone is allowed`,
+		},
+		{
+			name: "subnets not covering the machine network",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCSubnets = []string{otherVPCSubnet}
+				},
+			},
+			errorMsg: `VPC.vpcSubnets: Invalid value: \[\]string{"other-vpc-subnet"}: the subnets do not cover the machine network 192\.168\.0\.0/24`,
+		},
+		{
+			name: "security groups allowing the ports of the cluster",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCSecurityGroups = []string{validVPCSecurityGroup}
+				},
+			},
+			errorMsg: "",
+		},
+		{
+			name: "security group not found",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCSecurityGroups = []string{"bogus-security-group"}
+				},
+			},
+			errorMsg: `VPC.vpcSecurityGroups\[0\]: Not found: "bogus-security-group"`,
+		},
+		{
+			name: "security groups not allowing the ports of the cluster",
+			edits: editFunctions{
+				setValidVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.VPCSecurityGroups = []string{httpsSecurityGroup}
+				},
+			},
+			errorMsg: `VPC.vpcSecurityGroups: Invalid value: \[\]string{"https-security-group"}: the security groups do not allow inbound TCP ports \[80 6443 22623\]`,
+		},
 	}
 	setMockEnvVars()
 
@@ -400,6 +644,30 @@ func TestValidateCustomVPCSettings(t *testing.T) {
 	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
 	powervsClient.EXPECT().GetSubnetByName(gomock.Any(), validVPCSubnet, validVPCRegion).Return(validSubnet, nil)
 
+	// Mocks: VPC region not the one of the Power VS region
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), "us-south").Return(validVPCs, nil)
+
+	// Mocks: two subnets in the same zone
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
+	powervsClient.EXPECT().GetSubnetByName(gomock.Any(), validVPCSubnet, validVPCRegion).Return(validSubnet, nil)
+	powervsClient.EXPECT().GetSubnetByName(gomock.Any(), sameZoneVPCSubnet, validVPCRegion).Return(sameZoneSubnet, nil)
+
+	// Mocks: subnets not covering the machine network
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
+	powervsClient.EXPECT().GetSubnetByName(gomock.Any(), otherVPCSubnet, validVPCRegion).Return(otherSubnet, nil)
+
+	// Mocks: security groups allowing the ports of the cluster
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
+	powervsClient.EXPECT().GetSecurityGroups(gomock.Any(), validVPCRegion, validVPC).Return(validSecurityGroups, nil)
+
+	// Mocks: security group not found
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
+	powervsClient.EXPECT().GetSecurityGroups(gomock.Any(), validVPCRegion, validVPC).Return(validSecurityGroups, nil)
+
+	// Mocks: security groups not allowing the ports of the cluster
+	powervsClient.EXPECT().GetVPCs(gomock.Any(), validVPCRegion).Return(validVPCs, nil)
+	powervsClient.EXPECT().GetSecurityGroups(gomock.Any(), validVPCRegion, validVPC).Return(httpsOnlyGroups, nil)
+
 	// Run tests
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -603,20 +871,262 @@ func TestSystemPool(t *testing.T) {
 		},
 	}
 
-	err := powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEComputeCores)
+	err := powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEComputeCores, powervs.SMTLevels{})
 	assert.EqualError(t, err, "Not enough cores available (2) for the compute nodes (need 5)")
 
-	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEWorkerCores)
+	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEWorkerCores, powervs.SMTLevels{})
 	assert.EqualError(t, err, "Not enough cores available (1) for the worker nodes (need 3)")
 
-	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEComputeMemory)
+	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEComputeMemory, powervs.SMTLevels{})
 	assert.EqualError(t, err, "Not enough memory available (32) for the compute nodes (need 160)")
 
-	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEWorkerMemory)
+	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsNEWorkerMemory, powervs.SMTLevels{})
 	assert.EqualError(t, err, "Not enough memory available (32) for the worker nodes (need 96)")
 
-	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsGood)
+	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsGood, powervs.SMTLevels{})
 	assert.Empty(t, err)
+	// The compute MachineSets hold the provider config of dedicatedCompute.
+	dedicatedCompute.SystemType = "s922"
+	err = powervs.ValidateCapacityWithPools(dedicatedControlPlanes, dedicatedComputes, systemPoolsGood, powervs.SMTLevels{})
+	assert.EqualError(t, err, "The system type s922 of the worker nodes is not available in the workspace")
+}
+
+func TestSystemPoolComputeMachineSets(t *testing.T) {
+	dedicated := machinev1.PowerVSMachineProviderConfig{
+		TypeMeta:      metav1.TypeMeta{Kind: "PowerVSMachineProviderConfig", APIVersion: "machine.openshift.io/v1"},
+		SystemType:    "s922",
+		ProcessorType: "Dedicated",
+		Processors:    intstr.FromInt(1),
+		MemoryGiB:     32,
+	}
+	// The compute machines are spread over a MachineSet per zone.
+	computes := append(createComputes(2, &dedicated), createComputes(2, &dedicated)...)
+
+	newSystemPools := func(availableCores float64) models.SystemPools {
+		system := &models.System{
+			Cores:  func(f float64) *float64 { return &f }(availableCores),
+			ID:     1,
+			Memory: func(i int64) *int64 { return &i }(512),
+		}
+		return models.SystemPools{
+			"s922": models.SystemPool{
+				Capacity:           system,
+				MaxAvailable:       system,
+				MaxCoresAvailable:  system,
+				MaxMemoryAvailable: system,
+				Systems:            []*models.System{system},
+				Type:               "s922",
+			},
+		}
+	}
+
+	err := powervs.ValidateCapacityWithPools(createControlPlanes(1, &dedicated), computes, newSystemPools(5), powervs.SMTLevels{})
+	assert.NoError(t, err)
+
+	err = powervs.ValidateCapacityWithPools(createControlPlanes(1, &dedicated), computes, newSystemPools(4), powervs.SMTLevels{})
+	assert.EqualError(t, err, "Not enough cores available (3) for the worker nodes (need 4)")
+}
+
+func TestSystemPoolSMTLevels(t *testing.T) {
+	sharedControlPlane := machinev1.PowerVSMachineProviderConfig{
+		TypeMeta:      metav1.TypeMeta{Kind: "PowerVSMachineProviderConfig", APIVersion: "machine.openshift.io/v1"},
+		SystemType:    "s922",
+		ProcessorType: "Shared",
+		Processors:    intstr.FromInt(4),
+		MemoryGiB:     32,
+	}
+	sharedCompute := sharedControlPlane
+	sharedCompute.Processors = intstr.FromInt(3)
+
+	newSystemPools := func() models.SystemPools {
+		system := &models.System{
+			Cores:  func(f float64) *float64 { return &f }(8),
+			ID:     1,
+			Memory: func(i int64) *int64 { return &i }(512),
+		}
+		return models.SystemPools{
+			"s922": models.SystemPool{
+				Capacity:           system,
+				MaxAvailable:       system,
+				MaxCoresAvailable:  system,
+				MaxMemoryAvailable: system,
+				Systems:            []*models.System{system},
+				Type:               "s922",
+			},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		smtLevels powervs.SMTLevels
+		expected  string
+	}{
+		{
+			name: "default SMT8",
+		},
+		{
+			name:      "SMT4 control plane",
+			smtLevels: powervs.SMTLevels{ControlPlane: "4"},
+		},
+		{
+			name:      "SMT off control plane",
+			smtLevels: powervs.SMTLevels{ControlPlane: "off"},
+			expected:  `^Not enough cores available \(8\) for the compute nodes \(need 12\)$`,
+		},
+		{
+			name:      "SMT off compute",
+			smtLevels: powervs.SMTLevels{Compute: "off"},
+			expected:  `^Not enough cores available \(6\.5\) for the worker nodes \(need 9\)$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := powervs.ValidateCapacityWithPools(createControlPlanes(3, &sharedControlPlane), createComputes(3, &sharedCompute), newSystemPools(), tc.smtLevels)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestPlacement(t *testing.T) {
+	sharedControlPlane := machinev1.PowerVSMachineProviderConfig{
+		TypeMeta:      metav1.TypeMeta{Kind: "PowerVSMachineProviderConfig", APIVersion: "machine.openshift.io/v1"},
+		SystemType:    "s922",
+		ProcessorType: "Shared",
+		Processors:    intstr.FromInt(4),
+		MemoryGiB:     32,
+	}
+	sharedCompute := sharedControlPlane
+	sharedCompute.Processors = intstr.FromInt(3)
+
+	str := func(s string) *string { return &s }
+	cores := func(f float64) *float64 { return &f }
+	sharedProcessorPools := func(availableCores float64) *models.SharedProcessorPools {
+		return &models.SharedProcessorPools{
+			SharedProcessorPools: []*models.SharedProcessorPool{
+				{ID: str("pool-1-id"), Name: str("pool-1"), AvailableCores: cores(availableCores)},
+			},
+		}
+	}
+	placementGroups := &models.PlacementGroups{
+		PlacementGroups: []*models.PlacementGroup{
+			{ID: str("group-1-id"), Name: str("group-1"), Policy: str("anti-affinity")},
+		},
+	}
+
+	cases := []struct {
+		name                 string
+		edits                editFunctions
+		sharedProcessorPools *models.SharedProcessorPools
+		expected             string
+	}{
+		{
+			name: "no placement",
+		},
+		{
+			name: "shared processor pool",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.DefaultMachinePlatform = &powervstypes.MachinePool{SharedProcessorPool: "pool-1"}
+				},
+			},
+			sharedProcessorPools: sharedProcessorPools(3),
+		},
+		{
+			name: "shared processor pool by ID",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.PowerVS = &powervstypes.MachinePool{SharedProcessorPool: "pool-1-id"}
+				},
+			},
+			sharedProcessorPools: sharedProcessorPools(1.5),
+		},
+		{
+			name: "not enough cores in the shared processor pool",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.PowerVS.DefaultMachinePlatform = &powervstypes.MachinePool{SharedProcessorPool: "pool-1"}
+				},
+			},
+			sharedProcessorPools: sharedProcessorPools(2.5),
+			expected:             `^Not enough cores available \(2\.5\) in the shared processor pool "pool-1" for the machines \(need 3\)$`,
+		},
+		{
+			name: "missing shared processor pool",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Compute[0].Platform.PowerVS = &powervstypes.MachinePool{SharedProcessorPool: "pool-2"}
+				},
+			},
+			sharedProcessorPools: sharedProcessorPools(3),
+			expected:             `^shared processor pool "pool-2" not found in the workspace$`,
+		},
+		{
+			name: "server placement group",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.PowerVS = &powervstypes.MachinePool{
+						ServerPlacementGroup: &powervstypes.ServerPlacementGroup{Name: "group-1", Policy: powervstypes.PlacementGroupPolicyAntiAffinity},
+					}
+				},
+			},
+		},
+		{
+			name: "server placement group by ID without policy",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Compute[0].Platform.PowerVS = &powervstypes.MachinePool{
+						ServerPlacementGroup: &powervstypes.ServerPlacementGroup{Name: "group-1-id"},
+					}
+				},
+			},
+		},
+		{
+			name: "server placement group with another policy",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.PowerVS = &powervstypes.MachinePool{
+						ServerPlacementGroup: &powervstypes.ServerPlacementGroup{Name: "group-1", Policy: powervstypes.PlacementGroupPolicyAffinity},
+					}
+				},
+			},
+			expected: `^the server placement group "group-1" has the anti-affinity policy, not affinity$`,
+		},
+		{
+			name: "missing server placement group",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.PowerVS = &powervstypes.MachinePool{
+						ServerPlacementGroup: &powervstypes.ServerPlacementGroup{Name: "group-2"},
+					}
+				},
+			},
+			expected: `^server placement group "group-2" not found in the workspace$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := validInstallConfig()
+			ic.Compute[0].Name = types.MachinePoolComputeRoleName
+			for _, edit := range tc.edits {
+				edit(ic)
+			}
+			pools := tc.sharedProcessorPools
+			if pools == nil {
+				pools = &models.SharedProcessorPools{}
+			}
+
+			err := powervs.ValidatePlacementWithPools(ic, createControlPlanes(3, &sharedControlPlane), createComputes(3, &sharedCompute), pools, placementGroups)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
 }
 
 func setMockEnvVars() {
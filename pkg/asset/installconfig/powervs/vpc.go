@@ -0,0 +1,103 @@
+package powervs
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// requiredVPCPorts are the TCP ports which the security groups of an existing
+// VPC must allow inbound: HTTP and HTTPS ingress, the API and the Machine
+// Config Server.
+var requiredVPCPorts = []int64{80, 443, 6443, 22623}
+
+// vpcService returns a client of the VPC API of the region.
+func (c *BxClient) vpcService(region string) (*vpcv1.VpcV1, error) {
+	vpcService, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: c.Authenticator(),
+		URL:           c.ServiceURL(powervstypes.VPCServiceEndpointName, fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", region)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	configureService(vpcService.Service)
+	return vpcService, nil
+}
+
+// coveredBy returns true if one of the CIDRs contains the network.
+func coveredBy(network *net.IPNet, cidrs []*net.IPNet) bool {
+	networkOnes, _ := network.Mask.Size()
+	for _, cidr := range cidrs {
+		ones, _ := cidr.Mask.Size()
+		if ones <= networkOnes && cidr.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateVPCSecurityGroups checks that the named security groups exist in the
+// VPC and that together they allow the required ports inbound.
+func validateVPCSecurityGroups(securityGroups []vpcv1.SecurityGroup, names []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	byName := map[string]vpcv1.SecurityGroup{}
+	for _, securityGroup := range securityGroups {
+		if securityGroup.Name != nil {
+			byName[*securityGroup.Name] = securityGroup
+		}
+	}
+
+	var rules []vpcv1.SecurityGroupRuleIntf
+	for i, name := range names {
+		securityGroup, ok := byName[name]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fldPath.Index(i), name))
+			continue
+		}
+		rules = append(rules, securityGroup.Rules...)
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	var missing []int64
+	for _, port := range requiredVPCPorts {
+		if !inboundTCPAllowed(rules, port) {
+			missing = append(missing, port)
+		}
+	}
+	if len(missing) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, names, fmt.Sprintf("the security groups do not allow inbound TCP ports %v", missing)))
+	}
+	return allErrs
+}
+
+// inboundTCPAllowed returns true if one of the rules allows inbound TCP
+// traffic to the port.
+func inboundTCPAllowed(rules []vpcv1.SecurityGroupRuleIntf, port int64) bool {
+	for _, rule := range rules {
+		switch r := rule.(type) {
+		case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+			if r.Direction != nil && *r.Direction == vpcv1.SecurityGroupRuleDirectionInboundConst {
+				return true
+			}
+		case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+			if r.Direction == nil || *r.Direction != vpcv1.SecurityGroupRuleDirectionInboundConst {
+				continue
+			}
+			if r.Protocol == nil || *r.Protocol != vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudpProtocolTCPConst {
+				continue
+			}
+			// Rules without a port range apply to all ports.
+			if (r.PortMin == nil || *r.PortMin <= port) && (r.PortMax == nil || port <= *r.PortMax) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package powervs
+
+import (
+	"net"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestInboundTCPAllowed(t *testing.T) {
+	tcpRule := func(direction string, min, max int64) vpcv1.SecurityGroupRuleIntf {
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{
+			Direction: pointer.String(direction),
+			Protocol:  pointer.String("tcp"),
+			PortMin:   pointer.Int64(min),
+			PortMax:   pointer.Int64(max),
+		}
+	}
+	cases := []struct {
+		name     string
+		rules    []vpcv1.SecurityGroupRuleIntf
+		port     int64
+		expected bool
+	}{
+		{
+			name:     "no rules",
+			port:     6443,
+			expected: false,
+		},
+		{
+			name:     "port in range",
+			rules:    []vpcv1.SecurityGroupRuleIntf{tcpRule("inbound", 6443, 6443)},
+			port:     6443,
+			expected: true,
+		},
+		{
+			name:     "port out of range",
+			rules:    []vpcv1.SecurityGroupRuleIntf{tcpRule("inbound", 80, 443)},
+			port:     6443,
+			expected: false,
+		},
+		{
+			name:     "outbound rule",
+			rules:    []vpcv1.SecurityGroupRuleIntf{tcpRule("outbound", 1, 65535)},
+			port:     6443,
+			expected: false,
+		},
+		{
+			name: "udp rule",
+			rules: []vpcv1.SecurityGroupRuleIntf{&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{
+				Direction: pointer.String("inbound"),
+				Protocol:  pointer.String("udp"),
+			}},
+			port:     6443,
+			expected: false,
+		},
+		{
+			name: "all protocols",
+			rules: []vpcv1.SecurityGroupRuleIntf{&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll{
+				Direction: pointer.String("inbound"),
+				Protocol:  pointer.String("all"),
+			}},
+			port:     22623,
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, inboundTCPAllowed(tc.rules, tc.port))
+		})
+	}
+}
+
+func TestCoveredBy(t *testing.T) {
+	parse := func(cidr string) *net.IPNet {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ipNet
+	}
+	cidrs := []*net.IPNet{parse("10.0.0.0/24"), parse("10.0.1.0/24")}
+
+	assert.True(t, coveredBy(parse("10.0.0.0/24"), cidrs))
+	assert.True(t, coveredBy(parse("10.0.1.128/25"), cidrs))
+	assert.False(t, coveredBy(parse("10.0.0.0/16"), cidrs))
+	assert.False(t, coveredBy(parse("192.168.0.0/24"), cidrs))
+}
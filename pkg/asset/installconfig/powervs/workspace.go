@@ -0,0 +1,114 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// newWorkspaceOption is the survey option to let the installer create the
+// workspace of the cluster.
+const newWorkspaceOption = "<create a new workspace for the cluster>"
+
+// GetWorkspace returns the ID of a Power VS workspace in the zone chosen by
+// survey, or an empty ID when the installer is to create the workspace.
+func GetWorkspace(zone string) (string, error) {
+	client, err := NewClient(nil)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	workspaces, err := client.GetWorkspaces(ctx, zone)
+	if err != nil {
+		return "", errors.Wrap(err, "could not retrieve workspaces")
+	}
+	if len(workspaces) == 0 {
+		logrus.Infof("No Power VS workspaces found in zone %s, the installer will create one", zone)
+		return "", nil
+	}
+
+	options, optionToIDMap := workspaceOptions(workspaces)
+
+	var workspaceChoice string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Workspace",
+		Help:    "The Power VS workspace in which the cluster will be created.\n\nIf you don't see your intended workspace listed, create it in the selected zone and rerun the installer, or let the installer create one.",
+		Options: options,
+	},
+		&workspaceChoice,
+		survey.WithValidator(func(ans interface{}) error {
+			choice := ans.(core.OptionAnswer).Value
+			if _, ok := optionToIDMap[choice]; !ok {
+				return errors.Errorf("invalid workspace %q", choice)
+			}
+			return nil
+		}),
+	); err != nil {
+		return "", errors.Wrap(err, "failed UserInput")
+	}
+
+	return optionToIDMap[workspaceChoice], nil
+}
+
+// workspaceOptions returns the survey options for the workspaces, sorted by
+// name and followed by the option to create a new workspace, and the IDs of
+// the workspaces by option.
+func workspaceOptions(workspaces []WorkspaceResponse) ([]string, map[string]string) {
+	options := make([]string, 0, len(workspaces)+1)
+	optionToIDMap := make(map[string]string, len(workspaces)+1)
+	for _, workspace := range workspaces {
+		option := fmt.Sprintf("%s (%s)", workspace.Name, workspace.ID)
+		optionToIDMap[option] = workspace.ID
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	options = append(options, newWorkspaceOption)
+	optionToIDMap[newWorkspaceOption] = ""
+	return options, optionToIDMap
+}
+
+// EnsureWorkspace returns the ID of the Power VS workspace of the cluster
+// with the infra ID. When the platform has no workspace, the workspace of the
+// cluster is created in the zone and resource group of the platform, tagged
+// with the infra ID and the user tags, unless it was created by a previous run.
+// It is only called when the cluster is provisioned, so that generating the
+// assets creates no cloud resources.
+func EnsureWorkspace(ctx context.Context, client API, platform *powervs.Platform, infraID string) (string, error) {
+	if platform.ServiceInstanceID != "" {
+		return platform.ServiceInstanceID, nil
+	}
+
+	name := powervs.WorkspaceName(infraID)
+	workspaces, err := client.GetWorkspaces(ctx, platform.Zone)
+	if err != nil {
+		return "", errors.Wrap(err, "could not retrieve workspaces")
+	}
+	for _, workspace := range workspaces {
+		if workspace.Name == name {
+			logrus.Debugf("Using Power VS workspace %s (%s) created by a previous run", name, workspace.ID)
+			return workspace.ID, nil
+		}
+	}
+
+	resourceGroup, err := client.GetResourceGroup(ctx, platform.PowerVSResourceGroup)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find resource group %s", platform.PowerVSResourceGroup)
+	}
+
+	logrus.Infof("Creating Power VS workspace %s in zone %s", name, platform.Zone)
+	workspace, err := client.CreateWorkspace(ctx, name, platform.Zone, *resourceGroup.ID, platform.ResourceTags(infraID))
+	if err != nil {
+		return "", err
+	}
+	return workspace.ID, nil
+}
@@ -0,0 +1,95 @@
+package powervs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/asset/installconfig/powervs/mock"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestEnsureWorkspace(t *testing.T) {
+	const (
+		infraID       = "test-cluster-a1b2c"
+		zone          = "lon04"
+		resourceGroup = "test-rg"
+	)
+	resourceGroupID := "rg-id"
+
+	cases := []struct {
+		name       string
+		workspace  string
+		userTags   []string
+		setup      func(client *mock.MockAPI)
+		expectedID string
+	}{
+		{
+			name:       "workspace provided",
+			workspace:  "provided-id",
+			setup:      func(client *mock.MockAPI) {},
+			expectedID: "provided-id",
+		},
+		{
+			name: "workspace created by a previous run",
+			setup: func(client *mock.MockAPI) {
+				client.EXPECT().GetWorkspaces(gomock.Any(), zone).Return([]powervs.WorkspaceResponse{
+					{Name: "other", ID: "other-id", Zone: zone},
+					{Name: "test-cluster-a1b2c-power-iaas", ID: "existing-id", Zone: zone},
+				}, nil)
+			},
+			expectedID: "existing-id",
+		},
+		{
+			name: "workspace created",
+			setup: func(client *mock.MockAPI) {
+				client.EXPECT().GetWorkspaces(gomock.Any(), zone).Return(nil, nil)
+				client.EXPECT().GetResourceGroup(gomock.Any(), resourceGroup).Return(&resourcemanagerv2.ResourceGroup{ID: &resourceGroupID}, nil)
+				client.EXPECT().CreateWorkspace(gomock.Any(), "test-cluster-a1b2c-power-iaas", zone, resourceGroupID, []string{infraID}).Return(&powervs.WorkspaceResponse{
+					Name: "test-cluster-a1b2c-power-iaas",
+					ID:   "created-id",
+					Zone: zone,
+				}, nil)
+			},
+			expectedID: "created-id",
+		},
+		{
+			name:     "workspace created with user tags",
+			userTags: []string{"env:prod", infraID},
+			setup: func(client *mock.MockAPI) {
+				client.EXPECT().GetWorkspaces(gomock.Any(), zone).Return(nil, nil)
+				client.EXPECT().GetResourceGroup(gomock.Any(), resourceGroup).Return(&resourcemanagerv2.ResourceGroup{ID: &resourceGroupID}, nil)
+				client.EXPECT().CreateWorkspace(gomock.Any(), "test-cluster-a1b2c-power-iaas", zone, resourceGroupID, []string{infraID, "env:prod"}).Return(&powervs.WorkspaceResponse{
+					Name: "test-cluster-a1b2c-power-iaas",
+					ID:   "created-id",
+					Zone: zone,
+				}, nil)
+			},
+			expectedID: "created-id",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockAPI(mockCtrl)
+			tc.setup(client)
+
+			platform := &powervstypes.Platform{
+				ServiceInstanceID:    tc.workspace,
+				PowerVSResourceGroup: resourceGroup,
+				Zone:                 zone,
+				UserTags:             tc.userTags,
+			}
+			id, err := powervs.EnsureWorkspace(context.Background(), client, platform, infraID)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/defaults"
+	"github.com/openshift/installer/pkg/types/powervs"
+	powervsdefaults "github.com/openshift/installer/pkg/types/powervs/defaults"
+)
+
+// machineDemand is the capacity which the machines of a cluster need from
+// the system pool of their system type.
+type machineDemand struct {
+	SystemType string
+	Cores      float64
+	MemoryGiB  int64
+}
+
+// zoneCapacity is the capacity available in the system pools of a zone.
+type zoneCapacity struct {
+	Zone string
+	// Available is the capacity available in the system pool of each system
+	// type of the demand.
+	Available []machineDemand
+	// Fits is true if the machines of the cluster fit in the system pools.
+	Fits bool
+}
+
+// machinePoolsDemand returns the demand of the machines of the pools of the
+// install config, by system type: the bootstrap machine and the control plane
+// machines of the control plane pool, and the compute machines of the compute
+// pools. The platform of each pool overrides the default machine platform,
+// which overrides the defaults, as when the machines are created.
+func machinePoolsDemand(ic *types.InstallConfig) ([]machineDemand, error) {
+	demands := map[string]*machineDemand{}
+	add := func(pool *types.MachinePool, machines int64) error {
+		mpool := powervsdefaults.MachinePool()
+		mpool.Set(ic.Platform.PowerVS.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.PowerVS)
+		cores, err := DefaultCapacityModel.WithSMTLevel(mpool.SMTLevel).Cores(string(mpool.ProcType), mpool.Processors)
+		if err != nil {
+			return errors.Wrapf(err, "failed to calculate the cores of the %s machines", pool.Name)
+		}
+		demand, ok := demands[mpool.SysType]
+		if !ok {
+			demand = &machineDemand{SystemType: mpool.SysType}
+			demands[mpool.SysType] = demand
+		}
+		demand.Cores += float64(machines) * cores
+		demand.MemoryGiB += machines * int64(mpool.MemoryGiB)
+		return nil
+	}
+
+	if ic.ControlPlane != nil {
+		machines := int64(1)
+		if ic.ControlPlane.Replicas != nil {
+			machines += *ic.ControlPlane.Replicas
+		}
+		if err := add(ic.ControlPlane, machines); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ic.Compute {
+		if ic.Compute[i].Replicas == nil || *ic.Compute[i].Replicas == 0 {
+			continue
+		}
+		if err := add(&ic.Compute[i], *ic.Compute[i].Replicas); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]machineDemand, 0, len(demands))
+	for _, demand := range demands {
+		result = append(result, *demand)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SystemType < result[j].SystemType })
+	return result, nil
+}
+
+// defaultInstallConfig returns the install config whose machine pools are the
+// defaults, which the survey creates, for the demand of the zones prompted
+// for before the machine pools are known.
+func defaultInstallConfig() *types.InstallConfig {
+	ic := &types.InstallConfig{
+		Platform: types.Platform{
+			PowerVS: &powervs.Platform{},
+		},
+	}
+	defaults.SetInstallConfigDefaults(ic)
+	return ic
+}
+
+// poolCapacity returns the capacity available for the demand in the system
+// pools of the zone.
+func poolCapacity(zone string, pools models.SystemPools, demands []machineDemand) zoneCapacity {
+	capacity := zoneCapacity{Zone: zone, Fits: true}
+	for _, demand := range demands {
+		pool, ok := pools[demand.SystemType]
+		if !ok || pool.MaxCoresAvailable == nil || pool.MaxCoresAvailable.Cores == nil || pool.MaxCoresAvailable.Memory == nil {
+			capacity.Available = append(capacity.Available, machineDemand{SystemType: demand.SystemType})
+			capacity.Fits = false
+			continue
+		}
+		available := machineDemand{
+			SystemType: demand.SystemType,
+			Cores:      *pool.MaxCoresAvailable.Cores,
+			MemoryGiB:  *pool.MaxCoresAvailable.Memory,
+		}
+		capacity.Available = append(capacity.Available, available)
+		if available.Cores < demand.Cores || available.MemoryGiB < demand.MemoryGiB {
+			capacity.Fits = false
+		}
+	}
+	return capacity
+}
+
+// describeDemands returns the cores and memory of the demands, e.g.
+// "s922: 1.75 cores, 224 GiB".
+func describeDemands(demands []machineDemand) string {
+	descriptions := make([]string, 0, len(demands))
+	for _, demand := range demands {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %.2f cores, %d GiB", demand.SystemType, demand.Cores, demand.MemoryGiB))
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// getZoneCapacities returns the capacity of the zones of the region, sorted by
+// zone. Zones without a workspace, or whose system pools cannot be read, are
+// left out.
+func (c *BxClient) getZoneCapacities(ctx context.Context, region string, demands []machineDemand) ([]zoneCapacity, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	// NewClient would create another BxClient, so the resource controller is
+	// loaded from this one.
+	client := &Client{
+		APIKey:           c.APIKey,
+		TrustedProfileID: c.TrustedProfileID,
+		accountID:        c.User.Account,
+	}
+	if err := client.loadResourceControllerAPI(); err != nil {
+		return nil, errors.Wrap(err, "failed to load the resource controller API")
+	}
+
+	var (
+		mutex      sync.Mutex
+		capacities []zoneCapacity
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(lookupConcurrency)
+	for _, zone := range knownZones(region) {
+		zone := zone
+		g.Go(func() error {
+			pools, err := c.getZoneSystemPools(gctx, client, zone)
+			if err != nil {
+				logrus.Debugf("Failed to get the system pools of zone %s: %v", zone, err)
+				return nil
+			}
+			if pools == nil {
+				logrus.Debugf("No Power VS workspace in zone %s", zone)
+				return nil
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			capacities = append(capacities, poolCapacity(zone, pools, demands))
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(capacities, func(i, j int) bool { return capacities[i].Zone < capacities[j].Zone })
+	return capacities, nil
+}
+
+// getZoneSystemPools returns the system pools of the zone, as seen from one of
+// its workspaces, or nil if the zone has no workspace.
+func (c *BxClient) getZoneSystemPools(ctx context.Context, client *Client, zone string) (models.SystemPools, error) {
+	workspaces, err := client.GetWorkspaces(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaces) == 0 {
+		return nil, nil
+	}
+
+	session, err := ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+		Authenticator: c.Authenticator(),
+		UserAccount:   c.User.Account,
+		Zone:          zone,
+		URL:           c.ServiceURL(powervs.PowerServiceEndpointName, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	configurePISession(session)
+
+	systemPoolClient := instance.NewIBMPISystemPoolClient(ctx, session, workspaces[0].ID)
+	return systemPoolClient.GetSystemPools()
+}
+
+// GetZoneWithCapacity prompts the user for a zone of the region, offering
+// only the zones whose system pools can fit the machine pools of the install
+// config. If the capacity of no zone can be determined, or no zone fits, all
+// of the zones are offered with GetZone.
+func (c *BxClient) GetZoneWithCapacity(region string, ic *types.InstallConfig) (string, error) {
+	demands, err := machinePoolsDemand(ic)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.Infof("Looking up the capacity of the zones of %s", region)
+	capacities, err := c.getZoneCapacities(context.Background(), region, demands)
+	if err != nil {
+		logrus.Warnf("Failed to look up the capacity of the zones: %v", err)
+		return GetZone(region)
+	}
+
+	var options []string
+	for _, capacity := range capacities {
+		if capacity.Fits {
+			options = append(options, fmt.Sprintf("%s (%s available)", capacity.Zone, describeDemands(capacity.Available)))
+		}
+	}
+	if len(options) == 0 {
+		logrus.Warnf("No zone of %s has the capacity for the machine pools (%s)", region, describeDemands(demands))
+		return GetZone(region)
+	}
+
+	var zoneTransform survey.Transformer = func(ans interface{}) interface{} {
+		switch v := ans.(type) {
+		case core.OptionAnswer:
+			return core.OptionAnswer{Value: strings.SplitN(v.Value, " ", 2)[0], Index: v.Index}
+		case string:
+			return strings.SplitN(v, " ", 2)[0]
+		}
+		return ""
+	}
+
+	var zone string
+	err = survey.Ask([]*survey.Question{
+		{
+			Prompt: &survey.Select{
+				Message: "Zone",
+				Help:    "The Power VS zone within the region to be used for installation.\n\nOnly the zones with a workspace and the capacity for the machine pools are listed.",
+				Options: options,
+			},
+			Validate:  survey.Required,
+			Transform: zoneTransform,
+		},
+	}, &zone)
+	if err != nil {
+		return "", err
+	}
+	return zone, nil
+}
@@ -0,0 +1,136 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestMachinePoolsDemand(t *testing.T) {
+	replicas := func(n int64) *int64 { return &n }
+	cases := []struct {
+		name            string
+		defaultPlatform *powervs.MachinePool
+		controlPlane    *types.MachinePool
+		compute         []types.MachinePool
+		expected        []machineDemand
+	}{
+		{
+			name:         "default machine pools",
+			controlPlane: &types.MachinePool{Name: "master", Replicas: replicas(3)},
+			compute:      []types.MachinePool{{Name: "worker", Replicas: replicas(3)}},
+			expected:     []machineDemand{{SystemType: "s922", Cores: 1.75, MemoryGiB: 224}},
+		},
+		{
+			name:         "no compute machines",
+			controlPlane: &types.MachinePool{Name: "master", Replicas: replicas(3)},
+			compute:      []types.MachinePool{{Name: "worker", Replicas: replicas(0)}},
+			expected:     []machineDemand{{SystemType: "s922", Cores: 1, MemoryGiB: 128}},
+		},
+		{
+			name:            "default machine platform",
+			defaultPlatform: &powervs.MachinePool{MemoryGiB: 64, Processors: intstr.FromInt(1), ProcType: machinev1.PowerVSProcessorTypeDedicated},
+			controlPlane:    &types.MachinePool{Name: "master", Replicas: replicas(3)},
+			compute:         []types.MachinePool{{Name: "worker", Replicas: replicas(2)}},
+			expected:        []machineDemand{{SystemType: "s922", Cores: 6, MemoryGiB: 384}},
+		},
+		{
+			name:            "SMT off",
+			defaultPlatform: &powervs.MachinePool{SMTLevel: powervs.SMTLevelOff},
+			controlPlane:    &types.MachinePool{Name: "master", Replicas: replicas(3)},
+			compute:         []types.MachinePool{{Name: "worker", Replicas: replicas(3)}},
+			expected:        []machineDemand{{SystemType: "s922", Cores: 3.5, MemoryGiB: 224}},
+		},
+		{
+			name:         "pools of different system types",
+			controlPlane: &types.MachinePool{Name: "master", Replicas: replicas(3), Platform: types.MachinePoolPlatform{PowerVS: &powervs.MachinePool{SysType: "e980", Processors: intstr.FromString("1.1")}}},
+			compute: []types.MachinePool{
+				{Name: "worker", Replicas: replicas(2)},
+				{Name: "large", Replicas: replicas(1), Platform: types.MachinePoolPlatform{PowerVS: &powervs.MachinePool{MemoryGiB: 128}}},
+			},
+			expected: []machineDemand{
+				{SystemType: "e980", Cores: 1, MemoryGiB: 128},
+				{SystemType: "s922", Cores: 0.75, MemoryGiB: 192},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Platform:     types.Platform{PowerVS: &powervs.Platform{DefaultMachinePlatform: tc.defaultPlatform}},
+				ControlPlane: tc.controlPlane,
+				Compute:      tc.compute,
+			}
+			demands, err := machinePoolsDemand(ic)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, demands)
+		})
+	}
+}
+
+func TestPoolCapacity(t *testing.T) {
+	pools := func(cores float64, memory int64) models.SystemPools {
+		return models.SystemPools{
+			"s922": models.SystemPool{
+				Type: "s922",
+				MaxCoresAvailable: &models.System{
+					Cores:  &cores,
+					Memory: &memory,
+				},
+			},
+		}
+	}
+	demands := []machineDemand{{SystemType: "s922", Cores: 3.5, MemoryGiB: 224}}
+
+	cases := []struct {
+		name     string
+		pools    models.SystemPools
+		demands  []machineDemand
+		expected zoneCapacity
+	}{
+		{
+			name:     "fits",
+			pools:    pools(4, 512),
+			demands:  demands,
+			expected: zoneCapacity{Zone: "dal10", Available: []machineDemand{{SystemType: "s922", Cores: 4, MemoryGiB: 512}}, Fits: true},
+		},
+		{
+			name:     "not enough cores",
+			pools:    pools(3, 512),
+			demands:  demands,
+			expected: zoneCapacity{Zone: "dal10", Available: []machineDemand{{SystemType: "s922", Cores: 3, MemoryGiB: 512}}},
+		},
+		{
+			name:     "not enough memory",
+			pools:    pools(4, 128),
+			demands:  demands,
+			expected: zoneCapacity{Zone: "dal10", Available: []machineDemand{{SystemType: "s922", Cores: 4, MemoryGiB: 128}}},
+		},
+		{
+			name:     "no pool of the system type",
+			pools:    models.SystemPools{},
+			demands:  demands,
+			expected: zoneCapacity{Zone: "dal10", Available: []machineDemand{{SystemType: "s922"}}},
+		},
+		{
+			name:    "no pool of one of the system types",
+			pools:   pools(4, 512),
+			demands: append([]machineDemand{{SystemType: "e980", Cores: 1, MemoryGiB: 32}}, demands...),
+			expected: zoneCapacity{Zone: "dal10", Available: []machineDemand{
+				{SystemType: "e980"},
+				{SystemType: "s922", Cores: 4, MemoryGiB: 512},
+			}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, poolCapacity("dal10", tc.pools, tc.demands))
+		})
+	}
+}
@@ -12,7 +12,7 @@ type pullSecret struct {
 	PullSecret string
 }
 
-var _ asset.Asset = (*pullSecret)(nil)
+var _ asset.InteractiveAsset = (*pullSecret)(nil)
 
 // Dependencies returns no dependencies.
 func (a *pullSecret) Dependencies() []asset.Asset {
@@ -41,3 +41,6 @@ func (a *pullSecret) Generate(asset.Parents) error {
 func (a *pullSecret) Name() string {
 	return "Pull Secret"
 }
+
+// Interactive returns that the asset prompts the user
+func (a *pullSecret) Interactive() {}
@@ -0,0 +1,54 @@
+package installconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// multiArchChecker returns whether the release payload is multi-arch, e.g.
+// releaseimage.IsMultiArch, which fetches its manifest from its registry.
+type multiArchChecker func(ctx context.Context, pullSpec, pullSecret string) (bool, error)
+
+// validateReleaseArchitecture checks that the release payload is multi-arch
+// when a compute pool has a different architecture than the control plane,
+// as only a multi-arch payload provides the images of every architecture. The
+// check is skipped with a warning when the registry of the payload cannot be
+// reached, e.g. when the payload is only available through a mirror.
+func validateReleaseArchitecture(ic *types.InstallConfig, pullSpec string, isMultiArch multiArchChecker) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ic.ControlPlane == nil {
+		return allErrs
+	}
+	heterogeneous := []int{}
+	for i, pool := range ic.Compute {
+		if pool.Architecture != ic.ControlPlane.Architecture {
+			heterogeneous = append(heterogeneous, i)
+		}
+	}
+	if len(heterogeneous) == 0 {
+		return allErrs
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	multi, err := isMultiArch(ctx, pullSpec, ic.PullSecret)
+	if err != nil {
+		logrus.Warnf("Unable to check that the release payload %s is multi-arch: %v", pullSpec, err)
+		return allErrs
+	}
+	if multi {
+		return allErrs
+	}
+	for _, i := range heterogeneous {
+		pool := ic.Compute[i]
+		allErrs = append(allErrs, field.Invalid(field.NewPath("compute").Index(i).Child("architecture"), pool.Architecture,
+			fmt.Sprintf("the release payload %s is not multi-arch; compute pool architecture must match control plane", pullSpec)))
+	}
+	return allErrs
+}
@@ -0,0 +1,65 @@
+package installconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestValidateReleaseArchitecture(t *testing.T) {
+	const pullSpec = "quay.io/openshift-release-dev/ocp-release:4.13.0-x86_64"
+
+	cases := []struct {
+		name        string
+		computeArch types.Architecture
+		multi       bool
+		checkErr    error
+		expected    string
+	}{
+		{
+			name:        "homogeneous cluster",
+			computeArch: types.ArchitectureAMD64,
+		},
+		{
+			name:        "heterogeneous cluster with multi-arch payload",
+			computeArch: types.ArchitectureARM64,
+			multi:       true,
+		},
+		{
+			name:        "heterogeneous cluster with single-arch payload",
+			computeArch: types.ArchitectureARM64,
+			expected:    `^compute\[0\]\.architecture: Invalid value: "arm64": the release payload quay\.io/openshift-release-dev/ocp-release:4\.13\.0-x86_64 is not multi-arch; compute pool architecture must match control plane$`,
+		},
+		{
+			name:        "unreachable registry",
+			computeArch: types.ArchitectureARM64,
+			checkErr:    errors.New("connection refused"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				PullSecret:   `{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`,
+				ControlPlane: &types.MachinePool{Architecture: types.ArchitectureAMD64},
+				Compute:      []types.MachinePool{{Name: "worker", Architecture: tc.computeArch}},
+			}
+			checked := false
+			err := validateReleaseArchitecture(ic, pullSpec, func(_ context.Context, spec, secret string) (bool, error) {
+				checked = true
+				assert.Equal(t, pullSpec, spec)
+				assert.Equal(t, ic.PullSecret, secret)
+				return tc.multi, tc.checkErr
+			}).ToAggregate()
+			assert.Equal(t, tc.computeArch != types.ArchitectureAMD64, checked)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package installconfig
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/secretref"
+)
+
+// resolveSecretReferences sets the secrets of the install config which are
+// referenced by file path or environment variable, checking that the
+// references can be read.
+func resolveSecretReferences(config *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.PullSecretFile != "" {
+		ref := &secretref.Reference{File: config.PullSecretFile}
+		if err := resolveSecretReference(&config.PullSecret, ref, field.NewPath("pullSecretFile")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if p := config.Platform.BareMetal; p != nil {
+		fldPath := field.NewPath("platform", "baremetal", "hosts")
+		for i, host := range p.Hosts {
+			if host == nil {
+				continue
+			}
+			if err := resolveSecretReference(&host.BMC.Password, host.BMC.PasswordRef, fldPath.Index(i).Child("bmc", "passwordRef")); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	if p := config.Platform.Nutanix; p != nil {
+		if err := resolveSecretReference(&p.PrismCentral.Password, p.PrismCentral.PasswordRef, field.NewPath("platform", "nutanix", "prismCentral", "passwordRef")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if p := config.Platform.VSphere; p != nil {
+		fldPath := field.NewPath("platform", "vsphere", "vcenters")
+		for i := range p.VCenters {
+			vcenter := &p.VCenters[i]
+			if err := resolveSecretReference(&vcenter.Password, vcenter.PasswordRef, fldPath.Index(i).Child("passwordRef")); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	return allErrs
+}
+
+// resolveSecretReference sets the secret to the referenced one, if there is a
+// reference. The secret must not also be set inline.
+func resolveSecretReference(secret *string, ref *secretref.Reference, fldPath *field.Path) *field.Error {
+	if ref == nil {
+		return nil
+	}
+	if *secret != "" {
+		return field.Forbidden(fldPath, "the secret must not also be set inline")
+	}
+	value, err := ref.Resolve()
+	if err != nil {
+		return field.Invalid(fldPath, *ref, err.Error())
+	}
+	*secret = value
+	return nil
+}
+
+// hasSecretReferences returns true if any of the secrets of the install
+// config is referenced by file path or environment variable.
+func hasSecretReferences(config *types.InstallConfig) bool {
+	if config.PullSecretFile != "" {
+		return true
+	}
+	if p := config.Platform.BareMetal; p != nil {
+		for _, host := range p.Hosts {
+			if host != nil && host.BMC.PasswordRef != nil {
+				return true
+			}
+		}
+	}
+	if p := config.Platform.Nutanix; p != nil && p.PrismCentral.PasswordRef != nil {
+		return true
+	}
+	if p := config.Platform.VSphere; p != nil {
+		for _, vcenter := range p.VCenters {
+			if vcenter.PasswordRef != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withoutReferencedSecrets returns a copy of the install config without the
+// secrets which are referenced by file path or environment variable, so that
+// they are not written to install-config.yaml.
+func withoutReferencedSecrets(config *types.InstallConfig) (*types.InstallConfig, error) {
+	if !hasSecretReferences(config) {
+		return config, nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	redacted := &types.InstallConfig{}
+	if err := json.Unmarshal(data, redacted); err != nil {
+		return nil, err
+	}
+
+	if redacted.PullSecretFile != "" {
+		redacted.PullSecret = ""
+	}
+	if p := redacted.Platform.BareMetal; p != nil {
+		for _, host := range p.Hosts {
+			if host != nil && host.BMC.PasswordRef != nil {
+				host.BMC.Password = ""
+			}
+		}
+	}
+	if p := redacted.Platform.Nutanix; p != nil && p.PrismCentral.PasswordRef != nil {
+		p.PrismCentral.Password = ""
+	}
+	if p := redacted.Platform.VSphere; p != nil {
+		for i := range p.VCenters {
+			if p.VCenters[i].PasswordRef != nil {
+				p.VCenters[i].Password = ""
+			}
+		}
+	}
+	return redacted, nil
+}
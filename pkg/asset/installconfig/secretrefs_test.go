@@ -0,0 +1,112 @@
+package installconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/nutanix"
+	"github.com/openshift/installer/pkg/types/secretref"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func TestResolveSecretReferences(t *testing.T) {
+	dir := t.TempDir()
+	pullSecretFile := filepath.Join(dir, "pull-secret.json")
+	if err := os.WriteFile(pullSecretFile, []byte(`{"auths":{"example.com":{"auth":"authorization value"}}}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PRISM_CENTRAL_PASSWORD", "prism-password")
+
+	cases := []struct {
+		name           string
+		config         *types.InstallConfig
+		expectedConfig *types.InstallConfig
+		expectedErr    string
+	}{
+		{
+			name:           "no references",
+			config:         &types.InstallConfig{PullSecret: "inline"},
+			expectedConfig: &types.InstallConfig{PullSecret: "inline"},
+		},
+		{
+			name:   "pull secret file",
+			config: &types.InstallConfig{PullSecretFile: pullSecretFile},
+			expectedConfig: &types.InstallConfig{
+				PullSecret:     `{"auths":{"example.com":{"auth":"authorization value"}}}`,
+				PullSecretFile: pullSecretFile,
+			},
+		},
+		{
+			name:        "missing pull secret file",
+			config:      &types.InstallConfig{PullSecretFile: filepath.Join(dir, "missing.json")},
+			expectedErr: `^pullSecretFile: Invalid value: secretref.Reference{File:".*/missing.json", Env:""}: open .*/missing.json: no such file or directory$`,
+		},
+		{
+			name:        "pull secret file and inline pull secret",
+			config:      &types.InstallConfig{PullSecret: "inline", PullSecretFile: pullSecretFile},
+			expectedErr: `^pullSecretFile: Forbidden: the secret must not also be set inline$`,
+		},
+		{
+			name: "nutanix password from the environment",
+			config: &types.InstallConfig{
+				Platform: types.Platform{Nutanix: &nutanix.Platform{PrismCentral: nutanix.PrismCentral{
+					PasswordRef: &secretref.Reference{Env: "PRISM_CENTRAL_PASSWORD"},
+				}}},
+			},
+			expectedConfig: &types.InstallConfig{
+				Platform: types.Platform{Nutanix: &nutanix.Platform{PrismCentral: nutanix.PrismCentral{
+					Password:    "prism-password",
+					PasswordRef: &secretref.Reference{Env: "PRISM_CENTRAL_PASSWORD"},
+				}}},
+			},
+		},
+		{
+			name: "vsphere password from an unset variable",
+			config: &types.InstallConfig{
+				Platform: types.Platform{VSphere: &vsphere.Platform{VCenters: []vsphere.VCenter{{
+					PasswordRef: &secretref.Reference{Env: "UNSET_VCENTER_PASSWORD"},
+				}}}},
+			},
+			expectedErr: `^platform.vsphere.vcenters\[0\].passwordRef: Invalid value: .*: environment variable "UNSET_VCENTER_PASSWORD" is not set$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := resolveSecretReferences(tc.config).ToAggregate()
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.expectedErr, err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, tc.config)
+		})
+	}
+}
+
+func TestWithoutReferencedSecrets(t *testing.T) {
+	config := &types.InstallConfig{
+		PullSecret:     "pull-secret",
+		PullSecretFile: "pull-secret.json",
+		Platform: types.Platform{VSphere: &vsphere.Platform{VCenters: []vsphere.VCenter{
+			{Server: "a", Password: "a-password", PasswordRef: &secretref.Reference{Env: "A_PASSWORD"}},
+			{Server: "b", Password: "b-password"},
+		}}},
+	}
+	redacted, err := withoutReferencedSecrets(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "", redacted.PullSecret)
+	assert.Equal(t, "", redacted.VSphere.VCenters[0].Password)
+	assert.Equal(t, "b-password", redacted.VSphere.VCenters[1].Password)
+
+	// The install config itself keeps the secrets.
+	assert.Equal(t, "pull-secret", config.PullSecret)
+	assert.Equal(t, "a-password", config.VSphere.VCenters[0].Password)
+}
@@ -22,7 +22,7 @@ type sshPublicKey struct {
 	Key string
 }
 
-var _ asset.Asset = (*sshPublicKey)(nil)
+var _ asset.InteractiveAsset = (*sshPublicKey)(nil)
 
 // Dependencies returns no dependencies.
 func (a *sshPublicKey) Dependencies() []asset.Asset {
@@ -107,3 +107,6 @@ func (a *sshPublicKey) Generate(asset.Parents) error {
 func (a sshPublicKey) Name() string {
 	return "SSH Key"
 }
+
+// Interactive returns that the asset prompts the user
+func (a *sshPublicKey) Interactive() {}
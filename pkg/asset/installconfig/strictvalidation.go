@@ -0,0 +1,275 @@
+package installconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/alibabacloud"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/powervs"
+	"github.com/openshift/installer/pkg/types/validation"
+)
+
+// WarningClass is a class of the warnings of the install config validation,
+// which the strict validation turns into errors.
+type WarningClass string
+
+const (
+	// WarningDeprecatedFields warns about the deprecated fields of the
+	// install config, which are upconverted to their replacements.
+	WarningDeprecatedFields WarningClass = "deprecated-fields"
+
+	// WarningSmallDiskSize warns about the machine pools with a disk smaller
+	// than the recommended size.
+	WarningSmallDiskSize WarningClass = "small-disk-size"
+
+	// WarningSingleReplicaControlPlane warns about a control plane with a
+	// single replica on the cloud platforms, which is not highly available.
+	WarningSingleReplicaControlPlane WarningClass = "single-replica-control-plane"
+
+	// WarningReservedNetworkOverlap warns about the networks of the install
+	// config which overlap with the ranges used internally by the network
+	// plugin.
+	WarningReservedNetworkOverlap WarningClass = "reserved-network-overlap"
+
+	// strictValidationAll makes every class of warnings strict.
+	strictValidationAll = "all"
+
+	// recommendedDiskSizeGB is the recommended size of the disk of the
+	// machines, in GB.
+	recommendedDiskSizeGB = 100
+)
+
+// WarningClasses are the classes of warnings which can be made strict.
+var WarningClasses = []WarningClass{
+	WarningDeprecatedFields,
+	WarningSmallDiskSize,
+	WarningSingleReplicaControlPlane,
+	WarningReservedNetworkOverlap,
+}
+
+// StrictValidationAnnotation is the install-config annotation with the
+// comma-separated classes of warnings which fail the validation, in addition
+// to those of the --strict-validation flag.
+const StrictValidationAnnotation = "installer.openshift.io/strict-validation"
+
+// StrictValidation are the classes of warnings which fail the validation of
+// the install config instead of being logged, or "all" for every class. The
+// install config restored from the state file is validated against them
+// again, as they may differ from those of the run which loaded it.
+var StrictValidation []string
+
+// validationWarning is a warning of the install config validation.
+type validationWarning struct {
+	class WarningClass
+	err   *field.Error
+}
+
+// strictWarningClasses returns the classes of warnings made strict by the
+// flag and by the annotation of the install config.
+func strictWarningClasses(config *types.InstallConfig) (sets.String, error) {
+	requested := append([]string{}, StrictValidation...)
+	if value := config.Annotations[StrictValidationAnnotation]; value != "" {
+		requested = append(requested, strings.Split(value, ",")...)
+	}
+
+	known := sets.NewString()
+	for _, class := range WarningClasses {
+		known.Insert(string(class))
+	}
+	classes := sets.NewString()
+	for _, class := range requested {
+		class = strings.TrimSpace(class)
+		switch {
+		case class == "":
+		case class == strictValidationAll:
+			classes.Insert(known.List()...)
+		case known.Has(class):
+			classes.Insert(class)
+		default:
+			return nil, errors.Errorf("unknown class of warnings %q for the strict validation, must be one of %s", class, strings.Join(append(known.List(), strictValidationAll), ", "))
+		}
+	}
+	return classes, nil
+}
+
+// validateWarnings returns the warnings of the install config whose class is
+// made strict as errors, and logs the others if logWarnings is true.
+func validateWarnings(config *types.InstallConfig, logWarnings bool) error {
+	strict, err := strictWarningClasses(config)
+	if err != nil {
+		return err
+	}
+
+	allErrs := field.ErrorList{}
+	for _, warning := range validationWarnings(config) {
+		if !strict.Has(string(warning.class)) {
+			if logWarnings {
+				logrus.Warnf("%s: %s", warning.err.Field, warning.err.Detail)
+			}
+			continue
+		}
+		warning.err.Detail = fmt.Sprintf("%s (a %s warning, which is an error with the strict validation)", warning.err.Detail, warning.class)
+		allErrs = append(allErrs, warning.err)
+	}
+	return allErrs.ToAggregate()
+}
+
+// validationWarnings returns the warnings of the install config.
+func validationWarnings(config *types.InstallConfig) []validationWarning {
+	var warnings []validationWarning
+	add := func(class WarningClass, errs ...*field.Error) {
+		for _, err := range errs {
+			warnings = append(warnings, validationWarning{class: class, err: err})
+		}
+	}
+
+	add(WarningDeprecatedFields, deprecatedFields(config)...)
+
+	add(WarningSmallDiskSize, smallDiskSizes(defaultMachinePlatform(config.Platform), field.NewPath("platform", config.Platform.Name(), "defaultMachinePlatform"))...)
+	if config.ControlPlane != nil {
+		add(WarningSmallDiskSize, smallDiskSizes(config.ControlPlane.Platform, field.NewPath("controlPlane", "platform", config.Platform.Name()))...)
+	}
+	for i, pool := range config.Compute {
+		add(WarningSmallDiskSize, smallDiskSizes(pool.Platform, field.NewPath("compute").Index(i).Child("platform", config.Platform.Name()))...)
+	}
+
+	if config.ControlPlane != nil && config.ControlPlane.Replicas != nil && *config.ControlPlane.Replicas == 1 {
+		switch config.Platform.Name() {
+		case alibabacloud.Name, aws.Name, azure.Name, gcp.Name, ibmcloud.Name, powervs.Name:
+			add(WarningSingleReplicaControlPlane, field.Invalid(field.NewPath("controlPlane", "replicas"), *config.ControlPlane.Replicas, "a single control plane replica is not highly available"))
+		}
+	}
+
+	add(WarningReservedNetworkOverlap, validation.NetworkingWarnings(config.Networking)...)
+	return warnings
+}
+
+// deprecatedFields returns a warning for each deprecated field set in the
+// install config. The fields are kept after their upconversion.
+func deprecatedFields(config *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	deprecated := func(set bool, fldPath *field.Path, replacement string) {
+		if !set {
+			return
+		}
+		detail := "the field is deprecated"
+		if replacement != "" {
+			detail = fmt.Sprintf("the field is deprecated, use %s instead", replacement)
+		}
+		allErrs = append(allErrs, field.Forbidden(fldPath, detail))
+	}
+
+	if n := config.Networking; n != nil {
+		fldPath := field.NewPath("networking")
+		deprecated(n.DeprecatedMachineCIDR != nil, fldPath.Child("machineCIDR"), "networking.machineNetwork")
+		deprecated(n.DeprecatedType != "", fldPath.Child("type"), "networking.networkType")
+		deprecated(n.DeprecatedServiceCIDR != nil, fldPath.Child("serviceCIDR"), "networking.serviceNetwork")
+		deprecated(len(n.DeprecatedClusterNetworks) > 0, fldPath.Child("clusterNetworks"), "networking.clusterNetwork")
+		for i, entry := range n.ClusterNetwork {
+			deprecated(entry.DeprecatedHostSubnetLength != 0, fldPath.Child("clusterNetwork").Index(i).Child("hostSubnetLength"), "networking.clusterNetwork.hostPrefix")
+		}
+	}
+
+	platform := config.Platform
+	if p := platform.AWS; p != nil {
+		fldPath := field.NewPath("platform", "aws")
+		deprecated(p.ExperimentalPropagateUserTag != nil, fldPath.Child("experimentalPropagateUserTags"), "platform.aws.propagateUserTags")
+	}
+	if p := platform.BareMetal; p != nil {
+		fldPath := field.NewPath("platform", "baremetal")
+		deprecated(p.DeprecatedProvisioningHostIP != "", fldPath.Child("provisioningHostIP"), "platform.baremetal.clusterProvisioningIP")
+		deprecated(p.DeprecatedProvisioningDHCPExternal, fldPath.Child("provisioningDHCPExternal"), "platform.baremetal.provisioningNetwork")
+		deprecated(p.DeprecatedAPIVIP != "", fldPath.Child("apiVIP"), "platform.baremetal.apiVIPs")
+		deprecated(p.DeprecatedIngressVIP != "", fldPath.Child("ingressVIP"), "platform.baremetal.ingressVIPs")
+	}
+	if p := platform.Nutanix; p != nil {
+		fldPath := field.NewPath("platform", "nutanix")
+		deprecated(p.DeprecatedAPIVIP != "", fldPath.Child("apiVIP"), "platform.nutanix.apiVIPs")
+		deprecated(p.DeprecatedIngressVIP != "", fldPath.Child("ingressVIP"), "platform.nutanix.ingressVIPs")
+	}
+	if p := platform.OpenStack; p != nil {
+		fldPath := field.NewPath("platform", "openstack")
+		deprecated(p.DeprecatedRegion != "", fldPath.Child("region"), "")
+		deprecated(p.DeprecatedFlavorName != "", fldPath.Child("computeFlavor"), "platform.openstack.defaultMachinePlatform.type")
+		deprecated(p.DeprecatedLbFloatingIP != "", fldPath.Child("lbFloatingIP"), "platform.openstack.apiFloatingIP")
+		deprecated(p.DeprecatedTrunkSupport != "", fldPath.Child("trunkSupport"), "")
+		deprecated(p.DeprecatedOctaviaSupport != "", fldPath.Child("octaviaSupport"), "")
+		deprecated(p.DeprecatedAPIVIP != "", fldPath.Child("apiVIP"), "platform.openstack.apiVIPs")
+		deprecated(p.DeprecatedIngressVIP != "", fldPath.Child("ingressVIP"), "platform.openstack.ingressVIPs")
+	}
+	if p := platform.Ovirt; p != nil {
+		fldPath := field.NewPath("platform", "ovirt")
+		deprecated(p.DeprecatedAPIVIP != "", fldPath.Child("api_vip"), "platform.ovirt.api_vips")
+		deprecated(p.DeprecatedIngressVIP != "", fldPath.Child("ingress_vip"), "platform.ovirt.ingress_vips")
+	}
+	if p := platform.VSphere; p != nil {
+		fldPath := field.NewPath("platform", "vsphere")
+		deprecated(p.DeprecatedVCenter != "", fldPath.Child("vCenter"), "platform.vsphere.vcenters")
+		deprecated(p.DeprecatedUsername != "", fldPath.Child("username"), "platform.vsphere.vcenters")
+		deprecated(p.DeprecatedPassword != "", fldPath.Child("password"), "platform.vsphere.vcenters")
+		deprecated(p.DeprecatedDatacenter != "", fldPath.Child("datacenter"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedDefaultDatastore != "", fldPath.Child("defaultDatastore"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedFolder != "", fldPath.Child("folder"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedCluster != "", fldPath.Child("cluster"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedResourcePool != "", fldPath.Child("resourcePool"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedNetwork != "", fldPath.Child("network"), "platform.vsphere.failureDomains")
+		deprecated(p.DeprecatedAPIVIP != "", fldPath.Child("apiVIP"), "platform.vsphere.apiVIPs")
+		deprecated(p.DeprecatedIngressVIP != "", fldPath.Child("ingressVIP"), "platform.vsphere.ingressVIPs")
+	}
+	return allErrs
+}
+
+// defaultMachinePlatform returns the default machine platform of the
+// platform, as the platform of a machine pool.
+func defaultMachinePlatform(platform types.Platform) types.MachinePoolPlatform {
+	pool := types.MachinePoolPlatform{}
+	switch {
+	case platform.AWS != nil:
+		pool.AWS = platform.AWS.DefaultMachinePlatform
+	case platform.Azure != nil:
+		pool.Azure = platform.Azure.DefaultMachinePlatform
+	case platform.GCP != nil:
+		pool.GCP = platform.GCP.DefaultMachinePlatform
+	case platform.Nutanix != nil:
+		pool.Nutanix = platform.Nutanix.DefaultMachinePlatform
+	case platform.VSphere != nil:
+		pool.VSphere = platform.VSphere.DefaultMachinePlatform
+	}
+	return pool
+}
+
+// smallDiskSizes returns a warning for the disk of the machine pool platform
+// when it is smaller than the recommended size. The root volumes of OpenStack
+// are already checked against the recommended size by its validation.
+func smallDiskSizes(pool types.MachinePoolPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	check := func(size int64, sizePath *field.Path) {
+		if size != 0 && size < recommendedDiskSizeGB {
+			allErrs = append(allErrs, field.Invalid(sizePath, size, fmt.Sprintf("the disk is smaller than the recommended size of %d GB", recommendedDiskSizeGB)))
+		}
+	}
+
+	switch {
+	case pool.AWS != nil:
+		check(int64(pool.AWS.EC2RootVolume.Size), fldPath.Child("rootVolume", "size"))
+	case pool.Azure != nil:
+		check(int64(pool.Azure.OSDisk.DiskSizeGB), fldPath.Child("osDisk", "diskSizeGB"))
+	case pool.GCP != nil:
+		check(pool.GCP.OSDisk.DiskSizeGB, fldPath.Child("osDisk", "DiskSizeGB"))
+	case pool.Nutanix != nil:
+		check(pool.Nutanix.OSDisk.DiskSizeGiB, fldPath.Child("osDisk", "diskSizeGiB"))
+	case pool.VSphere != nil:
+		check(int64(pool.VSphere.OSDisk.DiskSizeGB), fldPath.Child("osDisk", "diskSizeGB"))
+	}
+	return allErrs
+}
@@ -0,0 +1,144 @@
+package installconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestValidateWarnings(t *testing.T) {
+	cases := []struct {
+		name        string
+		flag        []string
+		annotations map[string]string
+		edit        func(ic *types.InstallConfig)
+		expected    string
+	}{
+		{
+			name: "no warnings",
+			flag: []string{"all"},
+		},
+		{
+			name: "deprecated field not strict",
+			edit: func(ic *types.InstallConfig) {
+				ic.Networking.DeprecatedMachineCIDR = ipnet.MustParseCIDR("10.0.0.0/16")
+			},
+		},
+		{
+			name: "deprecated field strict by the flag",
+			flag: []string{"deprecated-fields"},
+			edit: func(ic *types.InstallConfig) {
+				ic.Networking.DeprecatedMachineCIDR = ipnet.MustParseCIDR("10.0.0.0/16")
+			},
+			expected: `^networking\.machineCIDR: Forbidden: the field is deprecated, use networking\.machineNetwork instead \(a deprecated-fields warning, which is an error with the strict validation\)$`,
+		},
+		{
+			name:        "deprecated field strict by the annotation",
+			annotations: map[string]string{StrictValidationAnnotation: "small-disk-size, deprecated-fields"},
+			edit: func(ic *types.InstallConfig) {
+				ic.Platform.AWS.ExperimentalPropagateUserTag = pointer.Bool(true)
+			},
+			expected: `^platform\.aws\.experimentalPropagateUserTags: Forbidden: the field is deprecated, use platform\.aws\.propagateUserTags instead \(a deprecated-fields warning, which is an error with the strict validation\)$`,
+		},
+		{
+			name: "small disk of another class strict",
+			flag: []string{"deprecated-fields"},
+			edit: func(ic *types.InstallConfig) {
+				ic.ControlPlane.Platform.AWS = &aws.MachinePool{EC2RootVolume: aws.EC2RootVolume{Size: 50}}
+			},
+		},
+		{
+			name: "small disks strict",
+			flag: []string{"all"},
+			edit: func(ic *types.InstallConfig) {
+				ic.Platform.AWS.DefaultMachinePlatform = &aws.MachinePool{EC2RootVolume: aws.EC2RootVolume{Size: 64}}
+				ic.Compute[0].Platform.AWS = &aws.MachinePool{EC2RootVolume: aws.EC2RootVolume{Size: 120}}
+			},
+			expected: `^platform\.aws\.defaultMachinePlatform\.rootVolume\.size: Invalid value: 64: the disk is smaller than the recommended size of 100 GB \(a small-disk-size warning, which is an error with the strict validation\)$`,
+		},
+		{
+			name: "single replica control plane strict",
+			flag: []string{"single-replica-control-plane"},
+			edit: func(ic *types.InstallConfig) {
+				ic.ControlPlane.Replicas = pointer.Int64(1)
+			},
+			expected: `^controlPlane\.replicas: Invalid value: 1: a single control plane replica is not highly available \(a single-replica-control-plane warning, which is an error with the strict validation\)$`,
+		},
+		{
+			name: "single replica control plane on platform none",
+			flag: []string{"all"},
+			edit: func(ic *types.InstallConfig) {
+				ic.Platform = types.Platform{None: &none.Platform{}}
+				ic.ControlPlane.Replicas = pointer.Int64(1)
+			},
+		},
+		{
+			name: "reserved network overlap not strict",
+			edit: func(ic *types.InstallConfig) {
+				ic.Networking.NetworkType = "OVNKubernetes"
+				ic.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("100.64.0.0/24")}}
+			},
+		},
+		{
+			name: "reserved network overlap strict",
+			flag: []string{"reserved-network-overlap"},
+			edit: func(ic *types.InstallConfig) {
+				ic.Networking.NetworkType = "OVNKubernetes"
+				ic.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("100.64.0.0/24")}}
+			},
+			expected: `^networking\.machineNetwork\[0\]: Invalid value: "100\.64\.0\.0/24": machine network overlaps with the OVN-Kubernetes join subnet 100\.64\.0\.0/16, which must then be changed in the configuration of the cluster network operator \(a reserved-network-overlap warning, which is an error with the strict validation\)$`,
+		},
+		{
+			name:     "unknown class",
+			flag:     []string{"small-disks"},
+			expected: `^unknown class of warnings "small-disks" for the strict validation, must be one of deprecated-fields, reserved-network-overlap, single-replica-control-plane, small-disk-size, all$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			StrictValidation = tc.flag
+			defer func() { StrictValidation = nil }()
+			ic := &types.InstallConfig{
+				ObjectMeta:   metav1.ObjectMeta{Annotations: tc.annotations},
+				Networking:   &types.Networking{},
+				Platform:     types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+				ControlPlane: &types.MachinePool{Name: "master", Replicas: pointer.Int64(3)},
+				Compute:      []types.MachinePool{{Name: "worker", Replicas: pointer.Int64(3)}},
+			}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+
+			err := validateWarnings(ic, true)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRevalidateInstallConfig(t *testing.T) {
+	ic := &InstallConfig{
+		AssetBase: AssetBase{
+			Config: &types.InstallConfig{
+				Networking:   &types.Networking{DeprecatedMachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16")},
+				Platform:     types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+				ControlPlane: &types.MachinePool{Name: "master", Replicas: pointer.Int64(3)},
+			},
+		},
+	}
+	assert.NoError(t, ic.Revalidate())
+
+	StrictValidation = []string{"deprecated-fields"}
+	defer func() { StrictValidation = nil }()
+	assert.Regexp(t, `^invalid install config: networking\.machineCIDR: Forbidden: the field is deprecated`, ic.Revalidate())
+}
@@ -0,0 +1,174 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25/mo"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+const (
+	computePoliciesPath = "/api/vcenter/compute/policies"
+
+	// vmAntiAffinityCapability is the capability of the compute policies
+	// keeping the virtual machines with a tag on separate hosts.
+	vmAntiAffinityCapability = "com.vmware.vcenter.compute.policies.capabilities.vm_vm_anti_affinity"
+
+	// computePolicyVersion is the first vCenter version supporting the
+	// compute policies through the /api endpoint.
+	computePolicyVersion = "8.0.0"
+)
+
+// ComputePolicy is a compute policy of vCenter.
+type ComputePolicy struct {
+	Policy      string `json:"policy"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Capability  string `json:"capability"`
+}
+
+// ControlPlaneAntiAffinityName returns the name of the DRS rule, or of the
+// compute policy and of its tag, which keep the control plane machines of the
+// cluster on separate hosts.
+func ControlPlaneAntiAffinityName(infraID string) string {
+	return fmt.Sprintf("%s-control-plane-anti-affinity", infraID)
+}
+
+// CreateAntiAffinityComputePolicy creates the compute policy with the name,
+// keeping the virtual machines with the tag on separate hosts, unless it
+// already exists.
+func CreateAntiAffinityComputePolicy(ctx context.Context, client *rest.Client, name, tagID string) error {
+	policy, err := FindComputePolicy(ctx, client, name)
+	if err != nil || policy != nil {
+		return err
+	}
+
+	spec := struct {
+		Capability  string `json:"capability"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		VMTag       string `json:"vm_tag"`
+	}{
+		Capability:  vmAntiAffinityCapability,
+		Name:        name,
+		Description: "Keeps the control plane machines of an OpenShift cluster on separate hosts",
+		VMTag:       tagID,
+	}
+	resource := client.Resource(computePoliciesPath)
+	if err := client.Do(ctx, resource.Request(http.MethodPost, spec), nil); err != nil {
+		return errors.Wrapf(err, "failed to create compute policy %s", name)
+	}
+	return nil
+}
+
+// FindComputePolicy returns the compute policy with the name, or nil when
+// there is none.
+func FindComputePolicy(ctx context.Context, client *rest.Client, name string) (*ComputePolicy, error) {
+	var policies []ComputePolicy
+	resource := client.Resource(computePoliciesPath)
+	if err := client.Do(ctx, resource.Request(http.MethodGet), &policies); err != nil {
+		return nil, errors.Wrap(err, "failed to list the compute policies")
+	}
+	for i := range policies {
+		if policies[i].Name == name {
+			return &policies[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteComputePolicy deletes the compute policy with the name, if it exists.
+func DeleteComputePolicy(ctx context.Context, client *rest.Client, name string) error {
+	policy, err := FindComputePolicy(ctx, client, name)
+	if err != nil || policy == nil {
+		return err
+	}
+	resource := client.Resource(computePoliciesPath + "/" + url.PathEscape(policy.Policy))
+	if err := client.Do(ctx, resource.Request(http.MethodDelete), nil); err != nil {
+		return errors.Wrapf(err, "failed to delete compute policy %s", name)
+	}
+	return nil
+}
+
+// validateControlPlaneAntiAffinity checks that the compute cluster of the
+// failure domain can keep its control plane machines on separate hosts: it
+// has as many usable hosts as control plane machines, and DRS enforces the
+// anti-affinity rules or vCenter supports the compute policies.
+func validateControlPlaneAntiAffinity(validationCtx *validationContext, failureDomain *vsphere.FailureDomain, demand *capacityDemand, policy vsphere.AntiAffinityPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == "" || demand == nil || demand.ControlPlaneMachines < 2 {
+		return allErrs
+	}
+	computeClusterField := fldPath.Child("topology", "computeCluster")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+	defer cancel()
+
+	clusterObj, err := validationCtx.Finder.ClusterComputeResource(ctx, failureDomain.Topology.ComputeCluster)
+	if err != nil {
+		return append(allErrs, field.Invalid(computeClusterField, failureDomain.Topology.ComputeCluster, err.Error()))
+	}
+	var clusterMo mo.ClusterComputeResource
+	if err := clusterObj.Properties(ctx, clusterObj.Reference(), []string{"host", "configurationEx"}, &clusterMo); err != nil {
+		return append(allErrs, field.InternalError(computeClusterField, err))
+	}
+
+	switch policy {
+	case vsphere.AntiAffinityPolicyDRSRule:
+		config, ok := clusterMo.ConfigurationEx.(*vim25types.ClusterConfigInfoEx)
+		if !ok || config.DrsConfig.Enabled == nil || !*config.DrsConfig.Enabled {
+			allErrs = append(allErrs, field.Invalid(computeClusterField, failureDomain.Topology.ComputeCluster,
+				"DRS must be enabled in the compute cluster to enforce the anti-affinity rule of the control plane machines"))
+		}
+	case vsphere.AntiAffinityPolicyComputePolicy:
+		if supported, err := vCenterVersionAtLeast(validationCtx, computePolicyVersion); err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+		} else if !supported {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("platform", "vsphere", "controlPlaneAntiAffinity"), policy,
+				fmt.Sprintf("the compute policies require vCenter %s or later, but vCenter is version %s", computePolicyVersion, validationCtx.Client.ServiceContent.About.Version)))
+		}
+	}
+
+	var hosts []mo.HostSystem
+	if len(clusterMo.Host) > 0 {
+		if err := property.DefaultCollector(validationCtx.Client).Retrieve(ctx, clusterMo.Host, []string{"name", "runtime"}, &hosts); err != nil {
+			return append(allErrs, field.InternalError(computeClusterField, err))
+		}
+	}
+	usable := int64(0)
+	for _, host := range hosts {
+		if host.Runtime.ConnectionState == vim25types.HostSystemConnectionStateConnected && !host.Runtime.InMaintenanceMode {
+			usable++
+		}
+	}
+	if usable < demand.ControlPlaneMachines {
+		allErrs = append(allErrs, field.Invalid(computeClusterField, failureDomain.Topology.ComputeCluster,
+			fmt.Sprintf("compute cluster has %d usable hosts, but the anti-affinity of the %d control plane machines of the failure domain requires one host for each", usable, demand.ControlPlaneMachines)))
+	}
+	return allErrs
+}
+
+// vCenterVersionAtLeast returns whether the version of vCenter is at least
+// the minimum version.
+func vCenterVersionAtLeast(validationCtx *validationContext, minimum string) (bool, error) {
+	constraints, err := version.NewConstraint(fmt.Sprintf(">= %s", minimum))
+	if err != nil {
+		return false, err
+	}
+	vCenterVersion, err := version.NewVersion(validationCtx.Client.ServiceContent.About.Version)
+	if err != nil {
+		return false, err
+	}
+	return constraints.Check(vCenterVersion), nil
+}
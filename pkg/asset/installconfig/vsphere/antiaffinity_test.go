@@ -0,0 +1,80 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func TestValidateControlPlaneAntiAffinity(t *testing.T) {
+	fldPath := field.NewPath("platform", "vsphere", "failureDomains").Index(0)
+
+	cases := []struct {
+		name                 string
+		policy               vsphere.AntiAffinityPolicy
+		controlPlaneMachines int64
+		computeCluster       string
+		expectErr            string
+	}{
+		{
+			name:                 "no anti-affinity",
+			controlPlaneMachines: 5,
+		},
+		{
+			name:                 "DRS rule",
+			policy:               vsphere.AntiAffinityPolicyDRSRule,
+			controlPlaneMachines: 3,
+		},
+		{
+			name:                 "single control plane machine",
+			policy:               vsphere.AntiAffinityPolicyDRSRule,
+			controlPlaneMachines: 1,
+			computeCluster:       "/DC0/host/invalid-cluster",
+		},
+		{
+			name:                 "not enough hosts",
+			policy:               vsphere.AntiAffinityPolicyDRSRule,
+			controlPlaneMachines: 4,
+			expectErr:            `^platform\.vsphere\.failureDomains\[0\]\.topology\.computeCluster: Invalid value: "/DC0/host/DC0_C0": compute cluster has 3 usable hosts, but the anti-affinity of the 4 control plane machines of the failure domain requires one host for each$`,
+		},
+		{
+			name:                 "compute policy before vCenter 8",
+			policy:               vsphere.AntiAffinityPolicyComputePolicy,
+			controlPlaneMachines: 3,
+			expectErr:            `^platform\.vsphere\.controlPlaneAntiAffinity: Invalid value: "ComputePolicy": the compute policies require vCenter 8\.0\.0 or later, but vCenter is version 7\.0\.2$`,
+		},
+		{
+			name:                 "compute cluster not found",
+			policy:               vsphere.AntiAffinityPolicyDRSRule,
+			controlPlaneMachines: 3,
+			computeCluster:       "/DC0/host/invalid-cluster",
+			expectErr:            `^platform\.vsphere\.failureDomains\[0\]\.topology\.computeCluster: Invalid value: "/DC0/host/invalid-cluster": cluster '/DC0/host/invalid-cluster' not found$`,
+		},
+	}
+
+	validationCtx, server, _, err := simulatorHelper(t, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failureDomain := validMultiVCenterPlatform().FailureDomains[0]
+			if tc.computeCluster != "" {
+				failureDomain.Topology.ComputeCluster = tc.computeCluster
+			}
+			demand := &capacityDemand{Machines: tc.controlPlaneMachines, ControlPlaneMachines: tc.controlPlaneMachines}
+
+			err := validateControlPlaneAntiAffinity(validationCtx, &failureDomain, demand, tc.policy, fldPath).ToAggregate()
+			if tc.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/mo"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// The sizing of machine pools which do not set it, kept in sync with the
+// defaults of the machines assets.
+const (
+	defaultNumCPUs    = 4
+	defaultMemoryMiB  = 16384
+	defaultDiskSizeGB = 120
+)
+
+// capacityDemand is the capacity which the machines placed in a failure
+// domain require.
+type capacityDemand struct {
+	Machines  int64
+	NumCPUs   int64
+	MemoryMiB int64
+	DiskGiB   int64
+
+	// ControlPlaneMachines are the control plane machines among the
+	// machines, which the anti-affinity rules keep on separate hosts.
+	ControlPlaneMachines int64
+}
+
+func (d *capacityDemand) add(pool *vsphere.MachinePool) {
+	d.Machines++
+	d.NumCPUs += int64(pool.NumCPUs)
+	d.MemoryMiB += pool.MemoryMiB
+	d.DiskGiB += int64(pool.OSDisk.DiskSizeGB)
+}
+
+// capacityDemands returns the capacity required in each failure domain by the
+// bootstrap, control plane and compute machines. Machines are spread over the
+// zones of their pool the same way the machines assets place them.
+func capacityDemands(ic *types.InstallConfig) map[string]*capacityDemand {
+	platform := ic.Platform.VSphere
+	demands := make(map[string]*capacityDemand, len(platform.FailureDomains))
+	if len(platform.FailureDomains) == 0 {
+		return demands
+	}
+	for _, failureDomain := range platform.FailureDomains {
+		demands[failureDomain.Name] = &capacityDemand{}
+	}
+
+	place := func(pool *types.MachinePool, controlPlane bool) {
+		if pool == nil || pool.Replicas == nil {
+			return
+		}
+		mpool := vsphere.MachinePool{
+			NumCPUs:   defaultNumCPUs,
+			MemoryMiB: defaultMemoryMiB,
+			OSDisk:    vsphere.OSDisk{DiskSizeGB: defaultDiskSizeGB},
+		}
+		mpool.Set(platform.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.VSphere)
+
+		zones := mpool.Zones
+		if len(zones) == 0 {
+			for _, failureDomain := range platform.FailureDomains {
+				zones = append(zones, failureDomain.Name)
+			}
+		}
+		if controlPlane {
+			// The bootstrap machine is placed with the first control plane
+			// machine.
+			if demand, ok := demands[zones[0]]; ok {
+				demand.add(&mpool)
+			}
+		}
+		for i := int64(0); i < *pool.Replicas; i++ {
+			if demand, ok := demands[zones[i%int64(len(zones))]]; ok {
+				demand.add(&mpool)
+				if controlPlane {
+					demand.ControlPlaneMachines++
+				}
+			}
+		}
+	}
+
+	place(ic.ControlPlane, true)
+	for i := range ic.Compute {
+		place(&ic.Compute[i], false)
+	}
+	return demands
+}
+
+// validateFailureDomainCapacity checks that the datastore, the resource pool
+// and the compute cluster of the failure domain have room for the machines
+// placed in it. Thin provisioned disks and CPU, which are overcommitted by
+// vSphere, only produce warnings.
+func validateFailureDomainCapacity(validationCtx *validationContext, failureDomain *vsphere.FailureDomain, demand *capacityDemand, diskType vsphere.DiskType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if demand == nil || demand.Machines == 0 {
+		return allErrs
+	}
+	topologyField := fldPath.Child("topology")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+	defer cancel()
+
+	if datastore, err := getDatastoreMo(ctx, validationCtx, failureDomain.Topology.Datacenter, failureDomain.Topology.Datastore); err != nil {
+		allErrs = append(allErrs, field.InternalError(topologyField.Child("datastore"), err))
+	} else {
+		freeGiB := datastore.Summary.FreeSpace / (1024 * 1024 * 1024)
+		if freeGiB < demand.DiskGiB {
+			msg := fmt.Sprintf("datastore has %d GiB free, but the %d machines of the failure domain require %d GiB", freeGiB, demand.Machines, demand.DiskGiB)
+			if diskType == vsphere.DiskTypeThin || diskType == "" {
+				logrus.Warnf("%s: %s; the thin provisioned disks may run out of space", topologyField.Child("datastore"), msg)
+			} else {
+				allErrs = append(allErrs, field.Invalid(topologyField.Child("datastore"), failureDomain.Topology.Datastore, msg))
+			}
+		}
+	}
+
+	resourcePool := fmt.Sprintf("%s/Resources", failureDomain.Topology.ComputeCluster)
+	if len(failureDomain.Topology.ResourcePool) != 0 {
+		resourcePool = failureDomain.Topology.ResourcePool
+	}
+	resourcePoolObj, err := validationCtx.Finder.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return append(allErrs, field.Invalid(topologyField.Child("resourcePool"), resourcePool, err.Error()))
+	}
+	var resourcePoolMo mo.ResourcePool
+	if err := resourcePoolObj.Properties(ctx, resourcePoolObj.Reference(), []string{"runtime"}, &resourcePoolMo); err != nil {
+		return append(allErrs, field.InternalError(topologyField.Child("resourcePool"), err))
+	}
+
+	memory := resourcePoolMo.Runtime.Memory
+	if memory.MaxUsage > 0 {
+		freeMiB := (memory.MaxUsage - memory.OverallUsage) / (1024 * 1024)
+		if freeMiB < demand.MemoryMiB {
+			allErrs = append(allErrs, field.Invalid(topologyField.Child("resourcePool"), resourcePool,
+				fmt.Sprintf("resource pool has %d MiB of memory available, but the %d machines of the failure domain require %d MiB", freeMiB, demand.Machines, demand.MemoryMiB)))
+		}
+	}
+
+	clusterObj, err := validationCtx.Finder.ClusterComputeResource(ctx, failureDomain.Topology.ComputeCluster)
+	if err != nil {
+		return append(allErrs, field.Invalid(topologyField.Child("computeCluster"), failureDomain.Topology.ComputeCluster, err.Error()))
+	}
+	var clusterMo mo.ClusterComputeResource
+	if err := clusterObj.Properties(ctx, clusterObj.Reference(), []string{"summary"}, &clusterMo); err != nil {
+		return append(allErrs, field.InternalError(topologyField.Child("computeCluster"), err))
+	}
+	if clusterMo.Summary == nil {
+		return allErrs
+	}
+	summary := clusterMo.Summary.GetComputeResourceSummary()
+	cpu := resourcePoolMo.Runtime.Cpu
+	if summary.NumCpuCores > 0 && cpu.MaxUsage > 0 {
+		mhzPerCore := int64(summary.TotalCpu) / int64(summary.NumCpuCores)
+		freeMHz := cpu.MaxUsage - cpu.OverallUsage
+		if requiredMHz := demand.NumCPUs * mhzPerCore; freeMHz < requiredMHz {
+			logrus.Warnf("%s: resource pool %s has %d MHz of CPU available, but the %d vCPUs of the failure domain can use up to %d MHz",
+				topologyField.Child("resourcePool"), resourcePool, freeMHz, demand.NumCPUs, requiredMHz)
+		}
+	}
+
+	return allErrs
+}
+
+// getDatastoreMo returns the summary of the named datastore in the datacenter.
+func getDatastoreMo(ctx context.Context, validationCtx *validationContext, datacenterName, datastoreName string) (*mo.Datastore, error) {
+	dataCenter, err := validationCtx.Finder.Datacenter(ctx, datacenterName)
+	if err != nil {
+		return nil, err
+	}
+	datastores, err := validationCtx.Finder.DatastoreList(ctx, fmt.Sprintf("%s/datastore/...", dataCenter.InventoryPath))
+	if err != nil {
+		return nil, err
+	}
+	for _, datastore := range datastores {
+		if datastore.InventoryPath == datastoreName || datastore.Name() == datastoreName {
+			var datastoreMo mo.Datastore
+			if err := datastore.Properties(ctx, datastore.Reference(), []string{"summary"}, &datastoreMo); err != nil {
+				return nil, err
+			}
+			return &datastoreMo, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find datastore %s", datastoreName)
+}
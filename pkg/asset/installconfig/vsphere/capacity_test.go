@@ -0,0 +1,59 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func TestCapacityDemands(t *testing.T) {
+	cases := []struct {
+		name     string
+		edit     func(ic *types.InstallConfig)
+		expected map[string]*capacityDemand
+	}{
+		{
+			name: "default sizing spread over failure domains",
+			expected: map[string]*capacityDemand{
+				"test-east-1a": {Machines: 5, NumCPUs: 20, MemoryMiB: 81920, DiskGiB: 600, ControlPlaneMachines: 2},
+				"test-east-2a": {Machines: 2, NumCPUs: 8, MemoryMiB: 32768, DiskGiB: 240, ControlPlaneMachines: 1},
+			},
+		},
+		{
+			name: "machine pool sizing and zones",
+			edit: func(ic *types.InstallConfig) {
+				ic.VSphere.DefaultMachinePlatform = &vsphere.MachinePool{MemoryMiB: 8192}
+				ic.Compute[0].Platform.VSphere = &vsphere.MachinePool{
+					NumCPUs: 8,
+					OSDisk:  vsphere.OSDisk{DiskSizeGB: 200},
+					Zones:   []string{"test-east-2a"},
+				}
+			},
+			expected: map[string]*capacityDemand{
+				"test-east-1a": {Machines: 3, NumCPUs: 12, MemoryMiB: 24576, DiskGiB: 360, ControlPlaneMachines: 2},
+				"test-east-2a": {Machines: 4, NumCPUs: 28, MemoryMiB: 32768, DiskGiB: 720, ControlPlaneMachines: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := validIPIInstallConfig()
+			ic.VSphere = validMultiVCenterPlatform()
+			secondDomain := ic.VSphere.FailureDomains[0]
+			secondDomain.Name = "test-east-2a"
+			ic.VSphere.FailureDomains = append(ic.VSphere.FailureDomains, secondDomain)
+			ic.ControlPlane = &types.MachinePool{Replicas: pointer.Int64(3)}
+			ic.Compute = []types.MachinePool{{Name: "worker", Replicas: pointer.Int64(3)}}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+
+			assert.Equal(t, tc.expected, capacityDemands(ic))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vapi/rest"
+)
+
+const (
+	libraryPath     = "/com/vmware/content/library"
+	libraryItemPath = "/com/vmware/content/library/item"
+
+	// ovfLibraryItemType is the type of the library items of OVF templates.
+	ovfLibraryItemType = "ovf"
+
+	// templateChecksumPrefix prefixes the sha256 checksum of the OVA in the
+	// description of the templates imported in a content library.
+	templateChecksumPrefix = "openshift-installer rhcos sha256:"
+)
+
+// LibraryItem is an item of a content library.
+type LibraryItem struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+// TemplateDescription returns the description of the template imported in a
+// content library from the OVA with the sha256 checksum, through which the
+// template is found by later installs.
+func TemplateDescription(checksum string) string {
+	return templateChecksumPrefix + checksum
+}
+
+// GetContentLibraryID returns the ID of the content library with the name.
+func GetContentLibraryID(ctx context.Context, client *rest.Client, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	spec := struct {
+		Spec struct {
+			Name string `json:"name"`
+		} `json:"spec"`
+	}{}
+	spec.Spec.Name = name
+
+	var ids []string
+	resource := client.Resource(libraryPath).WithAction("find")
+	if err := client.Do(ctx, resource.Request(http.MethodPost, spec), &ids); err != nil {
+		return "", errors.Wrapf(err, "failed to find content library %s", name)
+	}
+	switch len(ids) {
+	case 0:
+		return "", errors.Errorf("content library %s not found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", errors.Errorf("found %d content libraries named %s", len(ids), name)
+	}
+}
+
+// FindLibraryTemplate returns the template of the content library with the ID
+// imported from an OVA with the sha256 checksum, or nil when no such template
+// was imported.
+func FindLibraryTemplate(ctx context.Context, client *rest.Client, libraryID string, checksum string) (*LibraryItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	var ids []string
+	resource := client.Resource(libraryItemPath).WithParam("library_id", libraryID)
+	if err := client.Do(ctx, resource.Request(http.MethodGet), &ids); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the items of content library %s", libraryID)
+	}
+
+	description := TemplateDescription(checksum)
+	for _, id := range ids {
+		var item LibraryItem
+		resource := client.Resource(libraryItemPath).WithID(id)
+		if err := client.Do(ctx, resource.Request(http.MethodGet), &item); err != nil {
+			if strings.Contains(err.Error(), http.StatusText(http.StatusNotFound)) {
+				continue // deleted since listed
+			}
+			return nil, errors.Wrapf(err, "failed to get content library item %s", id)
+		}
+		if item.Type == ovfLibraryItemType && strings.TrimSpace(item.Description) == description {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+// OVAChecksum returns the sha256 checksum of the OVA at the URL, given in its
+// sha256 query parameter, e.g.
+// https://example.com/rhcos-vmware.x86_64.ova?sha256=098a5a...
+func OVAChecksum(imageURL string) (string, error) {
+	u, err := url.ParseRequestURI(imageURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the image URL %s", imageURL)
+	}
+	checksum := u.Query().Get("sha256")
+	if checksum == "" {
+		return "", errors.Errorf("the image URL %s has no sha256 query parameter, which is required to find the template in the content library", imageURL)
+	}
+	return checksum, nil
+}
+
+// LibraryTemplateName returns the name of the template imported in a content
+// library from the OVA with the sha256 checksum.
+func LibraryTemplateName(checksum string) string {
+	if len(checksum) > 12 {
+		checksum = checksum[:12]
+	}
+	return fmt.Sprintf("rhcos-%s", checksum)
+}
@@ -0,0 +1,93 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+)
+
+const testChecksum = "4d7c8a1f2b3e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c"
+
+func libraryServer(t *testing.T, items map[string]LibraryItem) *rest.Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/com/vmware/content/library/item", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "library-1", r.URL.Query().Get("library_id"))
+		ids := []string{"missing"}
+		for id := range items {
+			ids = append(ids, id)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"value": ids})
+	})
+	mux.HandleFunc("/rest/com/vmware/content/library/item/", func(w http.ResponseWriter, r *http.Request) {
+		item, ok := items[r.URL.Path[len("/rest/com/vmware/content/library/item/id:"):]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"value": item})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/sdk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rest.NewClient(&vim25.Client{Client: soap.NewClient(u, true)})
+}
+
+func TestFindLibraryTemplate(t *testing.T) {
+	cases := []struct {
+		name     string
+		items    map[string]LibraryItem
+		expected *LibraryItem
+	}{
+		{
+			name: "no template",
+			items: map[string]LibraryItem{
+				"item-1": {ID: "item-1", Name: "iso", Type: "iso"},
+			},
+		},
+		{
+			name: "template of another OVA",
+			items: map[string]LibraryItem{
+				"item-1": {ID: "item-1", Name: "rhcos-0123456789ab", Type: "ovf", Description: TemplateDescription("0123456789abcdef")},
+			},
+		},
+		{
+			name: "template with the checksum",
+			items: map[string]LibraryItem{
+				"item-1": {ID: "item-1", Name: "iso", Type: "iso", Description: TemplateDescription(testChecksum)},
+				"item-2": {ID: "item-2", Name: "rhcos-4d7c8a1f2b3e", Type: "ovf", Description: TemplateDescription(testChecksum)},
+			},
+			expected: &LibraryItem{ID: "item-2", Name: "rhcos-4d7c8a1f2b3e", Type: "ovf", Description: TemplateDescription(testChecksum)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := libraryServer(t, tc.items)
+			template, err := FindLibraryTemplate(context.TODO(), client, "library-1", testChecksum)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, template)
+		})
+	}
+}
+
+func TestOVAChecksum(t *testing.T) {
+	checksum, err := OVAChecksum("https://example.com/rhcos-vmware.x86_64.ova?sha256=" + testChecksum)
+	assert.NoError(t, err)
+	assert.Equal(t, testChecksum, checksum)
+
+	_, err = OVAChecksum("https://example.com/rhcos-vmware.x86_64.ova")
+	assert.EqualError(t, err, "the image URL https://example.com/rhcos-vmware.x86_64.ova has no sha256 query parameter, which is required to find the template in the content library")
+
+	assert.Equal(t, "rhcos-4d7c8a1f2b3e", LibraryTemplateName(checksum))
+}
@@ -0,0 +1,447 @@
+package vsphere
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25/soap"
+)
+
+const (
+	updateSessionPath     = "/com/vmware/content/library/item/update-session"
+	updateSessionFilePath = "/com/vmware/content/library/item/updatesession/file"
+
+	// importWorkers is the number of files of the OVA uploaded at the same
+	// time.
+	importWorkers = 4
+
+	// importFileAttempts is the number of times the upload of a file is
+	// attempted before giving up.
+	importFileAttempts = 3
+
+	// importProgressStep is the percentage of the OVA uploaded between two
+	// progress messages.
+	importProgressStep = 10
+)
+
+// Update session and file states of the content library.
+const (
+	sessionActive = "ACTIVE"
+	sessionDone   = "DONE"
+	fileReady     = "READY"
+)
+
+// importPollInterval is the interval between two checks of the state of an
+// update session being completed.
+var importPollInterval = 5 * time.Second
+
+// ovaFile is a file of an OVA, which is a tar archive of the OVF descriptor,
+// its manifest and its disks.
+type ovaFile struct {
+	name   string
+	offset int64
+	size   int64
+}
+
+// updateFileInfo is a file of an update session.
+type updateFileInfo struct {
+	Name             string `json:"name"`
+	Size             int64  `json:"size,omitempty"`
+	BytesTransferred int64  `json:"bytes_transferred,omitempty"`
+	Status           string `json:"status,omitempty"`
+	UploadEndpoint   struct {
+		URI string `json:"uri"`
+	} `json:"upload_endpoint"`
+}
+
+// importState is the state of an import, which is recorded next to the OVA so
+// that an interrupted import resumes with the files uploaded so far.
+type importState struct {
+	LibraryID string `json:"libraryID"`
+	ItemID    string `json:"itemID"`
+	SessionID string `json:"sessionID"`
+}
+
+// ImportLibraryTemplate imports the OVA at the path as a template with the
+// name and description in the content library with the ID, and returns the
+// imported template. The files of the OVA are uploaded in parallel, each file
+// is retried on failure, and the files uploaded by a previous, interrupted,
+// import of the OVA to the library are not uploaded again.
+func ImportLibraryTemplate(ctx context.Context, client *rest.Client, libraryID string, name string, description string, ovaPath string) (*LibraryItem, error) {
+	ova, err := os.Open(ovaPath)
+	if err != nil {
+		return nil, err
+	}
+	defer ova.Close()
+	files, err := ovaFiles(ova)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the OVA %s", ovaPath)
+	}
+
+	statePath := fmt.Sprintf("%s.library.json", ovaPath)
+	state, err := resumeImport(ctx, client, statePath, libraryID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = &importState{LibraryID: libraryID}
+		if err := startImport(ctx, client, state, name, description); err != nil {
+			return nil, err
+		}
+		if err := writeImportState(statePath, state); err != nil {
+			return nil, err
+		}
+	}
+
+	uploaded, err := sessionFiles(ctx, client, state.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	p := &uploadProgress{name: name}
+	var pending []ovaFile
+	for _, file := range files {
+		p.total += file.size
+		if info, ok := uploaded[file.name]; ok && info.Status == fileReady && info.Size == file.size {
+			p.add(file.size)
+			continue
+		}
+		pending = append(pending, file)
+	}
+	if len(pending) < len(files) {
+		logrus.Infof("Resuming the import of %s in content library %s, %d of %d MiB already uploaded", name, libraryID, p.done>>20, p.total>>20)
+	}
+
+	jobs := make(chan ovaFile)
+	errs := make(chan error, len(pending))
+	var wg sync.WaitGroup
+	for w := 0; w < importWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				_, added := uploaded[file.name]
+				if err := uploadOVAFile(ctx, client, state.SessionID, ova, file, added, p); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for _, file := range pending {
+		jobs <- file
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, errors.Wrapf(err, "failed to upload %s to content library %s, the import will resume from the uploaded files on the next attempt", ovaPath, libraryID)
+	}
+
+	if err := completeImport(ctx, client, state.SessionID); err != nil {
+		// The session cannot be resumed anymore, the next attempt starts a
+		// new one.
+		os.Remove(statePath)
+		return nil, err
+	}
+	if err := os.Remove(statePath); err != nil {
+		logrus.Debugf("Failed to remove the import state %s: %v", statePath, err)
+	}
+
+	item := &LibraryItem{}
+	if err := client.Do(ctx, client.Resource(libraryItemPath).WithID(state.ItemID).Request(http.MethodGet), item); err != nil {
+		return nil, errors.Wrapf(err, "failed to get content library item %s", state.ItemID)
+	}
+	logrus.Infof("Imported %s as template %s of content library %s", ovaPath, item.Name, libraryID)
+	return item, nil
+}
+
+// ovaFiles returns the files of the OVA, with their offsets in the OVA.
+func ovaFiles(ova io.ReadSeeker) ([]ovaFile, error) {
+	if _, err := ova.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	counter := &countingReader{r: ova}
+	archive := tar.NewReader(counter)
+	var files []ovaFile
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// The reader is at the start of the contents of the file once its
+		// header is read.
+		files = append(files, ovaFile{name: header.Name, offset: counter.n, size: header.Size})
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no files in the OVA")
+	}
+	return files, nil
+}
+
+// resumeImport returns the state of an interrupted import of the OVA to the
+// library, or nil when there is none to resume. A session which expired is
+// replaced by a new one for the same library item.
+func resumeImport(ctx context.Context, client *rest.Client, statePath string, libraryID string) (*importState, error) {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := &importState{}
+	if err := json.Unmarshal(data, state); err != nil || state.LibraryID != libraryID || state.ItemID == "" {
+		logrus.Debugf("Discarding the import state %s of another content library", statePath)
+		return nil, os.Remove(statePath)
+	}
+
+	var session struct {
+		State string `json:"state"`
+	}
+	err = client.Do(ctx, client.Resource(updateSessionPath).WithID(state.SessionID).Request(http.MethodGet), &session)
+	if err == nil && session.State == sessionActive {
+		return state, nil
+	}
+	logrus.Debugf("The update session %s of content library item %s cannot be resumed (%s): %v", state.SessionID, state.ItemID, session.State, err)
+
+	if err := client.Do(ctx, client.Resource(libraryItemPath).WithID(state.ItemID).Request(http.MethodGet), &LibraryItem{}); err != nil {
+		logrus.Debugf("Discarding the import state %s of a removed content library item: %v", statePath, err)
+		return nil, os.Remove(statePath)
+	}
+	if state.SessionID, err = createUpdateSession(ctx, client, state.ItemID); err != nil {
+		return nil, err
+	}
+	return state, writeImportState(statePath, state)
+}
+
+// startImport creates the library item of the template, and the update session
+// in which its files are uploaded.
+func startImport(ctx context.Context, client *rest.Client, state *importState, name string, description string) error {
+	spec := struct {
+		CreateSpec struct {
+			LibraryID   string `json:"library_id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Type        string `json:"type"`
+		} `json:"create_spec"`
+	}{}
+	spec.CreateSpec.LibraryID = state.LibraryID
+	spec.CreateSpec.Name = name
+	spec.CreateSpec.Description = description
+	spec.CreateSpec.Type = ovfLibraryItemType
+
+	if err := client.Do(ctx, client.Resource(libraryItemPath).Request(http.MethodPost, spec), &state.ItemID); err != nil {
+		return errors.Wrapf(err, "failed to create the template %s in content library %s", name, state.LibraryID)
+	}
+	var err error
+	state.SessionID, err = createUpdateSession(ctx, client, state.ItemID)
+	return err
+}
+
+// createUpdateSession returns the ID of a new update session of the library
+// item.
+func createUpdateSession(ctx context.Context, client *rest.Client, itemID string) (string, error) {
+	spec := struct {
+		CreateSpec struct {
+			LibraryItemID string `json:"library_item_id"`
+		} `json:"create_spec"`
+	}{}
+	spec.CreateSpec.LibraryItemID = itemID
+
+	var sessionID string
+	if err := client.Do(ctx, client.Resource(updateSessionPath).Request(http.MethodPost, spec), &sessionID); err != nil {
+		return "", errors.Wrapf(err, "failed to create an update session of content library item %s", itemID)
+	}
+	return sessionID, nil
+}
+
+// sessionFiles returns the files added to the update session, by name.
+func sessionFiles(ctx context.Context, client *rest.Client, sessionID string) (map[string]updateFileInfo, error) {
+	var files []updateFileInfo
+	resource := client.Resource(updateSessionFilePath).WithParam("update_session_id", sessionID)
+	if err := client.Do(ctx, resource.Request(http.MethodGet), &files); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the files of update session %s", sessionID)
+	}
+	byName := make(map[string]updateFileInfo, len(files))
+	for _, file := range files {
+		byName[file.Name] = file
+	}
+	return byName, nil
+}
+
+// uploadOVAFile uploads the file of the OVA to the update session, retrying
+// the upload on failure. When the file was already added to the session, by
+// an interrupted import, it is uploaded again to its endpoint.
+func uploadOVAFile(ctx context.Context, client *rest.Client, sessionID string, ova io.ReaderAt, file ovaFile, added bool, p *uploadProgress) error {
+	var err error
+	for attempt := 1; attempt <= importFileAttempts; attempt++ {
+		var info updateFileInfo
+		if added {
+			body := struct {
+				FileName string `json:"file_name"`
+			}{FileName: file.name}
+			err = client.Do(ctx, client.Resource(updateSessionFilePath).WithID(sessionID).WithAction("get").Request(http.MethodPost, body), &info)
+		} else {
+			spec := struct {
+				FileSpec struct {
+					Name       string `json:"name"`
+					SourceType string `json:"source_type"`
+					Size       int64  `json:"size"`
+				} `json:"file_spec"`
+			}{}
+			spec.FileSpec.Name = file.name
+			spec.FileSpec.SourceType = "PUSH"
+			spec.FileSpec.Size = file.size
+			err = client.Do(ctx, client.Resource(updateSessionFilePath).WithID(sessionID).WithAction("add").Request(http.MethodPost, spec), &info)
+			added = err == nil
+		}
+		if err == nil {
+			var u *url.URL
+			u, err = client.ParseURL(info.UploadEndpoint.URI)
+			if err == nil {
+				upload := soap.DefaultUpload
+				upload.ContentLength = file.size
+				counter := &progressReader{r: io.NewSectionReader(ova, file.offset, file.size), p: p}
+				err = client.Upload(ctx, counter, u, &upload)
+				if err == nil {
+					return nil
+				}
+				p.add(-counter.n)
+			}
+		}
+		logrus.Debugf("Attempt %d to upload %s failed: %v", attempt, file.name, err)
+	}
+	return errors.Wrapf(err, "failed to upload %s", file.name)
+}
+
+// completeImport validates the files of the update session and completes it,
+// which imports the template, and waits for the import to be done.
+func completeImport(ctx context.Context, client *rest.Client, sessionID string) error {
+	var validation struct {
+		MissingFiles []string `json:"missing_files"`
+		InvalidFiles []struct {
+			Name         string `json:"name"`
+			ErrorMessage struct {
+				DefaultMessage string `json:"default_message"`
+			} `json:"error_message"`
+		} `json:"invalid_files"`
+	}
+	if err := client.Do(ctx, client.Resource(updateSessionFilePath).WithID(sessionID).WithAction("validate").Request(http.MethodPost), &validation); err != nil {
+		return errors.Wrapf(err, "failed to validate the files of update session %s", sessionID)
+	}
+	var problems []string
+	for _, name := range validation.MissingFiles {
+		problems = append(problems, fmt.Sprintf("%s is missing", name))
+	}
+	for _, file := range validation.InvalidFiles {
+		problems = append(problems, fmt.Sprintf("%s is invalid: %s", file.Name, file.ErrorMessage.DefaultMessage))
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("the uploaded template is not valid: %s", strings.Join(problems, ", "))
+	}
+
+	if err := client.Do(ctx, client.Resource(updateSessionPath).WithID(sessionID).WithAction("complete").Request(http.MethodPost), nil); err != nil {
+		return errors.Wrapf(err, "failed to complete update session %s", sessionID)
+	}
+	for {
+		var session struct {
+			State        string `json:"state"`
+			ErrorMessage struct {
+				DefaultMessage string `json:"default_message"`
+			} `json:"error_message"`
+		}
+		if err := client.Do(ctx, client.Resource(updateSessionPath).WithID(sessionID).Request(http.MethodGet), &session); err != nil {
+			return errors.Wrapf(err, "failed to get the state of update session %s", sessionID)
+		}
+		switch session.State {
+		case sessionDone:
+			return nil
+		case sessionActive:
+		default:
+			return errors.Errorf("update session %s is %s: %s", sessionID, session.State, session.ErrorMessage.DefaultMessage)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(importPollInterval):
+		}
+	}
+}
+
+func writeImportState(statePath string, state *importState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0o600)
+}
+
+// uploadProgress logs the progress of an import.
+type uploadProgress struct {
+	name     string
+	total    int64
+	done     int64
+	reported int64
+}
+
+func (p *uploadProgress) add(n int64) {
+	done := atomic.AddInt64(&p.done, n)
+	if p.total <= 0 {
+		return
+	}
+	step := done * 100 / p.total / importProgressStep * importProgressStep
+	for {
+		reported := atomic.LoadInt64(&p.reported)
+		if step <= reported {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&p.reported, reported, step) {
+			logrus.Infof("Uploaded %d%% of %s (%d of %d MiB)", step, p.name, done>>20, p.total>>20)
+			return
+		}
+	}
+}
+
+// progressReader adds the bytes read from it to the progress of an import.
+type progressReader struct {
+	r io.Reader
+	p *uploadProgress
+	n int64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.n += int64(n)
+	r.p.add(int64(n))
+	return n, err
+}
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,296 @@
+package vsphere
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+)
+
+// fakeLibrary is a content library serving the REST API of the update
+// sessions.
+type fakeLibrary struct {
+	t      *testing.T
+	server *httptest.Server
+
+	lock     sync.Mutex
+	items    map[string]LibraryItem
+	sessions map[string]string
+	files    map[string]map[string]*updateFileInfo
+	contents map[string][]byte
+	// uploads counts the uploads of every file, by name.
+	uploads map[string]int
+	// failures are the uploads of files, by name, which fail.
+	failures map[string]int
+}
+
+func newFakeLibrary(t *testing.T) *fakeLibrary {
+	l := &fakeLibrary{
+		t:        t,
+		items:    map[string]LibraryItem{},
+		sessions: map[string]string{},
+		files:    map[string]map[string]*updateFileInfo{},
+		contents: map[string][]byte{},
+		uploads:  map[string]int{},
+		failures: map[string]int{},
+	}
+	l.server = httptest.NewServer(http.HandlerFunc(l.serve))
+	t.Cleanup(l.server.Close)
+	return l
+}
+
+func (l *fakeLibrary) client() *rest.Client {
+	u, err := url.Parse(l.server.URL + "/sdk")
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return rest.NewClient(&vim25.Client{Client: soap.NewClient(u, true)})
+}
+
+func (l *fakeLibrary) reply(w http.ResponseWriter, value interface{}) {
+	json.NewEncoder(w).Encode(map[string]interface{}{"value": value})
+}
+
+func (l *fakeLibrary) serve(w http.ResponseWriter, r *http.Request) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	action := r.URL.Query().Get("~action")
+	path := strings.TrimPrefix(r.URL.Path, "/rest")
+	switch {
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/upload/"):
+		parts := strings.SplitN(strings.TrimPrefix(path, "/upload/"), "/", 2)
+		file, ok := l.files[parts[0]][parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		l.uploads[file.Name]++
+		data, err := io.ReadAll(r.Body)
+		if err != nil || l.failures[file.Name] > 0 {
+			l.failures[file.Name]--
+			http.Error(w, "connection reset", http.StatusBadGateway)
+			return
+		}
+		l.contents[file.Name] = data
+		file.Status = fileReady
+		file.BytesTransferred = int64(len(data))
+
+	case path == libraryItemPath && r.Method == http.MethodPost:
+		var spec struct {
+			CreateSpec LibraryItem `json:"create_spec"`
+		}
+		json.NewDecoder(r.Body).Decode(&spec)
+		item := spec.CreateSpec
+		item.ID = fmt.Sprintf("item-%d", len(l.items)+1)
+		l.items[item.ID] = item
+		l.reply(w, item.ID)
+
+	case strings.HasPrefix(path, libraryItemPath+"/id:"):
+		item, ok := l.items[strings.TrimPrefix(path, libraryItemPath+"/id:")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		l.reply(w, item)
+
+	case path == updateSessionPath:
+		id := fmt.Sprintf("session-%d", len(l.sessions)+1)
+		l.sessions[id] = sessionActive
+		l.files[id] = map[string]*updateFileInfo{}
+		l.reply(w, id)
+
+	case strings.HasPrefix(path, updateSessionPath+"/id:"):
+		id := strings.TrimPrefix(path, updateSessionPath+"/id:")
+		if action == "complete" {
+			l.sessions[id] = sessionDone
+			return
+		}
+		l.reply(w, map[string]string{"state": l.sessions[id]})
+
+	case path == updateSessionFilePath:
+		files := []*updateFileInfo{}
+		for _, file := range l.files[r.URL.Query().Get("update_session_id")] {
+			files = append(files, file)
+		}
+		l.reply(w, files)
+
+	case strings.HasPrefix(path, updateSessionFilePath+"/id:"):
+		id := strings.TrimPrefix(path, updateSessionFilePath+"/id:")
+		switch action {
+		case "add":
+			var spec struct {
+				FileSpec updateFileInfo `json:"file_spec"`
+			}
+			json.NewDecoder(r.Body).Decode(&spec)
+			file := spec.FileSpec
+			file.Status = "WAITING_FOR_TRANSFER"
+			file.UploadEndpoint.URI = fmt.Sprintf("%s/upload/%s/%s", l.server.URL, id, file.Name)
+			l.files[id][file.Name] = &file
+			l.reply(w, file)
+		case "get":
+			var body struct {
+				FileName string `json:"file_name"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			l.reply(w, l.files[id][body.FileName])
+		case "validate":
+			l.reply(w, map[string][]string{"missing_files": {}})
+		}
+
+	default:
+		l.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		http.NotFound(w, r)
+	}
+}
+
+// testOVA writes an OVA with the files to the directory, and returns its
+// path.
+func testOVA(t *testing.T, dir string, files map[string][]byte) string {
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	for _, name := range []string{"rhcos.ovf", "rhcos.mf", "disk.vmdk"} {
+		if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write(files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "rhcos.ova")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportLibraryTemplate(t *testing.T) {
+	defer func(interval time.Duration) { importPollInterval = interval }(importPollInterval)
+	importPollInterval = time.Millisecond
+
+	disk := make([]byte, 3000)
+	rand.New(rand.NewSource(1)).Read(disk)
+	files := map[string][]byte{
+		"rhcos.ovf": []byte("<Envelope/>"),
+		"rhcos.mf":  []byte("SHA256(disk.vmdk)= 0123"),
+		"disk.vmdk": disk,
+	}
+
+	cases := []struct {
+		name string
+		// setup prepares the library and returns the state of an
+		// interrupted import.
+		setup    func(l *fakeLibrary) *importState
+		failures map[string]int
+		uploads  map[string]int
+		item     string
+	}{
+		{
+			name:    "new import",
+			setup:   func(*fakeLibrary) *importState { return nil },
+			uploads: map[string]int{"rhcos.ovf": 1, "rhcos.mf": 1, "disk.vmdk": 1},
+			item:    "item-1",
+		},
+		{
+			name:     "failed uploads are retried",
+			setup:    func(*fakeLibrary) *importState { return nil },
+			failures: map[string]int{"disk.vmdk": 2},
+			uploads:  map[string]int{"rhcos.ovf": 1, "rhcos.mf": 1, "disk.vmdk": 3},
+			item:     "item-1",
+		},
+		{
+			name: "resume",
+			setup: func(l *fakeLibrary) *importState {
+				l.items["item-1"] = LibraryItem{ID: "item-1", Name: "rhcos-4d7c8a1f2b3e", Type: "ovf"}
+				l.sessions["session-1"] = sessionActive
+				l.files["session-1"] = map[string]*updateFileInfo{
+					"rhcos.ovf": {Name: "rhcos.ovf", Size: int64(len(files["rhcos.ovf"])), Status: fileReady},
+					"disk.vmdk": {Name: "disk.vmdk", Size: int64(len(disk)), Status: "TRANSFERRING", BytesTransferred: 1000},
+				}
+				l.files["session-1"]["disk.vmdk"].UploadEndpoint.URI = l.server.URL + "/upload/session-1/disk.vmdk"
+				l.contents["rhcos.ovf"] = files["rhcos.ovf"]
+				return &importState{LibraryID: "library-1", ItemID: "item-1", SessionID: "session-1"}
+			},
+			uploads: map[string]int{"rhcos.ovf": 0, "rhcos.mf": 1, "disk.vmdk": 1},
+			item:    "item-1",
+		},
+		{
+			name: "expired session",
+			setup: func(l *fakeLibrary) *importState {
+				l.items["item-1"] = LibraryItem{ID: "item-1", Name: "rhcos-4d7c8a1f2b3e", Type: "ovf"}
+				l.sessions["session-1"] = "ERROR"
+				return &importState{LibraryID: "library-1", ItemID: "item-1", SessionID: "session-1"}
+			},
+			uploads: map[string]int{"rhcos.ovf": 1, "rhcos.mf": 1, "disk.vmdk": 1},
+			item:    "item-1",
+		},
+		{
+			name: "removed item",
+			setup: func(l *fakeLibrary) *importState {
+				return &importState{LibraryID: "library-1", ItemID: "item-0", SessionID: "session-0"}
+			},
+			uploads: map[string]int{"rhcos.ovf": 1, "rhcos.mf": 1, "disk.vmdk": 1},
+			item:    "item-1",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newFakeLibrary(t)
+			for name, count := range tc.failures {
+				l.failures[name] = count
+			}
+			ovaPath := testOVA(t, t.TempDir(), files)
+			if state := tc.setup(l); state != nil {
+				assert.NoError(t, writeImportState(ovaPath+".library.json", state))
+			}
+
+			item, err := ImportLibraryTemplate(context.TODO(), l.client(), "library-1", "rhcos-4d7c8a1f2b3e", TemplateDescription(testChecksum), ovaPath)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.item, item.ID)
+			assert.Equal(t, "rhcos-4d7c8a1f2b3e", item.Name)
+			for name, count := range tc.uploads {
+				assert.Equal(t, count, l.uploads[name], "uploads of %s", name)
+				assert.Equal(t, files[name], l.contents[name], "contents of %s", name)
+			}
+			_, err = os.Stat(ovaPath + ".library.json")
+			assert.True(t, os.IsNotExist(err), "import state not removed")
+		})
+	}
+}
+
+func TestImportLibraryTemplateFailure(t *testing.T) {
+	l := newFakeLibrary(t)
+	l.failures["disk.vmdk"] = importFileAttempts
+	ovaPath := testOVA(t, t.TempDir(), map[string][]byte{"rhcos.ovf": []byte("<Envelope/>"), "disk.vmdk": make([]byte, 100)})
+
+	_, err := ImportLibraryTemplate(context.TODO(), l.client(), "library-1", "rhcos-4d7c8a1f2b3e", TemplateDescription(testChecksum), ovaPath)
+	assert.Regexp(t, `^failed to upload .*rhcos.ova to content library library-1, the import will resume from the uploaded files on the next attempt: failed to upload disk.vmdk: 502 Bad Gateway$`, err)
+
+	data, err := os.ReadFile(ovaPath + ".library.json")
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"libraryID": "library-1", "itemID": "item-1", "sessionID": "session-1"}`, string(data))
+	}
+	assert.Equal(t, fileReady, l.files["session-1"]["rhcos.ovf"].Status)
+}
@@ -117,6 +117,7 @@ func ValidateForProvisioning(ic *types.InstallConfig) error {
 		checkTags = true
 	}
 
+	demands := capacityDemands(ic)
 	for i, failureDomain := range ic.VSphere.FailureDomains {
 		if _, exists := clients[failureDomain.Server]; !exists {
 			validationCtx, cleanup, err := getVCenterClient(failureDomain, ic)
@@ -130,6 +131,8 @@ func ValidateForProvisioning(ic *types.InstallConfig) error {
 
 		validationCtx := clients[failureDomain.Server]
 		allErrs = append(allErrs, validateFailureDomain(validationCtx, &ic.VSphere.FailureDomains[i], checkTags)...)
+		allErrs = append(allErrs, validateFailureDomainCapacity(validationCtx, &ic.VSphere.FailureDomains[i], demands[failureDomain.Name], ic.VSphere.DiskType, field.NewPath("platform", "vsphere", "failureDomains").Index(i))...)
+		allErrs = append(allErrs, validateControlPlaneAntiAffinity(validationCtx, &ic.VSphere.FailureDomains[i], demands[failureDomain.Name], ic.VSphere.ControlPlaneAntiAffinity, field.NewPath("platform", "vsphere", "failureDomains").Index(i))...)
 	}
 	return allErrs.ToAggregate()
 }
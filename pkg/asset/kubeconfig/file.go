@@ -0,0 +1,44 @@
+package kubeconfig
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/openshift/installer/pkg/asset/encryption"
+)
+
+// LoadFile returns the kubeconfig in the file written to the asset directory,
+// which is encrypted when the state of the asset directory is.
+func LoadFile(path string) (*clientcmdapi.Config, error) {
+	data, err := encryption.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load %s", path)
+	}
+	return config, nil
+}
+
+// RESTConfigFromFile returns the client configuration of the kubeconfig in
+// the file written to the asset directory.
+func RESTConfigFromFile(path string) (*rest.Config, error) {
+	config, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+// WriteFile writes the kubeconfig to the file of the asset directory, and
+// encrypts it when the state of the asset directory is encrypted.
+func WriteFile(config *clientcmdapi.Config, path string) error {
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return err
+	}
+	return encryption.WriteFile(path, data, 0o600)
+}
@@ -27,6 +27,7 @@ type machineProviderInput struct {
 	osImage        string
 	zone           string
 	role           string
+	profile        string
 	userDataSecret string
 	root           *aws.EC2RootVolume
 	imds           aws.EC2Metadata
@@ -61,6 +62,7 @@ func Machines(clusterID string, region string, subnets map[string]string, pool *
 			osImage:        mpool.AMIID,
 			zone:           zone,
 			role:           role,
+			profile:        mpool.IAMInstanceProfile,
 			userDataSecret: userDataSecret,
 			root:           &mpool.EC2RootVolume,
 			imds:           mpool.EC2Metadata,
@@ -175,6 +177,10 @@ func provider(in *machineProviderInput) (*machineapi.AWSMachineProviderConfig, e
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create machineapi.TagSpecifications from UserTags")
 	}
+	profile := in.profile
+	if profile == "" {
+		profile = fmt.Sprintf("%s-%s-profile", in.clusterID, in.role)
+	}
 	config := &machineapi.AWSMachineProviderConfig{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "machine.openshift.io/v1beta1",
@@ -194,7 +200,7 @@ func provider(in *machineProviderInput) (*machineapi.AWSMachineProviderConfig, e
 		},
 		Tags: tags,
 		IAMInstanceProfile: &machineapi.AWSResourceReference{
-			ID: pointer.String(fmt.Sprintf("%s-%s-profile", in.clusterID, in.role)),
+			ID: pointer.String(profile),
 		},
 		UserDataSecret:    &corev1.LocalObjectReference{Name: in.userDataSecret},
 		CredentialsSecret: &corev1.LocalObjectReference{Name: "aws-cloud-credentials"},
@@ -196,7 +196,7 @@ func provider(platform *azure.Platform, mpool *azure.MachinePool, osImage string
 	}
 
 	publicLB := clusterID
-	if platform.OutboundType == azure.UserDefinedRoutingOutboundType {
+	if platform.OutboundType == azure.UserDefinedRoutingOutboundType || platform.OutboundType == azure.UserDefinedNATGatewayOutboundType {
 		publicLB = ""
 	}
 
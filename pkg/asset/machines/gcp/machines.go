@@ -177,6 +177,13 @@ func provider(clusterID string, platform *gcp.Platform, mpool *gcp.MachinePool,
 	if mpool.SecureBoot == string(machineapi.SecureBootPolicyEnabled) {
 		shieldedInstanceConfig.SecureBoot = machineapi.SecureBootPolicyEnabled
 	}
+	if c := mpool.ShieldedInstanceConfig; c != nil {
+		if c.SecureBoot != "" {
+			shieldedInstanceConfig.SecureBoot = machineapi.SecureBootPolicy(c.SecureBoot)
+		}
+		shieldedInstanceConfig.VirtualizedTrustedPlatformModule = machineapi.VirtualizedTrustedPlatformModulePolicy(c.VirtualizedTrustedPlatformModule)
+		shieldedInstanceConfig.IntegrityMonitoring = machineapi.IntegrityMonitoringPolicy(c.IntegrityMonitoring)
+	}
 	return &machineapi.GCPMachineProviderSpec{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "machine.openshift.io/v1beta1",
@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	"github.com/openshift/installer/pkg/asset/machines/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/azure"
@@ -51,6 +52,7 @@ import (
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
 	azuredefaults "github.com/openshift/installer/pkg/types/azure/defaults"
 	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
+	externaltypes "github.com/openshift/installer/pkg/types/external"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 	libvirttypes "github.com/openshift/installer/pkg/types/libvirt"
@@ -84,11 +86,23 @@ type Master struct {
 	// HostFiles is the list of baremetal hosts provided in the
 	// installer configuration.
 	HostFiles []*asset.File
+
+	// ClusterAPIFiles are the Cluster API manifests of the control plane,
+	// which are generated alongside the Machine API ones on the platforms
+	// that support a Cluster API based installation.
+	ClusterAPIFiles []*asset.File
 }
 
 const (
 	directory = "openshift"
 
+	// clusterAPIDirectory is the directory of the Cluster API manifests.
+	clusterAPIDirectory = "cluster-api"
+
+	// clusterAPIFileName is the format string for constructing the Cluster
+	// API manifest filenames from their kind and name.
+	clusterAPIFileName = "10_%s-%s.yaml"
+
 	// secretFileName is the format string for constructing the Secret
 	// filenames for baremetal clusters.
 	secretFileName = "99_openshift-cluster-api_host-bmc-secrets-%s.yaml"
@@ -118,6 +132,7 @@ var (
 	secretFileNamePattern              = fmt.Sprintf(secretFileName, "*")
 	networkConfigSecretFileNamePattern = fmt.Sprintf(networkConfigSecretFileName, "*")
 	hostFileNamePattern                = fmt.Sprintf(hostFileName, "*")
+	clusterAPIFileNamePattern          = fmt.Sprintf(clusterAPIFileName, "*", "*")
 	masterMachineFileNamePattern       = fmt.Sprintf(masterMachineFileName, "*")
 
 	_ asset.WritableAsset = (*Master)(nil)
@@ -264,9 +279,13 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		aws.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
+		// User-managed load balancers register the control plane machines
+		// themselves.
+		if !ic.Platform.AWS.UserManagedLoadBalancer() {
+			aws.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
+		}
 	case gcptypes.Name:
-		mpool := defaultGCPMachinePoolPlatform()
+		mpool := defaultGCPMachinePoolPlatform(pool.Architecture)
 		mpool.Set(ic.Platform.GCP.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.GCP)
 		if len(mpool.Zones) == 0 {
@@ -281,9 +300,11 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		err := gcp.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
-		if err != nil {
-			return err
+		if !ic.Platform.GCP.UserManagedLoadBalancer() {
+			err := gcp.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
+			if err != nil {
+				return err
+			}
 		}
 	case ibmcloudtypes.Name:
 		subnets := map[string]string{}
@@ -381,9 +402,11 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		err = azure.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID)
-		if err != nil {
-			return err
+		if !ic.Platform.Azure.UserManagedLoadBalancer() {
+			err = azure.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID)
+			if err != nil {
+				return err
+			}
 		}
 	case baremetaltypes.Name:
 		mpool := defaultBareMetalMachinePoolPlatform()
@@ -471,6 +494,12 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		mpool := defaultPowerVSMachinePoolPlatform()
 		mpool.Set(ic.Platform.PowerVS.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.PowerVS)
+		if mpool.SSHKeyName != "" {
+			mpool.SSHKeyName, err = icpowervs.ResolveSSHKeyName(context.TODO(), ic, clusterID.InfraID, mpool.SSHKeyName)
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve the SSH key of master machines")
+			}
+		}
 		// Only the service instance is guaranteed to exist and be passed via the install config
 		// The other two, we should standardize a name including the cluster id. At this point, all
 		// we have are names.
@@ -480,7 +509,25 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
 		powervs.ConfigMasters(machines, clusterID.InfraID)
-	case nonetypes.Name:
+		// The Cluster API manifests are a tech preview.
+		if ic.FeatureSet == configv1.TechPreviewNoUpgrade {
+			objects, err := powervs.ClusterAPIManifests(clusterID.InfraID, ic, &pool)
+			if err != nil {
+				return errors.Wrap(err, "failed to create Cluster API manifests")
+			}
+			m.ClusterAPIFiles = make([]*asset.File, 0, len(objects))
+			for _, obj := range objects {
+				data, err := yaml.Marshal(obj.Object)
+				if err != nil {
+					return errors.Wrapf(err, "marshal %s %s", obj.GetKind(), obj.GetName())
+				}
+				m.ClusterAPIFiles = append(m.ClusterAPIFiles, &asset.File{
+					Filename: filepath.Join(clusterAPIDirectory, fmt.Sprintf(clusterAPIFileName, strings.ToLower(obj.GetKind()), obj.GetName())),
+					Data:     data,
+				})
+			}
+		}
+	case externaltypes.Name, nonetypes.Name:
 	case nutanixtypes.Name:
 		mpool := defaultNutanixMachinePoolPlatform()
 		mpool.NumCPUs = 8
@@ -533,6 +580,20 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 		machineConfigs = append(machineConfigs, ignFIPS)
 	}
+	if pool.Platform.PowerVS != nil && pool.Platform.PowerVS.SMTLevel != "" {
+		ignSMT, err := machineconfig.ForSMTLevel(pool.Platform.PowerVS.SMTLevel, "master")
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for the SMT level of master machines")
+		}
+		machineConfigs = append(machineConfigs, ignSMT)
+	}
+	if args := ic.NodeCustomization.KernelArgumentsFor(types.NodeRoleMaster); len(args) > 0 {
+		ignKargs, err := machineconfig.ForKernelArguments(args, "master")
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for kernel arguments for master machines")
+		}
+		machineConfigs = append(machineConfigs, ignKargs)
+	}
 
 	m.MachineConfigFiles, err = machineconfig.Manifests(machineConfigs, "master", directory)
 	if err != nil {
@@ -585,6 +646,7 @@ func (m *Master) Files() []*asset.File {
 	if m.ControlPlaneMachineSet != nil {
 		files = append(files, m.ControlPlaneMachineSet)
 	}
+	files = append(files, m.ClusterAPIFiles...)
 	return files
 }
 
@@ -630,6 +692,12 @@ func (m *Master) Load(f asset.FileFetcher) (found bool, err error) {
 	}
 	m.MachineFiles = fileList
 
+	fileList, err = f.FetchByPattern(filepath.Join(clusterAPIDirectory, clusterAPIFileNamePattern))
+	if err != nil {
+		return true, err
+	}
+	m.ClusterAPIFiles = fileList
+
 	file, err = f.FetchByName(filepath.Join(directory, controlPlaneMachineSetFileName))
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -27,13 +27,23 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	platform := config.Platform.Nutanix
 	mpool := pool.Platform.Nutanix
 
+	failureDomains, err := getFailureDomains(platform, mpool)
+	if err != nil {
+		return nil, err
+	}
+
 	total := int64(1)
 	if pool.Replicas != nil {
 		total = *pool.Replicas
 	}
 	var machines []machineapi.Machine
 	for idx := int64(0); idx < total; idx++ {
-		provider, err := provider(clusterID, platform, mpool, osImage, userDataSecret)
+		// Spread the machines across the failure domains.
+		var failureDomain *nutanix.FailureDomain
+		if len(failureDomains) > 0 {
+			failureDomain = &failureDomains[int(idx)%len(failureDomains)]
+		}
+		provider, err := provider(clusterID, platform, failureDomain, mpool, osImage, userDataSecret)
 
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
@@ -64,10 +74,38 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	return machines, nil
 }
 
-func provider(clusterID string, platform *nutanix.Platform, mpool *nutanix.MachinePool, osImage string, userDataSecret string) (*machinev1.NutanixMachineProviderConfig, error) {
+// getFailureDomains returns the failure domains the machines of the pool are
+// spread across: those of the pool, or all of those of the platform if the
+// pool does not set any.
+func getFailureDomains(platform *nutanix.Platform, mpool *nutanix.MachinePool) ([]nutanix.FailureDomain, error) {
+	if len(mpool.FailureDomains) == 0 {
+		return platform.FailureDomains, nil
+	}
+	failureDomains := make([]nutanix.FailureDomain, 0, len(mpool.FailureDomains))
+	for _, name := range mpool.FailureDomains {
+		fd, err := platform.GetFailureDomainByName(name)
+		if err != nil {
+			return nil, err
+		}
+		failureDomains = append(failureDomains, *fd)
+	}
+	return failureDomains, nil
+}
+
+// provider returns the provider config of the machines in the failure domain,
+// or in the Prism Element and subnets of the platform if it is nil.
+func provider(clusterID string, platform *nutanix.Platform, failureDomain *nutanix.FailureDomain, mpool *nutanix.MachinePool, osImage string, userDataSecret string) (*machinev1.NutanixMachineProviderConfig, error) {
+	subnetUUIDs := platform.SubnetUUIDs
+	clusterUUID := platform.PrismElements[0].UUID
+	if failureDomain != nil {
+		subnetUUIDs = failureDomain.SubnetUUIDs
+		clusterUUID = failureDomain.PrismElement.UUID
+	}
+
 	// subnets
 	subnets := []machinev1.NutanixResourceIdentifier{}
-	for _, subnetUUID := range platform.SubnetUUIDs {
+	for _, subnetUUID := range subnetUUIDs {
+		subnetUUID := subnetUUID
 		subnet := machinev1.NutanixResourceIdentifier{
 			Type: machinev1.NutanixIdentifierUUID,
 			UUID: &subnetUUID,
@@ -92,7 +130,7 @@ func provider(clusterID string, platform *nutanix.Platform, mpool *nutanix.Machi
 		MemorySize:     resource.MustParse(fmt.Sprintf("%dMi", mpool.MemoryMiB)),
 		Cluster: machinev1.NutanixResourceIdentifier{
 			Type: machinev1.NutanixIdentifierUUID,
-			UUID: &platform.PrismElements[0].UUID,
+			UUID: &clusterUUID,
 		},
 		SystemDiskSize: resource.MustParse(fmt.Sprintf("%dGi", mpool.OSDisk.DiskSizeGiB)),
 	}
@@ -25,17 +25,51 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	platform := config.Platform.Nutanix
 	mpool := pool.Platform.Nutanix
 
+	failureDomains, err := getFailureDomains(platform, mpool)
+	if err != nil {
+		return nil, err
+	}
+
 	total := int32(0)
 	if pool.Replicas != nil {
 		total = int32(*pool.Replicas)
 	}
-	var machinesets []*machineapi.MachineSet
-	provider, err := provider(clusterID, platform, mpool, osImage, userDataSecret)
+
+	if len(failureDomains) == 0 {
+		name := fmt.Sprintf("%s-%s", clusterID, pool.Name)
+		mset, err := getMachineSet(clusterID, name, platform, nil, mpool, osImage, total, role, userDataSecret)
+		if err != nil {
+			return nil, err
+		}
+		return []*machineapi.MachineSet{mset}, nil
+	}
+
+	// Create a machine set in each failure domain, and spread the replicas
+	// across them.
+	numOfFDs := int32(len(failureDomains))
+	machinesets := make([]*machineapi.MachineSet, 0, numOfFDs)
+	for idx := range failureDomains {
+		replicas := total / numOfFDs
+		if int32(idx) < total%numOfFDs {
+			replicas++
+		}
+		name := fmt.Sprintf("%s-%s-%d", clusterID, pool.Name, idx)
+		mset, err := getMachineSet(clusterID, name, platform, &failureDomains[idx], mpool, osImage, replicas, role, userDataSecret)
+		if err != nil {
+			return nil, err
+		}
+		machinesets = append(machinesets, mset)
+	}
+
+	return machinesets, nil
+}
+
+func getMachineSet(clusterID, name string, platform *nutanix.Platform, failureDomain *nutanix.FailureDomain, mpool *nutanix.MachinePool, osImage string, replicas int32, role, userDataSecret string) (*machineapi.MachineSet, error) {
+	provider, err := provider(clusterID, platform, failureDomain, mpool, osImage, userDataSecret)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create provider")
 	}
 
-	name := fmt.Sprintf("%s-%s", clusterID, pool.Name)
 	mset := &machineapi.MachineSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "machine.openshift.io/v1beta1",
@@ -49,7 +83,7 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 			},
 		},
 		Spec: machineapi.MachineSetSpec{
-			Replicas: &total,
+			Replicas: &replicas,
 			Selector: metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					"machine.openshift.io/cluster-api-machineset": name,
@@ -74,7 +108,5 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 			},
 		},
 	}
-	machinesets = append(machinesets, mset)
-
-	return machinesets, nil
+	return mset, nil
 }
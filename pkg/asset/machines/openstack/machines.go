@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackdefaults "github.com/openshift/installer/pkg/types/openstack/defaults"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 const (
@@ -121,6 +122,15 @@ func generateProvider(clusterID string, platform *openstack.Platform, mpool *ope
 				UUID: platform.MachinesSubnet,
 			}},
 		}
+		// The machines subnet is a subnet of the provider network the nodes
+		// are attached to.
+		if platform.ProviderNetwork != "" {
+			if validate.UUID(platform.ProviderNetwork) == nil {
+				controlPlaneNetwork.UUID = platform.ProviderNetwork
+			} else {
+				controlPlaneNetwork.Filter.Name = platform.ProviderNetwork
+			}
+		}
 	} else {
 		controlPlaneNetwork = machinev1alpha1.NetworkParam{
 			Subnets: []machinev1alpha1.SubnetParam{
@@ -0,0 +1,124 @@
+package powervs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+const (
+	// capiNamespace is the namespace of the Cluster API objects of the cluster.
+	capiNamespace = "openshift-cluster-api-guests"
+
+	clusterAPIVersion        = "cluster.x-k8s.io/v1beta1"
+	infrastructureAPIVersion = "infrastructure.cluster.x-k8s.io/v1beta2"
+)
+
+// ClusterAPIManifests returns the Cluster API (CAPIBM) objects of the cluster:
+// the Cluster, its IBMPowerVSCluster and the IBMPowerVSMachineTemplate of the
+// control plane machine pool.
+func ClusterAPIManifests(clusterID string, config *types.InstallConfig, pool *types.MachinePool) ([]*unstructured.Unstructured, error) {
+	if configPlatform := config.Platform.Name(); configPlatform != powervs.Name {
+		return nil, fmt.Errorf("non-PowerVS configuration: %q", configPlatform)
+	}
+	if poolPlatform := pool.Platform.Name(); poolPlatform != powervs.Name {
+		return nil, fmt.Errorf("non-PowerVS machine-pool: %q", poolPlatform)
+	}
+	platform := config.Platform.PowerVS
+	mpool := pool.Platform.PowerVS
+
+	image := fmt.Sprintf("rhcos-%s", clusterID)
+	if platform.ClusterOSImage != "" {
+		image = platform.ClusterOSImage
+	}
+	network := map[string]interface{}{
+		"regex": fmt.Sprintf("^DHCPSERVER.*%s.*_Private$", clusterID),
+	}
+	if platform.PVSNetworkName != "" {
+		network = map[string]interface{}{"name": platform.PVSNetworkName}
+	}
+
+	powerVSCluster := capiObject(infrastructureAPIVersion, "IBMPowerVSCluster", clusterID, clusterID)
+	powerVSClusterSpec := map[string]interface{}{
+		"zone":    platform.Zone,
+		"network": network,
+	}
+	setServiceInstance(powerVSClusterSpec, clusterID, platform)
+	if platform.PowerVSResourceGroup != "" {
+		powerVSClusterSpec["resourceGroup"] = map[string]interface{}{"name": platform.PowerVSResourceGroup}
+	}
+	if platform.VPCName != "" {
+		vpc := map[string]interface{}{"name": platform.VPCName}
+		if platform.VPCRegion != "" {
+			vpc["region"] = platform.VPCRegion
+		}
+		powerVSClusterSpec["vpc"] = vpc
+	}
+	if err := unstructured.SetNestedMap(powerVSCluster.Object, powerVSClusterSpec, "spec"); err != nil {
+		return nil, errors.Wrap(err, "failed to set the IBMPowerVSCluster spec")
+	}
+
+	cluster := capiObject(clusterAPIVersion, "Cluster", clusterID, clusterID)
+	infrastructureRef := map[string]interface{}{
+		"apiVersion": infrastructureAPIVersion,
+		"kind":       "IBMPowerVSCluster",
+		"name":       clusterID,
+		"namespace":  capiNamespace,
+	}
+	if err := unstructured.SetNestedMap(cluster.Object, infrastructureRef, "spec", "infrastructureRef"); err != nil {
+		return nil, errors.Wrap(err, "failed to set the Cluster infrastructure reference")
+	}
+
+	processors := interface{}(mpool.Processors.StrVal)
+	if mpool.Processors.StrVal == "" {
+		processors = int64(mpool.Processors.IntVal)
+	}
+	sshKey := powervs.SSHKeyName(clusterID)
+	if mpool.SSHKeyName != "" {
+		sshKey = mpool.SSHKeyName
+	}
+	machineTemplate := capiObject(infrastructureAPIVersion, "IBMPowerVSMachineTemplate", fmt.Sprintf("%s-%s", clusterID, pool.Name), clusterID)
+	machineSpec := map[string]interface{}{
+		"sshKey":        sshKey,
+		"image":         map[string]interface{}{"name": image},
+		"network":       network,
+		"systemType":    mpool.SysType,
+		"processorType": string(mpool.ProcType),
+		"processors":    processors,
+		"memoryGiB":     int64(mpool.MemoryGiB),
+	}
+	setServiceInstance(machineSpec, clusterID, platform)
+	if err := unstructured.SetNestedMap(machineTemplate.Object, machineSpec, "spec", "template", "spec"); err != nil {
+		return nil, errors.Wrap(err, "failed to set the IBMPowerVSMachineTemplate spec")
+	}
+
+	return []*unstructured.Unstructured{cluster, powerVSCluster, machineTemplate}, nil
+}
+
+// setServiceInstance sets the workspace of the cluster in a Cluster API spec:
+// by ID when the install config has one, and otherwise by the name of the
+// workspace created by the installer when the cluster is provisioned.
+func setServiceInstance(spec map[string]interface{}, clusterID string, platform *powervs.Platform) {
+	if platform.ServiceInstanceID != "" {
+		spec["serviceInstanceID"] = platform.ServiceInstanceID
+		return
+	}
+	spec["serviceInstance"] = map[string]interface{}{"name": powervs.WorkspaceName(clusterID)}
+}
+
+// capiObject returns an empty Cluster API object of the cluster.
+func capiObject(apiVersion, kind, name, clusterID string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(capiNamespace)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{
+		"cluster.x-k8s.io/cluster-name": clusterID,
+	})
+	return obj
+}
@@ -0,0 +1,190 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestClusterAPIManifests(t *testing.T) {
+	cases := []struct {
+		name        string
+		platform    types.Platform
+		pool        types.MachinePoolPlatform
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name: "workspace created by the installer",
+			platform: types.Platform{PowerVS: &powervs.Platform{
+				Zone: "dal10",
+			}},
+			pool: types.MachinePoolPlatform{PowerVS: &powervs.MachinePool{
+				MemoryGiB:  32,
+				Processors: intstr.FromString("0.5"),
+				ProcType:   machinev1.PowerVSProcessorTypeShared,
+				SysType:    "s922",
+			}},
+			expected: []string{`apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id
+  namespace: openshift-cluster-api-guests
+spec:
+  infrastructureRef:
+    apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+    kind: IBMPowerVSCluster
+    name: infra-id
+    namespace: openshift-cluster-api-guests
+`, `apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: IBMPowerVSCluster
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id
+  namespace: openshift-cluster-api-guests
+spec:
+  network:
+    regex: ^DHCPSERVER.*infra-id.*_Private$
+  serviceInstance:
+    name: infra-id-power-iaas
+  zone: dal10
+`, `apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: IBMPowerVSMachineTemplate
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id-master
+  namespace: openshift-cluster-api-guests
+spec:
+  template:
+    spec:
+      image:
+        name: rhcos-infra-id
+      memoryGiB: 32
+      network:
+        regex: ^DHCPSERVER.*infra-id.*_Private$
+      processorType: Shared
+      processors: "0.5"
+      serviceInstance:
+        name: infra-id-power-iaas
+      sshKey: infra-id-key
+      systemType: s922
+`},
+		},
+		{
+			name: "existing workspace, network and VPC",
+			platform: types.Platform{PowerVS: &powervs.Platform{
+				ServiceInstanceID:    "service-instance-id",
+				PowerVSResourceGroup: "resource-group",
+				Zone:                 "dal10",
+				VPCRegion:            "us-south",
+				VPCName:              "vpc",
+				PVSNetworkName:       "network",
+				ClusterOSImage:       "image",
+			}},
+			pool: types.MachinePoolPlatform{PowerVS: &powervs.MachinePool{
+				MemoryGiB:  64,
+				Processors: intstr.FromInt(2),
+				ProcType:   machinev1.PowerVSProcessorTypeDedicated,
+				SysType:    "e980",
+				SSHKeyName: "ssh-key",
+			}},
+			expected: []string{`apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id
+  namespace: openshift-cluster-api-guests
+spec:
+  infrastructureRef:
+    apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+    kind: IBMPowerVSCluster
+    name: infra-id
+    namespace: openshift-cluster-api-guests
+`, `apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: IBMPowerVSCluster
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id
+  namespace: openshift-cluster-api-guests
+spec:
+  network:
+    name: network
+  resourceGroup:
+    name: resource-group
+  serviceInstanceID: service-instance-id
+  vpc:
+    name: vpc
+    region: us-south
+  zone: dal10
+`, `apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: IBMPowerVSMachineTemplate
+metadata:
+  labels:
+    cluster.x-k8s.io/cluster-name: infra-id
+  name: infra-id-master
+  namespace: openshift-cluster-api-guests
+spec:
+  template:
+    spec:
+      image:
+        name: image
+      memoryGiB: 64
+      network:
+        name: network
+      processorType: Dedicated
+      processors: 2
+      serviceInstanceID: service-instance-id
+      sshKey: ssh-key
+      systemType: e980
+`},
+		},
+		{
+			name:        "non-PowerVS configuration",
+			platform:    types.Platform{AWS: &aws.Platform{}},
+			pool:        types.MachinePoolPlatform{PowerVS: &powervs.MachinePool{}},
+			expectedErr: `^non-PowerVS configuration: "aws"$`,
+		},
+		{
+			name:        "non-PowerVS machine pool",
+			platform:    types.Platform{PowerVS: &powervs.Platform{}},
+			pool:        types.MachinePoolPlatform{AWS: &aws.MachinePool{}},
+			expectedErr: `^non-PowerVS machine-pool: "aws"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &types.InstallConfig{Platform: tc.platform}
+			pool := &types.MachinePool{Name: "master", Platform: tc.pool}
+			objects, err := ClusterAPIManifests("infra-id", config, pool)
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			var manifests []string
+			for _, obj := range objects {
+				data, err := yaml.Marshal(obj.Object)
+				if !assert.NoError(t, err) {
+					return
+				}
+				manifests = append(manifests, string(data))
+			}
+			assert.Equal(t, tc.expected, manifests)
+		})
+	}
+}
@@ -85,11 +85,8 @@ func provider(clusterID string, platform *powervs.Platform, mpool *powervs.Machi
 			Kind:       "PowerVSMachineProviderConfig",
 			APIVersion: machinev1.GroupVersion.String(),
 		},
-		ObjectMeta: metav1.ObjectMeta{},
-		ServiceInstance: machinev1.PowerVSResource{
-			Type: machinev1.PowerVSResourceTypeID,
-			ID:   &platform.ServiceInstanceID,
-		},
+		ObjectMeta:      metav1.ObjectMeta{},
+		ServiceInstance: serviceInstance(clusterID, platform),
 		Image: machinev1.PowerVSResource{
 			Type: machinev1.PowerVSResourceTypeName,
 			Name: &image,
@@ -104,7 +101,10 @@ func provider(clusterID string, platform *powervs.Platform, mpool *powervs.Machi
 		ProcessorType: mpool.ProcType,
 		Processors:    mpool.Processors,
 		MemoryGiB:     mpool.MemoryGiB,
-		KeyPairName:   fmt.Sprintf("%s-key", clusterID),
+		KeyPairName:   powervs.SSHKeyName(clusterID),
+	}
+	if mpool.SSHKeyName != "" {
+		config.KeyPairName = mpool.SSHKeyName
 	}
 	if network != "" {
 		config.Network = machinev1.PowerVSResource{
@@ -124,3 +124,20 @@ func provider(clusterID string, platform *powervs.Platform, mpool *powervs.Machi
 func ConfigMasters(machines []machineapi.Machine, clusterID string) {
 
 }
+
+// serviceInstance returns the reference to the workspace of the cluster: by ID
+// when the install config has one, and otherwise by the name of the workspace
+// created by the installer when the cluster is provisioned.
+func serviceInstance(clusterID string, platform *powervs.Platform) machinev1.PowerVSResource {
+	if platform.ServiceInstanceID != "" {
+		return machinev1.PowerVSResource{
+			Type: machinev1.PowerVSResourceTypeID,
+			ID:   &platform.ServiceInstanceID,
+		}
+	}
+	name := powervs.WorkspaceName(clusterID)
+	return machinev1.PowerVSResource{
+		Type: machinev1.PowerVSResourceTypeName,
+		Name: &name,
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/yaml"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -29,6 +28,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	"github.com/openshift/installer/pkg/asset/machines/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/azure"
@@ -51,6 +51,7 @@ import (
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
 	azuredefaults "github.com/openshift/installer/pkg/types/azure/defaults"
 	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
+	externaltypes "github.com/openshift/installer/pkg/types/external"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 	libvirttypes "github.com/openshift/installer/pkg/types/libvirt"
@@ -59,6 +60,7 @@ import (
 	openstacktypes "github.com/openshift/installer/pkg/types/openstack"
 	ovirttypes "github.com/openshift/installer/pkg/types/ovirt"
 	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+	powervsdefaults "github.com/openshift/installer/pkg/types/powervs/defaults"
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
 	ibmcloudapi "github.com/openshift/machine-api-provider-ibmcloud/pkg/apis"
 	ibmcloudprovider "github.com/openshift/machine-api-provider-ibmcloud/pkg/apis/ibmcloudprovider/v1"
@@ -119,9 +121,14 @@ func defaultAzureMachinePoolPlatform() azuretypes.MachinePool {
 	}
 }
 
-func defaultGCPMachinePoolPlatform() gcptypes.MachinePool {
+func defaultGCPMachinePoolPlatform(architecture types.Architecture) gcptypes.MachinePool {
+	// The N2 machine types are x86 only, Arm machines are T2A.
+	instanceType := "n2-standard-4"
+	if architecture == types.ArchitectureARM64 {
+		instanceType = "t2a-standard-4"
+	}
 	return gcptypes.MachinePool{
-		InstanceType: "n2-standard-4",
+		InstanceType: instanceType,
 		OSDisk: gcptypes.OSDisk{
 			DiskSizeGB: powerOfTwoRootVolumeSize,
 			DiskType:   "pd-ssd",
@@ -173,12 +180,7 @@ func defaultVSphereMachinePoolPlatform() vspheretypes.MachinePool {
 }
 
 func defaultPowerVSMachinePoolPlatform() powervstypes.MachinePool {
-	return powervstypes.MachinePool{
-		MemoryGiB:  32,
-		Processors: intstr.FromString("0.5"),
-		ProcType:   machinev1.PowerVSProcessorTypeShared,
-		SysType:    "s922",
-	}
+	return powervsdefaults.MachinePool()
 }
 
 func defaultNutanixMachinePoolPlatform() nutanixtypes.MachinePool {
@@ -213,6 +215,29 @@ func awsDiscoveryPreferredEdgeInstanceByZone(ctx context.Context, defaultTypes [
 	return true, nil
 }
 
+// awsLocalZoneSubnets returns the metadata of the public subnets created by the
+// installer in the Local Zones of the edge pool when installing in a new VPC.
+func awsLocalZoneSubnets(ctx context.Context, meta *icaws.Metadata, zones []string) (icaws.Subnets, error) {
+	localZones, err := meta.LocalZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subnets := icaws.Subnets{}
+	for _, zone := range zones {
+		localZone, ok := localZones[zone]
+		if !ok {
+			return nil, errors.Errorf("zone %s of the edge pool is not an opted-in Local Zone", zone)
+		}
+		subnets[zone] = icaws.Subnet{
+			Zone:          zone,
+			ZoneType:      localZone.Type,
+			ZoneGroupName: localZone.GroupName,
+			Public:        true,
+		}
+	}
+	return subnets, nil
+}
+
 // Worker generates the machinesets for `worker` machine pool.
 type Worker struct {
 	UserDataFile       *asset.File
@@ -225,6 +250,10 @@ func (w *Worker) Name() string {
 	return "Worker Machines"
 }
 
+// Cacheable marks the Worker asset as cacheable, because looking up the
+// zones and instance types of the machines queries the cloud.
+func (w *Worker) Cacheable() {}
+
 // Dependencies returns all of the dependencies directly needed by the
 // Worker asset
 func (w *Worker) Dependencies() []asset.Asset {
@@ -236,6 +265,7 @@ func (w *Worker) Dependencies() []asset.Asset {
 		&installconfig.PlatformCredsCheck{},
 		&installconfig.InstallConfig{},
 		new(rhcos.Image),
+		new(rhcos.ComputeImages),
 		new(rhcos.Release),
 		&machine.Worker{},
 	}
@@ -247,9 +277,10 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	rhcosImage := new(rhcos.Image)
+	rhcosComputeImages := new(rhcos.ComputeImages)
 	rhcosRelease := new(rhcos.Release)
 	wign := &machine.Worker{}
-	dependencies.Get(clusterID, installConfig, rhcosImage, rhcosRelease, wign)
+	dependencies.Get(clusterID, installConfig, rhcosImage, rhcosComputeImages, rhcosRelease, wign)
 
 	workerUserDataSecretName := "worker-user-data"
 
@@ -280,6 +311,12 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			machineConfigs = append(machineConfigs, ignFIPS)
 		}
+		// Pools with a different architecture than the control plane use
+		// the RHCOS image of their architecture.
+		poolImage := string(*rhcosImage)
+		if pool.Architecture != ic.ControlPlane.Architecture {
+			poolImage = (*rhcosComputeImages)[pool.Architecture]
+		}
 		switch ic.Platform.Name() {
 		case alibabacloudtypes.Name:
 			client, err := installConfig.AlibabaCloud.Client()
@@ -331,10 +368,21 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				var subnetsMeta icaws.Subnets
 				switch pool.Name {
 				case types.MachinePoolEdgeRoleName:
-					subnetsMeta, err = installConfig.AWS.EdgeSubnets(ctx)
+					edgeSubnets, err := installConfig.AWS.EdgeSubnets(ctx)
 					if err != nil {
 						return err
 					}
+					// The pool is placed either on the Outpost or in the Local Zones.
+					outpostARN := ""
+					if pool.Platform.AWS != nil {
+						outpostARN = pool.Platform.AWS.OutpostARN
+					}
+					subnetsMeta = icaws.Subnets{}
+					for id, subnet := range edgeSubnets {
+						if subnet.OutpostARN == outpostARN {
+							subnetsMeta[id] = subnet
+						}
+					}
 					if *pool.Replicas == 0 {
 						sbCount := int64(len(subnetsMeta))
 						pool.Replicas = &sbCount
@@ -352,7 +400,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 
 			mpool := defaultAWSMachinePoolPlatform(pool.Name)
 
-			osImage := strings.SplitN(string(*rhcosImage), ",", 2)
+			osImage := strings.SplitN(poolImage, ",", 2)
 			osImageID := osImage[0]
 			if len(osImage) == 2 {
 				osImageID = "" // the AMI will be generated later on
@@ -361,6 +409,16 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 
 			mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.AWS)
+			if pool.Name == types.MachinePoolEdgeRoleName && len(ic.Platform.AWS.Subnets) == 0 {
+				subnets, err = awsLocalZoneSubnets(ctx, installConfig.AWS, mpool.Zones)
+				if err != nil {
+					return err
+				}
+				if *pool.Replicas == 0 {
+					sbCount := int64(len(subnets))
+					pool.Replicas = &sbCount
+				}
+			}
 			zoneDefaults := false
 			if len(mpool.Zones) == 0 {
 				if len(subnets) > 0 {
@@ -377,7 +435,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 
 			if mpool.InstanceType == "" {
-				instanceTypes := awsdefaults.InstanceTypes(installConfig.Config.Platform.AWS.Region, installConfig.Config.ControlPlane.Architecture, configv1.HighlyAvailableTopologyMode)
+				instanceTypes := awsdefaults.InstanceTypes(installConfig.Config.Platform.AWS.Region, pool.Architecture, configv1.HighlyAvailableTopologyMode)
 
 				switch pool.Name {
 				case types.MachinePoolEdgeRoleName:
@@ -487,7 +545,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				machineSets = append(machineSets, set)
 			}
 		case gcptypes.Name:
-			mpool := defaultGCPMachinePoolPlatform()
+			mpool := defaultGCPMachinePoolPlatform(pool.Architecture)
 			mpool.Set(ic.Platform.GCP.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.GCP)
 			if len(mpool.Zones) == 0 {
@@ -498,7 +556,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				mpool.Zones = azs
 			}
 			pool.Platform.GCP = &mpool
-			sets, err := gcp.MachineSets(clusterID.InfraID, ic, &pool, string(*rhcosImage), "worker", workerUserDataSecretName)
+			sets, err := gcp.MachineSets(clusterID.InfraID, ic, &pool, poolImage, "worker", workerUserDataSecretName)
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects")
 			}
@@ -594,15 +652,28 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			mpool := defaultPowerVSMachinePoolPlatform()
 			mpool.Set(ic.Platform.PowerVS.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.PowerVS)
+			if mpool.SSHKeyName != "" {
+				mpool.SSHKeyName, err = icpowervs.ResolveSSHKeyName(context.TODO(), ic, clusterID.InfraID, mpool.SSHKeyName)
+				if err != nil {
+					return errors.Wrap(err, "failed to resolve the SSH key of worker machines")
+				}
+			}
 			pool.Platform.PowerVS = &mpool
 			sets, err := powervs.MachineSets(clusterID.InfraID, ic, &pool, "worker", "worker-user-data")
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects for powervs provider")
 			}
+			if mpool.SMTLevel != "" {
+				ignSMT, err := machineconfig.ForSMTLevel(mpool.SMTLevel, "worker")
+				if err != nil {
+					return errors.Wrap(err, "failed to create ignition for the SMT level of worker machines")
+				}
+				machineConfigs = append(machineConfigs, ignSMT)
+			}
 			for _, set := range sets {
 				machineSets = append(machineSets, set)
 			}
-		case nonetypes.Name:
+		case externaltypes.Name, nonetypes.Name:
 		case nutanixtypes.Name:
 			mpool := defaultNutanixMachinePoolPlatform()
 			mpool.Set(ic.Platform.Nutanix.DefaultMachinePlatform)
@@ -634,6 +705,14 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 		Data:     data,
 	}
 
+	if args := ic.NodeCustomization.KernelArgumentsFor(types.NodeRoleWorker); len(args) > 0 {
+		ignKargs, err := machineconfig.ForKernelArguments(args, "worker")
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for kernel arguments for worker machines")
+		}
+		machineConfigs = append(machineConfigs, ignKargs)
+	}
+
 	w.MachineConfigFiles, err = machineconfig.Manifests(machineConfigs, "worker", directory)
 	if err != nil {
 		return errors.Wrap(err, "failed to create MachineConfig manifests for worker machines")
@@ -154,6 +154,7 @@ spec:
 						},
 					}),
 				(*rhcos.Image)(pointer.StringPtr("test-image")),
+				&rhcos.ComputeImages{},
 				(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 				&machine.Worker{
 					File: &asset.File{
@@ -216,6 +217,7 @@ func TestComputeIsNotModified(t *testing.T) {
 		},
 		installConfig,
 		(*rhcos.Image)(pointer.StringPtr("test-image")),
+		&rhcos.ComputeImages{},
 		(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 		&machine.Worker{
 			File: &asset.File{
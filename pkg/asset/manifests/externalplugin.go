@@ -0,0 +1,75 @@
+package manifests
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icexternal "github.com/openshift/installer/pkg/asset/installconfig/external"
+)
+
+// ExternalPlugin generates the manifests of the plugin of the provider of the
+// external platform.
+type ExternalPlugin struct {
+	FileList []*asset.File
+}
+
+// Type check the interface at compile time.
+var _ asset.WritableAsset = (*ExternalPlugin)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*ExternalPlugin) Name() string {
+	return "External Platform Plugin Manifests"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate the
+// asset.
+func (*ExternalPlugin) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate runs the manifests hook of the plugin.
+func (ep *ExternalPlugin) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	ep.FileList = nil
+	if installConfig.Config.Platform.External == nil {
+		return nil
+	}
+
+	plugin, err := icexternal.Find(context.TODO(), installConfig.Config.Platform.External.PlatformName)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the external platform plugin")
+	}
+	if plugin == nil {
+		return nil
+	}
+
+	files, err := plugin.Manifests(context.TODO(), installConfig.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate the external platform plugin manifests")
+	}
+	for _, file := range files {
+		ep.FileList = append(ep.FileList, &asset.File{
+			Filename: filepath.Join(manifestDir, file.Filename),
+			Data:     []byte(file.Contents),
+		})
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (ep *ExternalPlugin) Files() []*asset.File {
+	return ep.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (ep *ExternalPlugin) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,290 @@
+package manifests
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+// ExtraManifestsDir is the directory of the extra manifests set by the
+// --extra-manifests-dir flag, which overrides the one of the install config.
+var ExtraManifestsDir string
+
+var (
+	_ asset.WritableAsset = (*ExtraManifests)(nil)
+
+	// knownScheme resolves the kinds of the APIs bundled with the installer.
+	knownScheme = func() *runtime.Scheme {
+		s := runtime.NewScheme()
+		utilruntime.Must(scheme.AddToScheme(s))
+		utilruntime.Must(apiextensionsv1.AddToScheme(s))
+		utilruntime.Must(configv1.Install(s))
+		utilruntime.Must(machinev1.Install(s))
+		utilruntime.Must(machinev1beta1.Install(s))
+		utilruntime.Must(operatorv1.Install(s))
+		utilruntime.Must(mcfgv1.Install(s))
+		return s
+	}()
+
+	// payloadKinds are the kinds of the CRDs of the release payload which
+	// are commonly used in day-0 manifests, but whose APIs are not bundled
+	// with the installer.
+	payloadKinds = map[schema.GroupVersionKind]bool{
+		{Group: "operators.coreos.com", Version: "v1", Kind: "OperatorGroup"}:              true,
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "CatalogSource"}:        true,
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"}:         true,
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}:            true,
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}:            true,
+		{Group: "imageregistry.operator.openshift.io", Version: "v1", Kind: "Config"}:      true,
+		{Group: "cloudcredential.openshift.io", Version: "v1", Kind: "CredentialsRequest"}: true,
+		{Group: "route.openshift.io", Version: "v1", Kind: "Route"}:                        true,
+	}
+)
+
+// ExtraManifests loads the day-0 manifests of the extra manifests directory
+// of the install config.
+type ExtraManifests struct {
+	FileList []*asset.File
+}
+
+// Name returns a human friendly name for the asset.
+func (*ExtraManifests) Name() string {
+	return "Extra Manifests"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate the
+// asset.
+func (*ExtraManifests) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate reads and validates the manifests of the extra manifests
+// directory.
+func (em *ExtraManifests) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	dir := ExtraManifestsDir
+	if dir == "" {
+		dir = installConfig.Config.ExtraManifestsDir
+	}
+	em.FileList = nil
+	if dir == "" {
+		return nil
+	}
+
+	files, err := loadExtraManifests(dir)
+	if err != nil {
+		return errors.Wrapf(err, "invalid extra manifests directory %s", dir)
+	}
+	em.FileList = files
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (em *ExtraManifests) Files() []*asset.File {
+	return em.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (em *ExtraManifests) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
+
+// filesIn returns the extra manifests of the directory of the assets, e.g.
+// manifests or openshift.
+func (em *ExtraManifests) filesIn(directory string) []*asset.File {
+	var files []*asset.File
+	for _, file := range em.FileList {
+		if filepath.Dir(file.Filename) == directory {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// loadExtraManifests reads the manifests of the directory and of its
+// manifests and openshift subdirectories, and returns them with the paths
+// they have in the assets directory.
+func loadExtraManifests(dir string) ([]*asset.File, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("not a directory")
+	}
+
+	sources := []struct {
+		dir    string
+		target string
+	}{
+		{dir: dir, target: openshiftManifestDir},
+		{dir: filepath.Join(dir, manifestDir), target: manifestDir},
+		{dir: filepath.Join(dir, openshiftManifestDir), target: openshiftManifestDir},
+	}
+
+	files := []*asset.File{}
+	crds := []*apiextensionsv1.CustomResourceDefinition{}
+	objects := map[string][]*unstructured.Unstructured{}
+	seen := map[string]string{}
+	for _, source := range sources {
+		entries, err := os.ReadDir(source.dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+			default:
+				continue
+			}
+			if entry.IsDir() {
+				continue
+			}
+
+			path := filepath.Join(source.dir, entry.Name())
+			filename := filepath.Join(source.target, entry.Name())
+			if previous, ok := seen[filename]; ok {
+				return nil, errors.Errorf("%s and %s are both added as %s", previous, path, filename)
+			}
+			seen[filename] = path
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			fileObjects, err := parseManifests(data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %s", path)
+			}
+			for _, obj := range fileObjects {
+				if obj.GroupVersionKind() != apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition") {
+					continue
+				}
+				crd := &apiextensionsv1.CustomResourceDefinition{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+					return nil, errors.Wrapf(err, "failed to parse the CustomResourceDefinition %s of %s", obj.GetName(), path)
+				}
+				crds = append(crds, crd)
+			}
+			objects[path] = fileObjects
+			files = append(files, &asset.File{Filename: filename, Data: data})
+		}
+	}
+
+	// The kinds of the manifests must be bundled with the installer or be
+	// defined by the CRDs of the extra manifests.
+	known := func(gvk schema.GroupVersionKind) bool {
+		if knownScheme.Recognizes(gvk) || payloadKinds[gvk] {
+			return true
+		}
+		for _, crd := range crds {
+			if crd.Spec.Group != gvk.Group || crd.Spec.Names.Kind != gvk.Kind {
+				continue
+			}
+			for _, version := range crd.Spec.Versions {
+				if version.Name == gvk.Version && version.Served {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	paths := make([]string, 0, len(objects))
+	for path := range objects {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var errs []error
+	for _, path := range paths {
+		for _, obj := range objects[path] {
+			if gvk := obj.GroupVersionKind(); !known(gvk) {
+				errs = append(errs, errors.Errorf("%s: unknown kind %s of %s, which is neither a bundled API nor defined by a CustomResourceDefinition of the extra manifests", path, gvk, obj.GetName()))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	asset.SortFiles(files)
+	return files, nil
+}
+
+// parseManifests parses the YAML or JSON documents of the manifest.
+func parseManifests(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	objects := []*unstructured.Unstructured{}
+	for i := 0; ; i++ {
+		raw := map[string]interface{}{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrapf(err, "document %d", i)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: raw}
+		var missing []string
+		if obj.GetAPIVersion() == "" {
+			missing = append(missing, "apiVersion")
+		}
+		if obj.GetKind() == "" {
+			missing = append(missing, "kind")
+		}
+		if obj.GetName() == "" {
+			missing = append(missing, "metadata.name")
+		}
+		if len(missing) > 0 {
+			return nil, errors.Errorf("document %d has no %s", i, strings.Join(missing, ", "))
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// mergeExtraManifests adds the extra manifests to the files, which must not
+// already contain them.
+func mergeExtraManifests(files []*asset.File, extra []*asset.File) ([]*asset.File, error) {
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[file.Filename] = true
+	}
+	for _, file := range extra {
+		if names[file.Filename] {
+			return nil, errors.Errorf("extra manifest %s conflicts with a manifest generated by the installer", file.Filename)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,153 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	machineConfigManifest = `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-worker-chrony
+  labels:
+    machineconfiguration.openshift.io/role: worker
+`
+	crdManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+`
+	widgetManifest = `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: test
+  namespace: default
+`
+)
+
+func TestLoadExtraManifests(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         map[string]string
+		expectedFiles []string
+		expectedErr   string
+	}{
+		{
+			name: "root and subdirectories",
+			files: map[string]string{
+				"99-chrony.yaml":             machineConfigManifest,
+				"openshift/namespace.yml":    "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n",
+				"manifests/proxy.json":       `{"apiVersion": "config.openshift.io/v1", "kind": "Proxy", "metadata": {"name": "cluster"}}`,
+				"README.md":                  "not a manifest",
+				"unrelated/deployment.yaml":  "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: test\n",
+				"manifests/subscription.yml": "apiVersion: operators.coreos.com/v1alpha1\nkind: Subscription\nmetadata:\n  name: test\n",
+			},
+			expectedFiles: []string{
+				"manifests/proxy.json",
+				"manifests/subscription.yml",
+				"openshift/99-chrony.yaml",
+				"openshift/namespace.yml",
+			},
+		},
+		{
+			name: "multiple documents with a CRD",
+			files: map[string]string{
+				"widgets.yaml": crdManifest + "---\n" + widgetManifest + "---\n",
+			},
+			expectedFiles: []string{"openshift/widgets.yaml"},
+		},
+		{
+			name: "CRD in another file",
+			files: map[string]string{
+				"manifests/widget-crd.yaml": crdManifest,
+				"widget.yaml":               widgetManifest,
+			},
+			expectedFiles: []string{"manifests/widget-crd.yaml", "openshift/widget.yaml"},
+		},
+		{
+			name: "unknown kind",
+			files: map[string]string{
+				"widget.yaml": widgetManifest,
+			},
+			expectedErr: `^.*/widget\.yaml: unknown kind example\.com/v1, Kind=Widget of test, which is neither a bundled API nor defined by a CustomResourceDefinition of the extra manifests$`,
+		},
+		{
+			name: "invalid YAML",
+			files: map[string]string{
+				"invalid.yaml": "apiVersion: v1\nkind: [ConfigMap\n",
+			},
+			expectedErr: `^failed to parse .*/invalid\.yaml: document 0: `,
+		},
+		{
+			name: "missing kind and name",
+			files: map[string]string{
+				"manifests/invalid.yaml": "apiVersion: v1\nmetadata: {}\n",
+			},
+			expectedErr: `^failed to parse .*/manifests/invalid\.yaml: document 0 has no kind, metadata\.name$`,
+		},
+		{
+			name: "same file in the root and the openshift subdirectory",
+			files: map[string]string{
+				"99-chrony.yaml":           machineConfigManifest,
+				"openshift/99-chrony.yaml": machineConfigManifest,
+			},
+			expectedErr: `^.*/99-chrony\.yaml and .*/openshift/99-chrony\.yaml are both added as openshift/99-chrony\.yaml$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			files, err := loadExtraManifests(dir)
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			filenames := []string{}
+			for _, file := range files {
+				filenames = append(filenames, file.Filename)
+				assert.Equal(t, tc.files[filepath.Base(file.Filename)]+tc.files[file.Filename], string(file.Data))
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+		})
+	}
+}
+
+func TestMergeExtraManifests(t *testing.T) {
+	generated := []*asset.File{{Filename: "manifests/cluster-config.yaml"}}
+
+	files, err := mergeExtraManifests(generated, []*asset.File{{Filename: "manifests/proxy.yaml"}})
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	_, err = mergeExtraManifests(generated, []*asset.File{{Filename: "manifests/cluster-config.yaml"}})
+	assert.EqualError(t, err, "extra manifest manifests/cluster-config.yaml conflicts with a manifest generated by the installer")
+}
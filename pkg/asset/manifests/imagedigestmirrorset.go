@@ -0,0 +1,91 @@
+package manifests
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var imageDigestMirrorSetFilename = filepath.Join(manifestDir, "image-digest-mirror-set.yaml")
+
+// ImageDigestMirrorSet generates the image-digest-mirror-set.yaml file from
+// the image mirror results of the install config.
+type ImageDigestMirrorSet struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ImageDigestMirrorSet)(nil)
+
+// Name returns a human-friendly name for the asset.
+func (*ImageDigestMirrorSet) Name() string {
+	return "Image Digest Mirror Set"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*ImageDigestMirrorSet) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the ImageDigestMirrorSet config.
+func (p *ImageDigestMirrorSet) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	p.FileList = nil
+	if len(installConfig.MirrorSources) == 0 {
+		return nil
+	}
+
+	mirrorSet := &configv1.ImageDigestMirrorSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "ImageDigestMirrorSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "image-digest-mirror",
+			// not namespaced
+		},
+	}
+	for _, group := range installConfig.MirrorSources {
+		mirrors := make([]configv1.ImageMirror, 0, len(group.Mirrors))
+		for _, mirror := range group.Mirrors {
+			mirrors = append(mirrors, configv1.ImageMirror(mirror))
+		}
+		mirrorSet.Spec.ImageDigestMirrors = append(mirrorSet.Spec.ImageDigestMirrors, configv1.ImageDigestMirrors{
+			Source:  group.Source,
+			Mirrors: mirrors,
+		})
+	}
+
+	data, err := yaml.Marshal(mirrorSet)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal ImageDigestMirrorSet")
+	}
+	p.FileList = []*asset.File{
+		{
+			Filename: imageDigestMirrorSetFilename,
+			Data:     data,
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (p *ImageDigestMirrorSet) Files() []*asset.File {
+	return p.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (p *ImageDigestMirrorSet) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -20,6 +21,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -32,7 +34,8 @@ import (
 )
 
 var (
-	infraCfgFilename           = filepath.Join(manifestDir, "cluster-infrastructure-02-config.yml")
+	// InfraCfgFilename is the path of the Infrastructure config file.
+	InfraCfgFilename           = filepath.Join(manifestDir, "cluster-infrastructure-02-config.yml")
 	cloudControllerUIDFilename = filepath.Join(manifestDir, "cloud-controller-uid-config.yml")
 )
 
@@ -204,6 +207,12 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		config.Spec.PlatformSpec.Type = configv1.LibvirtPlatformType
 	case none.Name:
 		config.Spec.PlatformSpec.Type = configv1.NonePlatformType
+	case external.Name:
+		config.Spec.PlatformSpec.Type = configv1.ExternalPlatformType
+		config.Spec.PlatformSpec.External = &configv1.ExternalPlatformSpec{
+			PlatformName: installConfig.Config.Platform.External.PlatformName,
+		}
+		config.Status.PlatformStatus.External = &configv1.ExternalPlatformStatus{}
 	case openstack.Name:
 		config.Spec.PlatformSpec.Type = configv1.OpenStackPlatformType
 		config.Status.PlatformStatus.OpenStack = &configv1.OpenStackPlatformStatus{
@@ -265,6 +274,12 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 			CISInstanceCRN: cisInstanceCRN,
 			DNSInstanceCRN: dnsInstanceCRN,
 		}
+		if endpoints := installConfig.Config.Platform.PowerVS.ServiceEndpoints; len(endpoints) > 0 {
+			config.Spec.PlatformSpec.PowerVS = &configv1.PowerVSPlatformSpec{
+				ServiceEndpoints: endpoints,
+			}
+			config.Status.PlatformStatus.PowerVS.ServiceEndpoints = endpoints
+		}
 	case nutanix.Name:
 		nutanixPlatform := installConfig.Config.Nutanix
 
@@ -303,6 +318,23 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 			}},
 		}
 
+		// Add the Prism Elements of the failure domains, which the machines
+		// may also run in.
+		prismElementUUIDs := sets.NewString(nutanixPlatform.PrismElements[0].UUID)
+		for _, fd := range nutanixPlatform.FailureDomains {
+			if prismElementUUIDs.Has(fd.PrismElement.UUID) {
+				continue
+			}
+			prismElementUUIDs.Insert(fd.PrismElement.UUID)
+			config.Spec.PlatformSpec.Nutanix.PrismElements = append(config.Spec.PlatformSpec.Nutanix.PrismElements, configv1.NutanixPrismElementEndpoint{
+				Name: fd.PrismElement.Name,
+				Endpoint: configv1.NutanixPrismEndpoint{
+					Address: fd.PrismElement.Endpoint.Address,
+					Port:    fd.PrismElement.Endpoint.Port,
+				},
+			})
+		}
+
 		if len(installConfig.Config.Nutanix.APIVIPs) > 0 {
 			config.Status.PlatformStatus.Nutanix = &configv1.NutanixPlatformStatus{
 				APIServerInternalIP:  installConfig.Config.Nutanix.APIVIPs[0],
@@ -333,7 +365,7 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		return errors.Wrapf(err, "failed to marshal config: %#v", config)
 	}
 	i.FileList = append(i.FileList, &asset.File{
-		Filename: infraCfgFilename,
+		Filename: InfraCfgFilename,
 		Data:     configData,
 	})
 	return nil
@@ -15,7 +15,6 @@ import (
 	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
-	"github.com/openshift/installer/pkg/types/powervs"
 )
 
 var (
@@ -124,8 +123,23 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 		},
 	}
 
-	switch installConfig.Config.Platform.Name() {
-	case aws.Name:
+	// The OVNKubernetes configuration is checked first, as it is written for
+	// every platform with OVNKubernetes settings, which include the MTU of the
+	// AWS edge pools. The AWS case is then only left with OpenShiftSDN.
+	switch {
+	case netConfig.NetworkType == string(operatorv1.NetworkTypeOVNKubernetes):
+		if ovnConfig := ovnKubernetesConfig(installConfig.Config); ovnConfig != nil {
+			cnoCfg, err := OvnKubeConfig(clusterNet, serviceNet, ovnConfig)
+			if err != nil {
+				return errors.Wrapf(err, "cannot marshal OVNKube Config")
+			}
+			no.FileList = append(no.FileList, &asset.File{
+				Filename: cnoCfgFilename,
+				Data:     cnoCfg,
+			})
+		}
+
+	case installConfig.Config.Platform.Name() == aws.Name:
 		cnoDefCfg, exists, err := no.generateDefaultNetworkConfigAWSEdge(installConfig)
 		if err != nil {
 			return err
@@ -136,19 +150,6 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 				Data:     cnoDefCfg,
 			})
 		}
-
-	case powervs.Name:
-		if netConfig.NetworkType == "OVNKubernetes" {
-			ovnConfig, err := OvnKubeConfig(clusterNet, serviceNet, true)
-			if err != nil {
-				return errors.Wrapf(err, "cannot marshal Power VS OVNKube Config")
-			}
-			no.FileList = append(no.FileList, &asset.File{
-				Filename: cnoCfgFilename,
-				Data:     ovnConfig,
-			})
-		}
-
 	}
 
 	return nil
@@ -191,32 +192,18 @@ func (no *Networking) generateDefaultNetworkConfig(defaultNetwork *operatorv1.De
 // https://docs.aws.amazon.com/local-zones/latest/ug/how-local-zones-work.html
 func (no *Networking) generateDefaultNetworkConfigAWSEdge(ic *installconfig.InstallConfig) ([]byte, bool, error) {
 	var (
-		hasEdgePool = false
-		defNetCfg   *operatorv1.DefaultNetworkDefinition
-		err         error
+		defNetCfg *operatorv1.DefaultNetworkDefinition
+		err       error
 	)
 
 	netConfig := ic.Config.Networking
 
 	// Setup defaultNetwork only for Edge deployment on AWS
-	for _, mp := range ic.Config.Compute {
-		if mp.Name == types.MachinePoolEdgeRoleName {
-			hasEdgePool = true
-		}
-	}
-	if !hasEdgePool {
+	if !hasEdgePool(ic.Config) {
 		return nil, false, nil
 	}
 
 	switch netConfig.NetworkType {
-	case string(operatorv1.NetworkTypeOVNKubernetes):
-		defNetCfg = &operatorv1.DefaultNetworkDefinition{
-			Type: operatorv1.NetworkTypeOVNKubernetes,
-			OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
-				MTU: &ovnKNetworkMtuEdge,
-			},
-		}
-
 	case string(operatorv1.NetworkTypeOpenShiftSDN):
 		defNetCfg = &operatorv1.DefaultNetworkDefinition{
 			Type: operatorv1.NetworkTypeOpenShiftSDN,
@@ -235,3 +222,13 @@ func (no *Networking) generateDefaultNetworkConfigAWSEdge(ic *installconfig.Inst
 
 	return cnoConfig, true, nil
 }
+
+// hasEdgePool returns whether the install config has an edge machine pool.
+func hasEdgePool(ic *types.InstallConfig) bool {
+	for _, mp := range ic.Compute {
+		if mp.Name == types.MachinePoolEdgeRoleName {
+			return true
+		}
+	}
+	return false
+}
@@ -70,6 +70,7 @@ func (o *Openshift) Dependencies() []asset.Asset {
 		&openshift.BaremetalConfig{},
 		new(rhcos.Image),
 		&openshift.AzureCloudProviderSecret{},
+		&ExtraManifests{},
 	}
 }
 
@@ -303,6 +304,14 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 	o.FileList = append(o.FileList, openshiftInstall.Files()...)
 	o.FileList = append(o.FileList, featureGate.Files()...)
 
+	extraManifests := &ExtraManifests{}
+	dependencies.Get(extraManifests)
+	fileList, err := mergeExtraManifests(o.FileList, extraManifests.filesIn(openshiftManifestDir))
+	if err != nil {
+		return err
+	}
+	o.FileList = fileList
+
 	asset.SortFiles(o.FileList)
 
 	return nil
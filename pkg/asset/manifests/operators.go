@@ -62,7 +62,10 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&Proxy{},
 		&Scheduler{},
 		&ImageContentSourcePolicy{},
+		&ImageDigestMirrorSet{},
 		&ClusterCSIDriverConfig{},
+		&ExternalPlugin{},
+		&ExtraManifests{},
 		&tls.RootCA{},
 		&tls.MCSCertKey{},
 
@@ -84,8 +87,11 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	proxy := &Proxy{}
 	scheduler := &Scheduler{}
 	imageContentSourcePolicy := &ImageContentSourcePolicy{}
+	imageDigestMirrorSet := &ImageDigestMirrorSet{}
 	clusterCSIDriverConfig := &ClusterCSIDriverConfig{}
-	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy, clusterCSIDriverConfig)
+	externalPlugin := &ExternalPlugin{}
+	extraManifests := &ExtraManifests{}
+	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy, imageDigestMirrorSet, clusterCSIDriverConfig, externalPlugin, extraManifests)
 
 	redactedConfig, err := redactedInstallConfig(*installConfig.Config)
 	if err != nil {
@@ -120,7 +126,13 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	m.FileList = append(m.FileList, proxy.Files()...)
 	m.FileList = append(m.FileList, scheduler.Files()...)
 	m.FileList = append(m.FileList, imageContentSourcePolicy.Files()...)
+	m.FileList = append(m.FileList, imageDigestMirrorSet.Files()...)
 	m.FileList = append(m.FileList, clusterCSIDriverConfig.Files()...)
+	m.FileList = append(m.FileList, externalPlugin.Files()...)
+	m.FileList, err = mergeExtraManifests(m.FileList, extraManifests.filesIn(manifestDir))
+	if err != nil {
+		return err
+	}
 
 	asset.SortFiles(m.FileList)
 
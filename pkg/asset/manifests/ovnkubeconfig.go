@@ -6,10 +6,13 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/powervs"
 )
 
 // OvnKubeConfig creates a config file for the OVNKubernetes CNI provider
-func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouting bool) ([]byte, error) {
+func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, ovnKubeConfig *operatorv1.OVNKubernetesConfig) ([]byte, error) {
 
 	operCNs := []operatorv1.ClusterNetworkEntry{}
 	for _, cn := range cns {
@@ -32,12 +35,8 @@ func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouti
 			ClusterNetwork: operCNs,
 			ServiceNetwork: sn,
 			DefaultNetwork: operatorv1.DefaultNetworkDefinition{
-				Type: operatorv1.NetworkTypeOVNKubernetes,
-				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
-					GatewayConfig: &operatorv1.GatewayConfig{
-						RoutingViaHost: useHostRouting,
-					},
-				},
+				Type:                operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: ovnKubeConfig,
 			},
 		},
 		Status: operatorv1.NetworkStatus{},
@@ -45,3 +44,42 @@ func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouti
 
 	return yaml.Marshal(ovnConfig)
 }
+
+// ovnKubernetesConfig returns the configuration of the OVNKubernetes CNI
+// provider for the install config, or nil when the defaults of the cluster
+// network operator are used. On AWS, the MTU defaults to the MTU of the AWS
+// Local Zones when there is an edge machine pool. On Power VS, the traffic is
+// always routed via the host.
+func ovnKubernetesConfig(ic *types.InstallConfig) *operatorv1.OVNKubernetesConfig {
+	ovnConfig := &operatorv1.OVNKubernetesConfig{}
+	if c := ic.Networking.OVNKubernetesConfig; c != nil {
+		if c.MTU != 0 {
+			mtu := c.MTU
+			ovnConfig.MTU = &mtu
+		}
+		if c.V4InternalSubnet != nil {
+			ovnConfig.V4InternalSubnet = c.V4InternalSubnet.String()
+		}
+		if c.V6InternalSubnet != nil {
+			ovnConfig.V6InternalSubnet = c.V6InternalSubnet.String()
+		}
+		if c.GatewayConfig != nil {
+			ovnConfig.GatewayConfig = &operatorv1.GatewayConfig{RoutingViaHost: c.GatewayConfig.RoutingViaHost}
+		}
+	}
+
+	switch ic.Platform.Name() {
+	case aws.Name:
+		if ovnConfig.MTU == nil && hasEdgePool(ic) {
+			mtu := ovnKNetworkMtuEdge
+			ovnConfig.MTU = &mtu
+		}
+	case powervs.Name:
+		ovnConfig.GatewayConfig = &operatorv1.GatewayConfig{RoutingViaHost: true}
+	}
+
+	if ovnConfig.MTU == nil && ovnConfig.V4InternalSubnet == "" && ovnConfig.V6InternalSubnet == "" && ovnConfig.GatewayConfig == nil {
+		return nil
+	}
+	return ovnConfig
+}
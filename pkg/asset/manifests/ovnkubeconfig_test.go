@@ -0,0 +1,86 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/none"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestOVNKubernetesConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform types.Platform
+		compute  []types.MachinePool
+		config   *types.OVNKubernetesConfig
+		expected *operatorv1.OVNKubernetesConfig
+	}{
+		{
+			name:     "defaults",
+			platform: types.Platform{None: &none.Platform{}},
+		},
+		{
+			name:     "install config",
+			platform: types.Platform{None: &none.Platform{}},
+			config: &types.OVNKubernetesConfig{
+				MTU:              1400,
+				V4InternalSubnet: ipnet.MustParseCIDR("100.66.0.0/16"),
+				V6InternalSubnet: ipnet.MustParseCIDR("fd99::/64"),
+				GatewayConfig:    &types.OVNGatewayConfig{RoutingViaHost: true},
+			},
+			expected: &operatorv1.OVNKubernetesConfig{
+				MTU:              pointer.Uint32(1400),
+				V4InternalSubnet: "100.66.0.0/16",
+				V6InternalSubnet: "fd99::/64",
+				GatewayConfig:    &operatorv1.GatewayConfig{RoutingViaHost: true},
+			},
+		},
+		{
+			name:     "AWS edge pool",
+			platform: types.Platform{AWS: &aws.Platform{}},
+			compute:  []types.MachinePool{{Name: types.MachinePoolComputeRoleName}, {Name: types.MachinePoolEdgeRoleName}},
+			expected: &operatorv1.OVNKubernetesConfig{MTU: pointer.Uint32(1200)},
+		},
+		{
+			name:     "AWS edge pool with MTU",
+			platform: types.Platform{AWS: &aws.Platform{}},
+			compute:  []types.MachinePool{{Name: types.MachinePoolEdgeRoleName}},
+			config:   &types.OVNKubernetesConfig{MTU: 1100},
+			expected: &operatorv1.OVNKubernetesConfig{MTU: pointer.Uint32(1100)},
+		},
+		{
+			name:     "AWS without edge pool",
+			platform: types.Platform{AWS: &aws.Platform{}},
+			compute:  []types.MachinePool{{Name: types.MachinePoolComputeRoleName}},
+		},
+		{
+			name:     "Power VS",
+			platform: types.Platform{PowerVS: &powervs.Platform{}},
+			config:   &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("100.66.0.0/16")},
+			expected: &operatorv1.OVNKubernetesConfig{
+				V4InternalSubnet: "100.66.0.0/16",
+				GatewayConfig:    &operatorv1.GatewayConfig{RoutingViaHost: true},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Platform: tc.platform,
+				Compute:  tc.compute,
+				Networking: &types.Networking{
+					NetworkType:         string(operatorv1.NetworkTypeOVNKubernetes),
+					OVNKubernetesConfig: tc.config,
+				},
+			}
+			assert.Equal(t, tc.expected, ovnKubernetesConfig(ic))
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"text/template"
+
+	"github.com/openshift/installer/pkg/types/powervs"
 )
 
 // https://github.com/kubernetes/kubernetes/blob/368ee4bb8ee7a0c18431cd87ee49f0c890aa53e5/staging/src/k8s.io/legacy-cloud-providers/gce/gce.go#L188
@@ -32,11 +34,14 @@ type provider struct {
 	G2WorkerServiceAccountID string `gcfg:"g2workerServiceAccountID"`
 	G2VPCSubnetNames         string `gcfg:"g2VpcSubnetNames"`
 	PowerVSCloudInstanceID   string `gcfg:"powerVSCloudInstanceID"`
+	PowerVSCloudInstanceName string `gcfg:"powerVSCloudInstanceName"`
 	PowerVSRegion            string `gcfg:"powerVSRegion"`
 	PowerVSZone              string `gcfg:"powerVSZone"`
 }
 
 // CloudProviderConfig generates the cloud provider config for the IBM Power VS platform.
+// Without a cloud instance ID, the workspace created by the installer when the
+// cluster is provisioned is referenced by name.
 func CloudProviderConfig(infraID string, accountID string, vpcName string, region string, resourceGroupName string, subnets []string, cloudInstID string, pvsRegion string, pvsZone string) (string, error) {
 	config := &config{
 		Global: global{
@@ -56,6 +61,7 @@ func CloudProviderConfig(infraID string, accountID string, vpcName string, regio
 			G2WorkerServiceAccountID: accountID,
 			G2VPCSubnetNames:         strings.Join(subnets, ","),
 			PowerVSCloudInstanceID:   cloudInstID,
+			PowerVSCloudInstanceName: cloudInstanceName(infraID, cloudInstID),
 			PowerVSRegion:            pvsRegion,
 			PowerVSZone:              pvsZone,
 		},
@@ -82,7 +88,20 @@ g2ResourceGroupName = {{.Provider.G2ResourceGroupName}}
 g2VpcName = {{.Provider.G2VPCName}}
 g2workerServiceAccountID = {{.Provider.G2WorkerServiceAccountID}}
 g2VpcSubnetNames = {{.Provider.G2VPCSubnetNames}}
+{{- if ne .Provider.PowerVSCloudInstanceID "" }}
 powerVSCloudInstanceID = {{.Provider.PowerVSCloudInstanceID}}
+{{- else }}
+powerVSCloudInstanceName = {{.Provider.PowerVSCloudInstanceName}}
+{{- end }}
 powerVSRegion = {{.Provider.PowerVSRegion}}
 powerVSZone = {{.Provider.PowerVSZone}}
 `
+
+// cloudInstanceName returns the name of the workspace created by the
+// installer, or an empty string when the install config has a workspace.
+func cloudInstanceName(infraID string, cloudInstID string) string {
+	if cloudInstID != "" {
+		return ""
+	}
+	return powervs.WorkspaceName(infraID)
+}
@@ -0,0 +1,24 @@
+// Package prompt controls whether the assets may prompt the user for the
+// input missing from the install config and the environment.
+package prompt
+
+import (
+	"os"
+
+	terminal "golang.org/x/term"
+)
+
+// nonInteractive disables the prompts of all of the assets.
+var nonInteractive = false
+
+// SetNonInteractive controls whether the assets fail instead of prompting
+// the user for missing input.
+func SetNonInteractive(value bool) {
+	nonInteractive = value
+}
+
+// Interactive returns true if the user can be prompted for input, i.e. the
+// prompts are not disabled and the standard input is a terminal.
+func Interactive() bool {
+	return !nonInteractive && terminal.IsTerminal(int(os.Stdin.Fd()))
+}
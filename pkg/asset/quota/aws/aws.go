@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"sort"
 	"strings"
@@ -34,6 +35,7 @@ func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine
 		network(config, append(ctrplConfigs, computeConfigs...)),
 		controlPlane(config, ctrplConfigs, instanceTypes),
 		compute(config, computeReplicas, computeConfigs, instanceTypes),
+		storage(config, ctrplConfigs, computeReplicas, computeConfigs),
 		others,
 	} {
 		ret = append(ret, gen()...)
@@ -127,6 +129,74 @@ func compute(config *types.InstallConfig, replicas []int64, machines []*machinea
 	}
 }
 
+// ebsStorageQuotas maps the EBS volume types to the quotas of their total
+// storage in TiB.
+var ebsStorageQuotas = map[string]string{
+	"gp2": "ebs/L-D18FCD1D",
+	"gp3": "ebs/L-7A658B76",
+	"io1": "ebs/L-FD252861",
+	"io2": "ebs/L-09BD8365",
+}
+
+func storage(config *types.InstallConfig, controlPlanes []*machineapi.AWSMachineProviderConfig, replicas []int64, computes []*machineapi.AWSMachineProviderConfig) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		// The quotas are in TiB, so the sizes are summed in GiB before converting.
+		sizes := map[string]int64{}
+		add := func(m *machineapi.AWSMachineProviderConfig, count int64) {
+			for _, device := range m.BlockDevices {
+				if device.EBS == nil || device.EBS.VolumeSize == nil || device.EBS.VolumeType == nil {
+					continue
+				}
+				sizes[*device.EBS.VolumeType] += count * *device.EBS.VolumeSize
+			}
+		}
+		for _, m := range controlPlanes {
+			add(m, 1)
+		}
+		for idx, m := range computes {
+			add(m, replicas[idx])
+		}
+
+		var ret []quota.Constraint
+		for volumeType, size := range sizes {
+			name, ok := ebsStorageQuotas[volumeType]
+			if !ok {
+				continue
+			}
+			ret = append(ret, quota.Constraint{
+				Name:   name,
+				Region: config.Platform.AWS.Region,
+				Count:  int64(math.Ceil(float64(size) / 1024)),
+			})
+		}
+		return ret
+	}
+}
+
+// IncreaseRequests returns the quota increase requests to file for the
+// constraints which are not available.
+func IncreaseRequests(quotas []quota.Quota, reports []quota.ConstraintReport) []string {
+	var requests []string
+	for _, report := range reports {
+		if report.Result != quota.NotAvailable {
+			continue
+		}
+		for _, q := range quotas {
+			if !strings.EqualFold(q.Name, report.For.Name) || !strings.EqualFold(q.Region, report.For.Region) {
+				continue
+			}
+			parts := strings.SplitN(q.Name, "/", 2)
+			if len(parts) != 2 {
+				break
+			}
+			requests = append(requests, fmt.Sprintf("Request an increase of %s in %s to at least %d at https://console.aws.amazon.com/servicequotas/home/services/%s/quotas/%s",
+				q.Name, report.For.Region, q.InUse+report.For.Count, parts[0], parts[1]))
+			break
+		}
+	}
+	return requests
+}
+
 func others() []quota.Constraint {
 	return []quota.Constraint{}
 }
@@ -6,7 +6,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 func Test_aggregate(t *testing.T) {
@@ -56,3 +59,46 @@ func Test_aggregate(t *testing.T) {
 		})
 	}
 }
+
+func Test_storage(t *testing.T) {
+	volume := func(volumeType string, size int64) *machineapi.AWSMachineProviderConfig {
+		return &machineapi.AWSMachineProviderConfig{
+			BlockDevices: []machineapi.BlockDeviceMappingSpec{{
+				EBS: &machineapi.EBSBlockDeviceSpec{
+					VolumeType: &volumeType,
+					VolumeSize: &size,
+				},
+			}},
+		}
+	}
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			AWS: &awstypes.Platform{Region: "us-east-1"},
+		},
+	}
+
+	got := aggregate(storage(config,
+		[]*machineapi.AWSMachineProviderConfig{volume("gp3", 120), volume("gp3", 120), volume("gp3", 120)},
+		[]int64{6, 2},
+		[]*machineapi.AWSMachineProviderConfig{volume("gp3", 120), volume("io1", 500)},
+	)())
+	assert.EqualValues(t, []quota.Constraint{
+		{Name: "ebs/L-7A658B76", Region: "us-east-1", Count: 2},
+		{Name: "ebs/L-FD252861", Region: "us-east-1", Count: 1},
+	}, got)
+}
+
+func TestIncreaseRequests(t *testing.T) {
+	quotas := []quota.Quota{
+		{Name: "vpc/L-F678F1CE", Region: "us-east-1", InUse: 5, Limit: 5},
+		{Name: "ec2/L-1216C47A", Region: "us-east-1", InUse: 0, Limit: 64},
+	}
+	reports := []quota.ConstraintReport{
+		{For: &quota.Constraint{Name: "vpc/L-F678F1CE", Region: "us-east-1", Count: 1}, Result: quota.NotAvailable},
+		{For: &quota.Constraint{Name: "ec2/L-1216C47A", Region: "us-east-1", Count: 24}, Result: quota.Available},
+	}
+
+	assert.Equal(t, []string{
+		"Request an increase of vpc/L-F678F1CE in us-east-1 to at least 6 at https://console.aws.amazon.com/servicequotas/home/services/vpc/quotas/L-F678F1CE",
+	}, IncreaseRequests(quotas, reports))
+}
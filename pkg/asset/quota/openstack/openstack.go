@@ -48,10 +48,19 @@ func Constraints(ci *validation.CloudInfo, controlPlanes []machineapi.Machine, c
 	}
 	constraints = append(constraints, instanceConstraint(int64(len(controlPlanes))))
 
+	// The bootstrap machine uses the same flavor, networks and root volume
+	// as the control plane machines.
+	if len(controlPlanes) > 0 {
+		constraints = append(constraints, machineConstraints(ci, &controlPlanes[0], networkType)...)
+		constraints = append(constraints, instanceConstraint(1))
+		if ci.ExternalNetwork != nil {
+			constraints = append(constraints, floatingIPConstraint(1))
+		}
+	}
+
 	for i := 0; i < len(computes); i++ {
 		constraints = append(constraints, machineSetConstraints(ci, &computes[i], networkType)...)
 	}
-	constraints = append(constraints, instanceConstraint(int64(len(computes))))
 	constraints = append(constraints, getNetworkConstraints(networkType)...)
 
 	// If the cluster is using pre-provisioned networks, then the quota constraints should be
@@ -87,7 +96,8 @@ func machineConstraints(ci *validation.CloudInfo, machine *machineapi.Machine, n
 		return nil
 	}
 	flavor := flavorInfo.Flavor
-	return []quota.Constraint{machineFlavorCoresToQuota(&flavor), machineFlavorRAMToQuota(&flavor), portConstraint(int64(len(osps.Networks)))}
+	constraints := []quota.Constraint{machineFlavorCoresToQuota(&flavor), machineFlavorRAMToQuota(&flavor), portConstraint(int64(len(osps.Networks)))}
+	return append(constraints, rootVolumeConstraints(osps, 1)...)
 }
 
 func machineSetConstraints(ci *validation.CloudInfo, ms *machineapi.MachineSet, networkType string) []quota.Constraint {
@@ -120,7 +130,20 @@ func machineSetConstraints(ci *validation.CloudInfo, ms *machineapi.MachineSet,
 	ramConstraint.Count = ramConstraint.Count * int64(*replicas)
 	portConstraint := portConstraint((int64(len(osps.Networks)) * int64(*replicas)))
 
-	return []quota.Constraint{coresConstraint, ramConstraint, portConstraint}
+	constraints := []quota.Constraint{coresConstraint, ramConstraint, portConstraint, instanceConstraint(int64(*replicas))}
+	return append(constraints, rootVolumeConstraints(osps, int64(*replicas))...)
+}
+
+// rootVolumeConstraints returns the Cinder volumes and gigabytes used by the
+// root volumes of the machines, if they boot from volume.
+func rootVolumeConstraints(osps *machinev1alpha1.OpenstackProviderSpec, replicas int64) []quota.Constraint {
+	if osps.RootVolume == nil {
+		return nil
+	}
+	return []quota.Constraint{
+		generateConstraint("Volumes", replicas),
+		generateConstraint("Gigabytes", int64(osps.RootVolume.Size)*replicas),
+	}
 }
 
 func aggregate(quotas []quota.Constraint) []quota.Constraint {
@@ -151,6 +174,10 @@ func portConstraint(count int64) quota.Constraint {
 	return generateConstraint("Port", count)
 }
 
+func floatingIPConstraint(count int64) quota.Constraint {
+	return generateConstraint("FloatingIP", count)
+}
+
 func routerConstraint(count int64) quota.Constraint {
 	return generateConstraint("Router", count)
 }
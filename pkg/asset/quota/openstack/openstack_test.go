@@ -0,0 +1,63 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	machinev1alpha1 "github.com/openshift/api/machine/v1alpha1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/asset/installconfig/openstack/validation"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func providerSpec(flavor string, rootVolumeSize int) machineapi.ProviderSpec {
+	osps := &machinev1alpha1.OpenstackProviderSpec{
+		Flavor:   flavor,
+		Networks: []machinev1alpha1.NetworkParam{{}},
+	}
+	if rootVolumeSize > 0 {
+		osps.RootVolume = &machinev1alpha1.RootVolume{Size: rootVolumeSize}
+	}
+	return machineapi.ProviderSpec{Value: &runtime.RawExtension{Object: osps}}
+}
+
+func TestConstraints(t *testing.T) {
+	ci := &validation.CloudInfo{
+		ExternalNetwork: &networks.Network{},
+		Flavors: map[string]validation.Flavor{
+			"master": {Flavor: flavors.Flavor{VCPUs: 4, RAM: 16384}},
+			"worker": {Flavor: flavors.Flavor{VCPUs: 2, RAM: 8192}},
+		},
+	}
+
+	controlPlanes := make([]machineapi.Machine, 3)
+	for i := range controlPlanes {
+		controlPlanes[i].Spec.ProviderSpec = providerSpec("master", 100)
+	}
+	computes := []machineapi.MachineSet{{}, {}}
+	for i := range computes {
+		computes[i].Spec.Replicas = pointer.Int32(2)
+		computes[i].Spec.Template.Spec.ProviderSpec = providerSpec("worker", 0)
+	}
+
+	expected := []quota.Constraint{
+		{Name: "Cores", Count: 4*4 + 4*2},
+		{Name: "RAM", Count: 4*16384 + 4*8192},
+		{Name: "Instances", Count: 8},
+		{Name: "Volumes", Count: 4},
+		{Name: "Gigabytes", Count: 400},
+		{Name: "FloatingIP", Count: 1},
+		{Name: "Port", Count: 8 + 4},
+		{Name: "Router", Count: 1},
+		{Name: "Subnet", Count: 1},
+		{Name: "Network", Count: 1},
+		{Name: "SecurityGroup", Count: 2},
+		{Name: "SecurityGroupRule", Count: 56},
+	}
+	assert.ElementsMatch(t, expected, Constraints(ci, controlPlanes, computes, "OVNKubernetes"))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/quota/aws"
 	"github.com/openshift/installer/pkg/asset/quota/gcp"
 	"github.com/openshift/installer/pkg/asset/quota/openstack"
+	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/diagnostics"
 	"github.com/openshift/installer/pkg/quota"
 	quotaaws "github.com/openshift/installer/pkg/quota/aws"
@@ -26,6 +27,7 @@ import (
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	typesgcp "github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -50,6 +52,7 @@ func (a *PlatformQuotaCheck) Dependencies() []asset.Asset {
 		&installconfig.InstallConfig{},
 		&machines.Master{},
 		&machines.Worker{},
+		new(rhcos.Image),
 	}
 }
 
@@ -58,8 +61,14 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 	ic := &installconfig.InstallConfig{}
 	mastersAsset := &machines.Master{}
 	workersAsset := &machines.Worker{}
-	dependencies.Get(ic, mastersAsset, workersAsset)
+	rhcosImage := new(rhcos.Image)
+	dependencies.Get(ic, mastersAsset, workersAsset, rhcosImage)
+	return installconfig.CloudValidationError(ic.Config, "platform quota check", checkQuota(ic, mastersAsset, workersAsset, rhcosImage))
+}
 
+// checkQuota validates the quotas of the platform against the resources of
+// the cluster.
+func checkQuota(ic *installconfig.InstallConfig, mastersAsset *machines.Master, workersAsset *machines.Worker, rhcosImage *rhcos.Image) error {
 	masters, err := mastersAsset.Machines()
 	if err != nil {
 		return err
@@ -77,7 +86,7 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			logrus.Debugf("%s does not support API for checking quotas, therefore skipping.", ic.AWS.Region)
 			return nil
 		}
-		services := []string{"ec2", "vpc"}
+		services := []string{"ec2", "vpc", "ebs"}
 		session, err := ic.AWS.Session(context.TODO())
 		if err != nil {
 			return errors.Wrap(err, "failed to load AWS session")
@@ -101,6 +110,9 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		}
 		reports, err := quota.Check(q, aws.Constraints(ic.Config, masters, workers, instanceTypes))
 		if err != nil {
+			for _, request := range aws.IncreaseRequests(q, reports) {
+				logrus.Error(request)
+			}
 			return summarizeFailingReport(reports)
 		}
 		summarizeReport(reports)
@@ -146,7 +158,7 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		}
 		summarizeReport(reports)
 	case powervs.Name:
-		bxCli, err := configpowervs.NewBxClient()
+		bxCli, err := configpowervs.NewBxClient(ic.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
 			return errors.Wrap(err, "failed to create bluemix client")
 		}
@@ -156,24 +168,25 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "failed to get PowerVS connection details")
 		}
 
-		// Only check that there isn't an existing Cloud connection if we're not re-using one
-		if ic.Config.Platform.PowerVS.CloudConnectionName == "" {
-			err = bxCli.ValidateCloudConnectionInPowerVSRegion(context.TODO(), ic.Config.Platform.PowerVS.ServiceInstanceID)
-			if err != nil {
-				return errors.Wrap(err, "failed to meet the prerequisite for Cloud Connections")
-			}
+		report := bxCli.ValidateAll(context.TODO(), ic.Config, string(*rhcosImage), masters, workers)
+		report.LogWarnings()
+		if err := report.ToAggregate(); err != nil {
+			return err
 		}
-		err = bxCli.ValidateCapacity(context.TODO(), masters, workers, ic.Config.Platform.PowerVS.ServiceInstanceID)
+
+		client, err := configpowervs.NewClient(ic.Config.PowerVS.ServiceEndpoints)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to create PowerVS client")
 		}
-		if ic.Config.Platform.PowerVS.PVSNetworkName == "" {
-			err = bxCli.ValidateDhcpService(context.TODO(), ic.Config.Platform.PowerVS.ServiceInstanceID, ic.Config.MachineNetwork)
-			if err != nil {
-				return errors.Wrap(err, "failed to meet the prerequisite of one DHCP service per Power VS instance")
+		reports, err := configpowervs.ValidateVPCQuotas(context.TODO(), client, ic.Config)
+		if err != nil {
+			if reports == nil {
+				return errors.Wrap(err, "failed to load VPC quotas")
 			}
+			return summarizeFailingReport(reports)
 		}
-	case alibabacloud.Name, azure.Name, baremetal.Name, ibmcloud.Name, libvirt.Name, none.Name, ovirt.Name, vsphere.Name, nutanix.Name:
+		summarizeReport(reports)
+	case alibabacloud.Name, azure.Name, baremetal.Name, external.Name, ibmcloud.Name, libvirt.Name, none.Name, ovirt.Name, vsphere.Name, nutanix.Name:
 		// no special provisioning requirements to check
 	default:
 		err = fmt.Errorf("unknown platform type %q", platform)
@@ -0,0 +1,172 @@
+package releaseimage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// manifestListMediaTypes are the media types of the manifests which
+	// reference an image for each architecture.
+	manifestListMediaTypes = []string{
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.index.v1+json",
+	}
+	// manifestMediaTypes are the media types of the manifests of a single
+	// image.
+	manifestMediaTypes = []string{
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+	}
+)
+
+// IsMultiArch returns whether the release payload is multi-arch, i.e. whether
+// its pull spec references a manifest list with an image for each
+// architecture. The pull secret authenticates with the registry of the
+// payload.
+func IsMultiArch(ctx context.Context, pullSpec, pullSecret string) (bool, error) {
+	return isMultiArch(ctx, http.DefaultClient, "https", pullSpec, pullSecret)
+}
+
+func isMultiArch(ctx context.Context, client *http.Client, scheme, pullSpec, pullSecret string) (bool, error) {
+	named, reference, err := parseReference(pullSpec)
+	if err != nil {
+		return false, err
+	}
+	registry, err := newRegistryClient(client, scheme, named, pullSecret)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := registry.do(ctx, http.MethodHead, "manifests/"+reference, allManifestMediaTypes())
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("failed to fetch the manifest of %s: %s", pullSpec, resp.Status)
+	}
+
+	return isManifestList(resp.Header.Get("Content-Type")), nil
+}
+
+// isManifestList returns whether the content type is the one of a manifest
+// list.
+func isManifestList(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	for _, t := range manifestListMediaTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize answers the authentication challenge of the registry, and returns
+// the Authorization header of the following requests.
+func authorize(ctx context.Context, client *http.Client, challenge, repository, username, password string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" {
+			return "", errors.New("no credentials in the pull secret")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+	default:
+		return "", errors.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", errors.Errorf("invalid authentication realm %q", params["realm"])
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.Wrap(err, "failed to decode the token")
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", errors.New("no token returned")
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+// `Bearer realm="https://auth.example.com/token",service="registry"`.
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	for _, param := range strings.Split(rest, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+		params[strings.ToLower(key)] = strings.Trim(value, `"`)
+	}
+	return scheme, params
+}
+
+// registryCredentials returns the credentials of the registry in the pull
+// secret, if any.
+func registryCredentials(pullSecret, registry string) (string, string, error) {
+	if pullSecret == "" {
+		return "", "", nil
+	}
+	var secret struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(pullSecret), &secret); err != nil {
+		return "", "", errors.Wrap(err, "failed to parse the pull secret")
+	}
+	for _, key := range []string{registry, "https://" + registry} {
+		auth, ok := secret.Auths[key]
+		if !ok || auth.Auth == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to decode the pull secret of %s", registry)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	return "", "", nil
+}
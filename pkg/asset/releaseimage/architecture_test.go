@@ -0,0 +1,86 @@
+package releaseimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMultiArch(t *testing.T) {
+	// The pull secret authenticates as user:pass.
+	const pullSecretFormat = `{"auths":{"%s":{"auth":"dXNlcjpwYXNz"}}}`
+
+	cases := []struct {
+		name        string
+		contentType string
+		pullSecret  bool
+		expected    bool
+		errorMsg    string
+	}{
+		{
+			name:        "manifest list",
+			contentType: "application/vnd.docker.distribution.manifest.list.v2+json",
+			pullSecret:  true,
+			expected:    true,
+		},
+		{
+			name:        "oci index",
+			contentType: "application/vnd.oci.image.index.v1+json",
+			pullSecret:  true,
+			expected:    true,
+		},
+		{
+			name:        "single architecture manifest",
+			contentType: "application/vnd.docker.distribution.manifest.v2+json",
+			pullSecret:  true,
+			expected:    false,
+		},
+		{
+			name:     "no credentials",
+			errorMsg: `^failed to authenticate with 127\.0\.0\.1:[0-9]+: token request failed: 401 Unauthorized$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			registry := strings.TrimPrefix(server.URL, "http://")
+
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "user" || password != "pass" || r.URL.Query().Get("scope") != "repository:ocp/release:pull" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"token":"secret-token"}`)
+			})
+			mux.HandleFunc("/v2/ocp/release/manifests/4.13.0-multi", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer secret-token" {
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", tc.contentType)
+			})
+
+			pullSecret := ""
+			if tc.pullSecret {
+				pullSecret = fmt.Sprintf(pullSecretFormat, registry)
+			}
+			multi, err := isMultiArch(context.Background(), server.Client(), "http", registry+"/ocp/release:4.13.0-multi", pullSecret)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, multi)
+		})
+	}
+}
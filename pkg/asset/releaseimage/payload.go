@@ -0,0 +1,285 @@
+package releaseimage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// imageReferencesFile is the file of the release payload image listing
+	// the images of the release, as an ImageStream.
+	imageReferencesFile = "release-manifests/image-references"
+
+	// maxManifestSize is the maximum size of the manifests read from the
+	// registry.
+	maxManifestSize = 4 << 20
+)
+
+// Payload is a release payload and the images it references.
+type Payload struct {
+	// PullSpec is the pull spec of the payload image, by digest.
+	PullSpec string `json:"pullSpec"`
+	// Version is the version of the release.
+	Version string `json:"version,omitempty"`
+	// Images are the images referenced by the payload, sorted by name.
+	Images []PayloadImage `json:"images"`
+}
+
+// PayloadImage is an image referenced by a release payload.
+type PayloadImage struct {
+	// Name is the name of the image in the payload, e.g. machine-config-operator.
+	Name string `json:"name"`
+	// PullSpec is the pull spec of the image, by digest.
+	PullSpec string `json:"pullSpec"`
+}
+
+// imageStream is the part of the image-references ImageStream of a payload
+// which lists its images.
+type imageStream struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Tags []struct {
+			Name string `json:"name"`
+			From struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+			} `json:"from"`
+		} `json:"tags"`
+	} `json:"spec"`
+}
+
+// manifest is the part of an image manifest, or of a manifest list, which
+// references its layers, or its images.
+type manifest struct {
+	Layers []struct {
+		Digest string `json:"digest"`
+	} `json:"layers"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// PayloadImages fetches the release payload from the mirrors of its
+// repository in the sources, in order, or else from its registry, and returns
+// the images it references. The image of the architecture is read when the
+// payload is multi-arch. The pull secret authenticates with the registries,
+// whose certificates may be signed by the authorities of the trust bundle,
+// e.g. the additionalTrustBundle of the install config.
+func PayloadImages(ctx context.Context, pullSpec, pullSecret, architecture string, sources []types.ImageContentSource, trustBundle string) (*Payload, error) {
+	client, err := newTrustingClient(trustBundle)
+	if err != nil {
+		return nil, err
+	}
+	return mirroredPayloadImages(ctx, client, "https", pullSpec, pullSecret, architecture, sources)
+}
+
+// MirrorPullSpecs returns the pull specs of the image in the mirrors of the
+// sources whose repository contains it. As with the ImageContentSourcePolicy
+// of the cluster, only the pull specs by digest are mirrored.
+func MirrorPullSpecs(pullSpec string, sources []types.ImageContentSource) []string {
+	repository, digest, ok := strings.Cut(pullSpec, "@")
+	if !ok {
+		return nil
+	}
+	var mirrors []string
+	for _, source := range sources {
+		if repository != source.Source && !strings.HasPrefix(repository, source.Source+"/") {
+			continue
+		}
+		for _, mirror := range source.Mirrors {
+			mirrors = append(mirrors, fmt.Sprintf("%s%s@%s", mirror, strings.TrimPrefix(repository, source.Source), digest))
+		}
+	}
+	return mirrors
+}
+
+// newTrustingClient returns a client which trusts the authorities of the PEM
+// bundle in addition to those of the system.
+func newTrustingClient(trustBundle string) (*http.Client, error) {
+	if trustBundle == "" {
+		return http.DefaultClient, nil
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM([]byte(trustBundle)) {
+		return nil, errors.New("the trust bundle has no PEM certificate")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: transport}, nil
+}
+
+// mirroredPayloadImages returns the images of the payload, fetched from the
+// first of its mirrors, or of its registry, which serves it. The pull spec of
+// the payload is the one of its repository, whatever registry served it.
+func mirroredPayloadImages(ctx context.Context, client *http.Client, scheme, pullSpec, pullSecret, architecture string, sources []types.ImageContentSource) (*Payload, error) {
+	named, _, err := parseReference(pullSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, mirror := range MirrorPullSpecs(pullSpec, sources) {
+		payload, err := payloadImages(ctx, client, scheme, mirror, pullSecret, architecture)
+		if err != nil {
+			logrus.Debugf("Unable to fetch the release payload from mirror %s: %v", mirror, err)
+			continue
+		}
+		_, digest, _ := strings.Cut(payload.PullSpec, "@")
+		payload.PullSpec = fmt.Sprintf("%s@%s", named.Name(), digest)
+		return payload, nil
+	}
+	return payloadImages(ctx, client, scheme, pullSpec, pullSecret, architecture)
+}
+
+func payloadImages(ctx context.Context, client *http.Client, scheme, pullSpec, pullSecret, architecture string) (*Payload, error) {
+	named, reference, err := parseReference(pullSpec)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := newRegistryClient(client, scheme, named, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	data, contentType, digest, err := registry.manifest(ctx, reference)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the manifest of %s", pullSpec)
+	}
+	payload := &Payload{PullSpec: fmt.Sprintf("%s@%s", named.Name(), digest)}
+
+	m := manifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the manifest of %s", pullSpec)
+	}
+	if isManifestList(contentType) {
+		image := ""
+		for _, entry := range m.Manifests {
+			if entry.Platform.OS == "linux" && entry.Platform.Architecture == architecture {
+				image = entry.Digest
+				break
+			}
+		}
+		if image == "" {
+			return nil, errors.Errorf("the release payload %s has no image for architecture %s", pullSpec, architecture)
+		}
+		if data, _, _, err = registry.manifest(ctx, image); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch the %s manifest of %s", architecture, pullSpec)
+		}
+		m = manifest{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the %s manifest of %s", architecture, pullSpec)
+		}
+	}
+
+	// The image references are added by the last layers of the payload, so
+	// the layers are searched from the top.
+	for i := len(m.Layers) - 1; i >= 0; i-- {
+		references, err := registry.findFile(ctx, m.Layers[i].Digest, imageReferencesFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %s of %s", m.Layers[i].Digest, pullSpec)
+		}
+		if references == nil {
+			continue
+		}
+		stream := imageStream{}
+		if err := json.Unmarshal(references, &stream); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the image references of %s", pullSpec)
+		}
+		payload.Version = stream.Metadata.Name
+		for _, tag := range stream.Spec.Tags {
+			if tag.From.Kind != "DockerImage" || tag.From.Name == "" {
+				continue
+			}
+			payload.Images = append(payload.Images, PayloadImage{Name: tag.Name, PullSpec: tag.From.Name})
+		}
+		sort.Slice(payload.Images, func(i, j int) bool { return payload.Images[i].Name < payload.Images[j].Name })
+		return payload, nil
+	}
+	return nil, errors.Errorf("the release payload %s has no %s file", pullSpec, imageReferencesFile)
+}
+
+// manifest returns the manifest of the reference, its content type and its
+// digest.
+func (c *registryClient) manifest(ctx context.Context, reference string) ([]byte, string, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, "manifests/"+reference, allManifestMediaTypes())
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", errors.New(resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+	return data, resp.Header.Get("Content-Type"), digest, nil
+}
+
+// findFile returns the content of the file in the layer, or nil if the layer
+// does not contain it.
+func (c *registryClient) findFile(ctx context.Context, digest, filename string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+
+	layer := bufio.NewReader(resp.Body)
+	var content io.Reader = layer
+	if magic, err := layer.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(layer)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		content = gz
+	}
+
+	tr := tar.NewReader(content)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || strings.TrimPrefix(path.Clean("/"+header.Name), "/") != filename {
+			continue
+		}
+		return io.ReadAll(tr)
+	}
+}
@@ -0,0 +1,249 @@
+package releaseimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const imageReferences = `{
+  "kind": "ImageStream",
+  "apiVersion": "image.openshift.io/v1",
+  "metadata": {"name": "4.13.0"},
+  "spec": {
+    "tags": [
+      {"name": "machine-config-operator", "from": {"kind": "DockerImage", "name": "quay.io/ocp/release@sha256:2222"}},
+      {"name": "cluster-version-operator", "from": {"kind": "DockerImage", "name": "quay.io/ocp/release@sha256:1111"}}
+    ]
+  }
+}`
+
+// layer returns a gzipped tar layer with the files.
+func layer(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPayloadImages(t *testing.T) {
+	cases := []struct {
+		name         string
+		multiArch    bool
+		architecture string
+		topLayer     map[string]string
+		expected     *Payload
+		errorMsg     string
+	}{
+		{
+			name:         "single architecture",
+			architecture: "amd64",
+			topLayer:     map[string]string{"./release-manifests/image-references": imageReferences},
+			expected: &Payload{
+				PullSpec: "REGISTRY/ocp/release@sha256:payload",
+				Version:  "4.13.0",
+				Images: []PayloadImage{
+					{Name: "cluster-version-operator", PullSpec: "quay.io/ocp/release@sha256:1111"},
+					{Name: "machine-config-operator", PullSpec: "quay.io/ocp/release@sha256:2222"},
+				},
+			},
+		},
+		{
+			name:         "multi-arch",
+			multiArch:    true,
+			architecture: "arm64",
+			topLayer:     map[string]string{"release-manifests/image-references": imageReferences},
+			expected: &Payload{
+				PullSpec: "REGISTRY/ocp/release@sha256:payload",
+				Version:  "4.13.0",
+				Images: []PayloadImage{
+					{Name: "cluster-version-operator", PullSpec: "quay.io/ocp/release@sha256:1111"},
+					{Name: "machine-config-operator", PullSpec: "quay.io/ocp/release@sha256:2222"},
+				},
+			},
+		},
+		{
+			name:         "multi-arch without the architecture",
+			multiArch:    true,
+			architecture: "s390x",
+			errorMsg:     `^the release payload 127\.0\.0\.1:[0-9]+/ocp/release:4\.13\.0 has no image for architecture s390x$`,
+		},
+		{
+			name:         "no image references",
+			architecture: "amd64",
+			topLayer:     map[string]string{"release-manifests/0000_00_cluster-version-operator_00_namespace.yaml": "kind: Namespace"},
+			errorMsg:     `^the release payload 127\.0\.0\.1:[0-9]+/ocp/release:4\.13\.0 has no release-manifests/image-references file$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			base := layer(t, map[string]string{"etc/os-release": "rhel"})
+			top := layer(t, tc.topLayer)
+			blobs := map[string][]byte{}
+			for _, data := range [][]byte{base, top} {
+				blobs[fmt.Sprintf("sha256:%x", sha256.Sum256(data))] = data
+			}
+			image := fmt.Sprintf(`{"schemaVersion":2,"layers":[{"digest":"sha256:%x"},{"digest":"sha256:%x"}]}`, sha256.Sum256(base), sha256.Sum256(top))
+
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			registry := strings.TrimPrefix(server.URL, "http://")
+
+			mux.HandleFunc("/v2/ocp/release/manifests/", func(w http.ResponseWriter, r *http.Request) {
+				switch reference := strings.TrimPrefix(r.URL.Path, "/v2/ocp/release/manifests/"); {
+				case reference == "4.13.0" && tc.multiArch:
+					w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+					w.Header().Set("Docker-Content-Digest", "sha256:payload")
+					fmt.Fprint(w, `{"schemaVersion":2,"manifests":[{"digest":"sha256:amd64","platform":{"architecture":"amd64","os":"linux"}},{"digest":"sha256:arm64","platform":{"architecture":"arm64","os":"linux"}}]}`)
+				case reference == "4.13.0", reference == "sha256:arm64":
+					w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+					w.Header().Set("Docker-Content-Digest", "sha256:payload")
+					fmt.Fprint(w, image)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			mux.HandleFunc("/v2/ocp/release/blobs/", func(w http.ResponseWriter, r *http.Request) {
+				data, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/ocp/release/blobs/")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write(data) //nolint:errcheck
+			})
+
+			payload, err := payloadImages(context.Background(), server.Client(), "http", registry+"/ocp/release:4.13.0", "", tc.architecture)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			tc.expected.PullSpec = strings.Replace(tc.expected.PullSpec, "REGISTRY", registry, 1)
+			assert.Equal(t, tc.expected, payload)
+		})
+	}
+}
+
+func TestMirroredPayloadImages(t *testing.T) {
+	image := layer(t, map[string]string{"release-manifests/image-references": imageReferences})
+	layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(image))
+	payloadDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("payload")))
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mirror := strings.TrimPrefix(server.URL, "http://")
+
+	mux.HandleFunc("/v2/mirror/release/manifests/"+payloadDigest, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		w.Header().Set("Docker-Content-Digest", payloadDigest)
+		fmt.Fprintf(w, `{"schemaVersion":2,"layers":[{"digest":"%s"}]}`, layerDigest)
+	})
+	mux.HandleFunc("/v2/mirror/release/blobs/"+layerDigest, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(image) //nolint:errcheck
+	})
+
+	cases := []struct {
+		name     string
+		pullSpec string
+		sources  []types.ImageContentSource
+		errorMsg string
+	}{
+		{
+			name:     "mirror",
+			pullSpec: "quay.invalid/ocp/release@" + payloadDigest,
+			sources:  []types.ImageContentSource{{Source: "quay.invalid/ocp", Mirrors: []string{mirror + "/mirror"}}},
+		},
+		{
+			name:     "unreachable mirror before reachable mirror",
+			pullSpec: "quay.invalid/ocp/release@" + payloadDigest,
+			sources: []types.ImageContentSource{
+				{Source: "quay.invalid/ocp/release", Mirrors: []string{mirror + "/missing/release"}},
+				{Source: "quay.invalid/ocp", Mirrors: []string{mirror + "/mirror"}},
+			},
+		},
+		{
+			name:     "no mirror of the repository",
+			pullSpec: "quay.invalid/ocp/release@" + payloadDigest,
+			sources:  []types.ImageContentSource{{Source: "quay.invalid/other", Mirrors: []string{mirror + "/mirror"}}},
+			errorMsg: `failed to fetch the manifest of quay\.invalid/ocp/release@sha256:`,
+		},
+		{
+			name:     "pull spec by tag",
+			pullSpec: "quay.invalid/ocp/release:4.13.0",
+			sources:  []types.ImageContentSource{{Source: "quay.invalid/ocp", Mirrors: []string{mirror + "/mirror"}}},
+			errorMsg: `failed to fetch the manifest of quay\.invalid/ocp/release:4\.13\.0`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := mirroredPayloadImages(context.Background(), server.Client(), "http", tc.pullSpec, "", "amd64", tc.sources)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "quay.invalid/ocp/release@"+payloadDigest, payload.PullSpec)
+			assert.Equal(t, "4.13.0", payload.Version)
+			assert.Len(t, payload.Images, 2)
+		})
+	}
+}
+
+func TestMirrorPullSpecs(t *testing.T) {
+	sources := []types.ImageContentSource{
+		{Source: "quay.io/ocp", Mirrors: []string{"mirror.example.com/ocp", "backup.example.com/ocp"}},
+		{Source: "quay.io/ocp/release", Mirrors: []string{"release.example.com/release"}},
+	}
+	cases := []struct {
+		name     string
+		pullSpec string
+		expected []string
+	}{
+		{
+			name:     "repository of the source",
+			pullSpec: "quay.io/ocp/release@sha256:1111",
+			expected: []string{"mirror.example.com/ocp/release@sha256:1111", "backup.example.com/ocp/release@sha256:1111", "release.example.com/release@sha256:1111"},
+		},
+		{
+			name:     "repository prefix is not a path",
+			pullSpec: "quay.io/ocp-dev/release@sha256:1111",
+		},
+		{
+			name:     "pull spec by tag",
+			pullSpec: "quay.io/ocp/release:4.13.0",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MirrorPullSpecs(tc.pullSpec, sources))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package releaseimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dockerref "github.com/containers/image/docker/reference"
+	"github.com/pkg/errors"
+)
+
+// registryClient fetches the manifests and blobs of a repository from its
+// registry, authenticating with the credentials of the pull secret when the
+// registry challenges the requests.
+type registryClient struct {
+	client     *http.Client
+	scheme     string
+	registry   string
+	repository string
+	username   string
+	password   string
+
+	// authorization is the Authorization header of the requests, once the
+	// registry challenged one.
+	authorization string
+}
+
+// parseReference returns the repository of the pull spec, and its digest,
+// or its tag, defaulting to latest.
+func parseReference(pullSpec string) (dockerref.Named, string, error) {
+	named, err := dockerref.ParseNormalizedNamed(pullSpec)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to parse the release image pull spec")
+	}
+	named = dockerref.TagNameOnly(named)
+	reference := ""
+	switch r := named.(type) {
+	case dockerref.Canonical:
+		reference = r.Digest().String()
+	case dockerref.Tagged:
+		reference = r.Tag()
+	}
+	return named, reference, nil
+}
+
+func newRegistryClient(client *http.Client, scheme string, named dockerref.Named, pullSecret string) (*registryClient, error) {
+	registry := dockerref.Domain(named)
+	username, password, err := registryCredentials(pullSecret, registry)
+	if err != nil {
+		return nil, err
+	}
+	if registry == "docker.io" {
+		registry = "registry-1.docker.io"
+	}
+	return &registryClient{
+		client:     client,
+		scheme:     scheme,
+		registry:   registry,
+		repository: dockerref.Path(named),
+		username:   username,
+		password:   password,
+	}, nil
+}
+
+// do sends a request for the path of the repository, e.g. manifests/latest,
+// and answers the authentication challenge of the registry. The caller closes
+// the body of the response.
+func (c *registryClient) do(ctx context.Context, method, path string, accept []string) (*http.Response, error) {
+	resp, err := c.send(ctx, method, path, accept)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.authorization != "" {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	c.authorization, err = authorize(ctx, c.client, resp.Header.Get("WWW-Authenticate"), c.repository, c.username, c.password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to authenticate with %s", c.registry)
+	}
+	return c.send(ctx, method, path, accept)
+}
+
+func (c *registryClient) send(ctx context.Context, method, path string, accept []string) (*http.Response, error) {
+	url := fmt.Sprintf("%s://%s/v2/%s/%s", c.scheme, c.registry, c.repository, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %s", url)
+	}
+	return resp, nil
+}
+
+// allManifestMediaTypes are the media types of the manifests of images and of
+// manifest lists.
+func allManifestMediaTypes() []string {
+	return append(append([]string{}, manifestListMediaTypes...), manifestMediaTypes...)
+}
@@ -0,0 +1,76 @@
+package releaseimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultSignatureStore is the store from which the cluster-version operator
+// fetches the signatures of the OpenShift release payloads.
+const DefaultSignatureStore = "https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release"
+
+// PayloadSignature is the signature of a release payload in a signature store.
+type PayloadSignature struct {
+	// Digest is the digest of the payload, which is signed.
+	Digest string
+	// URL is the location of the first signature of the digest in the store.
+	URL string
+	// Signed is true when the store holds a signature of the digest.
+	Signed bool
+}
+
+// FindPayloadSignature resolves the digest of the release payload from its
+// registry, and checks that the signature store holds a signature of it. The
+// signature itself is verified by the cluster-version operator.
+func FindPayloadSignature(ctx context.Context, pullSpec, pullSecret, store string) (*PayloadSignature, error) {
+	return findPayloadSignature(ctx, http.DefaultClient, "https", pullSpec, pullSecret, store)
+}
+
+func findPayloadSignature(ctx context.Context, client *http.Client, scheme, pullSpec, pullSecret, store string) (*PayloadSignature, error) {
+	named, reference, err := parseReference(pullSpec)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := newRegistryClient(client, scheme, named, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	// The digest of a multi-arch payload is the digest of its manifest list.
+	_, _, digest, err := registry.manifest(ctx, reference)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the manifest of %s", pullSpec)
+	}
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil, errors.Errorf("invalid digest %q of %s", digest, pullSpec)
+	}
+
+	signature := &PayloadSignature{
+		Digest: digest,
+		URL:    fmt.Sprintf("%s/%s=%s/signature-1", strings.TrimSuffix(store, "/"), algorithm, hex),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, signature.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %s", signature.URL)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		signature.Signed = true
+	case http.StatusNotFound, http.StatusForbidden:
+		// The object stores behind the signature stores answer with
+		// Forbidden for the missing objects.
+	default:
+		return nil, errors.Errorf("failed to fetch %s: %s", signature.URL, resp.Status)
+	}
+	return signature, nil
+}
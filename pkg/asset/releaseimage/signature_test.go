@@ -0,0 +1,93 @@
+package releaseimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPayloadSignature(t *testing.T) {
+	cases := []struct {
+		name     string
+		tag      string
+		status   int
+		expected *PayloadSignature
+		errorMsg string
+	}{
+		{
+			name:   "signed",
+			tag:    "4.13.0",
+			status: http.StatusOK,
+			expected: &PayloadSignature{
+				Digest: "sha256:1234",
+				URL:    "STORE/sha256=1234/signature-1",
+				Signed: true,
+			},
+		},
+		{
+			name:   "not signed",
+			tag:    "4.13.0",
+			status: http.StatusNotFound,
+			expected: &PayloadSignature{
+				Digest: "sha256:1234",
+				URL:    "STORE/sha256=1234/signature-1",
+			},
+		},
+		{
+			name:   "forbidden by the object store",
+			tag:    "4.13.0",
+			status: http.StatusForbidden,
+			expected: &PayloadSignature{
+				Digest: "sha256:1234",
+				URL:    "STORE/sha256=1234/signature-1",
+			},
+		},
+		{
+			name:     "store unavailable",
+			tag:      "4.13.0",
+			status:   http.StatusServiceUnavailable,
+			errorMsg: `^failed to fetch http://127\.0\.0\.1:[0-9]+/signatures/sha256=1234/signature-1: 503 Service Unavailable$`,
+		},
+		{
+			name:     "payload not found",
+			tag:      "4.12.0",
+			errorMsg: `^failed to fetch the manifest of 127\.0\.0\.1:[0-9]+/ocp/release:4\.12\.0: 404 Not Found$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			registry := strings.TrimPrefix(server.URL, "http://")
+			store := server.URL + "/signatures/"
+
+			mux.HandleFunc("/v2/ocp/release/manifests/4.13.0", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/vnd.docker.distribution.manifest.list.v2+json")
+				w.Header().Set("Docker-Content-Digest", "sha256:1234")
+				w.Write([]byte(`{"schemaVersion":2}`)) //nolint:errcheck
+			})
+			mux.HandleFunc("/signatures/sha256=1234/signature-1", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				w.WriteHeader(tc.status)
+			})
+
+			signature, err := findPayloadSignature(context.Background(), server.Client(), "http", registry+"/ocp/release:"+tc.tag, "", store)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				return
+			}
+			assert.NoError(t, err)
+			tc.expected.URL = strings.Replace(tc.expected.URL, "STORE", server.URL+"/signatures", 1)
+			assert.Equal(t, tc.expected, signature)
+		})
+	}
+}
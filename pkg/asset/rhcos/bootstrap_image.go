@@ -48,7 +48,7 @@ func (i *BootstrapImage) Generate(p asset.Parents) error {
 	switch config.Platform.Name() {
 	case baremetal.Name:
 		archName := arch.RpmArch(string(config.ControlPlane.Architecture))
-		st, err := rhcos.FetchCoreOSBuild(ctx)
+		st, err := rhcos.FetchCoreOSStream(ctx, config.CoreOSStream)
 		if err != nil {
 			return err
 		}
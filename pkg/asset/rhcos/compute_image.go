@@ -0,0 +1,55 @@
+package rhcos
+
+import (
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// ComputeImages are the locations of the RHCOS images of the compute pools
+// whose architecture differs from the control plane, by architecture. The
+// compute pools with the architecture of the control plane use the Image.
+type ComputeImages map[types.Architecture]string
+
+var _ asset.Asset = (*ComputeImages)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (i *ComputeImages) Name() string {
+	return "Compute Images"
+}
+
+// Dependencies returns dependencies used by the asset.
+func (i *ComputeImages) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate the RHCOS image locations.
+func (i *ComputeImages) Generate(p asset.Parents) error {
+	ic := &installconfig.InstallConfig{}
+	p.Get(ic)
+	config := ic.Config
+
+	images := ComputeImages{}
+	for _, pool := range config.Compute {
+		if pool.Architecture == config.ControlPlane.Architecture {
+			continue
+		}
+		if _, ok := images[pool.Architecture]; ok {
+			continue
+		}
+		// A pool with its own AMI does not need the RHCOS AMI.
+		if config.Platform.Name() == aws.Name && pool.Platform.AWS != nil && pool.Platform.AWS.AMIID != "" {
+			continue
+		}
+		osimage, err := osImage(config, pool.Architecture)
+		if err != nil {
+			return err
+		}
+		images[pool.Architecture] = osimage
+	}
+	*i = images
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -59,7 +60,7 @@ func (i *Image) Generate(p asset.Parents) error {
 	ic := &installconfig.InstallConfig{}
 	p.Get(ic)
 	config := ic.Config
-	osimage, err := osImage(config)
+	osimage, err := osImage(config, config.ControlPlane.Architecture)
 	if err != nil {
 		return err
 	}
@@ -67,13 +68,16 @@ func (i *Image) Generate(p asset.Parents) error {
 	return nil
 }
 
-func osImage(config *types.InstallConfig) (string, error) {
+// osImage returns the location of the RHCOS image of the architecture. The
+// image overrides of the platform apply only to the architecture of the
+// control plane.
+func osImage(config *types.InstallConfig, architecture types.Architecture) (string, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
-	archName := arch.RpmArch(string(config.ControlPlane.Architecture))
+	archName := arch.RpmArch(string(architecture))
 
-	st, err := rhcos.FetchCoreOSBuild(ctx)
+	st, err := rhcos.FetchCoreOSStream(ctx, config.CoreOSStream)
 	if err != nil {
 		return "", err
 	}
@@ -83,11 +87,15 @@ func osImage(config *types.InstallConfig) (string, error) {
 	}
 	switch config.Platform.Name() {
 	case aws.Name:
-		if len(config.Platform.AWS.AMIID) > 0 {
+		if len(config.Platform.AWS.AMIID) > 0 && architecture == config.ControlPlane.Architecture {
 			return config.Platform.AWS.AMIID, nil
 		}
 		region := config.Platform.AWS.Region
-		if !rhcos.AMIRegions(config.ControlPlane.Architecture).Has(region) {
+		if !rhcos.StreamAMIRegions(st, architecture).Has(region) {
+			if architecture != config.ControlPlane.Architecture {
+				// The AMI is only copied to the region for the control plane.
+				return "", fmt.Errorf("%s: No AMI found in %s, set the amiID of the %s compute pools", st.FormatPrefix(archName), region, architecture)
+			}
 			const globalResourceRegion = "us-east-1"
 			logrus.Debugf("No AMI found in %s. Using AMI from %s.", region, globalResourceRegion)
 			region = globalResourceRegion
@@ -131,7 +139,15 @@ func osImage(config *types.InstallConfig) (string, error) {
 	case azure.Name:
 		ext := streamArch.RHELCoreOSExtensions
 		if config.Platform.Azure.CloudName == azure.StackCloud {
-			return config.Platform.Azure.ClusterOSImage, nil
+			if oi := config.Platform.Azure.ClusterOSImage; oi != "" {
+				return oi, nil
+			}
+			// The VHD is uploaded to the storage account of the
+			// environment by the installer.
+			if a, ok := streamArch.Artifacts["azurestack"]; ok {
+				return rhcos.FindArtifactURL(a)
+			}
+			return "", fmt.Errorf("%s: No azurestack build found", st.FormatPrefix(archName))
 		}
 		if ext == nil {
 			return "", fmt.Errorf("%s: No azure build found", st.FormatPrefix(archName))
@@ -178,7 +194,7 @@ func osImage(config *types.InstallConfig) (string, error) {
 		}
 
 		return "", fmt.Errorf("%s: No Power VS build found", st.FormatPrefix(archName))
-	case none.Name:
+	case external.Name, none.Name:
 		return "", nil
 	case nutanix.Name:
 		if config.Platform.Nutanix != nil && config.Platform.Nutanix.ClusterOSImage != "" {
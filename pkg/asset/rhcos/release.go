@@ -53,7 +53,7 @@ func release(config *types.InstallConfig) (string, error) {
 
 	archName := arch.RpmArch(string(config.ControlPlane.Architecture))
 
-	st, err := rhcos.FetchCoreOSBuild(ctx)
+	st, err := rhcos.FetchCoreOSStream(ctx, config.CoreOSStream)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/encryption"
+)
+
+const (
+	cacheDirName = ".openshift_install_cache"
+)
+
+// assetCache stores generated assets by the hash of the contents of their
+// parents, so that cacheable assets are not generated again when their
+// parents did not change. It only keeps the latest entry of each asset, so
+// that it does not grow with every change of the install config.
+type assetCache struct {
+	directory string
+}
+
+// cacheEntry is the content of the cache file of an asset.
+type cacheEntry struct {
+	// Key is the hash of the parents the asset was generated from.
+	Key string `json:"key"`
+	// Asset is the generated asset.
+	Asset json.RawMessage `json:"asset"`
+}
+
+// key returns the cache key of the asset generated from the given parents.
+func (c *assetCache) key(a asset.Asset, parents []asset.Asset) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(reflect.TypeOf(a).String()))
+	for _, p := range parents {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to marshal asset %q", p.Name())
+		}
+		hash.Write([]byte{0})
+		hash.Write([]byte(reflect.TypeOf(p).String()))
+		hash.Write([]byte{0})
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// path returns the path of the cache file of the asset.
+func (c *assetCache) path(a asset.Asset) string {
+	t := reflect.TypeOf(a)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := sha256.Sum256([]byte(t.PkgPath() + "." + t.Name()))
+	return filepath.Join(c.directory, cacheDirName, hex.EncodeToString(name[:])+".json")
+}
+
+// get populates the asset from its cache entry if it has the given key, and
+// returns whether it had.
+func (c *assetCache) get(key string, a asset.Asset) (bool, error) {
+	data, err := encryption.ReadFile(c.path(a))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal cached asset %q", a.Name())
+	}
+	if entry.Key != key {
+		return false, nil
+	}
+	if err := json.Unmarshal(entry.Asset, a); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal cached asset %q", a.Name())
+	}
+	return true, nil
+}
+
+// put replaces the cache entry of the asset with the asset generated from
+// the parents with the given key.
+func (c *assetCache) put(key string, a asset.Asset) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(&cacheEntry{Key: key, Asset: data})
+	if err != nil {
+		return err
+	}
+	path := c.path(a)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return encryption.WriteFile(path, data, 0o600)
+}
+
+// clear removes all the cache entries.
+func (c *assetCache) clear() error {
+	return os.RemoveAll(filepath.Join(c.directory, cacheDirName))
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// parentContent is the content which testCacheParentAsset is generated with.
+var parentContent string
+
+type testCacheParentAsset struct {
+	Content string
+}
+
+func (a *testCacheParentAsset) Name() string {
+	return "parent"
+}
+
+func (a *testCacheParentAsset) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+func (a *testCacheParentAsset) Generate(asset.Parents) error {
+	a.Content = parentContent
+	return generateTestStoreAsset(a)
+}
+
+type testCachedAsset struct {
+	Value string
+}
+
+func (a *testCachedAsset) Name() string {
+	return "cached"
+}
+
+func (a *testCachedAsset) Dependencies() []asset.Asset {
+	return []asset.Asset{&testCacheParentAsset{}}
+}
+
+func (a *testCachedAsset) Generate(parents asset.Parents) error {
+	parent := &testCacheParentAsset{}
+	parents.Get(parent)
+	a.Value = "generated from " + parent.Content
+	return generateTestStoreAsset(a)
+}
+
+func (a *testCachedAsset) Cacheable() {}
+
+func TestStoreFetchCached(t *testing.T) {
+	cases := []struct {
+		name                  string
+		parentContent         string
+		opts                  []Option
+		expectedGenerationLog []string
+	}{
+		{
+			name:                  "first generation",
+			parentContent:         "x",
+			expectedGenerationLog: []string{"parent", "cached"},
+		},
+		{
+			name:                  "unchanged parent",
+			parentContent:         "x",
+			expectedGenerationLog: []string{"parent"},
+		},
+		{
+			name:                  "changed parent",
+			parentContent:         "y",
+			expectedGenerationLog: []string{"parent", "cached"},
+		},
+		{
+			name:                  "earlier parent",
+			parentContent:         "x",
+			expectedGenerationLog: []string{"parent", "cached"},
+		},
+		{
+			name:                  "unchanged earlier parent",
+			parentContent:         "x",
+			expectedGenerationLog: []string{"parent"},
+		},
+		{
+			name:                  "cache disabled",
+			parentContent:         "x",
+			opts:                  []Option{DisableCache()},
+			expectedGenerationLog: []string{"parent", "cached"},
+		},
+	}
+
+	dir := t.TempDir()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			parentContent = tc.parentContent
+			if err := os.Remove(filepath.Join(dir, stateFileName)); err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+
+			store, err := newStore(dir, tc.opts...)
+			assert.NoError(t, err, "failed to create asset store")
+
+			cached := &testCachedAsset{}
+			err = store.fetch(cached, "")
+			assert.NoError(t, err, "failed to fetch asset")
+			assert.Equal(t, "generated from "+tc.parentContent, cached.Value)
+			assert.Equal(t, tc.expectedGenerationLog, generationLog)
+		})
+	}
+}
+
+func TestStoreDestroyStateClearsCache(t *testing.T) {
+	clearAssetBehaviors()
+	parentContent = "x"
+	dir := t.TempDir()
+
+	store, err := newStore(dir)
+	assert.NoError(t, err, "failed to create asset store")
+	assert.NoError(t, store.Fetch(&testCachedAsset{}), "failed to fetch asset")
+	assert.DirExists(t, filepath.Join(dir, cacheDirName))
+
+	assert.NoError(t, store.DestroyState(), "failed to destroy state")
+	assert.NoDirExists(t, filepath.Join(dir, cacheDirName))
+}
+
+func TestStoreCacheEntries(t *testing.T) {
+	clearAssetBehaviors()
+	dir := t.TempDir()
+
+	for _, content := range []string{"x", "y", "z"} {
+		parentContent = content
+		if err := os.Remove(filepath.Join(dir, stateFileName)); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		store, err := newStore(dir)
+		assert.NoError(t, err, "failed to create asset store")
+		assert.NoError(t, store.Fetch(&testCachedAsset{}), "failed to fetch asset")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, cacheDirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, entries, 1, "only the latest entry of the asset should be kept") {
+		info, err := entries[0].Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
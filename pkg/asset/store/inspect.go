@@ -0,0 +1,176 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// AssetStatus is the status of an asset in a directory, as the next fetch of
+// the asset would find it.
+type AssetStatus struct {
+	// Type is the type of the asset, e.g. installconfig.InstallConfig.
+	Type string
+	// Name is the human-friendly name of the asset.
+	Name string
+	// Dependencies are the types of the direct dependencies of the asset.
+	Dependencies []string
+	// OnDisk is true if the asset is present in the directory.
+	OnDisk bool
+	// Dirty is true if the asset in the directory differs from the state
+	// file. The asset is used as it is, and the assets depending on it are
+	// regenerated.
+	Dirty bool
+	// ParentsDirty is true if any of the dependencies of the asset, direct or
+	// not, is dirty.
+	ParentsDirty bool
+	// Regenerate is true if the asset is generated by the next fetch, because
+	// it was never generated or because its dependencies are dirty.
+	Regenerate bool
+}
+
+// fileLoader is an asset which is loaded from its files without being
+// validated, e.g. the install config, whose Load also validates it against the
+// platform.
+type fileLoader interface {
+	LoadFromFile(asset.FileFetcher) (bool, error)
+}
+
+// Inspect loads the assets and all of their dependencies from the directory,
+// without generating any of them, and returns their statuses. The status of
+// an asset comes after the statuses of its dependencies. The assets loaded
+// from their files alone, e.g. the install config, are not validated, and are
+// dirty if their files differ from the state file.
+func Inspect(dir string, assets ...asset.Asset) ([]AssetStatus, error) {
+	s, err := newStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []AssetStatus
+	inspected := map[reflect.Type]*AssetStatus{}
+	var inspect func(a asset.Asset) (*AssetStatus, error)
+	inspect = func(a asset.Asset) (*AssetStatus, error) {
+		if status, ok := inspected[reflect.TypeOf(a)]; ok {
+			return status, nil
+		}
+
+		dependencies := a.Dependencies()
+		status := &AssetStatus{
+			Type:         assetType(a),
+			Name:         a.Name(),
+			Dependencies: make([]string, 0, len(dependencies)),
+		}
+		for _, d := range dependencies {
+			dependency, err := inspect(d)
+			if err != nil {
+				return nil, err
+			}
+			if dependency.Dirty || dependency.ParentsDirty {
+				status.ParentsDirty = true
+			}
+			status.Dependencies = append(status.Dependencies, assetType(d))
+		}
+
+		inState := s.isAssetInState(a)
+		dirty := false
+		if _, isWritable := a.(asset.WritableAsset); isWritable {
+			status.OnDisk, dirty, err = s.inspectOnDisk(a, inState)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load asset %q", a.Name())
+			}
+		}
+		// The assets whose dependencies are dirty are regenerated, whatever
+		// is on disk.
+		status.Dirty = dirty && !status.ParentsDirty
+		status.Regenerate = status.ParentsDirty || (!status.Dirty && !inState)
+		statuses = append(statuses, *status)
+		inspected[reflect.TypeOf(a)] = status
+		return status, nil
+	}
+	for _, a := range assets {
+		if _, err := inspect(a); err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+// inspectOnDisk returns whether the writable asset is present in the
+// directory, and whether it differs from the state file.
+func (s *storeImpl) inspectOnDisk(a asset.Asset, inState bool) (onDisk bool, dirty bool, err error) {
+	var stateFileAsset asset.Asset
+	if inState {
+		stateFileAsset = reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.Asset)
+		if err := s.loadAssetFromState(stateFileAsset); err != nil {
+			return false, false, errors.Wrap(err, "failed to load the asset from the state file")
+		}
+	}
+
+	onDiskAsset := reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.WritableAsset)
+	if loader, ok := onDiskAsset.(fileLoader); ok {
+		if inState {
+			return s.filesDiffer(stateFileAsset.(asset.WritableAsset).Files())
+		}
+		onDisk, err = loader.LoadFromFile(s.fileFetcher)
+		return onDisk, onDisk, err
+	}
+
+	onDisk, err = onDiskAsset.Load(s.fileFetcher)
+	if err != nil || !onDisk {
+		return false, false, err
+	}
+	return true, !inState || !reflect.DeepEqual(onDiskAsset, stateFileAsset), nil
+}
+
+// filesDiffer returns whether any of the files is present in the directory,
+// and whether any of them differs from its data in the state file.
+func (s *storeImpl) filesDiffer(files []*asset.File) (onDisk bool, differ bool, err error) {
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(s.directory, f.Filename))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, false, err
+		}
+		onDisk = true
+		if !bytes.Equal(data, f.Data) {
+			differ = true
+		}
+	}
+	return onDisk, differ, nil
+}
+
+// LoadFromState loads the asset as it was recorded in the state file of the
+// directory by the last fetch, ignoring any copy of the asset in the
+// directory. It returns false if the asset was never generated.
+func LoadFromState(dir string, a asset.Asset) (bool, error) {
+	s, err := newStore(dir)
+	if err != nil {
+		return false, err
+	}
+	if !s.isAssetInState(a) {
+		return false, nil
+	}
+	if err := s.loadAssetFromState(a); err != nil {
+		return false, errors.Wrapf(err, "failed to load asset %q from state file", a.Name())
+	}
+	return true, nil
+}
+
+// NewFileFetcher returns a fetcher of the files of the directory, with which
+// an asset is loaded without the store, e.g. without its dependencies.
+func NewFileFetcher(dir string) asset.FileFetcher {
+	return &fileFetcher{directory: dir}
+}
+
+// assetType returns the name of the type of the asset.
+func assetType(a asset.Asset) string {
+	return reflect.TypeOf(a).Elem().String()
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestInspect(t *testing.T) {
+	cases := []struct {
+		name             string
+		onDiskAssets     []asset.Asset
+		stateFile        string
+		expectedStatuses []AssetStatus
+	}{
+		{
+			name: "nothing generated",
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreAssetB", Name: "b", Dependencies: []string{}, Regenerate: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreAssetB"}, Regenerate: true},
+			},
+		},
+		{
+			name:      "generated",
+			stateFile: `{"*store.testStoreAssetA": {}, "*store.testStoreAssetB": {}}`,
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreAssetB", Name: "b", Dependencies: []string{}},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreAssetB"}},
+			},
+		},
+		{
+			name:         "dependency edited on disk",
+			onDiskAssets: []asset.Asset{&testStoreAssetB{}},
+			stateFile:    `{"*store.testStoreAssetA": {}}`,
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreAssetB", Name: "b", Dependencies: []string{}, OnDisk: true, Dirty: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreAssetB"}, ParentsDirty: true, Regenerate: true},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			dependencies[reflect.TypeOf(&testStoreAssetA{})] = []asset.Asset{&testStoreAssetB{}}
+			for _, a := range tc.onDiskAssets {
+				onDiskAssets[reflect.TypeOf(a)] = true
+			}
+			dir := t.TempDir()
+			if tc.stateFile != "" {
+				if err := os.WriteFile(filepath.Join(dir, stateFileName), []byte(tc.stateFile), 0o640); err != nil {
+					t.Fatal(err)
+				}
+			}
+			statuses, err := Inspect(dir, &testStoreAssetA{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatuses, statuses)
+		})
+	}
+}
+
+// testStoreConfig is an asset loaded from its file alone by Inspect, whose
+// Load fails as a validation against the platform would.
+type testStoreConfig struct {
+	Data string
+}
+
+func (a *testStoreConfig) Name() string {
+	return "config"
+}
+
+func (a *testStoreConfig) Dependencies() []asset.Asset {
+	return nil
+}
+
+func (a *testStoreConfig) Generate(asset.Parents) error {
+	return nil
+}
+
+func (a *testStoreConfig) Files() []*asset.File {
+	return []*asset.File{{Filename: "config", Data: []byte(a.Data)}}
+}
+
+func (a *testStoreConfig) Load(asset.FileFetcher) (bool, error) {
+	return false, errors.New("the config must not be validated")
+}
+
+func (a *testStoreConfig) LoadFromFile(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName("config")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	a.Data = string(file.Data)
+	return true, nil
+}
+
+func TestInspectFileLoader(t *testing.T) {
+	cases := []struct {
+		name             string
+		onDisk           string
+		stateFile        string
+		expectedStatuses []AssetStatus
+	}{
+		{
+			name: "nothing generated",
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreConfig", Name: "config", Dependencies: []string{}, Regenerate: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreConfig"}, Regenerate: true},
+			},
+		},
+		{
+			name:   "written by the user",
+			onDisk: "written",
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreConfig", Name: "config", Dependencies: []string{}, OnDisk: true, Dirty: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreConfig"}, ParentsDirty: true, Regenerate: true},
+			},
+		},
+		{
+			name:      "generated",
+			onDisk:    "generated",
+			stateFile: `{"*store.testStoreAssetA": {}, "*store.testStoreConfig": {"Data": "generated"}}`,
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreConfig", Name: "config", Dependencies: []string{}, OnDisk: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreConfig"}},
+			},
+		},
+		{
+			name:      "generated and consumed",
+			stateFile: `{"*store.testStoreAssetA": {}, "*store.testStoreConfig": {"Data": "generated"}}`,
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreConfig", Name: "config", Dependencies: []string{}},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreConfig"}},
+			},
+		},
+		{
+			name:      "edited",
+			onDisk:    "edited",
+			stateFile: `{"*store.testStoreAssetA": {}, "*store.testStoreConfig": {"Data": "generated"}}`,
+			expectedStatuses: []AssetStatus{
+				{Type: "store.testStoreConfig", Name: "config", Dependencies: []string{}, OnDisk: true, Dirty: true},
+				{Type: "store.testStoreAssetA", Name: "a", Dependencies: []string{"store.testStoreConfig"}, ParentsDirty: true, Regenerate: true},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			dependencies[reflect.TypeOf(&testStoreAssetA{})] = []asset.Asset{&testStoreConfig{}}
+			dir := t.TempDir()
+			if tc.onDisk != "" {
+				if err := os.WriteFile(filepath.Join(dir, "config"), []byte(tc.onDisk), 0o640); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.stateFile != "" {
+				if err := os.WriteFile(filepath.Join(dir, stateFileName), []byte(tc.stateFile), 0o640); err != nil {
+					t.Fatal(err)
+				}
+			}
+			statuses, err := Inspect(dir, &testStoreAssetA{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestLoadFromState(t *testing.T) {
+	dir := t.TempDir()
+	found, err := LoadFromState(dir, &testStoreAssetA{})
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	if err := os.WriteFile(filepath.Join(dir, stateFileName), []byte(`{"*store.testStoreAssetA": {}}`), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	found, err = LoadFromState(dir, &testStoreAssetA{})
+	assert.NoError(t, err)
+	assert.True(t, found)
+	found, err = LoadFromState(dir, &testStoreAssetB{})
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// fetchNode is an asset of the dependency graph generated by fetchParallel.
+type fetchNode struct {
+	// asset is the instance of the asset which is generated.
+	asset asset.Asset
+	state *assetState
+	// dependencies are the instances of the parents of the asset, which
+	// are populated once the parents are fetched.
+	dependencies []asset.Asset
+	// pending is the number of parents which are not generated yet.
+	pending int
+	// dependents are the nodes of the assets depending on the asset.
+	dependents []*fetchNode
+	// order is the position of the asset in the depth-first post-order of
+	// the graph, in which fetch generates the assets.
+	order int
+	// parent is the node through which the asset was first reached, whose
+	// names prefix the errors of the asset, as with fetch.
+	parent *fetchNode
+	err    error
+}
+
+// fetchParallel populates the given asset like fetch, but generates the
+// independent assets of the dependency graph concurrently, with a pool of
+// workers. The assets are scheduled in the order in which fetch generates
+// them, and the interactive assets, which prompt the user or mutate shared
+// state, are generated alone, so that the prompts and the errors are the same
+// as with fetch.
+func (s *storeImpl) fetchParallel(a asset.Asset) error {
+	if _, err := s.load(a, ""); err != nil {
+		return err
+	}
+
+	nodes := map[reflect.Type]*fetchNode{}
+	var ordered []*fetchNode
+	var visit func(a asset.Asset, parent *fetchNode, indent string) (*fetchNode, error)
+	visit = func(a asset.Asset, parent *fetchNode, indent string) (*fetchNode, error) {
+		logrus.Debugf("%sFetching %s...", indent, a.Name())
+		if node, ok := nodes[reflect.TypeOf(a)]; ok {
+			return node, nil
+		}
+		state, ok := s.assets[reflect.TypeOf(a)]
+		if !ok {
+			var err error
+			if state, err = s.load(a, indent); err != nil {
+				return nil, err
+			}
+		}
+		if state.source != unfetched {
+			logrus.Debugf("%sReusing previously-fetched %s", indent, a.Name())
+			return nil, nil
+		}
+
+		node := &fetchNode{asset: a, state: state, parent: parent}
+		nodes[reflect.TypeOf(a)] = node
+		node.dependencies = a.Dependencies()
+		for _, d := range node.dependencies {
+			dependency, err := visit(d, node, increaseIndent(indent))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch dependency of %q", a.Name())
+			}
+			if dependency != nil {
+				node.pending++
+				dependency.dependents = append(dependency.dependents, node)
+			}
+		}
+		node.order = len(ordered)
+		ordered = append(ordered, node)
+		return node, nil
+	}
+	root, err := visit(a, nil, "")
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		reflect.ValueOf(a).Elem().Set(reflect.ValueOf(s.assets[reflect.TypeOf(a)].asset).Elem())
+		return nil
+	}
+
+	jobs := make(chan *fetchNode)
+	results := make(chan *fetchNode)
+	defer close(jobs)
+	for i := 0; i < s.parallelism; i++ {
+		go func() {
+			for node := range jobs {
+				node.err = s.generateNode(node)
+				results <- node
+			}
+		}()
+	}
+
+	var ready, failed []*fetchNode
+	for _, node := range ordered {
+		if node.pending == 0 {
+			ready = append(ready, node)
+		}
+	}
+	running, interactiveRunning := 0, false
+	for {
+		// No asset is scheduled after a failure, as fetch stops at the
+		// first failure.
+		for len(failed) == 0 && len(ready) > 0 && running < s.parallelism && !interactiveRunning {
+			if _, interactive := ready[0].asset.(asset.InteractiveAsset); interactive {
+				if running > 0 {
+					break
+				}
+				interactiveRunning = true
+			}
+			jobs <- ready[0]
+			ready = ready[1:]
+			running++
+		}
+		if running == 0 {
+			break
+		}
+
+		node := <-results
+		running--
+		interactiveRunning = false
+		if node.err != nil {
+			failed = append(failed, node)
+			continue
+		}
+		for _, dependent := range node.dependents {
+			if dependent.pending--; dependent.pending == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool { return ready[i].order < ready[j].order })
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	// Report the failure of the asset which fetch would have generated
+	// first.
+	sort.Slice(failed, func(i, j int) bool { return failed[i].order < failed[j].order })
+	err = failed[0].err
+	for parent := failed[0].parent; parent != nil; parent = parent.parent {
+		err = errors.Wrapf(err, "failed to fetch dependency of %q", parent.asset.Name())
+	}
+	return err
+}
+
+// generateNode populates the parents of the asset of the node with copies of
+// the fetched assets, and generates it. The assets generated concurrently may
+// modify their parents, so that they are not shared with the other assets.
+func (s *storeImpl) generateNode(node *fetchNode) error {
+	dependencies := make([]asset.Asset, len(node.dependencies))
+	parents := make(asset.Parents, len(node.dependencies))
+	for i, d := range node.dependencies {
+		fetched := s.assets[reflect.TypeOf(d)].asset
+		copied, err := copyAsset(fetched)
+		if err != nil {
+			return errors.Wrapf(err, "failed to copy asset %q", d.Name())
+		}
+		// The instance of the dependency is populated, unless it is the
+		// fetched asset.
+		if fetched != d {
+			reflect.ValueOf(d).Elem().Set(reflect.ValueOf(copied).Elem())
+			copied = d
+		}
+		dependencies[i] = copied
+		parents.Add(copied)
+	}
+	if err := s.generate(node.asset, dependencies, parents, ""); err != nil {
+		return err
+	}
+	node.state.asset = node.asset
+	node.state.source = generatedSource
+	return nil
+}
+
+// copyAsset returns a deep copy of the asset, encoded as in the state file.
+func copyAsset(a asset.Asset) (asset.Asset, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	copied := reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.Asset)
+	if err := json.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,301 @@
+package store
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// parallelTestAsset is the behavior of the assets of the parallel fetch
+// tests, which records the generations and the concurrency.
+type parallelTestAsset struct {
+	Generated bool
+}
+
+var (
+	parallelDependencies map[reflect.Type][]asset.Asset
+	parallelGenerate     map[reflect.Type]func() error
+	parallelMu           sync.Mutex
+	parallelRunning      int
+	parallelLog          []string
+)
+
+func (a *parallelTestAsset) generate(self asset.Asset) error {
+	parallelMu.Lock()
+	parallelRunning++
+	parallelLog = append(parallelLog, self.Name())
+	_, interactive := self.(asset.InteractiveAsset)
+	if interactive && parallelRunning != 1 {
+		parallelMu.Unlock()
+		return errors.New("interactive asset generated concurrently")
+	}
+	parallelMu.Unlock()
+	defer func() {
+		parallelMu.Lock()
+		parallelRunning--
+		parallelMu.Unlock()
+	}()
+
+	if generate := parallelGenerate[reflect.TypeOf(self)]; generate != nil {
+		if err := generate(); err != nil {
+			return err
+		}
+	}
+	a.Generated = true
+	return nil
+}
+
+type parallelRoot struct{ parallelTestAsset }
+
+func (a *parallelRoot) Name() string                 { return "root" }
+func (a *parallelRoot) Dependencies() []asset.Asset  { return parallelDependencies[reflect.TypeOf(a)] }
+func (a *parallelRoot) Generate(asset.Parents) error { return a.generate(a) }
+
+type parallelLeft struct{ parallelTestAsset }
+
+func (a *parallelLeft) Name() string                 { return "left" }
+func (a *parallelLeft) Dependencies() []asset.Asset  { return parallelDependencies[reflect.TypeOf(a)] }
+func (a *parallelLeft) Generate(asset.Parents) error { return a.generate(a) }
+
+type parallelRight struct{ parallelTestAsset }
+
+func (a *parallelRight) Name() string                 { return "right" }
+func (a *parallelRight) Dependencies() []asset.Asset  { return parallelDependencies[reflect.TypeOf(a)] }
+func (a *parallelRight) Generate(asset.Parents) error { return a.generate(a) }
+
+type parallelBase struct{ parallelTestAsset }
+
+func (a *parallelBase) Name() string                 { return "base" }
+func (a *parallelBase) Dependencies() []asset.Asset  { return parallelDependencies[reflect.TypeOf(a)] }
+func (a *parallelBase) Generate(asset.Parents) error { return a.generate(a) }
+
+type parallelPrompt struct{ parallelTestAsset }
+
+func (a *parallelPrompt) Name() string                 { return "prompt" }
+func (a *parallelPrompt) Dependencies() []asset.Asset  { return parallelDependencies[reflect.TypeOf(a)] }
+func (a *parallelPrompt) Generate(asset.Parents) error { return a.generate(a) }
+func (a *parallelPrompt) Interactive()                 {}
+
+// TestStoreFetchParallel tests that the independent assets are generated
+// concurrently, except the interactive ones, and that the dependencies are
+// populated before the assets depending on them are generated.
+func TestStoreFetchParallel(t *testing.T) {
+	cases := []struct {
+		name      string
+		left      func() error
+		right     func() error
+		expectErr string
+	}{
+		{
+			name: "concurrent generation",
+		},
+		{
+			name:      "failed dependency",
+			right:     func() error { return errors.New("right failed") },
+			expectErr: `^failed to fetch dependency of "root": failed to generate asset "right": right failed$`,
+		},
+		{
+			name:      "failed dependencies",
+			left:      func() error { return errors.New("left failed") },
+			right:     func() error { return errors.New("right failed") },
+			expectErr: `^failed to fetch dependency of "root": failed to generate asset "left": left failed$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The prompt and the base are generated first, then left and
+			// right, which wait for each other, and the root.
+			root, left, right := &parallelRoot{}, &parallelLeft{}, &parallelRight{}
+			parallelDependencies = map[reflect.Type][]asset.Asset{
+				reflect.TypeOf(root):  {&parallelLeft{}, &parallelRight{}},
+				reflect.TypeOf(left):  {&parallelBase{}, &parallelPrompt{}},
+				reflect.TypeOf(right): {&parallelBase{}},
+			}
+			var arrivedMu sync.Mutex
+			arrived := 0
+			all := make(chan struct{})
+			barrier := func(generate func() error) func() error {
+				return func() error {
+					arrivedMu.Lock()
+					if arrived++; arrived == 2 {
+						close(all)
+					}
+					arrivedMu.Unlock()
+					select {
+					case <-all:
+					case <-time.After(10 * time.Second):
+						return errors.New("not generated concurrently")
+					}
+					if generate != nil {
+						return generate()
+					}
+					return nil
+				}
+			}
+			parallelGenerate = map[reflect.Type]func() error{
+				reflect.TypeOf(left):  barrier(tc.left),
+				reflect.TypeOf(right): barrier(tc.right),
+			}
+			parallelLog = nil
+
+			store := &storeImpl{
+				directory:   t.TempDir(),
+				assets:      map[reflect.Type]*assetState{},
+				parallelism: 4,
+			}
+			err := store.Fetch(root)
+			if tc.expectErr != "" {
+				assert.Regexp(t, tc.expectErr, err)
+				assert.False(t, root.Generated)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, root.Generated)
+			assert.Len(t, parallelLog, 5)
+			assert.ElementsMatch(t, []string{"base", "prompt"}, parallelLog[:2])
+			assert.ElementsMatch(t, []string{"left", "right"}, parallelLog[2:4])
+			assert.Equal(t, "root", parallelLog[4])
+			for _, d := range parallelDependencies[reflect.TypeOf(root)] {
+				assert.True(t, reflect.ValueOf(d).Elem().FieldByName("Generated").Bool(), "%s not populated", d.Name())
+			}
+		})
+	}
+}
+
+// TestStoreFetchParallelOrder tests that a single worker generates the
+// assets in the order of the serial fetch.
+func TestStoreFetchParallelOrder(t *testing.T) {
+	cases := []struct {
+		name           string
+		assets         map[string][]string
+		existingAssets []string
+	}{
+		{
+			name: "intragenerational shared dependency",
+			assets: map[string][]string{
+				"a": {"b", "c"},
+				"b": {"d"},
+				"c": {"d"},
+				"d": {},
+			},
+		},
+		{
+			name: "intergenerational shared dependency",
+			assets: map[string][]string{
+				"a": {"b", "c"},
+				"b": {"c"},
+				"c": {},
+			},
+		},
+		{
+			name: "absent grandchild with absent parent",
+			assets: map[string][]string{
+				"a": {"b", "c"},
+				"b": {"d"},
+				"c": {"d"},
+				"d": {},
+			},
+			existingAssets: []string{"b"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs [][]string
+			for _, parallel := range []bool{false, true} {
+				clearAssetBehaviors()
+				store := &storeImpl{
+					directory: t.TempDir(),
+					assets:    map[reflect.Type]*assetState{},
+				}
+				assets := make(map[string]asset.Asset, len(tc.assets))
+				for name := range tc.assets {
+					assets[name] = newTestStoreAsset(name)
+				}
+				for name, deps := range tc.assets {
+					dependenciesOfAsset := make([]asset.Asset, len(deps))
+					for i, d := range deps {
+						dependenciesOfAsset[i] = assets[d]
+					}
+					dependencies[reflect.TypeOf(assets[name])] = dependenciesOfAsset
+				}
+				for _, assetName := range tc.existingAssets {
+					store.assets[reflect.TypeOf(assets[assetName])] = &assetState{
+						asset:  assets[assetName],
+						source: generatedSource,
+					}
+				}
+				fetch := func() error { return store.fetch(assets["a"], "") }
+				if parallel {
+					store.parallelism = 1
+					fetch = func() error { return store.fetchParallel(assets["a"]) }
+				}
+				assert.NoError(t, fetch(), "error fetching asset")
+				logs = append(logs, generationLog)
+			}
+			assert.Equal(t, logs[0], logs[1])
+		})
+	}
+}
+
+type parallelShared struct {
+	Values map[string]bool
+}
+
+func (a *parallelShared) Name() string                 { return "shared" }
+func (a *parallelShared) Dependencies() []asset.Asset  { return nil }
+func (a *parallelShared) Generate(asset.Parents) error { a.Values = map[string]bool{}; return nil }
+
+// parallelMutator modifies its parent, once the other mutator is generated
+// concurrently.
+type parallelMutator struct {
+	name    string
+	arrived *sync.WaitGroup
+}
+
+func (a *parallelMutator) Name() string                { return a.name }
+func (a *parallelMutator) Dependencies() []asset.Asset { return []asset.Asset{&parallelShared{}} }
+func (a *parallelMutator) Generate(parents asset.Parents) error {
+	shared := &parallelShared{}
+	parents.Get(shared)
+	a.arrived.Done()
+	a.arrived.Wait()
+	shared.Values[a.name] = true
+	return nil
+}
+
+type parallelLeftMutator struct{ parallelMutator }
+type parallelRightMutator struct{ parallelMutator }
+
+type parallelMutators struct{ left, right asset.Asset }
+
+func (a *parallelMutators) Name() string                 { return "mutators" }
+func (a *parallelMutators) Dependencies() []asset.Asset  { return []asset.Asset{a.left, a.right} }
+func (a *parallelMutators) Generate(asset.Parents) error { return nil }
+
+// TestStoreFetchParallelCopiesParents tests that the assets generated
+// concurrently are given their own copies of their shared parents.
+func TestStoreFetchParallelCopiesParents(t *testing.T) {
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	root := &parallelMutators{
+		left:  &parallelLeftMutator{parallelMutator{name: "left", arrived: &arrived}},
+		right: &parallelRightMutator{parallelMutator{name: "right", arrived: &arrived}},
+	}
+	store := &storeImpl{
+		directory:   t.TempDir(),
+		assets:      map[reflect.Type]*assetState{},
+		parallelism: 2,
+	}
+	if !assert.NoError(t, store.Fetch(root)) {
+		return
+	}
+	shared := &parallelShared{}
+	assert.NoError(t, store.Fetch(shared))
+	assert.Empty(t, shared.Values)
+}
@@ -5,11 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/encryption"
+	"github.com/openshift/installer/pkg/logformat"
 )
 
 const (
@@ -28,6 +31,9 @@ const (
 	onDiskSource
 	// stateFileSource indicates that the asset was fetched from the state file
 	stateFileSource
+	// skippedSource indicates that the asset is skipped, and used as it is
+	// instead of being generated. It is not saved to the state file.
+	skippedSource
 )
 
 type assetState struct {
@@ -49,18 +55,83 @@ type storeImpl struct {
 	assets          map[reflect.Type]*assetState
 	stateFileAssets map[string]json.RawMessage
 	fileFetcher     asset.FileFetcher
+	// cache is the cache of the cacheable assets. It is nil when caching is
+	// disabled.
+	cache *assetCache
+	// transaction stages the changes of the asset directory, which are
+	// otherwise written directly.
+	transaction *asset.Transaction
+	// parallelism is the number of independent assets generated
+	// concurrently. The assets are generated serially when it is 1.
+	parallelism int
+	// ephemeral is true if the fetched assets are discarded instead of
+	// being saved to the directory.
+	ephemeral bool
+}
+
+// Option configures the asset store.
+type Option func(*storeImpl)
+
+// DisableCache makes the store generate cacheable assets again instead of
+// reusing the ones generated earlier from the same parents.
+func DisableCache() Option {
+	return func(s *storeImpl) {
+		s.cache = nil
+	}
+}
+
+// WithTransaction makes the store stage the state file and the purges of
+// the consumed assets in the transaction.
+func WithTransaction(tx *asset.Transaction) Option {
+	return func(s *storeImpl) {
+		s.transaction = tx
+	}
+}
+
+// WithParallelism makes the store generate up to n independent assets
+// concurrently.
+func WithParallelism(n int) Option {
+	return func(s *storeImpl) {
+		s.parallelism = n
+	}
+}
+
+// Ephemeral makes the store discard the fetched assets instead of saving them
+// to the state file, purging the consumed assets or caching them, so that
+// fetching leaves the directory as it is.
+func Ephemeral() Option {
+	return func(s *storeImpl) {
+		s.ephemeral = true
+		s.cache = nil
+	}
+}
+
+// WithSkippedAssets makes the store use the assets as they are instead of
+// generating them, e.g. to skip the checks which hold no state. They are not
+// saved to the state file, so that a later fetch generates them.
+func WithSkippedAssets(assets ...asset.Asset) Option {
+	return func(s *storeImpl) {
+		for _, a := range assets {
+			s.assets[reflect.TypeOf(a)] = &assetState{asset: a, source: skippedSource}
+		}
+	}
 }
 
 // NewStore returns an asset store that implements the asset.Store interface.
-func NewStore(dir string) (asset.Store, error) {
-	return newStore(dir)
+func NewStore(dir string, opts ...Option) (asset.Store, error) {
+	return newStore(dir, opts...)
 }
 
-func newStore(dir string) (*storeImpl, error) {
+func newStore(dir string, opts ...Option) (*storeImpl, error) {
 	store := &storeImpl{
 		directory:   dir,
 		fileFetcher: &fileFetcher{directory: dir},
 		assets:      map[reflect.Type]*assetState{},
+		cache:       &assetCache{directory: dir},
+		parallelism: 1,
+	}
+	for _, opt := range opts {
+		opt(store)
 	}
 
 	if err := store.loadStateFile(); err != nil {
@@ -73,9 +144,16 @@ func newStore(dir string) (*storeImpl, error) {
 // dependencies if necessary. When purging consumed assets, none of the
 // assets in preserved will be purged.
 func (s *storeImpl) Fetch(a asset.Asset, preserved ...asset.WritableAsset) error {
-	if err := s.fetch(a, ""); err != nil {
+	fetch := func() error { return s.fetch(a, "") }
+	if s.parallelism > 1 {
+		fetch = func() error { return s.fetchParallel(a) }
+	}
+	if err := fetch(); err != nil {
 		return err
 	}
+	if s.ephemeral {
+		return nil
+	}
 	if err := s.saveStateFile(); err != nil {
 		return errors.Wrap(err, "failed to save state")
 	}
@@ -110,9 +188,12 @@ func (s *storeImpl) Destroy(a asset.Asset) error {
 	return s.saveStateFile()
 }
 
-// DestroyState removes the state file from disk
+// DestroyState removes the state file and the asset cache from disk
 func (s *storeImpl) DestroyState() error {
 	s.stateFileAssets = nil
+	if err := (&assetCache{directory: s.directory}).clear(); err != nil {
+		return err
+	}
 	path := filepath.Join(s.directory, stateFileName)
 	err := os.Remove(path)
 	if err != nil {
@@ -129,7 +210,7 @@ func (s *storeImpl) DestroyState() error {
 func (s *storeImpl) loadStateFile() error {
 	path := filepath.Join(s.directory, stateFileName)
 	assets := map[string]json.RawMessage{}
-	data, err := os.ReadFile(path)
+	data, err := encryption.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
@@ -165,7 +246,7 @@ func (s *storeImpl) saveStateFile() error {
 		s.stateFileAssets = map[string]json.RawMessage{}
 	}
 	for k, v := range s.assets {
-		if v.source == unfetched {
+		if v.source == unfetched || v.source == skippedSource {
 			continue
 		}
 		data, err := json.MarshalIndent(v.asset, "", "    ")
@@ -179,11 +260,15 @@ func (s *storeImpl) saveStateFile() error {
 		return err
 	}
 
-	path := filepath.Join(s.directory, stateFileName)
+	dir := s.directory
+	if s.transaction != nil {
+		dir = s.transaction.StagingDir()
+	}
+	path := filepath.Join(dir, stateFileName)
 	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
 		return err
 	}
-	if err := os.WriteFile(path, data, 0o640); err != nil { //nolint:gosec // no sensitive info
+	if err := encryption.WriteFile(path, data, 0o640); err != nil { //nolint:gosec // encrypted when a passphrase is set
 		return err
 	}
 	return nil
@@ -222,15 +307,51 @@ func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 		}
 		parents.Add(d)
 	}
-	logrus.Debugf("%sGenerating %s...", indent, a.Name())
-	if err := a.Generate(parents); err != nil {
-		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
+
+	if err := s.generate(a, dependencies, parents, indent); err != nil {
+		return err
 	}
 	assetState.asset = a
 	assetState.source = generatedSource
 	return nil
 }
 
+// generate generates the asset from its fetched parents, unless it is
+// cacheable and the cache holds the asset generated from the same parents.
+func (s *storeImpl) generate(a asset.Asset, dependencies []asset.Asset, parents asset.Parents, indent string) error {
+	var cacheKey string
+	if _, cacheable := a.(asset.CacheableAsset); cacheable && s.cache != nil {
+		key, err := s.cache.key(a, dependencies)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute the cache key of asset %q", a.Name())
+		}
+		found, err := s.cache.get(key, a)
+		if err != nil {
+			logrus.Debugf("%sIgnoring the cached %s: %v", indent, a.Name(), err)
+		}
+		if found {
+			logrus.Debugf("%sReusing cached %s", indent, a.Name())
+			return nil
+		}
+		cacheKey = key
+	}
+
+	logger := logrus.WithField(logformat.AssetField, a.Name())
+	logger.Debugf("%sGenerating %s...", indent, a.Name())
+	start := time.Now()
+	if err := a.Generate(parents); err != nil {
+		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
+	}
+	logger.WithField(logformat.DurationField, time.Since(start).Seconds()).Debugf("%sGenerated %s", indent, a.Name())
+
+	if cacheKey != "" {
+		if err := s.cache.put(cacheKey, a); err != nil {
+			logrus.Debugf("%sFailed to cache %s: %v", indent, a.Name(), err)
+		}
+	}
+	return nil
+}
+
 // load loads the asset and all of its ancestors from on-disk and the state file.
 func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 	logrus.Debugf("%sLoading %s...", indent, a.Name())
@@ -315,6 +436,11 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 	// The asset is in the state file. The asset is sourced from state file.
 	case foundInStateFile:
 		logrus.Debugf("%sUsing %s loaded from state file", indent, a.Name())
+		if revalidated, ok := stateFileAsset.(asset.RevalidatedAsset); ok {
+			if err := revalidated.Revalidate(); err != nil {
+				return nil, errors.Wrapf(err, "failed to validate asset %q from state file", a.Name())
+			}
+		}
 		assetToStore = stateFileAsset
 		source = stateFileSource
 	// There is no existing source for the asset. The asset will be generated.
@@ -345,7 +471,11 @@ func (s *storeImpl) purge(excluded []asset.WritableAsset) error {
 			continue
 		}
 		logrus.Infof("Consuming %s from target directory", assetState.asset.Name())
-		if err := asset.DeleteAssetFromDisk(assetState.asset.(asset.WritableAsset), s.directory); err != nil {
+		remove := func(a asset.WritableAsset) error { return asset.DeleteAssetFromDisk(a, s.directory) }
+		if s.transaction != nil {
+			remove = s.transaction.Remove
+		}
+		if err := remove(assetState.asset.(asset.WritableAsset)); err != nil {
 			return err
 		}
 		assetState.presentOnDisk = false
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -474,3 +475,115 @@ func TestStoreLoadOnDiskAssets(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreFetchSkippedAssets(t *testing.T) {
+	cases := []struct {
+		name                  string
+		skipped               []string
+		expectedGenerationLog []string
+	}{
+		{
+			name:                  "no skipped assets",
+			expectedGenerationLog: []string{"c", "b", "a"},
+		},
+		{
+			name:                  "skipped dependency",
+			skipped:               []string{"b"},
+			expectedGenerationLog: []string{"a"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			dependencies[reflect.TypeOf(&testStoreAssetA{})] = []asset.Asset{&testStoreAssetB{}}
+			dependencies[reflect.TypeOf(&testStoreAssetB{})] = []asset.Asset{&testStoreAssetC{}}
+
+			tempDir := t.TempDir()
+			skipped := make([]asset.Asset, 0, len(tc.skipped))
+			for _, name := range tc.skipped {
+				skipped = append(skipped, newTestStoreAsset(name))
+			}
+			store, err := newStore(tempDir, WithSkippedAssets(skipped...))
+			if !assert.NoError(t, err, "unexpected error creating store") {
+				t.Fatal()
+			}
+			err = store.Fetch(&testStoreAssetA{})
+			assert.NoError(t, err, "unexpected error fetching asset")
+			assert.EqualValues(t, tc.expectedGenerationLog, generationLog)
+
+			// The skipped assets are generated by a later fetch.
+			store, err = newStore(tempDir)
+			if !assert.NoError(t, err, "unexpected error creating store") {
+				t.Fatal()
+			}
+			for _, a := range skipped {
+				assert.False(t, store.isAssetInState(a), "skipped asset %q saved to the state file", a.Name())
+			}
+		})
+	}
+}
+
+func TestStoreFetchEphemeral(t *testing.T) {
+	clearAssetBehaviors()
+	dependencies[reflect.TypeOf(&testStoreAssetA{})] = []asset.Asset{&testStoreAssetB{}}
+
+	tempDir := t.TempDir()
+	store, err := newStore(tempDir, Ephemeral())
+	if !assert.NoError(t, err, "unexpected error creating store") {
+		t.Fatal()
+	}
+	err = store.Fetch(&testStoreAssetA{})
+	assert.NoError(t, err, "unexpected error fetching asset")
+	assert.EqualValues(t, []string{"b", "a"}, generationLog)
+
+	entries, err := os.ReadDir(tempDir)
+	assert.NoError(t, err, "unexpected error reading the directory")
+	assert.Empty(t, entries, "unexpected files in the directory")
+}
+
+// revalidationErr is the error of the validation of testRevalidatedAsset
+// restored from the state file.
+var revalidationErr error
+
+type testRevalidatedAsset struct{}
+
+func (a *testRevalidatedAsset) Name() string {
+	return "revalidated"
+}
+
+func (a *testRevalidatedAsset) Dependencies() []asset.Asset {
+	return nil
+}
+
+func (a *testRevalidatedAsset) Generate(asset.Parents) error {
+	return generateTestStoreAsset(a)
+}
+
+func (a *testRevalidatedAsset) Revalidate() error {
+	return revalidationErr
+}
+
+func TestStoreFetchRevalidatedAssets(t *testing.T) {
+	clearAssetBehaviors()
+	revalidationErr = nil
+	defer func() { revalidationErr = nil }()
+
+	tempDir := t.TempDir()
+	store, err := newStore(tempDir)
+	if !assert.NoError(t, err, "unexpected error creating store") {
+		t.Fatal()
+	}
+	err = store.Fetch(&testRevalidatedAsset{})
+	assert.NoError(t, err, "unexpected error fetching asset")
+	assert.EqualValues(t, []string{"revalidated"}, generationLog)
+
+	// The asset restored from the state file is validated again.
+	revalidationErr = errors.New("invalid asset")
+	store, err = newStore(tempDir)
+	if !assert.NoError(t, err, "unexpected error creating store") {
+		t.Fatal()
+	}
+	err = store.Fetch(&testRevalidatedAsset{})
+	assert.EqualError(t, err, `failed to validate asset "revalidated" from state file: invalid asset`)
+	assert.EqualValues(t, []string{"revalidated"}, generationLog)
+}
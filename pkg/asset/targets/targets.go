@@ -72,4 +72,9 @@ var (
 		&tls.JournalCertKey{},
 		&cluster.Cluster{},
 	}
+
+	// ClusterPlan are the assets targeted by a dry run of the cluster.
+	ClusterPlan = []asset.WritableAsset{
+		&cluster.Plan{},
+	}
 )
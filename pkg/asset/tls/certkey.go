@@ -131,10 +131,10 @@ func (c *SignedCertKey) Generate(
 	var crt *x509.Certificate
 	var err error
 
-	caKey, err := PemToPrivateKey(parentCA.Key())
+	caKey, err := PemToSigner(parentCA.Key())
 	if err != nil {
-		logrus.Debugf("Failed to parse RSA private key: %s", err)
-		return errors.Wrap(err, "failed to parse rsa private key")
+		logrus.Debugf("Failed to parse the private key of the CA: %s", err)
+		return errors.Wrap(err, "failed to parse the private key of the CA")
 	}
 
 	caCert, err := PemToCertificate(parentCA.Cert())
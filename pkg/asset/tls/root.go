@@ -1,10 +1,33 @@
 package tls
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/encryption"
+)
+
+const (
+	rootCAFilenameBase = "root-ca"
+
+	// rootCAMinValidity is the minimum remaining validity of a root CA
+	// provided by the user.
+	rootCAMinValidity = ValidityOneYear
+
+	// ecdsaMinCurveSize is the minimum size of the curve of the ECDSA key of
+	// a root CA provided by the user.
+	ecdsaMinCurveSize = 256
 )
 
 // RootCA contains the private key and the cert that's
@@ -29,10 +52,142 @@ func (c *RootCA) Generate(parents asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "root-ca")
+	return c.SelfSignedCertKey.Generate(cfg, rootCAFilenameBase)
 }
 
 // Name returns the human-friendly name of the asset.
 func (c *RootCA) Name() string {
 	return "Root CA"
 }
+
+// Load reads the root CA provided by the user in tls/root-ca.crt and
+// tls/root-ca.key. The certificate may be a root or an intermediate CA, with
+// an RSA or ECDSA key, optionally followed by the certificates of its
+// issuers.
+//
+// A provided root CA signs the same certificates as a generated one: the
+// serving certificate of the machine config server and the client
+// certificate of the bootstrap journal. The signers of the kube-apiserver,
+// kubelet, aggregator and admin kubeconfig certificates stay self-signed, as
+// the operators of the cluster replace them with self-signed ones when they
+// rotate them anyway.
+//
+// Like every asset, the private key is kept in the state file of the asset
+// directory, encrypted only when a passphrase is set for the asset directory.
+func (c *RootCA) Load(f asset.FileFetcher) (bool, error) {
+	fetch := func(suffix string) (*asset.File, error) {
+		file, err := f.FetchByName(assetFilePath(rootCAFilenameBase + suffix))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return file, nil
+	}
+	keyFile, err := fetch(".key")
+	if err != nil {
+		return false, err
+	}
+	certFile, err := fetch(".crt")
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case keyFile == nil && certFile == nil:
+		return false, nil
+	case keyFile == nil:
+		return false, errors.Errorf("%s is provided without its private key %s", assetFilePath(rootCAFilenameBase+".crt"), assetFilePath(rootCAFilenameBase+".key"))
+	case certFile == nil:
+		return false, errors.Errorf("%s is provided without its certificate %s", assetFilePath(rootCAFilenameBase+".key"), assetFilePath(rootCAFilenameBase+".crt"))
+	}
+
+	if err := validateRootCA(certFile.Data, keyFile.Data, time.Now()); err != nil {
+		return false, errors.Wrap(err, "invalid root CA")
+	}
+
+	if !encryption.Enabled() {
+		logrus.Warnf("The private key of the root CA is kept in plain text in the state file of the asset directory, set %s to encrypt it", encryption.PassphraseEnv)
+	}
+
+	c.KeyRaw = keyFile.Data
+	c.CertRaw = certFile.Data
+	c.FileList = []*asset.File{keyFile, certFile}
+	return true, nil
+}
+
+// validateRootCA checks that the certificate, the first of the PEM bundle, is
+// a CA which can sign the cluster certificates with the key. The other
+// certificates of the bundle must be its issuers.
+func validateRootCA(certPEM, keyPEM []byte, now time.Time) error {
+	var certs []*x509.Certificate
+	for rest := bytes.TrimSpace(certPEM); len(rest) > 0; rest = bytes.TrimSpace(rest) {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return errors.New("the certificate file must only contain PEM encoded certificates")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse the certificate")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return errors.New("could not find a PEM block in the certificate")
+	}
+	cert := certs[0]
+
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return errors.Errorf("the certificate %q is not a CA", cert.Subject)
+	}
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return errors.Errorf("the key usage of the certificate %q does not allow signing certificates", cert.Subject)
+	}
+	switch cert.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return errors.Errorf("the certificate %q is signed with the weak algorithm %s", cert.Subject, cert.SignatureAlgorithm)
+	}
+	if now.Before(cert.NotBefore) {
+		return errors.Errorf("the certificate %q is not valid before %s", cert.Subject, cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.Add(rootCAMinValidity).After(cert.NotAfter) {
+		return errors.Errorf("the certificate %q expires on %s, it must be valid for at least one more year", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Add(ValidityTenYears).After(cert.NotAfter) {
+		logrus.Warnf("The root CA %q expires on %s, before the certificates signed by it, which are no longer trusted from then on", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	if len(certs) > 1 {
+		issuers := x509.NewCertPool()
+		for _, issuer := range certs[1:] {
+			issuers.AddCert(issuer)
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{
+			Roots:       issuers,
+			CurrentTime: now,
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return errors.Wrapf(err, "the certificate %q is not issued by the other certificates of the file", cert.Subject)
+		}
+	}
+
+	key, err := PemToSigner(keyPEM)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the private key")
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < keySize {
+			return errors.Errorf("the RSA private key is %d bits long, it must be at least %d bits long", key.N.BitLen(), keySize)
+		}
+	case *ecdsa.PrivateKey:
+		if key.Curve.Params().BitSize < ecdsaMinCurveSize {
+			return errors.Errorf("the ECDSA private key is on curve %s, it must be on a curve of at least %d bits", key.Curve.Params().Name, ecdsaMinCurveSize)
+		}
+	}
+	if !key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(cert.PublicKey) {
+		return errors.Errorf("the private key does not match the certificate %q", cert.Subject)
+	}
+	return nil
+}
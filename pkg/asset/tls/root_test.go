@@ -0,0 +1,340 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string, issuer *testCA, modify func(*x509.Certificate)) *testCA {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(2 * ValidityTenYears),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if modify != nil {
+		modify(tmpl)
+	}
+	parent, signer := tmpl, key
+	if issuer != nil {
+		parent, signer = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func newTestECDSACA(t *testing.T, name string, curve elliptic.Curve) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(2 * ValidityTenYears),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func ecPrivateKeyToPem(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestValidateRootCA(t *testing.T) {
+	root := newTestCA(t, "root", nil, nil)
+	intermediate := newTestCA(t, "intermediate", root, nil)
+	other := newTestCA(t, "other", nil, nil)
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecCert, ecKey := newTestECDSACA(t, "ecdsa", elliptic.P256())
+	ecKeyDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weakECCert, weakECKey := newTestECDSACA(t, "p224", elliptic.P224())
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKeyDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKeyPKCS8, err := x509.MarshalPKCS8PrivateKey(root.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		certs    []*x509.Certificate
+		key      []byte
+		expected string
+	}{
+		{
+			name:  "root CA",
+			certs: []*x509.Certificate{root.cert},
+			key:   PrivateKeyToPem(root.key),
+		},
+		{
+			name:  "PKCS#8 key",
+			certs: []*x509.Certificate{root.cert},
+			key:   pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rootKeyPKCS8}),
+		},
+		{
+			name:  "intermediate CA",
+			certs: []*x509.Certificate{intermediate.cert},
+			key:   PrivateKeyToPem(intermediate.key),
+		},
+		{
+			name:  "intermediate CA with its chain",
+			certs: []*x509.Certificate{intermediate.cert, root.cert},
+			key:   PrivateKeyToPem(intermediate.key),
+		},
+		{
+			name:     "intermediate CA with another chain",
+			certs:    []*x509.Certificate{intermediate.cert, other.cert},
+			key:      PrivateKeyToPem(intermediate.key),
+			expected: `^the certificate "CN=intermediate" is not issued by the other certificates of the file: x509: certificate signed by unknown authority`,
+		},
+		{
+			name: "not a CA",
+			certs: []*x509.Certificate{newTestCA(t, "leaf", root, func(c *x509.Certificate) {
+				c.IsCA = false
+				c.KeyUsage = x509.KeyUsageDigitalSignature
+			}).cert},
+			key:      PrivateKeyToPem(root.key),
+			expected: `^the certificate "CN=leaf" is not a CA$`,
+		},
+		{
+			name: "no certificate signing usage",
+			certs: []*x509.Certificate{newTestCA(t, "no-sign", nil, func(c *x509.Certificate) {
+				c.KeyUsage = x509.KeyUsageDigitalSignature
+			}).cert},
+			key:      PrivateKeyToPem(root.key),
+			expected: `^the key usage of the certificate "CN=no-sign" does not allow signing certificates$`,
+		},
+		{
+			name: "not yet valid",
+			certs: []*x509.Certificate{newTestCA(t, "future", nil, func(c *x509.Certificate) {
+				c.NotBefore = time.Now().Add(time.Hour)
+			}).cert},
+			key:      PrivateKeyToPem(root.key),
+			expected: `^the certificate "CN=future" is not valid before .*$`,
+		},
+		{
+			name: "expiring",
+			certs: []*x509.Certificate{newTestCA(t, "expiring", nil, func(c *x509.Certificate) {
+				c.NotAfter = time.Now().Add(30 * ValidityOneDay)
+			}).cert},
+			key:      PrivateKeyToPem(root.key),
+			expected: `^the certificate "CN=expiring" expires on .*, it must be valid for at least one more year$`,
+		},
+		{
+			name: "weak signature",
+			certs: []*x509.Certificate{newTestCA(t, "sha1", nil, func(c *x509.Certificate) {
+				c.SignatureAlgorithm = x509.SHA1WithRSA
+			}).cert},
+			key:      PrivateKeyToPem(root.key),
+			expected: `^the certificate "CN=sha1" is signed with the weak algorithm SHA1-RSA$`,
+		},
+		{
+			name:     "weak key",
+			certs:    []*x509.Certificate{root.cert},
+			key:      PrivateKeyToPem(weakKey),
+			expected: `^the RSA private key is 1024 bits long, it must be at least 2048 bits long$`,
+		},
+		{
+			name:  "ECDSA CA",
+			certs: []*x509.Certificate{ecCert},
+			key:   ecPrivateKeyToPem(t, ecKey),
+		},
+		{
+			name:  "ECDSA CA with a PKCS#8 key",
+			certs: []*x509.Certificate{ecCert},
+			key:   pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecKeyDER}),
+		},
+		{
+			name:     "weak ECDSA key",
+			certs:    []*x509.Certificate{weakECCert},
+			key:      ecPrivateKeyToPem(t, weakECKey),
+			expected: `^the ECDSA private key is on curve P-224, it must be on a curve of at least 256 bits$`,
+		},
+		{
+			name:     "unsupported key",
+			certs:    []*x509.Certificate{root.cert},
+			key:      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edKeyDER}),
+			expected: `^failed to parse the private key: the private key is a ed25519\.PrivateKey, only RSA and ECDSA keys are supported$`,
+		},
+		{
+			name:     "ECDSA key of an RSA CA",
+			certs:    []*x509.Certificate{root.cert},
+			key:      ecPrivateKeyToPem(t, ecKey),
+			expected: `^the private key does not match the certificate "CN=root"$`,
+		},
+		{
+			name:     "mismatched key",
+			certs:    []*x509.Certificate{root.cert},
+			key:      PrivateKeyToPem(other.key),
+			expected: `^the private key does not match the certificate "CN=root"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var certPEM []byte
+			for _, cert := range tc.certs {
+				certPEM = append(certPEM, CertToPem(cert)...)
+			}
+			err := validateRootCA(certPEM, tc.key, time.Now())
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRootCALoad(t *testing.T) {
+	root := newTestCA(t, "root", nil, nil)
+	certFile := &asset.File{Filename: assetFilePath("root-ca.crt"), Data: CertToPem(root.cert)}
+	keyFile := &asset.File{Filename: assetFilePath("root-ca.key"), Data: PrivateKeyToPem(root.key)}
+	ecCert, ecKey := newTestECDSACA(t, "ecdsa", elliptic.P384())
+	ecCertFile := &asset.File{Filename: assetFilePath("root-ca.crt"), Data: CertToPem(ecCert)}
+	ecKeyFile := &asset.File{Filename: assetFilePath("root-ca.key"), Data: ecPrivateKeyToPem(t, ecKey)}
+
+	cases := []struct {
+		name     string
+		cert     *asset.File
+		key      *asset.File
+		found    bool
+		expected string
+	}{
+		{
+			name: "not provided",
+		},
+		{
+			name:  "provided",
+			cert:  certFile,
+			key:   keyFile,
+			found: true,
+		},
+		{
+			name:  "provided ECDSA",
+			cert:  ecCertFile,
+			key:   ecKeyFile,
+			found: true,
+		},
+		{
+			name:     "certificate only",
+			cert:     certFile,
+			expected: `^tls/root-ca\.crt is provided without its private key tls/root-ca\.key$`,
+		},
+		{
+			name:     "key only",
+			key:      keyFile,
+			expected: `^tls/root-ca\.key is provided without its certificate tls/root-ca\.crt$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			for _, f := range []struct {
+				name string
+				file *asset.File
+			}{{keyFile.Filename, tc.key}, {certFile.Filename, tc.cert}} {
+				if f.file != nil {
+					fileFetcher.EXPECT().FetchByName(f.name).Return(f.file, nil)
+				} else {
+					fileFetcher.EXPECT().FetchByName(f.name).Return(nil, os.ErrNotExist)
+				}
+			}
+
+			rootCA := &RootCA{}
+			found, err := rootCA.Load(fileFetcher)
+			assert.Equal(t, tc.found, found)
+			if tc.expected != "" {
+				assert.Regexp(t, tc.expected, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.found {
+				assert.Equal(t, tc.cert.Data, rootCA.Cert())
+				assert.Equal(t, tc.key.Data, rootCA.Key())
+				assert.Equal(t, []*asset.File{tc.key, tc.cert}, rootCA.Files())
+
+				// The cluster certificates are signed by the provided CA.
+				parents := asset.Parents{}
+				parents.Add(rootCA)
+				journal := &JournalCertKey{}
+				if !assert.NoError(t, journal.Generate(parents)) {
+					return
+				}
+				roots := x509.NewCertPool()
+				roots.AppendCertsFromPEM(tc.cert.Data)
+				cert, err := PemToCertificate(journal.Cert())
+				if assert.NoError(t, err) {
+					_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
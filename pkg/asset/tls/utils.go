@@ -1,6 +1,8 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -68,6 +70,34 @@ func PemToPrivateKey(data []byte) (*rsa.PrivateKey, error) {
 	return x509.ParsePKCS1PrivateKey(block.Bytes)
 }
 
+// PemToSigner converts a data block to the private key of a CA: an RSA key in
+// the PKCS#1 format, an ECDSA key in the SEC 1 format, or either in the PKCS#8
+// format.
+func PemToSigner(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("could not find a PEM block in the private key")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, errors.Errorf("the private key is a %T, only RSA and ECDSA keys are supported", key)
+	}
+}
+
 // PemToPublicKey converts a data block to rsa.PublicKey.
 func PemToPublicKey(data []byte) (*rsa.PublicKey, error) {
 	block, _ := pem.Decode(data)
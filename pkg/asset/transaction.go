@@ -0,0 +1,286 @@
+package asset
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// rollbackDirName is the directory of the asset directory holding the
+	// files which the last committed transaction replaced or removed.
+	rollbackDirName = ".openshift_install_rollback"
+	// stagingDirPattern is the pattern of the directory of the asset
+	// directory where a transaction stages the files it writes.
+	stagingDirPattern = ".openshift_install_staging"
+	// journalFileName is the file of the rollback directory listing the
+	// changes of the transaction.
+	journalFileName = "journal.json"
+	// metadataFileName is the cluster metadata, which is needed to destroy
+	// the cluster.
+	metadataFileName = "metadata.json"
+)
+
+// provisioningFile returns whether the file, relative to the asset directory,
+// records the provisioning of the cluster: the cluster metadata and the
+// Terraform state, without which the cluster cannot be destroyed. They are
+// never rolled back.
+func provisioningFile(filename string) bool {
+	return filename == metadataFileName || strings.HasSuffix(filename, ".tfstate")
+}
+
+// journal lists the files, relative to the asset directory, changed by a
+// committed transaction.
+type journal struct {
+	// Created are the files which did not exist before the transaction.
+	Created []string `json:"created,omitempty"`
+	// Saved are the files which the transaction replaced or removed, and
+	// which are saved in the rollback directory.
+	Saved []string `json:"saved,omitempty"`
+}
+
+// Transaction stages the files written to the asset directory during a run,
+// and the removals of consumed files, so that the asset directory is only
+// changed when the run succeeds. The files it replaces are kept until the
+// next transaction, so that Rollback restores them, unless the transaction
+// provisioned the cluster.
+type Transaction struct {
+	directory string
+	staging   string
+	removed   map[string]bool
+}
+
+// BeginTransaction starts a transaction on the asset directory.
+func BeginTransaction(directory string) (*Transaction, error) {
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return nil, errors.Wrap(err, "failed to create dir")
+	}
+	staging, err := os.MkdirTemp(directory, stagingDirPattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the staging dir")
+	}
+	return &Transaction{
+		directory: directory,
+		staging:   staging,
+		removed:   map[string]bool{},
+	}, nil
+}
+
+// StagingDir returns the directory where the files written by the
+// transaction are staged.
+func (t *Transaction) StagingDir() string {
+	return t.staging
+}
+
+// Persist stages the files of the writer.
+func (t *Transaction) Persist(w FileWriter) error {
+	return w.PersistToFile(t.staging)
+}
+
+// Remove stages the removal of the files of the asset from the asset
+// directory.
+func (t *Transaction) Remove(a WritableAsset) error {
+	logrus.Debugf("Purging asset %q from disk", a.Name())
+	for _, f := range a.Files() {
+		filename := filepath.Clean(f.Filename)
+		if err := os.Remove(filepath.Join(t.staging, filename)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove file")
+		}
+		t.removed[filename] = true
+	}
+	return nil
+}
+
+// Abort discards the staged changes, leaving the asset directory as it was.
+func (t *Transaction) Abort() error {
+	return os.RemoveAll(t.staging)
+}
+
+// Commit moves the staged files into the asset directory and removes the
+// files staged for removal. The files it replaces or removes are saved in the
+// rollback directory, replacing the ones of the previous transaction. When
+// the transaction writes the files recording the provisioning of the
+// cluster, nothing is saved: the cluster exists, and the replaced files,
+// which hold the secrets of the cluster, are not kept.
+func (t *Transaction) Commit() error {
+	written, err := t.stagedFiles()
+	if err != nil {
+		return err
+	}
+
+	rollbackDir := filepath.Join(t.directory, rollbackDirName)
+	pending := rollbackDir + ".new"
+	if err := os.RemoveAll(pending); err != nil {
+		return err
+	}
+
+	isWritten := make(map[string]bool, len(written))
+	changed := append([]string{}, written...)
+	for _, filename := range written {
+		isWritten[filename] = true
+	}
+	for filename := range t.removed {
+		if !isWritten[filename] {
+			changed = append(changed, filename)
+		}
+	}
+	sort.Strings(changed)
+
+	j := journal{}
+	for _, filename := range changed {
+		if _, err := os.Stat(filepath.Join(t.directory, filename)); err == nil {
+			j.Saved = append(j.Saved, filename)
+		} else if !os.IsNotExist(err) {
+			return err
+		} else if isWritten[filename] {
+			j.Created = append(j.Created, filename)
+		}
+	}
+	// The journal is written before any change, so that an interrupted
+	// commit is rolled back too.
+	if err := writeJournal(pending, j); err != nil {
+		return err
+	}
+
+	for _, filename := range j.Saved {
+		if err := move(filepath.Join(t.directory, filename), filepath.Join(pending, "files", filename)); err != nil {
+			return errors.Wrapf(err, "failed to save %s", filename)
+		}
+	}
+	for _, filename := range written {
+		if err := move(filepath.Join(t.staging, filename), filepath.Join(t.directory, filename)); err != nil {
+			return errors.Wrapf(err, "failed to write %s", filename)
+		}
+	}
+	for filename := range t.removed {
+		if !isWritten[filename] {
+			removeEmptyDirs(t.directory, filepath.Dir(filepath.Join(t.directory, filename)))
+		}
+	}
+
+	if err := os.RemoveAll(rollbackDir); err != nil {
+		return err
+	}
+	provisioned := false
+	for _, filename := range written {
+		provisioned = provisioned || provisioningFile(filename)
+	}
+	if provisioned {
+		logrus.Debugf("Removing the rollback directory, the transaction provisioned the cluster")
+		if err := os.RemoveAll(pending); err != nil {
+			return err
+		}
+	} else if err := os.Rename(pending, rollbackDir); err != nil {
+		return err
+	}
+	return os.RemoveAll(t.staging)
+}
+
+// stagedFiles returns the files of the staging directory, relative to it.
+func (t *Transaction) stagedFiles() ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(t.staging, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(t.staging, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// Rollback restores the asset directory to its state before the last
+// committed transaction: the files it created are removed, and the ones it
+// replaced or removed are restored. A transaction is rolled back once. The
+// cluster metadata and the Terraform state are kept as they are, so that a
+// cluster whose provisioning started can still be destroyed.
+func Rollback(directory string) error {
+	rollbackDir := filepath.Join(directory, rollbackDirName)
+	// A commit that was interrupted is rolled back instead of the last one.
+	if _, err := os.Stat(rollbackDir + ".new"); err == nil {
+		rollbackDir += ".new"
+	}
+
+	data, err := os.ReadFile(filepath.Join(rollbackDir, journalFileName))
+	if os.IsNotExist(err) {
+		return errors.New("there is no change of the asset directory to roll back")
+	} else if err != nil {
+		return err
+	}
+	j := journal{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", journalFileName)
+	}
+
+	for _, filename := range j.Created {
+		if provisioningFile(filename) {
+			logrus.Warnf("Keeping %s, which is needed to destroy the cluster", filename)
+			continue
+		}
+		path := filepath.Join(directory, filename)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %s", filename)
+		}
+		removeEmptyDirs(directory, filepath.Dir(path))
+		logrus.Debugf("Removed %s", filename)
+	}
+	for _, filename := range j.Saved {
+		if provisioningFile(filename) {
+			logrus.Warnf("Keeping the current %s, which is needed to destroy the cluster", filename)
+			continue
+		}
+		saved := filepath.Join(rollbackDir, "files", filename)
+		if _, err := os.Stat(saved); os.IsNotExist(err) {
+			// The commit was interrupted before the file was replaced.
+			continue
+		}
+		if err := move(saved, filepath.Join(directory, filename)); err != nil {
+			return errors.Wrapf(err, "failed to restore %s", filename)
+		}
+		logrus.Debugf("Restored %s", filename)
+	}
+	return os.RemoveAll(rollbackDir)
+}
+
+func writeJournal(dir string, j journal) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, journalFileName), data, 0o600)
+}
+
+// move renames the file, creating the directory of the destination.
+func move(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return err
+	}
+	return os.Rename(src, dst)
+}
+
+// removeEmptyDirs removes the directory and its parents while they are
+// empty, up to the asset directory.
+func removeEmptyDirs(directory, dir string) {
+	for dir != filepath.Clean(directory) {
+		if ok, err := isDirEmpty(dir); err != nil || !ok {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,182 @@
+package asset
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readFiles returns the content of the files of the directory, skipping the
+// staging and rollback directories.
+func readFiles(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".openshift_install") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[rel] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestTransaction(t *testing.T) {
+	dir := t.TempDir()
+	before := map[string]string{
+		"a.yaml":         "old",
+		"consumed.yaml":  "install config",
+		"unchanged.yaml": "unchanged",
+	}
+	writeFiles(t, dir, before)
+
+	generated := &writablePersistAsset{FileList: []*File{
+		{Filename: "a.yaml", Data: []byte("new")},
+		{Filename: "dir/b.yaml", Data: []byte("b")},
+	}}
+	consumed := &writablePersistAsset{FileList: []*File{
+		{Filename: "consumed.yaml"},
+	}}
+
+	tx, err := BeginTransaction(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, tx.Persist(NewDefaultFileWriter(generated)))
+	assert.NoError(t, tx.Remove(consumed))
+	assert.Equal(t, before, readFiles(t, dir), "nothing is changed before the commit")
+
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, map[string]string{
+		"a.yaml":         "new",
+		"dir/b.yaml":     "b",
+		"unchanged.yaml": "unchanged",
+	}, readFiles(t, dir))
+
+	assert.NoError(t, Rollback(dir))
+	assert.Equal(t, before, readFiles(t, dir))
+	assert.NoDirExists(t, filepath.Join(dir, "dir"))
+	assert.EqualError(t, Rollback(dir), "there is no change of the asset directory to roll back")
+}
+
+func TestTransactionAbort(t *testing.T) {
+	dir := t.TempDir()
+	before := map[string]string{"a.yaml": "old"}
+	writeFiles(t, dir, before)
+
+	tx, err := BeginTransaction(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, tx.Persist(NewDefaultFileWriter(&writablePersistAsset{FileList: []*File{
+		{Filename: "a.yaml", Data: []byte("new")},
+	}})))
+	assert.NoError(t, tx.Abort())
+	assert.Equal(t, before, readFiles(t, dir))
+}
+
+func TestTransactionProvisioning(t *testing.T) {
+	cases := []struct {
+		name     string
+		before   map[string]string
+		written  []*File
+		expected map[string]string
+		rollback string
+	}{
+		{
+			name:   "provisioning committed",
+			before: map[string]string{"auth/kubeconfig": "old"},
+			written: []*File{
+				{Filename: "auth/kubeconfig", Data: []byte("new")},
+				{Filename: "metadata.json", Data: []byte("{}")},
+				{Filename: "terraform.tfstate", Data: []byte("{}")},
+			},
+			rollback: "there is no change of the asset directory to roll back",
+		},
+		{
+			name:   "no provisioning",
+			before: map[string]string{"install-config.yaml": "install config"},
+			written: []*File{
+				{Filename: "manifests/a.yaml", Data: []byte("a")},
+			},
+			expected: map[string]string{"install-config.yaml": "install config"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.before)
+
+			tx, err := BeginTransaction(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, tx.Persist(NewDefaultFileWriter(&writablePersistAsset{FileList: tc.written})))
+			assert.NoError(t, tx.Commit())
+
+			err = Rollback(dir)
+			if tc.rollback != "" {
+				assert.EqualError(t, err, tc.rollback)
+				assert.NoDirExists(t, filepath.Join(dir, rollbackDirName))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, readFiles(t, dir))
+		})
+	}
+}
+
+func TestRollbackKeepsProvisioningFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.yaml": "old"})
+
+	tx, err := BeginTransaction(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, tx.Persist(NewDefaultFileWriter(&writablePersistAsset{FileList: []*File{
+		{Filename: "a.yaml", Data: []byte("new")},
+	}})))
+	assert.NoError(t, tx.Commit())
+
+	// A create cluster run interrupted while committing leaves the journal
+	// of the pending commit, with the provisioning files already written.
+	j := journal{Created: []string{"metadata.json", "terraform.tfstate"}, Saved: []string{"a.yaml"}}
+	assert.NoError(t, writeJournal(filepath.Join(dir, rollbackDirName+".new"), j))
+	writeFiles(t, dir, map[string]string{"metadata.json": "{}", "terraform.tfstate": "{}"})
+
+	assert.NoError(t, Rollback(dir))
+	assert.Equal(t, map[string]string{
+		"a.yaml":            "new",
+		"metadata.json":     "{}",
+		"terraform.tfstate": "{}",
+	}, readFiles(t, dir))
+}
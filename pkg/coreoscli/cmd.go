@@ -2,23 +2,65 @@ package coreoscli
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/coreos/stream-metadata-go/arch"
+	"github.com/coreos/stream-metadata-go/stream"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/rhcos"
 )
 
+var printStreamOpts struct {
+	arch string
+}
+
 // printStreamJSON is the implementation of print-stream-json
 func printStreamJSON(cmd *cobra.Command, _ []string) error {
 	streamData, err := rhcos.FetchRawCoreOSStream(context.Background())
 	if err != nil {
 		return err
 	}
+	if printStreamOpts.arch != "" {
+		streamData, err = filterStreamArch(streamData, printStreamOpts.arch)
+		if err != nil {
+			return err
+		}
+	}
 	os.Stdout.Write(streamData)
 	return nil
 }
 
+// filterStreamArch returns the stream metadata with only the architecture,
+// given by its Go (amd64) or RPM (x86_64) name.
+func filterStreamArch(streamData []byte, archName string) ([]byte, error) {
+	var st stream.Stream
+	if err := json.Unmarshal(streamData, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse CoreOS stream metadata: %w", err)
+	}
+	rpmArch := arch.RpmArch(archName)
+	streamArch, ok := st.Architectures[rpmArch]
+	if !ok {
+		available := make([]string, 0, len(st.Architectures))
+		for name := range st.Architectures {
+			available = append(available, name)
+		}
+		sort.Strings(available)
+		return nil, fmt.Errorf("%s: architecture not found, available architectures: %s", st.FormatPrefix(rpmArch), strings.Join(available, ", "))
+	}
+	st.Architectures = map[string]stream.Arch{rpmArch: streamArch}
+
+	data, err := json.MarshalIndent(&st, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
 // NewCmd returns a subcommand for explain
 func NewCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -33,9 +75,15 @@ func NewCmd() *cobra.Command {
 	printStreamCmd := &cobra.Command{
 		Use:   "print-stream-json",
 		Short: "Outputs the CoreOS stream metadata for the bootimages",
-		Args:  cobra.ExactArgs(0),
-		RunE:  printStreamJSON,
+		Long: `Outputs the CoreOS stream metadata for the bootimages.
+
+The output can be edited and set as the coreOSStream path of the install config
+to override the boot images, and the release of an architecture can be set as
+the coreOSStream release to pin the build of the boot images.`,
+		Args: cobra.ExactArgs(0),
+		RunE: printStreamJSON,
 	}
+	printStreamCmd.Flags().StringVar(&printStreamOpts.arch, "arch", "", "only output the boot images of the architecture (e.g. amd64 or x86_64)")
 	cmd.AddCommand(printStreamCmd)
 
 	return cmd
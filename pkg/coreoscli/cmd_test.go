@@ -0,0 +1,64 @@
+package coreoscli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterStreamArch(t *testing.T) {
+	streamData := []byte(`{
+  "stream": "rhcos-4.13",
+  "metadata": {"last-modified": "2023-05-02T17:36:00Z"},
+  "architectures": {
+    "aarch64": {"artifacts": {"metal": {"release": "413.92.202305021736-0", "formats": {}}}},
+    "x86_64": {"artifacts": {"metal": {"release": "413.92.202305021736-0", "formats": {}}}}
+  }
+}`)
+
+	cases := []struct {
+		name     string
+		arch     string
+		expected string
+		errorMsg string
+	}{
+		{
+			name: "go architecture name",
+			arch: "amd64",
+			expected: `{
+  "stream": "rhcos-4.13",
+  "metadata": {
+    "last-modified": "2023-05-02T17:36:00Z"
+  },
+  "architectures": {
+    "x86_64": {
+      "artifacts": {
+        "metal": {
+          "release": "413.92.202305021736-0",
+          "formats": {}
+        }
+      },
+      "images": {}
+    }
+  }
+}
+`,
+		},
+		{
+			name:     "unknown architecture",
+			arch:     "s390x",
+			errorMsg: "rhcos-4.13/s390x: architecture not found, available architectures: aarch64, x86_64",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := filterStreamArch(streamData, tc.arch)
+			if tc.errorMsg != "" {
+				assert.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+}
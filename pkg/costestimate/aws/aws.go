@@ -0,0 +1,229 @@
+// Package aws prices the resources of AWS clusters.
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/costestimate"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	awsdefaults "github.com/openshift/installer/pkg/types/aws/defaults"
+)
+
+const (
+	// rootVolumeSize is the default size of the root volumes, in GiB.
+	rootVolumeSize = 120
+	// gp3BaselineIOPS are the IOPS of gp3 volumes included in their price.
+	gp3BaselineIOPS = 3000
+	// defaultZoneCount is the number of zones assumed when the install config
+	// does not list the zones of the control plane.
+	defaultZoneCount = 3
+)
+
+// Prices are the list prices of the AWS resources of a region, in USD.
+type Prices struct {
+	Region string
+	// Instances are the hourly on-demand prices of the Linux instance types.
+	Instances map[string]float64
+	// Volumes are the monthly prices of a GiB of the EBS volume types.
+	Volumes map[string]float64
+	// ProvisionedIOPS are the monthly prices of a provisioned IOPS of the EBS
+	// volume types. The IOPS of gp3 volumes above the baseline are priced.
+	ProvisionedIOPS map[string]float64
+	// NetworkLoadBalancer is the hourly price of a Network Load Balancer.
+	NetworkLoadBalancer float64
+	// ClassicLoadBalancer is the hourly price of a Classic Load Balancer.
+	ClassicLoadBalancer float64
+	// NATGateway is the hourly price of a NAT gateway.
+	NATGateway float64
+}
+
+// USEast1Prices are the list prices of us-east-1.
+var USEast1Prices = Prices{
+	Region: "us-east-1",
+	Instances: map[string]float64{
+		"c5.2xlarge":  0.34,
+		"c5.4xlarge":  0.68,
+		"c5d.2xlarge": 0.384,
+		"m5.large":    0.096,
+		"m5.xlarge":   0.192,
+		"m5.2xlarge":  0.384,
+		"m5.4xlarge":  0.768,
+		"m6a.xlarge":  0.1728,
+		"m6a.2xlarge": 0.3456,
+		"m6g.large":   0.077,
+		"m6g.xlarge":  0.154,
+		"m6g.2xlarge": 0.308,
+		"m6g.4xlarge": 0.616,
+		"m6i.large":   0.096,
+		"m6i.xlarge":  0.192,
+		"m6i.2xlarge": 0.384,
+		"m6i.4xlarge": 0.768,
+		"m7i.xlarge":  0.2016,
+		"m7i.2xlarge": 0.4032,
+		"r5.xlarge":   0.252,
+		"r5.2xlarge":  0.504,
+	},
+	Volumes: map[string]float64{
+		"gp2": 0.10,
+		"gp3": 0.08,
+		"io1": 0.125,
+		"io2": 0.125,
+	},
+	ProvisionedIOPS: map[string]float64{
+		"gp3": 0.005,
+		"io1": 0.065,
+		"io2": 0.065,
+	},
+	NetworkLoadBalancer: 0.0225,
+	ClassicLoadBalancer: 0.025,
+	NATGateway:          0.045,
+}
+
+// Backend prices the resources of AWS clusters from static list prices.
+type Backend struct {
+	Prices Prices
+}
+
+var _ costestimate.Backend = (*Backend)(nil)
+
+// Estimate returns the estimated monthly cost of the cluster of the install
+// config.
+func (b *Backend) Estimate(ctx context.Context, ic *types.InstallConfig) (*costestimate.Estimate, error) {
+	estimate := &costestimate.Estimate{Currency: "USD"}
+	region := ic.Platform.AWS.Region
+	if region != b.Prices.Region {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("the resources are priced with the list prices of %s, which may differ from the ones of %s", b.Prices.Region, region))
+	}
+	estimate.Notes = append(estimate.Notes, "data transfer and the temporary bootstrap machine are not included")
+
+	if ic.ControlPlane != nil {
+		topology := configv1.HighlyAvailableTopologyMode
+		if costestimate.Replicas(ic.ControlPlane) == 1 {
+			topology = configv1.SingleReplicaTopologyMode
+		}
+		b.priceMachinePool(estimate, ic, ic.ControlPlane, topology)
+	}
+	for i := range ic.Compute {
+		b.priceMachinePool(estimate, ic, &ic.Compute[i], configv1.HighlyAvailableTopologyMode)
+	}
+
+	b.priceLoadBalancers(estimate, ic)
+
+	// The installer creates a NAT gateway in each zone of the VPC it creates.
+	if len(ic.Platform.AWS.Subnets) == 0 {
+		mpool := awstypes.MachinePool{}
+		mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
+		if ic.ControlPlane != nil {
+			mpool.Set(ic.ControlPlane.Platform.AWS)
+		}
+		zones := len(mpool.Zones)
+		if zones == 0 {
+			zones = defaultZoneCount
+			estimate.Notes = append(estimate.Notes, fmt.Sprintf("the VPC is assumed to span %d zones", defaultZoneCount))
+		}
+		estimate.Items = append(estimate.Items, costestimate.Item{
+			Category:    costestimate.Network,
+			Description: fmt.Sprintf("%d x NAT gateway", zones),
+			Quantity:    float64(zones),
+			UnitPrice:   b.Prices.NATGateway * costestimate.HoursPerMonth,
+		})
+	}
+
+	return estimate, nil
+}
+
+// priceMachinePool adds the machines of the pool and their root volumes to
+// the estimate.
+func (b *Backend) priceMachinePool(estimate *costestimate.Estimate, ic *types.InstallConfig, pool *types.MachinePool, topology configv1.TopologyMode) {
+	replicas := costestimate.Replicas(pool)
+	if replicas == 0 {
+		return
+	}
+
+	volumeType := awstypes.VolumeTypeGp3
+	// The edge pools default to gp2, which all the Local Zones offer.
+	if pool.Name == types.MachinePoolEdgeRoleName {
+		volumeType = awstypes.VolumeTypeGp2
+	}
+	mpool := awstypes.MachinePool{
+		EC2RootVolume: awstypes.EC2RootVolume{
+			Type: volumeType,
+			Size: rootVolumeSize,
+		},
+	}
+	mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
+	mpool.Set(pool.Platform.AWS)
+	if mpool.InstanceType == "" {
+		mpool.InstanceType = awsdefaults.InstanceTypes(ic.Platform.AWS.Region, pool.Architecture, topology)[0]
+	}
+
+	description := fmt.Sprintf("%s: %d x %s", pool.Name, replicas, mpool.InstanceType)
+	if price, ok := b.Prices.Instances[mpool.InstanceType]; ok {
+		estimate.Items = append(estimate.Items, costestimate.Item{
+			Category:    costestimate.Compute,
+			Description: description,
+			Quantity:    float64(replicas),
+			UnitPrice:   price * costestimate.HoursPerMonth,
+		})
+	} else {
+		estimate.Unpriced = append(estimate.Unpriced, description)
+	}
+
+	description = fmt.Sprintf("%s: %d x %d GiB %s", pool.Name, replicas, mpool.EC2RootVolume.Size, mpool.EC2RootVolume.Type)
+	price, ok := b.Prices.Volumes[mpool.EC2RootVolume.Type]
+	if !ok {
+		estimate.Unpriced = append(estimate.Unpriced, description)
+		return
+	}
+	estimate.Items = append(estimate.Items, costestimate.Item{
+		Category:    costestimate.Storage,
+		Description: description,
+		Quantity:    float64(replicas) * float64(mpool.EC2RootVolume.Size),
+		UnitPrice:   price,
+	})
+
+	iops := mpool.EC2RootVolume.IOPS
+	if mpool.EC2RootVolume.Type == awstypes.VolumeTypeGp3 {
+		iops -= gp3BaselineIOPS
+	}
+	if price, ok := b.Prices.ProvisionedIOPS[mpool.EC2RootVolume.Type]; ok && iops > 0 {
+		estimate.Items = append(estimate.Items, costestimate.Item{
+			Category:    costestimate.Storage,
+			Description: fmt.Sprintf("%s: %d x %d provisioned IOPS", pool.Name, replicas, iops),
+			Quantity:    float64(replicas) * float64(iops),
+			UnitPrice:   price,
+		})
+	}
+}
+
+// priceLoadBalancers adds the Network Load Balancers of the API and the load
+// balancer the ingress operator creates for the default ingress controller.
+func (b *Backend) priceLoadBalancers(estimate *costestimate.Estimate, ic *types.InstallConfig) {
+	apiLoadBalancers := 1
+	description := "API: 1 x internal Network Load Balancer"
+	if ic.Publish != types.InternalPublishingStrategy {
+		apiLoadBalancers = 2
+		description = "API: 2 x Network Load Balancer, external and internal"
+	}
+	estimate.Items = append(estimate.Items, costestimate.Item{
+		Category:    costestimate.LoadBalancer,
+		Description: description,
+		Quantity:    float64(apiLoadBalancers),
+		UnitPrice:   b.Prices.NetworkLoadBalancer * costestimate.HoursPerMonth,
+	})
+
+	item := costestimate.Item{
+		Category:    costestimate.LoadBalancer,
+		Description: "ingress: 1 x Classic Load Balancer",
+		Quantity:    1,
+		UnitPrice:   b.Prices.ClassicLoadBalancer * costestimate.HoursPerMonth,
+	}
+	if ic.Platform.AWS.LBType == configv1.NLB {
+		item.Description = "ingress: 1 x Network Load Balancer"
+		item.UnitPrice = b.Prices.NetworkLoadBalancer * costestimate.HoursPerMonth
+	}
+	estimate.Items = append(estimate.Items, item)
+}
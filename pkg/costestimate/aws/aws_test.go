@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/costestimate"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		name     string
+		edit     func(ic *types.InstallConfig)
+		items    []string
+		unpriced []string
+		notes    []string
+		total    float64
+	}{
+		{
+			name: "defaults",
+			items: []string{
+				"master: 3 x m6i.xlarge",
+				"master: 3 x 120 GiB gp3",
+				"worker: 3 x m6i.xlarge",
+				"worker: 3 x 120 GiB gp3",
+				"API: 2 x Network Load Balancer, external and internal",
+				"ingress: 1 x Classic Load Balancer",
+				"3 x NAT gateway",
+			},
+			notes: []string{
+				"data transfer and the temporary bootstrap machine are not included",
+				"the VPC is assumed to span 3 zones",
+			},
+			total: 1048.21,
+		},
+		{
+			name: "internal cluster in existing subnets",
+			edit: func(ic *types.InstallConfig) {
+				ic.Publish = types.InternalPublishingStrategy
+				ic.Platform.AWS.Region = "eu-west-1"
+				ic.Platform.AWS.Subnets = []string{"subnet-1", "subnet-2"}
+				ic.Compute[0].Platform.AWS = &awstypes.MachinePool{
+					InstanceType: "x2iedn.xlarge",
+					EC2RootVolume: awstypes.EC2RootVolume{
+						Type: "io1",
+						Size: 200,
+						IOPS: 2000,
+					},
+				}
+			},
+			items: []string{
+				"master: 3 x m6i.xlarge",
+				"master: 3 x 120 GiB gp3",
+				"worker: 3 x 200 GiB io1",
+				"worker: 3 x 2000 provisioned IOPS",
+				"API: 1 x internal Network Load Balancer",
+				"ingress: 1 x Classic Load Balancer",
+			},
+			unpriced: []string{"worker: 3 x x2iedn.xlarge"},
+			notes: []string{
+				"the resources are priced with the list prices of us-east-1, which may differ from the ones of eu-west-1",
+				"data transfer and the temporary bootstrap machine are not included",
+			},
+			total: 420.48 + 28.8 + 75 + 390 + 16.425 + 18.25,
+		},
+		{
+			name: "single node",
+			edit: func(ic *types.InstallConfig) {
+				ic.ControlPlane.Replicas = pointer.Int64(1)
+				ic.Compute[0].Replicas = pointer.Int64(0)
+				ic.Platform.AWS.LBType = "NLB"
+				ic.ControlPlane.Platform.AWS = &awstypes.MachinePool{Zones: []string{"us-east-1a"}}
+			},
+			items: []string{
+				"master: 1 x m6i.2xlarge",
+				"master: 1 x 120 GiB gp3",
+				"API: 2 x Network Load Balancer, external and internal",
+				"ingress: 1 x Network Load Balancer",
+				"1 x NAT gateway",
+			},
+			notes: []string{
+				"data transfer and the temporary bootstrap machine are not included",
+			},
+			total: 280.32 + 9.6 + 32.85 + 16.425 + 32.85,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Publish: types.ExternalPublishingStrategy,
+				ControlPlane: &types.MachinePool{
+					Name:     types.MachinePoolControlPlaneRoleName,
+					Replicas: pointer.Int64(3),
+				},
+				Compute: []types.MachinePool{{
+					Name:     types.MachinePoolComputeRoleName,
+					Replicas: pointer.Int64(3),
+				}},
+				Platform: types.Platform{
+					AWS: &awstypes.Platform{Region: "us-east-1"},
+				},
+			}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+
+			backend := &Backend{Prices: USEast1Prices}
+			estimate, err := backend.Estimate(context.Background(), ic)
+			if !assert.NoError(t, err) {
+				return
+			}
+			items := []string{}
+			for _, item := range estimate.Items {
+				items = append(items, item.Description)
+			}
+			assert.Equal(t, tc.items, items)
+			assert.Equal(t, tc.unpriced, estimate.Unpriced)
+			assert.Equal(t, tc.notes, estimate.Notes)
+			assert.InDelta(t, tc.total, estimate.Total(), 0.001)
+			assert.Equal(t, "USD", estimate.Currency)
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	assert.Contains(t, costestimate.Registry, awstypes.Name)
+}
@@ -0,0 +1,10 @@
+package aws
+
+import (
+	"github.com/openshift/installer/pkg/costestimate"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func init() {
+	costestimate.Registry[awstypes.Name] = &Backend{Prices: USEast1Prices}
+}
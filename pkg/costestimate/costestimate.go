@@ -0,0 +1,113 @@
+package costestimate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// HoursPerMonth is the number of hours of an average month, which converts
+// hourly list prices to monthly ones.
+const HoursPerMonth = 730
+
+// Category groups the items of an estimate.
+type Category string
+
+const (
+	// Compute is the category of the machines.
+	Compute Category = "compute"
+	// Storage is the category of the disks of the machines.
+	Storage Category = "storage"
+	// LoadBalancer is the category of the load balancers of the API and of
+	// the ingress.
+	LoadBalancer Category = "load balancer"
+	// Network is the category of the other network resources, such as NAT
+	// gateways.
+	Network Category = "network"
+)
+
+// Item is a priced resource of the cluster.
+type Item struct {
+	Category    Category
+	Description string
+	// Quantity is the number of units of the resource, e.g. machines or GiB.
+	Quantity float64
+	// UnitPrice is the monthly list price of a unit of the resource.
+	UnitPrice float64
+}
+
+// MonthlyCost returns the monthly cost of the item.
+func (i Item) MonthlyCost() float64 {
+	return i.Quantity * i.UnitPrice
+}
+
+// Estimate is the estimated monthly cost of a cluster.
+type Estimate struct {
+	Currency string
+	Items    []Item
+	// Unpriced lists the resources of the cluster which have no known list
+	// price, and which are therefore missing from the total.
+	Unpriced []string
+	// Notes describe the assumptions of the estimate.
+	Notes []string
+}
+
+// Total returns the monthly cost of the priced items.
+func (e *Estimate) Total() float64 {
+	total := 0.0
+	for _, item := range e.Items {
+		total += item.MonthlyCost()
+	}
+	return total
+}
+
+// Print writes a summary of the estimate to w.
+func (e *Estimate) Print(w io.Writer) error {
+	fmt.Fprintf(w, "Estimated monthly cost in %s, from list prices before taxes and discounts:\n", e.Currency)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, item := range e.Items {
+		fmt.Fprintf(tw, "  %s\t%s\t%10.2f\n", item.Category, item.Description, item.MonthlyCost())
+	}
+	fmt.Fprintf(tw, "  total\t\t%10.2f\n", e.Total())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, resource := range e.Unpriced {
+		fmt.Fprintf(w, "Not priced: %s\n", resource)
+	}
+	for _, note := range e.Notes {
+		fmt.Fprintf(w, "Note: %s\n", note)
+	}
+	return nil
+}
+
+// Backend prices the resources of the install configs of a platform.
+type Backend interface {
+	Estimate(ctx context.Context, ic *types.InstallConfig) (*Estimate, error)
+}
+
+// Registry maps the platform names to their pricing backends.
+var Registry = make(map[string]Backend)
+
+// New returns the estimated monthly cost of the cluster of the install config.
+func New(ctx context.Context, ic *types.InstallConfig) (*Estimate, error) {
+	platform := ic.Platform.Name()
+	backend, ok := Registry[platform]
+	if !ok {
+		return nil, errors.Errorf("no cost estimate for platform %q", platform)
+	}
+	return backend.Estimate(ctx, ic)
+}
+
+// Replicas returns the number of machines of the pool.
+func Replicas(pool *types.MachinePool) int64 {
+	if pool == nil || pool.Replicas == nil {
+		return 0
+	}
+	return *pool.Replicas
+}
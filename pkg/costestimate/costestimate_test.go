@@ -0,0 +1,41 @@
+package costestimate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestEstimatePrint(t *testing.T) {
+	estimate := &Estimate{
+		Currency: "USD",
+		Items: []Item{
+			{Category: Compute, Description: "master: 3 x m6i.xlarge", Quantity: 3, UnitPrice: 140.16},
+			{Category: Storage, Description: "master: 3 x 120 GiB gp3", Quantity: 360, UnitPrice: 0.08},
+		},
+		Unpriced: []string{"worker: 3 x x2iedn.xlarge"},
+		Notes:    []string{"data transfer is not included"},
+	}
+	assert.InDelta(t, 449.28, estimate.Total(), 0.001)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, estimate.Print(buf))
+	assert.Equal(t, `Estimated monthly cost in USD, from list prices before taxes and discounts:
+  compute  master: 3 x m6i.xlarge       420.48
+  storage  master: 3 x 120 GiB gp3       28.80
+  total                                 449.28
+Not priced: worker: 3 x x2iedn.xlarge
+Note: data transfer is not included
+`, buf.String())
+}
+
+func TestNewUnknownPlatform(t *testing.T) {
+	ic := &types.InstallConfig{Platform: types.Platform{None: &none.Platform{}}}
+	_, err := New(context.Background(), ic)
+	assert.EqualError(t, err, `no cost estimate for platform "none"`)
+}
@@ -0,0 +1,5 @@
+// Package costestimate estimates the monthly cost of a cluster from the list
+// prices of the machines, storage and load balancers requested by its install
+// config. The prices come from per-platform backends, which register
+// themselves in Registry.
+package costestimate
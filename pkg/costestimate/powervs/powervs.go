@@ -0,0 +1,189 @@
+// Package powervs prices the resources of Power VS clusters.
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/openshift/installer/pkg/costestimate"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+const (
+	// bootVolumeSize is the size of the boot volumes of the machines, in GiB.
+	bootVolumeSize = 120
+	// bootVolumeTier is the storage tier of the boot volumes.
+	bootVolumeTier = "tier1"
+)
+
+// SystemPrices are the hourly list prices of a system type.
+type SystemPrices struct {
+	// Cores are the prices of a core of the processor types.
+	Cores map[machinev1.PowerVSProcessorType]float64
+	// MemoryGiB is the price of a GiB of memory.
+	MemoryGiB float64
+}
+
+// Prices are the list prices of the Power VS resources, in USD.
+type Prices struct {
+	// Systems are the prices of the system types.
+	Systems map[string]SystemPrices
+	// StorageTiers are the monthly prices of a GiB of the storage tiers.
+	StorageTiers map[string]float64
+	// LoadBalancer is the hourly price of a VPC load balancer.
+	LoadBalancer float64
+}
+
+// ListPrices are the list prices of the Power VS workspaces and of the VPC
+// load balancers in Dallas.
+var ListPrices = Prices{
+	Systems: map[string]SystemPrices{
+		"s922": {
+			Cores: map[machinev1.PowerVSProcessorType]float64{
+				machinev1.PowerVSProcessorTypeShared:    0.1543,
+				machinev1.PowerVSProcessorTypeCapped:    0.1543,
+				machinev1.PowerVSProcessorTypeDedicated: 0.2315,
+			},
+			MemoryGiB: 0.0117,
+		},
+		"s1022": {
+			Cores: map[machinev1.PowerVSProcessorType]float64{
+				machinev1.PowerVSProcessorTypeShared:    0.1453,
+				machinev1.PowerVSProcessorTypeCapped:    0.1453,
+				machinev1.PowerVSProcessorTypeDedicated: 0.2180,
+			},
+			MemoryGiB: 0.0117,
+		},
+		"e980": {
+			Cores: map[machinev1.PowerVSProcessorType]float64{
+				machinev1.PowerVSProcessorTypeShared:    0.4395,
+				machinev1.PowerVSProcessorTypeCapped:    0.4395,
+				machinev1.PowerVSProcessorTypeDedicated: 0.6593,
+			},
+			MemoryGiB: 0.0194,
+		},
+		"e1080": {
+			Cores: map[machinev1.PowerVSProcessorType]float64{
+				machinev1.PowerVSProcessorTypeShared:    0.4614,
+				machinev1.PowerVSProcessorTypeCapped:    0.4614,
+				machinev1.PowerVSProcessorTypeDedicated: 0.6921,
+			},
+			MemoryGiB: 0.0204,
+		},
+	},
+	StorageTiers: map[string]float64{
+		"tier0": 0.24,
+		"tier1": 0.20,
+		"tier3": 0.10,
+	},
+	LoadBalancer: 0.025,
+}
+
+// Backend prices the resources of Power VS clusters from static list prices.
+type Backend struct {
+	Prices Prices
+}
+
+var _ costestimate.Backend = (*Backend)(nil)
+
+// Estimate returns the estimated monthly cost of the cluster of the install
+// config.
+func (b *Backend) Estimate(ctx context.Context, ic *types.InstallConfig) (*costestimate.Estimate, error) {
+	estimate := &costestimate.Estimate{
+		Currency: "USD",
+		Notes: []string{
+			"the resources are priced with the list prices of Dallas, which may differ from the ones of other regions",
+			"the Cloud Connection or Transit Gateway, data transfer and the temporary bootstrap machine are not included",
+		},
+	}
+
+	if ic.ControlPlane != nil {
+		if err := b.priceMachinePool(estimate, ic, ic.ControlPlane); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ic.Compute {
+		if err := b.priceMachinePool(estimate, ic, &ic.Compute[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// The installer creates a public and a private load balancer for the
+	// API in the VPC, and the cloud controller manager one for the ingress.
+	loadBalancers := 3
+	description := "API and ingress: 3 x VPC load balancer"
+	if ic.Publish == types.InternalPublishingStrategy {
+		loadBalancers = 2
+		description = "API and ingress: 2 x private VPC load balancer"
+	}
+	estimate.Items = append(estimate.Items, costestimate.Item{
+		Category:    costestimate.LoadBalancer,
+		Description: description,
+		Quantity:    float64(loadBalancers),
+		UnitPrice:   b.Prices.LoadBalancer * costestimate.HoursPerMonth,
+	})
+
+	return estimate, nil
+}
+
+// priceMachinePool adds the cores and the memory of the machines of the pool
+// and their boot volumes to the estimate.
+func (b *Backend) priceMachinePool(estimate *costestimate.Estimate, ic *types.InstallConfig, pool *types.MachinePool) error {
+	replicas := costestimate.Replicas(pool)
+	if replicas == 0 {
+		return nil
+	}
+
+	// The defaults of the machines of the installer.
+	mpool := powervstypes.MachinePool{
+		MemoryGiB:  32,
+		Processors: intstr.FromString("0.5"),
+		ProcType:   machinev1.PowerVSProcessorTypeShared,
+		SysType:    "s922",
+	}
+	mpool.Set(ic.Platform.PowerVS.DefaultMachinePlatform)
+	mpool.Set(pool.Platform.PowerVS)
+
+	processors, err := processorCount(mpool.Processors)
+	if err != nil {
+		return errors.Wrapf(err, "invalid processors of machine pool %s", pool.Name)
+	}
+
+	description := fmt.Sprintf("%s: %d x %s, %g %s cores, %d GiB", pool.Name, replicas, mpool.SysType, processors, mpool.ProcType, mpool.MemoryGiB)
+	system, ok := b.Prices.Systems[mpool.SysType]
+	corePrice, coreOK := system.Cores[mpool.ProcType]
+	if ok && coreOK {
+		machinePrice := processors*corePrice + float64(mpool.MemoryGiB)*system.MemoryGiB
+		estimate.Items = append(estimate.Items, costestimate.Item{
+			Category:    costestimate.Compute,
+			Description: description,
+			Quantity:    float64(replicas),
+			UnitPrice:   machinePrice * costestimate.HoursPerMonth,
+		})
+	} else {
+		estimate.Unpriced = append(estimate.Unpriced, description)
+	}
+
+	estimate.Items = append(estimate.Items, costestimate.Item{
+		Category:    costestimate.Storage,
+		Description: fmt.Sprintf("%s: %d x %d GiB %s boot volume", pool.Name, replicas, bootVolumeSize, bootVolumeTier),
+		Quantity:    float64(replicas) * bootVolumeSize,
+		UnitPrice:   b.Prices.StorageTiers[bootVolumeTier],
+	})
+	return nil
+}
+
+// processorCount returns the number of cores of the processors, which are
+// either an integer or a decimal string such as "0.5".
+func processorCount(processors intstr.IntOrString) (float64, error) {
+	if processors.Type == intstr.Int {
+		return float64(processors.IntVal), nil
+	}
+	return strconv.ParseFloat(processors.StrVal, 64)
+}
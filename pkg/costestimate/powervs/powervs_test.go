@@ -0,0 +1,102 @@
+package powervs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		name     string
+		edit     func(ic *types.InstallConfig)
+		items    []string
+		unpriced []string
+		total    float64
+		err      string
+	}{
+		{
+			name: "defaults",
+			items: []string{
+				"master: 3 x s922, 0.5 Shared cores, 32 GiB",
+				"master: 3 x 120 GiB tier1 boot volume",
+				"worker: 3 x s922, 0.5 Shared cores, 32 GiB",
+				"worker: 3 x 120 GiB tier1 boot volume",
+				"API and ingress: 3 x VPC load balancer",
+			},
+			total: 2176.539,
+		},
+		{
+			name: "dedicated workers and unknown system type",
+			edit: func(ic *types.InstallConfig) {
+				ic.Publish = types.InternalPublishingStrategy
+				ic.Platform.PowerVS.DefaultMachinePlatform = &powervstypes.MachinePool{
+					Processors: intstr.FromInt(2),
+					ProcType:   machinev1.PowerVSProcessorTypeDedicated,
+					MemoryGiB:  64,
+				}
+				ic.ControlPlane.Platform.PowerVS = &powervstypes.MachinePool{SysType: "s999"}
+			},
+			items: []string{
+				"master: 3 x 120 GiB tier1 boot volume",
+				"worker: 3 x s922, 2 Dedicated cores, 64 GiB",
+				"worker: 3 x 120 GiB tier1 boot volume",
+				"API and ingress: 2 x private VPC load balancer",
+			},
+			unpriced: []string{"master: 3 x s999, 2 Dedicated cores, 64 GiB"},
+			total:    72 + 3*(2*0.2315+64*0.0117)*730 + 72 + 36.5,
+		},
+		{
+			name: "invalid processors",
+			edit: func(ic *types.InstallConfig) {
+				ic.Compute[0].Platform.PowerVS = &powervstypes.MachinePool{Processors: intstr.FromString("half")}
+			},
+			err: `^invalid processors of machine pool worker: strconv.ParseFloat: parsing "half": invalid syntax$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				Publish: types.ExternalPublishingStrategy,
+				ControlPlane: &types.MachinePool{
+					Name:     types.MachinePoolControlPlaneRoleName,
+					Replicas: pointer.Int64(3),
+				},
+				Compute: []types.MachinePool{{
+					Name:     types.MachinePoolComputeRoleName,
+					Replicas: pointer.Int64(3),
+				}},
+				Platform: types.Platform{
+					PowerVS: &powervstypes.Platform{Region: "dal", Zone: "dal10"},
+				},
+			}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+
+			backend := &Backend{Prices: ListPrices}
+			estimate, err := backend.Estimate(context.Background(), ic)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			items := []string{}
+			for _, item := range estimate.Items {
+				items = append(items, item.Description)
+			}
+			assert.Equal(t, tc.items, items)
+			assert.Equal(t, tc.unpriced, estimate.Unpriced)
+			assert.InDelta(t, tc.total, estimate.Total(), 0.001)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package powervs
+
+import (
+	"github.com/openshift/installer/pkg/costestimate"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+func init() {
+	costestimate.Registry[powervstypes.Name] = &Backend{Prices: ListPrices}
+}
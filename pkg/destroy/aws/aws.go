@@ -117,37 +117,11 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 		return nil, err
 	}
 
-	awsSession := o.Session
-	if awsSession == nil {
-		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
-		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
-		if err != nil {
-			return nil, err
-		}
-	}
-	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
-		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
-		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
-	})
-
-	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
-		resourcegroupstaggingapi.New(awsSession),
-	}
-
-	switch o.Region {
-	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
-		break
-	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
-		if o.Region != endpoints.UsGovWest1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
-		}
-	default:
-		if o.Region != endpoints.UsEast1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
-		}
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
 	}
+	tagClients := o.tagClients(awsSession)
 
 	iamClient := iam.New(awsSession)
 	iamRoleSearch := &iamRoleSearch{
@@ -257,6 +231,105 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	return nil, nil
 }
 
+// ListResources returns the resources which destroying the cluster would
+// delete, without deleting any of them.
+func (o *ClusterUninstaller) ListResources() ([]providers.Resource, error) {
+	ctx := context.Background()
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
+	}
+
+	iamClient := iam.New(awsSession)
+	deleted := sets.NewString()
+	resources, _, err := o.findResourcesToDelete(
+		ctx,
+		o.tagClients(awsSession),
+		iamClient,
+		&iamRoleSearch{client: iamClient, filters: o.Filters, logger: o.Logger},
+		&iamUserSearch{client: iamClient, filters: o.Filters, logger: o.Logger},
+		deleted,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find resources")
+	}
+	instancesRunning, instancesNotTerminated, err := findEC2Instances(ctx, ec2.New(awsSession), deleted, o.Filters, o.Logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find EC2 instances")
+	}
+	resources.Insert(instancesRunning...)
+	resources.Insert(instancesNotTerminated...)
+
+	list := make([]providers.Resource, 0, len(resources))
+	for _, arnString := range resources.List() {
+		list = append(list, arnResource(arnString))
+	}
+	return list, nil
+}
+
+// arnResource returns the resource of the ARN, whose type is the service and
+// the type of the resource, e.g. ec2:instance, when the ARN has one.
+func arnResource(arnString string) providers.Resource {
+	resource := providers.Resource{ID: arnString}
+	parsed, err := arn.Parse(arnString)
+	if err != nil {
+		return resource
+	}
+	resource.Type = parsed.Service
+	if resourceType, id, err := splitSlash("resource", parsed.Resource); err == nil {
+		resource.Type = fmt.Sprintf("%s:%s", parsed.Service, resourceType)
+		resource.Name = id
+	}
+	return resource
+}
+
+// session returns the session of the uninstaller, or a new one based on the
+// usual credential configuration.
+func (o *ClusterUninstaller) session() (*session.Session, error) {
+	awsSession := o.Session
+	if awsSession == nil {
+		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
+		var err error
+		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
+		if err != nil {
+			return nil, err
+		}
+	}
+	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
+		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
+	})
+	return awsSession, nil
+}
+
+// tagClients returns the clients of the tagging API of the cluster region and
+// of the region of the global resources of the partition.
+func (o *ClusterUninstaller) tagClients(awsSession *session.Session) []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI {
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
+		resourcegroupstaggingapi.New(awsSession),
+	}
+
+	switch o.Region {
+	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
+		break
+	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
+		if o.Region != endpoints.UsGovWest1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
+		}
+	default:
+		if o.Region != endpoints.UsEast1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
+		}
+	}
+	return tagClients
+}
+
 // findResourcesToDelete returns the resources that should be deleted.
 //
 //	tagClients - clients of the tagging API to use to search for resources.
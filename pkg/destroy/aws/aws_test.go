@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+func TestARNResource(t *testing.T) {
+	cases := []struct {
+		name     string
+		arn      string
+		expected providers.Resource
+	}{
+		{
+			name:     "resource with a type",
+			arn:      "arn:aws:ec2:us-east-1:123456789012:instance/i-0123",
+			expected: providers.Resource{Type: "ec2:instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123", Name: "i-0123"},
+		},
+		{
+			name:     "resource without a type",
+			arn:      "arn:aws:s3:::ostest-x7k2p-bootstrap",
+			expected: providers.Resource{Type: "s3", ID: "arn:aws:s3:::ostest-x7k2p-bootstrap"},
+		},
+		{
+			name:     "resource with a path",
+			arn:      "arn:aws:iam::123456789012:role/path/ostest-x7k2p-master-role",
+			expected: providers.Resource{Type: "iam:role", ID: "arn:aws:iam::123456789012:role/path/ostest-x7k2p-master-role", Name: "path/ostest-x7k2p-master-role"},
+		},
+		{
+			name:     "not an ARN",
+			arn:      "i-0123",
+			expected: providers.Resource{ID: "i-0123"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, arnResource(tc.arn))
+		})
+	}
+}
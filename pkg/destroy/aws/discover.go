@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// Discover returns the metadata of the cluster with the infra ID in the
+// region. The destroyer finds the resources of the cluster by their
+// kubernetes.io/cluster/<infra ID> tag, so the metadata only has that tag and
+// the cluster domain, which is the name of the hosted zone owned by the
+// cluster and is needed to clean the records of a shared hosted zone. The
+// hosted zones tagged shared are provided by the user, and may hold the
+// records of other clusters, so they never give the cluster domain.
+func Discover(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	awsSession, err := awssession.GetSessionWithOptions(awssession.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+
+	o := &ClusterUninstaller{Region: region, Logger: logger, ClusterID: infraID}
+	key := fmt.Sprintf("kubernetes.io/cluster/%s", infraID)
+	var tagged int
+	var hostedZoneID string
+	for _, tagClient := range o.tagClients(awsSession) {
+		logger.Debugf("Search for resources in %s tagged %s", *tagClient.Config.Region, key)
+		err := tagClient.GetResourcesPagesWithContext(
+			ctx,
+			&resourcegroupstaggingapi.GetResourcesInput{TagFilters: []*resourcegroupstaggingapi.TagFilter{{Key: aws.String(key)}}},
+			func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+				for _, resource := range results.ResourceTagMappingList {
+					tagged++
+					parsed, err := arn.Parse(aws.StringValue(resource.ResourceARN))
+					if err != nil {
+						continue
+					}
+					if resourceType, id, err := splitSlash("resource", parsed.Resource); err == nil && parsed.Service == "route53" && resourceType == "hostedzone" && tagValue(resource.Tags, key) == "owned" {
+						hostedZoneID = id
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to search for resources in %s tagged %s", *tagClient.Config.Region, key)
+		}
+	}
+	if tagged == 0 {
+		logger.Warnf("No resources tagged %s were found in %s, only the IAM resources of the cluster may be destroyed", key, region)
+	}
+	logger.Warnf("The discovered metadata only matches the resources tagged %s=owned; resources only tagged with the openshiftClusterID of the cluster are not destroyed", key)
+	if hostedZoneID == "" {
+		logger.Warnf("No hosted zone owned by the cluster was found, the records of the cluster in the shared hosted zones are not destroyed")
+	}
+
+	metadata := &awstypes.Metadata{
+		Region:     region,
+		Identifier: []map[string]string{{key: "owned"}},
+	}
+	if hostedZoneID != "" {
+		zone, err := route53.New(awsSession).GetHostedZoneWithContext(ctx, &route53.GetHostedZoneInput{Id: aws.String(hostedZoneID)})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the hosted zone %s", hostedZoneID)
+		}
+		metadata.ClusterDomain = strings.TrimSuffix(aws.StringValue(zone.HostedZone.Name), ".")
+	}
+	return &types.ClusterMetadata{
+		InfraID:                 infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: metadata},
+	}, nil
+}
+
+// tagValue returns the value of the tag with the key, or "" if there is none.
+func tagValue(tags []*resourcegroupstaggingapi.Tag, key string) string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
@@ -50,6 +50,7 @@ type ClusterUninstaller struct {
 	Logger logrus.FieldLogger
 
 	resourceGroupsClient    resources.GroupsClient
+	resourcesClient         resources.Client
 	zonesClient             dns.ZonesClient
 	recordsClient           dns.RecordSetsClient
 	privateRecordSetsClient privatedns.RecordSetsClient
@@ -61,6 +62,9 @@ func (o *ClusterUninstaller) configureClients() error {
 	o.resourceGroupsClient = resources.NewGroupsClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
 	o.resourceGroupsClient.Authorizer = o.Authorizer
 
+	o.resourcesClient = resources.NewClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.resourcesClient.Authorizer = o.Authorizer
+
 	o.zonesClient = dns.NewZonesClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
 	o.zonesClient.Authorizer = o.Authorizer
 
@@ -229,6 +233,75 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 	return nil, utilerrors.NewAggregate(errs)
 }
 
+// ListResources returns the resource group of the cluster, the resources in
+// it and the application registrations of the cluster, which destroying the
+// cluster would delete. The records of the cluster in the public DNS zone are
+// not listed.
+func (o *ClusterUninstaller) ListResources() ([]providers.Resource, error) {
+	if err := o.configureClients(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	group, err := o.resourceGroupsClient.Get(ctx, o.ResourceGroupName)
+	if err != nil {
+		if wasNotFound(group.Response.Response) {
+			o.Logger.Debugf("resource group %s does not exist", o.ResourceGroupName)
+			return o.listApplicationRegistrations(ctx)
+		}
+		return nil, errors.Wrapf(err, "failed to get resource group %s", o.ResourceGroupName)
+	}
+	list := []providers.Resource{{
+		Type: "Microsoft.Resources/resourceGroups",
+		ID:   to.String(group.ID),
+		Name: to.String(group.Name),
+	}}
+
+	iter, err := o.resourcesClient.ListByResourceGroupComplete(ctx, o.ResourceGroupName, "", "", nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the resources of resource group %s", o.ResourceGroupName)
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the resources of resource group %s", o.ResourceGroupName)
+		}
+		resource := iter.Value()
+		list = append(list, providers.Resource{
+			Type: to.String(resource.Type),
+			ID:   to.String(resource.ID),
+			Name: to.String(resource.Name),
+		})
+	}
+
+	registrations, err := o.listApplicationRegistrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(list, registrations...), nil
+}
+
+// listApplicationRegistrations returns the service principals of the
+// application registrations of the cluster.
+func (o *ClusterUninstaller) listApplicationRegistrations(ctx context.Context) ([]providers.Resource, error) {
+	tag := fmt.Sprintf("kubernetes.io_cluster.%s=owned", o.InfraID)
+	servicePrincipals, err := getServicePrincipalsByTag(ctx, o.msgraphClient, tag, o.InfraID)
+	if err != nil {
+		return nil, errors.Wrap(extractODataError(err), "failed to gather list of Service Principals by tag")
+	}
+
+	list := make([]providers.Resource, 0, len(servicePrincipals))
+	for _, sp := range servicePrincipals {
+		list = append(list, providers.Resource{
+			Type: "Microsoft.Graph/applications",
+			ID:   to.String(sp.GetAppId()),
+			Name: to.String(sp.GetDisplayName()),
+		})
+	}
+	return list, nil
+}
+
 func deleteAzureStackPublicRecords(ctx context.Context, o *ClusterUninstaller) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+// Discover returns the metadata of the cluster with the infra ID in the
+// region of the public cloud. The resource group of the cluster is the
+// resource group tagged kubernetes.io_cluster.<infra ID>: owned, both when it
+// was created by the installer and when it was provided by the user.
+func Discover(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	session, err := azuresession.GetSession(azure.PublicCloud, "")
+	if err != nil {
+		return nil, err
+	}
+	client := resources.NewGroupsClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	tag := fmt.Sprintf("kubernetes.io_cluster.%s", infraID)
+	logger.Debugf("Search for resource groups tagged %s: owned", tag)
+	groups, err := client.ListComplete(ctx, fmt.Sprintf("tagName eq '%s' and tagValue eq 'owned'", tag), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the resource groups tagged %s", tag)
+	}
+	var names []string
+	for groups.NotDone() {
+		group := groups.Value()
+		if group.Location != nil && !strings.EqualFold(*group.Location, region) {
+			logger.Debugf("Skipping the resource group %s in %s", to.String(group.Name), *group.Location)
+		} else {
+			names = append(names, to.String(group.Name))
+		}
+		if err := groups.NextWithContext(ctx); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the resource groups tagged %s", tag)
+		}
+	}
+
+	group := infraID + "-rg"
+	switch len(names) {
+	case 0:
+		return nil, errors.Errorf("no resource group in %s is tagged %s: owned", region, tag)
+	case 1:
+		group = names[0]
+	default:
+		found := false
+		for _, name := range names {
+			found = found || name == group
+		}
+		if !found {
+			return nil, errors.Errorf("more than one resource group in %s is tagged %s: owned (%s)", region, tag, strings.Join(names, ", "))
+		}
+	}
+	logger.Infof("Found the resource group %s of the cluster", group)
+
+	return &types.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{Azure: &azure.Metadata{
+			CloudName:         azure.PublicCloud,
+			Region:            region,
+			ResourceGroupName: group,
+		}},
+	}, nil
+}
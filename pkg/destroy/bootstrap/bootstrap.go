@@ -2,23 +2,58 @@
 package bootstrap
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openshift/installer/pkg/asset/cluster"
 	openstackasset "github.com/openshift/installer/pkg/asset/cluster/openstack"
 	osp "github.com/openshift/installer/pkg/destroy/openstack"
 	"github.com/openshift/installer/pkg/terraform"
 	platformstages "github.com/openshift/installer/pkg/terraform/stages/platform"
+	"github.com/openshift/installer/pkg/types"
 	typesazure "github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/openstack"
+	typespowervs "github.com/openshift/installer/pkg/types/powervs"
 )
 
-// Destroy uses Terraform to remove bootstrap resources.
-func Destroy(dir string) (err error) {
+// metadataVarsFileName is the name of the Terraform variables file with the
+// resource IDs re-derived from the cluster metadata.
+const metadataVarsFileName = "terraform.metadata.auto.tfvars.json"
+
+// notFoundRx matches the errors of the cloud APIs for resources which do not
+// exist (anymore).
+var notFoundRx = regexp.MustCompile(`(?i)\b404\b|notfound\b|\bresources? not found\b|could not be found|no longer exists`)
+
+// diagnosticRx matches the start of the diagnostics of the errors of
+// Terraform, with or without the box drawing of Terraform 1.0 and later.
+var diagnosticRx = regexp.MustCompile(`(?m)^\W*Error: `)
+
+// diagnosticResourceRx matches the address of the resource of a diagnostic of
+// Terraform.
+var diagnosticResourceRx = regexp.MustCompile(`(?m)^\W*with ([^\s,]+),`)
+
+// Options are the options for destroying the bootstrap resources.
+type Options struct {
+	// Force skips the stages whose Terraform state or outputs are missing,
+	// and carries on with the remaining stages when a stage fails, instead of
+	// stopping. It allows recovering from a partially completed destroy.
+	Force bool
+}
+
+// Destroy uses Terraform to remove bootstrap resources. Resources which were
+// already removed, e.g. by a previous destroy which failed halfway, are not
+// an error.
+func Destroy(dir string, opts Options) (err error) {
 	metadata, err := cluster.LoadMetadata(dir)
 	if err != nil {
 		return err
@@ -36,7 +71,10 @@ func Destroy(dir string) (err error) {
 
 		imageName := metadata.InfraID + "-ignition"
 		if err := osp.DeleteGlanceImage(imageName, metadata.OpenStack.Cloud); err != nil {
-			return errors.Wrapf(err, "Failed to delete glance image %s", imageName)
+			if !opts.Force {
+				return errors.Wrapf(err, "Failed to delete glance image %s", imageName)
+			}
+			logrus.Warnf("Failed to delete glance image %s: %v", imageName, err)
 		}
 	}
 
@@ -52,7 +90,7 @@ func Destroy(dir string) (err error) {
 	}
 
 	terraformDir := filepath.Join(dir, "terraform")
-	if err := os.Mkdir(terraformDir, 0777); err != nil {
+	if err := os.MkdirAll(terraformDir, 0777); err != nil {
 		return errors.Wrap(err, "could not create the terraform directory")
 	}
 
@@ -64,6 +102,7 @@ func Destroy(dir string) (err error) {
 	defer os.RemoveAll(terraformDirPath)
 	terraform.UnpackTerraform(terraformDirPath, tfStages)
 
+	var errs []error
 	for i := len(tfStages) - 1; i >= 0; i-- {
 		stage := tfStages[i]
 
@@ -71,46 +110,225 @@ func Destroy(dir string) (err error) {
 			continue
 		}
 
-		tempDir, err := os.MkdirTemp("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
-		if err != nil {
-			return errors.Wrap(err, "failed to create temporary directory for Terraform execution")
+		err := destroyStage(dir, terraformDirPath, stage, varFiles, metadata, opts)
+		if err == nil {
+			continue
 		}
-		defer os.RemoveAll(tempDir)
+		if !opts.Force {
+			return err
+		}
+		logrus.Errorf("Failed to destroy the %s stage, carrying on with the remaining stages: %v", stage.Name(), err)
+		errs = append(errs, errors.Wrapf(err, "failed to destroy the %s stage", stage.Name()))
+	}
 
-		stateFilePathInInstallDir := filepath.Join(dir, stage.StateFilename())
-		stateFilePathInTempDir := filepath.Join(tempDir, terraform.StateFilename)
-		if err := copy(stateFilePathInInstallDir, stateFilePathInTempDir); err != nil {
-			return errors.Wrap(err, "failed to copy state file to the temporary directory")
+	return utilerrors.NewAggregate(errs)
+}
+
+// destroyStage destroys the resources of the stage in a temporary directory,
+// and copies the resulting Terraform state back to the install directory.
+func destroyStage(dir string, terraformDirPath string, stage terraform.Stage, varFiles []string, metadata *types.ClusterMetadata, opts Options) error {
+	stateFilePathInInstallDir := filepath.Join(dir, stage.StateFilename())
+	if _, err := os.Stat(stateFilePathInInstallDir); err != nil {
+		if opts.Force && os.IsNotExist(err) {
+			logrus.Warnf("Skipping the %s stage: no Terraform state found at %s", stage.Name(), stateFilePathInInstallDir)
+			return nil
+		}
+		if os.IsNotExist(err) {
+			return errors.Wrapf(err, "no Terraform state for the %s stage, use --force to skip it", stage.Name())
 		}
+		return errors.Wrap(err, "failed to read the state file")
+	}
+
+	tempDir, err := os.MkdirTemp("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory for Terraform execution")
+	}
+	defer os.RemoveAll(tempDir)
 
-		targetVarFiles := make([]string, 0, len(varFiles))
-		for _, filename := range varFiles {
-			sourcePath := filepath.Join(dir, filename)
-			targetPath := filepath.Join(tempDir, filename)
-			if err := copy(sourcePath, targetPath); err != nil {
+	stateFilePathInTempDir := filepath.Join(tempDir, terraform.StateFilename)
+	if err := copy(stateFilePathInInstallDir, stateFilePathInTempDir); err != nil {
+		return errors.Wrap(err, "failed to copy state file to the temporary directory")
+	}
+
+	targetVarFiles := make([]string, 0, len(varFiles)+1)
+	for _, filename := range varFiles {
+		sourcePath := filepath.Join(dir, filename)
+		targetPath := filepath.Join(tempDir, filename)
+		if err := copy(sourcePath, targetPath); err != nil {
+			if os.IsNotExist(err) && err.(*os.PathError).Path == sourcePath {
 				// platform may not need platform-specific Terraform variables
 				if filename == cluster.TfPlatformVarsFileName {
-					if os.IsNotExist(err) && err.(*os.PathError).Path == sourcePath {
-						continue
-					}
+					continue
+				}
+				if opts.Force {
+					logrus.Warnf("Skipping missing Terraform variables file %s", filename)
+					continue
 				}
-				return errors.Wrapf(err, "failed to copy %s to the temporary directory", filename)
 			}
-			targetVarFiles = append(targetVarFiles, targetPath)
+			return errors.Wrapf(err, "failed to copy %s to the temporary directory", filename)
 		}
+		targetVarFiles = append(targetVarFiles, targetPath)
+	}
+
+	metadataVarFile, err := writeMetadataVarFile(tempDir, metadata, targetVarFiles)
+	if err != nil {
+		return err
+	}
+	if metadataVarFile != "" {
+		targetVarFiles = append(targetVarFiles, metadataVarFile)
+	}
 
-		if err := stage.Destroy(tempDir, terraformDirPath, targetVarFiles); err != nil {
+	// The resources which were already removed fail their deletion, and
+	// block the deletion of the resources depending on them: they are
+	// removed from the state, and the destroy retried for the remaining
+	// resources, until it succeeds or fails for another reason.
+	removed := sets.NewString()
+	for {
+		err := stage.Destroy(tempDir, terraformDirPath, targetVarFiles)
+		if err == nil {
+			break
+		}
+		addresses := notFoundResources(err)
+		if len(addresses) == 0 || removed.HasAny(addresses...) {
 			return err
 		}
-
-		if err := copy(stateFilePathInTempDir, stateFilePathInInstallDir); err != nil {
-			return errors.Wrap(err, "failed to copy state file from the temporary directory")
+		logrus.Warnf("Resources of the %s stage were already removed: %s", stage.Name(), strings.Join(addresses, ", "))
+		if err := terraform.StateRm(tempDir, terraformDirPath, addresses...); err != nil {
+			return err
 		}
+		removed.Insert(addresses...)
+	}
+
+	if err := copy(stateFilePathInTempDir, stateFilePathInInstallDir); err != nil {
+		return errors.Wrap(err, "failed to copy state file from the temporary directory")
 	}
 
+	// Metadata written by older installers has no inventory to update.
+	if len(metadata.Resources) > 0 {
+		if err := recordResources(dir, metadata, stage.Name(), stateFilePathInInstallDir); err != nil {
+			logrus.Warnf("Failed to update the resources of the %s stage in the cluster metadata: %v", stage.Name(), err)
+		}
+	}
 	return nil
 }
 
+// recordResources updates the inventory of the resources of the stage in the
+// cluster metadata with its Terraform state.
+func recordResources(dir string, metadata *types.ClusterMetadata, stage string, stateFilePath string) error {
+	state, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return err
+	}
+	if err := cluster.RecordResources(metadata, stage, state); err != nil {
+		return err
+	}
+	return cluster.WriteMetadata(dir, metadata)
+}
+
+// isNotFound returns true if the message is about resources which do not
+// exist, which is the expected outcome of removing them.
+func isNotFound(message string) bool {
+	return notFoundRx.MatchString(message)
+}
+
+// notFoundResources returns the addresses of the resources whose deletion
+// failed because they do not exist, if that is the only reason the destroy
+// failed. It returns nil if any diagnostic of the error is about another
+// failure, or is not about a resource.
+func notFoundResources(err error) []string {
+	message := err.Error()
+	starts := diagnosticRx.FindAllStringIndex(message, -1)
+	if len(starts) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(starts))
+	for i, start := range starts {
+		end := len(message)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		diagnostic := message[start[0]:end]
+		resource := diagnosticResourceRx.FindStringSubmatch(diagnostic)
+		if resource == nil || !isNotFound(diagnostic) {
+			return nil
+		}
+		addresses = append(addresses, resource[1])
+	}
+	return sets.NewString(addresses...).List()
+}
+
+// metadataVars returns the Terraform variables identifying the resources of
+// the cluster which can be re-derived from the cluster metadata.
+func metadataVars(metadata *types.ClusterMetadata) map[string]interface{} {
+	vars := map[string]interface{}{}
+	if metadata.InfraID != "" {
+		vars["cluster_id"] = metadata.InfraID
+	}
+	if metadata.Platform() == typespowervs.Name {
+		for name, value := range map[string]string{
+			"powervs_cloud_instance_id": metadata.PowerVS.ServiceInstanceGUID,
+			"powervs_resource_group":    metadata.PowerVS.PowerVSResourceGroup,
+			"powervs_region":            metadata.PowerVS.Region,
+			"powervs_zone":              metadata.PowerVS.Zone,
+			"powervs_vpc_region":        metadata.PowerVS.VPCRegion,
+			"powervs_cis_crn":           metadata.PowerVS.CISInstanceCRN,
+		} {
+			if value != "" {
+				vars[name] = value
+			}
+		}
+	}
+	return vars
+}
+
+// writeMetadataVarFile writes the Terraform variables re-derived from the
+// cluster metadata which are missing from, or empty in, the variables files,
+// e.g. because a variables file was lost. It returns the path of the written
+// file, or an empty string if no variable is missing.
+func writeMetadataVarFile(dir string, metadata *types.ClusterMetadata, varFiles []string) (string, error) {
+	vars := metadataVars(metadata)
+	for _, varFile := range varFiles {
+		data, err := os.ReadFile(varFile)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read %s", filepath.Base(varFile))
+		}
+		existing := map[string]interface{}{}
+		if err := json.Unmarshal(data, &existing); err != nil {
+			// Not a Terraform variables file, e.g. stage outputs.
+			continue
+		}
+		for name, value := range existing {
+			if value != nil && value != "" {
+				delete(vars, name)
+			}
+		}
+	}
+	if len(vars) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the Terraform variables from the cluster metadata")
+	}
+	path := filepath.Join(dir, metadataVarsFileName)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", metadataVarsFileName)
+	}
+	logrus.Debugf("Re-derived Terraform variables from the cluster metadata: %v", keys(vars))
+	return path, nil
+}
+
+func keys(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func copy(from string, to string) error {
 	data, err := os.ReadFile(from)
 	if err != nil {
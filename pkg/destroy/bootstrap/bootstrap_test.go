@@ -0,0 +1,142 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	typespowervs "github.com/openshift/installer/pkg/types/powervs"
+)
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		err      string
+		expected bool
+	}{
+		{err: "[DEBUG] DELETE dhcp: [404] Not Found", expected: true},
+		{err: "Error: pcloudPvminstancesDeleteNotFound", expected: true},
+		{err: "The requested resource could not be found", expected: true},
+		{err: "timeout while waiting for state to become 'deleted'", expected: false},
+		{err: "failed to authenticate: 401 Unauthorized", expected: false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, isNotFound(tc.err), tc.err)
+	}
+}
+
+func TestNotFoundResources(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      string
+		expected []string
+	}{
+		{
+			name: "not found",
+			err: `terraform destroy: failed doing terraform destroy: exit status 1
+
+Error: failed to delete the instance: [DELETE /pcloud/v1/cloud-instances/{cloud_instance_id}/pvm-instances/{pvm_instance_id}][404] pcloudPvminstancesDeleteNotFound
+
+  with module.bootstrap.ibm_pi_instance.bootstrap,
+  on bootstrap/main.tf line 12, in resource "ibm_pi_instance" "bootstrap":
+  12: resource "ibm_pi_instance" "bootstrap" {
+`,
+			expected: []string{"module.bootstrap.ibm_pi_instance.bootstrap"},
+		},
+		{
+			name: "box drawing",
+			err: `exit status 1
+╷
+│ Error: The requested resource could not be found
+│ 
+│   with ibm_is_lb_pool_member.bootstrap[0],
+│   on main.tf line 40, in resource "ibm_is_lb_pool_member" "bootstrap":
+│   40: resource "ibm_is_lb_pool_member" "bootstrap" {
+│ 
+╵
+╷
+│ Error: The requested resource could not be found
+│ 
+│   with ibm_is_lb_pool_member.bootstrap[1],
+│   on main.tf line 40, in resource "ibm_is_lb_pool_member" "bootstrap":
+│   40: resource "ibm_is_lb_pool_member" "bootstrap" {
+│ 
+╵
+`,
+			expected: []string{"ibm_is_lb_pool_member.bootstrap[0]", "ibm_is_lb_pool_member.bootstrap[1]"},
+		},
+		{
+			name: "other failure",
+			err: `exit status 1
+
+Error: The requested resource could not be found
+
+  with ibm_is_lb_pool_member.bootstrap[0],
+  on main.tf line 40, in resource "ibm_is_lb_pool_member" "bootstrap":
+
+Error: timeout while waiting for state to become 'deleted'
+
+  with ibm_pi_instance.bootstrap,
+  on main.tf line 12, in resource "ibm_pi_instance" "bootstrap":
+`,
+		},
+		{
+			name: "no resource",
+			err: `exit status 1
+
+Error: 404 Not Found
+`,
+		},
+		{
+			name: "no diagnostic",
+			err:  "failed to create a new tfexec: 404 Not Found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, notFoundResources(errors.New(tc.err)))
+		})
+	}
+}
+
+func TestWriteMetadataVarFile(t *testing.T) {
+	metadata := &types.ClusterMetadata{
+		InfraID: "cluster-abc12",
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+			PowerVS: &typespowervs.Metadata{
+				ServiceInstanceGUID: "2a8d4ac1-5c2f-4a43-9f7c-6b21e6f2a0b9",
+				Zone:                "dal10",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	varFile := filepath.Join(dir, "terraform.tfvars.json")
+	if err := os.WriteFile(varFile, []byte(`{"cluster_id":"cluster-abc12","powervs_zone":""}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := writeMetadataVarFile(dir, metadata, []string{varFile})
+	if !assert.NoError(t, err) {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	vars := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(data, &vars))
+	assert.Equal(t, map[string]interface{}{
+		"powervs_cloud_instance_id": "2a8d4ac1-5c2f-4a43-9f7c-6b21e6f2a0b9",
+		"powervs_zone":              "dal10",
+	}, vars)
+
+	// Nothing is written when no variable is missing.
+	path, err = writeMetadataVarFile(t.TempDir(), &types.ClusterMetadata{InfraID: "cluster-abc12"}, []string{varFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "", path)
+}
@@ -0,0 +1,82 @@
+package destroy
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Discover reconstructs the metadata of the cluster with the infra ID on the
+// platform, in the region, from the cloud resources tagged with the infra
+// ID, and writes it to metadata.json in rootDir. The cluster is then
+// destroyed from the reconstructed metadata as if it had been kept, including
+// the resume of destroys which time out. The metadata of the directory is
+// reused when it is the metadata of the same cluster, e.g. written by an
+// earlier discovery.
+func Discover(logger logrus.FieldLogger, rootDir, platform, infraID, region string) error {
+	if infraID == "" {
+		return errors.New("the infra ID of the cluster is required")
+	}
+	if region == "" {
+		return errors.New("the region of the cluster is required")
+	}
+
+	existing, err := cluster.LoadMetadata(rootDir)
+	switch {
+	case err == nil:
+		if existing.InfraID != infraID || existing.Platform() != platform {
+			return errors.Errorf("%s is the metadata of the %s cluster %s, not of the %s cluster %s", filepath.Join(rootDir, "metadata.json"), existing.Platform(), existing.InfraID, platform, infraID)
+		}
+		logger.Infof("Using the metadata of the cluster %s in %s", infraID, rootDir)
+		return nil
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	discover, ok := providers.DiscoveryRegistry[platform]
+	if !ok {
+		return errors.Errorf("the discovery of the clusters is not supported on %q, it is supported on %s", platform, strings.Join(discoveryPlatforms(), ", "))
+	}
+	logger.Infof("Discovering the resources of the cluster %s in %s", infraID, region)
+	metadata, err := discover(logger, infraID, region)
+	if err != nil {
+		return errors.Wrapf(err, "failed to discover the cluster %s", infraID)
+	}
+	metadata.InfraID = infraID
+	if metadata.ClusterName == "" {
+		metadata.ClusterName = clusterNameFromInfraID(infraID)
+	}
+
+	if err := cluster.WriteMetadata(rootDir, metadata); err != nil {
+		return errors.Wrap(err, "failed to write the discovered metadata")
+	}
+	logger.Infof("Wrote the discovered metadata of the cluster to %s", filepath.Join(rootDir, "metadata.json"))
+	return nil
+}
+
+// discoveryPlatforms returns the platforms supporting the discovery of the
+// clusters, sorted.
+func discoveryPlatforms() []string {
+	platforms := make([]string, 0, len(providers.DiscoveryRegistry))
+	for platform := range providers.DiscoveryRegistry {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// clusterNameFromInfraID returns the name of the cluster from its infra ID,
+// which is the name, possibly truncated, followed by a random suffix.
+func clusterNameFromInfraID(infraID string) string {
+	if i := strings.LastIndex(infraID, "-"); i > 0 {
+		return infraID[:i]
+	}
+	return infraID
+}
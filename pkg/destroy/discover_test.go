@@ -0,0 +1,119 @@
+package destroy
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+func TestDiscover(t *testing.T) {
+	discovered := &types.ClusterMetadata{
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+	}
+	providers.DiscoveryRegistry["aws"] = func(_ logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+		return discovered, nil
+	}
+	defer delete(providers.DiscoveryRegistry, "aws")
+
+	cases := []struct {
+		name     string
+		existing *types.ClusterMetadata
+		platform string
+		infraID  string
+		region   string
+		expected *types.ClusterMetadata
+		err      string
+	}{
+		{
+			name:     "discovered",
+			platform: "aws",
+			infraID:  "my-cluster-abcde",
+			region:   "us-east-1",
+			expected: &types.ClusterMetadata{
+				ClusterName:             "my-cluster",
+				InfraID:                 "my-cluster-abcde",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+			},
+		},
+		{
+			name: "existing metadata of the cluster",
+			existing: &types.ClusterMetadata{
+				ClusterName:             "my-cluster",
+				InfraID:                 "my-cluster-abcde",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+				Destroy:                 &types.DestroyProgress{Completed: []string{"instances"}},
+			},
+			platform: "aws",
+			infraID:  "my-cluster-abcde",
+			region:   "us-east-1",
+			expected: &types.ClusterMetadata{
+				ClusterName:             "my-cluster",
+				InfraID:                 "my-cluster-abcde",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+				Destroy:                 &types.DestroyProgress{Completed: []string{"instances"}},
+			},
+		},
+		{
+			name: "existing metadata of another cluster",
+			existing: &types.ClusterMetadata{
+				InfraID:                 "other-cluster-fghij",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{AWS: &aws.Metadata{Region: "us-east-1"}},
+			},
+			platform: "aws",
+			infraID:  "my-cluster-abcde",
+			region:   "us-east-1",
+			err:      `^.*metadata\.json is the metadata of the aws cluster other-cluster-fghij, not of the aws cluster my-cluster-abcde$`,
+		},
+		{
+			name:     "unsupported platform",
+			platform: "vsphere",
+			infraID:  "my-cluster-abcde",
+			region:   "us-east-1",
+			err:      `^the discovery of the clusters is not supported on "vsphere", it is supported on aws$`,
+		},
+		{
+			name:     "missing region",
+			platform: "aws",
+			infraID:  "my-cluster-abcde",
+			err:      `^the region of the cluster is required$`,
+		},
+		{
+			name:     "existing metadata on another platform",
+			existing: &types.ClusterMetadata{InfraID: "my-cluster-abcde", ClusterPlatformMetadata: types.ClusterPlatformMetadata{GCP: &gcp.Metadata{Region: "us-east1"}}},
+			platform: "aws",
+			infraID:  "my-cluster-abcde",
+			region:   "us-east-1",
+			err:      `^.*metadata\.json is the metadata of the gcp cluster my-cluster-abcde, not of the aws cluster my-cluster-abcde$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.existing != nil {
+				if !assert.NoError(t, cluster.WriteMetadata(dir, tc.existing)) {
+					return
+				}
+			}
+
+			err := Discover(logrus.StandardLogger(), dir, tc.platform, tc.infraID, tc.region)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			metadata, err := cluster.LoadMetadata(dir)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// Discover returns the metadata of the cluster with the infra ID in the
+// region of the project of the credentials. The instances labeled
+// kubernetes-io-cluster-<infra ID>: owned are attached to the subnets of the
+// network project of the cluster, which is another project when the network
+// is shared.
+func Discover(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	ssn, err := gcpconfig.GetSession(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get session")
+	}
+	projectID := ssn.Credentials.ProjectID
+	if projectID == "" {
+		return nil, errors.New("the credentials have no project, the project of the cluster is unknown")
+	}
+
+	computeSvc, err := compute.NewService(ctx,
+		option.WithCredentials(ssn.Credentials),
+		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compute service")
+	}
+
+	o := &ClusterUninstaller{ClusterID: infraID}
+	logger.Debugf("Search for instances in %s matching %s", projectID, o.clusterLabelFilter())
+	var instances int
+	var networkProjectID string
+	req := computeSvc.Instances.AggregatedList(projectID).Filter(o.clusterLabelFilter()).Fields("items/*/instances(name,networkInterfaces(subnetwork)),nextPageToken")
+	if err := req.Pages(ctx, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, item := range scopedList.Instances {
+				instances++
+				for _, iface := range item.NetworkInterfaces {
+					if project := subnetworkProject(iface.Subnetwork); project != "" && project != projectID {
+						networkProjectID = project
+					}
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the instances of the cluster in %s", projectID)
+	}
+	if instances == 0 {
+		logger.Warnf("No instances in %s are labeled for the cluster, its network project is assumed to be %s", projectID, projectID)
+	}
+	if networkProjectID != "" {
+		logger.Infof("The network of the cluster is shared from the project %s", networkProjectID)
+	}
+
+	return &types.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{GCP: &gcptypes.Metadata{
+			Region:           region,
+			ProjectID:        projectID,
+			NetworkProjectID: networkProjectID,
+		}},
+	}, nil
+}
+
+// subnetworkProject returns the project of the URL of a subnetwork, e.g.
+// https://www.googleapis.com/compute/v1/projects/<project>/regions/<region>/subnetworks/<name>.
+func subnetworkProject(url string) string {
+	segments := strings.Split(url, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "projects" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/types"
+	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+// Discover returns the metadata of the cluster with the infra ID in the
+// region. The destroyer finds the resources of the cluster by their names in
+// the resource group of the cluster, which is the resource group named after
+// the infra ID when the installer created it, or else the resource group of
+// the resources tagged with the infra ID.
+func Discover(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client, err := icibmcloud.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	apiKeyDetails, err := client.GetAuthenticatorAPIKeyDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group := infraID
+	if _, err := client.GetResourceGroup(ctx, infraID); err != nil {
+		logger.Debugf("Search for the resource group of the resources tagged %s: %v", infraID, err)
+		groupID, err := taggedResourceGroup(ctx, client.GetAPIKey(), infraID)
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, err := client.GetResourceGroup(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+		group = *resourceGroup.Name
+	}
+	logger.Infof("Found the resource group %s of the cluster", group)
+	logger.Warn("The DNS records of the cluster are not discovered, they are left behind")
+
+	return &types.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{IBMCloud: &ibmcloudtypes.Metadata{
+			AccountID:         *apiKeyDetails.AccountID,
+			Region:            region,
+			ResourceGroupName: group,
+		}},
+	}, nil
+}
+
+// taggedResourceGroup returns the ID of the resource group of the resources
+// tagged with the infra ID, through the global search service.
+func taggedResourceGroup(ctx context.Context, apiKey string, infraID string) (string, error) {
+	svc, err := globalsearchv2.NewGlobalSearchV2(&globalsearchv2.GlobalSearchV2Options{
+		Authenticator: &core.IamAuthenticator{ApiKey: apiKey},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create the global search service")
+	}
+
+	options := svc.NewSearchOptions()
+	options.SetQuery(fmt.Sprintf("tags:%q", infraID))
+	options.SetFields([]string{"crn", "resource_group_id"})
+	options.SetLimit(100)
+	scan, _, err := svc.SearchWithContext(ctx, options)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to search the resources tagged %s", infraID)
+	}
+
+	groups := map[string]struct{}{}
+	for _, item := range scan.Items {
+		if id, ok := item.GetProperty("resource_group_id").(string); ok && id != "" {
+			groups[id] = struct{}{}
+		}
+	}
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	switch len(ids) {
+	case 0:
+		return "", errors.Errorf("no resource group is named %s or has resources tagged %s", infraID, infraID)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", errors.Errorf("the resources tagged %s are in more than one resource group (%s)", infraID, strings.Join(ids, ", "))
+	}
+}
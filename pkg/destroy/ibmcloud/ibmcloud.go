@@ -64,11 +64,12 @@ type ClusterUninstaller struct {
 
 	errorTracker
 	pendingItemTracker
+	providers.CompletedStages
 }
 
 // New returns an IBMCloud destroyer from ClusterMetadata.
 func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
-	return &ClusterUninstaller{
+	o := &ClusterUninstaller{
 		ClusterName:         metadata.ClusterName,
 		Context:             context.Background(),
 		Logger:              logger,
@@ -83,7 +84,12 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		UserProvidedVPC:     metadata.ClusterPlatformMetadata.IBMCloud.VPC,
 		pendingItemTracker:  newPendingItemTracker(),
 		maxRetryAttempt:     30,
-	}, nil
+	}
+	if metadata.Destroy != nil {
+		logger.Infof("Resuming the destroy, checking the deleted %s last", strings.Join(metadata.Destroy.Completed, ", "))
+		o.MarkCompleted(metadata.Destroy.Completed...)
+	}
+	return o, nil
 }
 
 // Retry ...
@@ -124,11 +130,20 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 	return nil, nil
 }
 
+// RunWithContext is Run, stopping when the context is done.
+func (o *ClusterUninstaller) RunWithContext(ctx context.Context) (*types.ClusterQuota, error) {
+	o.Context = ctx
+	return o.Run()
+}
+
+// stageFunc deletes the resources of a kind.
+type stageFunc struct {
+	name    string
+	execute func() error
+}
+
 func (o *ClusterUninstaller) destroyCluster() error {
-	stagedFuncs := [][]struct {
-		name    string
-		execute func() error
-	}{{
+	stagedFuncs := [][]stageFunc{{
 		{name: "Stop instances", execute: o.stopInstances},
 	}, {
 		// Instances must occur before LB cleanup
@@ -162,26 +177,30 @@ func (o *ClusterUninstaller) destroyCluster() error {
 		{name: "Resource Groups", execute: o.destroyResourceGroups},
 	}}
 
+	// The resources deleted by an earlier destroy may have been created
+	// again since, e.g. by the machine API while the earlier destroy ran, so
+	// their stages are skipped until the other stages complete, and then run
+	// again in their order to delete the resources which reappeared.
+	var skippedStages [][]stageFunc
 	for _, stage := range stagedFuncs {
-		var wg sync.WaitGroup
-		errCh := make(chan error)
-		wgDone := make(chan bool)
-
+		var pending, skipped []stageFunc
 		for _, f := range stage {
-			wg.Add(1)
-			go o.executeStageFunction(f, errCh, &wg)
+			if o.IsCompleted(f.name) {
+				o.Logger.Debugf("Deferring %s, deleted by an earlier destroy", f.name)
+				skipped = append(skipped, f)
+				continue
+			}
+			pending = append(pending, f)
 		}
-
-		go func() {
-			wg.Wait()
-			close(wgDone)
-		}()
-
-		select {
-		case <-wgDone:
-			// On to the next stage
-			continue
-		case err := <-errCh:
+		if len(skipped) > 0 {
+			skippedStages = append(skippedStages, skipped)
+		}
+		if err := o.executeStage(pending); err != nil {
+			return err
+		}
+	}
+	for _, stage := range skippedStages {
+		if err := o.executeStage(stage); err != nil {
 			return err
 		}
 	}
@@ -189,13 +208,40 @@ func (o *ClusterUninstaller) destroyCluster() error {
 	return nil
 }
 
-func (o *ClusterUninstaller) executeStageFunction(f struct {
-	name    string
-	execute func() error
-}, errCh chan error, wg *sync.WaitGroup) error {
+// executeStage runs the functions of a stage in parallel, and returns when
+// they all complete, or when one of them fails.
+func (o *ClusterUninstaller) executeStage(stage []stageFunc) error {
+	var wg sync.WaitGroup
+	// The functions still running when the stage returns must not block
+	// on their errors.
+	errCh := make(chan error, len(stage))
+	wgDone := make(chan bool)
+
+	for _, f := range stage {
+		wg.Add(1)
+		go o.executeStageFunction(f, errCh, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(wgDone)
+	}()
+
+	select {
+	case <-wgDone:
+		// On to the next stage
+		return nil
+	case <-o.Context.Done():
+		return o.Context.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+func (o *ClusterUninstaller) executeStageFunction(f stageFunc, errCh chan error, wg *sync.WaitGroup) error {
 	defer wg.Done()
 
-	err := wait.PollImmediateInfinite(
+	err := wait.PollImmediateUntil(
 		time.Second*10,
 		func() (bool, error) {
 			ferr := f.execute()
@@ -205,11 +251,14 @@ func (o *ClusterUninstaller) executeStageFunction(f struct {
 			}
 			return true, nil
 		},
+		o.Context.Done(),
 	)
 
 	if err != nil {
 		errCh <- err
+		return nil
 	}
+	o.MarkCompleted(f.name)
 	return nil
 }
 
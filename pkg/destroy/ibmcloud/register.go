@@ -4,4 +4,5 @@ import "github.com/openshift/installer/pkg/destroy/providers"
 
 func init() {
 	providers.Registry["ibmcloud"] = New
+	providers.DiscoveryRegistry["ibmcloud"] = Discover
 }
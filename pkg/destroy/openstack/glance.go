@@ -1,9 +1,11 @@
 package openstack
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	"github.com/sirupsen/logrus"
@@ -55,6 +57,12 @@ func deleteGlanceImage(name string, cloud string) (bool, error) {
 	for _, image := range allImages {
 		err := images.Delete(conn, image.ID).ExtractErr()
 		if err != nil {
+			// Ignore the error if the image cannot be found, it was deleted in the meantime
+			var gerr gophercloud.ErrDefault404
+			if errors.As(err, &gerr) {
+				logrus.Debugf("Cannot find image %q. It's probably already been deleted.", image.ID)
+				continue
+			}
 			logrus.Warningf("There was an error during the image removal: %v", err)
 			return false, nil
 		}
@@ -2,9 +2,7 @@ package powervs
 
 import (
 	"context"
-	"math"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -113,30 +111,16 @@ func (o *ClusterUninstaller) destroyCloudInstances() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyCloudInstances: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.destroyCloudInstance(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyCloudInstances: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.destroyCloudInstance(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyCloudInstances: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(cloudInstanceTypeName); len(items) > 0 {
@@ -146,11 +130,7 @@ func (o *ClusterUninstaller) destroyCloudInstances() error {
 		return errors.Errorf("destroyCloudInstances: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, cloudInstanceTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listCloudInstances()
 		if err2 != nil {
@@ -2,9 +2,7 @@ package powervs
 
 import (
 	"context"
-	"math"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -173,30 +171,16 @@ func (o *ClusterUninstaller) destroyCloudSSHKeys() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyCloudSSHKeys: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.deleteCloudSSHKey(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyCloudSSHKeys: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.deleteCloudSSHKey(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyCloudSSHKeys: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(cloudSSHKeyTypeName); len(items) > 0 {
@@ -206,11 +190,7 @@ func (o *ClusterUninstaller) destroyCloudSSHKeys() error {
 		return errors.Errorf("destroyCloudSSHKeys: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, cloudSSHKeyTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listCloudSSHKeys()
 		if err2 != nil {
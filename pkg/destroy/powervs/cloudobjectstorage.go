@@ -2,10 +2,8 @@ package powervs
 
 import (
 	"fmt"
-	"math"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	// https://github.com/IBM/platform-services-go-sdk/blob/v0.18.16/resourcecontrollerv2/resource_controller_v2.go
@@ -64,12 +62,15 @@ func (o *ClusterUninstaller) listCOSInstances() (cloudResources, error) {
 		for _, instance := range resources.Resources {
 			o.Logger.Debugf("listCOSInstances: only found COS instance: %s", *instance.Name)
 		}
+		return o.listTaggedResources(cosTypeName, resourceInstanceGUID, "service_name:cloud-object-storage", "type:resource-instance")
 	}
 
 	return cloudResources{}.insert(result...), nil
 }
 
-func (o *ClusterUninstaller) findReclaimedCOSInstance(item cloudResource) (*resourcecontrollerv2.ResourceInstance, *resourcecontrollerv2.Reclamation) {
+// findReclaimedInstance returns the service instance of the item and its
+// reclamation, when the deleted instance is pending reclamation.
+func (o *ClusterUninstaller) findReclaimedInstance(item cloudResource) (*resourcecontrollerv2.ResourceInstance, *resourcecontrollerv2.Reclamation) {
 	var getReclamationOptions *resourcecontrollerv2.ListReclamationsOptions
 	var reclamations *resourcecontrollerv2.ReclamationsList
 	var response *core.DetailedResponse
@@ -110,7 +111,7 @@ func (o *ClusterUninstaller) findReclaimedCOSInstance(item cloudResource) (*reso
 func (o *ClusterUninstaller) destroyCOSInstance(item cloudResource) error {
 	var cosInstance *resourcecontrollerv2.ResourceInstance
 
-	cosInstance, _ = o.findReclaimedCOSInstance(item)
+	cosInstance, _ = o.findReclaimedInstance(item)
 	if cosInstance != nil {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
@@ -151,7 +152,7 @@ func (o *ClusterUninstaller) destroyCOSInstance(item cloudResource) error {
 
 	var reclamation *resourcecontrollerv2.Reclamation
 
-	cosInstance, reclamation = o.findReclaimedCOSInstance(item)
+	cosInstance, reclamation = o.findReclaimedInstance(item)
 	if cosInstance != nil {
 		var reclamationActionOptions *resourcecontrollerv2.RunReclamationActionOptions
 
@@ -186,30 +187,16 @@ func (o *ClusterUninstaller) destroyCOSInstances() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyCOSInstances: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.destroyCOSInstance(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyCOSInstances: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.destroyCOSInstance(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyCOSInstances: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(cosTypeName); len(items) > 0 {
@@ -219,11 +206,7 @@ func (o *ClusterUninstaller) destroyCOSInstances() error {
 		return errors.Errorf("destroyCOSInstances: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, cosTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listCOSInstances()
 		if err2 != nil {
@@ -0,0 +1,249 @@
+package powervs
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+const cosBucketTypeName = "cos bucket"
+
+// cosStorageClasses are the storage classes which suffix the location
+// constraint of a bucket, e.g. us-south-smart.
+var cosStorageClasses = []string{"standard", "vault", "cold", "smart", "flex", "onerate_active"}
+
+// listAllMyBucketsResult is the extended bucket listing of the S3 API of COS.
+type listAllMyBucketsResult struct {
+	Buckets []struct {
+		Name               string `xml:"Name"`
+		LocationConstraint string `xml:"LocationConstraint"`
+	} `xml:"Buckets>Bucket"`
+}
+
+// listBucketResult is the object listing (version 2) of the S3 API of COS.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// cosEndpoint returns the public S3 endpoint of the location of a bucket.
+func cosEndpoint(location string) string {
+	region := location
+	for _, class := range cosStorageClasses {
+		if trimmed := strings.TrimSuffix(location, "-"+class); trimmed != location {
+			region = trimmed
+			break
+		}
+	}
+	return fmt.Sprintf("https://s3.%s.cloud-object-storage.appdomain.cloud", region)
+}
+
+// bucketEndpoint returns the S3 endpoint of the location of a bucket, or the
+// custom COS endpoint which serves every location.
+func (o *ClusterUninstaller) bucketEndpoint(location string) string {
+	return o.serviceURL(powervstypes.COSServiceEndpointName, cosEndpoint(location))
+}
+
+// cosRequest sends an authenticated request to the S3 API of COS and returns
+// the body of the response, which must have one of the expected status codes.
+func (o *ClusterUninstaller) cosRequest(ctx context.Context, method string, u string, header http.Header, expected ...int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if err := o.cosAuthenticator.Authenticate(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return body, nil
+		}
+	}
+	return nil, errors.Errorf("%s %s: %s", method, u, resp.Status)
+}
+
+// listCOSBuckets lists the buckets of the cluster's COS instances which have a
+// name containing the cluster's infra ID, such as the bucket of the bootstrap
+// ignition and of the imported boot image.
+func (o *ClusterUninstaller) listCOSBuckets() (cloudResources, error) {
+	o.Logger.Debugf("Listing COS buckets")
+
+	instances, err := o.listCOSInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	result := []cloudResource{}
+	for _, instance := range instances.list() {
+		// Buckets are listed from any regional endpoint, with their location.
+		header := http.Header{}
+		header.Set("ibm-service-instance-id", instance.id)
+		body, err := o.cosRequest(ctx, http.MethodGet, o.bucketEndpoint(o.VPCRegion)+"/?extended", header, http.StatusOK)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the buckets of COS instance %s", instance.name)
+		}
+		buckets := listAllMyBucketsResult{}
+		if err := xml.Unmarshal(body, &buckets); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the buckets of COS instance %s", instance.name)
+		}
+
+		for _, bucket := range buckets.Buckets {
+			if !strings.Contains(bucket.Name, o.InfraID) {
+				o.Logger.Debugf("listCOSBuckets: skipping bucket %s of COS instance %s", bucket.Name, instance.name)
+				continue
+			}
+			o.Logger.Debugf("listCOSBuckets: FOUND: %s, %s", bucket.Name, bucket.LocationConstraint)
+			// The status holds the location of the bucket, which selects
+			// the endpoint of its objects.
+			result = append(result, cloudResource{
+				key:      bucket.Name,
+				name:     bucket.Name,
+				status:   bucket.LocationConstraint,
+				typeName: cosBucketTypeName,
+				id:       bucket.Name,
+			})
+		}
+	}
+
+	return cloudResources{}.insert(result...), nil
+}
+
+// deleteCOSBucket deletes the objects of a bucket, and then the bucket.
+func (o *ClusterUninstaller) deleteCOSBucket(item cloudResource) error {
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		o.Logger.Debugf("deleteCOSBucket: case <-ctx.Done()")
+		return o.Context.Err() // we're cancelled, abort
+	default:
+	}
+
+	bucketURL := o.bucketEndpoint(item.status) + "/" + url.PathEscape(item.id)
+	token := ""
+	for {
+		query := url.Values{"list-type": []string{"2"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		body, err := o.cosRequest(ctx, http.MethodGet, bucketURL+"?"+query.Encode(), nil, http.StatusOK, http.StatusNotFound)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list the objects of bucket %s", item.name)
+		}
+		objects := listBucketResult{}
+		if err := xml.Unmarshal(body, &objects); err != nil {
+			return errors.Wrapf(err, "failed to parse the objects of bucket %s", item.name)
+		}
+
+		for _, object := range objects.Contents {
+			objectURL := bucketURL + "/" + (&url.URL{Path: object.Key}).EscapedPath()
+			if _, err := o.cosRequest(ctx, http.MethodDelete, objectURL, nil, http.StatusNoContent, http.StatusNotFound); err != nil {
+				return errors.Wrapf(err, "failed to delete object %s of bucket %s", object.Key, item.name)
+			}
+			o.Logger.Debugf("deleteCOSBucket: deleted object %s of bucket %s", object.Key, item.name)
+		}
+
+		if !objects.IsTruncated {
+			break
+		}
+		token = objects.NextContinuationToken
+	}
+
+	if _, err := o.cosRequest(ctx, http.MethodDelete, bucketURL, nil, http.StatusNoContent, http.StatusNotFound); err != nil {
+		return errors.Wrapf(err, "failed to delete bucket %s", item.name)
+	}
+
+	o.Logger.Infof("Deleted COS Bucket %q", item.name)
+	o.deletePendingItems(item.typeName, []cloudResource{item})
+
+	return nil
+}
+
+// destroyCOSBuckets empties and removes the buckets of the cluster's COS
+// instances that have a name containing the cluster's infra ID, so that the
+// imported boot image and ignition objects are not left behind.
+func (o *ClusterUninstaller) destroyCOSBuckets() error {
+	firstPassList, err := o.listCOSBuckets()
+	if err != nil {
+		return err
+	}
+
+	if len(firstPassList.list()) == 0 {
+		return nil
+	}
+
+	items := o.insertPendingItems(cosBucketTypeName, firstPassList.list())
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.deleteCOSBucket(item)
+		if err2 == nil {
+			return true, err2
+		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyCOSBuckets: destroyItems returns ", err)
+	}
+
+	if items = o.getPendingItems(cosBucketTypeName); len(items) > 0 {
+		for _, item := range items {
+			o.Logger.Debugf("destroyCOSBuckets: found %s in pending items", item.name)
+		}
+		return errors.Errorf("destroyCOSBuckets: %d undeleted items pending", len(items))
+	}
+
+	backoff := resourceBackoff(ctx, cosBucketTypeName)
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		secondPassList, err2 := o.listCOSBuckets()
+		if err2 != nil {
+			return false, err2
+		}
+		if len(secondPassList) == 0 {
+			// We finally don't see any remaining instances!
+			return true, nil
+		}
+		for _, item := range secondPassList {
+			o.Logger.Debugf("destroyCOSBuckets: found %s in second pass", item.name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyCOSBuckets: ExponentialBackoffWithContext (list) returns ", err)
+	}
+
+	return nil
+}
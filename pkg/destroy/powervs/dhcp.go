@@ -1,9 +1,7 @@
 package powervs
 
 import (
-	"math"
 	"strings"
-	"time"
 
 	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/pkg/errors"
@@ -111,30 +109,16 @@ func (o *ClusterUninstaller) destroyDHCPNetworks() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyDHCPNetworks: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.destroyDHCPNetwork(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyDHCPNetworks: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.destroyDHCPNetwork(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyDHCPNetworks: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(dhcpTypeName); len(items) > 0 {
@@ -144,11 +128,7 @@ func (o *ClusterUninstaller) destroyDHCPNetworks() error {
 		return errors.Errorf("destroyDHCPNetworks: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, dhcpTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listDHCPNetworks()
 		if err2 != nil {
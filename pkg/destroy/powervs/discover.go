@@ -0,0 +1,99 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/crn"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// Discover returns the metadata of the cluster with the infra ID in the
+// region. The Power VS workspace of the cluster is the workspace tagged with
+// the infra ID, or named after it, in a zone of the region. Workspaces
+// provided by the user have neither, so they are not discovered.
+func Discover(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error) {
+	bxClient, err := powervs.NewBxClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := bxClient.GetBxClientAPIKey()
+	if apiKey == "" {
+		return nil, errors.New("powervs.GetSession did not return an API key")
+	}
+
+	svc, err := globalsearchv2.NewGlobalSearchV2(&globalsearchv2.GlobalSearchV2Options{
+		Authenticator: &core.IamAuthenticator{ApiKey: apiKey},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the global search service")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	query := workspaceQuery(infraID)
+	logger.Debugf("Search for the workspace of the cluster: %s", query)
+	options := svc.NewSearchOptions()
+	options.SetQuery(query)
+	options.SetFields([]string{"crn", "name"})
+	options.SetLimit(100)
+	scan, _, err := svc.SearchWithContext(ctx, options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search the workspace of the cluster")
+	}
+
+	var workspaces []crn.CRN
+	for _, item := range scan.Items {
+		if item.CRN == nil {
+			continue
+		}
+		parsed, err := crn.Parse(*item.CRN)
+		if err != nil {
+			logger.Debugf("Discover: skipping %s: %v", *item.CRN, err)
+			continue
+		}
+		if powervstypes.RegionFromZone(parsed.Region) != region {
+			logger.Debugf("Discover: skipping the workspace %s in %s", parsed.ServiceInstance, parsed.Region)
+			continue
+		}
+		workspaces = append(workspaces, parsed)
+	}
+	switch len(workspaces) {
+	case 0:
+		return nil, errors.Errorf("no workspace in %s is tagged %s or named %s", region, infraID, powervstypes.WorkspaceName(infraID))
+	case 1:
+	default:
+		guids := make([]string, 0, len(workspaces))
+		for _, workspace := range workspaces {
+			guids = append(guids, workspace.ServiceInstance)
+		}
+		return nil, errors.Errorf("more than one workspace in %s is tagged %s or named %s (%s)", region, infraID, powervstypes.WorkspaceName(infraID), strings.Join(guids, ", "))
+	}
+	workspace := workspaces[0]
+	logger.Infof("Found the workspace %s of the cluster in %s", workspace.ServiceInstance, workspace.Region)
+	logger.Warn("The DNS records of the cluster are not discovered, they are left behind")
+
+	return &types.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{PowerVS: &powervstypes.Metadata{
+			Region:              region,
+			Zone:                workspace.Region,
+			ServiceInstanceGUID: workspace.ServiceInstance,
+		}},
+	}, nil
+}
+
+// workspaceQuery returns the global search query of the workspaces tagged
+// with the infra ID or named after it.
+func workspaceQuery(infraID string) string {
+	return fmt.Sprintf("service_name:power-iaas AND type:resource-instance AND (tags:%q OR name:%q)", infraID, powervstypes.WorkspaceName(infraID))
+}
@@ -2,10 +2,8 @@ package powervs
 
 import (
 	"fmt"
-	"math"
 	"net/http"
 	"regexp"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v4/core"
 	"github.com/pkg/errors"
@@ -162,30 +160,16 @@ func (o *ClusterUninstaller) destroyDNSRecords() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyDNSRecords: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.destroyDNSRecord(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyDNSRecords: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.destroyDNSRecord(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyDNSRecords: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(cisDNSRecordTypeName); len(items) > 0 {
@@ -195,11 +179,7 @@ func (o *ClusterUninstaller) destroyDNSRecords() error {
 		return errors.Errorf("destroyDNSRecords: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, cisDNSRecordTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listDNSRecords()
 		if err2 != nil {
@@ -1,9 +1,7 @@
 package powervs
 
 import (
-	"math"
 	"strings"
-	"time"
 
 	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/pkg/errors"
@@ -135,38 +133,24 @@ func (o *ClusterUninstaller) destroyJobs() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyJobs: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		result, err2 := o.deleteJob(item)
+		switch result {
+		case DeleteJobSuccess:
+			o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobSuccess")
+			return true, nil
+		case DeleteJobRunning:
+			o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobRunning")
+			return false, nil
+		case DeleteJobError:
+			o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobError: %v", err2)
+			return false, err2
 		default:
+			return false, errors.Errorf("destroyJobs: deleteJob unknown result enum %v", result)
 		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			result, err2 := o.deleteJob(item)
-			switch result {
-			case DeleteJobSuccess:
-				o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobSuccess")
-				return true, nil
-			case DeleteJobRunning:
-				o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobRunning")
-				return false, nil
-			case DeleteJobError:
-				o.Logger.Debugf("destroyJobs: deleteJob returns DeleteJobError: %v", err2)
-				return false, err2
-			default:
-				return false, errors.Errorf("destroyJobs: deleteJob unknown result enum %v", result)
-			}
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyJobs: ExponentialBackoffWithContext (destroy) returns ", err)
-		}
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyJobs: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(jobTypeName); len(items) > 0 {
@@ -176,11 +160,7 @@ func (o *ClusterUninstaller) destroyJobs() error {
 		return errors.Errorf("destroyJobs: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, jobTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listJobs()
 		if err2 != nil {
@@ -1,10 +1,8 @@
 package powervs
 
 import (
-	"math"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -56,6 +54,7 @@ func (o *ClusterUninstaller) listLoadBalancers() (cloudResources, error) {
 		for _, loadbalancer := range resources.LoadBalancers {
 			o.Logger.Debugf("listLoadBalancers: loadbalancer: %s", *loadbalancer.Name)
 		}
+		return o.listTaggedResources(loadBalancerTypeName, vpcResourceID, "type:load-balancer")
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -136,30 +135,16 @@ func (o *ClusterUninstaller) destroyLoadBalancers() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyLoadBalancers: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.deleteLoadBalancer(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyLoadBalancers: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.deleteLoadBalancer(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyLoadBalancers: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(loadBalancerTypeName); len(items) > 0 {
@@ -169,11 +154,7 @@ func (o *ClusterUninstaller) destroyLoadBalancers() error {
 		return errors.Errorf("destroyLoadBalancers: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, loadBalancerTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listLoadBalancers()
 		if err2 != nil {
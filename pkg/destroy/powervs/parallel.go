@@ -0,0 +1,119 @@
+package powervs
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultParallelism is the default number of resources of a type which
+	// are deleted concurrently.
+	DefaultParallelism = 4
+)
+
+// parallelism is the number of resources of a type which are deleted
+// concurrently.
+var parallelism = DefaultParallelism
+
+// SetParallelism sets the number of resources of a type which are deleted
+// concurrently. Values below one delete the resources one at a time.
+func SetParallelism(value int) {
+	if value < 1 {
+		value = 1
+	}
+	parallelism = value
+}
+
+// defaultBackoff is the retry backoff of resource types without a tuned one.
+var defaultBackoff = wait.Backoff{Duration: 15 * time.Second, Factor: 1.1}
+
+// resourceBackoffs are the retry backoffs of the resource types. Resources
+// which are removed quickly are polled often, while instances, DHCP services
+// and cloud connections, which take minutes to go away, back off faster to
+// avoid exhausting the API rate limits.
+var resourceBackoffs = map[string]wait.Backoff{
+	cloudInstanceTypeName: {Duration: 15 * time.Second, Factor: 1.3},
+	powerInstanceTypeName: {Duration: 15 * time.Second, Factor: 1.3},
+	dhcpTypeName:          {Duration: 30 * time.Second, Factor: 1.3},
+	jobTypeName:           {Duration: 30 * time.Second, Factor: 1.3},
+	workspaceTypeName:     {Duration: 30 * time.Second, Factor: 1.3},
+	loadBalancerTypeName:  {Duration: 15 * time.Second, Factor: 1.2},
+	cosTypeName:           {Duration: 10 * time.Second, Factor: 1.2},
+	publicGatewayTypeName: {Duration: 10 * time.Second, Factor: 1.2},
+	subnetTypeName:        {Duration: 10 * time.Second, Factor: 1.2},
+	vpcTypeName:           {Duration: 10 * time.Second, Factor: 1.2},
+	securityGroupTypeName: {Duration: 10 * time.Second, Factor: 1.2},
+	imageTypeName:         {Duration: 10 * time.Second, Factor: 1.2},
+	powerSnapshotTypeName: {Duration: 10 * time.Second, Factor: 1.2},
+	cosBucketTypeName:     {Duration: 10 * time.Second, Factor: 1.2},
+	cloudSSHKeyTypeName:   {Duration: 5 * time.Second, Factor: 1.5},
+	powerSSHKeyTypeName:   {Duration: 5 * time.Second, Factor: 1.5},
+	cisDNSRecordTypeName:  {Duration: 5 * time.Second, Factor: 1.5},
+	ibmDNSRecordTypeName:  {Duration: 5 * time.Second, Factor: 1.5},
+}
+
+// resourceBackoff returns the retry backoff of the resource type, capped by
+// the time left in the context.
+func resourceBackoff(ctx context.Context, typeName string) wait.Backoff {
+	backoff, ok := resourceBackoffs[typeName]
+	if !ok {
+		backoff = defaultBackoff
+	}
+	backoff.Cap = leftInContext(ctx)
+	backoff.Steps = math.MaxInt32
+	return backoff
+}
+
+// destroyItems deletes the items, at most parallelism of them concurrently.
+// The deletion of every item is retried with the backoff of its resource
+// type until destroy reports it done or fails. Items which are not deleted
+// when the context expires are left pending.
+func (o *ClusterUninstaller) destroyItems(ctx context.Context, items []cloudResource, destroy func(item cloudResource) (bool, error)) error {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		deleted int
+	)
+	slots := make(chan struct{}, parallelism)
+
+schedule:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			o.Logger.Debugf("destroyItems: case <-ctx.Done()")
+			break schedule
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(item cloudResource) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := wait.ExponentialBackoffWithContext(ctx, resourceBackoff(ctx, item.typeName), func() (bool, error) {
+				return destroy(item)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if ctx.Err() == nil {
+					errs = append(errs, errors.Wrapf(err, "failed to delete %s %q", item.typeName, item.name))
+				}
+				return
+			}
+			deleted++
+			o.Logger.Infof("Deleted %d of %d %s resources", deleted, len(items), item.typeName)
+		}(item)
+	}
+
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
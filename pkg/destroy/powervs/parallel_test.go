@@ -0,0 +1,84 @@
+package powervs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestroyItems(t *testing.T) {
+	defer SetParallelism(DefaultParallelism)
+
+	items := make([]cloudResource, 10)
+	for i := range items {
+		items[i] = cloudResource{key: fmt.Sprint(i), name: fmt.Sprint(i), typeName: "test"}
+	}
+
+	cases := []struct {
+		name        string
+		parallelism int
+		failing     string
+		expectedErr string
+	}{
+		{
+			name:        "serial",
+			parallelism: 1,
+		},
+		{
+			name:        "parallel",
+			parallelism: 3,
+		},
+		{
+			name:        "failing item",
+			parallelism: 3,
+			failing:     "4",
+			expectedErr: `failed to delete test "4": not allowed`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetParallelism(tc.parallelism)
+			o := &ClusterUninstaller{Logger: logrus.New()}
+
+			var (
+				mu               sync.Mutex
+				running, maxSeen int
+				deleted          = map[string]bool{}
+			)
+			err := o.destroyItems(context.Background(), items, func(item cloudResource) (bool, error) {
+				mu.Lock()
+				running++
+				if running > maxSeen {
+					maxSeen = running
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				defer mu.Unlock()
+				running--
+				if item.name == tc.failing {
+					return false, errors.New("not allowed")
+				}
+				deleted[item.name] = true
+				return true, nil
+			})
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Len(t, deleted, len(items)-1)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, deleted, len(items))
+			}
+			assert.LessOrEqual(t, maxSeen, tc.parallelism)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/IBM/networking-go-sdk/dnszonesv1"
 	"github.com/IBM/networking-go-sdk/resourcerecordsv1"
 	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
 	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -33,6 +34,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
@@ -152,6 +154,11 @@ type ClusterUninstaller struct {
 	ServiceGUID    string
 	VPCRegion      string
 	Zone           string
+	// ServiceEndpoints are the custom endpoints of the IBM Cloud services.
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint
+	// UserTags are the user tags of the resources of the cluster, which
+	// are listed by tag when they are not found by name.
+	UserTags []string
 
 	managementSvc         *resourcemanagerv2.ResourceManagerV2
 	controllerSvc         *resourcecontrollerv2.ResourceControllerV2
@@ -167,6 +174,9 @@ type ClusterUninstaller struct {
 	keyClient             *instance.IBMPIKeyClient
 	cloudConnectionClient *instance.IBMPICloudConnectionClient
 	dhcpClient            *instance.IBMPIDhcpClient
+	snapshotClient        *instance.IBMPISnapshotClient
+	cosAuthenticator      core.Authenticator
+	searchSvc             *globalsearchv2.GlobalSearchV2
 
 	resourceGroupID string
 	cosInstanceID   string
@@ -174,6 +184,7 @@ type ClusterUninstaller struct {
 
 	errorTracker
 	pendingItemTracker
+	providers.CompletedStages
 }
 
 // New returns an IBMCloud destroyer from ClusterMetadata.
@@ -184,7 +195,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		err      error
 	)
 
-	bxClient, err = powervs.NewBxClient()
+	bxClient, err = powervs.NewBxClient(metadata.ClusterPlatformMetadata.PowerVS.ServiceEndpoints)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +224,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		metadata.ClusterPlatformMetadata.PowerVS.VPCRegion = derivedVPCRegion
 	}
 
-	return &ClusterUninstaller{
+	o := &ClusterUninstaller{
 		APIKey:             APIKey,
 		BaseDomain:         metadata.ClusterPlatformMetadata.PowerVS.BaseDomain,
 		ClusterName:        metadata.ClusterName,
@@ -226,9 +237,16 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		ServiceGUID:        metadata.ClusterPlatformMetadata.PowerVS.ServiceInstanceGUID,
 		VPCRegion:          metadata.ClusterPlatformMetadata.PowerVS.VPCRegion,
 		Zone:               metadata.ClusterPlatformMetadata.PowerVS.Zone,
+		ServiceEndpoints:   metadata.ClusterPlatformMetadata.PowerVS.ServiceEndpoints,
+		UserTags:           metadata.ClusterPlatformMetadata.PowerVS.UserTags,
 		pendingItemTracker: newPendingItemTracker(),
 		resourceGroupID:    metadata.ClusterPlatformMetadata.PowerVS.PowerVSResourceGroup,
-	}, nil
+	}
+	if metadata.Destroy != nil {
+		logger.Infof("Resuming the destroy, checking the deleted %s last", strings.Join(metadata.Destroy.Completed, ", "))
+		o.MarkCompleted(metadata.Destroy.Completed...)
+	}
+	return o, nil
 }
 
 // Run is the entrypoint to start the uninstall process.
@@ -270,6 +288,12 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 	return nil, err
 }
 
+// RunWithContext is Run, stopping when the context is done.
+func (o *ClusterUninstaller) RunWithContext(ctx context.Context) (*types.ClusterQuota, error) {
+	o.Context = ctx
+	return o.Run()
+}
+
 // PolledRun is the Run function which will be called with Polling.
 func (o *ClusterUninstaller) PolledRun() (bool, error) {
 	o.Logger.Debugf("powervs.PolledRun")
@@ -291,12 +315,66 @@ func (o *ClusterUninstaller) PolledRun() (bool, error) {
 	return true, nil
 }
 
+// ListResources returns the resources of the cluster which destroying the
+// cluster would delete.
+func (o *ClusterUninstaller) ListResources() ([]providers.Resource, error) {
+	err := o.loadSDKServices()
+	if err != nil {
+		return nil, err
+	}
+
+	listFuncs := []func() (cloudResources, error){
+		o.listCloudInstances,
+		o.listPowerInstances,
+		o.listLoadBalancers,
+		o.listSubnets,
+		o.listPublicGateways,
+		o.listDHCPNetworks,
+		o.listCloudConnections,
+		o.listImages,
+		o.listPowerSnapshots,
+		o.listCOSBuckets,
+		o.listVPCs,
+		o.listSecurityGroups,
+		o.listCOSInstances,
+		o.listCloudSSHKeys,
+		o.listPowerSSHKeys,
+	}
+	// Install config didn't specify using these resources
+	if o.dnsRecordsSvc != nil {
+		listFuncs = append(listFuncs, o.listDNSRecords)
+	}
+	if o.resourceRecordsSvc != nil {
+		listFuncs = append(listFuncs, o.listResourceRecords)
+	}
+
+	list := []providers.Resource{}
+	for _, listFunc := range listFuncs {
+		items, err := listFunc()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items.list() {
+			list = append(list, providers.Resource{
+				Type: item.typeName,
+				ID:   item.id,
+				Name: item.name,
+			})
+		}
+	}
+	return list, nil
+}
+
+// stageFunc deletes the resources of a kind.
+type stageFunc struct {
+	name    string
+	execute func() error
+}
+
 func (o *ClusterUninstaller) destroyCluster() error {
-	stagedFuncs := [][]struct {
-		name    string
-		execute func() error
-	}{{
+	stagedFuncs := [][]stageFunc{{
 		{name: "Cloud Instances", execute: o.destroyCloudInstances},
+		{name: "Power Snapshots", execute: o.destroyPowerSnapshots},
 	}, {
 		{name: "Power Instances", execute: o.destroyPowerInstances},
 	}, {
@@ -311,6 +389,7 @@ func (o *ClusterUninstaller) destroyCluster() error {
 		{name: "Cloud Connections", execute: o.destroyCloudConnections},
 	}, {
 		{name: "Images", execute: o.destroyImages},
+		{name: "Cloud Object Storage Buckets", execute: o.destroyCOSBuckets},
 		{name: "VPCs", execute: o.destroyVPCs},
 	}, {
 		{name: "Security Groups", execute: o.destroySecurityGroups},
@@ -321,36 +400,34 @@ func (o *ClusterUninstaller) destroyCluster() error {
 	}, {
 		{name: "DNS Records", execute: o.destroyDNSRecords},
 		{name: "DNS Resource Records", execute: o.destroyResourceRecords},
+	}, {
+		{name: "Power Workspaces", execute: o.destroyWorkspaces},
 	}}
 
+	// The resources deleted by an earlier destroy may have been created
+	// again since, e.g. by the machine API while the earlier destroy ran, so
+	// their stages are skipped until the other stages complete, and then run
+	// again in their order to delete the resources which reappeared.
+	var skippedStages [][]stageFunc
 	for _, stage := range stagedFuncs {
-		var wg sync.WaitGroup
-		errCh := make(chan error)
-		wgDone := make(chan bool)
-
+		var pending, skipped []stageFunc
 		for _, f := range stage {
-			wg.Add(1)
-			// Start a parallel goroutine
-			go o.executeStageFunction(f, errCh, &wg)
+			if o.IsCompleted(f.name) {
+				o.Logger.Debugf("destroyCluster: Deferring %s, deleted by an earlier destroy", f.name)
+				skipped = append(skipped, f)
+				continue
+			}
+			pending = append(pending, f)
 		}
-
-		// Start a parallel goroutine
-		go func() {
-			wg.Wait()
-			close(wgDone)
-		}()
-
-		select {
-		// Did the wait group goroutine finish?
-		case <-wgDone:
-			// On to the next stage
-			o.Logger.Debugf("destroyCluster: <-wgDone")
-			continue
-		// Have we taken too long?
-		case <-time.After(stageTimeout):
-			return fmt.Errorf("destroyCluster: timed out")
-		// Has an error been sent via the channel?
-		case err := <-errCh:
+		if len(skipped) > 0 {
+			skippedStages = append(skippedStages, skipped)
+		}
+		if err := o.executeStage(pending); err != nil {
+			return err
+		}
+	}
+	for _, stage := range skippedStages {
+		if err := o.executeStage(stage); err != nil {
 			return err
 		}
 	}
@@ -358,10 +435,46 @@ func (o *ClusterUninstaller) destroyCluster() error {
 	return nil
 }
 
-func (o *ClusterUninstaller) executeStageFunction(f struct {
-	name    string
-	execute func() error
-}, errCh chan error, wg *sync.WaitGroup) error {
+// executeStage runs the functions of a stage in parallel, and returns when
+// they all complete, or when one of them fails.
+func (o *ClusterUninstaller) executeStage(stage []stageFunc) error {
+	var wg sync.WaitGroup
+	// The functions still running when the stage returns must not block
+	// on their errors.
+	errCh := make(chan error, len(stage))
+	wgDone := make(chan bool)
+
+	for _, f := range stage {
+		wg.Add(1)
+		// Start a parallel goroutine
+		go o.executeStageFunction(f, errCh, &wg)
+	}
+
+	// Start a parallel goroutine
+	go func() {
+		wg.Wait()
+		close(wgDone)
+	}()
+
+	select {
+	// Did the wait group goroutine finish?
+	case <-wgDone:
+		// On to the next stage
+		o.Logger.Debugf("destroyCluster: <-wgDone")
+		return nil
+	// Have we taken too long?
+	case <-time.After(stageTimeout):
+		return fmt.Errorf("destroyCluster: timed out")
+	// Was the destroy stopped?
+	case <-o.Context.Done():
+		return o.Context.Err()
+	// Has an error been sent via the channel?
+	case err := <-errCh:
+		return err
+	}
+}
+
+func (o *ClusterUninstaller) executeStageFunction(f stageFunc, errCh chan error, wg *sync.WaitGroup) error {
 	o.Logger.Debugf("executeStageFunction: Adding: %s", f.name)
 
 	defer wg.Done()
@@ -410,14 +523,16 @@ func (o *ClusterUninstaller) executeStageFunction(f struct {
 
 	if err != nil {
 		errCh <- err
+		return nil
 	}
+	o.MarkCompleted(f.name)
 	return nil
 }
 
 func (o *ClusterUninstaller) loadSDKServices() error {
 	var (
 		bxSession             *bxsession.Session
-		tokenProviderEndpoint = "https://iam.cloud.ibm.com" //nolint:gosec // not a credential despite `token` in its name
+		tokenProviderEndpoint = o.serviceURL(powervstypes.IAMServiceEndpointName, "https://iam.cloud.ibm.com")
 		tokenRefresher        *authentication.IAMAuthRepository
 		err                   error
 		ctrlv2                controllerv2.ResourceControllerAPIV2
@@ -474,7 +589,11 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 		return fmt.Errorf("loadSDKServices: fetchUserDetails: %v", err)
 	}
 
-	ctrlv2, err = controllerv2.New(bxSession)
+	controllerSession := bxSession
+	if endpoint := o.serviceURL(powervstypes.ResourceControllerServiceEndpointName, ""); endpoint != "" {
+		controllerSession = bxSession.Copy(&bluemix.Config{Endpoint: &endpoint})
+	}
+	ctrlv2, err = controllerv2.New(controllerSession)
 	if err != nil {
 		return fmt.Errorf("loadSDKServices: controllerv2.New: %v", err)
 	}
@@ -496,6 +615,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 
 	var authenticator core.Authenticator = &core.IamAuthenticator{
 		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 	}
 
 	err = authenticator.Validate()
@@ -508,6 +628,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 		Debug:         false,
 		UserAccount:   user.Account,
 		Zone:          serviceInstance.RegionID,
+		URL:           o.serviceURL(powervstypes.PowerServiceEndpointName, ""),
 	}
 
 	o.piSession, err = ibmpisession.NewIBMPISession(options)
@@ -548,8 +669,21 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 		return fmt.Errorf("loadSDKServices: loadSDKServices: o.dhcpClient is nil")
 	}
 
+	o.snapshotClient = instance.NewIBMPISnapshotClient(context.Background(), o.piSession, o.ServiceGUID)
+	if o.snapshotClient == nil {
+		return fmt.Errorf("loadSDKServices: loadSDKServices: o.snapshotClient is nil")
+	}
+
+	// The S3 API of COS, which holds the buckets of the cluster, has no SDK
+	// here and is called with the bearer token of the API key.
+	o.cosAuthenticator = &core.IamAuthenticator{
+		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
+	}
+
 	authenticator = &core.IamAuthenticator{
 		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 	}
 
 	err = authenticator.Validate()
@@ -560,7 +694,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 	// https://raw.githubusercontent.com/IBM/vpc-go-sdk/master/vpcv1/vpc_v1.go
 	o.vpcSvc, err = vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
 		Authenticator: authenticator,
-		URL:           "https://" + o.VPCRegion + ".iaas.cloud.ibm.com/v1",
+		URL:           o.serviceURL(powervstypes.VPCServiceEndpointName, "https://"+o.VPCRegion+".iaas.cloud.ibm.com/v1"),
 	})
 	if err != nil {
 		return fmt.Errorf("loadSDKServices: loadSDKServices: vpcv1.NewVpcV1: %v", err)
@@ -571,6 +705,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 
 	authenticator = &core.IamAuthenticator{
 		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 	}
 
 	err = authenticator.Validate()
@@ -587,6 +722,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 
 	authenticator = &core.IamAuthenticator{
 		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 	}
 
 	err = authenticator.Validate()
@@ -597,7 +733,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 	o.controllerSvc, err = resourcecontrollerv2.NewResourceControllerV2(&resourcecontrollerv2.ResourceControllerV2Options{
 		Authenticator: authenticator,
 		ServiceName:   "cloud-object-storage",
-		URL:           "https://resource-controller.cloud.ibm.com",
+		URL:           o.serviceURL(powervstypes.ResourceControllerServiceEndpointName, "https://resource-controller.cloud.ibm.com"),
 	})
 	if err != nil {
 		return fmt.Errorf("loadSDKServices: loadSDKServices: creating ControllerV2 Service: %v", err)
@@ -608,6 +744,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 	if len(o.CISInstanceCRN) > 0 {
 		authenticator = &core.IamAuthenticator{
 			ApiKey: o.APIKey,
+			URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 		}
 
 		err = authenticator.Validate()
@@ -651,6 +788,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 	if len(o.DNSInstanceCRN) > 0 {
 		authenticator = &core.IamAuthenticator{
 			ApiKey: o.APIKey,
+			URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
 		}
 
 		err = authenticator.Validate()
@@ -659,6 +797,7 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 
 		o.dnsZonesSvc, err = dnszonesv1.NewDnsZonesV1(&dnszonesv1.DnsZonesV1Options{
 			Authenticator: authenticator,
+			URL:           o.serviceURL(powervstypes.DNSServicesServiceEndpointName, ""),
 		})
 		if err != nil {
 			return fmt.Errorf("loadSDKServices: loadSDKServices: creating zonesSvc: %v", err)
@@ -684,13 +823,23 @@ func (o *ClusterUninstaller) loadSDKServices() error {
 
 		o.resourceRecordsSvc, err = resourcerecordsv1.NewResourceRecordsV1(&resourcerecordsv1.ResourceRecordsV1Options{
 			Authenticator: authenticator,
+			URL:           o.serviceURL(powervstypes.DNSServicesServiceEndpointName, ""),
 		})
 		if err != nil {
 			return fmt.Errorf("loadSDKServices: loadSDKServices: Failed to instantiate resourceRecordsSvc: %v", err)
 		}
 	}
 
-	return nil
+	return o.loadSearchService()
+}
+
+// serviceURL returns the custom endpoint of the service with the name, or
+// defaultURL when the endpoint of the service is not overridden.
+func (o *ClusterUninstaller) serviceURL(name string, defaultURL string) string {
+	if u := powervstypes.ServiceEndpointURL(o.ServiceEndpoints, name); u != "" {
+		return u
+	}
+	return defaultURL
 }
 
 func (o *ClusterUninstaller) contextWithTimeout() (context.Context, context.CancelFunc) {
@@ -744,18 +893,23 @@ func aggregateError(errs []error, pending ...int) error {
 }
 
 // pendingItemTracker tracks a set of pending item names for a given type of resource.
+// It is safe for concurrent use.
 type pendingItemTracker struct {
+	mu           *sync.Mutex
 	pendingItems map[string]cloudResources
 }
 
 func newPendingItemTracker() pendingItemTracker {
 	return pendingItemTracker{
+		mu:           &sync.Mutex{},
 		pendingItems: map[string]cloudResources{},
 	}
 }
 
 // GetAllPendintItems returns a slice of all of the pending items across all types.
 func (t pendingItemTracker) GetAllPendingItems() []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	var items []cloudResource
 	for _, is := range t.pendingItems {
 		for _, i := range is {
@@ -767,6 +921,8 @@ func (t pendingItemTracker) GetAllPendingItems() []cloudResource {
 
 // getPendingItems returns the list of resources to be deleted.
 func (t pendingItemTracker) getPendingItems(itemType string) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
@@ -776,6 +932,8 @@ func (t pendingItemTracker) getPendingItems(itemType string) []cloudResource {
 
 // insertPendingItems adds to the list of resources to be deleted.
 func (t pendingItemTracker) insertPendingItems(itemType string, items []cloudResource) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
@@ -787,6 +945,8 @@ func (t pendingItemTracker) insertPendingItems(itemType string, items []cloudRes
 
 // deletePendingItems removes from the list of resources to be deleted.
 func (t pendingItemTracker) deletePendingItems(itemType string, items []cloudResource) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
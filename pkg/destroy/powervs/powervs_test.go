@@ -0,0 +1,19 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOSEndpoint(t *testing.T) {
+	assert.Equal(t, "https://s3.us-south.cloud-object-storage.appdomain.cloud", cosEndpoint("us-south-smart"))
+	assert.Equal(t, "https://s3.eu-de.cloud-object-storage.appdomain.cloud", cosEndpoint("eu-de-onerate_active"))
+	assert.Equal(t, "https://s3.us.cloud-object-storage.appdomain.cloud", cosEndpoint("us-standard"))
+	assert.Equal(t, "https://s3.jp-tok.cloud-object-storage.appdomain.cloud", cosEndpoint("jp-tok"))
+}
+
+func TestTaggedQuery(t *testing.T) {
+	assert.Equal(t, `tags:"mycluster-a1b2c" AND tags:"env:prod" AND tags:"cost center" AND type:vpc`, taggedQuery("mycluster-a1b2c", []string{"env:prod", "cost center"}, "type:vpc"))
+	assert.Equal(t, `tags:"mycluster-a1b2c" AND service_name:power-iaas AND type:resource-instance`, taggedQuery("mycluster-a1b2c", nil, "service_name:power-iaas", "type:resource-instance"))
+}
@@ -0,0 +1,151 @@
+package powervs
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const powerSnapshotTypeName = "powerSnapshot"
+
+// listPowerSnapshots lists the volume snapshots of the instances in the power
+// server.
+func (o *ClusterUninstaller) listPowerSnapshots() (cloudResources, error) {
+	o.Logger.Debugf("Listing Power snapshots (%s)", o.InfraID)
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		o.Logger.Debugf("listPowerSnapshots: case <-ctx.Done()")
+		return nil, o.Context.Err() // we're cancelled, abort
+	default:
+	}
+
+	snapshots, err := o.snapshotClient.GetAll()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list snapshots")
+	}
+
+	var foundOne = false
+
+	result := []cloudResource{}
+	for _, snapshot := range snapshots.Snapshots {
+		// https://github.com/IBM-Cloud/power-go-client/blob/master/power/models/snapshot.go
+		if snapshot.Name == nil || snapshot.SnapshotID == nil {
+			continue
+		}
+		if strings.Contains(*snapshot.Name, o.InfraID) {
+			foundOne = true
+			o.Logger.Debugf("listPowerSnapshots: FOUND: %s, %s, %s", *snapshot.SnapshotID, *snapshot.Name, snapshot.Status)
+			result = append(result, cloudResource{
+				key:      *snapshot.SnapshotID,
+				name:     *snapshot.Name,
+				status:   snapshot.Status,
+				typeName: powerSnapshotTypeName,
+				id:       *snapshot.SnapshotID,
+			})
+		}
+	}
+	if !foundOne {
+		o.Logger.Debugf("listPowerSnapshots: NO matching snapshot against: %s", o.InfraID)
+	}
+
+	return cloudResources{}.insert(result...), nil
+}
+
+func (o *ClusterUninstaller) deletePowerSnapshot(item cloudResource) error {
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		o.Logger.Debugf("deletePowerSnapshot: case <-ctx.Done()")
+		return o.Context.Err() // we're cancelled, abort
+	default:
+	}
+
+	snapshot, err := o.snapshotClient.Get(item.id)
+	if err != nil {
+		o.Logger.Debugf("deletePowerSnapshot: snapshot %q no longer exists", item.name)
+		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.Logger.Infof("Deleted Power Snapshot %q", item.name)
+		return nil
+	}
+
+	// A snapshot which is being captured or restored cannot be deleted yet.
+	if strings.EqualFold(snapshot.Status, "deleting") || strings.EqualFold(snapshot.Action, "capture") || strings.EqualFold(snapshot.Action, "restore") {
+		o.Logger.Debugf("Waiting for snapshot %q (%s %s)", item.name, snapshot.Status, snapshot.Action)
+		return nil
+	}
+
+	err = o.snapshotClient.Delete(item.id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete snapshot %s", item.name)
+	}
+
+	o.Logger.Infof("Deleted Power Snapshot %q", item.name)
+	o.deletePendingItems(item.typeName, []cloudResource{item})
+
+	return nil
+}
+
+// destroyPowerSnapshots removes the volume snapshots that have a name
+// containing the cluster's infra ID.
+func (o *ClusterUninstaller) destroyPowerSnapshots() error {
+	firstPassList, err := o.listPowerSnapshots()
+	if err != nil {
+		return err
+	}
+
+	if len(firstPassList.list()) == 0 {
+		return nil
+	}
+
+	items := o.insertPendingItems(powerSnapshotTypeName, firstPassList.list())
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.deletePowerSnapshot(item)
+		if err2 == nil {
+			return true, err2
+		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyPowerSnapshots: destroyItems returns ", err)
+	}
+
+	if items = o.getPendingItems(powerSnapshotTypeName); len(items) > 0 {
+		for _, item := range items {
+			o.Logger.Debugf("destroyPowerSnapshots: found %s in pending items", item.name)
+		}
+		return errors.Errorf("destroyPowerSnapshots: %d undeleted items pending", len(items))
+	}
+
+	backoff := resourceBackoff(ctx, powerSnapshotTypeName)
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		secondPassList, err2 := o.listPowerSnapshots()
+		if err2 != nil {
+			return false, err2
+		}
+		if len(secondPassList) == 0 {
+			// We finally don't see any remaining instances!
+			return true, nil
+		}
+		for _, item := range secondPassList {
+			o.Logger.Debugf("destroyPowerSnapshots: found %s in second pass", item.name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyPowerSnapshots: ExponentialBackoffWithContext (list) returns ", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package powervs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/crn"
+	"github.com/IBM/go-sdk-core/v5/core"
+	// https://github.com/IBM/platform-services-go-sdk/blob/v0.18.16/globalsearchv2/global_search_v2.go
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
+	"github.com/pkg/errors"
+
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+// taggedResourceID returns the ID of a tagged resource, used by the API which
+// deletes it, from its CRN.
+type taggedResourceID func(c crn.CRN, raw string) string
+
+var (
+	// resourceInstanceCRN is the ID of the resource controller instances,
+	// e.g. the workspaces.
+	resourceInstanceCRN taggedResourceID = func(c crn.CRN, raw string) string { return raw }
+	// resourceInstanceGUID is the GUID of the resource controller instances,
+	// e.g. the COS instances.
+	resourceInstanceGUID taggedResourceID = func(c crn.CRN, raw string) string { return c.ServiceInstance }
+	// vpcResourceID is the ID of the VPC resources, e.g. the load balancers.
+	vpcResourceID taggedResourceID = func(c crn.CRN, raw string) string { return c.Resource }
+)
+
+// taggedQuery returns the global search query of the resources matching the
+// conditions and tagged with the infra ID and all the user tags.
+func taggedQuery(infraID string, userTags []string, conditions ...string) string {
+	terms := make([]string, 0, len(userTags)+len(conditions)+1)
+	for _, tag := range append([]string{infraID}, userTags...) {
+		terms = append(terms, fmt.Sprintf("tags:%q", tag))
+	}
+	return strings.Join(append(terms, conditions...), " AND ")
+}
+
+// listTaggedResources lists, through the global search service, the resources
+// matching the conditions and tagged with the infra ID and the user tags of
+// the cluster. It is the fallback of the listing by name: clusters without
+// user tags were created by installers which did not tag their resources, so
+// nothing is listed for them.
+func (o *ClusterUninstaller) listTaggedResources(typeName string, resourceID taggedResourceID, conditions ...string) (cloudResources, error) {
+	if o.searchSvc == nil {
+		return cloudResources{}, nil
+	}
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	query := taggedQuery(o.InfraID, o.UserTags, conditions...)
+	o.Logger.Debugf("Listing %s resources by tag: %s", typeName, query)
+
+	result := []cloudResource{}
+	options := o.searchSvc.NewSearchOptions()
+	options.SetQuery(query)
+	options.SetFields([]string{"crn", "name"})
+	options.SetLimit(100)
+	for {
+		scan, _, err := o.searchSvc.SearchWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to search the %s resources by tag", typeName)
+		}
+		for _, item := range scan.Items {
+			if item.CRN == nil {
+				continue
+			}
+			parsed, err := crn.Parse(*item.CRN)
+			if err != nil {
+				o.Logger.Debugf("listTaggedResources: skipping %s: %v", *item.CRN, err)
+				continue
+			}
+			name, _ := item.GetProperty("name").(string)
+			id := resourceID(parsed, *item.CRN)
+			o.Logger.Debugf("listTaggedResources: FOUND %s %s %s", typeName, name, id)
+			result = append(result, cloudResource{
+				key:      id,
+				name:     name,
+				typeName: typeName,
+				id:       id,
+			})
+		}
+		if scan.SearchCursor == nil || len(scan.Items) == 0 {
+			break
+		}
+		options.SetSearchCursor(*scan.SearchCursor)
+	}
+
+	return cloudResources{}.insert(result...), nil
+}
+
+// loadSearchService creates the client of the global search service, when
+// the resources of the cluster are tagged.
+func (o *ClusterUninstaller) loadSearchService() error {
+	if len(o.UserTags) == 0 {
+		return nil
+	}
+	authenticator := &core.IamAuthenticator{
+		ApiKey: o.APIKey,
+		URL:    o.serviceURL(powervstypes.IAMServiceEndpointName, ""),
+	}
+	svc, err := globalsearchv2.NewGlobalSearchV2(&globalsearchv2.GlobalSearchV2Options{
+		Authenticator: authenticator,
+		URL:           o.serviceURL(powervstypes.GlobalSearchServiceEndpointName, globalsearchv2.DefaultServiceURL),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the global search service")
+	}
+	o.searchSvc = svc
+	return nil
+}
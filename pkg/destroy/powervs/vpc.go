@@ -1,10 +1,8 @@
 package powervs
 
 import (
-	"math"
 	gohttp "net/http"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -56,6 +54,7 @@ func (o *ClusterUninstaller) listVPCs() (cloudResources, error) {
 		for _, vpc := range vpcs.Vpcs {
 			o.Logger.Debugf("listVPCs: vpc: %s", *vpc.Name)
 		}
+		return o.listTaggedResources(vpcTypeName, vpcResourceID, "type:vpc")
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -131,30 +130,16 @@ func (o *ClusterUninstaller) destroyVPCs() error {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	for _, item := range items {
-		select {
-		case <-ctx.Done():
-			o.Logger.Debugf("destroyVPCs: case <-ctx.Done()")
-			return o.Context.Err() // we're cancelled, abort
-		default:
-		}
-
-		backoff := wait.Backoff{
-			Duration: 15 * time.Second,
-			Factor:   1.1,
-			Cap:      leftInContext(ctx),
-			Steps:    math.MaxInt32}
-		err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
-			err2 := o.deleteVPC(item)
-			if err2 == nil {
-				return true, err2
-			}
-			o.errorTracker.suppressWarning(item.key, err2, o.Logger)
-			return false, err2
-		})
-		if err != nil {
-			o.Logger.Fatal("destroyVPCs: ExponentialBackoffWithContext (destroy) returns ", err)
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.deleteVPC(item)
+		if err2 == nil {
+			return true, err2
 		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		o.Logger.Fatal("destroyVPCs: destroyItems returns ", err)
 	}
 
 	if items = o.getPendingItems(vpcTypeName); len(items) > 0 {
@@ -164,11 +149,7 @@ func (o *ClusterUninstaller) destroyVPCs() error {
 		return errors.Errorf("destroyVPCs: %d undeleted items pending", len(items))
 	}
 
-	backoff := wait.Backoff{
-		Duration: 15 * time.Second,
-		Factor:   1.1,
-		Cap:      leftInContext(ctx),
-		Steps:    math.MaxInt32}
+	backoff := resourceBackoff(ctx, vpcTypeName)
 	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
 		secondPassList, err2 := o.listVPCs()
 		if err2 != nil {
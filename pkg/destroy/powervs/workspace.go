@@ -0,0 +1,136 @@
+package powervs
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+)
+
+const workspaceTypeName = "workspace"
+
+// $ ibmcloud catalog service power-iaas --output json | jq -r '.[].id'
+// abd259f0-9990-11e8-acc8-b9f54a8f1661.
+const powerIAASResourceID = "abd259f0-9990-11e8-acc8-b9f54a8f1661"
+
+// listWorkspaces lists the Power VS workspace created by the installer for the
+// cluster. Workspaces provided by the user have other names and no tags of
+// the cluster, so they are never listed.
+func (o *ClusterUninstaller) listWorkspaces() (cloudResources, error) {
+	o.Logger.Debugf("Listing workspaces")
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	options := o.controllerSvc.NewListResourceInstancesOptions()
+	options.SetResourceID(powerIAASResourceID)
+	options.SetName(powervstypes.WorkspaceName(o.InfraID))
+	options.SetType("service_instance")
+
+	resources, _, err := o.controllerSvc.ListResourceInstancesWithContext(ctx, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list workspaces")
+	}
+
+	result := []cloudResource{}
+	for _, instance := range resources.Resources {
+		if *instance.State == "removed" || *instance.State == "pending_reclamation" {
+			continue
+		}
+		o.Logger.Debugf("listWorkspaces: FOUND %s %s", *instance.Name, *instance.GUID)
+		result = append(result, cloudResource{
+			key:      *instance.ID,
+			name:     *instance.Name,
+			status:   *instance.State,
+			typeName: workspaceTypeName,
+			id:       *instance.ID,
+		})
+	}
+	if len(result) == 0 {
+		return o.listTaggedResources(workspaceTypeName, resourceInstanceCRN, "service_name:power-iaas", "type:resource-instance")
+	}
+
+	return cloudResources{}.insert(result...), nil
+}
+
+func (o *ClusterUninstaller) destroyWorkspace(item cloudResource) error {
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	getOptions := o.controllerSvc.NewGetResourceInstanceOptions(item.id)
+	_, response, err := o.controllerSvc.GetResourceInstanceWithContext(ctx, getOptions)
+	if err != nil && response != nil && response.StatusCode == http.StatusNotFound {
+		// The resource is gone
+		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.Logger.Infof("Deleted Workspace %q", item.name)
+		return nil
+	}
+
+	options := o.controllerSvc.NewDeleteResourceInstanceOptions(item.id)
+	options.SetRecursive(true)
+	response, err = o.controllerSvc.DeleteResourceInstanceWithContext(ctx, options)
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return errors.Wrapf(err, "failed to delete workspace %s", item.name)
+	}
+
+	// Reclaim the workspace right away, so that its name and quota are freed.
+	if _, reclamation := o.findReclaimedInstance(item); reclamation != nil {
+		reclamationActionOptions := o.controllerSvc.NewRunReclamationActionOptions(*reclamation.ID, "reclaim")
+		if _, _, err = o.controllerSvc.RunReclamationActionWithContext(ctx, reclamationActionOptions); err != nil {
+			return errors.Wrapf(err, "failed to reclaim workspace %s", item.name)
+		}
+	}
+
+	o.Logger.Infof("Deleted Workspace %q", item.name)
+	o.deletePendingItems(item.typeName, []cloudResource{item})
+
+	return nil
+}
+
+// destroyWorkspaces removes the Power VS workspace created by the installer
+// for the cluster, once all the resources inside it are gone.
+func (o *ClusterUninstaller) destroyWorkspaces() error {
+	firstPassList, err := o.listWorkspaces()
+	if err != nil {
+		return err
+	}
+
+	if len(firstPassList.list()) == 0 {
+		return nil
+	}
+
+	items := o.insertPendingItems(workspaceTypeName, firstPassList.list())
+
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	err = o.destroyItems(ctx, items, func(item cloudResource) (bool, error) {
+		err2 := o.destroyWorkspace(item)
+		if err2 == nil {
+			return true, err2
+		}
+		o.errorTracker.suppressWarning(item.key, err2, o.Logger)
+		return false, err2
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to destroy workspaces")
+	}
+
+	if items = o.getPendingItems(workspaceTypeName); len(items) > 0 {
+		return errors.Errorf("destroyWorkspaces: %d undeleted items pending", len(items))
+	}
+
+	backoff := resourceBackoff(ctx, workspaceTypeName)
+	return wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		secondPassList, err2 := o.listWorkspaces()
+		if err2 != nil {
+			return false, err2
+		}
+		for _, item := range secondPassList {
+			o.Logger.Debugf("destroyWorkspaces: found %s in second pass", item.name)
+		}
+		return len(secondPassList) == 0, nil
+	})
+}
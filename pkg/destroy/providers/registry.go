@@ -2,3 +2,7 @@ package providers
 
 // Registry maps ClusterMetadata.Platform() to per-platform Destroyer creators.
 var Registry = make(map[string]NewFunc)
+
+// DiscoveryRegistry maps platform names to the functions discovering the
+// metadata of the clusters of the platform from their cloud resources.
+var DiscoveryRegistry = make(map[string]DiscoverFunc)
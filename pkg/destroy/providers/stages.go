@@ -0,0 +1,48 @@
+package providers
+
+import (
+	"sort"
+	"sync"
+)
+
+// CompletedStages tracks the names of the stages of a destroy, i.e. kinds of
+// resources, which deleted all of their resources, including those of
+// earlier destroys. Embedded in a destroyer, it implements the Completed
+// method of Resumable.
+// It is safe for concurrent use.
+type CompletedStages struct {
+	mu        sync.Mutex
+	completed map[string]bool
+}
+
+// IsCompleted returns true if the stage deleted all of its resources.
+func (t *CompletedStages) IsCompleted(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.completed[name]
+}
+
+// MarkCompleted records that the stages deleted all of their resources.
+func (t *CompletedStages) MarkCompleted(names ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.completed == nil {
+		t.completed = map[string]bool{}
+	}
+	for _, name := range names {
+		t.completed[name] = true
+	}
+}
+
+// Completed returns the names of the stages which deleted all of their
+// resources, sorted.
+func (t *CompletedStages) Completed() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.completed))
+	for name := range t.completed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,19 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletedStages(t *testing.T) {
+	stages := CompletedStages{}
+	assert.False(t, stages.IsCompleted("VPCs"))
+	assert.Empty(t, stages.Completed())
+
+	stages.MarkCompleted("VPCs", "Cloud Instances")
+	stages.MarkCompleted("VPCs")
+	assert.True(t, stages.IsCompleted("VPCs"))
+	assert.False(t, stages.IsCompleted("Subnets"))
+	assert.Equal(t, []string{"Cloud Instances", "VPCs"}, stages.Completed())
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/types"
@@ -12,5 +14,39 @@ type Destroyer interface {
 	Run() (*types.ClusterQuota, error)
 }
 
+// Resource is a cloud resource which destroying the cluster deletes.
+type Resource struct {
+	// Type is the platform-specific type of the resource.
+	Type string `json:"type"`
+	// ID uniquely identifies the resource on the platform.
+	ID string `json:"id"`
+	// Name is the human-friendly name of the resource, if it has one.
+	Name string `json:"name,omitempty"`
+}
+
+// ResourceLister is implemented by destroyers which can list the resources
+// they would delete without deleting any of them.
+type ResourceLister interface {
+	ListResources() ([]Resource, error)
+}
+
+// Resumable is implemented by destroyers which can be stopped before they
+// complete and resumed by a later destroy. The destroyer deletes the kinds of
+// resources completed by earlier destroys, which it reads from the
+// DestroyProgress of the cluster metadata, last, once the other kinds are
+// deleted, in case some of them were created again.
+type Resumable interface {
+	// RunWithContext destroys the cluster until the context is done.
+	RunWithContext(ctx context.Context) (*types.ClusterQuota, error)
+	// Completed returns the kinds of resources which were all deleted,
+	// including those of earlier destroys.
+	Completed() []string
+}
+
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
+
+// DiscoverFunc reconstructs the metadata of the cluster with the infra ID in
+// the region from the resources tagged with the infra ID, for destroying a
+// cluster whose metadata.json is lost.
+type DiscoverFunc func(logger logrus.FieldLogger, infraID, region string) (*types.ClusterMetadata, error)
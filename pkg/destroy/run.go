@@ -0,0 +1,70 @@
+package destroy
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ErrTimedOut is returned by Run when the destroy did not complete before its
+// timeout, leaving part of the resources of the cluster behind.
+var ErrTimedOut = errors.New("timed out before deleting all of the resources of the cluster")
+
+// Run runs the destroyer of the cluster of the directory, until the timeout
+// expires when it is not zero. When a resumable destroyer does not complete,
+// the kinds of resources it deleted are recorded in the metadata of the
+// directory, so that the next destroy resumes where it stopped. The other
+// destroyers cannot be stopped, so they do not support a timeout.
+func Run(destroyer providers.Destroyer, rootDir string, timeout time.Duration) (*types.ClusterQuota, error) {
+	resumable, ok := destroyer.(providers.Resumable)
+	if !ok {
+		if timeout > 0 {
+			return nil, errors.New("the destroyer of the platform cannot be stopped and resumed, so it does not support a timeout")
+		}
+		return destroyer.Run()
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	quota, err := resumable.RunWithContext(ctx)
+	if err == nil {
+		return quota, nil
+	}
+	if recordErr := recordProgress(rootDir, resumable.Completed()); recordErr != nil {
+		logrus.Warnf("Failed to record the progress of the destroy: %v", recordErr)
+	}
+	if ctx.Err() != nil {
+		return nil, ErrTimedOut
+	}
+	return nil, err
+}
+
+// recordProgress records the kinds of resources which were all deleted in the
+// metadata of the directory.
+func recordProgress(rootDir string, completed []string) error {
+	if len(completed) == 0 {
+		return nil
+	}
+	metadata, err := cluster.LoadMetadata(rootDir)
+	if err != nil {
+		return err
+	}
+	metadata.Destroy = &types.DestroyProgress{Completed: completed}
+	if err := cluster.WriteMetadata(rootDir, metadata); err != nil {
+		return err
+	}
+	logrus.Infof("Recorded the deleted resources (%s) in the cluster metadata, the next destroy resumes from there", strings.Join(completed, ", "))
+	return nil
+}
@@ -0,0 +1,117 @@
+package destroy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// fakeDestroyer is a destroyer which cannot be stopped.
+type fakeDestroyer struct {
+	err error
+}
+
+func (d *fakeDestroyer) Run() (*types.ClusterQuota, error) {
+	return nil, d.err
+}
+
+// fakeResumable is a resumable destroyer which completes the stages, then
+// fails with its error or, without one, waits for the context to be done.
+type fakeResumable struct {
+	completed []string
+	err       error
+}
+
+func (d *fakeResumable) Run() (*types.ClusterQuota, error) {
+	return d.RunWithContext(context.Background())
+}
+
+func (d *fakeResumable) RunWithContext(ctx context.Context) (*types.ClusterQuota, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.completed == nil {
+		return &types.ClusterQuota{}, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (d *fakeResumable) Completed() []string {
+	return d.completed
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name      string
+		destroyer providers.Destroyer
+		timeout   time.Duration
+		err       string
+		progress  *types.DestroyProgress
+	}{
+		{
+			name:      "destroyer",
+			destroyer: &fakeDestroyer{},
+		},
+		{
+			name:      "failing destroyer",
+			destroyer: &fakeDestroyer{err: errors.New("failed to delete the instances")},
+			err:       "failed to delete the instances",
+		},
+		{
+			name:      "destroyer with a timeout",
+			destroyer: &fakeDestroyer{},
+			timeout:   time.Hour,
+			err:       "the destroyer of the platform cannot be stopped and resumed, so it does not support a timeout",
+		},
+		{
+			name:      "resumable destroyer",
+			destroyer: &fakeResumable{},
+			timeout:   time.Hour,
+		},
+		{
+			name:      "timed out resumable destroyer",
+			destroyer: &fakeResumable{completed: []string{"Cloud Instances", "Power Instances"}},
+			timeout:   time.Millisecond,
+			err:       ErrTimedOut.Error(),
+			progress:  &types.DestroyProgress{Completed: []string{"Cloud Instances", "Power Instances"}},
+		},
+		{
+			name:      "failing resumable destroyer",
+			destroyer: &fakeResumable{completed: []string{"Cloud Instances"}, err: errors.New("failed to delete the subnets")},
+			err:       "failed to delete the subnets",
+			progress:  &types.DestroyProgress{Completed: []string{"Cloud Instances"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			metadata := &types.ClusterMetadata{
+				InfraID:                 "my-cluster-abcde",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{PowerVS: &powervs.Metadata{Region: "dal"}},
+			}
+			if !assert.NoError(t, cluster.WriteMetadata(dir, metadata)) {
+				return
+			}
+
+			_, err := Run(tc.destroyer, dir, tc.timeout)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			metadata, err = cluster.LoadMetadata(dir)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.progress, metadata.Destroy)
+			}
+		})
+	}
+}
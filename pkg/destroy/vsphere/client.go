@@ -13,6 +13,7 @@ import (
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
@@ -34,6 +35,8 @@ type API interface {
 	DeleteStoragePolicy(ctx context.Context, policyName string) error
 	DeleteTag(ctx context.Context, id string) error
 	DeleteTagCategory(ctx context.Context, id string) error
+	DeleteDRSRules(ctx context.Context, name string) error
+	DeleteComputePolicy(ctx context.Context, name string) error
 }
 
 // Client makes calls to the Azure API.
@@ -280,3 +283,59 @@ func (c *Client) DeleteTagCategory(ctx context.Context, id string) error {
 
 	return utilerrors.NewAggregate(errs)
 }
+
+// DeleteDRSRules deletes the DRS rules named `name` of all the compute clusters.
+func (c *Client) DeleteDRSRules(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	viewManager := view.NewManager(c.client)
+	containerView, err := viewManager.CreateContainerView(ctx, c.client.ServiceContent.RootFolder, []string{"ClusterComputeResource"}, true)
+	if err != nil {
+		return err
+	}
+	defer containerView.Destroy(ctx)
+
+	var clusters []mo.ClusterComputeResource
+	if err := containerView.Retrieve(ctx, []string{"ClusterComputeResource"}, []string{"name", "configurationEx"}, &clusters); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, cluster := range clusters {
+		config, ok := cluster.ConfigurationEx.(*types.ClusterConfigInfoEx)
+		if !ok {
+			continue
+		}
+		var rulesSpec []types.ClusterRuleSpec
+		for _, rule := range config.Rule {
+			if info := rule.GetClusterRuleInfo(); info.Name == name {
+				rulesSpec = append(rulesSpec, types.ClusterRuleSpec{
+					ArrayUpdateSpec: types.ArrayUpdateSpec{
+						Operation: types.ArrayUpdateOperationRemove,
+						RemoveKey: info.Key,
+					},
+				})
+			}
+		}
+		if len(rulesSpec) == 0 {
+			continue
+		}
+		task, err := object.NewClusterComputeResource(c.client, cluster.Reference()).Reconfigure(ctx, &types.ClusterConfigSpecEx{RulesSpec: rulesSpec}, true)
+		if err == nil {
+			err = task.Wait(ctx)
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete DRS rule %s of compute cluster %s", name, cluster.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// DeleteComputePolicy deletes the compute policy named `name`.
+func (c *Client) DeleteComputePolicy(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	return vsphere.DeleteComputePolicy(ctx, c.restClient, name)
+}
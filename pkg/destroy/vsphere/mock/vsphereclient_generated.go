@@ -35,6 +35,34 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// DeleteComputePolicy mocks base method.
+func (m *MockAPI) DeleteComputePolicy(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComputePolicy", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComputePolicy indicates an expected call of DeleteComputePolicy.
+func (mr *MockAPIMockRecorder) DeleteComputePolicy(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComputePolicy", reflect.TypeOf((*MockAPI)(nil).DeleteComputePolicy), ctx, name)
+}
+
+// DeleteDRSRules mocks base method.
+func (m *MockAPI) DeleteDRSRules(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDRSRules", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDRSRules indicates an expected call of DeleteDRSRules.
+func (mr *MockAPIMockRecorder) DeleteDRSRules(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDRSRules", reflect.TypeOf((*MockAPI)(nil).DeleteDRSRules), ctx, name)
+}
+
 // DeleteFolder mocks base method.
 func (m *MockAPI) DeleteFolder(ctx context.Context, f mo.Folder) error {
 	m.ctrl.T.Helper()
@@ -12,8 +12,10 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 
+	icvsphere "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	installertypes "github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
 // ClusterUninstaller holds the various options for the cluster we want to delete.
@@ -22,6 +24,8 @@ type ClusterUninstaller struct {
 	InfraID           string
 	terraformPlatform string
 
+	controlPlaneAntiAffinity vsphere.AntiAffinityPolicy
+
 	Logger logrus.FieldLogger
 	client API
 }
@@ -41,6 +45,8 @@ func newWithClient(logger logrus.FieldLogger, metadata *installertypes.ClusterMe
 		InfraID:           metadata.InfraID,
 		terraformPlatform: metadata.VSphere.TerraformPlatform,
 
+		controlPlaneAntiAffinity: metadata.VSphere.ControlPlaneAntiAffinity,
+
 		Logger: logger,
 		client: client,
 	}
@@ -135,6 +141,31 @@ func (o *ClusterUninstaller) deleteTagCategory(ctx context.Context) error {
 	return nil
 }
 
+func (o *ClusterUninstaller) deleteControlPlaneAntiAffinity(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	name := icvsphere.ControlPlaneAntiAffinityName(o.InfraID)
+	var err error
+	switch o.controlPlaneAntiAffinity {
+	case vsphere.AntiAffinityPolicyDRSRule:
+		err = o.client.DeleteDRSRules(ctx, name)
+	case vsphere.AntiAffinityPolicyComputePolicy:
+		err = o.client.DeleteComputePolicy(ctx, name)
+	default:
+		return nil
+	}
+
+	antiAffinityLogger := o.Logger.WithField(string(o.controlPlaneAntiAffinity), name)
+	if err != nil {
+		antiAffinityLogger.Debug(err)
+		return err
+	}
+	antiAffinityLogger.Info("Deleted")
+
+	return nil
+}
+
 func (o *ClusterUninstaller) stopVirtualMachine(ctx context.Context, vmMO mo.VirtualMachine) error {
 	virtualMachineLogger := o.Logger.WithField("VirtualMachine", vmMO.Name)
 	err := o.client.StopVirtualMachine(ctx, vmMO)
@@ -208,6 +239,7 @@ func (o *ClusterUninstaller) destroyCluster(ctx context.Context) (bool, error) {
 		name    string
 		execute func(context.Context) error
 	}{{
+		{name: "Control plane anti-affinity", execute: o.deleteControlPlaneAntiAffinity},
 		{name: "Stop virtual machines", execute: o.stopVirtualMachines},
 	}, {
 		{name: "Virtual Machines", execute: o.deleteVirtualMachines},
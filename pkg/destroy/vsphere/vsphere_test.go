@@ -584,3 +584,86 @@ func TestDeleteTagCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteControlPlaneAntiAffinity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vsphereClient := mock.NewMockAPI(mockCtrl)
+
+	ruleFails := fmt.Sprintf("%s-control-plane-anti-affinity", deleteFailsID)
+
+	drsRule := func(m *types.ClusterMetadata) {
+		m.VSphere.ControlPlaneAntiAffinity = vsphere.AntiAffinityPolicyDRSRule
+	}
+	computePolicy := func(m *types.ClusterMetadata) {
+		m.VSphere.ControlPlaneAntiAffinity = vsphere.AntiAffinityPolicyComputePolicy
+	}
+	deleteFails := func(m *types.ClusterMetadata) {
+		m.InfraID = deleteFailsID
+	}
+
+	cases := []testCase{
+		{
+			name:      "No anti-affinity",
+			editFuncs: editMetadataFuncs{deleteFails},
+			errorMsg:  "",
+		},
+		{
+			name:      "Delete DRS rules succeeds",
+			editFuncs: editMetadataFuncs{drsRule},
+			errorMsg:  "",
+		},
+		{
+			name:      "Delete DRS rules fails",
+			editFuncs: editMetadataFuncs{drsRule, deleteFails},
+			errorMsg:  "some vsphere error",
+		},
+		{
+			name:      "Delete compute policy succeeds",
+			editFuncs: editMetadataFuncs{computePolicy},
+			errorMsg:  "",
+		},
+		{
+			name:      "Delete compute policy fails",
+			editFuncs: editMetadataFuncs{computePolicy, deleteFails},
+			errorMsg:  "some vsphere error",
+		},
+	}
+
+	vsphereClient.
+		EXPECT().
+		DeleteDRSRules(gomock.Any(), gomock.Eq(ruleFails)).
+		Return(errors.New("some vsphere error deleting DRS rules")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteDRSRules(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteComputePolicy(gomock.Any(), gomock.Eq(ruleFails)).
+		Return(errors.New("some vsphere error deleting compute policy")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteComputePolicy(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			editedMetadata := newDefaultMetadata()
+			for _, edit := range tc.editFuncs {
+				edit(&editedMetadata)
+			}
+			uninstaller := newWithClient(nullLogger, &editedMetadata, vsphereClient)
+			assert.NotNil(t, uninstaller)
+			err := uninstaller.deleteControlPlaneAntiAffinity(context.TODO())
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
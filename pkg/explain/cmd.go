@@ -11,6 +11,16 @@ import (
 	"github.com/openshift/installer/data"
 )
 
+const (
+	outputText       = "text"
+	outputJSONSchema = "json-schema"
+)
+
+var opts struct {
+	output   string
+	platform string
+}
+
 // NewCmd returns a subcommand for explain
 func NewCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -26,9 +36,14 @@ installconfig.<fieldName>[.<fieldName>]
 openshift-install explain installconfig
 
 # Get the documentation of a AWS platform
-openshift-install explain installconfig.platform.aws`,
+openshift-install explain installconfig.platform.aws
+
+# Generate a JSON Schema to validate install-config.yaml files for PowerVS
+openshift-install explain installconfig --output=json-schema --platform=powervs`,
 		RunE: runCmd,
 	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", outputText, "output format, text or json-schema")
+	cmd.Flags().StringVar(&opts.platform, "platform", "", "restrict the JSON Schema of the installconfig resource to a platform")
 
 	return cmd
 }
@@ -67,7 +82,23 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return errors.Wrapf(err, "failed to load schema for the field %s", strings.Join(path, "."))
 	}
 
-	p := printer{Writer: os.Stdout}
+	switch opts.output {
+	case outputJSONSchema:
+		doc, err := jsonSchema(fschema, path, opts.platform)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate JSON Schema")
+		}
+		_, err = os.Stdout.Write(append(doc, '\n'))
+		return err
+	case outputText:
+		if opts.platform != "" {
+			return errors.Errorf("--platform is only supported with --output=%s", outputJSONSchema)
+		}
+	default:
+		return errors.Errorf("unsupported output format %q, must be %q or %q", opts.output, outputText, outputJSONSchema)
+	}
+
+	p := printer{Writer: os.Stdout, Path: path, Examples: true}
 	p.PrintKindAndVersion()
 	p.PrintResource(fschema)
 	p.PrintFields(fschema)
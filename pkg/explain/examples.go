@@ -0,0 +1,145 @@
+package explain
+
+import (
+	"strings"
+)
+
+// examples are YAML examples of the fields of the InstallConfig, keyed by the
+// dot separated path of the field. The empty path is the InstallConfig itself.
+var examples = map[string]string{
+	"": `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+controlPlane:
+  name: master
+  replicas: 3
+compute:
+- name: worker
+  replicas: 3
+networking:
+  networkType: OVNKubernetes
+  machineNetwork:
+  - cidr: 10.0.0.0/16
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths": ...}'
+sshKey: ssh-ed25519 AAAA...`,
+
+	"controlPlane": `controlPlane:
+  name: master
+  replicas: 3
+  platform:
+    aws:
+      type: m6i.xlarge`,
+
+	"compute": `compute:
+- name: worker
+  replicas: 3
+  platform:
+    aws:
+      type: m6i.xlarge
+      zones:
+      - us-east-1a`,
+
+	"networking": `networking:
+  networkType: OVNKubernetes
+  clusterNetwork:
+  - cidr: 10.128.0.0/14
+    hostPrefix: 23
+  machineNetwork:
+  - cidr: 10.0.0.0/16
+  serviceNetwork:
+  - 172.30.0.0/16`,
+
+	"proxy": `proxy:
+  httpProxy: http://proxy.example.com:3128
+  httpsProxy: http://proxy.example.com:3128
+  noProxy: .example.com,10.0.0.0/16`,
+
+	"platform.aws": `aws:
+  region: us-east-1
+  subnets:
+  - subnet-0123456789abcdef0
+  userTags:
+    owner: team-a`,
+
+	"platform.azure": `azure:
+  region: centralus
+  baseDomainResourceGroupName: os4-common
+  cloudName: AzurePublicCloud
+  outboundType: Loadbalancer`,
+
+	"platform.gcp": `gcp:
+  projectID: example-project
+  region: us-central1`,
+
+	"platform.ibmcloud": `ibmcloud:
+  region: us-south
+  resourceGroupName: example-resource-group`,
+
+	"platform.openstack": `openstack:
+  cloud: mycloud
+  externalNetwork: external
+  apiFloatingIP: 203.0.113.23`,
+
+	"platform.powervs": `powervs:
+  userID: example-user-id
+  region: dal
+  zone: dal10
+  powervsResourceGroup: example-resource-group
+  serviceInstanceID: 0123abcd-4567-89ef-0123-456789abcdef`,
+
+	"platform.powervs.defaultMachinePlatform": `defaultMachinePlatform:
+  memoryGiB: 32
+  processors: "0.5"
+  procType: Shared
+  sysType: s922`,
+
+	"platform.vsphere": `vsphere:
+  apiVIPs:
+  - 10.0.0.1
+  ingressVIPs:
+  - 10.0.0.2
+  vcenters:
+  - server: vcenter.example.com
+    user: administrator@vsphere.local
+    password: password
+    datacenters:
+    - datacenter`,
+}
+
+// machinePoolPlatformExamples are the examples of the platform configuration
+// of the control plane and compute machine pools.
+var machinePoolPlatformExamples = map[string]string{
+	"aws": `aws:
+  type: m6i.xlarge
+  zones:
+  - us-east-1a`,
+	"azure": `azure:
+  type: Standard_D8s_v3
+  osDisk:
+    diskSizeGB: 128`,
+	"gcp": `gcp:
+  type: n2-standard-4
+  zones:
+  - us-central1-a`,
+	"powervs": `powervs:
+  memoryGiB: 32
+  processors: "0.5"
+  procType: Shared
+  sysType: s922`,
+}
+
+// exampleFor returns the example of the field at the path, if any.
+func exampleFor(path []string) (string, bool) {
+	if example, ok := examples[strings.Join(path, ".")]; ok {
+		return example, true
+	}
+	if len(path) == 3 && (path[0] == "controlPlane" || path[0] == "compute") && path[1] == "platform" {
+		example, ok := machinePoolPlatformExamples[path[2]]
+		return example, ok
+	}
+	return "", false
+}
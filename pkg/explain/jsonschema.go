@@ -0,0 +1,91 @@
+package explain
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// platformProperties are the platform properties in the schema of the
+// InstallConfig. Machine pools may leave the platform empty to use the
+// defaults, so only the cluster platform is required.
+var platformProperties = []struct {
+	path     []string
+	required bool
+}{
+	{path: []string{"properties", "platform"}, required: true},
+	{path: []string{"properties", "controlPlane", "properties", "platform"}},
+	{path: []string{"properties", "compute", "items", "properties", "platform"}},
+}
+
+// jsonSchema returns the schema as a JSON Schema document. The OpenAPI v3
+// schema of the CRD is a subset of JSON Schema, so only the top-level keywords
+// are added. If platform is set, the InstallConfig schema is restricted to
+// that platform.
+func jsonSchema(schema *apiextv1.JSONSchemaProps, path []string, platform string) ([]byte, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	if platform != "" {
+		if len(path) > 0 {
+			return nil, errors.New("a platform can only be selected for the installconfig resource")
+		}
+		for _, property := range platformProperties {
+			if err := restrictPlatform(doc, property.path, platform, property.required); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	doc["$schema"] = jsonSchemaDraft
+	title := "InstallConfig"
+	for _, name := range path {
+		title += "." + name
+	}
+	doc["title"] = title
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// restrictPlatform removes all platforms but platform from the platform
+// property at the path and rejects the others, optionally making the
+// remaining one required.
+func restrictPlatform(doc map[string]interface{}, path []string, platform string, required bool) error {
+	property := doc
+	for _, key := range path {
+		next, ok := property[key].(map[string]interface{})
+		if !ok {
+			return errors.Errorf("schema has no %v property", path)
+		}
+		property = next
+	}
+
+	platforms, ok := property["properties"].(map[string]interface{})
+	if !ok {
+		return errors.Errorf("schema has no platforms in %v", path)
+	}
+	platformSchema, ok := platforms[platform]
+	if !ok {
+		names := make([]string, 0, len(platforms))
+		for name := range platforms {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return errors.Errorf("unknown platform %q, must be one of %v", platform, names)
+	}
+	property["properties"] = map[string]interface{}{platform: platformSchema}
+	property["additionalProperties"] = false
+	if required {
+		property["required"] = []string{platform}
+	}
+	return nil
+}
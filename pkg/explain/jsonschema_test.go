@@ -0,0 +1,142 @@
+package explain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func testPlatformSchema() apiextv1.JSONSchemaProps {
+	return apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"aws":     {Type: "object"},
+			"powervs": {Type: "object"},
+		},
+	}
+}
+
+func testInstallConfigSchema() *apiextv1.JSONSchemaProps {
+	pool := apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"platform": testPlatformSchema(),
+		},
+	}
+	return &apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"baseDomain":   {Type: "string"},
+			"controlPlane": pool,
+			"compute": {
+				Type:  "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{Schema: &pool},
+			},
+			"platform": testPlatformSchema(),
+		},
+		Required: []string{"baseDomain"},
+	}
+}
+
+func Test_jsonSchema(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     []string
+		platform string
+		expected string
+		err      string
+	}{{
+		name: "field",
+		path: []string{"baseDomain"},
+		expected: `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "InstallConfig.baseDomain",
+  "type": "string"
+}`,
+	}, {
+		name:     "platform",
+		platform: "powervs",
+		expected: `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "properties": {
+    "baseDomain": {
+      "type": "string"
+    },
+    "compute": {
+      "items": {
+        "properties": {
+          "platform": {
+            "additionalProperties": false,
+            "properties": {
+              "powervs": {
+                "type": "object"
+              }
+            },
+            "type": "object"
+          }
+        },
+        "type": "object"
+      },
+      "type": "array"
+    },
+    "controlPlane": {
+      "properties": {
+        "platform": {
+          "additionalProperties": false,
+          "properties": {
+            "powervs": {
+              "type": "object"
+            }
+          },
+          "type": "object"
+        }
+      },
+      "type": "object"
+    },
+    "platform": {
+      "additionalProperties": false,
+      "properties": {
+        "powervs": {
+          "type": "object"
+        }
+      },
+      "required": [
+        "powervs"
+      ],
+      "type": "object"
+    }
+  },
+  "required": [
+    "baseDomain"
+  ],
+  "title": "InstallConfig",
+  "type": "object"
+}`,
+	}, {
+		name:     "unknown platform",
+		platform: "foo",
+		err:      `unknown platform "foo", must be one of [aws powervs]`,
+	}, {
+		name:     "platform of a field",
+		path:     []string{"platform"},
+		platform: "aws",
+		err:      "a platform can only be selected for the installconfig resource",
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, err := lookup(testInstallConfigSchema(), tc.path)
+			assert.NoError(t, err)
+
+			doc, err := jsonSchema(schema, tc.path, tc.platform)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, json.Valid(doc))
+			assert.Equal(t, tc.expected, string(doc))
+		})
+	}
+}
@@ -17,6 +17,11 @@ const (
 
 type printer struct {
 	Writer io.Writer
+	// Path is the path of the printed resource, which is used to look up
+	// the examples of the resource and its fields.
+	Path []string
+	// Examples enables the printing of examples.
+	Examples bool
 }
 
 func (p printer) PrintKindAndVersion() {
@@ -40,6 +45,11 @@ func (p printer) PrintResource(schema *apiextv1.JSONSchemaProps) {
 	write(2, p.Writer, desc)
 	io.WriteString(p.Writer, "\n")
 
+	if example, ok := p.example(p.Path); ok {
+		io.WriteString(p.Writer, "EXAMPLE:\n")
+		writeExample(2, p.Writer, example)
+		io.WriteString(p.Writer, "\n")
+	}
 }
 
 func (p printer) PrintFields(schema *apiextv1.JSONSchemaProps) {
@@ -90,9 +100,20 @@ func (p printer) printField(name string, required bool, schema *apiextv1.JSONSch
 	if schema.Items != nil && schema.Items.Schema != nil && len(schema.Items.Schema.Description) > 0 {
 		write(6, p.Writer, schema.Items.Schema.Description)
 	}
+	if example, ok := p.example(append(append([]string{}, p.Path...), name)); ok {
+		write(fieldDescIndent, p.Writer, "Example:")
+		writeExample(fieldDescIndent+2, p.Writer, example)
+	}
 	io.WriteString(p.Writer, "\n")
 }
 
+func (p printer) example(path []string) (string, bool) {
+	if !p.Examples {
+		return "", false
+	}
+	return exampleFor(path)
+}
+
 func (p printer) printLabels(indentLevel int, schema *apiextv1.JSONSchemaProps) {
 	if schema.Default != nil {
 		write(indentLevel, p.Writer, fmt.Sprintf("Default: %s", defaultString(*schema.Default)))
@@ -116,6 +137,13 @@ func write(indentLevel int, w io.Writer, s string) {
 	io.WriteString(w, indent+s+"\n")
 }
 
+func writeExample(indentLevel int, w io.Writer, example string) {
+	indent := strings.Repeat(" ", indentLevel)
+	for _, line := range strings.Split(example, "\n") {
+		io.WriteString(w, indent+line+"\n")
+	}
+}
+
 func defaultString(obj apiextv1.JSON) string { return string(obj.Raw) }
 
 func validValues(objs []apiextv1.JSON) []string {
@@ -0,0 +1,305 @@
+package powervs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	powervssession "github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/gather"
+	"github.com/openshift/installer/pkg/gather/providers"
+	"github.com/openshift/installer/pkg/types"
+	powervstypes "github.com/openshift/installer/pkg/types/powervs"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// Gather holds options for resources we want to gather.
+//
+// Most failed Power VS installs are caused by the network between the
+// workspace and the VPC, so the state of the DHCP servers, the Cloud
+// Connections or Transit Gateways, the load balancers and the security groups
+// of the cluster is exported next to the console logs.
+type Gather struct {
+	bxClient        *powervssession.BxClient
+	infraID         string
+	serviceGUID     string
+	vpcRegion       string
+	zone            string
+	logger          logrus.FieldLogger
+	serialLogBundle string
+	bootstrap       string
+	masters         []string
+	directory       string
+}
+
+// New returns a Power VS Gather from ClusterMetadata.
+func New(logger logrus.FieldLogger, serialLogBundle string, bootstrap string, masters []string, metadata *types.ClusterMetadata) (providers.Gather, error) {
+	bxClient, err := powervssession.NewBxClient(metadata.ClusterPlatformMetadata.PowerVS.ServiceEndpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcRegion := metadata.ClusterPlatformMetadata.PowerVS.VPCRegion
+	if vpcRegion == "" {
+		vpcRegion, err = powervstypes.VPCRegionForPowerVSRegion(metadata.ClusterPlatformMetadata.PowerVS.Region)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive the VPC region")
+		}
+	}
+
+	return &Gather{
+		bxClient:        bxClient,
+		infraID:         metadata.InfraID,
+		serviceGUID:     metadata.ClusterPlatformMetadata.PowerVS.ServiceInstanceGUID,
+		vpcRegion:       vpcRegion,
+		zone:            metadata.ClusterPlatformMetadata.PowerVS.Zone,
+		logger:          logger,
+		serialLogBundle: serialLogBundle,
+		bootstrap:       bootstrap,
+		masters:         masters,
+		directory:       filepath.Dir(serialLogBundle),
+	}, nil
+}
+
+// TransitGateway is a Transit Gateway with its connections.
+type TransitGateway struct {
+	powervssession.TransitGateway
+	Connections []powervssession.TransitGatewayConnection `json:"connections"`
+}
+
+// LoadBalancer is a load balancer of the VPC with the members of its pools.
+type LoadBalancer struct {
+	vpcv1.LoadBalancer
+	// PoolMembers maps the pool names to their members, whose health tells
+	// which control plane machines serve the pool.
+	PoolMembers map[string][]vpcv1.LoadBalancerPoolMember `json:"pool_members"`
+}
+
+// Run is the entrypoint to start the gather process.
+func (g *Gather) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	piSession, err := ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+		Authenticator: g.bxClient.Authenticator(),
+		UserAccount:   g.bxClient.User.Account,
+		Zone:          g.zone,
+		URL:           g.bxClient.ServiceURL(powervstypes.PowerServiceEndpointName, ""),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the Power VS session")
+	}
+	// The Transit Gateway lookups of the client need its session.
+	g.bxClient.PISession = piSession
+
+	vpcSvc, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: g.bxClient.Authenticator(),
+		URL:           g.bxClient.ServiceURL(powervstypes.VPCServiceEndpointName, fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", g.vpcRegion)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the VPC client")
+	}
+	vpcSvc.Service.SetUserAgent(fmt.Sprintf("OpenShift/4.x Gather/%s", version.Raw))
+
+	serialLogBundleDir := strings.TrimSuffix(filepath.Base(g.serialLogBundle), ".tar.gz")
+	filePathDir := filepath.Join(g.directory, serialLogBundleDir)
+	err = os.MkdirAll(filePathDir, 0755)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+
+	diagnostics := []struct {
+		name    string
+		collect func(context.Context) (interface{}, error)
+	}{
+		{name: "dhcp-servers", collect: func(ctx context.Context) (interface{}, error) {
+			return g.dhcpServers(ctx, piSession)
+		}},
+		{name: "cloud-connections", collect: func(ctx context.Context) (interface{}, error) {
+			return g.cloudConnections(ctx, piSession)
+		}},
+		{name: "transit-gateways", collect: func(ctx context.Context) (interface{}, error) {
+			return g.transitGateways(ctx)
+		}},
+		{name: "load-balancers", collect: func(ctx context.Context) (interface{}, error) {
+			return g.loadBalancers(ctx, vpcSvc)
+		}},
+		{name: "security-groups", collect: func(ctx context.Context) (interface{}, error) {
+			return g.securityGroups(ctx, vpcSvc)
+		}},
+	}
+
+	var errs []error
+	var files []string
+	for _, diagnostic := range diagnostics {
+		g.logger.Debugf("Gathering Power VS %s", diagnostic.name)
+		data, err := diagnostic.collect(ctx)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to gather %s", diagnostic.name))
+			continue
+		}
+		filePath, err := g.saveToFile(diagnostic.name, data, filePathDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		files = append(files, filePath)
+	}
+
+	if len(files) > 0 {
+		err := gather.CreateArchive(files, g.serialLogBundle)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to create archive"))
+		}
+	}
+
+	if err := gather.DeleteArchiveDirectory(filePathDir); err != nil {
+		// Note: cleanup is best effort, it shouldn't fail the gather
+		g.logger.Debugf("Failed to remove archive directory: %v", err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// dhcpServers returns the DHCP servers of the cluster networks with their
+// lease tables.
+func (g *Gather) dhcpServers(ctx context.Context, piSession *ibmpisession.IBMPISession) ([]*models.DHCPServerDetail, error) {
+	dhcpClient := instance.NewIBMPIDhcpClient(ctx, piSession, g.serviceGUID)
+	servers, err := dhcpClient.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.DHCPServerDetail{}
+	for _, server := range servers {
+		if server.ID == nil || server.Network == nil || server.Network.Name == nil || !strings.Contains(*server.Network.Name, g.infraID) {
+			continue
+		}
+		detail, err := dhcpClient.Get(*server.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get DHCP server %s", *server.ID)
+		}
+		result = append(result, detail)
+	}
+	return result, nil
+}
+
+// cloudConnections returns the Cloud Connections of the cluster, whose link
+// status tells whether the workspace reaches the VPC. Workspaces in Power Edge
+// Router zones have none.
+func (g *Gather) cloudConnections(ctx context.Context, piSession *ibmpisession.IBMPISession) ([]*models.CloudConnection, error) {
+	result := []*models.CloudConnection{}
+	per, err := g.bxClient.IsPERWorkspace(ctx, g.serviceGUID)
+	if err != nil {
+		return nil, err
+	}
+	if per {
+		return result, nil
+	}
+
+	cloudConnectionClient := instance.NewIBMPICloudConnectionClient(ctx, piSession, g.serviceGUID)
+	cloudConnections, err := cloudConnectionClient.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cloudConnection := range cloudConnections.CloudConnections {
+		if cloudConnection.Name != nil && strings.Contains(*cloudConnection.Name, g.infraID) {
+			result = append(result, cloudConnection)
+		}
+	}
+	return result, nil
+}
+
+// transitGateways returns the Transit Gateways of the cluster with the status
+// of their connections. Workspaces in Power Edge Router zones reach the VPC
+// through a Transit Gateway instead of a Cloud Connection.
+func (g *Gather) transitGateways(ctx context.Context) ([]TransitGateway, error) {
+	gateways, err := g.bxClient.ListTransitGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []TransitGateway{}
+	for _, gateway := range gateways {
+		if !strings.Contains(gateway.Name, g.infraID) {
+			continue
+		}
+		connections, err := g.bxClient.ListTransitGatewayConnections(ctx, gateway.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list connections of Transit Gateway %s", gateway.Name)
+		}
+		result = append(result, TransitGateway{TransitGateway: gateway, Connections: connections})
+	}
+	return result, nil
+}
+
+// loadBalancers returns the load balancers of the cluster with the health of
+// their pool members.
+func (g *Gather) loadBalancers(ctx context.Context, vpcSvc *vpcv1.VpcV1) ([]LoadBalancer, error) {
+	loadBalancers, _, err := vpcSvc.ListLoadBalancersWithContext(ctx, vpcSvc.NewListLoadBalancersOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []LoadBalancer{}
+	for _, loadBalancer := range loadBalancers.LoadBalancers {
+		if loadBalancer.ID == nil || loadBalancer.Name == nil || !strings.Contains(*loadBalancer.Name, g.infraID) {
+			continue
+		}
+		poolMembers := map[string][]vpcv1.LoadBalancerPoolMember{}
+		for _, pool := range loadBalancer.Pools {
+			if pool.ID == nil || pool.Name == nil {
+				continue
+			}
+			members, _, err := vpcSvc.ListLoadBalancerPoolMembersWithContext(ctx, vpcSvc.NewListLoadBalancerPoolMembersOptions(*loadBalancer.ID, *pool.ID))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list members of pool %s of load balancer %s", *pool.Name, *loadBalancer.Name)
+			}
+			poolMembers[*pool.Name] = members.Members
+		}
+		result = append(result, LoadBalancer{LoadBalancer: loadBalancer, PoolMembers: poolMembers})
+	}
+	return result, nil
+}
+
+// securityGroups returns the security groups of the cluster with their rules.
+func (g *Gather) securityGroups(ctx context.Context, vpcSvc *vpcv1.VpcV1) ([]vpcv1.SecurityGroup, error) {
+	securityGroups, _, err := vpcSvc.ListSecurityGroupsWithContext(ctx, vpcSvc.NewListSecurityGroupsOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []vpcv1.SecurityGroup{}
+	for _, securityGroup := range securityGroups.SecurityGroups {
+		if securityGroup.Name != nil && strings.Contains(*securityGroup.Name, g.infraID) {
+			result = append(result, securityGroup)
+		}
+	}
+	return result, nil
+}
+
+func (g *Gather) saveToFile(name string, data interface{}, filePathDir string) (string, error) {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal %s", name)
+	}
+
+	filename := filepath.Join(filePathDir, fmt.Sprintf("powervs-%s.json", name))
+	if err := os.WriteFile(filename, content, 0o600); err != nil {
+		return "", errors.Wrap(err, "failed to write to file")
+	}
+	return filename, nil
+}
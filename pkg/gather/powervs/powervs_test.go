@@ -0,0 +1,186 @@
+package powervs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer returns the URL of a server answering the requests for the
+// paths with their JSON responses, and a 500 error for the other paths.
+func newTestServer(t *testing.T, responses map[string]string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestDHCPServers(t *testing.T) {
+	const dhcpPath = "/pcloud/v1/cloud-instances/service-instance-id/services/dhcp"
+	cases := []struct {
+		name        string
+		responses   map[string]string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name: "servers of the cluster",
+			responses: map[string]string{
+				dhcpPath: `[
+					{"id": "dhcp-1", "network": {"id": "network-1", "name": "DHCPSERVERinfra-id_Private"}, "status": "ACTIVE"},
+					{"id": "dhcp-2", "network": {"id": "network-2", "name": "DHCPSERVERother-id_Private"}, "status": "ACTIVE"},
+					{"id": "dhcp-3", "status": "BUILD"}
+				]`,
+				dhcpPath + "/dhcp-1": `{"id": "dhcp-1", "network": {"id": "network-1", "name": "DHCPSERVERinfra-id_Private"}, "status": "ACTIVE", "leases": [{"instanceIP": "192.168.0.10", "instanceMacAddress": "fa:16:3e:00:00:01"}]}`,
+			},
+			expected: []string{"dhcp-1"},
+		},
+		{
+			name: "no servers",
+			responses: map[string]string{
+				dhcpPath: `[]`,
+			},
+			expected: []string{},
+		},
+		{
+			name: "server detail failing",
+			responses: map[string]string{
+				dhcpPath: `[{"id": "dhcp-1", "network": {"id": "network-1", "name": "DHCPSERVERinfra-id_Private"}, "status": "ACTIVE"}]`,
+			},
+			expectedErr: `^failed to get DHCP server dhcp-1: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			piSession, err := ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+				Authenticator: &core.NoAuthAuthenticator{},
+				UserAccount:   "account",
+				Zone:          "dal10",
+				URL:           newTestServer(t, tc.responses),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := &Gather{infraID: "infra-id", serviceGUID: "service-instance-id"}
+			servers, err := g.dhcpServers(context.Background(), piSession)
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			ids := []string{}
+			for _, server := range servers {
+				ids = append(ids, *server.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func newTestVPCService(t *testing.T, responses map[string]string) *vpcv1.VpcV1 {
+	vpcSvc, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: &core.NoAuthAuthenticator{},
+		URL:           newTestServer(t, responses),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vpcSvc
+}
+
+func TestLoadBalancers(t *testing.T) {
+	cases := []struct {
+		name        string
+		responses   map[string]string
+		expected    map[string]map[string][]string
+		expectedErr string
+	}{
+		{
+			name: "load balancers of the cluster",
+			responses: map[string]string{
+				"/load_balancers": `{"load_balancers": [
+					{"id": "lb-1", "name": "infra-id-loadbalancer", "pools": [{"id": "pool-1", "name": "api"}, {"id": "pool-2", "name": "machine-config"}]},
+					{"id": "lb-2", "name": "other-id-loadbalancer", "pools": [{"id": "pool-3", "name": "api"}]},
+					{"id": "lb-3", "name": "infra-id-loadbalancer-int"}
+				]}`,
+				"/load_balancers/lb-1/pools/pool-1/members": `{"members": [{"id": "member-1", "health": "ok"}, {"id": "member-2", "health": "faulted"}]}`,
+				"/load_balancers/lb-1/pools/pool-2/members": `{"members": []}`,
+			},
+			expected: map[string]map[string][]string{
+				"infra-id-loadbalancer": {
+					"api":            {"member-1 ok", "member-2 faulted"},
+					"machine-config": {},
+				},
+				"infra-id-loadbalancer-int": {},
+			},
+		},
+		{
+			name: "pool members failing",
+			responses: map[string]string{
+				"/load_balancers": `{"load_balancers": [{"id": "lb-1", "name": "infra-id-loadbalancer", "pools": [{"id": "pool-1", "name": "api"}]}]}`,
+			},
+			expectedErr: `^failed to list members of pool api of load balancer infra-id-loadbalancer: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &Gather{infraID: "infra-id"}
+			loadBalancers, err := g.loadBalancers(context.Background(), newTestVPCService(t, tc.responses))
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			actual := map[string]map[string][]string{}
+			for _, loadBalancer := range loadBalancers {
+				pools := map[string][]string{}
+				for pool, members := range loadBalancer.PoolMembers {
+					pools[pool] = []string{}
+					for _, member := range members {
+						pools[pool] = append(pools[pool], *member.ID+" "+*member.Health)
+					}
+				}
+				actual[*loadBalancer.Name] = pools
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSecurityGroups(t *testing.T) {
+	g := &Gather{infraID: "infra-id"}
+	vpcSvc := newTestVPCService(t, map[string]string{
+		"/security_groups": `{"security_groups": [
+			{"id": "sg-1", "name": "infra-id-sg-cluster-wide", "rules": [{"id": "rule-1", "direction": "inbound", "protocol": "all"}]},
+			{"id": "sg-2", "name": "other-id-sg-cluster-wide"},
+			{"id": "sg-3", "name": "infra-id-sg-control-plane"}
+		]}`,
+	})
+	securityGroups, err := g.securityGroups(context.Background(), vpcSvc)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var names []string
+	for _, securityGroup := range securityGroups {
+		names = append(names, *securityGroup.Name)
+	}
+	assert.Equal(t, []string{"infra-id-sg-cluster-wide", "infra-id-sg-control-plane"}, names)
+	assert.Len(t, securityGroups[0].Rules, 1)
+}
@@ -0,0 +1,7 @@
+package powervs
+
+import "github.com/openshift/installer/pkg/gather/providers"
+
+func init() {
+	providers.Registry["powervs"] = New
+}
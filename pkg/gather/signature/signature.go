@@ -0,0 +1,178 @@
+// Package signature recognizes known causes of failed installs from the
+// messages they leave in the logs.
+package signature
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxEvidence is the number of log lines kept as evidence of a match.
+	maxEvidence = 3
+	// maxEvidenceLength is the length at which the evidence lines are cut.
+	maxEvidenceLength = 300
+	// maxLineLength is the length of the longest log line scanned.
+	maxLineLength = 1024 * 1024
+)
+
+// scannedExtensions are the extensions of the files of the gather bundle which
+// are scanned. The other files are binary or hold no log messages.
+var scannedExtensions = map[string]bool{
+	"":      true,
+	".json": true,
+	".log":  true,
+	".txt":  true,
+	".yaml": true,
+}
+
+// Signature is a known cause of failed installs.
+type Signature struct {
+	// Name identifies the signature.
+	Name string
+	// Cause describes the cause of the failure.
+	Cause string
+	// Remediation describes how to fix the cause before installing again.
+	Remediation string
+	// Priority ranks the signatures matched by the same logs. Causes which
+	// fail the install on their own, such as a denied permission, have a
+	// higher priority than causes which are often transient, such as a DNS
+	// failure.
+	Priority int
+	// Patterns match the log lines left by the cause.
+	Patterns []*regexp.Regexp
+}
+
+// Match is a signature whose patterns matched the logs.
+type Match struct {
+	Signature *Signature
+	// Count is the number of matching log lines.
+	Count int
+	// Evidence are the first matching log lines, prefixed with their file.
+	Evidence []string
+}
+
+// Matcher scans logs for the signatures.
+type Matcher struct {
+	signatures []Signature
+	matches    map[string]*Match
+}
+
+// NewMatcher returns a matcher of the signatures.
+func NewMatcher(signatures []Signature) *Matcher {
+	return &Matcher{
+		signatures: signatures,
+		matches:    map[string]*Match{},
+	}
+}
+
+// Scan scans the log lines read from r, which come from the named file.
+func (m *Matcher) Scan(name string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		m.scanLine(name, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return errors.Wrapf(err, "failed to read %s", name)
+	}
+	return nil
+}
+
+func (m *Matcher) scanLine(name, line string) {
+	for i := range m.signatures {
+		signature := &m.signatures[i]
+		for _, pattern := range signature.Patterns {
+			if !pattern.MatchString(line) {
+				continue
+			}
+			match, ok := m.matches[signature.Name]
+			if !ok {
+				match = &Match{Signature: signature}
+				m.matches[signature.Name] = match
+			}
+			match.Count++
+			if len(match.Evidence) < maxEvidence {
+				evidence := strings.TrimSpace(line)
+				if len(evidence) > maxEvidenceLength {
+					evidence = evidence[:maxEvidenceLength] + "..."
+				}
+				match.Evidence = append(match.Evidence, fmt.Sprintf("%s: %s", name, evidence))
+			}
+			break
+		}
+	}
+}
+
+// Matches returns the matched signatures, the most likely cause first.
+func (m *Matcher) Matches() []Match {
+	matches := make([]Match, 0, len(m.matches))
+	for _, match := range m.matches {
+		matches = append(matches, *match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Signature.Priority != matches[j].Signature.Priority {
+			return matches[i].Signature.Priority > matches[j].Signature.Priority
+		}
+		if matches[i].Count != matches[j].Count {
+			return matches[i].Count > matches[j].Count
+		}
+		return matches[i].Signature.Name < matches[j].Signature.Name
+	})
+	return matches
+}
+
+// ScanFile scans the log file.
+func (m *Matcher) ScanFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Scan(path.Base(filename), f)
+}
+
+// ScanGatherBundle scans the log files of the gather bundle.
+func (m *Matcher) ScanGatherBundle(bundlePath string) error {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return errors.Wrap(err, "could not open the gather bundle")
+	}
+	defer f.Close()
+	return m.scanGatherBundle(f)
+}
+
+func (m *Matcher) scanGatherBundle(r io.Reader) error {
+	uncompressedStream, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "could not decompress the gather bundle")
+	}
+	defer uncompressedStream.Close()
+
+	tarReader := tar.NewReader(uncompressedStream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "encountered an error reading from the gather bundle")
+		}
+		if header.Typeflag != tar.TypeReg || !scannedExtensions[path.Ext(header.Name)] {
+			continue
+		}
+		if err := m.Scan(header.Name, tarReader); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package signature
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher(t *testing.T) {
+	cases := []struct {
+		name     string
+		log      string
+		expected []string
+	}{
+		{
+			name: "no known cause",
+			log:  `level=info msg="Waiting up to 20m0s for the Kubernetes API"`,
+		},
+		{
+			name: "aws permission denied",
+			log: `level=error msg="Error: creating EC2 VPC: UnauthorizedOperation: You are not authorized to perform this operation."
+level=error msg="Error: creating IAM Role: AccessDenied: User: arn:aws:iam::123456789012:user/installer is not authorized to perform: iam:CreateRole"`,
+			expected: []string{"aws-permission-denied"},
+		},
+		{
+			name: "quota and dns, quota first",
+			log: `Jan 02 03:04:05 bootstrap bootkube.sh[1234]: dial tcp: lookup api-int.test.example.com on 10.0.0.2:53: no such host
+Jan 02 03:04:06 bootstrap bootkube.sh[1234]: dial tcp: lookup api-int.test.example.com on 10.0.0.2:53: no such host
+level=error msg="Error: Error creating instance: VcpuLimitExceeded: You have requested more vCPU capacity than your current vCPU limit"`,
+			expected: []string{"quota-exceeded", "dns-resolution-failed"},
+		},
+		{
+			name:     "expired certificate",
+			log:      `kubelet[123]: Unable to register node with API server: x509: certificate has expired or is not yet valid`,
+			expected: []string{"certificate-error"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMatcher(Signatures)
+			assert.NoError(t, m.Scan(".openshift_install.log", strings.NewReader(tc.log)))
+			names := []string{}
+			for _, match := range m.Matches() {
+				names = append(names, match.Signature.Name)
+			}
+			if tc.expected == nil {
+				tc.expected = []string{}
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestMatcherEvidence(t *testing.T) {
+	m := NewMatcher(Signatures)
+	log := strings.Repeat("Temporary failure in name resolution\n", 5)
+	assert.NoError(t, m.Scan("bootkube.log", strings.NewReader(log)))
+
+	matches := m.Matches()
+	if !assert.Len(t, matches, 1) {
+		return
+	}
+	assert.Equal(t, 5, matches[0].Count)
+	assert.Equal(t, []string{
+		"bootkube.log: Temporary failure in name resolution",
+		"bootkube.log: Temporary failure in name resolution",
+		"bootkube.log: Temporary failure in name resolution",
+	}, matches[0].Evidence)
+}
+
+func TestScanGatherBundle(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	files := map[string]string{
+		"log-bundle-20230102030405/bootstrap/journals/release-image.log": "error pinging docker registry quay.io",
+		"log-bundle-20230102030405/bootstrap/images.bin":                 "x509: certificate has expired",
+	}
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMatcher(Signatures)
+	assert.NoError(t, m.scanGatherBundle(buf))
+	matches := m.Matches()
+	if !assert.Len(t, matches, 1) {
+		return
+	}
+	assert.Equal(t, "image-pull-failed", matches[0].Signature.Name)
+	assert.Equal(t, []string{"log-bundle-20230102030405/bootstrap/journals/release-image.log: error pinging docker registry quay.io"}, matches[0].Evidence)
+}
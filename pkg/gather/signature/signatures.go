@@ -0,0 +1,77 @@
+package signature
+
+import "regexp"
+
+// Signatures are the known causes of failed installs.
+var Signatures = []Signature{
+	{
+		Name:        "aws-permission-denied",
+		Cause:       "The AWS credentials of the installer lack IAM permissions.",
+		Remediation: "Grant the IAM permissions named in the denied requests to the user or role of the installer credentials, or use credentials with the permissions required for the install, then destroy the cluster and install again.",
+		Priority:    90,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`UnauthorizedOperation|AccessDeniedException|AccessDenied:`),
+			regexp.MustCompile(`is not authorized to perform: [a-z0-9-]+:[A-Za-z0-9]+`),
+		},
+	},
+	{
+		Name:        "gcp-permission-denied",
+		Cause:       "The GCP service account of the installer lacks permissions.",
+		Remediation: "Grant the roles holding the permissions named in the denied requests to the service account of the installer, then destroy the cluster and install again.",
+		Priority:    90,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`googleapi: Error 403: Required '[A-Za-z0-9.]+' permission`),
+			regexp.MustCompile(`googleapi: Error 403: .*(forbidden|PERMISSION_DENIED|does not have)`),
+		},
+	},
+	{
+		Name:        "azure-authorization-failed",
+		Cause:       "The Azure service principal of the installer lacks role assignments.",
+		Remediation: "Assign a role allowing the actions named in the failed requests, such as Contributor and User Access Administrator, to the service principal of the installer on the subscription, then destroy the cluster and install again.",
+		Priority:    90,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`AuthorizationFailed|does not have authorization to perform action`),
+		},
+	},
+	{
+		Name:        "quota-exceeded",
+		Cause:       "The cloud account ran out of quota for the resources of the cluster.",
+		Remediation: "Request a quota increase for the exhausted resource in the region of the cluster, or release unused resources, then destroy the cluster and install again.",
+		Priority:    85,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(VcpuLimitExceeded|InstanceLimitExceeded|AddressLimitExceeded|VpcLimitExceeded|NatGatewayLimitExceeded|QuotaExceeded|LimitExceeded)`),
+			regexp.MustCompile(`Quota '[A-Z_0-9]+' exceeded`),
+			regexp.MustCompile(`(?i)operation could not be completed as it results in exceeding approved .* quota`),
+		},
+	},
+	{
+		Name:        "certificate-error",
+		Cause:       "A TLS certificate was rejected, e.g. an expired certificate from the ignition of an old install, a clock skew, or a proxy or mirror registry with a certificate authority missing from the trust bundle.",
+		Remediation: "Generate the ignition configs again if they are older than 24 hours, check the clocks of the hosts, and add the certificate authority of the proxy or mirror registry to additionalTrustBundle in the install config.",
+		Priority:    70,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`x509: certificate (has expired|signed by unknown authority|is valid for|is not valid)`),
+			regexp.MustCompile(`tls: failed to verify certificate`),
+		},
+	},
+	{
+		Name:        "image-pull-failed",
+		Cause:       "The release images could not be pulled.",
+		Remediation: "Check that the pull secret in the install config is valid for the release registry or the mirror registry, and that the registry is reachable from the hosts, directly or through the proxy.",
+		Priority:    60,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`unauthorized: authentication required|unauthorized: access to the requested resource is not authorized`),
+			regexp.MustCompile(`(?i)error pinging docker registry|reading manifest .* manifest unknown`),
+		},
+	},
+	{
+		Name:        "dns-resolution-failed",
+		Cause:       "Host names could not be resolved, e.g. the API and API-Int records of the cluster or the endpoints of the cloud.",
+		Remediation: "Check that the DNS servers of the hosts resolve api.<cluster name>.<base domain> and api-int.<cluster name>.<base domain>, and that the hosts can reach the DNS servers.",
+		Priority:    50,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`dial tcp: lookup [^ ]+( on [^ ]+)?: (no such host|server misbehaving)`),
+			regexp.MustCompile(`Temporary failure in name resolution|Could not resolve host`),
+		},
+	},
+}
@@ -0,0 +1,231 @@
+package ssh
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+// bastionDialTimeout is the timeout of the TCP connection to an SSH bastion.
+const bastionDialTimeout = 30 * time.Second
+
+// Bastion is an SSH host through which the connections to the hosts of a
+// cluster which are not reachable directly, e.g. of a cluster published
+// internally, are tunneled.
+type Bastion struct {
+	// User is the user logging into the bastion.
+	User string
+	// Address is the host and port of the bastion.
+	Address string
+}
+
+// ParseBastion parses a bastion of the form user@host, with an optional port
+// defaulting to the SSH port.
+func ParseBastion(bastion string) (*Bastion, error) {
+	i := strings.LastIndex(bastion, "@")
+	if i <= 0 || i == len(bastion)-1 {
+		return nil, errors.Errorf("invalid SSH bastion %q, must be of the form user@host or user@host:port", bastion)
+	}
+	address := bastion[i+1:]
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), "22")
+	}
+	return &Bastion{User: bastion[:i], Address: address}, nil
+}
+
+// String returns the bastion in the form user@host:port.
+func (b *Bastion) String() string {
+	return b.User + "@" + b.Address
+}
+
+// Tunnel dials TCP connections from a bastion, e.g. to the Kubernetes API of
+// a cluster published internally. The connection to the bastion is opened by
+// the first dial and reopened by the next dial once it is lost. The host key
+// of the bastion, a host managed by the user, must be known in the
+// known_hosts file of the user.
+type Tunnel struct {
+	bastion *Bastion
+	keys    []string
+
+	mu     sync.Mutex
+	client *ssh.Client
+	// connecting is the running connection to the bastion, if any, which
+	// the concurrent dials wait for.
+	connecting *bastionConnection
+	closed     bool
+}
+
+// bastionConnection is a connection to the bastion, which is done once its
+// done channel is closed.
+type bastionConnection struct {
+	done   chan struct{}
+	client *ssh.Client
+	err    error
+}
+
+// NewTunnel returns a tunnel through the bastion, logged into with the keys.
+//
+// if keys list is empty, it tries to load the keys from the user's environment.
+func NewTunnel(bastion *Bastion, keys []string) *Tunnel {
+	return &Tunnel{bastion: bastion, keys: keys}
+}
+
+// DialContext dials the address from the bastion. It has the signature of the
+// Dial of the Kubernetes client configuration.
+func (t *Tunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	for attempt := 0; ; attempt++ {
+		client, err := t.connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := dial(ctx, client, network, address)
+		if err == nil {
+			return conn, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// The bastion failing to connect to the address, e.g. to an API
+		// server not listening yet, leaves the connection to the bastion,
+		// and the other connections tunneled through it, untouched.
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) || attempt > 0 {
+			return nil, errors.Wrapf(err, "failed to connect to %s through the SSH bastion %s", address, t.bastion)
+		}
+		// Otherwise the connection to the bastion may have been lost, e.g.
+		// during a long wait, so it is reopened once.
+		logrus.Debugf("Reconnecting to the SSH bastion %s: %v", t.bastion, err)
+		t.disconnect(client)
+	}
+}
+
+// dial dials the address from the bastion, and stops waiting for the bastion
+// to open the connection when the context is done. The connection opened
+// once the dial stopped waiting is closed.
+func dial(ctx context.Context, client *ssh.Client, network, address string) (net.Conn, error) {
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		conn, err := client.Dial(network, address)
+		results <- result{conn: conn, err: err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.conn, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-results; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// connect returns the connection to the bastion, opening it if needed. The
+// connection is opened once for the concurrent dials, without holding the
+// lock, and the dials stop waiting for it when their context is done.
+func (t *Tunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil, errors.Errorf("the tunnel through the SSH bastion %s is closed", t.bastion)
+	}
+	if t.client != nil {
+		client := t.client
+		t.mu.Unlock()
+		return client, nil
+	}
+	connection := t.connecting
+	if connection == nil {
+		connection = &bastionConnection{done: make(chan struct{})}
+		t.connecting = connection
+		go t.open(connection)
+	}
+	t.mu.Unlock()
+
+	select {
+	case <-connection.done:
+		return connection.client, connection.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// open opens the connection to the bastion.
+func (t *Tunnel) open(connection *bastionConnection) {
+	client, err := t.dialBastion()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.connecting = nil
+	switch {
+	case err != nil:
+		err = errors.Wrapf(err, "failed to connect to the SSH bastion %s", t.bastion)
+	case t.closed:
+		client.Close()
+		client, err = nil, errors.Errorf("the tunnel through the SSH bastion %s is closed", t.bastion)
+	default:
+		t.client = client
+	}
+	connection.client, connection.err = client, err
+	close(connection.done)
+}
+
+// dialBastion logs into the bastion, after verifying its host key against
+// the known_hosts file of the user.
+func (t *Tunnel) dialBastion() (*ssh.Client, error) {
+	knownHosts, err := LoadKnownHosts()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot verify the host key")
+	}
+	ag, _, err := getAgent(t.keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
+	}
+	config := clientConfig(t.bastion.User, ag)
+	config.HostKeyCallback = knownHosts.HostKeyCallback
+	config.HostKeyAlgorithms = knownHosts.HostKeyAlgorithms(t.bastion.Address)
+	config.Timeout = bastionDialTimeout
+	return ssh.Dial("tcp", t.bastion.Address, config)
+}
+
+// disconnect closes the connection to the bastion, unless it was already
+// replaced by another one.
+func (t *Tunnel) disconnect(client *ssh.Client) {
+	t.mu.Lock()
+	if t.client == client {
+		t.client = nil
+	}
+	t.mu.Unlock()
+	client.Close()
+}
+
+// Close closes the connection to the bastion, if any. The tunnel cannot be
+// used anymore.
+func (t *Tunnel) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	if t.client == nil {
+		return nil
+	}
+	err := t.client.Close()
+	t.client = nil
+	return err
+}
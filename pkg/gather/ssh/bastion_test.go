@@ -0,0 +1,331 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseBastion(t *testing.T) {
+	cases := []struct {
+		bastion  string
+		expected *Bastion
+		err      string
+	}{
+		{
+			bastion:  "core@bastion.example.com",
+			expected: &Bastion{User: "core", Address: "bastion.example.com:22"},
+		},
+		{
+			bastion:  "ec2-user@10.0.0.5:2222",
+			expected: &Bastion{User: "ec2-user", Address: "10.0.0.5:2222"},
+		},
+		{
+			bastion:  "core@[fd00::5]",
+			expected: &Bastion{User: "core", Address: "[fd00::5]:22"},
+		},
+		{
+			bastion:  "core@fd00::5",
+			expected: &Bastion{User: "core", Address: "[fd00::5]:22"},
+		},
+		{
+			bastion:  "core@[fd00::5]:2222",
+			expected: &Bastion{User: "core", Address: "[fd00::5]:2222"},
+		},
+		{
+			bastion: "bastion.example.com",
+			err:     `^invalid SSH bastion "bastion\.example\.com", must be of the form user@host or user@host:port$`,
+		},
+		{
+			bastion: "core@",
+			err:     `^invalid SSH bastion "core@", must be of the form user@host or user@host:port$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.bastion, func(t *testing.T) {
+			bastion, err := ParseBastion(tc.bastion)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, bastion)
+			}
+		})
+	}
+}
+
+// unresponsiveHost is a host to which the test bastion never answers the
+// connections.
+const unresponsiveHost = "unresponsive.invalid"
+
+// testBastion is an SSH server forwarding the TCP connections of its
+// clients.
+type testBastion struct {
+	address string
+	hostKey ssh.Signer
+	// logins is the number of connections of the clients.
+	logins int32
+}
+
+func newTestBastion(t *testing.T, userKey ssh.PublicKey) *testBastion {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	b := &testBastion{address: listener.Addr().String(), hostKey: newTestHostKey(t)}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !bytes.Equal(key.Marshal(), userKey.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(b.hostKey)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn, config)
+		}
+	}()
+	return b
+}
+
+func (b *testBastion) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	atomic.AddInt32(&b.logins, 1)
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		// The connections to the unresponsive host are never answered.
+		if target.Host == unresponsiveHost {
+			continue
+		}
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			targetConn.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelRequests)
+		go func() {
+			io.Copy(channel, targetConn)
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(targetConn, channel)
+			targetConn.Close()
+		}()
+	}
+}
+
+// newEchoServer returns the address of a TCP server echoing what it reads.
+func newEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// closedAddress returns an address refusing the TCP connections.
+func closedAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+// setUpTunnelUser sets up the home directory of the user with the known
+// host keys, and returns the path of the private key of the user.
+func setUpTunnelUser(t *testing.T, knownHosts ...string) (string, ssh.PublicKey) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SSH_AUTH_SOCK", "")
+	if err := os.Mkdir(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "known_hosts"), []byte(strings.Join(knownHosts, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(home, ".ssh", "id_ecdsa")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPath, publicKey
+}
+
+func TestTunnel(t *testing.T) {
+	keyPath, userKey := setUpTunnelUser(t)
+	bastion := newTestBastion(t, userKey)
+	host, port, _ := net.SplitHostPort(bastion.address)
+	if err := os.WriteFile(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"), []byte(knownHostsLine("["+host+"]:"+port, bastion.hostKey.PublicKey())+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	echo := newEchoServer(t)
+
+	tunnel := NewTunnel(&Bastion{User: "core", Address: bastion.address}, []string{keyPath})
+	ctx := context.Background()
+
+	// Concurrent dials share a single connection to the bastion.
+	var wg sync.WaitGroup
+	conns := make([]net.Conn, 5)
+	errs := make([]error, len(conns))
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conns[i], errs[i] = tunnel.DialContext(ctx, "tcp", echo)
+		}(i)
+	}
+	wg.Wait()
+	for i, conn := range conns {
+		if !assert.NoError(t, errs[i]) {
+			return
+		}
+		defer conn.Close()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&bastion.logins))
+
+	// A refused connection leaves the connection to the bastion, and the
+	// connections tunneled through it, untouched.
+	_, err := tunnel.DialContext(ctx, "tcp", closedAddress(t))
+	var openErr *ssh.OpenChannelError
+	assert.True(t, errors.As(err, &openErr), "%v", err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&bastion.logins))
+	if _, err := conns[0].Write([]byte("ping")); assert.NoError(t, err) {
+		buf := make([]byte, 4)
+		_, err := io.ReadFull(conns[0], buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "ping", string(buf))
+	}
+
+	// A lost connection to the bastion is reopened.
+	tunnel.mu.Lock()
+	tunnel.client.Close()
+	tunnel.mu.Unlock()
+	conn, err := tunnel.DialContext(ctx, "tcp", echo)
+	if assert.NoError(t, err) {
+		conn.Close()
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&bastion.logins))
+
+	assert.NoError(t, tunnel.Close())
+	_, err = tunnel.DialContext(ctx, "tcp", echo)
+	assert.Regexp(t, `^the tunnel through the SSH bastion core@.* is closed$`, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = NewTunnel(&Bastion{User: "core", Address: bastion.address}, []string{keyPath}).DialContext(canceled, "tcp", echo)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestTunnelDialContext(t *testing.T) {
+	keyPath, userKey := setUpTunnelUser(t)
+	bastion := newTestBastion(t, userKey)
+	host, port, _ := net.SplitHostPort(bastion.address)
+	if err := os.WriteFile(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"), []byte(knownHostsLine("["+host+"]:"+port, bastion.hostKey.PublicKey())+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	echo := newEchoServer(t)
+	tunnel := NewTunnel(&Bastion{User: "core", Address: bastion.address}, []string{keyPath})
+	defer tunnel.Close()
+	conn, err := tunnel.DialContext(context.Background(), "tcp", echo)
+	if !assert.NoError(t, err) {
+		return
+	}
+	conn.Close()
+
+	// The dial stops waiting for the bastion once its context is done,
+	// without reconnecting to the bastion.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = tunnel.DialContext(ctx, "tcp", net.JoinHostPort(unresponsiveHost, "6443"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	conn, err = tunnel.DialContext(context.Background(), "tcp", echo)
+	if assert.NoError(t, err) {
+		conn.Close()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&bastion.logins))
+}
+
+func TestTunnelUnknownHostKey(t *testing.T) {
+	keyPath, userKey := setUpTunnelUser(t, knownHostsLine("*", newTestHostKey(t).PublicKey()))
+	bastion := newTestBastion(t, userKey)
+
+	tunnel := NewTunnel(&Bastion{User: "core", Address: bastion.address}, []string{keyPath})
+	_, err := tunnel.DialContext(context.Background(), "tcp", newEchoServer(t))
+	assert.Regexp(t, `^failed to connect to the SSH bastion core@.*: ssh: handshake failed: the ssh-ed25519 host key of .* is not a known key of the host in .*known_hosts`, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&bastion.logins))
+}
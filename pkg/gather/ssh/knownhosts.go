@@ -0,0 +1,115 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// probeKey is a key of no host, with which the keys known for a host are
+// listed by the mismatch it causes.
+var probeKey, _ = ssh.NewPublicKey(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public())
+
+// KnownHosts are the keys of the hosts known to the user, with which the
+// host keys of the hosts managed by the user, e.g. an SSH bastion, are
+// verified. The known_hosts file is parsed by knownhosts, which handles
+// the hashed names, the patterns, and the @revoked and @cert-authority
+// markers.
+type KnownHosts struct {
+	path     string
+	callback ssh.HostKeyCallback
+}
+
+// LoadKnownHosts loads the known_hosts file of the user, ~/.ssh/known_hosts.
+func LoadKnownHosts() (*KnownHosts, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the home directory")
+	}
+	return loadKnownHosts(filepath.Join(home, ".ssh", "known_hosts"))
+}
+
+func loadKnownHosts(path string) (*KnownHosts, error) {
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			return nil, errors.Wrap(err, "failed to read the known hosts")
+		}
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return &KnownHosts{path: path, callback: callback}, nil
+}
+
+// HostKeyAlgorithms returns the algorithms of the keys known for the address,
+// so that the host presents a key which can be verified. It returns nil when
+// no key is known for the address, e.g. when its host key is certified by a
+// known certificate authority, so that the host presents any key.
+func (k *KnownHosts) HostKeyAlgorithms(address string) []string {
+	var keyErr *knownhosts.KeyError
+	if err := k.callback(knownHostAddress(address), addr(address), probeKey); !errors.As(err, &keyErr) {
+		return nil
+	}
+	var algorithms []string
+	seen := map[string]bool{}
+	for _, known := range keyErr.Want {
+		for _, algorithm := range hostKeyAlgorithms(known.Key.Type()) {
+			if !seen[algorithm] {
+				seen[algorithm] = true
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	return algorithms
+}
+
+// HostKeyCallback verifies that the key of the host is a known key of the
+// address the host was dialed at, or a certificate of a known authority of
+// the address.
+func (k *KnownHosts) HostKeyCallback(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	if remote == nil {
+		remote = addr(hostname)
+	}
+	err := k.callback(knownHostAddress(hostname), remote, key)
+	var revokedErr *knownhosts.RevokedError
+	var keyErr *knownhosts.KeyError
+	switch {
+	case errors.As(err, &revokedErr):
+		return errors.Errorf("the host key of %s is revoked in %s", hostname, k.path)
+	case errors.As(err, &keyErr):
+		return errors.Errorf("the %s host key of %s, with fingerprint %s, is not a known key of the host in %s: connect to the host once with ssh to verify it and add it", key.Type(), hostname, ssh.FingerprintSHA256(key), k.path)
+	}
+	return err
+}
+
+// knownHostAddress returns the address with its host in lower case, as the
+// host names of the known_hosts file are.
+func knownHostAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return strings.ToLower(address)
+	}
+	return net.JoinHostPort(strings.ToLower(host), port)
+}
+
+// addr is the remote address of a host which was not dialed.
+type addr string
+
+func (a addr) Network() string { return "tcp" }
+
+func (a addr) String() string { return string(a) }
+
+// hostKeyAlgorithms returns the host key algorithms of the key type.
+func hostKeyAlgorithms(keyType string) []string {
+	if keyType == ssh.KeyAlgoRSA {
+		return []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+	}
+	return []string{keyType}
+}
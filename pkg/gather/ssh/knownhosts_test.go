@@ -0,0 +1,163 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // hashed known_hosts entries use HMAC-SHA1
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestHostKey(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func knownHostsLine(hosts string, key ssh.PublicKey) string {
+	return hosts + " " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+func hashedHostName(t *testing.T, name string) string {
+	salt := make([]byte, sha1.Size)
+	if _, err := rand.Read(salt); err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(name))
+	return "|1|" + base64.StdEncoding.EncodeToString(salt) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func writeKnownHosts(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(path, []byte("# known hosts\n"+strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// hostCertificate returns a certificate of the host key for the principals,
+// signed by the certificate authority.
+func hostCertificate(t *testing.T, hostKey ssh.PublicKey, authority ssh.Signer, principals ...string) *ssh.Certificate {
+	cert := &ssh.Certificate{
+		Key:             hostKey,
+		CertType:        ssh.HostCert,
+		ValidPrincipals: principals,
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if err := cert.SignCert(rand.Reader, authority); err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestKnownHostsHostKeyCallback(t *testing.T) {
+	hostKey := newTestHostKey(t).PublicKey()
+	otherKey := newTestHostKey(t).PublicKey()
+	authority := newTestHostKey(t)
+	otherAuthority := newTestHostKey(t)
+	path := writeKnownHosts(t,
+		knownHostsLine("bastion.example.com,10.0.0.5", hostKey),
+		knownHostsLine("[bastion.example.com]:2222", otherKey),
+		knownHostsLine(hashedHostName(t, "hashed.example.com"), hostKey),
+		knownHostsLine("*.lab.example.com,!secret.lab.example.com", hostKey),
+		"@revoked "+knownHostsLine("*", otherKey),
+		"@cert-authority "+knownHostsLine("*.ca.example.com", authority.PublicKey()),
+	)
+	knownHosts, err := loadKnownHosts(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		address  string
+		key      ssh.PublicKey
+		expected string
+	}{
+		{address: "bastion.example.com:22", key: hostKey},
+		{address: "BASTION.example.com:22", key: hostKey},
+		{address: "10.0.0.5:22", key: hostKey},
+		{address: "hashed.example.com:22", key: hostKey},
+		{address: "bastion1.lab.example.com:22", key: hostKey},
+		{
+			address:  "bastion.example.com:22",
+			key:      otherKey,
+			expected: `^the host key of bastion\.example\.com:22 is revoked in .*known_hosts$`,
+		},
+		{
+			address:  "bastion.example.com:2222",
+			key:      hostKey,
+			expected: `^the ssh-ed25519 host key of bastion\.example\.com:2222, with fingerprint SHA256:.*, is not a known key of the host in .*known_hosts: connect to the host once with ssh to verify it and add it$`,
+		},
+		{
+			address:  "secret.lab.example.com:22",
+			key:      hostKey,
+			expected: `^the ssh-ed25519 host key of secret\.lab\.example\.com:22, .* is not a known key of the host`,
+		},
+		{
+			address:  "unknown.example.com:22",
+			key:      hostKey,
+			expected: `^the ssh-ed25519 host key of unknown\.example\.com:22, .* is not a known key of the host`,
+		},
+		{
+			address: "bastion.ca.example.com:22",
+			key:     hostCertificate(t, newTestHostKey(t).PublicKey(), authority, "bastion.ca.example.com"),
+		},
+		{
+			address:  "bastion.ca.example.com:22",
+			key:      hostCertificate(t, newTestHostKey(t).PublicKey(), otherAuthority, "bastion.ca.example.com"),
+			expected: `^ssh: no authorities for hostname: bastion\.ca\.example\.com:22$`,
+		},
+		{
+			address:  "bastion.ca.example.com:22",
+			key:      hostCertificate(t, newTestHostKey(t).PublicKey(), authority, "other.ca.example.com"),
+			expected: `^ssh: principal "bastion\.ca\.example\.com" not in the set of valid principals for given certificate: \["other\.ca\.example\.com"\]$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.address, func(t *testing.T) {
+			err := knownHosts.HostKeyCallback(tc.address, nil, tc.key)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestKnownHostsHostKeyAlgorithms(t *testing.T) {
+	hostKey := newTestHostKey(t).PublicKey()
+	knownHosts, err := loadKnownHosts(writeKnownHosts(t,
+		knownHostsLine("bastion.example.com", hostKey),
+		knownHostsLine("bastion.example.com", newTestHostKey(t).PublicKey()),
+	))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{ssh.KeyAlgoED25519}, knownHosts.HostKeyAlgorithms("bastion.example.com:22"))
+	assert.Equal(t, []string{ssh.KeyAlgoED25519}, knownHosts.HostKeyAlgorithms("Bastion.example.com:22"))
+	assert.Empty(t, knownHosts.HostKeyAlgorithms("unknown.example.com:22"))
+	assert.Equal(t, []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}, hostKeyAlgorithms(ssh.KeyAlgoRSA))
+}
+
+func TestLoadKnownHostsInvalid(t *testing.T) {
+	_, err := loadKnownHosts(writeKnownHosts(t, "bastion.example.com ssh-ed25519"))
+	assert.Regexp(t, `^failed to parse .*known_hosts: `, err)
+
+	_, err = loadKnownHosts(filepath.Join(t.TempDir(), "known_hosts"))
+	assert.Regexp(t, `^failed to read the known hosts: `, err)
+}
@@ -20,21 +20,37 @@ import (
 //
 // if keys list is empty, it tries to load the keys from the user's environment.
 func NewClient(user, address string, keys []string) (*ssh.Client, error) {
+	return NewProxiedClient(user, "", address, keys)
+}
+
+// NewProxiedClient creates a new SSH client which can be used to SSH to address using user and the keys,
+// tunneling the connection through the jumpHost address. An empty jumpHost connects directly.
+//
+// if keys list is empty, it tries to load the keys from the user's environment.
+func NewProxiedClient(user, jumpHost, address string, keys []string) (*ssh.Client, error) {
+	var bastion *Bastion
+	if jumpHost != "" {
+		bastion = &Bastion{User: user, Address: jumpHost}
+	}
+	return NewBastionClient(bastion, user, address, keys)
+}
+
+// NewBastionClient creates a new SSH client which can be used to SSH to address using user and the keys,
+// tunneling the connection through the bastion, logged into as its own user. A nil bastion connects directly.
+//
+// if keys list is empty, it tries to load the keys from the user's environment.
+func NewBastionClient(bastion *Bastion, user, address string, keys []string) (*ssh.Client, error) {
 	ag, agentType, err := getAgent(keys)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
 	}
 
-	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
-		User: user,
-		Auth: []ssh.AuthMethod{
-			// Use a callback rather than PublicKeys
-			// so we only consult the agent once the remote server
-			// wants it.
-			ssh.PublicKeysCallback(ag.Signers),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	})
+	var client *ssh.Client
+	if bastion == nil {
+		client, err = ssh.Dial("tcp", address, clientConfig(user, ag))
+	} else {
+		client, err = dialThrough(bastion, address, clientConfig(bastion.User, ag), clientConfig(user, ag))
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
 			if agentType == "agent" {
@@ -50,6 +66,46 @@ func NewClient(user, address string, keys []string) (*ssh.Client, error) {
 	return client, nil
 }
 
+// clientConfig returns the configuration of the SSH clients logging into a host as user with the keys of the agent.
+func clientConfig(user string, ag agent.Agent) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User: user,
+		Auth: []ssh.AuthMethod{
+			// Use a callback rather than PublicKeys
+			// so we only consult the agent once the remote server
+			// wants it.
+			ssh.PublicKeysCallback(ag.Signers),
+		},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}
+}
+
+// dialThrough connects to address over a connection tunneled through the bastion.
+func dialThrough(bastion *Bastion, address string, bastionConfig, config *ssh.ClientConfig) (*ssh.Client, error) {
+	jump, err := ssh.Dial("tcp", bastion.Address, bastionConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to the jump host %s", bastion.Address)
+	}
+	conn, err := jump.Dial("tcp", address)
+	if err != nil {
+		jump.Close()
+		return nil, errors.Wrapf(err, "failed to connect to %s through the jump host", address)
+	}
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		jump.Close()
+		return nil, err
+	}
+	client := ssh.NewClient(clientConn, chans, reqs)
+	go func() {
+		// The jump host connection is only needed as long as the tunneled one.
+		client.Wait()
+		jump.Close()
+	}()
+	return client, nil
+}
+
 // Run uses an SSH client to execute commands.
 func Run(client *ssh.Client, command string) error {
 	sess, err := client.NewSession()
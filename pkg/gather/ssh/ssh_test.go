@@ -0,0 +1,67 @@
+package ssh
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProxiedClient(t *testing.T) {
+	keyPath, userKey := setUpTunnelUser(t)
+	jumpHost := newTestBastion(t, userKey)
+	host := newTestBastion(t, userKey)
+	closed := closedAddress(t)
+
+	cases := []struct {
+		name              string
+		jumpHost          string
+		address           string
+		expectedErr       string
+		expectedJumpLogin bool
+	}{
+		{
+			name:    "direct",
+			address: host.address,
+		},
+		{
+			name:              "through the jump host",
+			jumpHost:          jumpHost.address,
+			address:           host.address,
+			expectedJumpLogin: true,
+		},
+		{
+			name:        "unreachable jump host",
+			jumpHost:    closed,
+			address:     host.address,
+			expectedErr: `^failed to connect to the jump host 127\.0\.0\.1:\d+: dial tcp 127\.0\.0\.1:\d+: connect: connection refused$`,
+		},
+		{
+			name:              "host unreachable from the jump host",
+			jumpHost:          jumpHost.address,
+			address:           closed,
+			expectedErr:       `^failed to connect to 127\.0\.0\.1:\d+ through the jump host: ssh: rejected: connect failed \(.*connection refused\)$`,
+			expectedJumpLogin: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			jumpLogins, hostLogins := atomic.LoadInt32(&jumpHost.logins), atomic.LoadInt32(&host.logins)
+			client, err := NewProxiedClient("core", tc.jumpHost, tc.address, []string{keyPath})
+			if tc.expectedErr != "" {
+				assert.Regexp(t, tc.expectedErr, err)
+			} else {
+				if !assert.NoError(t, err) {
+					return
+				}
+				client.Close()
+				assert.Equal(t, hostLogins+1, atomic.LoadInt32(&host.logins), "logins to the host")
+			}
+			expectedJumpLogins := jumpLogins
+			if tc.expectedJumpLogin {
+				expectedJumpLogins++
+			}
+			assert.Equal(t, expectedJumpLogins, atomic.LoadInt32(&jumpHost.logins), "logins to the jump host")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package hostcrypt checks the crypto of the host running the installer.
+package hostcrypt
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// skipHostCryptValidationEnv skips the check of the host crypto, e.g. to
+// install from a binary whose backend could not be detected, without
+// skipping the other preflight checks.
+const skipHostCryptValidationEnv = "OPENSHIFT_INSTALL_SKIP_HOSTCRYPT_VALIDATION"
+
+// fipsEnabledPath is the file through which the kernel reports whether the
+// host is in FIPS mode.
+var fipsEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+// fipsExperiments are the GOEXPERIMENTs of the Go toolchains which delegate
+// the crypto of the standard library to a FIPS validated module.
+var fipsExperiments = sets.NewString("boringcrypto", "opensslcrypto", "strictfipsruntime", "systemcrypto")
+
+// VerifyFIPS returns an error unless the installer can generate the keys and
+// certificates of a FIPS cluster: the binary must use a FIPS-capable crypto
+// backend, which only uses FIPS validated modules when the Linux host itself
+// is in FIPS mode.
+func VerifyFIPS() error {
+	if skip, _ := strconv.ParseBool(os.Getenv(skipHostCryptValidationEnv)); skip {
+		logrus.Warnf("Skipping the check of the FIPS crypto of the host, as %s is set", skipHostCryptValidationEnv)
+		return nil
+	}
+	return verifyFIPS(fipsCapableBackend(), runtime.GOOS)
+}
+
+// fipsCapableBackend returns whether the running binary was built with a
+// FIPS-capable crypto backend, as recorded in its build information.
+func fipsCapableBackend() bool {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	return fipsCapableBuild(info.Settings)
+}
+
+// fipsCapableBuild returns whether the build settings are those of a
+// FIPS-capable binary: built with the fipscapable tag, or by a toolchain
+// with a FIPS crypto GOEXPERIMENT.
+func fipsCapableBuild(settings []debug.BuildSetting) bool {
+	for _, setting := range settings {
+		switch setting.Key {
+		case "-tags":
+			if sets.NewString(strings.Split(setting.Value, ",")...).Has("fipscapable") {
+				return true
+			}
+		case "GOEXPERIMENT":
+			if fipsExperiments.HasAny(strings.Split(setting.Value, ",")...) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func verifyFIPS(capableBackend bool, goos string) error {
+	if !capableBackend {
+		return errors.Errorf("the installer binary was not built with a FIPS-capable crypto backend; use a binary built with the fipscapable tag by a FIPS-capable Go toolchain to install a cluster with FIPS enabled, or set %s=true to skip this check", skipHostCryptValidationEnv)
+	}
+	if goos != "linux" {
+		return errors.Errorf("installing a cluster with FIPS enabled is only supported from a Linux host in FIPS mode, not from %s", goos)
+	}
+	data, err := os.ReadFile(fipsEnabledPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to check that the host is in FIPS mode")
+	}
+	if strings.TrimSpace(string(data)) != "1" {
+		return errors.Errorf("the host is not in FIPS mode (%s is %q); enable the FIPS mode of the host to install a cluster with FIPS enabled", fipsEnabledPath, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
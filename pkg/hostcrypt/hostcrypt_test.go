@@ -0,0 +1,118 @@
+package hostcrypt
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyFIPS(t *testing.T) {
+	cases := []struct {
+		name           string
+		capableBackend bool
+		goos           string
+		fipsEnabled    string
+		errorMsg       string
+	}{
+		{
+			name:           "host in FIPS mode",
+			capableBackend: true,
+			goos:           "linux",
+			fipsEnabled:    "1\n",
+		},
+		{
+			name:        "not FIPS-capable backend",
+			goos:        "linux",
+			fipsEnabled: "1\n",
+			errorMsg:    `^the installer binary was not built with a FIPS-capable crypto backend`,
+		},
+		{
+			name:           "not linux",
+			capableBackend: true,
+			goos:           "darwin",
+			errorMsg:       `^installing a cluster with FIPS enabled is only supported from a Linux host in FIPS mode, not from darwin$`,
+		},
+		{
+			name:           "host not in FIPS mode",
+			capableBackend: true,
+			goos:           "linux",
+			fipsEnabled:    "0\n",
+			errorMsg:       `^the host is not in FIPS mode \(.*fips_enabled is "0"\)`,
+		},
+		{
+			name:           "no FIPS support in the kernel",
+			capableBackend: true,
+			goos:           "linux",
+			errorMsg:       `^failed to check that the host is in FIPS mode: open .*fips_enabled: no such file or directory$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fipsEnabledPath = filepath.Join(t.TempDir(), "fips_enabled")
+			if tc.fipsEnabled != "" {
+				if err := os.WriteFile(fipsEnabledPath, []byte(tc.fipsEnabled), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := verifyFIPS(tc.capableBackend, tc.goos)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFIPSCapableBuild(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings []debug.BuildSetting
+		expected bool
+	}{
+		{
+			name:     "no settings",
+			expected: false,
+		},
+		{
+			name:     "release build",
+			settings: []debug.BuildSetting{{Key: "-tags", Value: "release"}, {Key: "CGO_ENABLED", Value: "0"}},
+			expected: false,
+		},
+		{
+			name:     "fipscapable tag",
+			settings: []debug.BuildSetting{{Key: "-tags", Value: "release,fipscapable"}},
+			expected: true,
+		},
+		{
+			name:     "similar tag",
+			settings: []debug.BuildSetting{{Key: "-tags", Value: "notfipscapable"}},
+			expected: false,
+		},
+		{
+			name:     "strictfipsruntime experiment",
+			settings: []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "strictfipsruntime"}},
+			expected: true,
+		},
+		{
+			name:     "boringcrypto experiment",
+			settings: []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "arenas,boringcrypto"}},
+			expected: true,
+		},
+		{
+			name:     "other experiment",
+			settings: []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "arenas"}},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fipsCapableBuild(tc.settings))
+		})
+	}
+}
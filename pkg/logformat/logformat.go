@@ -0,0 +1,104 @@
+// Package logformat defines the fields of the structured logs of the installer
+// and the formatters of its logs.
+package logformat
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FormatText formats the logs as free-form text.
+	FormatText = "text"
+	// FormatJSON formats the logs as JSON objects, one per line.
+	FormatJSON = "json"
+)
+
+const (
+	// AssetField is the name of the asset being generated.
+	AssetField = "asset"
+	// PlatformField is the platform of the cluster.
+	PlatformField = "platform"
+	// APICallField is the name of the cloud API call, e.g. "DescribeSubnets".
+	APICallField = "api_call"
+	// OperationField is the name of an operation other than an API call,
+	// e.g. a validation or a provisioning step.
+	OperationField = "operation"
+	// DurationField is the duration, in seconds, of an asset generation, an
+	// API call or an operation.
+	DurationField = "duration"
+)
+
+// structuredFields are the fields which are only written in the JSON logs,
+// because the text of the messages already carries them.
+var structuredFields = []string{AssetField, PlatformField, APICallField, OperationField, DurationField}
+
+var (
+	platformLock sync.RWMutex
+	platform     string
+)
+
+// SetPlatform sets the platform of the cluster added to the JSON logs.
+func SetPlatform(name string) {
+	platformLock.Lock()
+	defer platformLock.Unlock()
+	platform = name
+}
+
+func getPlatform() string {
+	platformLock.RLock()
+	defer platformLock.RUnlock()
+	return platform
+}
+
+// JSONFormatter formats the entries as JSON objects, one per line, with the
+// platform of the cluster once it is known.
+type JSONFormatter struct {
+	logrus.JSONFormatter
+}
+
+// Format implements logrus.Formatter.
+func (f *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if p := getPlatform(); p != "" {
+		if _, ok := entry.Data[PlatformField]; !ok {
+			entry = withData(entry, func(data logrus.Fields) { data[PlatformField] = p })
+		}
+	}
+	return f.JSONFormatter.Format(entry)
+}
+
+// TextFormatter formats the entries as text, without the structured fields,
+// so that the text logs read as before the structured fields were added.
+type TextFormatter struct {
+	logrus.TextFormatter
+}
+
+// Format implements logrus.Formatter.
+func (f *TextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	for _, field := range structuredFields {
+		if _, ok := entry.Data[field]; ok {
+			entry = withData(entry, func(data logrus.Fields) {
+				for _, field := range structuredFields {
+					delete(data, field)
+				}
+			})
+			break
+		}
+	}
+	return f.TextFormatter.Format(entry)
+}
+
+// withData returns a copy of the entry with a copy of its data modified by
+// the function. The hooks share the entry, so it must not be modified.
+func withData(entry *logrus.Entry, modify func(logrus.Fields)) *logrus.Entry {
+	data := make(logrus.Fields, len(entry.Data)+1)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	modify(data)
+
+	copied := *entry
+	copied.Data = data
+	return &copied
+}
@@ -0,0 +1,71 @@
+package logformat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatters(t *testing.T) {
+	defer SetPlatform("")
+
+	entry := logrus.NewEntry(logrus.New()).WithFields(logrus.Fields{
+		AssetField:    "Install Config",
+		DurationField: 1.5,
+		"stage":       "bootstrap",
+	})
+	entry.Level = logrus.InfoLevel
+	entry.Message = "Generated Install Config"
+
+	cases := []struct {
+		name     string
+		platform string
+		expected map[string]interface{}
+	}{
+		{
+			name: "no platform",
+			expected: map[string]interface{}{
+				"level":       "info",
+				"msg":         "Generated Install Config",
+				AssetField:    "Install Config",
+				DurationField: 1.5,
+				"stage":       "bootstrap",
+			},
+		},
+		{
+			name:     "platform",
+			platform: "aws",
+			expected: map[string]interface{}{
+				"level":       "info",
+				"msg":         "Generated Install Config",
+				AssetField:    "Install Config",
+				DurationField: 1.5,
+				PlatformField: "aws",
+				"stage":       "bootstrap",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetPlatform(tc.platform)
+			line, err := (&JSONFormatter{logrus.JSONFormatter{DisableTimestamp: true}}).Format(entry)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var actual map[string]interface{}
+			assert.NoError(t, json.Unmarshal(line, &actual))
+			assert.Equal(t, tc.expected, actual)
+			assert.NotContains(t, entry.Data, PlatformField, "entry modified")
+		})
+	}
+
+	t.Run("text", func(t *testing.T) {
+		SetPlatform("aws")
+		line, err := (&TextFormatter{logrus.TextFormatter{DisableTimestamp: true, DisableColors: true}}).Format(entry)
+		assert.NoError(t, err)
+		assert.Equal(t, "level=info msg=\"Generated Install Config\" stage=bootstrap\n", string(line))
+		assert.Contains(t, entry.Data, AssetField, "entry modified")
+	})
+}
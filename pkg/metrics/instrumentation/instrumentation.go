@@ -0,0 +1,205 @@
+// Package instrumentation records the durations of the cloud API calls, the
+// validations and the provisioning steps of a run, and writes them to the
+// asset directory to help triage slow installs.
+package instrumentation
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/logformat"
+)
+
+// MetricsFileName is the name of the file in the asset directory to which the
+// records of a run are written.
+const MetricsFileName = ".openshift_install_metrics.json"
+
+// Kind is the kind of an instrumented operation.
+type Kind string
+
+const (
+	// KindAPICall is a call to a cloud API, including its retries.
+	KindAPICall Kind = "api-call"
+	// KindValidation is a validation check.
+	KindValidation Kind = "validation"
+	// KindProvisioning is a step which provisions the infrastructure.
+	KindProvisioning Kind = "provisioning"
+)
+
+// Record is the duration and outcome of an operation.
+type Record struct {
+	Kind            Kind      `json:"kind"`
+	Name            string    `json:"name"`
+	Start           time.Time `json:"start"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	// Error is the failure of the operation, if any.
+	Error string `json:"error,omitempty"`
+	// Attributes are details of the operation, e.g. the status code of an
+	// API call.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Summary aggregates the records of the operations of the same kind and name.
+type Summary struct {
+	Kind         Kind    `json:"kind"`
+	Name         string  `json:"name"`
+	Count        int     `json:"count"`
+	Errors       int     `json:"errors"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	MeanSeconds  float64 `json:"meanSeconds"`
+	MaxSeconds   float64 `json:"maxSeconds"`
+}
+
+// Metrics is the content of the metrics file.
+type Metrics struct {
+	// Summaries are sorted by decreasing total duration.
+	Summaries []Summary `json:"summaries"`
+	Records   []Record  `json:"records"`
+}
+
+// Recorder collects the records of a run. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+	now     func() time.Time
+}
+
+var recorder = NewRecorder()
+
+// NewRecorder returns an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{now: time.Now}
+}
+
+// Observe records an operation which started at start and has just ended.
+func (r *Recorder) Observe(kind Kind, name string, start time.Time, err error, attributes map[string]string) {
+	record := Record{
+		Kind:            kind,
+		Name:            name,
+		Start:           start,
+		DurationSeconds: r.now().Sub(start).Seconds(),
+		Attributes:      attributes,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	logRecord(record, err)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+}
+
+// logRecord logs the record at debug level, with the structured fields of the
+// operation.
+func logRecord(record Record, err error) {
+	nameField := logformat.OperationField
+	if record.Kind == KindAPICall {
+		nameField = logformat.APICallField
+	}
+	fields := logrus.Fields{
+		nameField:               record.Name,
+		logformat.DurationField: record.DurationSeconds,
+	}
+	for k, v := range record.Attributes {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+	entry := logrus.WithFields(fields)
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	entry.Debugf("Completed %s %s in %.3fs", record.Kind, record.Name, record.DurationSeconds)
+}
+
+// Time runs the operation and records its duration and outcome.
+func (r *Recorder) Time(kind Kind, name string, operation func() error) error {
+	start := r.now()
+	err := operation()
+	r.Observe(kind, name, start, err, nil)
+	return err
+}
+
+// Metrics returns the records, and their summaries.
+func (r *Recorder) Metrics() *Metrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type key struct {
+		kind Kind
+		name string
+	}
+	summaries := map[key]*Summary{}
+	for _, record := range r.records {
+		k := key{kind: record.Kind, name: record.Name}
+		s, ok := summaries[k]
+		if !ok {
+			s = &Summary{Kind: record.Kind, Name: record.Name}
+			summaries[k] = s
+		}
+		s.Count++
+		if record.Error != "" {
+			s.Errors++
+		}
+		s.TotalSeconds += record.DurationSeconds
+		if record.DurationSeconds > s.MaxSeconds {
+			s.MaxSeconds = record.DurationSeconds
+		}
+	}
+
+	metrics := &Metrics{
+		Summaries: make([]Summary, 0, len(summaries)),
+		Records:   append([]Record{}, r.records...),
+	}
+	for _, s := range summaries {
+		s.MeanSeconds = s.TotalSeconds / float64(s.Count)
+		metrics.Summaries = append(metrics.Summaries, *s)
+	}
+	sort.Slice(metrics.Summaries, func(i, j int) bool {
+		si, sj := metrics.Summaries[i], metrics.Summaries[j]
+		if si.TotalSeconds != sj.TotalSeconds {
+			return si.TotalSeconds > sj.TotalSeconds
+		}
+		if si.Kind != sj.Kind {
+			return si.Kind < sj.Kind
+		}
+		return si.Name < sj.Name
+	})
+	return metrics
+}
+
+// Write writes the metrics to the metrics file in the directory, unless
+// nothing was recorded.
+func (r *Recorder) Write(dir string) error {
+	metrics := r.Metrics()
+	if len(metrics.Records) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, MetricsFileName), data, 0o640) //nolint:gosec // no sensitive info
+}
+
+// Observe records an operation which started at start and has just ended.
+func Observe(kind Kind, name string, start time.Time, err error, attributes map[string]string) {
+	recorder.Observe(kind, name, start, err, attributes)
+}
+
+// Time runs the operation and records its duration and outcome.
+func Time(kind Kind, name string, operation func() error) error {
+	return recorder.Time(kind, name, operation)
+}
+
+// Write writes the metrics of the run to the metrics file in the directory,
+// unless nothing was recorded.
+func Write(dir string) error {
+	return recorder.Write(dir)
+}
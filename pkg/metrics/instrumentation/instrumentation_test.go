@@ -0,0 +1,63 @@
+package instrumentation
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderMetrics(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRecorder()
+	r.now = func() time.Time { return now }
+
+	r.Observe(KindAPICall, "GET /v1/vpcs", now.Add(-2*time.Second), nil, map[string]string{"status": "200"})
+	r.Observe(KindAPICall, "GET /v1/vpcs", now.Add(-4*time.Second), errors.New("503 Service Unavailable"), nil)
+	r.Observe(KindValidation, "capacity", now.Add(-10*time.Second), nil, nil)
+
+	metrics := r.Metrics()
+	assert.Equal(t, []Summary{
+		{Kind: KindValidation, Name: "capacity", Count: 1, TotalSeconds: 10, MeanSeconds: 10, MaxSeconds: 10},
+		{Kind: KindAPICall, Name: "GET /v1/vpcs", Count: 2, Errors: 1, TotalSeconds: 6, MeanSeconds: 3, MaxSeconds: 4},
+	}, metrics.Summaries)
+	assert.Len(t, metrics.Records, 3)
+	assert.Equal(t, "503 Service Unavailable", metrics.Records[1].Error)
+}
+
+func TestRecorderTime(t *testing.T) {
+	r := NewRecorder()
+	failure := errors.New("failed")
+	err := r.Time(KindProvisioning, "bootstrap", func() error { return failure })
+	assert.Equal(t, failure, err)
+
+	metrics := r.Metrics()
+	assert.Len(t, metrics.Records, 1)
+	assert.Equal(t, KindProvisioning, metrics.Records[0].Kind)
+	assert.Equal(t, "failed", metrics.Records[0].Error)
+}
+
+func TestRecorderWrite(t *testing.T) {
+	dir := t.TempDir()
+	r := NewRecorder()
+
+	// Nothing is written when nothing was recorded.
+	assert.NoError(t, r.Write(dir))
+	_, err := os.Stat(filepath.Join(dir, MetricsFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	r.Observe(KindValidation, "image import", time.Now(), nil, nil)
+	assert.NoError(t, r.Write(dir))
+	data, err := os.ReadFile(filepath.Join(dir, MetricsFileName))
+	if !assert.NoError(t, err) {
+		return
+	}
+	metrics := &Metrics{}
+	assert.NoError(t, json.Unmarshal(data, metrics))
+	assert.Len(t, metrics.Summaries, 1)
+	assert.Equal(t, "image import", metrics.Summaries[0].Name)
+}
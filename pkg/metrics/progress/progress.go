@@ -0,0 +1,176 @@
+// Package progress emits machine-readable events about the progress of the
+// stages of the create and destroy commands.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// FormatText reports progress only through the log.
+	FormatText = "text"
+	// FormatJSON additionally writes one JSON event per line.
+	FormatJSON = "json"
+)
+
+// Status is the status of a stage reported by an event.
+type Status string
+
+const (
+	// StatusStarted is reported when a stage starts.
+	StatusStarted Status = "started"
+	// StatusProgressing is reported while a stage makes progress.
+	StatusProgressing Status = "progressing"
+	// StatusCompleted is reported when a stage completes successfully.
+	StatusCompleted Status = "completed"
+	// StatusFailed is reported when a stage fails.
+	StatusFailed Status = "failed"
+)
+
+// Event is a progress event.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Stage     string    `json:"stage"`
+	Status    Status    `json:"status"`
+	// Percent is the completion of the stage, if known.
+	Percent *int `json:"percent,omitempty"`
+	// Resources are the IDs of the resources created or deleted by the stage.
+	Resources []string `json:"resources,omitempty"`
+	// Host is the host whose progress is reported, for the stages which
+	// install several hosts.
+	Host    *Host  `json:"host,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Host is the progress of a single host.
+type Host struct {
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+	// Stage is the stage the host has reached, e.g. installing or joined.
+	Stage string `json:"stage"`
+}
+
+// Listener receives the progress events, e.g. to serve the status of the
+// command.
+type Listener func(Event)
+
+// Reporter writes progress events.
+type Reporter struct {
+	mu        sync.Mutex
+	encoder   *json.Encoder
+	listeners []Listener
+	now       func() time.Time
+}
+
+var reporter = NewReporter(nil)
+
+// NewReporter returns a reporter which writes the events to w as JSON lines.
+// A reporter with a nil writer discards the events.
+func NewReporter(w io.Writer) *Reporter {
+	r := &Reporter{now: time.Now}
+	if w != nil {
+		r.encoder = json.NewEncoder(w)
+	}
+	return r
+}
+
+// SetOutput makes the package-level functions write the events to w. A nil
+// writer discards the events.
+func SetOutput(w io.Writer) {
+	r := NewReporter(w)
+	reporter.mu.Lock()
+	r.listeners = reporter.listeners
+	reporter.mu.Unlock()
+	reporter = r
+}
+
+// AddListener makes the package-level functions send the events to the
+// listener, whatever the output of the events.
+func AddListener(l Listener) {
+	reporter.AddListener(l)
+}
+
+// Start reports that the stage started.
+func Start(stage string) {
+	reporter.Start(stage)
+}
+
+// Progress reports the completion of the stage in percent.
+func Progress(stage string, percent int, message string) {
+	reporter.Progress(stage, percent, message)
+}
+
+// HostProgress reports the progress of a host installed by the stage, and the
+// completion of the host in percent.
+func HostProgress(stage string, host Host, percent int, message string) {
+	reporter.HostProgress(stage, host, percent, message)
+}
+
+// Complete reports that the stage completed, and the resources it created or
+// deleted.
+func Complete(stage string, resources ...string) {
+	reporter.Complete(stage, resources...)
+}
+
+// Fail reports that the stage failed with the error.
+func Fail(stage string, err error) {
+	reporter.Fail(stage, err)
+}
+
+// Start reports that the stage started.
+func (r *Reporter) Start(stage string) {
+	percent := 0
+	r.emit(Event{Stage: stage, Status: StatusStarted, Percent: &percent})
+}
+
+// Progress reports the completion of the stage in percent.
+func (r *Reporter) Progress(stage string, percent int, message string) {
+	r.emit(Event{Stage: stage, Status: StatusProgressing, Percent: &percent, Message: message})
+}
+
+// HostProgress reports the progress of a host installed by the stage, and the
+// completion of the host in percent.
+func (r *Reporter) HostProgress(stage string, host Host, percent int, message string) {
+	r.emit(Event{Stage: stage, Status: StatusProgressing, Percent: &percent, Host: &host, Message: message})
+}
+
+// Complete reports that the stage completed, and the resources it created or
+// deleted.
+func (r *Reporter) Complete(stage string, resources ...string) {
+	percent := 100
+	r.emit(Event{Stage: stage, Status: StatusCompleted, Percent: &percent, Resources: resources})
+}
+
+// Fail reports that the stage failed with the error.
+func (r *Reporter) Fail(stage string, err error) {
+	event := Event{Stage: stage, Status: StatusFailed}
+	if err != nil {
+		event.Message = err.Error()
+	}
+	r.emit(event)
+}
+
+// AddListener sends the events of the reporter to the listener.
+func (r *Reporter) AddListener(l Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, l)
+}
+
+func (r *Reporter) emit(event Event) {
+	event.Timestamp = r.now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, l := range r.listeners {
+		l(event)
+	}
+	if r.encoder == nil {
+		return
+	}
+	// Progress reporting is best effort and must not fail the command.
+	_ = r.encoder.Encode(&event)
+}
@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewReporter(buf)
+	r.now = func() time.Time {
+		return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	r.Start("Cluster")
+	r.Progress("Cluster Operators", 42, "Working towards 4.13.0")
+	r.HostProgress("Hosts", Host{Name: "master-0", Role: "master", Stage: "installing"}, 38, "Writing image to disk")
+	r.Complete("bootstrap", "aws_instance.bootstrap:i-0123")
+	r.Fail("Bootstrap Complete", errors.New("timed out"))
+
+	assert.Equal(t, `{"timestamp":"2023-01-02T03:04:05Z","stage":"Cluster","status":"started","percent":0}
+{"timestamp":"2023-01-02T03:04:05Z","stage":"Cluster Operators","status":"progressing","percent":42,"message":"Working towards 4.13.0"}
+{"timestamp":"2023-01-02T03:04:05Z","stage":"Hosts","status":"progressing","percent":38,"host":{"name":"master-0","role":"master","stage":"installing"},"message":"Writing image to disk"}
+{"timestamp":"2023-01-02T03:04:05Z","stage":"bootstrap","status":"completed","percent":100,"resources":["aws_instance.bootstrap:i-0123"]}
+{"timestamp":"2023-01-02T03:04:05Z","stage":"Bootstrap Complete","status":"failed","message":"timed out"}
+`, buf.String())
+}
+
+func TestReporterWithoutOutput(t *testing.T) {
+	r := NewReporter(nil)
+	r.Start("Cluster")
+	r.Complete("Cluster")
+}
+
+func TestReporterListener(t *testing.T) {
+	r := NewReporter(nil)
+	events := []Event{}
+	r.AddListener(func(e Event) {
+		events = append(events, e)
+	})
+
+	r.Start("Cluster")
+	r.Fail("Cluster", errors.New("timed out"))
+
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	assert.Equal(t, StatusStarted, events[0].Status)
+	assert.Equal(t, "timed out", events[1].Message)
+}
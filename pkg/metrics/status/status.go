@@ -0,0 +1,216 @@
+// Package status serves the progress of the create cluster command as JSON
+// over a local HTTP endpoint, so wrappers can poll it without parsing the
+// logs.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/metrics/progress"
+)
+
+const (
+	// Path is the path of the status endpoint.
+	Path = "/status"
+
+	// ClusterOperatorsStage is the stage which reports the progress of the
+	// cluster operators.
+	ClusterOperatorsStage = "Cluster Operators"
+
+	// maxErrors is the number of recent errors kept in the status.
+	maxErrors = 10
+)
+
+// Stage is the status of a stage of the command.
+type Stage struct {
+	Name   string          `json:"name"`
+	Status progress.Status `json:"status"`
+	// Percent is the completion of the stage, if known.
+	Percent   *int       `json:"percent,omitempty"`
+	Message   string     `json:"message,omitempty"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// Error is an error logged by the command.
+type Error struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Stage is the stage which failed, if the error failed a stage.
+	Stage   string `json:"stage,omitempty"`
+	Message string `json:"message"`
+}
+
+// Status is the status of the command served by the endpoint.
+type Status struct {
+	StartedAt time.Time `json:"startedAt"`
+	// CurrentStage is the stage which started last and has not ended.
+	CurrentStage *Stage `json:"currentStage,omitempty"`
+	// Completed are the names of the completed stages, which include the
+	// generated assets, in the order they completed.
+	Completed []string `json:"completed"`
+	// ClusterOperators is the progress of the cluster operators, once the
+	// installer waits for them.
+	ClusterOperators *Stage `json:"clusterOperators,omitempty"`
+	// Stages are all the stages of the command, in the order they started.
+	Stages []Stage `json:"stages"`
+	// RecentErrors are the last errors of the command, oldest first.
+	RecentErrors []Error `json:"recentErrors"`
+}
+
+// Tracker tracks the status of the command from its progress events and its
+// error logs.
+type Tracker struct {
+	mu     sync.Mutex
+	status Status
+	stages map[string]int
+}
+
+var _ logrus.Hook = (*Tracker)(nil)
+
+// NewTracker returns a tracker of a command which starts now.
+func NewTracker() *Tracker {
+	return &Tracker{
+		status: Status{
+			StartedAt:    time.Now().UTC(),
+			Completed:    []string{},
+			Stages:       []Stage{},
+			RecentErrors: []Error{},
+		},
+		stages: map[string]int{},
+	}
+}
+
+// Observe updates the status with the progress event.
+func (t *Tracker) Observe(event progress.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i, ok := t.stages[event.Stage]
+	if !ok || event.Status == progress.StatusStarted {
+		t.status.Stages = append(t.status.Stages, Stage{Name: event.Stage, StartedAt: event.Timestamp})
+		i = len(t.status.Stages) - 1
+		t.stages[event.Stage] = i
+	}
+	stage := &t.status.Stages[i]
+	stage.Status = event.Status
+	if event.Percent != nil {
+		percent := *event.Percent
+		stage.Percent = &percent
+	}
+	// The host progress events report the stages of single hosts.
+	if event.Host == nil {
+		stage.Message = event.Message
+	}
+
+	switch event.Status {
+	case progress.StatusCompleted:
+		endedAt := event.Timestamp
+		stage.EndedAt = &endedAt
+		t.status.Completed = append(t.status.Completed, event.Stage)
+	case progress.StatusFailed:
+		endedAt := event.Timestamp
+		stage.EndedAt = &endedAt
+		t.addError(Error{Timestamp: event.Timestamp, Stage: event.Stage, Message: event.Message})
+	}
+}
+
+// Levels returns the log levels of the errors of the status.
+func (t *Tracker) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire adds the logged error to the recent errors of the status.
+func (t *Tracker) Fire(entry *logrus.Entry) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.addError(Error{Timestamp: entry.Time.UTC(), Message: entry.Message})
+	return nil
+}
+
+func (t *Tracker) addError(err Error) {
+	t.status.RecentErrors = append(t.status.RecentErrors, err)
+	if n := len(t.status.RecentErrors); n > maxErrors {
+		t.status.RecentErrors = append([]Error{}, t.status.RecentErrors[n-maxErrors:]...)
+	}
+}
+
+// Status returns a snapshot of the status.
+func (t *Tracker) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := t.status
+	status.Completed = append([]string{}, t.status.Completed...)
+	status.Stages = append([]Stage{}, t.status.Stages...)
+	status.RecentErrors = append([]Error{}, t.status.RecentErrors...)
+	for i := len(status.Stages) - 1; i >= 0; i-- {
+		stage := status.Stages[i]
+		if stage.EndedAt == nil && status.CurrentStage == nil {
+			status.CurrentStage = &stage
+		}
+		if stage.Name == ClusterOperatorsStage && status.ClusterOperators == nil {
+			status.ClusterOperators = &stage
+		}
+	}
+	return status
+}
+
+// ServeHTTP writes the status as JSON.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(t.Status()); err != nil {
+		logrus.Debugf("Failed to write the status: %v", err)
+	}
+}
+
+// Serve serves the status of the tracker on the address until the context is
+// done, and returns the address it listens on. The address must be a loopback
+// address, as the status is not authenticated; a zero port picks a free port.
+func Serve(ctx context.Context, address string, t *Tracker) (net.Addr, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid status address %q", address)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, errors.Errorf("invalid status address %q: the status is only served on loopback addresses", address)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen for the status")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, t)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Debugf("The status server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	return listener.Addr(), nil
+}
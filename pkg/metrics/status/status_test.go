@@ -0,0 +1,108 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/metrics/progress"
+)
+
+func TestTracker(t *testing.T) {
+	timestamp := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	percent := func(p int) *int { return &p }
+
+	tracker := NewTracker()
+	for _, event := range []progress.Event{
+		{Stage: "Metadata", Status: progress.StatusStarted, Percent: percent(0)},
+		{Stage: "Metadata", Status: progress.StatusCompleted, Percent: percent(100)},
+		{Stage: "Cluster", Status: progress.StatusStarted, Percent: percent(0)},
+		{Stage: "Cluster", Status: progress.StatusProgressing, Percent: percent(50), Message: `applied stage "network"`},
+		{Stage: "Cluster", Status: progress.StatusCompleted, Percent: percent(100)},
+		{Stage: "Install Complete", Status: progress.StatusStarted, Percent: percent(0)},
+		{Stage: ClusterOperatorsStage, Status: progress.StatusProgressing, Percent: percent(42), Message: "Working towards 4.13.0"},
+	} {
+		event.Timestamp = timestamp
+		tracker.Observe(event)
+	}
+
+	status := tracker.Status()
+	assert.Equal(t, []string{"Metadata", "Cluster"}, status.Completed)
+	if assert.NotNil(t, status.CurrentStage) {
+		assert.Equal(t, ClusterOperatorsStage, status.CurrentStage.Name)
+	}
+	if assert.NotNil(t, status.ClusterOperators) {
+		assert.Equal(t, 42, *status.ClusterOperators.Percent)
+		assert.Equal(t, "Working towards 4.13.0", status.ClusterOperators.Message)
+	}
+	assert.Len(t, status.Stages, 4)
+	assert.Empty(t, status.RecentErrors)
+
+	tracker.Observe(progress.Event{Timestamp: timestamp, Stage: ClusterOperatorsStage, Status: progress.StatusFailed, Message: "timed out"})
+	tracker.Observe(progress.Event{Timestamp: timestamp, Stage: "Install Complete", Status: progress.StatusFailed, Message: "timed out"})
+	status = tracker.Status()
+	assert.Nil(t, status.CurrentStage)
+	assert.Equal(t, []Error{
+		{Timestamp: timestamp, Stage: ClusterOperatorsStage, Message: "timed out"},
+		{Timestamp: timestamp, Stage: "Install Complete", Message: "timed out"},
+	}, status.RecentErrors)
+}
+
+func TestTrackerRecentErrors(t *testing.T) {
+	tracker := NewTracker()
+	for i := 0; i < 15; i++ {
+		assert.NoError(t, tracker.Fire(&logrus.Entry{Message: fmt.Sprintf("error %d", i)}))
+	}
+
+	errs := tracker.Status().RecentErrors
+	if assert.Len(t, errs, maxErrors) {
+		assert.Equal(t, "error 5", errs[0].Message)
+		assert.Equal(t, "error 14", errs[maxErrors-1].Message)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Observe(progress.Event{Stage: "Cluster", Status: progress.StatusStarted})
+
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	status := Status{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	if assert.NotNil(t, status.CurrentStage) {
+		assert.Equal(t, "Cluster", status.CurrentStage.Name)
+	}
+
+	rec = httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tracker := NewTracker()
+
+	_, err := Serve(ctx, "0.0.0.0:0", tracker)
+	assert.EqualError(t, err, `invalid status address "0.0.0.0:0": the status is only served on loopback addresses`)
+
+	addr, err := Serve(ctx, "127.0.0.1:0", tracker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", addr, Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/quota"
 )
@@ -21,17 +23,23 @@ func Load(ctx context.Context, sess *session.Session, region string, services ..
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load limits for servicequotas")
 	}
-	return newQuota(region, records), nil
+	usage, err := loadUsage(ctx, ec2.New(sess, aws.NewConfig().WithRegion(region)))
+	if err != nil {
+		// The limits are still useful without the usage, so only the
+		// requirements exceeding the limits are caught.
+		logrus.Debugf("Failed to load the usage of the quotas, assuming none: %v", err)
+	}
+	return newQuota(region, records, usage), nil
 }
 
-func newQuota(region string, limits []record) []quota.Quota {
+func newQuota(region string, limits []record, usage map[string]int64) []quota.Quota {
 	var ret []quota.Quota
 	for _, limit := range limits {
 		q := quota.Quota{
 			Service: limit.Service,
 			Name:    fmt.Sprintf("%s/%s", limit.Service, limit.Name),
 			Region:  region,
-			InUse:   0,
+			InUse:   usage[fmt.Sprintf("%s/%s", limit.Service, limit.Name)],
 			Limit:   limit.Value,
 		}
 		if limit.global {
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+)
+
+// loadUsage counts the resources in use for the quotas whose usage is not
+// reported by Service Quotas.
+func loadUsage(ctx context.Context, client *ec2.EC2) (map[string]int64, error) {
+	usage := map[string]int64{}
+
+	if err := client.DescribeVpcsPagesWithContext(ctx,
+		&ec2.DescribeVpcsInput{},
+		func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+			usage["vpc/L-F678F1CE"] += int64(len(page.Vpcs))
+			return !lastPage
+		}); err != nil {
+		return nil, errors.Wrap(err, "failed to count VPCs")
+	}
+
+	if err := client.DescribeInternetGatewaysPagesWithContext(ctx,
+		&ec2.DescribeInternetGatewaysInput{},
+		func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+			usage["vpc/L-A4707A72"] += int64(len(page.InternetGateways))
+			return !lastPage
+		}); err != nil {
+		return nil, errors.Wrap(err, "failed to count internet gateways")
+	}
+
+	addresses, err := client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("domain"),
+			Values: []*string{aws.String(ec2.DomainTypeVpc)},
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count elastic IPs")
+	}
+	usage["ec2/L-0263D0A3"] = int64(len(addresses.Addresses))
+
+	return usage, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/coreos/stream-metadata-go/arch"
+	"github.com/coreos/stream-metadata-go/stream"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -17,6 +18,11 @@ func AMIRegions(architecture types.Architecture) sets.String {
 		logrus.Error("could not fetch the rhcos stream data: %w", err)
 		return nil
 	}
+	return StreamAMIRegions(stream, architecture)
+}
+
+// StreamAMIRegions returns the AWS regions in which the stream has an RHCOS AMI for the specified architecture.
+func StreamAMIRegions(stream *stream.Stream, architecture types.Architecture) sets.String {
 	rpmArch := arch.RpmArch(string(architecture))
 	awsImages := stream.Architectures[rpmArch].Images.Aws
 	if awsImages == nil {
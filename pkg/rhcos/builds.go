@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"os"
 
 	"github.com/coreos/stream-metadata-go/stream"
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // FetchRawCoreOSStream returns the raw stream metadata for the
@@ -41,6 +43,24 @@ func FetchCoreOSBuild(ctx context.Context) (*stream.Stream, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseCoreOSStream(body)
+}
+
+// FetchCoreOSStream returns the stream metadata of the boot images of a
+// cluster: the stream metadata file of the coreOSStream of its install
+// config, if any, or else the stream embedded in the installer.
+func FetchCoreOSStream(ctx context.Context, coreOSStream *types.CoreOSStream) (*stream.Stream, error) {
+	if coreOSStream == nil || coreOSStream.Path == "" {
+		return FetchCoreOSBuild(ctx)
+	}
+	body, err := os.ReadFile(coreOSStream.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CoreOS stream metadata")
+	}
+	return parseCoreOSStream(body)
+}
+
+func parseCoreOSStream(body []byte) (*stream.Stream, error) {
 	var st stream.Stream
 	if err := json.Unmarshal(body, &st); err != nil {
 		return nil, errors.Wrap(err, "failed to parse CoreOS stream metadata")
@@ -0,0 +1,96 @@
+package rhcos
+
+import (
+	"fmt"
+
+	"github.com/coreos/stream-metadata-go/arch"
+	"github.com/coreos/stream-metadata-go/stream"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/alibabacloud"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
+	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/libvirt"
+	"github.com/openshift/installer/pkg/types/none"
+	"github.com/openshift/installer/pkg/types/nutanix"
+	"github.com/openshift/installer/pkg/types/openstack"
+	"github.com/openshift/installer/pkg/types/ovirt"
+	"github.com/openshift/installer/pkg/types/powervs"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// bootImageArtifacts are the stream artifacts of the boot images of the
+// platforms which boot from a downloaded image.
+var bootImageArtifacts = map[string]string{
+	baremetal.Name: "metal",
+	external.Name:  "metal",
+	ibmcloud.Name:  "ibmcloud",
+	libvirt.Name:   "qemu",
+	none.Name:      "metal",
+	nutanix.Name:   "nutanix",
+	openstack.Name: "openstack",
+	ovirt.Name:     "openstack",
+	vsphere.Name:   "vmware",
+}
+
+// BootImageRelease returns the RHCOS build of the boot image of the platform
+// for the architecture in the stream, or an error if the stream has no boot
+// image for them.
+func BootImageRelease(st *stream.Stream, architecture types.Architecture, platform *types.Platform) (string, error) {
+	archName := arch.RpmArch(string(architecture))
+	streamArch, err := st.GetArchitecture(archName)
+	if err != nil {
+		return "", err
+	}
+	prefix := st.FormatPrefix(archName)
+
+	switch platform.Name() {
+	case aws.Name:
+		if streamArch.Images.Aws != nil {
+			for _, region := range []string{platform.AWS.Region, "us-east-1"} {
+				if image, ok := streamArch.Images.Aws.Regions[region]; ok && image.Image != "" {
+					return image.Release, nil
+				}
+			}
+		}
+		return "", fmt.Errorf("%s: No AMI found in %s", prefix, platform.AWS.Region)
+	case alibabacloud.Name:
+		if streamArch.Images.Aliyun != nil {
+			if image, ok := streamArch.Images.Aliyun.Regions[platform.AlibabaCloud.Region]; ok {
+				return image.Release, nil
+			}
+		}
+		return "", fmt.Errorf("%s: No Alibaba Cloud image found in %s", prefix, platform.AlibabaCloud.Region)
+	case azure.Name:
+		if ext := streamArch.RHELCoreOSExtensions; ext != nil && ext.AzureDisk != nil {
+			return ext.AzureDisk.Release, nil
+		}
+		return "", fmt.Errorf("%s: No azure build found", prefix)
+	case gcp.Name:
+		if streamArch.Images.Gcp != nil {
+			return streamArch.Images.Gcp.Release, nil
+		}
+		return "", fmt.Errorf("%s: No GCP build found", prefix)
+	case powervs.Name:
+		if streamArch.Images.PowerVS != nil {
+			vpcRegion := powervs.Regions[platform.PowerVS.Region].VPCRegion
+			if image, ok := streamArch.Images.PowerVS.Regions[vpcRegion]; ok {
+				return image.Release, nil
+			}
+		}
+		return "", fmt.Errorf("%s: No Power VS build found in %s", prefix, platform.PowerVS.Region)
+	}
+
+	name, ok := bootImageArtifacts[platform.Name()]
+	if !ok {
+		return "", fmt.Errorf("invalid platform %v", platform.Name())
+	}
+	if a, ok := streamArch.Artifacts[name]; ok {
+		return a.Release, nil
+	}
+	return "", fmt.Errorf("%s: No %s build found", prefix, name)
+}
@@ -0,0 +1,68 @@
+package terraform
+
+import (
+	"regexp"
+
+	"github.com/openshift/installer/pkg/diagnostics"
+)
+
+// CapacityError is returned by Apply when the infrastructure provider could
+// not create an instance because it lacks the capacity for it. These errors
+// are usually transient, so the stage can be retried.
+type CapacityError struct {
+	// Zone is the zone lacking capacity, if the provider reported it.
+	Zone string
+
+	Err error
+}
+
+func (e *CapacityError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap allows the error to be unwrapped.
+func (e *CapacityError) Unwrap() error { return e.Err }
+
+// capacityConditions match the capacity errors of the providers. The zone
+// subexpression, when there is one, captures the zone lacking capacity.
+var capacityConditions = []condition{{
+	match: regexp.MustCompile(`InsufficientInstanceCapacity: .* in the Availability Zone you requested \((?P<zone>[a-z0-9-]+)\)`),
+
+	reason:  "AWSInsufficientInstanceCapacity",
+	message: `AWS does not have enough capacity for the instance type in the availability zone.`,
+}, {
+	match: regexp.MustCompile(`InsufficientInstanceCapacity`),
+
+	reason:  "AWSInsufficientInstanceCapacity",
+	message: `AWS does not have enough capacity for the instance type.`,
+}, {
+	match: regexp.MustCompile(`(?i)pcloudPvminstancesPost.*(insufficient|not enough) (capacity|resources|processor|memory)`),
+
+	reason:  "PowerVSInsufficientCapacity",
+	message: `The Power VS workspace pool does not have enough processor or memory capacity for the instance.`,
+}}
+
+// diagnoseCapacityError returns a CapacityError if the error from terraform
+// is a capacity error, and nil otherwise.
+func diagnoseCapacityError(err error) *CapacityError {
+	message := err.Error()
+	for _, cand := range capacityConditions {
+		match := cand.match.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		zone := ""
+		if i := cand.match.SubexpIndex("zone"); i >= 0 {
+			zone = match[i]
+		}
+		return &CapacityError{
+			Zone: zone,
+			Err: &diagnostics.Err{
+				Source:  "Infrastructure Provider",
+				Reason:  cand.reason,
+				Message: cand.message,
+			},
+		}
+	}
+	return nil
+}
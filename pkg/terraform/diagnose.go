@@ -13,6 +13,10 @@ func diagnoseApplyError(err error) error {
 		return nil
 	}
 
+	if cerr := diagnoseCapacityError(err); cerr != nil {
+		return cerr
+	}
+
 	message := err.Error()
 	for _, cand := range conditions {
 		if cand.match.MatchString(message) {
@@ -108,6 +108,26 @@ Error: could not inspect: could not inspect node, node is currently 'inspect fai
 `,
 
 		err: `error\(BaremetalIronicInspectTimeout\) from Infrastructure Provider: Timed out waiting for node inspection to complete\. Please check the console on the host for more details\.`,
+	}, {
+		input: `
+Error: creating EC2 Instance: InsufficientInstanceCapacity: We currently do not have sufficient m6i.xlarge capacity in the Availability Zone you requested (us-east-1e). Our system will be working on provisioning additional capacity. You can currently get m6i.xlarge capacity by not specifying an Availability Zone in your request or choosing us-east-1a, us-east-1b, us-east-1c.
+	status code: 500, request id: 5b1e3a52-8e0f-4a7e-9a0e-2b1b4c0c1d2e
+
+  with module.masters.aws_instance.master[2],
+  on master/main.tf line 131, in resource "aws_instance" "master":
+ 131: resource "aws_instance" "master" {
+`,
+
+		err: `error\(AWSInsufficientInstanceCapacity\) from Infrastructure Provider: AWS does not have enough capacity for the instance type in the availability zone\.`,
+	}, {
+		input: `
+Error: failed to provision: [POST /pcloud/v1/cloud-instances/{cloud_instance_id}/pvm-instances][400] pcloudPvminstancesPostBadRequest  &{Code:0 Description:bad request: insufficient processor capacity in the shared processor pool Error:bad request Message:}
+
+  with ibm_pi_instance.master[0],
+  on master/main.tf line 42, in resource "ibm_pi_instance" "master":
+`,
+
+		err: `error\(PowerVSInsufficientCapacity\) from Infrastructure Provider: The Power VS workspace pool does not have enough processor or memory capacity for the instance\.`,
 	}}
 
 	for _, test := range cases {
@@ -122,3 +142,41 @@ Error: could not inspect: could not inspect node, node is currently 'inspect fai
 		})
 	}
 }
+
+func TestDiagnoseCapacityError(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		capacity bool
+		zone     string
+	}{{
+		name:     "aws with zone",
+		input:    `Error: creating EC2 Instance: InsufficientInstanceCapacity: We currently do not have sufficient m6i.xlarge capacity in the Availability Zone you requested (us-east-1e). Our system will be working on provisioning additional capacity.`,
+		capacity: true,
+		zone:     "us-east-1e",
+	}, {
+		name:     "aws without zone",
+		input:    `Error: creating EC2 Instance: InsufficientInstanceCapacity: Insufficient capacity.`,
+		capacity: true,
+	}, {
+		name:     "powervs",
+		input:    `Error: failed to provision: pcloudPvminstancesPostBadRequest  &{Description:bad request: not enough memory available in the pool}`,
+		capacity: true,
+	}, {
+		name:  "other",
+		input: `Error: Error waiting for instance to create: Internal error`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cerr := diagnoseCapacityError(errors.New(tc.input))
+			if !tc.capacity {
+				assert.Nil(t, cerr)
+				return
+			}
+			if assert.NotNil(t, cerr) {
+				assert.Equal(t, tc.zone, cerr.Zone)
+			}
+		})
+	}
+}
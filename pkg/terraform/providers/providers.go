@@ -2,8 +2,10 @@ package providers
 
 import (
 	"embed"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -66,6 +68,27 @@ func provider(name string) Provider {
 //go:embed mirror/*
 var mirror embed.FS
 
+// versionsFile lists the upstream versions of terraform and of the providers,
+// which are all mirrored as version 1.0.0. It is written by hack/build.sh.
+const versionsFile = "mirror/versions.json"
+
+// Versions returns the upstream versions of the embedded terraform binary and
+// providers, by name. It is empty when the installer was not built with
+// hack/build.sh.
+func Versions() (map[string]string, error) {
+	versions := map[string]string{}
+	data, err := mirror.ReadFile(versionsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return versions, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", versionsFile)
+	}
+	return versions, nil
+}
+
 // Extract extracts the provider from the embedded data into the specified directory.
 func (p Provider) Extract(dir string) error {
 	providerDir := filepath.Join(strings.Split(p.Source, "/")...)
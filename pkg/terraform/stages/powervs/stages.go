@@ -1,15 +1,31 @@
 package powervs
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
+	powervsconfig "github.com/openshift/installer/pkg/asset/installconfig/powervs"
+	"github.com/openshift/installer/pkg/metrics/instrumentation"
 	"github.com/openshift/installer/pkg/terraform"
 	"github.com/openshift/installer/pkg/terraform/providers"
 	"github.com/openshift/installer/pkg/terraform/stages"
+	"github.com/openshift/installer/pkg/types"
 	powervstypes "github.com/openshift/installer/pkg/types/powervs"
 )
 
+// metadataFileName is the name of the cluster metadata file in the asset
+// directory.
+const metadataFileName = "metadata.json"
+
 // PlatformStages are the stages to run to provision the infrastructure in PowerVS.
 var PlatformStages = []terraform.Stage{
 	stages.NewStage("powervs",
@@ -18,7 +34,8 @@ var PlatformStages = []terraform.Stage{
 	stages.NewStage("powervs",
 		"bootstrap",
 		[]providers.Provider{providers.IBM, providers.Ignition, providers.Time},
-		stages.WithNormalBootstrapDestroy()),
+		stages.WithNormalBootstrapDestroy(),
+		stages.WithCustomExtractHostAddresses(extractLeaseHostAddresses)),
 	stages.NewStage("powervs",
 		"bootstrap-routing",
 		[]providers.Provider{providers.IBM},
@@ -32,7 +49,68 @@ func removeFromLoadBalancers(s stages.SplitStage, directory string, terraformDir
 	}
 	opts = append(opts, tfexec.Var("powervs_expose_bootstrap=false"))
 	return errors.Wrap(
-		terraform.Apply(directory, powervstypes.Name, s, terraformDir, opts...),
+		instrumentation.Time(instrumentation.KindProvisioning, "powervs bootstrap load balancing removal", func() error {
+			return terraform.Apply(directory, powervstypes.Name, s, terraformDir, opts...)
+		}),
 		"failed disabling bootstrap load balancing",
 	)
 }
+
+// extractLeaseHostAddresses returns the addresses of the bootstrap and control
+// plane machines, which are assigned by the DHCP server of the workspace and
+// therefore not known to terraform.
+func extractLeaseHostAddresses(s stages.SplitStage, directory string, config *types.InstallConfig) (string, int, []string, error) {
+	data, err := os.ReadFile(filepath.Join(directory, metadataFileName))
+	if err != nil {
+		return "", 0, nil, errors.Wrap(err, "failed to read cluster metadata")
+	}
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return "", 0, nil, errors.Wrap(err, "failed to unmarshal cluster metadata")
+	}
+
+	serviceInstanceID := config.Platform.PowerVS.ServiceInstanceID
+	if serviceInstanceID == "" && metadata.PowerVS != nil {
+		serviceInstanceID = metadata.PowerVS.ServiceInstanceGUID
+	}
+
+	client, err := powervsconfig.NewBxClient(config.Platform.PowerVS.ServiceEndpoints)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if err := client.NewPISession(); err != nil {
+		return "", 0, nil, errors.Wrap(err, "failed to create a Power VS session")
+	}
+
+	var ips map[string]string
+	err = instrumentation.Time(instrumentation.KindProvisioning, "powervs DHCP lease lookup", func() error {
+		var err error
+		ips, err = client.GetInstanceLeaseIPs(context.TODO(), serviceInstanceID, metadata.InfraID)
+		return err
+	})
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	bootstrap, masters := leaseHostAddresses(metadata.InfraID, ips)
+	if bootstrap == "" {
+		logrus.Debugf("No DHCP lease found for the bootstrap machine of %s", metadata.InfraID)
+	}
+	return bootstrap, 0, masters, nil
+}
+
+// leaseHostAddresses returns the addresses of the bootstrap and control plane
+// machines of the cluster among the leased addresses, keyed by the name of the
+// instance.
+func leaseHostAddresses(infraID string, ips map[string]string) (string, []string) {
+	bootstrap := ips[fmt.Sprintf("%s-bootstrap", infraID)]
+	masterPrefix := fmt.Sprintf("%s-master-", infraID)
+	var masters []string
+	for name, ip := range ips {
+		if strings.HasPrefix(name, masterPrefix) {
+			masters = append(masters, ip)
+		}
+	}
+	sort.Strings(masters)
+	return bootstrap, masters
+}
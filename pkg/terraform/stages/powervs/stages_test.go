@@ -0,0 +1,55 @@
+package powervs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaseHostAddresses(t *testing.T) {
+	cases := []struct {
+		name              string
+		ips               map[string]string
+		expectedBootstrap string
+		expectedMasters   []string
+	}{
+		{
+			name: "no leases",
+		},
+		{
+			name: "bootstrap and control plane",
+			ips: map[string]string{
+				"infra-id-master-2":  "192.168.0.12",
+				"infra-id-bootstrap": "192.168.0.5",
+				"infra-id-master-0":  "192.168.0.10",
+				"infra-id-master-1":  "192.168.0.11",
+			},
+			expectedBootstrap: "192.168.0.5",
+			expectedMasters:   []string{"192.168.0.10", "192.168.0.11", "192.168.0.12"},
+		},
+		{
+			name: "bootstrap destroyed",
+			ips: map[string]string{
+				"infra-id-master-0": "192.168.0.10",
+			},
+			expectedMasters: []string{"192.168.0.10"},
+		},
+		{
+			name: "compute and other clusters",
+			ips: map[string]string{
+				"infra-id-worker-0":  "192.168.0.20",
+				"infra-id-master-0":  "192.168.0.10",
+				"other-id-bootstrap": "192.168.0.6",
+				"other-id-master-0":  "192.168.0.30",
+			},
+			expectedMasters: []string{"192.168.0.10"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bootstrap, masters := leaseHostAddresses("infra-id", tc.ips)
+			assert.Equal(t, tc.expectedBootstrap, bootstrap)
+			assert.Equal(t, tc.expectedMasters, masters)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/pkg/errors"
 )
@@ -30,3 +31,79 @@ func Outputs(dir string, terraformDir string) ([]byte, error) {
 	data, err := json.Marshal(outputs)
 	return data, errors.Wrap(err, "could not marshal outputs")
 }
+
+// Resource is a managed resource in the terraform state.
+type Resource struct {
+	// Type is the type of the resource, e.g. aws_instance.
+	Type string
+	// Name is the name of the resource in the terraform configuration.
+	Name string
+	// ID is the ID of the resource for the infrastructure provider.
+	ID string
+}
+
+// Resources returns the managed resources in the terraform state file
+// contents.
+func Resources(state []byte) ([]Resource, error) {
+	var tfstate struct {
+		Resources []struct {
+			Mode      string `json:"mode"`
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				Attributes struct {
+					ID string `json:"id"`
+				} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(state, &tfstate); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal terraform state")
+	}
+
+	resources := []Resource{}
+	for _, resource := range tfstate.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			if instance.Attributes.ID == "" {
+				continue
+			}
+			resources = append(resources, Resource{Type: resource.Type, Name: resource.Name, ID: instance.Attributes.ID})
+		}
+	}
+	return resources, nil
+}
+
+// ResourceIDs returns the IDs of the managed resources in the terraform state
+// file contents, in the form <type>.<name>:<id>.
+func ResourceIDs(state []byte) ([]string, error) {
+	resources, err := Resources(state)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, fmt.Sprintf("%s.%s:%s", resource.Type, resource.Name, resource.ID))
+	}
+	return ids, nil
+}
+
+// StateRm removes the resources with the addresses from the terraform state
+// file of the stage, without destroying them. The stage must have been
+// initialized, e.g. by a previous Apply or Destroy.
+func StateRm(dir string, terraformDir string, addresses ...string) error {
+	tf, err := newTFExec(dir, terraformDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create a new tfexec")
+	}
+
+	for _, address := range addresses {
+		if err := tf.StateRm(context.Background(), address); err != nil {
+			return errors.Wrapf(err, "failed to remove %s from the terraform state", address)
+		}
+	}
+	return nil
+}
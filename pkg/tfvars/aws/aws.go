@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
@@ -23,6 +24,7 @@ type config struct {
 	MasterInstanceType           string            `json:"aws_master_instance_type,omitempty"`
 	MasterAvailabilityZones      []string          `json:"aws_master_availability_zones"`
 	WorkerAvailabilityZones      []string          `json:"aws_worker_availability_zones"`
+	EdgeLocalZones               []string          `json:"aws_edge_local_zones,omitempty"`
 	IOPS                         int64             `json:"aws_master_root_volume_iops"`
 	Size                         int64             `json:"aws_master_root_volume_size,omitempty"`
 	Type                         string            `json:"aws_master_root_volume_type,omitempty"`
@@ -38,7 +40,10 @@ type config struct {
 	BootstrapIgnitionStub        string            `json:"aws_bootstrap_stub_ignition"`
 	MasterIAMRoleName            string            `json:"aws_master_iam_role_name,omitempty"`
 	WorkerIAMRoleName            string            `json:"aws_worker_iam_role_name,omitempty"`
+	MasterIAMInstanceProfileName string            `json:"aws_master_iam_instance_profile_name,omitempty"`
+	WorkerIAMInstanceProfileName string            `json:"aws_worker_iam_instance_profile_name,omitempty"`
 	MasterMetadataAuthentication string            `json:"aws_master_instance_metadata_authentication,omitempty"`
+	UserManagedLoadBalancer      bool              `json:"aws_user_managed_load_balancer"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -48,6 +53,10 @@ type TFVarsSources struct {
 	InternalZone                  string
 	Services                      []typesaws.ServiceEndpoint
 
+	// EdgeLocalZones are the Local Zones of the edge machine pool, in which
+	// public subnets are created when installing in a new VPC.
+	EdgeLocalZones []string
+
 	Publish types.PublishingStrategy
 
 	AMIID, AMIRegion string
@@ -60,11 +69,22 @@ type TFVarsSources struct {
 
 	MasterIAMRoleName, WorkerIAMRoleName string
 
+	MasterIAMInstanceProfileName, WorkerIAMInstanceProfileName string
+
 	MasterMetadataAuthentication string
 
 	Architecture types.Architecture
 
 	Proxy *types.Proxy
+
+	// UserTags, when set, replace the user tags of the control plane
+	// machines in the tags of the resources, e.g. with the resource tags of
+	// an edited Infrastructure config.
+	UserTags map[string]string
+
+	// UserManagedLoadBalancer skips the load balancers and the DNS records
+	// of the API, which are provisioned by the user.
+	UserManagedLoadBalancer bool
 }
 
 // TFVars generates AWS-specific Terraform variables launching the cluster.
@@ -79,8 +99,14 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 
 	tags := make(map[string]string, len(masterConfig.Tags))
 	for _, tag := range masterConfig.Tags {
+		if sources.UserTags != nil && !strings.HasPrefix(tag.Name, "kubernetes.io/cluster/") {
+			continue
+		}
 		tags[tag.Name] = tag.Value
 	}
+	for key, value := range sources.UserTags {
+		tags[key] = value
+	}
 
 	masterAvailabilityZones := make([]string, len(sources.MasterConfigs))
 	for i, c := range sources.MasterConfigs {
@@ -89,7 +115,11 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 
 	exists := struct{}{}
 	availabilityZoneMap := map[string]struct{}{}
+	edgeLocalZones := sets.NewString(sources.EdgeLocalZones...)
 	for _, c := range sources.WorkerConfigs {
+		if edgeLocalZones.Has(c.Placement.AvailabilityZone) {
+			continue
+		}
 		availabilityZoneMap[c.Placement.AvailabilityZone] = exists
 	}
 	workerAvailabilityZones := make([]string, 0, len(availabilityZoneMap))
@@ -124,6 +154,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		ExtraTags:               tags,
 		MasterAvailabilityZones: masterAvailabilityZones,
 		WorkerAvailabilityZones: workerAvailabilityZones,
+		EdgeLocalZones:          sources.EdgeLocalZones,
 		BootstrapInstanceType:   masterConfig.InstanceType,
 		MasterInstanceType:      masterConfig.InstanceType,
 		Size:                    *rootVolume.EBS.VolumeSize,
@@ -135,6 +166,10 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		IgnitionBucket:          sources.IgnitionBucket,
 		MasterIAMRoleName:       sources.MasterIAMRoleName,
 		WorkerIAMRoleName:       sources.WorkerIAMRoleName,
+
+		MasterIAMInstanceProfileName: sources.MasterIAMInstanceProfileName,
+		WorkerIAMInstanceProfileName: sources.WorkerIAMInstanceProfileName,
+		UserManagedLoadBalancer:      sources.UserManagedLoadBalancer,
 	}
 
 	stubIgn, err := bootstrap.GenerateIgnitionShimWithCertBundleAndProxy(sources.IgnitionPresignedURL, sources.AdditionalTrustBundle, sources.Proxy)
@@ -54,6 +54,7 @@ type config struct {
 	VMNetworkingType                bool              `json:"azure_control_plane_vm_networking_type"`
 	RandomStringPrefix              string            `json:"random_storage_account_suffix"`
 	VMArchitecture                  string            `json:"azure_vm_architecture"`
+	UserManagedLoadBalancer         bool              `json:"azure_user_managed_load_balancer"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -75,6 +76,7 @@ type TFVarsSources struct {
 	HyperVGeneration                string
 	VMArchitecture                  types.Architecture
 	InfrastructureName              string
+	UserManagedLoadBalancer         bool
 }
 
 // TFVars generates Azure-specific Terraform variables launching the cluster.
@@ -115,6 +117,10 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		tags[k] = v
 	}
 
+	// The egress through NAT gateways does not need the outbound rules of the
+	// public load balancer either.
+	outboundUDR := sources.OutboundType == azure.UserDefinedRoutingOutboundType || sources.OutboundType == azure.UserDefinedNATGatewayOutboundType
+
 	cfg := &config{
 		Auth:                            sources.Auth,
 		Environment:                     environment,
@@ -130,7 +136,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		ImageURL:                        sources.ImageURL,
 		ImageRelease:                    sources.ImageRelease,
 		Private:                         sources.Publish == types.InternalPublishingStrategy,
-		OutboundUDR:                     sources.OutboundType == azure.UserDefinedRoutingOutboundType,
+		OutboundUDR:                     outboundUDR,
 		ResourceGroupName:               sources.ResourceGroupName,
 		BaseDomainResourceGroupName:     sources.BaseDomainResourceGroupName,
 		NetworkResourceGroupName:        masterConfig.NetworkResourceGroup,
@@ -145,6 +151,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		RandomStringPrefix:              randomStringPrefixFunction(),
 		VMArchitecture:                  vmarch,
 		ExtraTags:                       tags,
+		UserManagedLoadBalancer:         sources.UserManagedLoadBalancer,
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
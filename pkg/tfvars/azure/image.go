@@ -0,0 +1,13 @@
+package azure
+
+import (
+	"github.com/openshift/installer/pkg/tfvars/internal/cache"
+)
+
+// CachedImage returns the path of the RHCOS VHD of the URL in the local
+// cache, downloading it when it is not cached yet. The download resumes where
+// a previous attempt stopped, and the checksum of the sha256 query parameter
+// of the URL is verified before the VHD is cached.
+func CachedImage(imageURL string) (string, error) {
+	return cache.DownloadImageFile(imageURL)
+}
@@ -47,19 +47,21 @@ type config struct {
 	SecureBoot                string   `json:"gcp_master_secure_boot,omitempty"`
 	OnHostMaintenance         string   `json:"gcp_master_on_host_maintenance,omitempty"`
 	EnableConfidentialCompute string   `json:"gcp_master_confidential_compute,omitempty"`
+	UserManagedLoadBalancer   bool     `json:"gcp_user_managed_load_balancer"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
 type TFVarsSources struct {
-	Auth                Auth
-	CreateFirewallRules bool
-	ImageURI            string
-	ImageLicenses       []string
-	MasterConfigs       []*machineapi.GCPMachineProviderSpec
-	WorkerConfigs       []*machineapi.GCPMachineProviderSpec
-	PublicZoneName      string
-	PublishStrategy     types.PublishingStrategy
-	PreexistingNetwork  bool
+	Auth                    Auth
+	CreateFirewallRules     bool
+	ImageURI                string
+	ImageLicenses           []string
+	MasterConfigs           []*machineapi.GCPMachineProviderSpec
+	WorkerConfigs           []*machineapi.GCPMachineProviderSpec
+	PublicZoneName          string
+	PublishStrategy         types.PublishingStrategy
+	PreexistingNetwork      bool
+	UserManagedLoadBalancer bool
 }
 
 // TFVars generates gcp-specific Terraform variables launching the cluster.
@@ -93,6 +95,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		SecureBoot:                string(masterConfig.ShieldedInstanceConfig.SecureBoot),
 		EnableConfidentialCompute: string(masterConfig.ConfidentialCompute),
 		OnHostMaintenance:         string(masterConfig.OnHostMaintenance),
+		UserManagedLoadBalancer:   sources.UserManagedLoadBalancer,
 	}
 
 	cfg.PreexistingImage = true
@@ -21,10 +21,18 @@ type DedicatedHost struct {
 	Profile string `json:"profile,omitempty"`
 }
 
+// PlacementGroup is the format used by terraform. Either the ID of a
+// pre-existing placement group or the strategy of a new one is set.
+type PlacementGroup struct {
+	ID       string `json:"id,omitempty"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
 type config struct {
 	Auth                     `json:",inline"`
 	Region                   string          `json:"ibmcloud_region,omitempty"`
 	BootstrapInstanceType    string          `json:"ibmcloud_bootstrap_instance_type,omitempty"`
+	BootstrapInPlace         bool            `json:"ibmcloud_bootstrap_in_place,omitempty"`
 	CISInstanceCRN           string          `json:"ibmcloud_cis_crn,omitempty"`
 	DNSInstanceID            string          `json:"ibmcloud_dns_id,omitempty"`
 	ExtraTags                []string        `json:"ibmcloud_extra_tags,omitempty"`
@@ -33,6 +41,7 @@ type config struct {
 	MasterInstanceType       string          `json:"ibmcloud_master_instance_type,omitempty"`
 	MasterDedicatedHosts     []DedicatedHost `json:"ibmcloud_master_dedicated_hosts,omitempty"`
 	WorkerDedicatedHosts     []DedicatedHost `json:"ibmcloud_worker_dedicated_hosts,omitempty"`
+	MasterPlacementGroup     *PlacementGroup `json:"ibmcloud_master_placement_group,omitempty"`
 	PublishStrategy          string          `json:"ibmcloud_publish_strategy,omitempty"`
 	NetworkResourceGroupName string          `json:"ibmcloud_network_resource_group_name,omitempty"`
 	ResourceGroupName        string          `json:"ibmcloud_resource_group_name,omitempty"`
@@ -46,12 +55,16 @@ type config struct {
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
 type TFVarsSources struct {
-	Auth                     Auth
+	Auth Auth
+	// BootstrapInPlace skips the bootstrap machine, the single control
+	// plane machine booting with the bootstrap ignition instead.
+	BootstrapInPlace         bool
 	CISInstanceCRN           string
 	DNSInstanceID            string
 	ImageURL                 string
 	MasterConfigs            []*ibmcloudprovider.IBMCloudMachineProviderSpec
 	MasterDedicatedHosts     []DedicatedHost
+	MasterPlacementGroup     *PlacementGroup
 	NetworkResourceGroupName string
 	PreexistingVPC           bool
 	PublishStrategy          types.PublishingStrategy
@@ -95,12 +108,14 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 	cfg := &config{
 		Auth:                     sources.Auth,
 		BootstrapInstanceType:    masterConfig.Profile,
+		BootstrapInPlace:         sources.BootstrapInPlace,
 		CISInstanceCRN:           sources.CISInstanceCRN,
 		DNSInstanceID:            sources.DNSInstanceID,
 		ImageFilePath:            cachedImage,
 		MasterAvailabilityZones:  masterAvailabilityZones,
 		MasterDedicatedHosts:     sources.MasterDedicatedHosts,
 		MasterInstanceType:       masterConfig.Profile,
+		MasterPlacementGroup:     sources.MasterPlacementGroup,
 		NetworkResourceGroupName: sources.NetworkResourceGroupName,
 		PublishStrategy:          string(sources.PublishStrategy),
 		Region:                   masterConfig.Region,
@@ -204,23 +204,34 @@ func (u *urlWithIntegrity) download(dataType string) (string, error) {
 		return "", err
 	}
 
-	// Send a request
-	resp, err := http.Get(u.location.String())
+	// Download the file as is, it is unpacked into the cache from the
+	// partially downloaded file once complete
+	partPath := filepath.Join(cacheDir, fmt.Sprintf("%s.part", filepath.Base(u.location.Path)))
+	err = fetchFile(http.DefaultClient, u.location.String(), partPath)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
 
-	// Check server response
-	if resp.StatusCode != http.StatusOK {
-		return "", errors.Errorf("bad status: %s", resp.Status)
+	part, err := os.Open(partPath)
+	if err != nil {
+		return "", err
 	}
+	defer part.Close()
 
-	err = cacheFile(resp.Body, filePath, u.uncompressedSHA256)
+	err = cacheFile(part, filePath, u.uncompressedSHA256)
 	if err != nil {
+		// The download was complete, so it is corrupted and must not be
+		// resumed
+		part.Close()
+		os.Remove(partPath)
 		return "", err
 	}
 
+	part.Close()
+	if err := os.Remove(partPath); err != nil {
+		logrus.Debugf("Failed to remove the downloaded file %s: %v", partPath, err)
+	}
+
 	return filePath, nil
 }
 
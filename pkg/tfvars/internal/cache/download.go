@@ -0,0 +1,298 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// downloadChunkSize is the size of the byte ranges downloaded in parallel.
+var downloadChunkSize int64 = 64 << 20
+
+const (
+	// downloadWorkers is the number of byte ranges downloaded at the same
+	// time.
+	downloadWorkers = 4
+
+	// downloadChunkAttempts is the number of times the download of a byte
+	// range is attempted before giving up.
+	downloadChunkAttempts = 5
+
+	// downloadProgressStep is the percentage of the file downloaded between
+	// two progress messages.
+	downloadProgressStep = 10
+)
+
+// chunk is a byte range of a file.
+type chunk struct {
+	index int
+	start int64
+	end   int64 // inclusive
+}
+
+// progress logs the progress of a download.
+type progress struct {
+	name     string
+	total    int64
+	done     int64
+	reported int64
+}
+
+func (p *progress) add(n int64) {
+	done := atomic.AddInt64(&p.done, n)
+	if p.total <= 0 {
+		return
+	}
+	percent := done * 100 / p.total
+	step := percent / downloadProgressStep * downloadProgressStep
+	for {
+		reported := atomic.LoadInt64(&p.reported)
+		if step <= reported {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&p.reported, reported, step) {
+			logrus.Infof("Downloaded %d%% of %s (%d of %d MiB)", step, p.name, done>>20, p.total>>20)
+			return
+		}
+	}
+}
+
+// fetchFile downloads the file at the URL to the path. When the server
+// supports byte ranges, the file is downloaded in chunks by parallel workers,
+// each chunk is retried on failure, and the chunks completed by a previous,
+// interrupted, download to the same path are not downloaded again. When the
+// size and the byte range support cannot be found out, e.g. because a mirror
+// or a proxy rejects HEAD requests, the file is downloaded in a single
+// stream.
+func fetchFile(client *http.Client, location string, path string) error {
+	resp, err := client.Head(location)
+	if err != nil {
+		logrus.Debugf("Failed to get the size of %s, downloading it in a single stream: %v", location, err)
+		return fetchStream(client, location, path, -1)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logrus.Debugf("Failed to get the size of %s (%s), downloading it in a single stream", location, resp.Status)
+		return fetchStream(client, location, path, -1)
+	}
+
+	if resp.ContentLength <= 0 || resp.Header.Get("Accept-Ranges") != "bytes" {
+		logrus.Debugf("%s does not support byte ranges, downloading it in a single stream", location)
+		return fetchStream(client, location, path, resp.ContentLength)
+	}
+	return fetchChunks(client, location, path, resp.ContentLength)
+}
+
+// fetchStream downloads the file at the URL to the path in a single request.
+func fetchStream(client *http.Client, location string, path string, size int64) error {
+	resp, err := client.Get(location)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status: %s", resp.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	p := &progress{name: location, total: size}
+	if _, err := io.Copy(file, io.TeeReader(resp.Body, writerFunc(p.add))); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// fetchChunks downloads the file of the size at the URL to the path in
+// parallel byte ranges. The indices of the completed chunks are recorded in
+// the state file next to the path, so that an interrupted download resumes
+// where it stopped.
+func fetchChunks(client *http.Client, location string, path string, size int64) error {
+	statePath := fmt.Sprintf("%s.chunks", path)
+	completed, err := readCompletedChunks(statePath, path, size)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := file.Truncate(size); err != nil {
+		return err
+	}
+
+	state, err := os.OpenFile(statePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer state.Close()
+
+	p := &progress{name: location, total: size}
+	var pending []chunk
+	for i, start := 0, int64(0); start < size; i, start = i+1, start+downloadChunkSize {
+		c := chunk{index: i, start: start, end: start + downloadChunkSize - 1}
+		if c.end >= size {
+			c.end = size - 1
+		}
+		if completed[i] {
+			p.add(c.end - c.start + 1)
+			continue
+		}
+		pending = append(pending, c)
+	}
+	if len(pending) > 0 && len(completed) > 0 {
+		logrus.Infof("Resuming the download of %s, %d of %d MiB already downloaded", location, p.done>>20, size>>20)
+	}
+
+	chunks := make(chan chunk)
+	errs := make(chan error, len(pending))
+	var stateLock sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < downloadWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				if err := fetchChunk(client, location, file, c, p); err != nil {
+					errs <- err
+					continue
+				}
+				stateLock.Lock()
+				_, err := fmt.Fprintln(state, c.index)
+				stateLock.Unlock()
+				if err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for _, c := range pending {
+		chunks <- c
+	}
+	close(chunks)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return errors.Wrapf(err, "failed to download %s, the download will resume from the completed chunks on the next attempt", location)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	state.Close()
+	return os.Remove(statePath)
+}
+
+// fetchChunk downloads the byte range of the file at the URL into the file,
+// resuming from the last byte received when the transfer is interrupted.
+func fetchChunk(client *http.Client, location string, file *os.File, c chunk, p *progress) error {
+	start := c.start
+	var err error
+	for attempt := 1; attempt <= downloadChunkAttempts; attempt++ {
+		var n int64
+		n, err = fetchRange(client, location, file, start, c.end, p)
+		start += n
+		if err == nil {
+			return nil
+		}
+		logrus.Debugf("Attempt %d to download bytes %d-%d of %s failed: %v", attempt, c.start, c.end, location, err)
+	}
+	return errors.Wrapf(err, "failed to download bytes %d-%d", c.start, c.end)
+}
+
+// fetchRange writes the bytes from start to end of the file at the URL at the
+// same offsets of the file, and returns the number of bytes written.
+func fetchRange(client *http.Client, location string, file *os.File, start int64, end int64, p *progress) (int64, error) {
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, errors.Errorf("bad status: %s", resp.Status)
+	}
+
+	w := &offsetWriter{file: file, offset: start}
+	n, err := io.Copy(w, io.TeeReader(io.LimitReader(resp.Body, end-start+1), writerFunc(p.add)))
+	if err == nil && n != end-start+1 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// readCompletedChunks returns the indices of the chunks recorded in the state
+// file of a previous download of the file of the size. The state is discarded
+// when the partially downloaded file does not have the expected size, e.g.
+// because the file changed on the server.
+func readCompletedChunks(statePath string, path string, size int64) (map[int]bool, error) {
+	completed := map[int]bool{}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != size {
+		if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return completed, nil
+	}
+
+	state, err := os.Open(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return completed, nil
+		}
+		return nil, err
+	}
+	defer state.Close()
+
+	scanner := bufio.NewScanner(state)
+	for scanner.Scan() {
+		index, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			// a line may be truncated if the installer was interrupted
+			continue
+		}
+		completed[index] = true
+	}
+	return completed, scanner.Err()
+}
+
+// offsetWriter writes sequentially to a file from an offset.
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+}
+
+func (w *offsetWriter) Write(b []byte) (int, error) {
+	n, err := w.file.WriteAt(b, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+// writerFunc counts the bytes written to it.
+type writerFunc func(int64)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	f(int64(len(b)))
+	return len(b), nil
+}
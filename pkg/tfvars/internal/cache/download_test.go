@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testContent(size int) []byte {
+	content := make([]byte, size)
+	rand.New(rand.NewSource(1)).Read(content)
+	return content
+}
+
+func TestFetchFile(t *testing.T) {
+	defer func(size int64) { downloadChunkSize = size }(downloadChunkSize)
+	downloadChunkSize = 1000
+	content := testContent(10500)
+
+	cases := []struct {
+		name     string
+		handler  func(requests map[string]int) http.HandlerFunc
+		chunks   string
+		prefix   int
+		requests map[string]int
+	}{
+		{
+			name: "byte ranges",
+			handler: func(map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					http.ServeContent(w, r, "rhcos.ova", time.Time{}, bytes.NewReader(content))
+				}
+			},
+			requests: map[string]int{"bytes=0-999": 1, "bytes=10000-10499": 1},
+		},
+		{
+			name: "no byte ranges",
+			handler: func(map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Write(content)
+				}
+			},
+			requests: map[string]int{"": 2},
+		},
+		{
+			name: "HEAD rejected",
+			handler: func(map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodHead {
+						http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+						return
+					}
+					http.ServeContent(w, r, "rhcos.ova", time.Time{}, bytes.NewReader(content))
+				}
+			},
+			requests: map[string]int{"": 2, "bytes=0-999": 0},
+		},
+		{
+			name: "HEAD failed",
+			handler: func(map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodHead {
+						// close the connection without a response
+						conn, _, err := w.(http.Hijacker).Hijack()
+						if err == nil {
+							conn.Close()
+						}
+						return
+					}
+					http.ServeContent(w, r, "rhcos.ova", time.Time{}, bytes.NewReader(content))
+				}
+			},
+			requests: map[string]int{"bytes=0-999": 0},
+		},
+		{
+			name: "resume",
+			handler: func(map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					http.ServeContent(w, r, "rhcos.ova", time.Time{}, bytes.NewReader(content))
+				}
+			},
+			chunks:   "0\n1\n2\n",
+			prefix:   3000,
+			requests: map[string]int{"bytes=0-999": 0, "bytes=3000-3999": 1},
+		},
+		{
+			name: "retry failed chunks",
+			handler: func(requests map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodGet && requests[r.Header.Get("Range")] == 1 {
+						http.Error(w, "unavailable", http.StatusServiceUnavailable)
+						return
+					}
+					http.ServeContent(w, r, "rhcos.ova", time.Time{}, bytes.NewReader(content))
+				}
+			},
+			requests: map[string]int{"bytes=0-999": 2, "bytes=5000-5999": 2},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			requests := map[string]int{}
+			handler := tc.handler(requests)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				requests[r.Header.Get("Range")]++
+				lock.Unlock()
+				handler(w, r)
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "fetch")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "rhcos.ova.part")
+			if tc.chunks != "" {
+				partial := make([]byte, len(content))
+				copy(partial, content[:tc.prefix])
+				assert.NoError(t, ioutil.WriteFile(path, partial, 0644))
+				assert.NoError(t, ioutil.WriteFile(path+".chunks", []byte(tc.chunks), 0644))
+			}
+
+			err = fetchFile(server.Client(), server.URL+"/rhcos.ova", path)
+			if !assert.NoError(t, err) {
+				return
+			}
+			downloaded, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+			assert.True(t, bytes.Equal(content, downloaded), "downloaded content differs")
+			_, err = os.Stat(path + ".chunks")
+			assert.True(t, os.IsNotExist(err), "chunks state file not removed")
+			for r, count := range tc.requests {
+				assert.Equal(t, count, requests[r], fmt.Sprintf("requests with range %q", r))
+			}
+		})
+	}
+}
+
+func TestReadCompletedChunks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chunks")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rhcos.ova.part")
+	statePath := path + ".chunks"
+	assert.NoError(t, ioutil.WriteFile(path, make([]byte, 100), 0644))
+	assert.NoError(t, ioutil.WriteFile(statePath, []byte(strings.Join([]string{"0", "3", "1"}, "\n")+"\n2"), 0644))
+
+	completed, err := readCompletedChunks(statePath, path, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{0: true, 1: true, 2: true, 3: true}, completed)
+
+	completed, err = readCompletedChunks(statePath, path, 200)
+	assert.NoError(t, err)
+	assert.Empty(t, completed)
+	_, err = os.Stat(statePath)
+	assert.True(t, os.IsNotExist(err), "state of a file of another size not removed")
+}
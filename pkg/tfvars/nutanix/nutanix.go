@@ -22,6 +22,8 @@ type config struct {
 	Categories                     map[string]string `json:"nutanix_control_plane_categories"`
 	PrismElementUUID               string            `json:"nutanix_prism_element_uuid"`
 	SubnetUUID                     string            `json:"nutanix_subnet_uuid"`
+	ControlPlanePrismElementUUIDs  []string          `json:"nutanix_control_plane_prism_element_uuids"`
+	ControlPlaneSubnetUUIDs        []string          `json:"nutanix_control_plane_subnet_uuids"`
 	Image                          string            `json:"nutanix_image"`
 	ImageURI                       string            `json:"nutanix_image_uri"`
 	BootstrapIgnitionImage         string            `json:"nutanix_bootstrap_ignition_image"`
@@ -66,6 +68,12 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		BootstrapIgnitionImageFilePath: bootstrapIgnitionImagePath,
 	}
 
+	// The control plane machines may be spread across failure domains.
+	for _, c := range sources.ControlPlaneConfigs {
+		cfg.ControlPlanePrismElementUUIDs = append(cfg.ControlPlanePrismElementUUIDs, *c.Cluster.UUID)
+		cfg.ControlPlaneSubnetUUIDs = append(cfg.ControlPlaneSubnetUUIDs, *c.Subnets[0].UUID)
+	}
+
 	if controlPlaneConfig.Project.Type == machinev1.NutanixIdentifierUUID {
 		cfg.ProjectUUID = *controlPlaneConfig.Project.UUID
 	}
@@ -8,65 +8,96 @@ import (
 
 	"github.com/IBM-Cloud/bluemix-go/crn"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machinev1 "github.com/openshift/api/machine/v1"
 	"github.com/openshift/installer/pkg/types"
 	powervstypes "github.com/openshift/installer/pkg/types/powervs"
 )
 
 type config struct {
-	ServiceInstanceID    string `json:"powervs_cloud_instance_id"`
-	APIKey               string `json:"powervs_api_key"`
-	SSHKey               string `json:"powervs_ssh_key"`
-	PowerVSRegion        string `json:"powervs_region"`
-	PowerVSZone          string `json:"powervs_zone"`
-	VPCRegion            string `json:"powervs_vpc_region"`
-	VPCZone              string `json:"powervs_vpc_zone"`
-	COSRegion            string `json:"powervs_cos_region"`
-	PowerVSResourceGroup string `json:"powervs_resource_group"`
-	CISInstanceCRN       string `json:"powervs_cis_crn"`
-	DNSInstanceGUID      string `json:"powervs_dns_guid"`
-	ImageBucketName      string `json:"powervs_image_bucket_name"`
-	ImageBucketFileName  string `json:"powervs_image_bucket_file_name"`
-	NetworkName          string `json:"powervs_network_name"`
-	VPCName              string `json:"powervs_vpc_name"`
-	VPCSubnetName        string `json:"powervs_vpc_subnet_name"`
-	VPCPermitted         bool   `json:"powervs_vpc_permitted"`
-	VPCGatewayName       string `json:"powervs_vpc_gateway_name"`
-	VPCGatewayAttached   bool   `json:"powervs_vpc_gateway_attached"`
-	CloudConnectionName  string `json:"powervs_ccon_name"`
-	BootstrapMemory      int32  `json:"powervs_bootstrap_memory"`
-	BootstrapProcessors  string `json:"powervs_bootstrap_processors"`
-	MasterMemory         int32  `json:"powervs_master_memory"`
-	MasterProcessors     string `json:"powervs_master_processors"`
-	ProcType             string `json:"powervs_proc_type"`
-	SysType              string `json:"powervs_sys_type"`
-	PublishStrategy      string `json:"powervs_publish_strategy"`
-	EnableSNAT           bool   `json:"powervs_enable_snat"`
+	ServiceInstanceID       string   `json:"powervs_cloud_instance_id"`
+	APIKey                  string   `json:"powervs_api_key"`
+	SSHKey                  string   `json:"powervs_ssh_key"`
+	PowerVSRegion           string   `json:"powervs_region"`
+	PowerVSZone             string   `json:"powervs_zone"`
+	VPCRegion               string   `json:"powervs_vpc_region"`
+	VPCZone                 string   `json:"powervs_vpc_zone"`
+	COSRegion               string   `json:"powervs_cos_region"`
+	PowerVSResourceGroup    string   `json:"powervs_resource_group"`
+	CISInstanceCRN          string   `json:"powervs_cis_crn"`
+	DNSInstanceGUID         string   `json:"powervs_dns_guid"`
+	ImageBucketName         string   `json:"powervs_image_bucket_name"`
+	ImageBucketFileName     string   `json:"powervs_image_bucket_file_name"`
+	NetworkName             string   `json:"powervs_network_name"`
+	VPCName                 string   `json:"powervs_vpc_name"`
+	VPCSubnetName           string   `json:"powervs_vpc_subnet_name"`
+	VPCSecurityGroups       []string `json:"powervs_vpc_security_groups,omitempty"`
+	VPCPermitted            bool     `json:"powervs_vpc_permitted"`
+	VPCGatewayName          string   `json:"powervs_vpc_gateway_name"`
+	VPCGatewayAttached      bool     `json:"powervs_vpc_gateway_attached"`
+	CloudConnectionName     string   `json:"powervs_ccon_name"`
+	TransitGatewayID        string   `json:"powervs_transit_gateway_id"`
+	BootstrapMemory         int32    `json:"powervs_bootstrap_memory"`
+	BootstrapProcessors     string   `json:"powervs_bootstrap_processors"`
+	MasterMemory            int32    `json:"powervs_master_memory"`
+	MasterProcessors        string   `json:"powervs_master_processors"`
+	ProcType                string   `json:"powervs_proc_type"`
+	SysType                 string   `json:"powervs_sys_type"`
+	PublishStrategy         string   `json:"powervs_publish_strategy"`
+	EnableSNAT              bool     `json:"powervs_enable_snat"`
+	UserManagedLoadBalancer bool     `json:"powervs_user_managed_load_balancer"`
+	BootstrapInPlace        bool     `json:"powervs_bootstrap_in_place"`
+	Tags                    []string `json:"powervs_tags"`
+	SharedProcessorPool     string   `json:"powervs_shared_processor_pool,omitempty"`
+	PlacementGroup          string   `json:"powervs_placement_group,omitempty"`
+	// ServiceEndpoints are the URLs overriding the endpoints of the IBM
+	// Cloud services, by the name of the service.
+	ServiceEndpoints map[string]string `json:"powervs_service_endpoints,omitempty"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
 type TFVarsSources struct {
-	MasterConfigs        []*machinev1.PowerVSMachineProviderConfig
-	APIKey               string
-	SSHKey               string
-	Region               string
-	Zone                 string
-	ImageBucketName      string
-	ImageBucketFileName  string
-	NetworkName          string
-	PowerVSResourceGroup string
-	CloudConnectionName  string
-	CISInstanceCRN       string
-	DNSInstanceCRN       string
-	VPCRegion            string
-	VPCZone              string
-	VPCName              string
-	VPCSubnetName        string
-	VPCPermitted         bool
-	VPCGatewayName       string
-	VPCGatewayAttached   bool
-	PublishStrategy      types.PublishingStrategy
-	EnableSNAT           bool
+	MasterConfigs           []*machinev1.PowerVSMachineProviderConfig
+	APIKey                  string
+	SSHKey                  string
+	Region                  string
+	Zone                    string
+	ImageBucketName         string
+	ImageBucketFileName     string
+	NetworkName             string
+	PowerVSResourceGroup    string
+	CloudConnectionName     string
+	TransitGatewayID        string
+	CISInstanceCRN          string
+	DNSInstanceCRN          string
+	VPCRegion               string
+	VPCZone                 string
+	VPCName                 string
+	VPCSubnetName           string
+	VPCSecurityGroups       []string
+	VPCPermitted            bool
+	VPCGatewayName          string
+	VPCGatewayAttached      bool
+	PublishStrategy         types.PublishingStrategy
+	EnableSNAT              bool
+	UserManagedLoadBalancer bool
+	// BootstrapInPlace creates no bootstrap instance, and boots the single
+	// control plane instance with the bootstrap ignition.
+	BootstrapInPlace bool
+	// Tags are attached to the workspace, VPC, load balancer and Cloud
+	// Object Storage resources.
+	Tags []string
+	// SharedProcessorPool is the name or ID of the shared processor pool of
+	// the control plane instances. The Machine API provider
+	// config has no field for it.
+	SharedProcessorPool string
+	// PlacementGroup is the name or ID of the server placement group of the
+	// control plane instances. The Machine API provider config has no field
+	// for it.
+	PlacementGroup string
+	// ServiceEndpoints are the custom endpoints of the IBM Cloud services,
+	// which the Terraform providers use instead of the default endpoints.
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint
 }
 
 // TFVars generates Power VS-specific Terraform variables launching the cluster.
@@ -79,9 +110,11 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 	}
 
 	var serviceInstanceID, processor, dnsGUID string
+	// The workspace created by the installer is referenced by name, and its
+	// ID is only set when the cluster is provisioned.
 	if masterConfig.ServiceInstance.ID != nil {
 		serviceInstanceID = *masterConfig.ServiceInstance.ID
-	} else {
+	} else if masterConfig.ServiceInstance.Name == nil {
 		return nil, fmt.Errorf("serviceInstanceID is nil")
 	}
 
@@ -101,37 +134,50 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 	}
 
 	cfg := &config{
-		ServiceInstanceID:    serviceInstanceID,
-		APIKey:               sources.APIKey,
-		SSHKey:               sources.SSHKey,
-		PowerVSRegion:        sources.Region,
-		PowerVSZone:          sources.Zone,
-		VPCRegion:            sources.VPCRegion,
-		VPCZone:              sources.VPCZone,
-		COSRegion:            cosRegion,
-		PowerVSResourceGroup: sources.PowerVSResourceGroup,
-		CISInstanceCRN:       sources.CISInstanceCRN,
-		DNSInstanceGUID:      dnsGUID,
-		ImageBucketName:      sources.ImageBucketName,
-		ImageBucketFileName:  sources.ImageBucketFileName,
-		VPCName:              sources.VPCName,
-		VPCSubnetName:        sources.VPCSubnetName,
-		VPCPermitted:         sources.VPCPermitted,
-		VPCGatewayName:       sources.VPCGatewayName,
-		VPCGatewayAttached:   sources.VPCGatewayAttached,
-		CloudConnectionName:  sources.CloudConnectionName,
-		BootstrapMemory:      masterConfig.MemoryGiB,
-		BootstrapProcessors:  processor,
-		MasterMemory:         masterConfig.MemoryGiB,
-		MasterProcessors:     processor,
-		ProcType:             strings.ToLower(string(masterConfig.ProcessorType)),
-		SysType:              masterConfig.SystemType,
-		PublishStrategy:      string(sources.PublishStrategy),
-		EnableSNAT:           sources.EnableSNAT,
+		ServiceInstanceID:       serviceInstanceID,
+		APIKey:                  sources.APIKey,
+		SSHKey:                  sources.SSHKey,
+		PowerVSRegion:           sources.Region,
+		PowerVSZone:             sources.Zone,
+		VPCRegion:               sources.VPCRegion,
+		VPCZone:                 sources.VPCZone,
+		COSRegion:               cosRegion,
+		PowerVSResourceGroup:    sources.PowerVSResourceGroup,
+		CISInstanceCRN:          sources.CISInstanceCRN,
+		DNSInstanceGUID:         dnsGUID,
+		ImageBucketName:         sources.ImageBucketName,
+		ImageBucketFileName:     sources.ImageBucketFileName,
+		VPCName:                 sources.VPCName,
+		VPCSubnetName:           sources.VPCSubnetName,
+		VPCSecurityGroups:       sources.VPCSecurityGroups,
+		VPCPermitted:            sources.VPCPermitted,
+		VPCGatewayName:          sources.VPCGatewayName,
+		VPCGatewayAttached:      sources.VPCGatewayAttached,
+		CloudConnectionName:     sources.CloudConnectionName,
+		TransitGatewayID:        sources.TransitGatewayID,
+		BootstrapMemory:         masterConfig.MemoryGiB,
+		BootstrapProcessors:     processor,
+		MasterMemory:            masterConfig.MemoryGiB,
+		MasterProcessors:        processor,
+		ProcType:                strings.ToLower(string(masterConfig.ProcessorType)),
+		SysType:                 masterConfig.SystemType,
+		PublishStrategy:         string(sources.PublishStrategy),
+		EnableSNAT:              sources.EnableSNAT,
+		UserManagedLoadBalancer: sources.UserManagedLoadBalancer,
+		BootstrapInPlace:        sources.BootstrapInPlace,
+		Tags:                    sources.Tags,
+		SharedProcessorPool:     sources.SharedProcessorPool,
+		PlacementGroup:          sources.PlacementGroup,
 	}
 	if masterConfig.Network.Name != nil {
 		cfg.NetworkName = *masterConfig.Network.Name
 	}
+	if len(sources.ServiceEndpoints) > 0 {
+		cfg.ServiceEndpoints = make(map[string]string, len(sources.ServiceEndpoints))
+		for _, endpoint := range sources.ServiceEndpoints {
+			cfg.ServiceEndpoints[endpoint.Name] = endpoint.URL
+		}
+	}
 
 	return json.MarshalIndent(cfg, "", "  ")
 }
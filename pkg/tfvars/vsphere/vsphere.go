@@ -1,13 +1,16 @@
 package vsphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vapi/rest"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	vsphereconfig "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
 	"github.com/openshift/installer/pkg/tfvars/internal/cache"
 	vtypes "github.com/openshift/installer/pkg/types/vsphere"
 )
@@ -19,6 +22,10 @@ type folder struct {
 
 type config struct {
 	OvaFilePath              string                                   `json:"vsphere_ova_filepath"`
+	ContentLibrary           string                                   `json:"vsphere_content_library,omitempty"`
+	LibraryTemplate          string                                   `json:"vsphere_library_template,omitempty"`
+	LibraryTemplateExists    bool                                     `json:"vsphere_library_template_exists"`
+	LibraryTemplateDesc      string                                   `json:"vsphere_library_template_description,omitempty"`
 	DiskType                 vtypes.DiskType                          `json:"vsphere_disk_type"`
 	VCenters                 map[string]vtypes.VCenter                `json:"vsphere_vcenters"`
 	FailureDomains           []vtypes.FailureDomain                   `json:"vsphere_failure_domains"`
@@ -31,6 +38,12 @@ type config struct {
 type TFVarsSources struct {
 	ControlPlaneConfigs     []*machineapi.VSphereMachineProviderSpec
 	ImageURL                string
+	RestClient              *rest.Client
+	ContentLibrary          string
+	LibraryID               string
+	LibraryTemplate         string
+	LibraryTemplateExists   bool
+	LibraryTemplateDesc     string
 	DiskType                vtypes.DiskType
 	NetworksInFailureDomain map[string]string
 	InstallConfig           *installconfig.InstallConfig
@@ -40,9 +53,25 @@ type TFVarsSources struct {
 
 // TFVars generate vSphere-specific Terraform variables
 func TFVars(sources TFVarsSources) ([]byte, error) {
-	cachedImage, err := cache.DownloadImageFile(sources.ImageURL)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to use cached vsphere image")
+	// The OVA is not needed when its template is already in the content
+	// library
+	var cachedImage string
+	libraryTemplateExists := sources.LibraryTemplateExists
+	if !libraryTemplateExists {
+		var err error
+		cachedImage, err = cache.DownloadImageFile(sources.ImageURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to use cached vsphere image")
+		}
+	}
+
+	// The template is imported in the content library by the installer, which
+	// resumes an interrupted upload, rather than by the infrastructure stage
+	if sources.ContentLibrary != "" && !libraryTemplateExists {
+		if _, err := vsphereconfig.ImportLibraryTemplate(context.TODO(), sources.RestClient, sources.LibraryID, sources.LibraryTemplate, sources.LibraryTemplateDesc, cachedImage); err != nil {
+			return nil, errors.Wrapf(err, "failed to import the RHCOS template in content library %s", sources.ContentLibrary)
+		}
+		libraryTemplateExists = true
 	}
 
 	vcenterZones := convertVCentersToMap(sources.InstallConfig.Config.VSphere.VCenters)
@@ -53,6 +82,10 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 
 	cfg := &config{
 		OvaFilePath:              cachedImage,
+		ContentLibrary:           sources.ContentLibrary,
+		LibraryTemplate:          sources.LibraryTemplate,
+		LibraryTemplateExists:    libraryTemplateExists,
+		LibraryTemplateDesc:      sources.LibraryTemplateDesc,
 		DiskType:                 sources.DiskType,
 		VCenters:                 vcenterZones,
 		FailureDomains:           sources.InstallConfig.Config.VSphere.FailureDomains,
@@ -26,6 +26,11 @@ type Config struct {
 	// ip address of node0
 	RendezvousIP string `json:"rendezvousIP,omitempty"`
 	Hosts        []Host `json:"hosts,omitempty"`
+	// BootArtifactsBaseURL is the URL of the HTTP server from which the PXE
+	// files are served, used by the iPXE script. When empty, the script
+	// loads the files relative to its own URL.
+	// +optional
+	BootArtifactsBaseURL string `json:"bootArtifactsBaseURL,omitempty"`
 }
 
 // Host defines per host configurations
@@ -5,4 +5,8 @@ const (
 	AvailabilityZoneType = "availability-zone"
 	// LocalZoneType is the type of Local zone placed on the metropolitan areas.
 	LocalZoneType = "local-zone"
+	// OutpostZoneType is the type given by the installer to the zones of the
+	// subnets of an AWS Outpost, which AWS reports as the availability zone the
+	// Outpost is anchored to.
+	OutpostZoneType = "outpost"
 )
@@ -34,6 +34,19 @@ type MachinePool struct {
 	// Leave unset to have the installer create the IAM Role on your behalf.
 	// +optional
 	IAMRole string `json:"iamRole,omitempty"`
+
+	// IAMInstanceProfile is the name of a pre-existing IAM instance profile to use for the machine.
+	// Leave unset to have the installer create the instance profile on your behalf.
+	// This field is mutually exclusive with iamRole.
+	// +optional
+	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
+
+	// OutpostARN is the ARN of the AWS Outpost on which the machines are placed.
+	// It is valid only for the edge machine pool, whose subnets must include
+	// subnets of the Outpost. Leave unset to place the machines in the Local
+	// Zones of the edge subnets.
+	// +optional
+	OutpostARN string `json:"outpostARN,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -74,6 +87,14 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.IAMRole != "" {
 		a.IAMRole = required.IAMRole
 	}
+
+	if required.IAMInstanceProfile != "" {
+		a.IAMInstanceProfile = required.IAMInstanceProfile
+	}
+
+	if required.OutpostARN != "" {
+		a.OutpostARN = required.OutpostARN
+	}
 }
 
 // EC2RootVolume defines the storage for an ec2 instance.
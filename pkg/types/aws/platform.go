@@ -92,6 +92,28 @@ type Platform struct {
 	//
 	// +optional
 	LBType configv1.AWSLBType `json:"lbType,omitempty"`
+
+	// LoadBalancer defines how the load balancers of the API are provisioned.
+	// When its type is UserManaged, the installer creates neither the
+	// internal and external network load balancers of the API nor their
+	// DNS records, which the user provisions ahead of the install.
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancer stores the configuration of the load balancers of the API.
+type LoadBalancer struct {
+	// Type is the type of the load balancers of the API.
+	// +kubebuilder:default=OpenShiftManagedDefault
+	// +kubebuilder:validation:Enum:="OpenShiftManagedDefault";"UserManaged"
+	// +optional
+	Type configv1.PlatformLoadBalancerType `json:"type,omitempty"`
+}
+
+// UserManagedLoadBalancer returns true if the load balancers of the API are
+// provisioned by the user.
+func (p *Platform) UserManagedLoadBalancer() bool {
+	return p.LoadBalancer != nil && p.LoadBalancer.Type == configv1.LoadBalancerTypeUserManaged
 }
 
 // ServiceEndpoint store the configuration for services to
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -47,6 +48,31 @@ func ValidateMachinePool(platform *aws.Platform, p *aws.MachinePool, fldPath *fi
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("authentication"), p.EC2Metadata.Authentication, "must be either Required or Optional"))
 	}
 
+	if p.IAMRole != "" && p.IAMInstanceProfile != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("iamInstanceProfile"), "iamInstanceProfile and iamRole are mutually exclusive"))
+	}
+
+	if p.OutpostARN != "" {
+		allErrs = append(allErrs, validateOutpostARN(platform, p.OutpostARN, fldPath.Child("outpostARN"))...)
+	}
+
+	return allErrs
+}
+
+// validateOutpostARN checks that the ARN is the ARN of an Outpost of the
+// region, e.g. arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0.
+func validateOutpostARN(platform *aws.Platform, outpostARN string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	parsed, err := arn.Parse(outpostARN)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, outpostARN, err.Error()))
+	}
+	if parsed.Service != "outposts" || !strings.HasPrefix(parsed.Resource, "outpost/op-") {
+		allErrs = append(allErrs, field.Invalid(fldPath, outpostARN, "must be the ARN of an Outpost"))
+	}
+	if parsed.Region != platform.Region {
+		allErrs = append(allErrs, field.Invalid(fldPath, outpostARN, fmt.Sprintf("Outpost not in configured region (%s)", platform.Region)))
+	}
 	return allErrs
 }
 
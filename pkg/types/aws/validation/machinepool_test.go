@@ -128,6 +128,33 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.authentication: Invalid value: \"foobarbaz\": must be either Required or Optional$`,
 		},
+		{
+			name: "valid outpost",
+			pool: &aws.MachinePool{
+				OutpostARN: "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+			},
+		},
+		{
+			name: "invalid outpost ARN",
+			pool: &aws.MachinePool{
+				OutpostARN: "op-0123456789abcdef0",
+			},
+			expected: `^test-path\.outpostARN: Invalid value: "op-0123456789abcdef0": arn: invalid prefix$`,
+		},
+		{
+			name: "outpost ARN of another service",
+			pool: &aws.MachinePool{
+				OutpostARN: "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789abcdef0",
+			},
+			expected: `^test-path\.outpostARN: Invalid value: "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789abcdef0": must be the ARN of an Outpost$`,
+		},
+		{
+			name: "outpost in another region",
+			pool: &aws.MachinePool{
+				OutpostARN: "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0",
+			},
+			expected: `^test-path\.outpostARN: Invalid value: "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0": Outpost not in configured region \(us-east-1\)$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types/aws"
 )
 
@@ -44,6 +45,13 @@ func ValidatePlatform(p *aws.Platform, fldPath *field.Path) field.ErrorList {
 
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p, p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		if p.DefaultMachinePlatform.OutpostARN != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("defaultMachinePlatform", "outpostARN"), "outpostARN is valid only for the edge machine pool"))
+		}
+	}
+
+	if p.LoadBalancer != nil {
+		allErrs = append(allErrs, validateLoadBalancer(p, fldPath)...)
 	}
 	return allErrs
 }
@@ -72,11 +80,26 @@ func validateUserTags(tags map[string]string, propagatingTags bool, fldPath *fie
 			if strings.HasPrefix(key, "kubernetes.io/cluster/") {
 				allErrs = append(allErrs, field.Invalid(fldPath.Key(key), tags[key], "Keys with prefix 'kubernetes.io/cluster/' are not allowed for user defined tags"))
 			}
+			// The tags are applied to the resources created by the installer
+			// even when they are not propagated, so the limits of AWS apply.
+			if len(key) > 128 {
+				allErrs = append(allErrs, field.TooLong(fldPath.Key(key), key, 128))
+			}
+			if len(value) > 256 {
+				allErrs = append(allErrs, field.TooLong(fldPath.Key(key), value, 256))
+			}
 		}
 	}
 	return allErrs
 }
 
+// ValidateResourceTags checks that the tags are valid as the resource tags of
+// the Infrastructure config, which are propagated to the resources created by
+// the cluster.
+func ValidateResourceTags(tags map[string]string, fldPath *field.Path) field.ErrorList {
+	return validateUserTags(tags, true, fldPath)
+}
+
 // validateTag checks the following things to ensure that the tag is acceptable as an additional tag.
 //   - The key and value contain only valid characters.
 //   - The key is not empty and at most 128 characters.
@@ -156,3 +179,20 @@ func validateServiceURL(uri string) error {
 
 	return nil
 }
+
+// validateLoadBalancer checks the type of the load balancers of the API.
+// User-managed load balancers target the control plane machines in subnets
+// which the user provisions too.
+func validateLoadBalancer(p *aws.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.LoadBalancer.Type {
+	case "", configv1.LoadBalancerTypeOpenShiftManagedDefault:
+	case configv1.LoadBalancerTypeUserManaged:
+		if len(p.Subnets) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subnets"), "subnets is required with user-managed load balancers"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("loadBalancer", "type"), p.LoadBalancer.Type, []string{string(configv1.LoadBalancerTypeOpenShiftManagedDefault), string(configv1.LoadBalancerTypeUserManaged)}))
+	}
+	return allErrs
+}
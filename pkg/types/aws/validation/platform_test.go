@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types/aws"
 )
 
@@ -158,6 +159,26 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			expected: `^\Qtest-path.userTags[kubernetes.io/cluster/test-cluster]: Invalid value: "shared": Keys with prefix 'kubernetes.io/cluster/' are not allowed for user defined tags\E$`,
 		},
+		{
+			name: "invalid userTags, key too long",
+			platform: &aws.Platform{
+				Region: "us-east-1",
+				UserTags: map[string]string{
+					strings.Repeat("k", 129): "value",
+				},
+			},
+			expected: `^\Qtest-path.userTags[` + strings.Repeat("k", 129) + `]: Too long: must have at most 128 bytes\E$`,
+		},
+		{
+			name: "invalid userTags, value too long",
+			platform: &aws.Platform{
+				Region: "us-east-1",
+				UserTags: map[string]string{
+					"key": strings.Repeat("v", 257),
+				},
+			},
+			expected: `^\Qtest-path.userTags[key]: Too long: must have at most 256 bytes\E$`,
+		},
 		{
 			name: "valid userTags",
 			platform: &aws.Platform{
@@ -175,6 +196,30 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			expected: fmt.Sprintf(`^\Qtest-path.userTags: Too many: %d: must have at most %d items`, userTagLimit+1, userTagLimit),
 		},
+		{
+			name: "user-managed load balancer",
+			platform: &aws.Platform{
+				Region:       "us-east-1",
+				Subnets:      []string{"test-subnet"},
+				LoadBalancer: &aws.LoadBalancer{Type: configv1.LoadBalancerTypeUserManaged},
+			},
+		},
+		{
+			name: "user-managed load balancer without subnets",
+			platform: &aws.Platform{
+				Region:       "us-east-1",
+				LoadBalancer: &aws.LoadBalancer{Type: configv1.LoadBalancerTypeUserManaged},
+			},
+			expected: `^test-path\.subnets: Required value: subnets is required with user-managed load balancers$`,
+		},
+		{
+			name: "unsupported load balancer type",
+			platform: &aws.Platform{
+				Region:       "us-east-1",
+				LoadBalancer: &aws.LoadBalancer{Type: "Other"},
+			},
+			expected: `^test-path\.loadBalancer\.type: Unsupported value: "Other": supported values: "OpenShiftManagedDefault", "UserManaged"$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -264,6 +309,39 @@ func TestValidateTag(t *testing.T) {
 	}
 }
 
+func TestValidateResourceTags(t *testing.T) {
+	cases := []struct {
+		name     string
+		tags     map[string]string
+		expected string
+	}{{
+		name: "valid",
+		tags: map[string]string{"app": "production"},
+	}, {
+		name:     "invalid characters",
+		tags:     map[string]string{"app": "bad-value***"},
+		expected: `^\Qtest-path[app]: Invalid value: "bad-value***": value contains invalid characters\E$`,
+	}, {
+		name:     "key in openshift.io namespace",
+		tags:     map[string]string{"openshift.io/app": "production"},
+		expected: `^\Qtest-path[openshift.io/app]: Invalid value: "production": key is in the openshift.io namespace\E$`,
+	}, {
+		name:     "too many",
+		tags:     generateTooManyUserTags(),
+		expected: fmt.Sprintf(`^\Qtest-path: Too many: %d: must have at most %d items`, userTagLimit+1, userTagLimit),
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateResourceTags(tc.tags, field.NewPath("test-path")).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
 func generateTooManyUserTags() map[string]string {
 	tags := map[string]string{}
 	for i := 0; i <= userTagLimit; i++ {
@@ -3,13 +3,15 @@ package azure
 import (
 	"fmt"
 	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
 )
 
 // aro is a setting to enable aro-only modifications
 var aro bool
 
 // OutboundType is a strategy for how egress from cluster is achieved.
-// +kubebuilder:validation:Enum="";Loadbalancer;UserDefinedRouting
+// +kubebuilder:validation:Enum="";Loadbalancer;UserDefinedRouting;UserDefinedNATGateway
 type OutboundType string
 
 const (
@@ -20,6 +22,10 @@ const (
 	// UserDefinedRoutingOutboundType uses user defined routing for egress from the cluster.
 	// see https://docs.microsoft.com/en-us/azure/virtual-network/virtual-networks-udr-overview
 	UserDefinedRoutingOutboundType OutboundType = "UserDefinedRouting"
+
+	// UserDefinedNATGatewayOutboundType uses existing NAT gateways, associated with the subnets, for egress from the cluster.
+	// see https://docs.microsoft.com/en-us/azure/virtual-network/nat-gateway/nat-overview
+	UserDefinedNATGatewayOutboundType OutboundType = "UserDefinedNATGateway"
 )
 
 // Platform stores all the global configuration that all machinesets
@@ -34,6 +40,13 @@ type Platform struct {
 	// ClusterOSImage is the url of a storage blob in the Azure Stack environment containing an RHCOS VHD. This field is required for Azure Stack and not applicable to Azure.
 	ClusterOSImage string `json:"clusterOSImage,omitempty"`
 
+	// ClusterOSImageUpload is the storage account of the Azure Stack environment where the installer uploads the RHCOS VHD
+	// when clusterOSImage is not set. The VHD is downloaded and its checksum verified before the upload, and an interrupted
+	// upload is resumed. It is not applicable to Azure.
+	//
+	// +optional
+	ClusterOSImageUpload *ImageUpload `json:"clusterOSImageUpload,omitempty"`
+
 	// BaseDomainResourceGroupName specifies the resource group where the Azure DNS zone for the base domain is found. This field is optional when creating a private cluster, otherwise required.
 	//
 	// +optional
@@ -77,6 +90,12 @@ type Platform struct {
 	// +optional
 	OutboundType OutboundType `json:"outboundType"`
 
+	// NATGateways are the existing NAT gateways associated with the control plane and compute subnets.
+	// They are required when the outbound type is UserDefinedNATGateway, and not allowed otherwise.
+	//
+	// +optional
+	NATGateways []NATGateway `json:"natGateways,omitempty"`
+
 	// ResourceGroupName is the name of an already existing resource group where the cluster should be installed.
 	// This resource group should only be used for this specific cluster and the cluster components will assume
 	// ownership of all resources in the resource group. Destroying the cluster using installer will delete this
@@ -94,6 +113,71 @@ type Platform struct {
 	// TechPreviewNoUpgrade to configure the tags.
 	// +optional
 	UserTags map[string]string `json:"userTags,omitempty"`
+
+	// LoadBalancer defines how the API load balancers are provisioned. With
+	// the UserManaged type, the installer does not create the internal and
+	// public API load balancers or their DNS records; the user provides them.
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancer stores the configuration of the load balancers of the API.
+type LoadBalancer struct {
+	// Type is the type of the load balancers of the API.
+	// +kubebuilder:default=OpenShiftManagedDefault
+	// +kubebuilder:validation:Enum:="OpenShiftManagedDefault";"UserManaged"
+	// +optional
+	Type configv1.PlatformLoadBalancerType `json:"type,omitempty"`
+}
+
+// UserManagedLoadBalancer returns true if the load balancers of the API are
+// provisioned by the user.
+func (p *Platform) UserManagedLoadBalancer() bool {
+	return p.LoadBalancer != nil && p.LoadBalancer.Type == configv1.LoadBalancerTypeUserManaged
+}
+
+// DefaultImageUploadContainer is the container where the RHCOS VHD is uploaded when none is set.
+const DefaultImageUploadContainer = "rhcos"
+
+// ImageUpload is the storage blob container where the RHCOS VHD is uploaded.
+type ImageUpload struct {
+	// StorageAccount is the name of an existing storage account.
+	StorageAccount string `json:"storageAccount"`
+
+	// ResourceGroup is the name of the resource group of the storage account.
+	ResourceGroup string `json:"resourceGroup"`
+
+	// Container is the name of the blob container, which is created if it does not exist.
+	// If empty, the value is equal to "rhcos".
+	//
+	// +optional
+	Container string `json:"container,omitempty"`
+}
+
+// ContainerName returns the name of the blob container of the upload.
+func (u *ImageUpload) ContainerName() string {
+	if u.Container != "" {
+		return u.Container
+	}
+	return DefaultImageUploadContainer
+}
+
+// NATGateway is an existing NAT gateway used for the egress of a subnet.
+type NATGateway struct {
+	// Subnet is the name of the subnet, either the control plane or the compute subnet, associated with the NAT gateway.
+	Subnet string `json:"subnet"`
+
+	// ID is the resource ID of the NAT gateway, e.g.
+	// /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Network/natGateways/<name>.
+	ID string `json:"id"`
+
+	// IdleTimeoutInMinutes is the idle timeout expected on the NAT gateway, between 4 and 120 minutes.
+	// When set, it must match the idle timeout configured on the NAT gateway.
+	//
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
 }
 
 // CloudEnvironment is the name of the Azure cloud environment
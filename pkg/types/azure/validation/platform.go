@@ -8,6 +8,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/azure"
 )
@@ -85,9 +86,10 @@ func ValidatePlatform(p *azure.Platform, publish types.PublishingStrategy, fldPa
 	if _, ok := validOutboundTypes[p.OutboundType]; !ok {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("outboundType"), p.OutboundType, validOutboundTypeValues))
 	}
-	if p.OutboundType == azure.UserDefinedRoutingOutboundType && p.VirtualNetwork == "" {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, fmt.Sprintf("%s is only allowed when installing to pre-existing network", azure.UserDefinedRoutingOutboundType)))
+	if (p.OutboundType == azure.UserDefinedRoutingOutboundType || p.OutboundType == azure.UserDefinedNATGatewayOutboundType) && p.VirtualNetwork == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, fmt.Sprintf("%s is only allowed when installing to pre-existing network", p.OutboundType)))
 	}
+	allErrs = append(allErrs, validateNATGateways(p, fldPath)...)
 
 	// support for Azure user-defined tags made available through
 	// RFE-2017 is for AzurePublicCloud only.
@@ -107,8 +109,14 @@ func ValidatePlatform(p *azure.Platform, publish types.PublishingStrategy, fldPa
 		if p.ClusterOSImage != "" {
 			allErrs = append(allErrs, field.Required(fldPath.Child("clusterOSImage"), fmt.Sprintf("clusterOSImage must not be set when the cloud name is %s", cloud)))
 		}
+		if p.ClusterOSImageUpload != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("clusterOSImageUpload"), fmt.Sprintf("clusterOSImageUpload must not be set when the cloud name is %s", cloud)))
+		}
 	}
 
+	if p.LoadBalancer != nil {
+		allErrs = append(allErrs, validateLoadBalancer(p, fldPath)...)
+	}
 	return allErrs
 }
 
@@ -184,8 +192,9 @@ func findDuplicateTagKeys(tagSet map[string]string) error {
 
 var (
 	validOutboundTypes = map[azure.OutboundType]struct{}{
-		azure.LoadbalancerOutboundType:       {},
-		azure.UserDefinedRoutingOutboundType: {},
+		azure.LoadbalancerOutboundType:          {},
+		azure.UserDefinedRoutingOutboundType:    {},
+		azure.UserDefinedNATGatewayOutboundType: {},
 	}
 
 	validOutboundTypeValues = func() []string {
@@ -198,6 +207,47 @@ var (
 	}()
 )
 
+// natGatewayIDRegex is for verifying that the ID of a NAT gateway is a NAT
+// gateway resource ID.
+var natGatewayIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/natGateways/[^/]+$`)
+
+// validateNATGateways checks that the NAT gateways are set, for both the
+// control plane and the compute subnets, only when the outbound type is
+// UserDefinedNATGateway.
+func validateNATGateways(p *azure.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath = fldPath.Child("natGateways")
+
+	if p.OutboundType != azure.UserDefinedNATGatewayOutboundType {
+		if len(p.NATGateways) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("NAT gateways are only allowed when the outbound type is %s", azure.UserDefinedNATGatewayOutboundType)))
+		}
+		return allErrs
+	}
+
+	subnets := map[string]bool{}
+	for i, gateway := range p.NATGateways {
+		if gateway.Subnet != p.ControlPlaneSubnet && gateway.Subnet != p.ComputeSubnet {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("subnet"), gateway.Subnet, "must be either the control plane or the compute subnet"))
+		} else if subnets[gateway.Subnet] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("subnet"), gateway.Subnet))
+		}
+		subnets[gateway.Subnet] = true
+		if !natGatewayIDRegex.MatchString(gateway.ID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("id"), gateway.ID, "must be the resource ID of a NAT gateway"))
+		}
+		if timeout := gateway.IdleTimeoutInMinutes; timeout != nil && (*timeout < 4 || *timeout > 120) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("idleTimeoutInMinutes"), *timeout, "must be between 4 and 120 minutes"))
+		}
+	}
+	for _, subnet := range []string{p.ControlPlaneSubnet, p.ComputeSubnet} {
+		if subnet != "" && !subnets[subnet] {
+			allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("a NAT gateway must be provided for subnet %s", subnet)))
+		}
+	}
+	return allErrs
+}
+
 func validateAzureStack(p *azure.Platform, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	if p.ARMEndpoint == "" {
@@ -206,5 +256,36 @@ func validateAzureStack(p *azure.Platform, fldPath *field.Path) field.ErrorList
 	if p.OutboundType == azure.UserDefinedRoutingOutboundType {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, "Azure Stack does not support user-defined routing"))
 	}
+	if p.OutboundType == azure.UserDefinedNATGatewayOutboundType {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, "Azure Stack does not support NAT gateways"))
+	}
+	if u := p.ClusterOSImageUpload; u != nil {
+		uploadPath := fldPath.Child("clusterOSImageUpload")
+		if p.ClusterOSImage != "" {
+			allErrs = append(allErrs, field.Forbidden(uploadPath, "clusterOSImageUpload must not be set with clusterOSImage"))
+		}
+		if u.StorageAccount == "" {
+			allErrs = append(allErrs, field.Required(uploadPath.Child("storageAccount"), "the storage account of the upload is required"))
+		}
+		if u.ResourceGroup == "" {
+			allErrs = append(allErrs, field.Required(uploadPath.Child("resourceGroup"), "the resource group of the storage account is required"))
+		}
+	}
+	return allErrs
+}
+
+// validateLoadBalancer checks the load balancer type. A user-managed load
+// balancer needs an existing virtual network to reach the control plane.
+func validateLoadBalancer(p *azure.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.LoadBalancer.Type {
+	case "", configv1.LoadBalancerTypeOpenShiftManagedDefault:
+	case configv1.LoadBalancerTypeUserManaged:
+		if p.VirtualNetwork == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("virtualNetwork"), "virtualNetwork is required with user-managed load balancers"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("loadBalancer", "type"), p.LoadBalancer.Type, []string{string(configv1.LoadBalancerTypeOpenShiftManagedDefault), string(configv1.LoadBalancerTypeUserManaged)}))
+	}
 	return allErrs
 }
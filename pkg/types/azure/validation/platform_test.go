@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/azure"
@@ -19,6 +20,13 @@ func validPlatform() *azure.Platform {
 	}
 }
 
+func validStackPlatform() *azure.Platform {
+	p := validPlatform()
+	p.CloudName = azure.StackCloud
+	p.ARMEndpoint = "https://management.stack.example.com"
+	return p
+}
+
 func validNetworkPlatform() *azure.Platform {
 	p := validPlatform()
 	p.NetworkResourceGroupName = "networkresourcegroup"
@@ -28,6 +36,23 @@ func validNetworkPlatform() *azure.Platform {
 	return p
 }
 
+func validNATGatewayPlatform() *azure.Platform {
+	p := validNetworkPlatform()
+	p.OutboundType = azure.UserDefinedNATGatewayOutboundType
+	p.NATGateways = []azure.NATGateway{
+		{
+			Subnet:               "controlplanesubnet",
+			ID:                   "/subscriptions/sub/resourceGroups/networkresourcegroup/providers/Microsoft.Network/natGateways/controlplane",
+			IdleTimeoutInMinutes: pointer.Int32(4),
+		},
+		{
+			Subnet: "computesubnet",
+			ID:     "/subscriptions/sub/resourceGroups/networkresourcegroup/providers/Microsoft.Network/natGateways/compute",
+		},
+	}
+	return p
+}
+
 func TestValidatePlatform(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -140,7 +165,7 @@ func TestValidatePlatform(t *testing.T) {
 				p.OutboundType = "random-egress"
 				return p
 			}(),
-			expected: `^test-path\.outboundType: Unsupported value: "random-egress": supported values: "Loadbalancer", "UserDefinedRouting"$`,
+			expected: `^test-path\.outboundType: Unsupported value: "random-egress": supported values: "Loadbalancer", "UserDefinedNATGateway", "UserDefinedRouting"$`,
 		},
 		{
 			name: "invalid user defined type",
@@ -151,6 +176,100 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			expected: `^test-path\.outboundType: Invalid value: "UserDefinedRouting": UserDefinedRouting is only allowed when installing to pre-existing network$`,
 		},
+		{
+			name:     "valid user defined NAT gateway",
+			platform: validNATGatewayPlatform(),
+		},
+		{
+			name: "invalid user defined NAT gateway without network",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.OutboundType = azure.UserDefinedNATGatewayOutboundType
+				return p
+			}(),
+			expected: `^test-path\.outboundType: Invalid value: "UserDefinedNATGateway": UserDefinedNATGateway is only allowed when installing to pre-existing network$`,
+		},
+		{
+			name: "invalid user defined NAT gateway missing compute subnet",
+			platform: func() *azure.Platform {
+				p := validNATGatewayPlatform()
+				p.NATGateways = p.NATGateways[:1]
+				return p
+			}(),
+			expected: `^test-path\.natGateways: Required value: a NAT gateway must be provided for subnet computesubnet$`,
+		},
+		{
+			name: "invalid user defined NAT gateway unknown subnet",
+			platform: func() *azure.Platform {
+				p := validNATGatewayPlatform()
+				p.NATGateways[1].Subnet = "othersubnet"
+				return p
+			}(),
+			expected: `^\[test-path\.natGateways\[1\]\.subnet: Invalid value: "othersubnet": must be either the control plane or the compute subnet, test-path\.natGateways: Required value: a NAT gateway must be provided for subnet computesubnet\]$`,
+		},
+		{
+			name: "invalid user defined NAT gateway ID",
+			platform: func() *azure.Platform {
+				p := validNATGatewayPlatform()
+				p.NATGateways[0].ID = "natgateway"
+				return p
+			}(),
+			expected: `^test-path\.natGateways\[0\]\.id: Invalid value: "natgateway": must be the resource ID of a NAT gateway$`,
+		},
+		{
+			name: "invalid user defined NAT gateway idle timeout",
+			platform: func() *azure.Platform {
+				p := validNATGatewayPlatform()
+				p.NATGateways[0].IdleTimeoutInMinutes = pointer.Int32(2)
+				return p
+			}(),
+			expected: `^test-path\.natGateways\[0\]\.idleTimeoutInMinutes: Invalid value: 2: must be between 4 and 120 minutes$`,
+		},
+		{
+			name: "invalid NAT gateways with load balancer outbound type",
+			platform: func() *azure.Platform {
+				p := validNATGatewayPlatform()
+				p.OutboundType = azure.LoadbalancerOutboundType
+				return p
+			}(),
+			expected: `^test-path\.natGateways: Forbidden: NAT gateways are only allowed when the outbound type is UserDefinedNATGateway$`,
+		},
+		{
+			name: "valid cluster OS image upload",
+			platform: func() *azure.Platform {
+				p := validStackPlatform()
+				p.ClusterOSImageUpload = &azure.ImageUpload{StorageAccount: "account", ResourceGroup: "group"}
+				return p
+			}(),
+		},
+		{
+			name: "invalid cluster OS image upload without storage account",
+			platform: func() *azure.Platform {
+				p := validStackPlatform()
+				p.ClusterOSImageUpload = &azure.ImageUpload{ResourceGroup: "group"}
+				return p
+			}(),
+			expected: `^test-path\.clusterOSImageUpload\.storageAccount: Required value: the storage account of the upload is required$`,
+		},
+		{
+			name: "invalid cluster OS image upload with cluster OS image",
+			platform: func() *azure.Platform {
+				p := validStackPlatform()
+				p.ClusterOSImage = "https://account.blob.stack.example.com/vhd/rhcos.vhd"
+				p.ClusterOSImageUpload = &azure.ImageUpload{StorageAccount: "account", ResourceGroup: "group"}
+				return p
+			}(),
+			expected: `^test-path\.clusterOSImageUpload: Forbidden: clusterOSImageUpload must not be set with clusterOSImage$`,
+		},
+		{
+			name: "invalid cluster OS image upload on public cloud",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.ClusterOSImageUpload = &azure.ImageUpload{StorageAccount: "account", ResourceGroup: "group"}
+				return p
+			}(),
+			expected: `^test-path\.clusterOSImageUpload: Forbidden: clusterOSImageUpload must not be set when the cloud name is AzurePublicCloud$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types/secretref"
 )
 
 // BMC stores the information about a baremetal host's management controller.
@@ -13,6 +14,10 @@ type BMC struct {
 	Password                       string `json:"password" validate:"required"`
 	Address                        string `json:"address" validate:"required,uniqueField"`
 	DisableCertificateVerification bool   `json:"disableCertificateVerification"`
+
+	// PasswordRef references the password stored outside of the install
+	// config, instead of setting Password.
+	PasswordRef *secretref.Reference `json:"passwordRef,omitempty"`
 }
 
 // BootMode puts the server in legacy (BIOS), UEFI secure boot or UEFI mode for
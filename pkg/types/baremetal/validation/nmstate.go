@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// bondModes are the modes of the bond interfaces supported by nmstate.
+var bondModes = sets.NewString("balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb")
+
+// maxVLANID is the highest VLAN ID, 4095 being reserved.
+const maxVLANID = 4094
+
+// validateNMState checks the interfaces of the nmstate network config of a
+// host, and especially its bonds and VLANs, against the nmstate schema. The
+// config is fully validated by nmstatectl, when it is installed, before the
+// cluster is created.
+func validateNMState(networkConfig map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	raw, ok := networkConfig["interfaces"]
+	if !ok {
+		return allErrs
+	}
+	interfaces, ok := raw.([]interface{})
+	if !ok {
+		return append(allErrs, field.Invalid(fldPath.Child("interfaces"), raw, "must be a list of interfaces"))
+	}
+
+	names := sets.NewString()
+	for i, raw := range interfaces {
+		iface, ok := raw.(map[string]interface{})
+		idxPath := fldPath.Child("interfaces").Index(i)
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(idxPath, raw, "must be an interface"))
+			continue
+		}
+		name, _ := iface["name"].(string)
+		if name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "the name of the interface is required"))
+		} else if names.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), name))
+		}
+		names.Insert(name)
+
+		// The type of an existing interface may be omitted, nmstate then
+		// finds it from the interface, so only the bonds and VLANs, which are
+		// created, are checked.
+		switch ifaceType, _ := iface["type"].(string); ifaceType {
+		case "bond":
+			allErrs = append(allErrs, validateBond(iface["link-aggregation"], idxPath.Child("link-aggregation"))...)
+		case "vlan":
+			allErrs = append(allErrs, validateVLAN(iface["vlan"], name, idxPath.Child("vlan"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateBond(raw interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	bond, ok := raw.(map[string]interface{})
+	if !ok {
+		return append(allErrs, field.Required(fldPath, "the link aggregation of a bond is required"))
+	}
+	mode, _ := bond["mode"].(string)
+	if mode == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("mode"), "the mode of the bond is required"))
+	} else if !bondModes.Has(mode) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), mode, bondModes.List()))
+	}
+
+	// Before nmstate 2.0, the ports of a bond were its slaves.
+	portsKey := "port"
+	if _, ok := bond[portsKey]; !ok {
+		if _, ok := bond["slaves"]; ok {
+			portsKey = "slaves"
+		}
+	}
+	ports, ok := bond[portsKey].([]interface{})
+	if !ok || len(ports) == 0 {
+		return append(allErrs, field.Required(fldPath.Child(portsKey), "a bond must have at least one port"))
+	}
+	seen := sets.NewString()
+	for i, raw := range ports {
+		port, _ := raw.(string)
+		switch {
+		case port == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(portsKey).Index(i), raw, "must be the name of an interface"))
+		case seen.Has(port):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child(portsKey).Index(i), port))
+		}
+		seen.Insert(port)
+	}
+	return allErrs
+}
+
+func validateVLAN(raw interface{}, name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	vlan, ok := raw.(map[string]interface{})
+	if !ok {
+		return append(allErrs, field.Required(fldPath, "the VLAN configuration of a vlan interface is required"))
+	}
+	baseIface, _ := vlan["base-iface"].(string)
+	switch {
+	case baseIface == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("base-iface"), "the base interface of the VLAN is required"))
+	case baseIface == name:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("base-iface"), baseIface, "a VLAN may not be its own base interface"))
+	}
+
+	// The documents are decoded from JSON, so the numbers are floats.
+	switch id := vlan["id"].(type) {
+	case nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("id"), "the ID of the VLAN is required"))
+	case float64:
+		if id != float64(int64(id)) || id < 0 || id > maxVLANID {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), id, fmt.Sprintf("must be an integer between 0 and %d", maxVLANID)))
+		}
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), id, fmt.Sprintf("must be an integer between 0 and %d", maxVLANID)))
+	}
+	return allErrs
+}
@@ -332,7 +332,8 @@ func validateHostsCount(hosts []*baremetal.Host, installConfig *types.InstallCon
 	return nil
 }
 
-// ensure that the NetworkConfig field contains a valid Yaml string
+// ensure that the NetworkConfig field contains a valid Yaml string, whose
+// interfaces are valid nmstate interfaces
 func validateNetworkConfig(hosts []*baremetal.Host, fldPath *field.Path) (errors field.ErrorList) {
 	for idx, host := range hosts {
 		if host.NetworkConfig != nil {
@@ -340,7 +341,9 @@ func validateNetworkConfig(hosts []*baremetal.Host, fldPath *field.Path) (errors
 			err := yaml.Unmarshal(host.NetworkConfig.Raw, &networkConfig)
 			if err != nil {
 				errors = append(errors, field.Invalid(fldPath.Index(idx).Child("networkConfig"), host.NetworkConfig, fmt.Sprintf("Not a valid yaml: %s", err.Error())))
+				continue
 			}
+			errors = append(errors, validateNMState(networkConfig, fldPath.Index(idx).Child("networkConfig"))...)
 		}
 	}
 	return
@@ -338,6 +338,60 @@ interfaces:
           stp-priority: 32`)).build(),
 			expected: "",
 		},
+		{
+			name: "networkConfig_bond_vlan_valid",
+			platform: platform().Hosts(host1().NetworkConfig(`
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eno1
+    - eno2
+- name: bond0.100
+  type: vlan
+  state: up
+  vlan:
+    base-iface: bond0
+    id: 100
+- name: eno3
+  state: absent`)).build(),
+		},
+		{
+			name: "networkConfig_bond_invalid",
+			platform: platform().Hosts(host1().NetworkConfig(`
+interfaces:
+- name: bond0
+  type: bond
+  link-aggregation:
+    mode: round-robin
+    port: []`)).build(),
+			expected: `^\[baremetal\.Hosts\[0\]\.networkConfig\.interfaces\[0\]\.link-aggregation\.mode: Unsupported value: "round-robin": supported values: .*, baremetal\.Hosts\[0\]\.networkConfig\.interfaces\[0\]\.link-aggregation\.port: Required value: a bond must have at least one port\]$`,
+		},
+		{
+			name: "networkConfig_vlan_invalid",
+			platform: platform().Hosts(host1().NetworkConfig(`
+interfaces:
+- name: eno1.5000
+  type: vlan
+  vlan:
+    id: 5000
+- name: eno1.5000
+  type: vlan
+  vlan:
+    base-iface: eno1
+    id: 10`)).build(),
+			expected: `^\[baremetal\.Hosts\[0\]\.networkConfig\.interfaces\[0\]\.vlan\.base-iface: Required value: the base interface of the VLAN is required, baremetal\.Hosts\[0\]\.networkConfig\.interfaces\[0\]\.vlan\.id: Invalid value: 5000: must be an integer between 0 and 4094, baremetal\.Hosts\[0\]\.networkConfig\.interfaces\[1\]\.name: Duplicate value: "eno1\.5000"\]$`,
+		},
+		{
+			name: "networkConfig_interface_without_type",
+			platform: platform().Hosts(host1().NetworkConfig(`
+interfaces:
+- name: eno1
+  state: up`)).build(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -25,6 +25,54 @@ type ClusterMetadata struct {
 	// InfraID is an ID that is used to identify cloud resources created by the installer.
 	InfraID                 string `json:"infraID"`
 	ClusterPlatformMetadata `json:",inline"`
+	// Resources is the inventory of the infrastructure resources created by
+	// the installer for the cluster.
+	Resources []ClusterResource `json:"resources,omitempty"`
+	// Destroy is the progress of a destroy which did not complete, which the
+	// next destroy resumes.
+	Destroy *DestroyProgress `json:"destroy,omitempty"`
+}
+
+// DestroyProgress is the progress of a destroy of the cluster.
+type DestroyProgress struct {
+	// Completed are the platform-specific names of the kinds of resources
+	// which were all deleted.
+	Completed []string `json:"completed,omitempty"`
+}
+
+// ClusterResourceKind is the kind of an infrastructure resource of the
+// cluster.
+type ClusterResourceKind string
+
+const (
+	// ClusterResourceInstance is a machine, e.g. a virtual machine instance.
+	ClusterResourceInstance ClusterResourceKind = "Instance"
+	// ClusterResourceNetwork is a network resource, e.g. a network, a subnet
+	// or a security group.
+	ClusterResourceNetwork ClusterResourceKind = "Network"
+	// ClusterResourceLoadBalancer is a load balancer, or a part of it, e.g. a
+	// listener or a pool.
+	ClusterResourceLoadBalancer ClusterResourceKind = "LoadBalancer"
+	// ClusterResourceDNSRecord is a DNS record, or a DNS zone.
+	ClusterResourceDNSRecord ClusterResourceKind = "DNSRecord"
+	// ClusterResourceOther is any other resource, e.g. an image or a bucket.
+	ClusterResourceOther ClusterResourceKind = "Other"
+)
+
+// ClusterResource is an infrastructure resource created by the installer.
+type ClusterResource struct {
+	// Kind is the kind of the resource.
+	Kind ClusterResourceKind `json:"kind"`
+	// Type is the type of the resource for the infrastructure provider,
+	// e.g. aws_instance.
+	Type string `json:"type"`
+	// Name is the name of the resource in the installer configuration,
+	// e.g. bootstrap.
+	Name string `json:"name"`
+	// ID is the ID of the resource for the infrastructure provider.
+	ID string `json:"id"`
+	// Stage is the provisioning stage which created the resource.
+	Stage string `json:"stage"`
 }
 
 // ClusterPlatformMetadata contains metadata for platfrom.
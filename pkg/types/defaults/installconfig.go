@@ -1,6 +1,8 @@
 package defaults
 
 import (
+	"strings"
+
 	operv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
@@ -8,6 +10,7 @@ import (
 	"github.com/openshift/installer/pkg/types/azure"
 	azuredefaults "github.com/openshift/installer/pkg/types/azure/defaults"
 	baremetaldefaults "github.com/openshift/installer/pkg/types/baremetal/defaults"
+	externaldefaults "github.com/openshift/installer/pkg/types/external/defaults"
 	gcpdefaults "github.com/openshift/installer/pkg/types/gcp/defaults"
 	ibmclouddefaults "github.com/openshift/installer/pkg/types/ibmcloud/defaults"
 	libvirtdefaults "github.com/openshift/installer/pkg/types/libvirt/defaults"
@@ -124,10 +127,26 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 		powervsdefaults.SetPlatformDefaults(c.Platform.PowerVS)
 	case c.Platform.None != nil:
 		nonedefaults.SetPlatformDefaults(c.Platform.None)
+	case c.Platform.External != nil:
+		externaldefaults.SetPlatformDefaults(c.Platform.External)
 	case c.Platform.Nutanix != nil:
 		nutanixdefaults.SetPlatformDefaults(c.Platform.Nutanix)
 	}
 
+	if c.ImageMirrorResults != nil && c.ImageMirrorResults.RegistryCA != "" {
+		registryCA := strings.TrimSpace(c.ImageMirrorResults.RegistryCA)
+		if !strings.Contains(c.AdditionalTrustBundle, registryCA) {
+			if c.AdditionalTrustBundle != "" && !strings.HasSuffix(c.AdditionalTrustBundle, "\n") {
+				c.AdditionalTrustBundle += "\n"
+			}
+			c.AdditionalTrustBundle += registryCA + "\n"
+		}
+		// The nodes must trust the mirror registry, not only the proxy.
+		if c.AdditionalTrustBundlePolicy == "" {
+			c.AdditionalTrustBundlePolicy = types.PolicyAlways
+		}
+	}
+
 	if c.AdditionalTrustBundlePolicy == "" {
 		c.AdditionalTrustBundlePolicy = types.PolicyProxyOnly
 	}
@@ -310,6 +310,40 @@ func TestSetInstallConfigDefaults(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "Mirror registry CA present",
+			config: &types.InstallConfig{
+				AdditionalTrustBundle: "proxy CA",
+				ImageMirrorResults:    &types.ImageMirrorResults{Path: "results.yaml", RegistryCA: "registry CA\n"},
+				Platform: types.Platform{
+					None: &none.Platform{},
+				},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultNoneInstallConfig()
+				c.AdditionalTrustBundle = "proxy CA\nregistry CA\n"
+				c.AdditionalTrustBundlePolicy = types.PolicyAlways
+				c.ImageMirrorResults = &types.ImageMirrorResults{Path: "results.yaml", RegistryCA: "registry CA\n"}
+				return c
+			}(),
+		},
+		{
+			name: "Mirror registry CA already trusted",
+			config: &types.InstallConfig{
+				AdditionalTrustBundle:       "registry CA\n",
+				AdditionalTrustBundlePolicy: types.PolicyProxyOnly,
+				ImageMirrorResults:          &types.ImageMirrorResults{Path: "results.yaml", RegistryCA: "registry CA"},
+				Platform: types.Platform{
+					None: &none.Platform{},
+				},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultNoneInstallConfig()
+				c.AdditionalTrustBundle = "registry CA\n"
+				c.ImageMirrorResults = &types.ImageMirrorResults{Path: "results.yaml", RegistryCA: "registry CA"}
+				return c
+			}(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,16 @@
+package defaults
+
+import (
+	"github.com/openshift/installer/pkg/types/external"
+)
+
+// UnknownPlatformName is the name of the infrastructure provider when none is
+// given.
+const UnknownPlatformName = "Unknown"
+
+// SetPlatformDefaults sets the defaults for the platform.
+func SetPlatformDefaults(p *external.Platform) {
+	if p.PlatformName == "" {
+		p.PlatformName = UnknownPlatformName
+	}
+}
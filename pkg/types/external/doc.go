@@ -0,0 +1,7 @@
+// Package external contains the structures for installing on infrastructure
+// whose integration is provided by a third-party, such as its cloud controller
+// manager, rather than by the installer.
+package external
+
+// Name is name for the External platform.
+const Name string = "external"
@@ -0,0 +1,12 @@
+package external
+
+// Platform stores the configuration of the External platform.
+type Platform struct {
+	// PlatformName is the name of the infrastructure provider, e.g. "oci".
+	// It is reported in the infrastructure of the cluster, and names the
+	// plugin of the provider, which hooks the validation of the install
+	// config, the manifests and the checks after the install.
+	//
+	// +optional
+	PlatformName string `json:"platformName,omitempty"`
+}
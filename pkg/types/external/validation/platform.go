@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/external"
+	"github.com/openshift/installer/pkg/types/external/defaults"
+)
+
+// ValidatePlatform checks that the specified platform is valid.
+func ValidatePlatform(p *external.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The platform name names the plugin of the provider, so it must be
+	// usable as a file name.
+	if p.PlatformName != "" && p.PlatformName != defaults.UnknownPlatformName {
+		for _, msg := range validation.IsDNS1123Label(p.PlatformName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("platformName"), p.PlatformName, msg))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/external"
+)
+
+func TestValidatePlatform(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform *external.Platform
+		expected string
+	}{
+		{
+			name:     "minimal",
+			platform: &external.Platform{},
+		},
+		{
+			name:     "unknown",
+			platform: &external.Platform{PlatformName: "Unknown"},
+		},
+		{
+			name:     "provider",
+			platform: &external.Platform{PlatformName: "oci"},
+		},
+		{
+			name:     "invalid name",
+			platform: &external.Platform{PlatformName: "../oci"},
+			expected: `^test-path\.platformName: Invalid value: "\.\./oci": a lowercase RFC 1123 label must consist of`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePlatform(tc.platform, field.NewPath("test-path")).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
@@ -43,6 +43,52 @@ type MachinePool struct {
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	// +optional
 	ConfidentialCompute string `json:"confidentialCompute,omitempty"`
+
+	// ShieldedInstanceConfig is the Shielded VM configuration of the instances.
+	// +optional
+	ShieldedInstanceConfig *ShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
+}
+
+// ShieldedInstanceConfig defines the Shielded VM options of the machines on GCP.
+type ShieldedInstanceConfig struct {
+	// SecureBoot defines whether the instance should have secure boot enabled.
+	// It must match the secureBoot of the machine pool when both are set.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Disabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	SecureBoot string `json:"secureBoot,omitempty"`
+
+	// VirtualizedTrustedPlatformModule defines whether the instance should have a virtualized trusted platform module,
+	// which measures the boot to create the integrity policy baseline. It must be enabled for integrity monitoring.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	VirtualizedTrustedPlatformModule string `json:"virtualizedTrustedPlatformModule,omitempty"`
+
+	// IntegrityMonitoring defines whether the boot measurements of the instance are compared with the integrity policy baseline.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	IntegrityMonitoring string `json:"integrityMonitoring,omitempty"`
+}
+
+// Set sets the values from `required` to `s`.
+func (s *ShieldedInstanceConfig) Set(required *ShieldedInstanceConfig) {
+	if required == nil || s == nil {
+		return
+	}
+
+	if required.SecureBoot != "" {
+		s.SecureBoot = required.SecureBoot
+	}
+
+	if required.VirtualizedTrustedPlatformModule != "" {
+		s.VirtualizedTrustedPlatformModule = required.VirtualizedTrustedPlatformModule
+	}
+
+	if required.IntegrityMonitoring != "" {
+		s.IntegrityMonitoring = required.IntegrityMonitoring
+	}
 }
 
 // OSDisk defines the disk for machines on GCP.
@@ -108,6 +154,13 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.ConfidentialCompute != "" {
 		a.ConfidentialCompute = required.ConfidentialCompute
 	}
+
+	if required.ShieldedInstanceConfig != nil {
+		if a.ShieldedInstanceConfig == nil {
+			a.ShieldedInstanceConfig = &ShieldedInstanceConfig{}
+		}
+		a.ShieldedInstanceConfig.Set(required.ShieldedInstanceConfig)
+	}
 }
 
 // EncryptionKeyReference describes the encryptionKey to use for a disk's encryption.
@@ -1,5 +1,9 @@
 package gcp
 
+import (
+	configv1 "github.com/openshift/api/config/v1"
+)
+
 // Platform stores all the global configuration that all machinesets
 // use.
 type Platform struct {
@@ -26,12 +30,14 @@ type Platform struct {
 	NetworkProjectID string `json:"networkProjectID,omitempty"`
 
 	// ControlPlaneSubnet is an existing subnet where the control plane will be deployed.
-	// The value should be the name of the subnet.
+	// The value should be the name of the subnet. When networkProjectID is set, the
+	// subnet is looked up in the network project.
 	// +optional
 	ControlPlaneSubnet string `json:"controlPlaneSubnet,omitempty"`
 
 	// ComputeSubnet is an existing subnet where the compute nodes will be deployed.
-	// The value should be the name of the subnet.
+	// The value should be the name of the subnet. When networkProjectID is set, the
+	// subnet is looked up in the network project.
 	// +optional
 	ComputeSubnet string `json:"computeSubnet,omitempty"`
 
@@ -42,4 +48,25 @@ type Platform struct {
 	// such as the current env OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE
 	// +optional
 	Licenses []string `json:"licenses,omitempty"`
+
+	// LoadBalancer defines how the API load balancers are provisioned. When
+	// it is UserManaged, the installer skips the forwarding rules and target
+	// pools of the API and their DNS records, which the user provides.
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancer stores the configuration of the load balancers of the API.
+type LoadBalancer struct {
+	// Type is the type of the load balancers of the API.
+	// +kubebuilder:default=OpenShiftManagedDefault
+	// +kubebuilder:validation:Enum:="OpenShiftManagedDefault";"UserManaged"
+	// +optional
+	Type configv1.PlatformLoadBalancerType `json:"type,omitempty"`
+}
+
+// UserManagedLoadBalancer returns true if the load balancers of the API are
+// provisioned by the user.
+func (p *Platform) UserManagedLoadBalancer() bool {
+	return p.LoadBalancer != nil && p.LoadBalancer.Type == configv1.LoadBalancerTypeUserManaged
 }
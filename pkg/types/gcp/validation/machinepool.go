@@ -13,6 +13,15 @@ import (
 	"github.com/openshift/installer/pkg/types/gcp"
 )
 
+var (
+	// confidentialComputeMachineFamilies are the machine families which
+	// support confidential computing, with AMD SEV.
+	confidentialComputeMachineFamilies = sets.NewString("c2d", "n2d")
+
+	policyValues            = sets.NewString("Enabled", "Disabled")
+	onHostMaintenanceValues = sets.NewString("Migrate", "Terminate")
+)
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(platform *gcp.Platform, p *gcp.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -47,9 +56,63 @@ func ValidateMachinePool(platform *gcp.Platform, p *gcp.MachinePool, fldPath *fi
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("tags").Index(i), tag, fmt.Sprintf("maximum number of characters is 63")))
 		}
 	}
+
+	if p.OnHostMaintenance != "" && !onHostMaintenanceValues.Has(p.OnHostMaintenance) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("onHostMaintenance"), p.OnHostMaintenance, onHostMaintenanceValues.List()))
+	}
+	allErrs = append(allErrs, validatePolicy(p.ConfidentialCompute, fldPath.Child("confidentialCompute"))...)
+	allErrs = append(allErrs, validatePolicy(p.SecureBoot, fldPath.Child("secureBoot"))...)
+	if p.ShieldedInstanceConfig != nil {
+		allErrs = append(allErrs, validateShieldedInstanceConfig(p, fldPath.Child("shieldedInstanceConfig"))...)
+	}
+	return allErrs
+}
+
+// validateShieldedInstanceConfig checks the Shielded VM options of the
+// machine pool.
+func validateShieldedInstanceConfig(p *gcp.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	c := p.ShieldedInstanceConfig
+	allErrs = append(allErrs, validatePolicy(c.SecureBoot, fldPath.Child("secureBoot"))...)
+	allErrs = append(allErrs, validatePolicy(c.VirtualizedTrustedPlatformModule, fldPath.Child("virtualizedTrustedPlatformModule"))...)
+	allErrs = append(allErrs, validatePolicy(c.IntegrityMonitoring, fldPath.Child("integrityMonitoring"))...)
+	if c.SecureBoot != "" && p.SecureBoot != "" && c.SecureBoot != p.SecureBoot {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("secureBoot"), c.SecureBoot, fmt.Sprintf("must match the secureBoot of the machine pool (%s)", p.SecureBoot)))
+	}
+	if c.IntegrityMonitoring != "Disabled" && c.VirtualizedTrustedPlatformModule == "Disabled" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("virtualizedTrustedPlatformModule"), c.VirtualizedTrustedPlatformModule, "integrityMonitoring must be Disabled when virtualizedTrustedPlatformModule is Disabled"))
+	}
 	return allErrs
 }
 
+// ValidateConfidentialCompute checks that a machine pool with confidential
+// computing enabled terminates its instances on host maintenance and uses a
+// machine type which supports confidential computing. The machine pool must
+// be the one applied to the machines, with the defaults of the platform.
+func ValidateConfidentialCompute(p *gcp.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.ConfidentialCompute != "Enabled" {
+		return allErrs
+	}
+	if p.OnHostMaintenance != "Terminate" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("onHostMaintenance"), p.OnHostMaintenance, "onHostMaintenance must be set to Terminate when confidentialCompute is Enabled"))
+	}
+	families := strings.Join(confidentialComputeMachineFamilies.List(), ", ")
+	if p.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), fmt.Sprintf("an instance type of a machine family supporting confidential computing (%s) is required when confidentialCompute is Enabled", families)))
+	} else if family := strings.SplitN(p.InstanceType, "-", 2)[0]; !confidentialComputeMachineFamilies.Has(family) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), p.InstanceType, fmt.Sprintf("machine family %s does not support confidential computing, use one of %s", family, families)))
+	}
+	return allErrs
+}
+
+func validatePolicy(value string, fldPath *field.Path) field.ErrorList {
+	if value == "" || policyValues.Has(value) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(fldPath, value, policyValues.List())}
+}
+
 // ValidateMasterDiskType checks that the specified disk type is valid for control plane.
 func ValidateMasterDiskType(p *types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -85,6 +85,43 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.diskSizeGB: Invalid value: 66000: exceeding maximum GCP disk size limit, must be below 65536$`,
 		},
+		{
+			name: "invalid on host maintenance",
+			pool: &gcp.MachinePool{
+				OnHostMaintenance: "Restart",
+			},
+			expected: `^test-path\.onHostMaintenance: Unsupported value: "Restart": supported values: "Migrate", "Terminate"$`,
+		},
+		{
+			name: "valid shielded instance config",
+			pool: &gcp.MachinePool{
+				SecureBoot: "Enabled",
+				ShieldedInstanceConfig: &gcp.ShieldedInstanceConfig{
+					SecureBoot:                       "Enabled",
+					VirtualizedTrustedPlatformModule: "Enabled",
+					IntegrityMonitoring:              "Enabled",
+				},
+			},
+		},
+		{
+			name: "conflicting secure boot",
+			pool: &gcp.MachinePool{
+				SecureBoot: "Enabled",
+				ShieldedInstanceConfig: &gcp.ShieldedInstanceConfig{
+					SecureBoot: "Disabled",
+				},
+			},
+			expected: `^test-path\.shieldedInstanceConfig\.secureBoot: Invalid value: "Disabled": must match the secureBoot of the machine pool \(Enabled\)$`,
+		},
+		{
+			name: "integrity monitoring without vTPM",
+			pool: &gcp.MachinePool{
+				ShieldedInstanceConfig: &gcp.ShieldedInstanceConfig{
+					VirtualizedTrustedPlatformModule: "Disabled",
+				},
+			},
+			expected: `^test-path\.shieldedInstanceConfig\.virtualizedTrustedPlatformModule: Invalid value: "Disabled": integrityMonitoring must be Disabled when virtualizedTrustedPlatformModule is Disabled$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -97,3 +134,59 @@ func TestValidateMachinePool(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfidentialCompute(t *testing.T) {
+	cases := []struct {
+		name     string
+		pool     *gcp.MachinePool
+		expected string
+	}{
+		{
+			name: "disabled",
+			pool: &gcp.MachinePool{InstanceType: "n2-standard-4"},
+		},
+		{
+			name: "valid",
+			pool: &gcp.MachinePool{
+				InstanceType:        "n2d-standard-4",
+				ConfidentialCompute: "Enabled",
+				OnHostMaintenance:   "Terminate",
+			},
+		},
+		{
+			name: "migrate on host maintenance",
+			pool: &gcp.MachinePool{
+				InstanceType:        "c2d-standard-4",
+				ConfidentialCompute: "Enabled",
+			},
+			expected: `^test-path\.onHostMaintenance: Invalid value: "": onHostMaintenance must be set to Terminate when confidentialCompute is Enabled$`,
+		},
+		{
+			name: "unsupported machine family",
+			pool: &gcp.MachinePool{
+				InstanceType:        "n2-standard-4",
+				ConfidentialCompute: "Enabled",
+				OnHostMaintenance:   "Terminate",
+			},
+			expected: `^test-path\.type: Invalid value: "n2-standard-4": machine family n2 does not support confidential computing, use one of c2d, n2d$`,
+		},
+		{
+			name: "default machine type",
+			pool: &gcp.MachinePool{
+				ConfidentialCompute: "Enabled",
+				OnHostMaintenance:   "Terminate",
+			},
+			expected: `^test-path\.type: Required value: an instance type of a machine family supporting confidential computing \(c2d, n2d\) is required when confidentialCompute is Enabled$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfidentialCompute(tc.pool, field.NewPath("test-path")).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
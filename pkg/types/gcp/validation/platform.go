@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/validate"
@@ -108,5 +109,24 @@ func ValidatePlatform(p *gcp.Platform, fldPath *field.Path, ic *types.InstallCon
 		}
 	}
 
+	if p.LoadBalancer != nil {
+		allErrs = append(allErrs, validateLoadBalancer(p, fldPath)...)
+	}
+	return allErrs
+}
+
+// validateLoadBalancer validates the type of the API load balancers, which
+// can only be user managed in an existing network.
+func validateLoadBalancer(p *gcp.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.LoadBalancer.Type {
+	case "", configv1.LoadBalancerTypeOpenShiftManagedDefault:
+	case configv1.LoadBalancerTypeUserManaged:
+		if p.Network == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("network"), "network is required with user-managed load balancers"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("loadBalancer", "type"), p.LoadBalancer.Type, []string{string(configv1.LoadBalancerTypeOpenShiftManagedDefault), string(configv1.LoadBalancerTypeUserManaged)}))
+	}
 	return allErrs
 }
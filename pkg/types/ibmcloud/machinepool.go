@@ -16,6 +16,13 @@ type MachinePool struct {
 	// DedicatedHosts is the configuration for the machine's dedicated host and profile.
 	// +optional
 	DedicatedHosts []DedicatedHost `json:"dedicatedHosts,omitempty"`
+
+	// PlacementGroup is the configuration for the placement group of the
+	// machines, which spreads them across hosts or power domains. It is
+	// supported for the control plane machines only, and cannot be combined
+	// with dedicated hosts.
+	// +optional
+	PlacementGroup *PlacementGroup `json:"placementGroup,omitempty"`
 }
 
 // BootVolume stores the configuration for an individual machine's boot volume.
@@ -40,6 +47,33 @@ type DedicatedHost struct {
 	Profile string `json:"profile,omitempty"`
 }
 
+// PlacementGroupStrategy is the strategy of a placement group.
+// +kubebuilder:validation:Enum=host_spread;power_spread
+type PlacementGroupStrategy string
+
+const (
+	// PlacementGroupStrategyHostSpread places the machines on different
+	// hosts.
+	PlacementGroupStrategyHostSpread PlacementGroupStrategy = "host_spread"
+
+	// PlacementGroupStrategyPowerSpread places the machines on hosts with
+	// different power sources.
+	PlacementGroupStrategyPowerSpread PlacementGroupStrategy = "power_spread"
+)
+
+// PlacementGroup stores the configuration for the machine's placement group.
+type PlacementGroup struct {
+	// Name is the name of a pre-existing placement group to provision the
+	// machines in.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Strategy is the strategy of a new placement group created for the
+	// machines. It is used when Name is not specified.
+	// +optional
+	Strategy PlacementGroupStrategy `json:"strategy,omitempty"`
+}
+
 // Set sets the values from `required` to `a`.
 func (a *MachinePool) Set(required *MachinePool) {
 	if required == nil || a == nil {
@@ -66,4 +100,8 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if len(required.DedicatedHosts) > 0 {
 		a.DedicatedHosts = required.DedicatedHosts
 	}
+
+	if required.PlacementGroup != nil {
+		a.PlacementGroup = required.PlacementGroup
+	}
 }
@@ -30,6 +30,39 @@ func ValidateMachinePool(platform *ibmcloud.Platform, mp *ibmcloud.MachinePool,
 	if mp.BootVolume != nil {
 		allErrs = append(allErrs, validateBootVolume(mp.BootVolume, path.Child("bootVolume"))...)
 	}
+
+	if mp.PlacementGroup != nil {
+		allErrs = append(allErrs, validatePlacementGroup(mp.PlacementGroup, path.Child("placementGroup"))...)
+
+		if len(mp.DedicatedHosts) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("placementGroup"), "placementGroup cannot be combined with dedicatedHosts"))
+		}
+	}
+	return allErrs
+}
+
+var validPlacementGroupStrategies = []string{
+	string(ibmcloud.PlacementGroupStrategyHostSpread),
+	string(ibmcloud.PlacementGroupStrategyPowerSpread),
+}
+
+func validatePlacementGroup(pg *ibmcloud.PlacementGroup, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Placement group name or strategy is required, but not both
+	switch {
+	case pg.Name == "" && pg.Strategy == "":
+		allErrs = append(allErrs, field.Required(path, "name or strategy must be set"))
+	case pg.Name != "" && pg.Strategy != "":
+		allErrs = append(allErrs, field.Invalid(path.Child("strategy"), pg.Strategy, "strategy cannot be set with the name of a pre-existing placement group"))
+	case pg.Strategy != "":
+		switch pg.Strategy {
+		case ibmcloud.PlacementGroupStrategyHostSpread, ibmcloud.PlacementGroupStrategyPowerSpread:
+		default:
+			allErrs = append(allErrs, field.NotSupported(path.Child("strategy"), pg.Strategy, validPlacementGroupStrategies))
+		}
+	}
+
 	return allErrs
 }
 
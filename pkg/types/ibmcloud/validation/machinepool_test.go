@@ -167,6 +167,69 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid placementGroup name",
+			machinepool: &ibmcloud.MachinePool{
+				PlacementGroup: &ibmcloud.PlacementGroup{
+					Name: "name",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid placementGroup strategy",
+			machinepool: &ibmcloud.MachinePool{
+				PlacementGroup: &ibmcloud.PlacementGroup{
+					Strategy: ibmcloud.PlacementGroupStrategyPowerSpread,
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid placementGroup empty",
+			machinepool: &ibmcloud.MachinePool{
+				PlacementGroup: &ibmcloud.PlacementGroup{},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid placementGroup name and strategy",
+			machinepool: &ibmcloud.MachinePool{
+				PlacementGroup: &ibmcloud.PlacementGroup{
+					Name:     "name",
+					Strategy: ibmcloud.PlacementGroupStrategyHostSpread,
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid placementGroup strategy",
+			machinepool: &ibmcloud.MachinePool{
+				PlacementGroup: &ibmcloud.PlacementGroup{
+					Strategy: "rack_spread",
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid placementGroup with dedicatedHosts",
+			machinepool: &ibmcloud.MachinePool{
+				Zones: validZones,
+				DedicatedHosts: []ibmcloud.DedicatedHost{
+					{
+						Name: "name",
+					},
+					{
+						Name: "name",
+					},
+				},
+				InstanceType: validType,
+				PlacementGroup: &ibmcloud.PlacementGroup{
+					Name: "name",
+				},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
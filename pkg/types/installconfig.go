@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -51,6 +52,7 @@ var (
 	// to the user in the interactive wizard.
 	HiddenPlatformNames = []string{
 		baremetal.Name,
+		external.Name,
 		none.Name,
 	}
 
@@ -132,6 +134,11 @@ type InstallConfig struct {
 	// PullSecret is the secret to use when pulling images.
 	PullSecret string `json:"pullSecret"`
 
+	// PullSecretFile is the path of a file containing the pull secret, which
+	// is read instead of setting PullSecret in the install config.
+	// +optional
+	PullSecretFile string `json:"pullSecretFile,omitempty"`
+
 	// Proxy defines the proxy settings for the cluster.
 	// If unset, the cluster will not be configured to use a proxy.
 	// +optional
@@ -141,6 +148,26 @@ type InstallConfig struct {
 	// +optional
 	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
 
+	// ImageMirrorResults configures the image mirrors from the results of oc-mirror.
+	// The mirrors are added to the bootstrap registries and to the
+	// ImageDigestMirrorSet manifests of the cluster.
+	// +optional
+	ImageMirrorResults *ImageMirrorResults `json:"imageMirrorResults,omitempty"`
+
+	// CoreOSStream overrides the RHCOS stream metadata embedded in the
+	// installer, which selects the boot images of the cluster machines, and
+	// pins the RHCOS build of the boot images.
+	// +optional
+	CoreOSStream *CoreOSStream `json:"coreOSStream,omitempty"`
+
+	// ExtraManifestsDir is the path of a directory of day-0 manifests, which
+	// are validated and added to the manifests of the cluster. The files in
+	// the root of the directory and in its openshift subdirectory are added to
+	// the openshift manifests, and the files in its manifests subdirectory to
+	// the manifests. The --extra-manifests-dir flag overrides it.
+	// +optional
+	ExtraManifestsDir string `json:"extraManifestsDir,omitempty"`
+
 	// Publish controls how the user facing endpoints of the cluster like the Kubernetes API, OpenShift routes etc. are exposed.
 	// When no strategy is specified, the strategy is "External".
 	//
@@ -199,6 +226,18 @@ type InstallConfig struct {
 	// FeatureSet enables features that are not part of the default feature set.
 	// +optional
 	FeatureSet configv1.FeatureSet `json:"featureSet,omitempty"`
+
+	// NodeCustomization declares additional files, systemd units and kernel
+	// arguments for the bootstrap, control plane and compute nodes.
+	// +optional
+	NodeCustomization *NodeCustomization `json:"nodeCustomization,omitempty"`
+
+	// PointerIgnition customizes the location and the TLS trust of the
+	// machine config server in the pointer Ignition configs of the control
+	// plane and compute machines, e.g. when the machines reach it through an
+	// L7 proxy.
+	// +optional
+	PointerIgnition *PointerIgnition `json:"pointerIgnition,omitempty"`
 }
 
 // ClusterDomain returns the DNS domain that all records for a cluster must belong to.
@@ -221,12 +260,36 @@ func (c *InstallConfig) IsOKD() bool {
 	return c.IsFCOS() || c.IsSCOS()
 }
 
+// UserManagedCloudLoadBalancer returns true if the load balancers of the API
+// of a cluster on AWS, Azure, GCP or Power VS are provisioned by the user.
+func (c *InstallConfig) UserManagedCloudLoadBalancer() bool {
+	switch {
+	case c.Platform.AWS != nil:
+		return c.Platform.AWS.UserManagedLoadBalancer()
+	case c.Platform.Azure != nil:
+		return c.Platform.Azure.UserManagedLoadBalancer()
+	case c.Platform.GCP != nil:
+		return c.Platform.GCP.UserManagedLoadBalancer()
+	case c.Platform.PowerVS != nil:
+		return c.Platform.PowerVS.UserManagedLoadBalancer()
+	default:
+		return false
+	}
+}
+
 // IsSingleNodeOpenShift returns true if the install-config has been configured for
 // bootstrapInPlace
 func (c *InstallConfig) IsSingleNodeOpenShift() bool {
 	return c.BootstrapInPlace != nil
 }
 
+// IsBootstrapInPlaceIPI returns true if the single node of a bootstrapInPlace
+// install-config is provisioned by the installer, which then creates no
+// bootstrap machine.
+func (c *InstallConfig) IsBootstrapInPlaceIPI() bool {
+	return c.BootstrapInPlace != nil && (c.Platform.IBMCloud != nil || c.Platform.PowerVS != nil)
+}
+
 // CPUPartitioningMode defines how the nodes should be setup for partitioning the CPU Sets.
 // +kubebuilder:validation:Enum=None;AllNodes
 type CPUPartitioningMode string
@@ -257,6 +320,11 @@ type Platform struct {
 	// +optional
 	BareMetal *baremetal.Platform `json:"baremetal,omitempty"`
 
+	// External is the configuration used when installing on infrastructure
+	// integrated by a third-party provider.
+	// +optional
+	External *external.Platform `json:"external,omitempty"`
+
 	// GCP is the configuration used when installing on Google Cloud Platform.
 	// +optional
 	GCP *gcp.Platform `json:"gcp,omitempty"`
@@ -309,6 +377,8 @@ func (p *Platform) Name() string {
 		return azure.Name
 	case p.BareMetal != nil:
 		return baremetal.Name
+	case p.External != nil:
+		return external.Name
 	case p.GCP != nil:
 		return gcp.Name
 	case p.IBMCloud != nil:
@@ -365,6 +435,13 @@ type Networking struct {
 	// +optional
 	ServiceNetwork []ipnet.IPNet `json:"serviceNetwork,omitempty"`
 
+	// OVNKubernetesConfig is the configuration of the OVNKubernetes network
+	// plugin, written to the configuration of the cluster network operator.
+	// It is only used with the OVNKubernetes network type.
+	//
+	// +optional
+	OVNKubernetesConfig *OVNKubernetesConfig `json:"ovnKubernetesConfig,omitempty"`
+
 	// Deprecated types, scheduled to be removed
 
 	// Deprecated way to configure an IP address pool for machines.
@@ -386,6 +463,49 @@ type Networking struct {
 	DeprecatedClusterNetworks []ClusterNetworkEntry `json:"clusterNetworks,omitempty"`
 }
 
+// OVNKubernetesConfig is the configuration of the OVNKubernetes network plugin
+// set at install time.
+type OVNKubernetesConfig struct {
+	// MTU is the MTU of the pod network. It must be smaller than the MTU of
+	// the machine network by at least the 100 bytes of the Geneve
+	// encapsulation. The default is the MTU of the machine network, detected
+	// by the cluster network operator, minus 100.
+	//
+	// +kubebuilder:validation:Minimum=576
+	// +optional
+	MTU uint32 `json:"mtu,omitempty"`
+
+	// V4InternalSubnet is the IPv4 subnet used internally by OVN-Kubernetes
+	// for the join switch of the nodes. It must not overlap with the machine,
+	// service and cluster networks. The default is 100.64.0.0/16.
+	//
+	// +optional
+	V4InternalSubnet *ipnet.IPNet `json:"v4InternalSubnet,omitempty"`
+
+	// V6InternalSubnet is the IPv6 subnet used internally by OVN-Kubernetes
+	// for the join switch of the nodes. It must not overlap with the machine,
+	// service and cluster networks. The default is fd98::/64.
+	//
+	// +optional
+	V6InternalSubnet *ipnet.IPNet `json:"v6InternalSubnet,omitempty"`
+
+	// GatewayConfig is the configuration of the gateway of the nodes.
+	//
+	// +optional
+	GatewayConfig *OVNGatewayConfig `json:"gatewayConfig,omitempty"`
+}
+
+// OVNGatewayConfig is the configuration of the gateway of the nodes of
+// OVN-Kubernetes.
+type OVNGatewayConfig struct {
+	// RoutingViaHost sends the egress traffic of the pods through the routing
+	// table of the host instead of directly to the next hop. It is always
+	// enabled on Power VS.
+	//
+	// +optional
+	RoutingViaHost bool `json:"routingViaHost,omitempty"`
+}
+
 // MachineNetworkEntry is a single IP address block for node IP blocks.
 type MachineNetworkEntry struct {
 	// CIDR is the IP block address pool for machines within the cluster.
@@ -453,6 +573,54 @@ const (
 	PassthroughCredentialsMode CredentialsMode = "Passthrough"
 )
 
+// CoreOSStream selects the RHCOS boot images of the cluster machines.
+type CoreOSStream struct {
+	// Path is the path to a CoreOS stream metadata JSON file, as printed by
+	// openshift-install coreos print-stream-json, which replaces the stream
+	// embedded in the installer.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Release pins the RHCOS build of the boot images, e.g.
+	// 413.92.202305021736-0. The stream must provide the build for the
+	// platform and the architectures of the cluster.
+	// +optional
+	Release string `json:"release,omitempty"`
+}
+
+// PointerIgnition is the location of the machine config server, from which
+// the machines fetch their Ignition config, and the CA bundle which they
+// trust when fetching it.
+type PointerIgnition struct {
+	// URL is the base URL of the machine config server, e.g.
+	// https://mcs.example.com:22623, to which the path of the config of the
+	// role of the machine is appended. Its host is the SNI hostname of the
+	// TLS connection. The default is the internal API endpoint of the
+	// cluster on port 22623.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// CABundle is the PEM-encoded CA bundle which the machines trust, in
+	// addition to the root CA of the cluster, when fetching their config,
+	// e.g. the CA of the certificate served by the proxy.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// ImageMirrorResults are the results of mirroring the release and operator
+// images with oc-mirror.
+type ImageMirrorResults struct {
+	// Path is the path to the ImageContentSourcePolicy or ImageDigestMirrorSet
+	// results file written by oc-mirror, e.g.
+	// oc-mirror-workspace/results-1680000000/imageContentSourcePolicy.yaml.
+	Path string `json:"path"`
+
+	// RegistryCA is the PEM-encoded CA bundle of the mirror registry. It is
+	// added to the additionalTrustBundle, whose policy defaults to Always.
+	// +optional
+	RegistryCA string `json:"registryCA,omitempty"`
+}
+
 // BootstrapInPlace defines the configuration for bootstrap-in-place installation
 type BootstrapInPlace struct {
 	// InstallationDisk is the target disk drive for coreos-installer
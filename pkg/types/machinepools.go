@@ -73,6 +73,8 @@ type MachinePool struct {
 
 	// Architecture is the instruction set architecture of the machine pool.
 	// Defaults to amd64.
+	// Compute pools may use a different architecture than the control plane
+	// on AWS and GCP when the release payload is multi-arch.
 	//
 	// +kubebuilder:default=amd64
 	// +optional
@@ -0,0 +1,135 @@
+package types
+
+// NodeRole is the role of a node a customization applies to.
+// +kubebuilder:validation:Enum=bootstrap;master;worker
+type NodeRole string
+
+const (
+	// NodeRoleBootstrap is the bootstrap node.
+	NodeRoleBootstrap NodeRole = "bootstrap"
+	// NodeRoleMaster is the control plane nodes.
+	NodeRoleMaster NodeRole = "master"
+	// NodeRoleWorker is the compute nodes.
+	NodeRoleWorker NodeRole = "worker"
+)
+
+// NodeCustomization declares additional files, systemd units and kernel
+// arguments for the nodes, which are merged into the bootstrap and pointer
+// Ignition configs, and into the machine configs of the cluster.
+type NodeCustomization struct {
+	// Files are written to the nodes.
+	// +optional
+	Files []NodeFile `json:"files,omitempty"`
+
+	// Units are systemd units installed on the nodes.
+	// +optional
+	Units []NodeUnit `json:"units,omitempty"`
+
+	// KernelArguments are appended to the kernel command line of the
+	// control plane and compute nodes.
+	// +optional
+	KernelArguments []NodeKernelArgument `json:"kernelArguments,omitempty"`
+}
+
+// NodeFile is a file written to the nodes.
+type NodeFile struct {
+	// Roles are the roles of the nodes the file is written to.
+	// The default is all roles.
+	// +optional
+	Roles []NodeRole `json:"roles,omitempty"`
+
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+
+	// Mode is the permission mode of the file, in decimal (e.g. 420 for 0644).
+	// The default is 420.
+	// +optional
+	Mode *int `json:"mode,omitempty"`
+
+	// Contents is the content of the file.
+	Contents string `json:"contents"`
+}
+
+// NodeUnit is a systemd unit installed on the nodes.
+type NodeUnit struct {
+	// Roles are the roles of the nodes the unit is installed on.
+	// The default is all roles.
+	// +optional
+	Roles []NodeRole `json:"roles,omitempty"`
+
+	// Name is the name of the unit, including its type suffix (e.g. foo.service).
+	Name string `json:"name"`
+
+	// Enabled enables the unit.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Contents is the content of the unit.
+	Contents string `json:"contents"`
+}
+
+// NodeKernelArgument is a kernel argument of the nodes.
+type NodeKernelArgument struct {
+	// Roles are the roles of the nodes the argument is set on. The bootstrap
+	// node is not supported. The default is the master and worker roles.
+	// +optional
+	Roles []NodeRole `json:"roles,omitempty"`
+
+	// Argument is the kernel argument, e.g. "nosmt" or "console=ttyS0".
+	Argument string `json:"argument"`
+}
+
+// appliesTo returns true if the roles include the role, or are empty.
+func appliesTo(roles []NodeRole, role NodeRole) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// FilesFor returns the files of the nodes with the role.
+func (c *NodeCustomization) FilesFor(role NodeRole) []NodeFile {
+	if c == nil {
+		return nil
+	}
+	var files []NodeFile
+	for _, f := range c.Files {
+		if appliesTo(f.Roles, role) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// UnitsFor returns the systemd units of the nodes with the role.
+func (c *NodeCustomization) UnitsFor(role NodeRole) []NodeUnit {
+	if c == nil {
+		return nil
+	}
+	var units []NodeUnit
+	for _, u := range c.Units {
+		if appliesTo(u.Roles, role) {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+// KernelArgumentsFor returns the kernel arguments of the nodes with the role.
+func (c *NodeCustomization) KernelArgumentsFor(role NodeRole) []string {
+	if c == nil || role == NodeRoleBootstrap {
+		return nil
+	}
+	var args []string
+	for _, a := range c.KernelArguments {
+		if appliesTo(a.Roles, role) {
+			args = append(args, a.Argument)
+		}
+	}
+	return args
+}
@@ -54,6 +54,11 @@ type MachinePool struct {
 	// +listMapKey=key
 	// +optional
 	Categories []machinev1.NutanixCategory `json:"categories,omitempty"`
+
+	// FailureDomains are the names of the failure domains of the platform the
+	// machines of the pool are spread across. The default is all of them.
+	// +optional
+	FailureDomains []string `json:"failureDomains,omitempty"`
 }
 
 // OSDisk defines the disk for a virtual machine.
@@ -97,6 +102,10 @@ func (p *MachinePool) Set(required *MachinePool) {
 	if len(required.Categories) > 0 {
 		p.Categories = required.Categories
 	}
+
+	if len(required.FailureDomains) > 0 {
+		p.FailureDomains = required.FailureDomains
+	}
 }
 
 // ValidateConfig validates the MachinePool configuration.
@@ -1,7 +1,10 @@
 package nutanix
 
 import (
+	"fmt"
+
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/types/secretref"
 )
 
 // Platform stores any global configuration used for Nutanix platforms.
@@ -65,6 +68,38 @@ type Platform struct {
 	// LoadBalancer is available in TechPreview.
 	// +optional
 	LoadBalancer *configv1.NutanixPlatformLoadBalancer `json:"loadBalancer,omitempty"`
+
+	// FailureDomains configures the failure domains of the cluster. Each failure
+	// domain is a Prism Element with its subnet. The machines of a pool are spread
+	// across the failure domains of the pool, or across all of them if the pool
+	// does not set any.
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+}
+
+// FailureDomain is a Prism Element (cluster) and its subnet, which the
+// machines of the cluster can be spread across.
+type FailureDomain struct {
+	// Name is the unique name of the failure domain, which the machine pools
+	// refer to.
+	Name string `json:"name"`
+
+	// PrismElement is the Prism Element (cluster) of the failure domain.
+	PrismElement PrismElement `json:"prismElement"`
+
+	// SubnetUUIDs identifies the network subnets of the failure domain.
+	// Currently we only support one subnet for a failure domain.
+	SubnetUUIDs []string `json:"subnetUUIDs"`
+}
+
+// GetFailureDomainByName returns the failure domain with the name.
+func (p *Platform) GetFailureDomainByName(name string) (*FailureDomain, error) {
+	for i := range p.FailureDomains {
+		if p.FailureDomains[i].Name == name {
+			return &p.FailureDomains[i], nil
+		}
+	}
+	return nil, fmt.Errorf("failure domain %q is not defined", name)
 }
 
 // PrismCentral holds the endpoint and credentials data used to connect to the Prism Central
@@ -77,6 +112,11 @@ type PrismCentral struct {
 
 	// Password is the password for the user to connect to the Prism Central
 	Password string `json:"password"`
+
+	// PasswordRef references the password stored outside of the install
+	// config, instead of setting Password.
+	// +optional
+	PasswordRef *secretref.Reference `json:"passwordRef,omitempty"`
 }
 
 // PrismElement holds the uuid, endpoint of the Prism Element (cluster)
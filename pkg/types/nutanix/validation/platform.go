@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -45,25 +46,7 @@ func ValidatePlatform(p *nutanix.Platform, fldPath *field.Path, c *types.Install
 	}
 
 	for _, pe := range p.PrismElements {
-		if len(pe.UUID) == 0 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("prismElements").Child("uuid"),
-				"must specify the Prism Element UUID"))
-		}
-
-		if len(pe.Endpoint.Address) == 0 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("prismElements").Child("endpoint").Child("address"),
-				"must specify the Prism Element endpoint address"))
-		} else {
-			if err := validate.Host(pe.Endpoint.Address); err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("prismElements").Child("endpoint").Child("address"),
-					pe.Endpoint.Address, "must be the domain name or IP address of the Prism Element (cluster)"))
-			}
-		}
-
-		if pe.Endpoint.Port < 1 || pe.Endpoint.Port > 65535 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("prismElements").Child("endpoint").Child("port"),
-				"The Prism Element endpoint port is invalid, must be in the range of 1 to 65535"))
-		}
+		allErrs = append(allErrs, validatePrismElement(pe, fldPath.Child("prismElements"))...)
 	}
 
 	// Currently we only support one subnet for an OpenShift cluster
@@ -71,6 +54,8 @@ func ValidatePlatform(p *nutanix.Platform, fldPath *field.Path, c *types.Install
 		allErrs = append(allErrs, field.Required(fldPath.Child("subnet"), "must specify the subnet"))
 	}
 
+	allErrs = append(allErrs, validateFailureDomains(p, fldPath.Child("failureDomains"), c)...)
+
 	// Platform fields only allowed in TechPreviewNoUpgrade
 	if c.FeatureSet != configv1.TechPreviewNoUpgrade {
 		if c.Nutanix.LoadBalancer != nil {
@@ -87,6 +72,81 @@ func ValidatePlatform(p *nutanix.Platform, fldPath *field.Path, c *types.Install
 	return allErrs
 }
 
+// validatePrismElement checks the UUID and endpoint of a Prism Element.
+func validatePrismElement(pe nutanix.PrismElement, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(pe.UUID) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("uuid"),
+			"must specify the Prism Element UUID"))
+	}
+
+	if len(pe.Endpoint.Address) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("endpoint").Child("address"),
+			"must specify the Prism Element endpoint address"))
+	} else {
+		if err := validate.Host(pe.Endpoint.Address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint").Child("address"),
+				pe.Endpoint.Address, "must be the domain name or IP address of the Prism Element (cluster)"))
+		}
+	}
+
+	if pe.Endpoint.Port < 1 || pe.Endpoint.Port > 65535 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("endpoint").Child("port"),
+			"The Prism Element endpoint port is invalid, must be in the range of 1 to 65535"))
+	}
+
+	return allErrs
+}
+
+// validateFailureDomains checks the failure domains, and that the machine
+// pools only refer to failure domains that are defined.
+func validateFailureDomains(p *nutanix.Platform, fldPath *field.Path, c *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, fd := range p.FailureDomains {
+		fdPath := fldPath.Index(i)
+		if err := validate.ClusterName1035(fd.Name); err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("name"), fd.Name, err.Error()))
+		} else if names.Has(fd.Name) {
+			allErrs = append(allErrs, field.Duplicate(fdPath.Child("name"), fd.Name))
+		}
+		names.Insert(fd.Name)
+
+		if len(fd.PrismElement.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("prismElement", "name"),
+				"must specify the Prism Element name"))
+		}
+		allErrs = append(allErrs, validatePrismElement(fd.PrismElement, fdPath.Child("prismElement"))...)
+
+		// Currently we only support one subnet for a failure domain
+		if len(fd.SubnetUUIDs) != 1 || len(fd.SubnetUUIDs[0]) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("subnetUUIDs"), "must specify the subnet"))
+		}
+	}
+
+	validatePool := func(pool *nutanix.MachinePool, poolPath *field.Path) {
+		if pool == nil {
+			return
+		}
+		for _, name := range pool.FailureDomains {
+			if !names.Has(name) {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("failureDomains"), name, "failure domain not defined in platform.nutanix.failureDomains"))
+			}
+		}
+	}
+	validatePool(p.DefaultMachinePlatform, field.NewPath("platform", "nutanix", "defaultMachinePlatform"))
+	if c.ControlPlane != nil {
+		validatePool(c.ControlPlane.Platform.Nutanix, field.NewPath("controlPlane", "platform", "nutanix"))
+	}
+	for i, compute := range c.Compute {
+		validatePool(compute.Platform.Nutanix, field.NewPath("compute").Index(i).Child("platform", "nutanix"))
+	}
+
+	return allErrs
+}
+
 // validateLoadBalancer returns an error if the load balancer is not valid.
 func validateLoadBalancer(lbType configv1.PlatformLoadBalancerType) bool {
 	switch lbType {
@@ -26,6 +26,26 @@ func validPlatform() *nutanix.Platform {
 	}
 }
 
+func validFailureDomains() []nutanix.FailureDomain {
+	return []nutanix.FailureDomain{{
+		Name: "fd-1",
+		PrismElement: nutanix.PrismElement{
+			UUID:     "test-pe-uuid-1",
+			Name:     "test-pe-1",
+			Endpoint: nutanix.PrismEndpoint{Address: "test-pe-1", Port: 9440},
+		},
+		SubnetUUIDs: []string{"b06179c8-dea3-4f8e-818a-b2e88fbc2201"},
+	}, {
+		Name: "fd-2",
+		PrismElement: nutanix.PrismElement{
+			UUID:     "test-pe-uuid-2",
+			Name:     "test-pe-2",
+			Endpoint: nutanix.PrismEndpoint{Address: "test-pe-2", Port: 9440},
+		},
+		SubnetUUIDs: []string{"c2a7e5f1-6b8d-4f3e-9a1c-5d4e3f2a1b0c"},
+	}}
+}
+
 func TestValidatePlatform(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -183,6 +203,45 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			expectedError: `^test-path\.prismCentral\.endpoint\.address: Invalid value: "https://test-pc": must be the domain name or IP address of the Prism Central$`,
 		},
+		{
+			name: "failure domains",
+			platform: func() *nutanix.Platform {
+				p := validPlatform()
+				p.FailureDomains = validFailureDomains()
+				p.DefaultMachinePlatform = &nutanix.MachinePool{FailureDomains: []string{"fd-2"}}
+				return p
+			}(),
+		},
+		{
+			name: "duplicate failure domain name",
+			platform: func() *nutanix.Platform {
+				p := validPlatform()
+				p.FailureDomains = validFailureDomains()
+				p.FailureDomains[1].Name = "fd-1"
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[1\]\.name: Duplicate value: "fd-1"$`,
+		},
+		{
+			name: "missing failure domain subnet",
+			platform: func() *nutanix.Platform {
+				p := validPlatform()
+				p.FailureDomains = validFailureDomains()
+				p.FailureDomains[0].SubnetUUIDs = nil
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[0\]\.subnetUUIDs: Required value: must specify the subnet$`,
+		},
+		{
+			name: "undefined failure domain in machine pool",
+			platform: func() *nutanix.Platform {
+				p := validPlatform()
+				p.FailureDomains = validFailureDomains()
+				p.DefaultMachinePlatform = &nutanix.MachinePool{FailureDomains: []string{"fd-3"}}
+				return p
+			}(),
+			expectedError: `^platform\.nutanix\.defaultMachinePlatform\.failureDomains: Invalid value: "fd-3": failure domain not defined in platform\.nutanix\.failureDomains$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
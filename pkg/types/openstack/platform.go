@@ -119,6 +119,13 @@ type Platform struct {
 	// +optional
 	MachinesSubnet string `json:"machinesSubnet,omitempty"`
 
+	// ProviderNetwork is the name or UUID of an existing flat or VLAN provider network. When set, the nodes are attached
+	// directly to the provider network, through the subnet specified in MachinesSubnet, instead of to a tenant network
+	// behind a router. No router and no floating IPs are created, so ExternalNetwork, APIFloatingIP and IngressFloatingIP
+	// must not be set.
+	// +optional
+	ProviderNetwork string `json:"providerNetwork,omitempty"`
+
 	// LoadBalancer defines how the load balancer used by the cluster is configured.
 	// LoadBalancer is available in TechPreview.
 	// +optional
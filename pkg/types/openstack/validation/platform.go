@@ -19,6 +19,8 @@ func ValidatePlatform(p *openstack.Platform, n *types.Networking, fldPath *field
 		}
 	}
 
+	allErrs = append(allErrs, validateProviderNetwork(p, fldPath)...)
+
 	allErrs = append(allErrs, ValidateMachinePool(p, p.DefaultMachinePlatform, "default", fldPath.Child("defaultMachinePlatform"))...)
 
 	if c.OpenStack.LoadBalancer != nil {
@@ -30,6 +32,29 @@ func ValidatePlatform(p *openstack.Platform, n *types.Networking, fldPath *field
 	return allErrs
 }
 
+// validateProviderNetwork checks that the nodes attached to a provider network
+// are given a subnet of it and are not exposed through floating IPs.
+func validateProviderNetwork(p *openstack.Platform, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if p.ProviderNetwork == "" {
+		return allErrs
+	}
+
+	if p.MachinesSubnet == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("machinesSubnet"), "machinesSubnet must be set to a subnet of the provider network when providerNetwork is set"))
+	}
+	if p.ExternalNetwork != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("externalNetwork"), "externalNetwork cannot be set when providerNetwork is set"))
+	}
+	if p.APIFloatingIP != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("apiFloatingIP"), "floating IPs cannot be used when providerNetwork is set"))
+	}
+	if p.IngressFloatingIP != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingressFloatingIP"), "floating IPs cannot be used when providerNetwork is set"))
+	}
+	return allErrs
+}
+
 // validateLoadBalancer returns an error if the load balancer is not valid.
 func validateLoadBalancer(lbType configv1.PlatformLoadBalancerType) bool {
 	switch lbType {
@@ -128,6 +128,44 @@ func TestValidatePlatform(t *testing.T) {
 			networking: validNetworking(),
 			valid:      true,
 		},
+		{
+			name: "valid provider network",
+			platform: func() *openstack.Platform {
+				p := validPlatform()
+				p.ExternalNetwork = ""
+				p.ProviderNetwork = "provider-vlan"
+				p.MachinesSubnet = "031a5b9d-5a89-4465-8d54-3517ec2bad48"
+				return p
+			}(),
+			networking: validNetworking(),
+			valid:      true,
+		},
+		{
+			name: "provider network without machines subnet",
+			platform: func() *openstack.Platform {
+				p := validPlatform()
+				p.ExternalNetwork = ""
+				p.ProviderNetwork = "provider-vlan"
+				return p
+			}(),
+			networking:    validNetworking(),
+			valid:         false,
+			expectedError: `^test-path\.machinesSubnet: Required value: machinesSubnet must be set to a subnet of the provider network when providerNetwork is set$`,
+		},
+		{
+			name: "provider network with external network and floating IPs",
+			platform: func() *openstack.Platform {
+				p := validPlatform()
+				p.ProviderNetwork = "provider-vlan"
+				p.MachinesSubnet = "031a5b9d-5a89-4465-8d54-3517ec2bad48"
+				p.APIFloatingIP = "128.35.27.8"
+				p.IngressFloatingIP = "128.35.27.13"
+				return p
+			}(),
+			networking:    validNetworking(),
+			valid:         false,
+			expectedError: `^\[test-path\.externalNetwork: Forbidden: externalNetwork cannot be set when providerNetwork is set, test-path\.apiFloatingIP: Forbidden: floating IPs cannot be used when providerNetwork is set, test-path\.ingressFloatingIP: Forbidden: floating IPs cannot be used when providerNetwork is set\]$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,19 @@
+package defaults
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// MachinePool returns the default platform of the machine pools, which the
+// default machine platform and the platform of each pool override.
+func MachinePool() powervs.MachinePool {
+	return powervs.MachinePool{
+		MemoryGiB:  32,
+		Processors: intstr.FromString("0.5"),
+		ProcType:   machinev1.PowerVSProcessorTypeShared,
+		SysType:    "s922",
+	}
+}
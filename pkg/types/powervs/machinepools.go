@@ -1,6 +1,8 @@
 package powervs
 
 import (
+	"strconv"
+
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	machinev1 "github.com/openshift/api/machine/v1"
@@ -34,6 +36,93 @@ type MachinePool struct {
 	//
 	// +optional
 	SysType string `json:"sysType,omitempty"`
+
+	// SSHKeyName is the name of an existing SSH key of the workspace to
+	// access the instances. When the key does not exist in the workspace, the
+	// sshKey of the install config is uploaded as a key named after the infra
+	// ID instead, which is deleted with the cluster.
+	//
+	// +optional
+	SSHKeyName string `json:"sshKeyName,omitempty"`
+
+	// SMTLevel is the simultaneous multithreading level of the instances,
+	// i.e. the number of hardware threads per core, set with the
+	// smt-enabled kernel argument. Must be one of {off, 2, 4, 8}. When
+	// omitted, the instances run with SMT8.
+	//
+	// +kubebuilder:validation:Enum:="off";"2";"4";"8";""
+	// +optional
+	SMTLevel string `json:"smtLevel,omitempty"`
+
+	// SharedProcessorPool is the name or ID of an existing shared processor
+	// pool of the workspace, from which the instances draw their processors.
+	// The instances of a shared processor pool cannot use dedicated
+	// processors. Only supported for the control plane machine pool.
+	//
+	// +optional
+	SharedProcessorPool string `json:"sharedProcessorPool,omitempty"`
+
+	// ServerPlacementGroup is an existing server placement group of the
+	// workspace, which places the instances on the same host or on different
+	// hosts. Only supported for the control plane machine pool.
+	//
+	// +optional
+	ServerPlacementGroup *ServerPlacementGroup `json:"serverPlacementGroup,omitempty"`
+}
+
+// PlacementGroupPolicy is the policy of a server placement group.
+type PlacementGroupPolicy string
+
+const (
+	// PlacementGroupPolicyAffinity places the instances on the same host.
+	PlacementGroupPolicyAffinity PlacementGroupPolicy = "affinity"
+
+	// PlacementGroupPolicyAntiAffinity places the instances on different
+	// hosts.
+	PlacementGroupPolicyAntiAffinity PlacementGroupPolicy = "anti-affinity"
+)
+
+// ServerPlacementGroup identifies a server placement group of the workspace.
+type ServerPlacementGroup struct {
+	// Name is the name or ID of the placement group.
+	Name string `json:"name"`
+
+	// Policy is the expected policy of the placement group. When set, the
+	// existing placement group must have this policy.
+	//
+	// +kubebuilder:validation:Enum:="affinity";"anti-affinity";""
+	// +optional
+	Policy PlacementGroupPolicy `json:"policy,omitempty"`
+}
+
+const (
+	// SMTLevelOff disables simultaneous multithreading, with one hardware
+	// thread per core.
+	SMTLevelOff = "off"
+
+	// defaultSMTThreads is the number of hardware threads per core of the
+	// instances when the SMT level is omitted.
+	defaultSMTThreads = 8
+)
+
+// SMTLevels are the supported SMT levels.
+var SMTLevels = []string{SMTLevelOff, "2", "4", "8"}
+
+// SMTThreads returns the number of hardware threads per core of the SMT
+// level of a machine pool.
+func SMTThreads(level string) int {
+	switch level {
+	case "":
+		return defaultSMTThreads
+	case SMTLevelOff:
+		return 1
+	}
+	threads, err := strconv.Atoi(level)
+	if err != nil {
+		// Invalid levels are reported by the validation.
+		return defaultSMTThreads
+	}
+	return threads
 }
 
 // Set stores values from required into a
@@ -56,4 +145,16 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.SysType != "" {
 		a.SysType = required.SysType
 	}
+	if required.SSHKeyName != "" {
+		a.SSHKeyName = required.SSHKeyName
+	}
+	if required.SMTLevel != "" {
+		a.SMTLevel = required.SMTLevel
+	}
+	if required.SharedProcessorPool != "" {
+		a.SharedProcessorPool = required.SharedProcessorPool
+	}
+	if required.ServerPlacementGroup != nil {
+		a.ServerPlacementGroup = required.ServerPlacementGroup
+	}
 }
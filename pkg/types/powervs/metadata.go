@@ -1,5 +1,9 @@
 package powervs
 
+import (
+	configv1 "github.com/openshift/api/config/v1"
+)
+
 // Metadata contains Power VS metadata (e.g. for uninstalling the cluster).
 type Metadata struct {
 	BaseDomain           string `json:"BaseDomain"`
@@ -10,4 +14,11 @@ type Metadata struct {
 	VPCRegion            string `json:"vpcRegion"`
 	Zone                 string `json:"zone"`
 	ServiceInstanceGUID  string `json:"serviceInstanceID"`
+
+	// ServiceEndpoints are the custom endpoints of the IBM Cloud services.
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// UserTags are the user tags attached to the resources of the cluster,
+	// along with the infra ID.
+	UserTags []string `json:"userTags,omitempty"`
 }
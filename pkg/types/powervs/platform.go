@@ -1,11 +1,20 @@
 package powervs
 
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
 // Platform stores all the global configuration that all machinesets
 // use.
 type Platform struct {
 
-	// ServiceInstanceID is the ID of the Power IAAS instance created from the IBM Cloud Catalog
-	ServiceInstanceID string `json:"serviceInstanceID"`
+	// ServiceInstanceID is the ID of the Power IAAS instance created from the IBM Cloud Catalog.
+	// When omitted, the installer creates a workspace named after the cluster's infra ID in Zone and
+	// PowerVSResourceGroup, and deletes it when the cluster is destroyed.
+	// +optional
+	ServiceInstanceID string `json:"serviceInstanceID,omitempty"`
 
 	// PowerVSResourceGroup is the resource group in which Power VS resources will be created.
 	PowerVSResourceGroup string `json:"powervsResourceGroup"`
@@ -33,11 +42,19 @@ type Platform struct {
 
 	// VPCSubnets specifies existing subnets (by ID) where cluster
 	// resources will be created.  Leave unset to have the installer
-	// create subnets in a new VPC on your behalf.
+	// create subnets in a new VPC on your behalf. At most one subnet
+	// may be given per VPC zone.
 	//
 	// +optional
 	VPCSubnets []string `json:"vpcSubnets,omitempty"`
 
+	// VPCSecurityGroups specifies existing security groups (by name) of the
+	// VPC in VPCName which are attached to the cluster resources in the VPC.
+	// Leave unset to have the installer create them on your behalf.
+	//
+	// +optional
+	VPCSecurityGroups []string `json:"vpcSecurityGroups,omitempty"`
+
 	// PVSNetworkName specifies an existing network within the Power VS Service Instance.
 	//
 	// +optional
@@ -59,4 +76,124 @@ type Platform struct {
 	// If empty, one is created by the installer.
 	// +optional
 	CloudConnectionName string `json:"cloudConnectionName,omitempty"`
+
+	// TransitGatewayID is the ID of an existing Transit Gateway which connects
+	// the Power VS workspace to the VPC. If empty, one is created by the installer.
+	// +optional
+	TransitGatewayID string `json:"transitGatewayID,omitempty"`
+
+	// DNSInstanceCRN is the CRN of an existing IBM Cloud DNS Services instance
+	// which manages the private DNS zone of the base domain, when the cluster
+	// is published internally. If empty, the instance is looked up by the
+	// base domain among the DNS Services instances of the account.
+	// +optional
+	DNSInstanceCRN string `json:"dnsInstanceCRN,omitempty"`
+
+	// LoadBalancer defines how the load balancers of the API are provisioned.
+	// When its type is UserManaged, the installer does not create the VPC
+	// load balancers of the API nor their DNS records, which the user
+	// provisions ahead of the install.
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+
+	// ServiceEndpoints is a list which contains custom endpoints to override
+	// the default endpoints of the IBM Cloud services, e.g. in restricted
+	// environments. There must be only one ServiceEndpoint for a service.
+	// The names of the services are iam, resource-controller, power, vpc,
+	// cos, dns-services, transit-gateway and global-search.
+	// +optional
+	ServiceEndpoints []configv1.PowerVSServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// UserTags are the IBM Cloud user tags, e.g. env:prod, attached to the
+	// workspace, VPC, load balancer and Cloud Object Storage resources
+	// created by the installer, along with the infra ID of the cluster. The
+	// destroyer finds the resources of the cluster by these tags when it
+	// cannot find them by name.
+	// +optional
+	UserTags []string `json:"userTags,omitempty"`
+}
+
+// The names of the services whose endpoints can be overridden.
+const (
+	// IAMServiceEndpointName is the IAM token and identity service.
+	IAMServiceEndpointName = "iam"
+	// ResourceControllerServiceEndpointName is the Resource Controller
+	// service.
+	ResourceControllerServiceEndpointName = "resource-controller"
+	// PowerServiceEndpointName is the Power Cloud service.
+	PowerServiceEndpointName = "power"
+	// VPCServiceEndpointName is the VPC service.
+	VPCServiceEndpointName = "vpc"
+	// COSServiceEndpointName is the S3 API of Cloud Object Storage.
+	COSServiceEndpointName = "cos"
+	// DNSServicesServiceEndpointName is the DNS Services service.
+	DNSServicesServiceEndpointName = "dns-services"
+	// TransitGatewayServiceEndpointName is the Transit Gateway service.
+	TransitGatewayServiceEndpointName = "transit-gateway"
+	// GlobalSearchServiceEndpointName is the Global Search service, with
+	// which the destroyer finds the resources tagged with the user tags.
+	GlobalSearchServiceEndpointName = "global-search"
+)
+
+// ServiceEndpointNames are the names of the services whose endpoints can be
+// overridden.
+var ServiceEndpointNames = []string{
+	IAMServiceEndpointName,
+	ResourceControllerServiceEndpointName,
+	PowerServiceEndpointName,
+	VPCServiceEndpointName,
+	COSServiceEndpointName,
+	DNSServicesServiceEndpointName,
+	TransitGatewayServiceEndpointName,
+	GlobalSearchServiceEndpointName,
+}
+
+// ServiceEndpointURL returns the URL overriding the endpoint of the service
+// with the name, or "" when the default endpoint is used.
+func ServiceEndpointURL(endpoints []configv1.PowerVSServiceEndpoint, name string) string {
+	for _, endpoint := range endpoints {
+		if endpoint.Name == name {
+			return endpoint.URL
+		}
+	}
+	return ""
+}
+
+// LoadBalancer stores the configuration of the load balancers of the API.
+type LoadBalancer struct {
+	// Type is the type of the load balancers of the API.
+	// +kubebuilder:default=OpenShiftManagedDefault
+	// +kubebuilder:validation:Enum:="OpenShiftManagedDefault";"UserManaged"
+	// +optional
+	Type configv1.PlatformLoadBalancerType `json:"type,omitempty"`
+}
+
+// UserManagedLoadBalancer returns true if the load balancers of the API are
+// provisioned by the user.
+func (p *Platform) UserManagedLoadBalancer() bool {
+	return p.LoadBalancer != nil && p.LoadBalancer.Type == configv1.LoadBalancerTypeUserManaged
+}
+
+// ResourceTags returns the tags attached to the resources created by the
+// installer for the cluster with the infra ID: the infra ID and the user tags.
+func (p *Platform) ResourceTags(infraID string) []string {
+	tags := []string{infraID}
+	for _, tag := range p.UserTags {
+		if tag != infraID {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// WorkspaceName returns the name of the Power VS workspace created by the
+// installer for the cluster with the infra ID.
+func WorkspaceName(infraID string) string {
+	return fmt.Sprintf("%s-power-iaas", infraID)
+}
+
+// SSHKeyName returns the name of the SSH key uploaded to the workspace by the
+// installer for the cluster with the infra ID.
+func SSHKeyName(infraID string) string {
+	return fmt.Sprintf("%s-key", infraID)
 }
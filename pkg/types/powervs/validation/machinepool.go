@@ -13,8 +13,10 @@ import (
 	"github.com/openshift/installer/pkg/types/powervs"
 )
 
-// ValidateMachinePool checks that the specified machine pool is valid.
-func ValidateMachinePool(p *powervs.MachinePool, fldPath *field.Path) field.ErrorList {
+// ValidateMachinePool checks that the specified machine pool is valid. The
+// pool name is empty for the default machine platform, which applies to every
+// pool.
+func ValidateMachinePool(p *powervs.MachinePool, poolName string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	// Validate VolumeIDs
@@ -79,6 +81,46 @@ func ValidateMachinePool(p *powervs.MachinePool, fldPath *field.Path) field.Erro
 		}
 	}
 
+	// Validate SMTLevel. The e980 and s922 system types both run POWER9
+	// processors, which support every level up to SMT8.
+	if p.SMTLevel != "" {
+		smtLevels := sets.NewString(powervs.SMTLevels...)
+		if !smtLevels.Has(p.SMTLevel) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("smtLevel"), p.SMTLevel, powervs.SMTLevels))
+		}
+	}
+
+	// Validate SharedProcessorPool. The instances of a shared processor pool
+	// share its processors, so they cannot have dedicated processors.
+	if p.SharedProcessorPool != "" && p.ProcType == "Dedicated" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("procType"), p.ProcType, "dedicated processors cannot be used with a shared processor pool"))
+	}
+
+	// The compute machines are created by the Machine API, whose Power VS
+	// provider config cannot set a shared processor pool or a server
+	// placement group yet, so only the control plane machines can use them.
+	if poolName != "master" {
+		if p.SharedProcessorPool != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sharedProcessorPool"), "a shared processor pool is only supported for the control plane machine pool"))
+		}
+		if p.ServerPlacementGroup != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("serverPlacementGroup"), "a server placement group is only supported for the control plane machine pool"))
+		}
+	}
+
+	// Validate ServerPlacementGroup
+	if p.ServerPlacementGroup != nil {
+		if p.ServerPlacementGroup.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("serverPlacementGroup", "name"), "the name or ID of the placement group must be specified"))
+		}
+		if p.ServerPlacementGroup.Policy != "" {
+			policies := sets.NewString(string(powervs.PlacementGroupPolicyAffinity), string(powervs.PlacementGroupPolicyAntiAffinity))
+			if !policies.Has(string(p.ServerPlacementGroup.Policy)) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("serverPlacementGroup", "policy"), p.ServerPlacementGroup.Policy, policies.List()))
+			}
+		}
+	}
+
 	// Validate for Maximum Memory and Processors limits
 	if p.MemoryGiB != 0 {
 		if p.MemoryGiB < 4 {
@@ -46,6 +46,26 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.volumeIDs\[1]: Duplicate value: "c8b709c4-93f1-499e-915e-0820bcc51406"$`,
 		},
+		{
+			name: "valid smtLevel",
+			pool: &powervs.MachinePool{
+				SMTLevel: "4",
+			},
+		},
+		{
+			name: "smtLevel off",
+			pool: &powervs.MachinePool{
+				SysType:  "e980",
+				SMTLevel: "off",
+			},
+		},
+		{
+			name: "invalid smtLevel",
+			pool: &powervs.MachinePool{
+				SMTLevel: "16",
+			},
+			expected: `^test-path\.smtLevel: Unsupported value: "16": supported values: "off", "2", "4", "8"$`,
+		},
 		{
 			name: "valid memory",
 			pool: &powervs.MachinePool{
@@ -144,10 +164,100 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.sysType: Invalid value: "p922": system type must be one of {e980,s922}$`,
 		},
+		{
+			name: "valid sharedProcessorPool",
+			pool: &powervs.MachinePool{
+				ProcType:            "Shared",
+				SharedProcessorPool: "pool-1",
+			},
+		},
+		{
+			name: "sharedProcessorPool with dedicated processors",
+			pool: &powervs.MachinePool{
+				ProcType:            "Dedicated",
+				SharedProcessorPool: "pool-1",
+			},
+			expected: `^test-path\.procType: Invalid value: "Dedicated": dedicated processors cannot be used with a shared processor pool$`,
+		},
+		{
+			name: "valid serverPlacementGroup",
+			pool: &powervs.MachinePool{
+				ServerPlacementGroup: &powervs.ServerPlacementGroup{
+					Name:   "group-1",
+					Policy: powervs.PlacementGroupPolicyAntiAffinity,
+				},
+			},
+		},
+		{
+			name: "serverPlacementGroup without name",
+			pool: &powervs.MachinePool{
+				ServerPlacementGroup: &powervs.ServerPlacementGroup{
+					Policy: powervs.PlacementGroupPolicyAffinity,
+				},
+			},
+			expected: `^test-path\.serverPlacementGroup\.name: Required value: the name or ID of the placement group must be specified$`,
+		},
+		{
+			name: "invalid serverPlacementGroup policy",
+			pool: &powervs.MachinePool{
+				ServerPlacementGroup: &powervs.ServerPlacementGroup{
+					Name:   "group-1",
+					Policy: "spread",
+				},
+			},
+			expected: `^test-path\.serverPlacementGroup\.policy: Unsupported value: "spread": supported values: "affinity", "anti-affinity"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateMachinePool(tc.pool, "master", field.NewPath("test-path")).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateMachinePoolPlacement(t *testing.T) {
+	cases := []struct {
+		name     string
+		poolName string
+		pool     *powervs.MachinePool
+		expected string
+	}{
+		{
+			name:     "control plane sharedProcessorPool",
+			poolName: "master",
+			pool:     &powervs.MachinePool{SharedProcessorPool: "pool-1"},
+		},
+		{
+			name:     "compute sharedProcessorPool",
+			poolName: "worker",
+			pool:     &powervs.MachinePool{SharedProcessorPool: "pool-1"},
+			expected: `^test-path\.sharedProcessorPool: Forbidden: a shared processor pool is only supported for the control plane machine pool$`,
+		},
+		{
+			name:     "default sharedProcessorPool",
+			pool:     &powervs.MachinePool{SharedProcessorPool: "pool-1"},
+			expected: `^test-path\.sharedProcessorPool: Forbidden: a shared processor pool is only supported for the control plane machine pool$`,
+		},
+		{
+			name:     "control plane serverPlacementGroup",
+			poolName: "master",
+			pool:     &powervs.MachinePool{ServerPlacementGroup: &powervs.ServerPlacementGroup{Name: "group-1"}},
+		},
+		{
+			name:     "compute serverPlacementGroup",
+			poolName: "worker",
+			pool:     &powervs.MachinePool{ServerPlacementGroup: &powervs.ServerPlacementGroup{Name: "group-1"}},
+			expected: `^test-path\.serverPlacementGroup: Forbidden: a server placement group is only supported for the control plane machine pool$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidateMachinePool(tc.pool, field.NewPath("test-path")).ToAggregate()
+			err := ValidateMachinePool(tc.pool, tc.poolName, field.NewPath("test-path")).ToAggregate()
 			if tc.expected == "" {
 				assert.NoError(t, err)
 			} else {
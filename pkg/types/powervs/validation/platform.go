@@ -1,9 +1,17 @@
 package validation
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types/powervs"
 )
 
@@ -40,9 +48,127 @@ func ValidatePlatform(p *powervs.Platform, fldPath *field.Path) field.ErrorList
 		}
 	}
 
+	// validate DNSInstanceCRN
+	if p.DNSInstanceCRN != "" {
+		if err := validateDNSInstanceCRN(p.DNSInstanceCRN); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsInstanceCRN"), p.DNSInstanceCRN, err.Error()))
+		}
+	}
+
+	// validate the existing VPC settings
+	if p.VPCName == "" {
+		if len(p.VPCSubnets) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcSubnets"), p.VPCSubnets, "vpcSubnets requires vpcName"))
+		}
+		if len(p.VPCSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcSecurityGroups"), p.VPCSecurityGroups, "vpcSecurityGroups requires vpcName"))
+		}
+	}
+
 	// validate DefaultMachinePlatform
 	if p.DefaultMachinePlatform != nil {
-		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, "", fldPath.Child("defaultMachinePlatform"))...)
+	}
+
+	if p.LoadBalancer != nil {
+		allErrs = append(allErrs, validateLoadBalancer(p, fldPath)...)
+	}
+
+	allErrs = append(allErrs, validateServiceEndpoints(p.ServiceEndpoints, fldPath.Child("serviceEndpoints"))...)
+	allErrs = append(allErrs, validateUserTags(p.UserTags, fldPath.Child("userTags"))...)
+	return allErrs
+}
+
+// userTagRegexp matches the tags accepted by the IBM Cloud global tagging
+// service.
+var userTagRegexp = regexp.MustCompile(`^[A-Za-z0-9_.:\- ]{1,128}$`)
+
+// validateUserTags checks that the tags are valid and unique IBM Cloud user
+// tags.
+func validateUserTags(tags []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, tag := range tags {
+		switch {
+		case !userTagRegexp.MatchString(tag) || strings.TrimSpace(tag) != tag:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), tag, "tags must be at most 128 characters of letters, digits, spaces and _ . : -, without leading or trailing spaces"))
+		case seen.Has(strings.ToLower(tag)):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), tag))
+		}
+		seen.Insert(strings.ToLower(tag))
+	}
+	return allErrs
+}
+
+// validateServiceEndpoints checks that the endpoints override known services,
+// once each, with https URLs.
+func validateServiceEndpoints(endpoints []configv1.PowerVSServiceEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	tracker := map[string]int{}
+	for idx, e := range endpoints {
+		fldp := fldPath.Index(idx)
+		if !sets.NewString(powervs.ServiceEndpointNames...).Has(e.Name) {
+			allErrs = append(allErrs, field.NotSupported(fldp.Child("name"), e.Name, powervs.ServiceEndpointNames))
+		} else if eidx, ok := tracker[e.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("name"), e.Name, fmt.Sprintf("duplicate service endpoint not allowed for %s, service endpoint already defined at %s", e.Name, fldPath.Index(eidx))))
+		} else {
+			tracker[e.Name] = idx
+		}
+
+		if err := validateServiceURL(e.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("url"), e.URL, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateServiceURL checks that the URL is an https URL with a host. Unlike
+// the endpoints of AWS, the URL may have a path, e.g. /v1 for the VPC API.
+func validateServiceURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return errors.Errorf("invalid scheme %q, only https allowed", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("host cannot be empty")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return errors.New("no request parameters must be provided")
+	}
+	return nil
+}
+
+// validateDNSInstanceCRN checks that the CRN is the CRN of an IBM Cloud DNS
+// Services instance, i.e. crn:v1:<cname>:<ctype>:dns-svcs:<location>:a/<account>:<instance>::
+func validateDNSInstanceCRN(crn string) error {
+	segments := strings.Split(crn, ":")
+	if len(segments) != 10 || segments[0] != "crn" {
+		return errors.New("must be a CRN of the form crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource")
+	}
+	if segments[4] != "dns-svcs" {
+		return errors.Errorf("must be the CRN of a DNS Services instance, not of a %q one", segments[4])
+	}
+	if segments[7] == "" {
+		return errors.New("must include the service instance")
+	}
+	return nil
+}
+
+// validateLoadBalancer checks the type of the load balancers of the API,
+// which are reached through an existing VPC when they are user managed.
+func validateLoadBalancer(p *powervs.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.LoadBalancer.Type {
+	case "", configv1.LoadBalancerTypeOpenShiftManagedDefault:
+	case configv1.LoadBalancerTypeUserManaged:
+		if p.VPCName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("vpcName"), "vpcName is required with user-managed load balancers"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("loadBalancer", "type"), p.LoadBalancer.Type, []string{string(configv1.LoadBalancerTypeOpenShiftManagedDefault), string(configv1.LoadBalancerTypeUserManaged)}))
 	}
 	return allErrs
 }
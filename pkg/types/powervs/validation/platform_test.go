@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types/powervs"
 )
 
@@ -109,6 +110,145 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "VPC: Existing VPC with subnets and security groups",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "vpc"
+				p.VPCSubnets = []string{"subnet"}
+				p.VPCSecurityGroups = []string{"security-group"}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "VPC: Subnets without VPC",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.VPCSubnets = []string{"subnet"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "VPC: Security groups without VPC",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.VPCSecurityGroups = []string{"security-group"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "DNSInstanceCRN: Valid DNS Services instance CRN",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.DNSInstanceCRN = "crn:v1:bluemix:public:dns-svcs:global:a/0a1b2c3d4e5f60718293a4b5c6d7e8f9:5b3a4d7c-2a62-4d01-b37b-71211be442f6::"
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "DNSInstanceCRN: CRN of a CIS instance",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.DNSInstanceCRN = "crn:v1:bluemix:public:internet-svcs:global:a/0a1b2c3d4e5f60718293a4b5c6d7e8f9:5b3a4d7c-2a62-4d01-b37b-71211be442f6::"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "DNSInstanceCRN: Invalid CRN",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.DNSInstanceCRN = "dns-instance"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "ServiceEndpoints: Valid service endpoints",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []configv1.PowerVSServiceEndpoint{
+					{Name: "iam", URL: "https://private.iam.cloud.ibm.com"},
+					{Name: "vpc", URL: "https://us-south.private.iaas.cloud.ibm.com/v1"},
+					{Name: "global-search", URL: "https://api.private.global-search-tagging.cloud.ibm.com"},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "ServiceEndpoints: Unknown service",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []configv1.PowerVSServiceEndpoint{
+					{Name: "key-protect", URL: "https://private.us-south.kms.cloud.ibm.com"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "ServiceEndpoints: Duplicate service",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []configv1.PowerVSServiceEndpoint{
+					{Name: "power", URL: "https://private.dal.power-iaas.cloud.ibm.com"},
+					{Name: "power", URL: "https://dal.power-iaas.cloud.ibm.com"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "ServiceEndpoints: Insecure URL",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []configv1.PowerVSServiceEndpoint{
+					{Name: "resource-controller", URL: "http://private.resource-controller.cloud.ibm.com"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "UserTags: Valid tags",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.UserTags = []string{"env:prod", "team_ocp-1.2", "cost center"}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "UserTags: Invalid character",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.UserTags = []string{"env,prod"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "UserTags: Leading space",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.UserTags = []string{" env:prod"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "UserTags: Duplicate tags",
+			platform: func() *powervs.Platform {
+				p := validMinimalPlatform()
+				p.UserTags = []string{"env:prod", "ENV:prod"}
+				return p
+			}(),
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
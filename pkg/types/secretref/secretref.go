@@ -0,0 +1,50 @@
+// Package secretref defines references to the secrets of the install config
+// which are stored outside of it, so that the install config can be kept in
+// version control.
+package secretref
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Reference references a secret stored in a file or in an environment
+// variable. Exactly one of File or Env must be set.
+type Reference struct {
+	// File is the path of a file containing the secret. Relative paths are
+	// relative to the working directory of the installer.
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// Env is the name of an environment variable containing the secret.
+	// +optional
+	Env string `json:"env,omitempty"`
+}
+
+// Resolve returns the referenced secret, without leading and trailing white
+// space.
+func (r *Reference) Resolve() (string, error) {
+	switch {
+	case r.File != "" && r.Env != "":
+		return "", fmt.Errorf("only one of file or env may be set")
+	case r.File != "":
+		data, err := os.ReadFile(r.File)
+		if err != nil {
+			return "", err
+		}
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("file %q is empty", r.File)
+		}
+		return secret, nil
+	case r.Env != "":
+		secret := strings.TrimSpace(os.Getenv(r.Env))
+		if secret == "" {
+			return "", fmt.Errorf("environment variable %q is not set", r.Env)
+		}
+		return secret, nil
+	default:
+		return "", fmt.Errorf("one of file or env must be set")
+	}
+}
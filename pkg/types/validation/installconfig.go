@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"net"
 	"net/url"
-	"os"
+	"path"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
 	dockerref "github.com/containers/image/docker/reference"
@@ -31,6 +30,8 @@ import (
 	azurevalidation "github.com/openshift/installer/pkg/types/azure/validation"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	baremetalvalidation "github.com/openshift/installer/pkg/types/baremetal/validation"
+	"github.com/openshift/installer/pkg/types/external"
+	externalvalidation "github.com/openshift/installer/pkg/types/external/validation"
 	"github.com/openshift/installer/pkg/types/gcp"
 	gcpvalidation "github.com/openshift/installer/pkg/types/gcp/validation"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
@@ -110,6 +111,7 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 		allErrs = append(allErrs, validateNetworking(c.Networking, c.IsSingleNodeOpenShift(), field.NewPath("networking"))...)
 		allErrs = append(allErrs, validateNetworkingIPVersion(c.Networking, &c.Platform)...)
 		allErrs = append(allErrs, validateNetworkingForPlatform(c.Networking, &c.Platform, field.NewPath("networking"))...)
+		allErrs = append(allErrs, validateOVNKubernetesConfig(c, field.NewPath("networking", "ovnKubernetesConfig"))...)
 		allErrs = append(allErrs, validateVIPsForPlatform(c.Networking, &c.Platform, field.NewPath("platform"))...)
 	} else {
 		allErrs = append(allErrs, field.Required(field.NewPath("networking"), "networking is required"))
@@ -128,6 +130,12 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 		allErrs = append(allErrs, validateProxy(c.Proxy, c, field.NewPath("proxy"))...)
 	}
 	allErrs = append(allErrs, validateImageContentSources(c.ImageContentSources, field.NewPath("imageContentSources"))...)
+	if c.ImageMirrorResults != nil && c.ImageMirrorResults.Path == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("imageMirrorResults", "path"), "the path to the oc-mirror results file must be set"))
+	}
+	if c.CoreOSStream != nil && c.CoreOSStream.Path == "" && c.CoreOSStream.Release == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("coreOSStream"), "either the path to a stream metadata file or the release to pin must be set"))
+	}
 	if _, ok := validPublishingStrategies[c.Publish]; !ok {
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("publish"), c.Publish, validPublishingStrategyValues))
 	}
@@ -135,6 +143,12 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 	if c.Capabilities != nil {
 		allErrs = append(allErrs, validateCapabilities(c.Capabilities, field.NewPath("capabilities"))...)
 	}
+	if c.NodeCustomization != nil {
+		allErrs = append(allErrs, validateNodeCustomization(c.NodeCustomization, field.NewPath("nodeCustomization"))...)
+	}
+	if c.PointerIgnition != nil {
+		allErrs = append(allErrs, validatePointerIgnition(c.PointerIgnition, field.NewPath("pointerIgnition"))...)
+	}
 
 	if c.Publish == types.InternalPublishingStrategy {
 		switch platformName := c.Platform.Name(); platformName {
@@ -144,11 +158,33 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 		}
 	}
 
+	if c.Platform.PowerVS != nil && c.Platform.PowerVS.DNSInstanceCRN != "" && c.Publish != types.InternalPublishingStrategy {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("platform", "powervs", "dnsInstanceCRN"), c.Platform.PowerVS.DNSInstanceCRN, "a DNS Services instance is only used when publish is Internal"))
+	}
+
+	if c.IsBootstrapInPlaceIPI() {
+		allErrs = append(allErrs, validateBootstrapInPlaceIPI(c)...)
+	}
+
 	allErrs = append(allErrs, validateFeatureSet(c)...)
 
 	return allErrs
 }
 
+// validateBootstrapInPlaceIPI checks that the single node provisioned by the
+// installer is the only control plane machine, since no bootstrap machine
+// hands over to the others.
+func validateBootstrapInPlaceIPI(c *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.ControlPlane != nil && (c.ControlPlane.Replicas == nil || *c.ControlPlane.Replicas != 1) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("controlPlane", "replicas"), c.ControlPlane.Replicas, "bootstrap in place requires a single control plane replica"))
+	}
+	if c.BootstrapInPlace.InstallationDisk == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("bootstrapInPlace", "installationDisk"), "installationDisk must be set to the disk on which the single node is installed"))
+	}
+	return allErrs
+}
+
 // ipAddressType indicates the address types provided for a given field
 type ipAddressType struct {
 	IPv4    bool
@@ -215,7 +251,8 @@ func ipnetworksToStrings(networks []ipnet.IPNet) []string {
 }
 
 // validateNetworkingIPVersion checks parameters for consistency when the user
-// requests single-stack IPv6 or dual-stack modes.
+// requests single-stack IPv6 or dual-stack modes, and that the platform
+// supports them.
 func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -231,22 +268,7 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", "serviceNetwork"), strings.Join(ipnetworksToStrings(n.ServiceNetwork), ", "), "when installing dual-stack IPv4/IPv6 you must provide two service networks, one for each IP address type"))
 		}
 
-		allowV6Primary := false
-		experimentalDualStackEnabled, _ := strconv.ParseBool(os.Getenv("OPENSHIFT_INSTALL_EXPERIMENTAL_DUAL_STACK"))
-		switch {
-		case p.Azure != nil && experimentalDualStackEnabled:
-			logrus.Warnf("Using experimental Azure dual-stack support")
-		case p.BareMetal != nil:
-			// We now support ipv6-primary dual stack on baremetal
-			allowV6Primary = true
-		case p.VSphere != nil:
-		case p.OpenStack != nil:
-		case p.Ovirt != nil:
-		case p.Nutanix != nil:
-		case p.None != nil:
-		default:
-			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "DualStack", "dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address"))
-		}
+		allErrs = append(allErrs, validateDualStackPlatform(p, presence, addresses)...)
 		for k, v := range presence {
 			switch {
 			case v.IPv4 && !v.IPv6:
@@ -254,13 +276,6 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 			case !v.IPv4 && v.IPv6:
 				allErrs = append(allErrs, field.Invalid(field.NewPath("networking", k), strings.Join(ipnetworksToStrings(addresses[k]), ", "), "dual-stack IPv4/IPv6 requires an IPv4 network in this list"))
 			}
-
-			// FIXME: we should allow either all-networks-IPv4Primary or
-			// all-networks-IPv6Primary, but the latter currently causes
-			// confusing install failures, so block it.
-			if !allowV6Primary && v.IPv4 && v.IPv6 && v.Primary != corev1.IPv4Protocol {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("networking", k), strings.Join(ipnetworksToStrings(addresses[k]), ", "), "IPv4 addresses must be listed before IPv6 addresses"))
-			}
 		}
 
 	case hasIPv6:
@@ -268,18 +283,7 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", "networkType"), n.NetworkType, "IPv6 is not supported for this networking plugin"))
 		}
 
-		switch {
-		case p.BareMetal != nil:
-		case p.VSphere != nil:
-		case p.OpenStack != nil:
-		case p.Ovirt != nil:
-		case p.Nutanix != nil:
-		case p.None != nil:
-		case p.Azure != nil && p.Azure.CloudName == azure.StackCloud:
-			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv6", "Azure Stack does not support IPv6"))
-		default:
-			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv6", "single-stack IPv6 is not supported for this platform"))
-		}
+		allErrs = append(allErrs, validateSingleStackIPv6Platform(p)...)
 
 	case hasIPv4:
 		if len(n.ServiceNetwork) > 1 {
@@ -308,11 +312,6 @@ func validateNetworking(n *types.Networking, singleNodeOpenShift bool, fldPath *
 			if err := validate.SubnetCIDR(&network.CIDR.IPNet); err != nil {
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), err.Error()))
 			}
-			for j, subNetwork := range n.MachineNetwork[0:i] {
-				if validate.DoCIDRsOverlap(&network.CIDR.IPNet, &subNetwork.CIDR.IPNet) {
-					allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), fmt.Sprintf("machine network must not overlap with machine network %d", j)))
-				}
-			}
 		}
 	} else {
 		allErrs = append(allErrs, field.Required(fldPath.Child("machineNetwork"), "at least one machine network is required"))
@@ -322,27 +321,21 @@ func validateNetworking(n *types.Networking, singleNodeOpenShift bool, fldPath *
 		if err := validate.ServiceSubnetCIDR(&sn.IPNet); err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), err.Error()))
 		}
-		for _, network := range n.MachineNetwork {
-			if validate.DoCIDRsOverlap(&sn.IPNet, &network.CIDR.IPNet) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), "service network must not overlap with any of the machine networks"))
-			}
-		}
-		for j, snn := range n.ServiceNetwork[0:i] {
-			if validate.DoCIDRsOverlap(&sn.IPNet, &snn.IPNet) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), fmt.Sprintf("service network must not overlap with service network %d", j)))
-			}
-		}
 	}
 	if len(n.ServiceNetwork) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("serviceNetwork"), "a service network is required"))
 	}
 
 	for i, cn := range n.ClusterNetwork {
-		allErrs = append(allErrs, validateClusterNetwork(n, &cn, i, fldPath.Child("clusterNetwork").Index(i))...)
+		allErrs = append(allErrs, validateClusterNetwork(n, &cn, fldPath.Child("clusterNetwork").Index(i))...)
 	}
 	if len(n.ClusterNetwork) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterNetwork"), "cluster network required"))
 	}
+
+	model := newNetworkModel(n, fldPath)
+	allErrs = append(allErrs, model.overlaps()...)
+	allErrs = append(allErrs, model.internalSubnetOverlaps(n.OVNKubernetesConfig, fldPath.Child("ovnKubernetesConfig"))...)
 	return allErrs
 }
 
@@ -387,26 +380,11 @@ func validateNetworkingForPlatform(n *types.Networking, platform *types.Platform
 	return allErrs
 }
 
-func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry, idx int, fldPath *field.Path) field.ErrorList {
+func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if err := validate.SubnetCIDR(&cn.CIDR.IPNet); err != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.IPNet.String(), err.Error()))
 	}
-	for _, network := range n.MachineNetwork {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &network.CIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), "cluster network must not overlap with any of the machine networks"))
-		}
-	}
-	for i, sn := range n.ServiceNetwork {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &sn.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with service network %d", i)))
-		}
-	}
-	for i, acn := range n.ClusterNetwork[0:idx] {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &acn.CIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with cluster network %d", i)))
-		}
-	}
 	if cn.HostPrefix < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "hostPrefix must be positive"))
 	}
@@ -460,13 +438,34 @@ func validateCompute(platform *types.Platform, control *types.MachinePool, pools
 		}
 		poolNames[p.Name] = true
 		if control != nil && control.Architecture != p.Architecture {
-			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("architecture"), p.Architecture, "heteregeneous multi-arch is not supported; compute pool architecture must match control plane"))
+			allErrs = append(allErrs, validateHeterogeneousCompute(platform, &p, poolFldPath.Child("architecture"))...)
 		}
 		allErrs = append(allErrs, ValidateMachinePool(platform, &p, poolFldPath)...)
 	}
 	return allErrs
 }
 
+// heterogeneousPlatforms are the platforms which support compute pools with
+// a different architecture than the control plane. The machine sets of these
+// platforms reference the RHCOS image of each architecture directly.
+var heterogeneousPlatforms = sets.NewString(aws.Name, gcp.Name)
+
+// validateHeterogeneousCompute checks that the platform supports a compute
+// pool with a different architecture than the control plane. The release
+// payload is checked to be multi-arch when the install config asset is
+// validated.
+func validateHeterogeneousCompute(platform *types.Platform, pool *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !heterogeneousPlatforms.Has(platform.Name()) {
+		allErrs = append(allErrs, field.Invalid(fldPath, pool.Architecture, fmt.Sprintf("heterogeneous multi-arch is only supported on %s; compute pool architecture must match control plane", strings.Join(heterogeneousPlatforms.List(), ", "))))
+		return allErrs
+	}
+	if platform.GCP != nil && len(platform.GCP.Licenses) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, pool.Architecture, "heterogeneous multi-arch is not supported with GCP licenses, which are applied to the image of the control plane architecture"))
+	}
+	return allErrs
+}
+
 // vips defines the VIPs to validate
 type vips struct {
 	API     []string
@@ -609,22 +608,7 @@ func validateAPIAndIngressVIPs(vips vips, fieldNames vipFields, vipIsRequired, r
 			allErrs = append(allErrs, field.Required(fldPath.Child(fieldNames.IngressVIPs), "must specify VIP for ingress, when VIP for API is set"))
 		}
 
-		if len(vips.API) == 1 {
-			hasIPv4, hasIPv6, presence, _ := inferIPVersionFromInstallConfig(n)
-
-			apiVIPIPFamily := corev1.IPv4Protocol
-			if utilsnet.IsIPv6String(vips.API[0]) {
-				apiVIPIPFamily = corev1.IPv6Protocol
-			}
-
-			if hasIPv4 && hasIPv6 && apiVIPIPFamily != presence["machineNetwork"].Primary {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child(fieldNames.APIVIPs), vips.API[0], "VIP for the API must be of the same IP family with machine network's primary IP Family for dual-stack IPv4/IPv6"))
-			}
-		} else if len(vips.API) == 2 {
-			if isDualStack, _ := utilsnet.IsDualStackIPStrings(vips.API); !isDualStack {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child(fieldNames.APIVIPs), vips.API, "If two API VIPs are given, one must be an IPv4 address, the other an IPv6"))
-			}
-		}
+		allErrs = append(allErrs, validateVIPsIPStack(vips.API, "API", n, fldPath.Child(fieldNames.APIVIPs))...)
 	} else {
 		allErrs = append(allErrs, field.TooMany(fldPath.Child(fieldNames.APIVIPs), len(vips.API), 2))
 	}
@@ -652,22 +636,7 @@ func validateAPIAndIngressVIPs(vips vips, fieldNames vipFields, vipIsRequired, r
 			allErrs = append(allErrs, field.Required(fldPath.Child(fieldNames.APIVIPs), "must specify VIP for API, when VIP for ingress is set"))
 		}
 
-		if len(vips.Ingress) == 1 {
-			hasIPv4, hasIPv6, presence, _ := inferIPVersionFromInstallConfig(n)
-
-			ingressVIPIPFamily := corev1.IPv4Protocol
-			if utilsnet.IsIPv6String(vips.Ingress[0]) {
-				ingressVIPIPFamily = corev1.IPv6Protocol
-			}
-
-			if hasIPv4 && hasIPv6 && ingressVIPIPFamily != presence["machineNetwork"].Primary {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child(fieldNames.IngressVIPs), vips.Ingress[0], "VIP for the Ingress must be of the same IP family with machine network's primary IP Family for dual-stack IPv4/IPv6"))
-			}
-		} else if len(vips.Ingress) == 2 {
-			if isDualStack, _ := utilsnet.IsDualStackIPStrings(vips.Ingress); !isDualStack {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child(fieldNames.IngressVIPs), vips.Ingress, "If two Ingress VIPs are given, one must be an IPv4 address, the other an IPv6"))
-			}
-		}
+		allErrs = append(allErrs, validateVIPsIPStack(vips.Ingress, "Ingress", n, fldPath.Child(fieldNames.IngressVIPs))...)
 	} else {
 		allErrs = append(allErrs, field.TooMany(fldPath.Child(fieldNames.IngressVIPs), len(vips.Ingress), 2))
 	}
@@ -719,6 +688,9 @@ func validatePlatform(platform *types.Platform, usingAgentMethod bool, fldPath *
 			return azurevalidation.ValidatePlatform(platform.Azure, c.Publish, f)
 		})
 	}
+	if platform.External != nil {
+		validate(external.Name, platform.External, func(f *field.Path) field.ErrorList { return externalvalidation.ValidatePlatform(platform.External, f) })
+	}
 	if platform.GCP != nil {
 		validate(gcp.Name, platform.GCP, func(f *field.Path) field.ErrorList { return gcpvalidation.ValidatePlatform(platform.GCP, f, c) })
 	}
@@ -859,6 +831,31 @@ var (
 	}()
 )
 
+// validatePointerIgnition checks the URL of the machine config server and the
+// CA bundle of the pointer Ignition configs.
+func validatePointerIgnition(p *types.PointerIgnition, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), p.URL, err.Error()))
+		case u.Scheme != "https":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), p.URL, "must use the https scheme"))
+		case u.Hostname() == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), p.URL, "must have a host"))
+		case u.User != nil || u.RawQuery != "" || u.Fragment != "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), p.URL, "must not have user info, a query or a fragment"))
+		}
+	}
+	if p.CABundle != "" {
+		if err := validate.CABundle(p.CABundle); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("caBundle"), p.CABundle, err.Error()))
+		}
+	}
+	return allErrs
+}
+
 func validateCloudCredentialsMode(mode types.CredentialsMode, fldPath *field.Path, platform types.Platform) field.ErrorList {
 	if mode == "" {
 		return nil
@@ -988,6 +985,88 @@ func validateCapabilities(c *types.Capabilities, fldPath *field.Path) field.Erro
 	return allErrs
 }
 
+var validNodeRoles = []string{string(types.NodeRoleBootstrap), string(types.NodeRoleMaster), string(types.NodeRoleWorker)}
+
+// validateNodeCustomization checks the files, systemd units and kernel arguments
+// declared for the nodes.
+func validateNodeCustomization(c *types.NodeCustomization, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	validateRoles := func(roles []types.NodeRole, fldPath *field.Path) {
+		for i, role := range roles {
+			switch role {
+			case types.NodeRoleBootstrap, types.NodeRoleMaster, types.NodeRoleWorker:
+			default:
+				allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), role, validNodeRoles))
+			}
+		}
+	}
+
+	// roleKeys returns the keys of the name for each role it applies to, so
+	// that the same name may be used once per role.
+	roleKeys := func(roles []types.NodeRole, name string) []string {
+		if len(roles) == 0 {
+			roles = []types.NodeRole{types.NodeRoleBootstrap, types.NodeRoleMaster, types.NodeRoleWorker}
+		}
+		keys := make([]string, 0, len(roles))
+		for _, role := range roles {
+			keys = append(keys, string(role)+":"+name)
+		}
+		return keys
+	}
+
+	paths := sets.NewString()
+	for i, f := range c.Files {
+		fldPath := fldPath.Child("files").Index(i)
+		validateRoles(f.Roles, fldPath.Child("roles"))
+		keys := roleKeys(f.Roles, path.Clean(f.Path))
+		switch {
+		case !path.IsAbs(f.Path):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), f.Path, "must be an absolute path"))
+		case paths.HasAny(keys...):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("path"), f.Path))
+		default:
+			paths.Insert(keys...)
+		}
+		if f.Mode != nil && (*f.Mode < 0 || *f.Mode > 07777) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mode"), *f.Mode, "must be between 0 and 4095 (07777)"))
+		}
+	}
+
+	units := sets.NewString()
+	for i, u := range c.Units {
+		fldPath := fldPath.Child("units").Index(i)
+		validateRoles(u.Roles, fldPath.Child("roles"))
+		keys := roleKeys(u.Roles, u.Name)
+		switch {
+		case strings.Contains(u.Name, "/") || path.Ext(u.Name) == "" || path.Ext(u.Name) == u.Name:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), u.Name, "must be a unit file name, e.g. foo.service"))
+		case units.HasAny(keys...):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), u.Name))
+		default:
+			units.Insert(keys...)
+		}
+		if u.Contents == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("contents"), "the contents of the unit must be set"))
+		}
+	}
+
+	for i, a := range c.KernelArguments {
+		fldPath := fldPath.Child("kernelArguments").Index(i)
+		validateRoles(a.Roles, fldPath.Child("roles"))
+		for j, role := range a.Roles {
+			if role == types.NodeRoleBootstrap {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("roles").Index(j), role, "kernel arguments are not supported on the bootstrap node"))
+			}
+		}
+		if a.Argument == "" || strings.ContainsAny(a.Argument, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("argument"), a.Argument, "must be a single, non-empty kernel argument"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateAdditionalCABundlePolicy(c *types.InstallConfig) error {
 	switch c.AdditionalTrustBundlePolicy {
 	case types.PolicyProxyOnly, types.PolicyAlways:
@@ -20,6 +20,7 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
@@ -396,7 +397,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("10.0.2.0/24")
 				return c
 			}(),
-			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "10\.0\.2\.0/24": service network must not overlap with any of the machine networks$`,
+			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "10\.0\.2\.0/24": service network must not overlap with machine network 0 \(10\.0\.0\.0/16\), which contains it: choose a service network outside of the machine networks$`,
 		},
 		{
 			name: "overlapping machine network and machine network",
@@ -410,7 +411,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				return c
 			}(),
 			// also triggers the only-one-machine-network validation
-			expectedError: `^networking\.machineNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": machine network must not overlap with machine network 0$`,
+			expectedError: `^networking\.machineNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": machine network must not overlap with machine network 0 \(13\.0\.0\.0/16\), which contains it: merge them or choose machine networks which do not overlap$`,
 		},
 		{
 			name: "overlapping service network and service network",
@@ -424,7 +425,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				return c
 			}(),
 			// also triggers the only-one-service-network validation
-			expectedError: `^\[networking\.serviceNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": service network must not overlap with service network 0, networking\.serviceNetwork: Invalid value: "13\.0\.0\.0/16, 13\.0\.2\.0/24": only one service network can be specified]$`,
+			expectedError: `^\[networking\.serviceNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": service network must not overlap with service network 0 \(13\.0\.0\.0/16\), which contains it: merge them or choose service networks which do not overlap, networking\.serviceNetwork: Invalid value: "13\.0\.0\.0/16, 13\.0\.2\.0/24": only one service network can be specified]$`,
 		},
 		{
 			name: "missing machine networks",
@@ -460,7 +461,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("10.0.3.0/24")
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "10\.0\.3\.0/24": cluster network must not overlap with any of the machine networks$`,
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "10\.0\.3\.0/24": cluster network must not overlap with machine network 0 \(10\.0\.0\.0/16\), which contains it: choose a cluster network outside of the machine networks$`,
 		},
 		{
 			name: "overlapping cluster network and service network",
@@ -469,7 +470,95 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("172.30.2.0/24")
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "172\.30\.2\.0/24": cluster network must not overlap with service network 0$`,
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "172\.30\.2\.0/24": cluster network must not overlap with service network 0 \(172\.30\.0\.0/16\), which contains it: choose a cluster network outside of the service networks$`,
+		},
+		{
+			name: "cluster network same as service network",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("172.30.0.0/16")
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "172\.30\.0\.0/16": cluster network must not overlap with service network 0 \(172\.30\.0\.0/16\), which is the same range: choose a cluster network outside of the service networks$`,
+		},
+		{
+			name: "cluster network overlapping machine and service networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/24")}}
+				c.Networking.ServiceNetwork = []ipnet.IPNet{*ipnet.MustParseCIDR("10.0.1.0/24")}
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("10.0.0.0/16")
+				return c
+			}(),
+			expectedError: `^\Q[networking.clusterNetwork[0].cidr: Invalid value: "10.0.0.0/16": cluster network must not overlap with machine network 0 (10.0.0.0/24), which it contains: choose a cluster network outside of the machine networks, networking.clusterNetwork[0].cidr: Invalid value: "10.0.0.0/16": cluster network must not overlap with service network 0 (10.0.1.0/24), which it contains: choose a cluster network outside of the service networks]\E$`,
+		},
+		{
+			name: "valid OVNKubernetes configuration",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{
+					MTU:              8901,
+					V4InternalSubnet: ipnet.MustParseCIDR("100.66.0.0/16"),
+					GatewayConfig:    &types.OVNGatewayConfig{RoutingViaHost: true},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "OVNKubernetes configuration with another network type",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = "OpenShiftSDN"
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 8901}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig: Invalid value: "OpenShiftSDN": the OVNKubernetes configuration is only used with the OVNKubernetes network type$`,
+		},
+		{
+			name: "OVNKubernetes v4 internal subnet of IPv6",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("fd98::/64")}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.v4InternalSubnet: Invalid value: "fd98::/64": must be an IPv4 subnet$`,
+		},
+		{
+			name: "OVNKubernetes internal subnet overlapping machine network",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("10.0.128.0/17")}
+				return c
+			}(),
+			expectedError: `^\Qnetworking.ovnKubernetesConfig.v4InternalSubnet: Invalid value: "10.0.128.0/17": the OVN-Kubernetes internal subnet must not overlap with machine network 0 (10.0.0.0/16): choose an internal subnet outside of the machine networks\E$`,
+		},
+		{
+			name: "OVNKubernetes MTU too small",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 500}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 500: must be at least 576$`,
+		},
+		{
+			name: "OVNKubernetes MTU larger than the hardware MTU",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 9000}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 9000: must be at most 8901, the MTU of the aws machine network \(9001\) minus the 100 bytes of the Geneve encapsulation$`,
+		},
+		{
+			name: "OVNKubernetes MTU larger than the MTU of the AWS Local Zones",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute = append(c.Compute, *validMachinePool(types.MachinePoolEdgeRoleName))
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 1300}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 1300: must be at most 1200, the MTU of the network of the AWS Local Zones \(1300\) minus the 100 bytes of the Geneve encapsulation$`,
 		},
 		{
 			name: "overlapping cluster network and cluster network",
@@ -481,7 +570,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[1]\.cidr: Invalid value: "12\.0\.3\.0/24": cluster network must not overlap with cluster network 0$`,
+			expectedError: `^networking\.clusterNetwork\[1]\.cidr: Invalid value: "12\.0\.3\.0/24": cluster network must not overlap with cluster network 0 \(12\.0\.0\.0/16\), which contains it: merge them or choose cluster networks which do not overlap$`,
 		},
 		{
 			name: "cluster network host prefix too large",
@@ -590,7 +679,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Platform = types.Platform{}
 				return c
 			}(),
-			expectedError: `^platform: Invalid value: "": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\)$`,
+			expectedError: `^platform: Invalid value: "": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\)$`,
 		},
 		{
 			name: "multiple platforms",
@@ -621,7 +710,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				}
 				return c
 			}(),
-			expectedError: `^platform: Invalid value: "libvirt": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\)$`,
+			expectedError: `^platform: Invalid value: "libvirt": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\)$`,
 		},
 		{
 			name: "invalid libvirt platform",
@@ -633,7 +722,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Platform.Libvirt.URI = ""
 				return c
 			}(),
-			expectedError: `^\[platform: Invalid value: "libvirt": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\), platform\.libvirt\.uri: Invalid value: "": invalid URI "" \(no scheme\)]$`,
+			expectedError: `^\[platform: Invalid value: "libvirt": must specify one of the platforms \(alibabacloud, aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, ovirt, powervs, vsphere\), platform\.libvirt\.uri: Invalid value: "": invalid URI "" \(no scheme\)]$`,
 		},
 		{
 			name: "valid none platform",
@@ -645,6 +734,27 @@ func TestValidateInstallConfig(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "valid external platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					External: &external.Platform{PlatformName: "oci"},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid external platform name",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					External: &external.Platform{PlatformName: "../oci"},
+				}
+				return c
+			}(),
+			expectedError: `^platform\.external\.platformName: Invalid value: "\.\./oci": .*`,
+		},
 		{
 			name: "valid openstack platform",
 			installConfig: func() *types.InstallConfig {
@@ -784,7 +894,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				)
 				return c
 			}(),
-			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
+			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0 (192.168.1.0/24), which it contains: merge them or choose cluster networks which do not overlap, proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
 		},
 		{
 			name: "non-overlapping HTTPProxy and Service Networks",
@@ -827,7 +937,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				)
 				return c
 			}(),
-			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0 (172.30.0.0/16), which contains it: merge them or choose service networks which do not overlap, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
 		},
 		{
 			name: "non-overlapping HTTPSProxy and Cluster Networks",
@@ -873,7 +983,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				)
 				return c
 			}(),
-			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
+			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0 (192.168.1.0/24), which it contains: merge them or choose cluster networks which do not overlap, proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
 		},
 		{
 			name: "overlapping HTTPSProxy and Service Networks",
@@ -907,7 +1017,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				)
 				return c
 			}(),
-			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0 (172.30.0.0/16), which contains it: merge them or choose service networks which do not overlap, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
 		},
 		{
 			name: "invalid HTTPProxy Schema different schema",
@@ -1086,6 +1196,30 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `^\Qplatform.powervs.zone: Required value: zone must be specified\E$`,
 		},
+		{
+			name: "valid powervs bootstrap in place",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
+				c.BootstrapInPlace = &types.BootstrapInPlace{InstallationDisk: "/dev/sda"}
+				return c
+			}(),
+		},
+		{
+			name: "invalid ibmcloud bootstrap in place",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					IBMCloud: validIBMCloudPlatform(),
+				}
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.BootstrapInPlace = &types.BootstrapInPlace{}
+				return c
+			}(),
+			expectedError: `^\[controlPlane.replicas: Invalid value: 3: bootstrap in place requires a single control plane replica, bootstrapInPlace.installationDisk: Required value: installationDisk must be set to the disk on which the single node is installed\]$`,
+		},
 		{
 			name: "valid azurestack platform",
 			installConfig: func() *types.InstallConfig {
@@ -1210,7 +1344,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Networking = validDualStackNetworkingConfig()
 				return c
 			}(),
-			expectedError: `Invalid value: "DualStack": dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address`,
+			expectedError: `Invalid value: "DualStack": dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address; it is supported on baremetal, external, none, nutanix, openstack, ovirt, vsphere`,
 		},
 		{
 			name: "invalid single-stack IPv6 configuration, bad platform",
@@ -1220,7 +1354,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Networking = validIPv6NetworkingConfig()
 				return c
 			}(),
-			expectedError: `Invalid value: "IPv6": single-stack IPv6 is not supported for this platform`,
+			expectedError: `Invalid value: "IPv6": single-stack IPv6 is not supported for this platform, it is supported on baremetal, external, none, nutanix, openstack, ovirt, vsphere`,
 		},
 		{
 			name: "invalid dual-stack configuration, bad plugin",
@@ -1267,7 +1401,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				}
 				return c
 			}(),
-			expectedError: `Invalid value: "ffd1::/112, 172.30.0.0/16": IPv4 addresses must be listed before IPv6 addresses`,
+			expectedError: `Invalid value: "ffd1::/112, 172.30.0.0/16": IPv4 addresses must be listed before IPv6 addresses, IPv6-primary dual-stack is only supported on baremetal`,
 		},
 		{
 			name: "valid dual-stack configuration with mixed-order clusterNetworks",
@@ -1285,6 +1419,30 @@ func TestValidateInstallConfig(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "invalid dual-stack configuration, experimental platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{Azure: validAzureStackPlatform()}
+				c.Networking = validDualStackNetworkingConfig()
+				return c
+			}(),
+			expectedError: `Invalid value: "DualStack": dual-stack IPv4/IPv6 is experimental on azure, set OPENSHIFT_INSTALL_EXPERIMENTAL_DUAL_STACK=true to enable it or specify only one type of address`,
+		},
+		{
+			name: "invalid dual-stack configuration, IPv6-primary machine networks only",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{BareMetal: validBareMetalPlatform()}
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.MachineNetwork = []types.MachineNetworkEntry{
+					c.Networking.MachineNetwork[1],
+					c.Networking.MachineNetwork[0],
+				}
+				return c
+			}(),
+			expectedError: `networking\.machineNetwork: Invalid value: "ffd0::/48, 10\.0\.0\.0/16": IPv4 addresses must be listed first, as in networking\.clusterNetwork: the primary IP family must be the same in all the networks`,
+		},
 		{
 			name: "invalid IPv6 hostprefix",
 			installConfig: func() *types.InstallConfig {
@@ -1339,13 +1497,41 @@ func TestValidateInstallConfig(t *testing.T) {
 			expectedError: `[controlPlane.architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64", compute\[0\].architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64"]`,
 		},
 		{
-			name: "cluster is not heteregenous",
+			name: "heterogeneous cluster",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute[0].Architecture = types.ArchitectureARM64
+				return c
+			}(),
+		},
+		{
+			name: "heterogeneous cluster on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
+				c.Platform = types.Platform{
+					None: &none.Platform{},
+				}
 				c.Compute[0].Architecture = types.ArchitectureARM64
 				return c
 			}(),
-			expectedError: `^compute\[0\].architecture: Invalid value: "arm64": heteregeneous multi-arch is not supported; compute pool architecture must match control plane$`,
+			expectedError: `^compute\[0\].architecture: Invalid value: "arm64": heterogeneous multi-arch is only supported on aws, gcp; compute pool architecture must match control plane$`,
+		},
+		{
+			name: "valid coreos stream",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.CoreOSStream = &types.CoreOSStream{Path: "rhcos.json", Release: "413.92.202305021736-0"}
+				return c
+			}(),
+		},
+		{
+			name: "empty coreos stream",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.CoreOSStream = &types.CoreOSStream{}
+				return c
+			}(),
+			expectedError: `^coreOSStream: Required value: either the path to a stream metadata file or the release to pin must be set$`,
 		},
 		{
 			name: "valid cloud credentials mode",
@@ -1500,6 +1686,117 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `capabilities.additionalEnabledCapabilities\[0\]: Unsupported value: "": supported values: .*`,
 		},
+		{
+			name: "valid node customization",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					Files:           []types.NodeFile{{Path: "/etc/motd", Contents: "hello"}},
+					Units:           []types.NodeUnit{{Roles: []types.NodeRole{types.NodeRoleBootstrap}, Name: "hello.service", Enabled: true, Contents: "[Unit]"}},
+					KernelArguments: []types.NodeKernelArgument{{Roles: []types.NodeRole{types.NodeRoleWorker}, Argument: "nosmt"}},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid node customization file",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					Files: []types.NodeFile{{Path: "etc/motd", Roles: []types.NodeRole{"infra"}}},
+				}
+				return c
+			}(),
+			expectedError: `^\[nodeCustomization.files\[0\].roles\[0\]: Unsupported value: "infra": supported values: "bootstrap", "master", "worker", nodeCustomization.files\[0\].path: Invalid value: "etc/motd": must be an absolute path\]$`,
+		},
+		{
+			name: "duplicate node customization unit",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					Units: []types.NodeUnit{{Name: "hello.service", Contents: "[Unit]"}, {Name: "hello.service", Contents: "[Unit]"}},
+				}
+				return c
+			}(),
+			expectedError: `^nodeCustomization.units\[1\].name: Duplicate value: "hello.service"$`,
+		},
+		{
+			name: "same node customization file for different roles",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					Files: []types.NodeFile{
+						{Roles: []types.NodeRole{types.NodeRoleMaster}, Path: "/etc/motd", Contents: "master"},
+						{Roles: []types.NodeRole{types.NodeRoleWorker}, Path: "/etc/motd", Contents: "worker"},
+					},
+					Units: []types.NodeUnit{
+						{Roles: []types.NodeRole{types.NodeRoleBootstrap}, Name: "hello.service", Contents: "[Unit]"},
+						{Roles: []types.NodeRole{types.NodeRoleMaster, types.NodeRoleWorker}, Name: "hello.service", Contents: "[Unit]"},
+					},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "duplicate node customization file for a role",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					Files: []types.NodeFile{
+						{Roles: []types.NodeRole{types.NodeRoleWorker}, Path: "/etc/motd", Contents: "worker"},
+						{Path: "/etc/../etc/motd", Contents: "all"},
+					},
+				}
+				return c
+			}(),
+			expectedError: `^nodeCustomization.files\[1\].path: Duplicate value: "/etc/../etc/motd"$`,
+		},
+		{
+			name: "bootstrap kernel argument",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.NodeCustomization = &types.NodeCustomization{
+					KernelArguments: []types.NodeKernelArgument{{Roles: []types.NodeRole{types.NodeRoleBootstrap}, Argument: "nosmt"}},
+				}
+				return c
+			}(),
+			expectedError: `^nodeCustomization.kernelArguments\[0\].roles\[0\]: Invalid value: "bootstrap": kernel arguments are not supported on the bootstrap node$`,
+		},
+		{
+			name: "valid pointer ignition",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PointerIgnition = &types.PointerIgnition{URL: "https://mcs.example.com:22623/cluster"}
+				return c
+			}(),
+		},
+		{
+			name: "pointer ignition URL without https",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PointerIgnition = &types.PointerIgnition{URL: "http://mcs.example.com:22623"}
+				return c
+			}(),
+			expectedError: `^pointerIgnition\.url: Invalid value: "http://mcs\.example\.com:22623": must use the https scheme$`,
+		},
+		{
+			name: "pointer ignition URL with a query",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PointerIgnition = &types.PointerIgnition{URL: "https://mcs.example.com:22623?role=master"}
+				return c
+			}(),
+			expectedError: `^pointerIgnition\.url: Invalid value: "https://mcs\.example\.com:22623\?role=master": must not have user info, a query or a fragment$`,
+		},
+		{
+			name: "invalid pointer ignition CA bundle",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PointerIgnition = &types.PointerIgnition{CABundle: "not a certificate"}
+				return c
+			}(),
+			expectedError: `^pointerIgnition\.caBundle: Invalid value: "not a certificate": invalid block$`,
+		},
 		{
 			name: "invalid additional enabled capability specified",
 			installConfig: func() *types.InstallConfig {
@@ -2040,6 +2337,36 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: "platform.ovirt.api_vips: Invalid value: \"foobar\": \"foobar\" is not a valid IP",
 		},
+		{
+			name: "VIP of each IP family without dual-stack networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					VSphere: validVSpherePlatform(),
+				}
+				c.Platform.VSphere.APIVIPs = []string{"10.0.0.1", "fd00::1"}
+				c.Platform.VSphere.IngressVIPs = []string{"10.0.0.2"}
+
+				return c
+			}(),
+			expectedError: `^platform\.vsphere\.apiVIPs: Invalid value: \[\]string\{"10\.0\.0\.1", "fd00::1"\}: a VIP for the API of each IP family is only used with dual-stack IPv4/IPv6 networks, specify a single VIP$`,
+		},
+		{
+			name: "dual-stack VIPs not listed in the order of the machine networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.MachineNetwork = []types.MachineNetworkEntry{c.Networking.MachineNetwork[1], c.Networking.MachineNetwork[0]}
+				c.Networking.ServiceNetwork = []ipnet.IPNet{c.Networking.ServiceNetwork[1], c.Networking.ServiceNetwork[0]}
+				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{c.Networking.ClusterNetwork[1], c.Networking.ClusterNetwork[0]}
+				c.Platform = types.Platform{
+					BareMetal: validBareMetalPlatform(),
+				}
+				c.Platform.BareMetal.APIVIPs = []string{"10.0.0.5", "ffd0::5"}
+				return c
+			}(),
+			expectedError: `platform\.baremetal\.apiVIPs: Invalid value: "10\.0\.0\.5": VIP for the API must be of the same IP family with machine network's primary IP Family for dual-stack IPv4/IPv6`,
+		},
 		{
 			name: "should return error if only API VIP is set",
 			installConfig: func() *types.InstallConfig {
@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilsnet "k8s.io/utils/net"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/external"
+	"github.com/openshift/installer/pkg/types/none"
+	"github.com/openshift/installer/pkg/types/nutanix"
+	"github.com/openshift/installer/pkg/types/openstack"
+	"github.com/openshift/installer/pkg/types/ovirt"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// experimentalDualStackEnv is the environment variable which enables the
+// experimental dual-stack support of the platforms which have one.
+const experimentalDualStackEnv = "OPENSHIFT_INSTALL_EXPERIMENTAL_DUAL_STACK"
+
+// ipStackSupport is the support of a platform for the IP stacks other than
+// single-stack IPv4, which every platform supports.
+type ipStackSupport struct {
+	// singleStackIPv6 is whether the platform supports single-stack IPv6.
+	singleStackIPv6 bool
+	// dualStack is whether the platform supports dual-stack IPv4/IPv6.
+	dualStack bool
+	// experimentalDualStack is whether the dual-stack support of the
+	// platform is experimental, and only enabled by experimentalDualStackEnv.
+	experimentalDualStack bool
+	// ipv6Primary is whether the platform supports dual-stack IPv4/IPv6 with
+	// the IPv6 networks listed first. The other platforms only support the
+	// IPv4 networks listed first.
+	ipv6Primary bool
+}
+
+// ipStackSupportByPlatform is the support for the IP stacks by the name of
+// the platform. The platforms missing only support single-stack IPv4.
+var ipStackSupportByPlatform = map[string]ipStackSupport{
+	azure.Name:     {experimentalDualStack: true},
+	baremetal.Name: {singleStackIPv6: true, dualStack: true, ipv6Primary: true},
+	external.Name:  {singleStackIPv6: true, dualStack: true},
+	none.Name:      {singleStackIPv6: true, dualStack: true},
+	nutanix.Name:   {singleStackIPv6: true, dualStack: true},
+	openstack.Name: {singleStackIPv6: true, dualStack: true},
+	ovirt.Name:     {singleStackIPv6: true, dualStack: true},
+	vsphere.Name:   {singleStackIPv6: true, dualStack: true},
+}
+
+// ipStackPlatforms returns the sorted names of the platforms whose support
+// for the IP stacks matches.
+func ipStackPlatforms(matches func(ipStackSupport) bool) string {
+	var platforms []string
+	for name, support := range ipStackSupportByPlatform {
+		if matches(support) {
+			platforms = append(platforms, name)
+		}
+	}
+	sort.Strings(platforms)
+	return strings.Join(platforms, ", ")
+}
+
+// validateDualStackPlatform validates that the platform supports dual-stack
+// IPv4/IPv6, and that the IPv6 networks are only listed first on the
+// platforms which support it, and then in all the lists of networks.
+func validateDualStackPlatform(p *types.Platform, presence ipAddressTypeByField, addresses ipNetByField) field.ErrorList {
+	var allErrs field.ErrorList
+
+	support := ipStackSupportByPlatform[p.Name()]
+	switch {
+	case support.dualStack:
+	case support.experimentalDualStack:
+		if enabled, _ := strconv.ParseBool(os.Getenv(experimentalDualStackEnv)); !enabled {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "DualStack", fmt.Sprintf("dual-stack IPv4/IPv6 is experimental on %s, set %s=true to enable it or specify only one type of address", p.Name(), experimentalDualStackEnv)))
+			break
+		}
+		logrus.Warnf("Using experimental %s dual-stack support", p.Name())
+	default:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "DualStack", fmt.Sprintf("dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address; it is supported on %s", ipStackPlatforms(func(s ipStackSupport) bool { return s.dualStack }))))
+	}
+
+	fields := make([]string, 0, len(presence))
+	for k := range presence {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	var primary corev1.IPFamily
+	var primaryField string
+	for _, k := range fields {
+		v := presence[k]
+		if !v.IPv4 || !v.IPv6 {
+			continue
+		}
+		// FIXME: we should allow either all-networks-IPv4Primary or
+		// all-networks-IPv6Primary, but the latter currently causes
+		// confusing install failures, so block it.
+		if !support.ipv6Primary && v.Primary != corev1.IPv4Protocol {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", k), strings.Join(ipnetworksToStrings(addresses[k]), ", "), fmt.Sprintf("IPv4 addresses must be listed before IPv6 addresses, IPv6-primary dual-stack is only supported on %s", ipStackPlatforms(func(s ipStackSupport) bool { return s.ipv6Primary }))))
+			continue
+		}
+		// The nodes and the services take their primary addresses from
+		// different networks, so the networks must all agree on the
+		// primary IP family, even where IPv6 can be primary.
+		if primary == "" {
+			primary, primaryField = v.Primary, k
+		} else if v.Primary != primary {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", k), strings.Join(ipnetworksToStrings(addresses[k]), ", "), fmt.Sprintf("%s addresses must be listed first, as in networking.%s: the primary IP family must be the same in all the networks", primary, primaryField)))
+		}
+	}
+	return allErrs
+}
+
+// validateSingleStackIPv6Platform validates that the platform supports
+// single-stack IPv6.
+func validateSingleStackIPv6Platform(p *types.Platform) field.ErrorList {
+	var allErrs field.ErrorList
+	switch {
+	case ipStackSupportByPlatform[p.Name()].singleStackIPv6:
+	case p.Azure != nil && p.Azure.CloudName == azure.StackCloud:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv6", "Azure Stack does not support IPv6"))
+	default:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv6", fmt.Sprintf("single-stack IPv6 is not supported for this platform, it is supported on %s", ipStackPlatforms(func(s ipStackSupport) bool { return s.singleStackIPv6 }))))
+	}
+	return allErrs
+}
+
+// validateVIPsIPStack validates the number and the IP families of the VIPs of
+// the API or the Ingress against the IP stack of the networking: a single VIP
+// in single-stack, and in dual-stack IPv4/IPv6 either a VIP of the primary IP
+// family of the machine networks or a VIP of each IP family, the primary
+// first.
+func validateVIPsIPStack(vips []string, name string, n *types.Networking, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	hasIPv4, hasIPv6, presence, _ := inferIPVersionFromInstallConfig(n)
+	dualStack := hasIPv4 && hasIPv6
+
+	switch len(vips) {
+	case 1:
+	case 2:
+		if isDualStack, _ := utilsnet.IsDualStackIPStrings(vips); !isDualStack {
+			return append(allErrs, field.Invalid(fldPath, vips, fmt.Sprintf("If two %s VIPs are given, one must be an IPv4 address, the other an IPv6", name)))
+		}
+		if !dualStack {
+			return append(allErrs, field.Invalid(fldPath, vips, fmt.Sprintf("a VIP for the %s of each IP family is only used with dual-stack IPv4/IPv6 networks, specify a single VIP", name)))
+		}
+	default:
+		return allErrs
+	}
+
+	vipIPFamily := corev1.IPv4Protocol
+	if utilsnet.IsIPv6String(vips[0]) {
+		vipIPFamily = corev1.IPv6Protocol
+	}
+	if dualStack && vipIPFamily != presence["machineNetwork"].Primary {
+		allErrs = append(allErrs, field.Invalid(fldPath, vips[0], fmt.Sprintf("VIP for the %s must be of the same IP family with machine network's primary IP Family for dual-stack IPv4/IPv6", name)))
+	}
+	return allErrs
+}
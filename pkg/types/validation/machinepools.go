@@ -97,6 +97,9 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 	}
 	if p.AWS != nil {
 		validate(aws.Name, p.AWS, func(f *field.Path) field.ErrorList { return awsvalidation.ValidateMachinePool(platform.AWS, p.AWS, f) })
+		if p.AWS.OutpostARN != "" && pool.Name != types.MachinePoolEdgeRoleName {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("aws", "outpostARN"), "outpostARN is valid only for the edge machine pool"))
+		}
 	}
 	if p.Azure != nil {
 		validate(azure.Name, p.Azure, func(f *field.Path) field.ErrorList {
@@ -105,11 +108,17 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 	}
 	if p.GCP != nil {
 		validate(gcp.Name, p.GCP, func(f *field.Path) field.ErrorList { return validateGCPMachinePool(platform, p, pool, f) })
+	} else if platform.GCP != nil {
+		// The pool only has the defaults of the platform.
+		allErrs = append(allErrs, validateGCPConfidentialCompute(platform, p, fldPath.Child(gcp.Name))...)
 	}
 	if p.IBMCloud != nil {
 		validate(ibmcloud.Name, p.IBMCloud, func(f *field.Path) field.ErrorList {
 			return ibmcloudvalidation.ValidateMachinePool(platform.IBMCloud, p.IBMCloud, f)
 		})
+		if p.IBMCloud.PlacementGroup != nil && pool.Name != types.MachinePoolControlPlaneRoleName {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ibmcloud", "placementGroup"), "placementGroup is valid only for the control plane machine pool"))
+		}
 	}
 	if p.Libvirt != nil {
 		validate(libvirt.Name, p.Libvirt, func(f *field.Path) field.ErrorList { return libvirtvalidation.ValidateMachinePool(p.Libvirt, f) })
@@ -126,7 +135,9 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 		validate(ovirt.Name, p.Ovirt, func(f *field.Path) field.ErrorList { return ovirtvalidation.ValidateMachinePool(p.Ovirt, f) })
 	}
 	if p.PowerVS != nil {
-		validate(powervs.Name, p.PowerVS, func(f *field.Path) field.ErrorList { return powervsvalidation.ValidateMachinePool(p.PowerVS, f) })
+		validate(powervs.Name, p.PowerVS, func(f *field.Path) field.ErrorList {
+			return powervsvalidation.ValidateMachinePool(p.PowerVS, pool.Name, f)
+		})
 	}
 	if p.OpenStack != nil {
 		validate(openstack.Name, p.OpenStack, func(f *field.Path) field.ErrorList {
@@ -141,6 +152,19 @@ func validateGCPMachinePool(platform *types.Platform, p *types.MachinePoolPlatfo
 
 	allErrs = append(allErrs, gcpvalidation.ValidateMachinePool(platform.GCP, p.GCP, f)...)
 	allErrs = append(allErrs, gcpvalidation.ValidateMasterDiskType(pool, f)...)
+	allErrs = append(allErrs, validateGCPConfidentialCompute(platform, p, f)...)
 
 	return allErrs
 }
+
+// validateGCPConfidentialCompute validates the confidential computing of the
+// machine pool merged with the default machine platform, as the machine type
+// may come from either.
+func validateGCPConfidentialCompute(platform *types.Platform, p *types.MachinePoolPlatform, f *field.Path) field.ErrorList {
+	mpool := gcp.MachinePool{}
+	if platform.GCP != nil {
+		mpool.Set(platform.GCP.DefaultMachinePlatform)
+	}
+	mpool.Set(p.GCP)
+	return gcpvalidation.ValidateConfidentialCompute(&mpool, f)
+}
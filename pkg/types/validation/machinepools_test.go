@@ -87,6 +87,34 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name:     "valid aws outpost edge pool",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool(types.MachinePoolEdgeRoleName)
+				p.Platform = types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{
+						OutpostARN: "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+					},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "invalid aws outpost compute pool",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool(types.MachinePoolComputeRoleName)
+				p.Platform = types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{
+						OutpostARN: "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+					},
+				}
+				return p
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid azure",
 			platform: &types.Platform{Azure: &azure.Platform{Region: "eastus"}},
@@ -190,6 +218,30 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "GCP confidential computing default with pool machine type",
+			platform: &types.Platform{GCP: &gcp.Platform{
+				Region:                 "us-east-1",
+				DefaultMachinePlatform: &gcp.MachinePool{ConfidentialCompute: "Enabled", OnHostMaintenance: "Terminate"},
+			}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform = types.MachinePoolPlatform{
+					GCP: &gcp.MachinePool{InstanceType: "n2d-standard-4"},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "GCP confidential computing default without machine type",
+			platform: &types.Platform{GCP: &gcp.Platform{
+				Region:                 "us-east-1",
+				DefaultMachinePlatform: &gcp.MachinePool{ConfidentialCompute: "Enabled", OnHostMaintenance: "Terminate"},
+			}},
+			pool:  validMachinePool("test-name"),
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+const (
+	ovnJoinSubnetName  = "OVN-Kubernetes join subnet"
+	machineNetworkKind = "machine network"
+)
+
+// ovnReservedRanges are the ranges which OVN-Kubernetes uses internally by
+// default, for the join switch, the transit switch and the masquerade
+// addresses of the nodes.
+var ovnReservedRanges = []struct {
+	name string
+	cidr string
+}{
+	{name: ovnJoinSubnetName, cidr: "100.64.0.0/16"},
+	{name: "OVN-Kubernetes transit switch subnet", cidr: "100.88.0.0/16"},
+	{name: "OVN-Kubernetes masquerade subnet", cidr: "169.254.169.0/29"},
+	{name: ovnJoinSubnetName, cidr: "fd98::/64"},
+	{name: "OVN-Kubernetes transit switch subnet", cidr: "fd97::/64"},
+	{name: "OVN-Kubernetes masquerade subnet", cidr: "fd69::/125"},
+}
+
+// networkRange is an address range of the networking model of the install
+// config.
+type networkRange struct {
+	// kind names the range in the messages, e.g. "service network".
+	kind string
+	// index is the index of the range in the list of its kind.
+	index int
+	path  *field.Path
+	cidr  *net.IPNet
+}
+
+func (r networkRange) String() string {
+	return fmt.Sprintf("%s %d (%s)", r.kind, r.index, r.cidr)
+}
+
+// networkModel holds the machine, service and cluster networks of the install
+// config, in that order, so that every overlap between them is reported once,
+// on the range declared last.
+type networkModel struct {
+	ranges []networkRange
+}
+
+func newNetworkModel(n *types.Networking, fldPath *field.Path) *networkModel {
+	m := &networkModel{}
+	for i := range n.MachineNetwork {
+		m.add(machineNetworkKind, i, fldPath.Child("machineNetwork").Index(i), &n.MachineNetwork[i].CIDR.IPNet)
+	}
+	for i := range n.ServiceNetwork {
+		m.add("service network", i, fldPath.Child("serviceNetwork").Index(i), &n.ServiceNetwork[i].IPNet)
+	}
+	for i := range n.ClusterNetwork {
+		m.add("cluster network", i, fldPath.Child("clusterNetwork").Index(i).Child("cidr"), &n.ClusterNetwork[i].CIDR.IPNet)
+	}
+	return m
+}
+
+func (m *networkModel) add(kind string, index int, path *field.Path, cidr *net.IPNet) {
+	m.ranges = append(m.ranges, networkRange{kind: kind, index: index, path: path, cidr: cidr})
+}
+
+// overlaps returns an error for every pair of overlapping ranges, with the
+// ranges in conflict and how to solve it.
+func (m *networkModel) overlaps() field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, r := range m.ranges {
+		for _, other := range m.ranges[:i] {
+			if !validate.DoCIDRsOverlap(r.cidr, other.cidr) {
+				continue
+			}
+			allErrs = append(allErrs, field.Invalid(r.path, r.cidr.String(), overlapMessage(r, other)))
+		}
+	}
+	return allErrs
+}
+
+// reservedOverlaps returns a warning for every range which overlaps with the
+// ranges used internally by the network plugin. They are not errors, as the
+// ranges of the plugin can be changed with manifests. The default join
+// subnets replaced by the internal subnets of the OVNKubernetes configuration
+// are skipped, the overlaps with those are errors.
+func (m *networkModel) reservedOverlaps(n *types.Networking) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if n.NetworkType != string(operv1.NetworkTypeOVNKubernetes) {
+		return allErrs
+	}
+	for _, reserved := range ovnReservedRanges {
+		_, cidr, err := net.ParseCIDR(reserved.cidr)
+		if err != nil {
+			continue
+		}
+		if reserved.name == ovnJoinSubnetName && internalSubnet(n.OVNKubernetesConfig, cidr.IP.To4() != nil) != nil {
+			continue
+		}
+		for _, r := range m.ranges {
+			if validate.DoCIDRsOverlap(r.cidr, cidr) {
+				allErrs = append(allErrs, field.Invalid(r.path, r.cidr.String(), fmt.Sprintf("%s overlaps with the %s %s, which must then be changed in the configuration of the cluster network operator", r.kind, reserved.name, cidr)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// cloudOverlaps returns an error for every cloud network which overlaps with
+// a service or cluster network, as the traffic to the cloud network would be
+// routed to the services or the pods. The cloud networks are expected in the
+// machine networks, which the platforms check.
+func (m *networkModel) cloudOverlaps(cloudNetworks []CloudNetwork) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, c := range cloudNetworks {
+		for _, r := range m.ranges {
+			if r.kind == machineNetworkKind || !validate.DoCIDRsOverlap(r.cidr, c.CIDR) {
+				continue
+			}
+			allErrs = append(allErrs, field.Invalid(c.Path, c.CIDR.String(), fmt.Sprintf("%s must not overlap with %s: choose a %s outside of %s", c.Name, r, r.kind, c.CIDR)))
+		}
+	}
+	return allErrs
+}
+
+// CloudNetwork is an address range of the cloud used by the cluster, either a
+// subnet provided in the install config or an existing network of the
+// platform, e.g. the address space of an Azure virtual network.
+type CloudNetwork struct {
+	// Name names the network in the messages, e.g. "subnet subnet-1234".
+	Name string
+	// Path is the field of the install config which references the network.
+	Path *field.Path
+	CIDR *net.IPNet
+}
+
+// ValidateCloudNetworks validates that the networks of the cloud used by the
+// cluster do not overlap with the service and cluster networks of the
+// install config.
+func ValidateCloudNetworks(n *types.Networking, cloudNetworks []CloudNetwork) field.ErrorList {
+	if n == nil {
+		return field.ErrorList{}
+	}
+	return newNetworkModel(n, field.NewPath("networking")).cloudOverlaps(cloudNetworks)
+}
+
+// NetworkingWarnings returns the warnings about the networking of the install
+// config, which do not fail its validation.
+func NetworkingWarnings(n *types.Networking) field.ErrorList {
+	if n == nil {
+		return field.ErrorList{}
+	}
+	return newNetworkModel(n, field.NewPath("networking")).reservedOverlaps(n)
+}
+
+// overlapMessage describes the overlap of the range with one declared before
+// it.
+func overlapMessage(r, other networkRange) string {
+	rOnes, _ := r.cidr.Mask.Size()
+	otherOnes, _ := other.cidr.Mask.Size()
+	var relation string
+	switch {
+	case rOnes == otherOnes:
+		relation = "which is the same range"
+	case rOnes > otherOnes:
+		relation = "which contains it"
+	default:
+		relation = "which it contains"
+	}
+
+	remediation := fmt.Sprintf("choose a %s outside of the %ss", r.kind, other.kind)
+	if r.kind == other.kind {
+		remediation = fmt.Sprintf("merge them or choose %ss which do not overlap", r.kind)
+	}
+	return fmt.Sprintf("%s must not overlap with %s, %s: %s", r.kind, other, relation, remediation)
+}
@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestValidateCloudNetworks(t *testing.T) {
+	cases := []struct {
+		name          string
+		cloudNetworks []CloudNetwork
+		expected      string
+	}{
+		{
+			name: "no cloud networks",
+		},
+		{
+			name: "subnet in the machine network",
+			cloudNetworks: []CloudNetwork{
+				{Name: "subnet subnet-1", Path: field.NewPath("platform", "aws", "subnets").Index(0), CIDR: &ipnet.MustParseCIDR("10.0.1.0/24").IPNet},
+			},
+		},
+		{
+			name: "subnet overlapping with the cluster network",
+			cloudNetworks: []CloudNetwork{
+				{Name: "subnet subnet-1", Path: field.NewPath("platform", "aws", "subnets").Index(0), CIDR: &ipnet.MustParseCIDR("10.128.1.0/24").IPNet},
+			},
+			expected: `^platform\.aws\.subnets\[0\]: Invalid value: "10\.128\.1\.0/24": subnet subnet-1 must not overlap with cluster network 0 \(10\.128\.0\.0/14\): choose a cluster network outside of 10\.128\.1\.0/24$`,
+		},
+		{
+			name: "virtual network overlapping with the service network",
+			cloudNetworks: []CloudNetwork{
+				{Name: "virtual network vnet", Path: field.NewPath("platform", "azure", "virtualNetwork"), CIDR: &ipnet.MustParseCIDR("172.16.0.0/12").IPNet},
+			},
+			expected: `^platform\.azure\.virtualNetwork: Invalid value: "172\.16\.0\.0/12": virtual network vnet must not overlap with service network 0 \(172\.30\.0\.0/16\): choose a service network outside of 172\.16\.0\.0/12$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &types.Networking{
+				MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+				ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
+				ClusterNetwork: []types.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.128.0.0/14"), HostPrefix: 23}},
+			}
+			err := ValidateCloudNetworks(n, tc.cloudNetworks).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestNetworkingWarnings(t *testing.T) {
+	cases := []struct {
+		name        string
+		networkType string
+		machineCIDR string
+		config      *types.OVNKubernetesConfig
+		expected    string
+	}{
+		{
+			name:        "no overlap",
+			networkType: "OVNKubernetes",
+			machineCIDR: "10.0.0.0/16",
+		},
+		{
+			name:        "join subnet",
+			networkType: "OVNKubernetes",
+			machineCIDR: "100.64.0.0/24",
+			expected:    `^networking\.machineNetwork\[0\]: Invalid value: "100\.64\.0\.0/24": machine network overlaps with the OVN-Kubernetes join subnet 100\.64\.0\.0/16, which must then be changed in the configuration of the cluster network operator$`,
+		},
+		{
+			name:        "join subnet replaced",
+			networkType: "OVNKubernetes",
+			machineCIDR: "100.64.0.0/24",
+			config:      &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("100.68.0.0/16")},
+		},
+		{
+			name:        "transit switch subnet",
+			networkType: "OVNKubernetes",
+			machineCIDR: "100.88.0.0/24",
+			expected:    `^networking\.machineNetwork\[0\]: Invalid value: "100\.88\.0\.0/24": machine network overlaps with the OVN-Kubernetes transit switch subnet 100\.88\.0\.0/16, which must then be changed in the configuration of the cluster network operator$`,
+		},
+		{
+			name:        "other network type",
+			networkType: "OpenShiftSDN",
+			machineCIDR: "100.64.0.0/24",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &types.Networking{
+				NetworkType:         tc.networkType,
+				MachineNetwork:      []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR(tc.machineCIDR)}},
+				OVNKubernetesConfig: tc.config,
+			}
+			err := NetworkingWarnings(n).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
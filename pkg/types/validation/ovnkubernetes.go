@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+const (
+	// geneveOverhead is the overhead of the Geneve encapsulation of
+	// OVN-Kubernetes, which the MTU of the pod network leaves room for.
+	geneveOverhead = 100
+	// minimumIPv4MTU and minimumIPv6MTU are the smallest MTUs of the pod
+	// network.
+	minimumIPv4MTU = 576
+	minimumIPv6MTU = 1280
+	// awsEdgeHardwareMTU is the MTU of the network between AWS Local Zones
+	// and their region.
+	awsEdgeHardwareMTU = 1300
+)
+
+// hardwareMTUs are the MTUs of the machine networks of the platforms which
+// set them, by the name of the platform. They are fixed by the clouds, so the
+// validation needs no credentials: the AWS VPCs support jumbo frames, the
+// Azure virtual networks do not, and 1460 is the default of the GCP networks,
+// which is why an existing GCP network is not checked.
+var hardwareMTUs = map[string]uint32{
+	aws.Name:   9001,
+	azure.Name: 1500,
+	gcp.Name:   1460,
+}
+
+// validateOVNKubernetesConfig validates the OVNKubernetes configuration of
+// the install config: the internal subnets must be of their IP family, and
+// the MTU must fit in the MTU of the machine network of the platform, when it
+// is known, with the Geneve encapsulation. The overlaps of the internal
+// subnets with the other networks are validated with the other overlaps.
+func validateOVNKubernetesConfig(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := c.Networking.OVNKubernetesConfig
+	if config == nil {
+		return allErrs
+	}
+	if c.Networking.NetworkType != string(operv1.NetworkTypeOVNKubernetes) {
+		return append(allErrs, field.Invalid(fldPath, c.Networking.NetworkType, fmt.Sprintf("the OVNKubernetes configuration is only used with the %s network type", operv1.NetworkTypeOVNKubernetes)))
+	}
+
+	if config.V4InternalSubnet != nil {
+		allErrs = append(allErrs, validateInternalSubnet(config.V4InternalSubnet, true, fldPath.Child("v4InternalSubnet"))...)
+	}
+	if config.V6InternalSubnet != nil {
+		allErrs = append(allErrs, validateInternalSubnet(config.V6InternalSubnet, false, fldPath.Child("v6InternalSubnet"))...)
+	}
+
+	if config.MTU != 0 {
+		_, hasIPv6, _, _ := inferIPVersionFromInstallConfig(c.Networking)
+		minimum := uint32(minimumIPv4MTU)
+		if hasIPv6 {
+			minimum = minimumIPv6MTU
+		}
+		if config.MTU < minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mtu"), int64(config.MTU), fmt.Sprintf("must be at least %d", minimum)))
+		} else if hardwareMTU, source := platformHardwareMTU(c); hardwareMTU != 0 && config.MTU+geneveOverhead > hardwareMTU {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mtu"), int64(config.MTU), fmt.Sprintf("must be at most %d, the MTU of %s (%d) minus the %d bytes of the Geneve encapsulation", hardwareMTU-geneveOverhead, source, hardwareMTU, geneveOverhead)))
+		}
+	}
+	return allErrs
+}
+
+// validateInternalSubnet validates that the internal subnet is a valid
+// subnet of its IP family.
+func validateInternalSubnet(subnet *ipnet.IPNet, ipv4 bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if isIPv4 := subnet.IP.To4() != nil; isIPv4 != ipv4 {
+		family := "IPv6"
+		if ipv4 {
+			family = "IPv4"
+		}
+		return append(allErrs, field.Invalid(fldPath, subnet.String(), fmt.Sprintf("must be an %s subnet", family)))
+	}
+	if err := validate.SubnetCIDR(&subnet.IPNet); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, subnet.String(), err.Error()))
+	}
+	return allErrs
+}
+
+// platformHardwareMTU returns the MTU of the machine network of the platform
+// of the install config and what it is the MTU of, or zero when it is not
+// known, e.g. on platforms whose networks are provided by the user.
+func platformHardwareMTU(c *types.InstallConfig) (uint32, string) {
+	platform := c.Platform.Name()
+	switch {
+	case c.Platform.AWS != nil:
+		for _, pool := range c.Compute {
+			if pool.Name == types.MachinePoolEdgeRoleName {
+				return awsEdgeHardwareMTU, "the network of the AWS Local Zones"
+			}
+		}
+	case c.Platform.GCP != nil:
+		if c.Platform.GCP.Network != "" {
+			return 0, ""
+		}
+	}
+	mtu, ok := hardwareMTUs[platform]
+	if !ok {
+		return 0, ""
+	}
+	return mtu, fmt.Sprintf("the %s machine network", platform)
+}
+
+// internalSubnet returns the internal subnet of the IP family of the
+// OVNKubernetes configuration, or nil when it is not set.
+func internalSubnet(config *types.OVNKubernetesConfig, ipv4 bool) *ipnet.IPNet {
+	switch {
+	case config == nil:
+		return nil
+	case ipv4:
+		return config.V4InternalSubnet
+	default:
+		return config.V6InternalSubnet
+	}
+}
+
+// internalSubnetOverlaps returns an error for every range of the model which
+// overlaps with an internal subnet of the OVNKubernetes configuration.
+func (m *networkModel) internalSubnetOverlaps(config *types.OVNKubernetesConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, subnet := range []struct {
+		name   string
+		subnet *ipnet.IPNet
+	}{
+		{name: "v4InternalSubnet", subnet: internalSubnet(config, true)},
+		{name: "v6InternalSubnet", subnet: internalSubnet(config, false)},
+	} {
+		if subnet.subnet == nil {
+			continue
+		}
+		for _, r := range m.ranges {
+			if validate.DoCIDRsOverlap(r.cidr, &subnet.subnet.IPNet) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(subnet.name), subnet.subnet.String(), fmt.Sprintf("the OVN-Kubernetes internal subnet must not overlap with %s: choose an internal subnet outside of the %ss", r, r.kind)))
+			}
+		}
+	}
+	return allErrs
+}
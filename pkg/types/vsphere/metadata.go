@@ -10,4 +10,7 @@ type Metadata struct {
 	Password string `json:"password"`
 	// TerraformPlatform is the type...
 	TerraformPlatform string `json:"terraform_platform"`
+	// ControlPlaneAntiAffinity is the policy keeping the control plane
+	// machines on separate hosts, whose rules are removed on destroy.
+	ControlPlaneAntiAffinity AntiAffinityPolicy `json:"controlPlaneAntiAffinity,omitempty"`
 }
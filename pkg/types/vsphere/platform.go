@@ -2,6 +2,7 @@ package vsphere
 
 import (
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/types/secretref"
 )
 
 // DiskType is a disk provisioning type for vsphere.
@@ -31,6 +32,23 @@ const (
 	TagCategoryZone = "openshift-zone"
 )
 
+// AntiAffinityPolicy is how the control plane virtual machines are kept on
+// separate ESXi hosts.
+// +kubebuilder:validation:Enum="";DRSRule;ComputePolicy
+type AntiAffinityPolicy string
+
+const (
+	// AntiAffinityPolicyDRSRule creates a DRS virtual machine anti-affinity
+	// rule in the compute clusters of the control plane machines. DRS must be
+	// enabled in the compute clusters.
+	AntiAffinityPolicyDRSRule AntiAffinityPolicy = "DRSRule"
+
+	// AntiAffinityPolicyComputePolicy creates a VM-VM anti-affinity compute
+	// policy, applied to the control plane machines through a tag, which
+	// requires vSphere 8.
+	AntiAffinityPolicyComputePolicy AntiAffinityPolicy = "ComputePolicy"
+)
+
 // Platform stores any global configuration used for vsphere platforms.
 type Platform struct {
 	// VCenter is the domain name or IP address of the vCenter.
@@ -63,6 +81,11 @@ type Platform struct {
 	DeprecatedResourcePool string `json:"resourcePool,omitempty"`
 	// ClusterOSImage overrides the url provided in rhcos.json to download the RHCOS OVA
 	ClusterOSImage string `json:"clusterOSImage,omitempty"`
+	// ContentLibrary is the name of an existing content library of the first vCenter, in which the RHCOS
+	// template is imported. A template already imported in the library from an OVA with the same sha256
+	// checksum is reused instead of uploading the OVA again.
+	// +optional
+	ContentLibrary string `json:"contentLibrary,omitempty"`
 
 	// DeprecatedAPIVIP is the virtual IP address for the api endpoint
 	// Deprecated: Use APIVIPs
@@ -121,6 +144,14 @@ type Platform struct {
 	// +kubebuilder:validation:Optional
 	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
 
+	// ControlPlaneAntiAffinity keeps the control plane machines on separate
+	// ESXi hosts, with a DRS rule or with a compute policy. The compute
+	// clusters of the control plane machines must have as many hosts as they
+	// hold control plane machines. The rules are removed when the cluster is
+	// destroyed.
+	// +optional
+	ControlPlaneAntiAffinity AntiAffinityPolicy `json:"controlPlaneAntiAffinity,omitempty"`
+
 	// LoadBalancer defines how the load balancer used by the cluster is configured.
 	// LoadBalancer is available in TechPreview.
 	// +optional
@@ -217,6 +248,10 @@ type VCenter struct {
 	// Password is the password for the user to use to connect to the vCenter.
 	// +kubebuilder:validation:Required
 	Password string `json:"password"`
+	// PasswordRef references the password stored outside of the install
+	// config, instead of setting Password.
+	// +optional
+	PasswordRef *secretref.Reference `json:"passwordRef,omitempty"`
 	// Datacenter in which VMs are located.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
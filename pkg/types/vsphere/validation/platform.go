@@ -45,6 +45,13 @@ func ValidatePlatform(p *vsphere.Platform, agentBasedInstallation bool, fldPath
 		allErrs = append(allErrs, validateFailureDomains(p, fldPath.Child("failureDomains"), isLegacyUpi)...)
 	}
 
+	switch p.ControlPlaneAntiAffinity {
+	case "", vsphere.AntiAffinityPolicyDRSRule, vsphere.AntiAffinityPolicyComputePolicy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("controlPlaneAntiAffinity"), p.ControlPlaneAntiAffinity,
+			[]string{string(vsphere.AntiAffinityPolicyDRSRule), string(vsphere.AntiAffinityPolicyComputePolicy)}))
+	}
+
 	// Platform fields only allowed in TechPreviewNoUpgrade
 	if c.FeatureSet != configv1.TechPreviewNoUpgrade {
 		if c.VSphere.LoadBalancer != nil {
@@ -86,6 +86,23 @@ func TestValidatePlatform(t *testing.T) {
 			name:     "Valid Multi-zone platform",
 			platform: validPlatform(),
 		},
+		{
+			name: "Valid control plane anti-affinity",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.ControlPlaneAntiAffinity = vsphere.AntiAffinityPolicyComputePolicy
+				return p
+			}(),
+		},
+		{
+			name: "Invalid control plane anti-affinity",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.ControlPlaneAntiAffinity = "HostGroup"
+				return p
+			}(),
+			expectedError: `^test-path\.controlPlaneAntiAffinity: Unsupported value: "HostGroup": supported values: "DRSRule", "ComputePolicy"$`,
+		},
 		{
 			name: "Multi-zone platform missing failureDomains",
 			platform: func() *vsphere.Platform {